@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ChiaYuChang/prism/cmd/api-server/dashboard"
 	app "github.com/ChiaYuChang/prism/internal/appconfig"
 	"github.com/ChiaYuChang/prism/internal/http/api"
 	"github.com/ChiaYuChang/prism/internal/obs"
@@ -22,18 +23,44 @@ type CacheConfig struct {
 	TerminalTTL time.Duration `mapstructure:"terminal-ttl" validate:"min=0"`
 }
 
-// RateLimitConfig toggles and tunes the per-IP rate limit on GET /fetches/{id}.
+// RateLimitConfig toggles and tunes the rate limit on public routes. RPS and
+// Burst are the default budget per API key (per client IP for anonymous
+// callers); keys may carry their own.
 type RateLimitConfig struct {
-	Enabled     bool    `mapstructure:"enabled"`
-	RPS         float64 `mapstructure:"rps"            validate:"min=0"`
-	Burst       int     `mapstructure:"burst"          validate:"min=0"`
-	IPCacheSize int     `mapstructure:"ip-cache-size"  validate:"min=0"`
+	Enabled      bool    `mapstructure:"enabled"`
+	RPS          float64 `mapstructure:"rps"             validate:"min=0"`
+	Burst        int     `mapstructure:"burst"           validate:"min=0"`
+	KeyCacheSize int     `mapstructure:"key-cache-size"  validate:"min=0"`
+}
+
+// StreamConfig toggles the SSE endpoints and their Postgres LISTEN
+// connection.
+type StreamConfig struct {
+	Enabled   bool          `mapstructure:"enabled"`
+	Settle    time.Duration `mapstructure:"settle"        validate:"min=0"`
+	Poll      time.Duration `mapstructure:"poll-interval" validate:"min=0"`
+	Heartbeat time.Duration `mapstructure:"heartbeat"     validate:"min=0"`
+}
+
+// DashboardConfig toggles the embedded analyst dashboard under /dashboard/.
+type DashboardConfig struct {
+	Enabled    bool   `mapstructure:"enabled"`
+	ArchiveURL string `mapstructure:"archive-url"`
 }
 
 // AuthConfig groups API authentication methods. JWT can be added alongside
 // token auth without changing middleware wiring.
 type AuthConfig struct {
-	Token TokenAuthConfig `mapstructure:"token"`
+	Token   TokenAuthConfig  `mapstructure:"token"`
+	APIKeys APIKeyAuthConfig `mapstructure:"api-keys"`
+}
+
+// APIKeyAuthConfig enables per-user API keys (users/api_keys tables). When
+// enabled, static tokens keep working as admin keys so operators can
+// bootstrap the first users.
+type APIKeyAuthConfig struct {
+	Enabled  bool          `mapstructure:"enabled"`
+	CacheTTL time.Duration `mapstructure:"cache-ttl" validate:"min=0"`
 }
 
 // TokenAuthConfig configures X-PRISM-TOKEN allow-list authentication.
@@ -99,7 +126,9 @@ type Config struct {
 	Valkey          app.ValkeyConfig    `mapstructure:"valkey"`
 	Cache           CacheConfig         `mapstructure:"cache"`
 	RateLimit       RateLimitConfig     `mapstructure:"rate-limit"`
+	Stream          StreamConfig        `mapstructure:"stream"`
 	Auth            AuthConfig          `mapstructure:"auth"`
+	Dashboard       DashboardConfig     `mapstructure:"dashboard"`
 	Monitoring      MonitoringConfig    `mapstructure:"monitoring"`
 }
 
@@ -179,13 +208,23 @@ func LoadConfig(args []string) (*Config, error) {
 	fs.Duration("cache-live-ttl", 2*time.Second, "Progress cache TTL for non-terminal responses")
 	fs.Duration("cache-terminal-ttl", 60*time.Second, "Progress cache TTL for terminal responses")
 
-	fs.Bool("rate-limit-enabled", false, "Enable per-IP rate limit on GET /fetches/{id}")
-	fs.Float64("rate-limit-rps", 5, "Per-IP requests-per-second budget")
-	fs.Int("rate-limit-burst", 10, "Per-IP burst capacity")
-	fs.Int("rate-limit-ip-cache-size", 4096, "Max distinct IPs tracked by the rate limiter (LRU)")
+	fs.Bool("rate-limit-enabled", false, "Enable per-API-key rate limit on public routes")
+	fs.Float64("rate-limit-rps", 5, "Default per-key requests-per-second budget")
+	fs.Int("rate-limit-burst", 10, "Default per-key burst capacity")
+	fs.Int("rate-limit-key-cache-size", 4096, "Max distinct keys/IPs tracked by the rate limiter (LRU)")
+
+	fs.Bool("stream-enabled", true, "Enable SSE endpoints GET /fetches/{id}/events and GET /candidates/stream")
+	fs.Duration("stream-settle", 2*time.Second, "Delay before streaming newly discovered candidates")
+	fs.Duration("stream-poll-interval", 30*time.Second, "Fallback re-read interval for SSE streams without a change notification")
+	fs.Duration("stream-heartbeat", 15*time.Second, "Keep-alive comment interval for SSE streams")
 
 	fs.StringSlice("auth-token", []string{}, "Allowed X-PRISM-TOKEN values (comma-separated or repeated)")
 	fs.String("auth-token-file", "", "Path to allowed X-PRISM-TOKEN file (one token per line)")
+	fs.Bool("auth-api-keys-enabled", false, "Authenticate with per-user API keys from the database (static tokens become admin keys)")
+	fs.Duration("auth-api-keys-cache-ttl", 30*time.Second, "How long resolved API keys are cached; also the revocation delay")
+
+	fs.Bool("dashboard-enabled", true, "Serve the analyst dashboard under /dashboard/")
+	fs.String("dashboard-archive-url", dashboard.DefaultArchiveURL, "Archive link of the dashboard content reader ({url} and {trace_id} are substituted)")
 
 	fs.String("monitoring-mode", "pull", "Monitoring mode: pull or push")
 	fs.String("monitoring-backend", "memory", "Monitoring status backend: memory or valkey")
@@ -225,9 +264,15 @@ func LoadConfig(args []string) (*Config, error) {
 	if err := bindRateLimitFlags(v, fs); err != nil {
 		return nil, err
 	}
+	if err := bindStreamFlags(v, fs); err != nil {
+		return nil, err
+	}
 	if err := bindAuthFlags(v, fs); err != nil {
 		return nil, err
 	}
+	if err := bindDashboardFlags(v, fs); err != nil {
+		return nil, err
+	}
 	if err := bindMonitoringFlags(v, fs); err != nil {
 		return nil, err
 	}
@@ -303,10 +348,24 @@ func bindCacheFlags(v *viper.Viper, fs *pflag.FlagSet) error {
 
 func bindRateLimitFlags(v *viper.Viper, fs *pflag.FlagSet) error {
 	for flag, key := range map[string]string{
-		"rate-limit-enabled":       "rate-limit.enabled",
-		"rate-limit-rps":           "rate-limit.rps",
-		"rate-limit-burst":         "rate-limit.burst",
-		"rate-limit-ip-cache-size": "rate-limit.ip-cache-size",
+		"rate-limit-enabled":        "rate-limit.enabled",
+		"rate-limit-rps":            "rate-limit.rps",
+		"rate-limit-burst":          "rate-limit.burst",
+		"rate-limit-key-cache-size": "rate-limit.key-cache-size",
+	} {
+		if err := v.BindPFlag(key, fs.Lookup(flag)); err != nil {
+			return fmt.Errorf("bind %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+func bindStreamFlags(v *viper.Viper, fs *pflag.FlagSet) error {
+	for flag, key := range map[string]string{
+		"stream-enabled":       "stream.enabled",
+		"stream-settle":        "stream.settle",
+		"stream-poll-interval": "stream.poll-interval",
+		"stream-heartbeat":     "stream.heartbeat",
 	} {
 		if err := v.BindPFlag(key, fs.Lookup(flag)); err != nil {
 			return fmt.Errorf("bind %s: %w", key, err)
@@ -317,8 +376,22 @@ func bindRateLimitFlags(v *viper.Viper, fs *pflag.FlagSet) error {
 
 func bindAuthFlags(v *viper.Viper, fs *pflag.FlagSet) error {
 	for flag, key := range map[string]string{
-		"auth-token":      "auth.token.tokens",
-		"auth-token-file": "auth.token.file",
+		"auth-token":              "auth.token.tokens",
+		"auth-token-file":         "auth.token.file",
+		"auth-api-keys-enabled":   "auth.api-keys.enabled",
+		"auth-api-keys-cache-ttl": "auth.api-keys.cache-ttl",
+	} {
+		if err := v.BindPFlag(key, fs.Lookup(flag)); err != nil {
+			return fmt.Errorf("bind %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+func bindDashboardFlags(v *viper.Viper, fs *pflag.FlagSet) error {
+	for flag, key := range map[string]string{
+		"dashboard-enabled":     "dashboard.enabled",
+		"dashboard-archive-url": "dashboard.archive-url",
 	} {
 		if err := v.BindPFlag(key, fs.Lookup(flag)); err != nil {
 			return fmt.Errorf("bind %s: %w", key, err)
@@ -22,6 +22,25 @@ func TestLoadConfig_Defaults(t *testing.T) {
 	assert.Equal(t, "localhost", cfg.Postgres.Host)
 	assert.Equal(t, 5432, cfg.Postgres.Port)
 	assert.Equal(t, "info", cfg.Logger.Level)
+	assert.True(t, cfg.Stream.Enabled)
+	assert.Equal(t, 2*time.Second, cfg.Stream.Settle)
+	assert.Equal(t, 30*time.Second, cfg.Stream.Poll)
+	assert.Equal(t, 15*time.Second, cfg.Stream.Heartbeat)
+	assert.Equal(t, 4096, cfg.RateLimit.KeyCacheSize)
+	assert.False(t, cfg.Auth.APIKeys.Enabled)
+	assert.Equal(t, 30*time.Second, cfg.Auth.APIKeys.CacheTTL)
+	assert.True(t, cfg.Dashboard.Enabled)
+	assert.Equal(t, "https://web.archive.org/web/{url}", cfg.Dashboard.ArchiveURL)
+}
+
+func TestLoadConfig_Dashboard(t *testing.T) {
+	cfg, err := LoadConfig([]string{
+		"--dashboard-enabled=false",
+		"--dashboard-archive-url=https://archive.internal/{trace_id}",
+	})
+	require.NoError(t, err)
+	assert.False(t, cfg.Dashboard.Enabled)
+	assert.Equal(t, "https://archive.internal/{trace_id}", cfg.Dashboard.ArchiveURL)
 }
 
 func TestLoadConfig_ShippedConfig(t *testing.T) {
@@ -35,7 +54,12 @@ func TestLoadConfig_ShippedConfig(t *testing.T) {
 	assert.Equal(t, "valkey", cfg.Valkey.Host)
 	assert.True(t, cfg.Cache.Enabled)
 	assert.True(t, cfg.RateLimit.Enabled)
+	assert.True(t, cfg.Stream.Enabled)
+	assert.Equal(t, 4096, cfg.RateLimit.KeyCacheSize)
+	assert.False(t, cfg.Auth.APIKeys.Enabled)
+	assert.Equal(t, 30*time.Second, cfg.Auth.APIKeys.CacheTTL)
 	assert.Equal(t, "prism.api", cfg.Telemetry.ServiceName)
+	assert.True(t, cfg.Dashboard.Enabled)
 
 	assert.Equal(t, "pull", cfg.Monitoring.Mode)
 	assert.Equal(t, 10*time.Second, cfg.Monitoring.Interval)
@@ -197,6 +221,19 @@ func TestLoadConfig_AuthTokenConfigFile(t *testing.T) {
 	assert.Len(t, tokens, 2)
 }
 
+func TestLoadConfig_APIKeyFlags(t *testing.T) {
+	cfg, err := LoadConfig([]string{
+		"--auth-api-keys-enabled",
+		"--auth-api-keys-cache-ttl=5s",
+		"--rate-limit-key-cache-size=128",
+	})
+	require.NoError(t, err)
+
+	assert.True(t, cfg.Auth.APIKeys.Enabled)
+	assert.Equal(t, 5*time.Second, cfg.Auth.APIKeys.CacheTTL)
+	assert.Equal(t, 128, cfg.RateLimit.KeyCacheSize)
+}
+
 func TestTokenAuthConfig_TokenSetNotConfigured(t *testing.T) {
 	tokens, err := TokenAuthConfig{}.TokenSet()
 	require.NoError(t, err)
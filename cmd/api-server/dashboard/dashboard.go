@@ -0,0 +1,154 @@
+// Package dashboard serves the analyst web UI under /dashboard/.
+//
+// Pages are server-rendered shells: they carry navigation, the filter form
+// and the ids from the URL, never API data. The embedded script fills them
+// in by calling the public /api/v1 JSON endpoints with the X-PRISM-TOKEN the
+// analyst enters once (kept in the browser's localStorage), so the dashboard
+// sees exactly what any other API client sees and needs no auth of its own.
+package dashboard
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+//go:embed templates/*.html
+var templateFS embed.FS
+
+//go:embed static
+var staticFS embed.FS
+
+// DefaultArchiveURL links the content reader to the Wayback Machine.
+const DefaultArchiveURL = "https://web.archive.org/web/{url}"
+
+// contentSecurityPolicy confines the pages to their own script, styles and
+// API; data is only ever inserted as text, so inline script is never needed.
+const contentSecurityPolicy = "default-src 'self'; script-src 'self'; style-src 'self'; " +
+	"img-src 'self' data:; connect-src 'self'; frame-ancestors 'none'; base-uri 'none'; form-action 'self'"
+
+// Options configures the dashboard.
+type Options struct {
+	// ArchiveURL is the "archive" link of the content reader. {url} is
+	// replaced by the article URL and {trace_id} by its trace id, so it can
+	// point at a public archive or at an internal archive browser.
+	ArchiveURL string
+}
+
+// Dashboard renders the dashboard pages.
+type Dashboard struct {
+	logger     *slog.Logger
+	archiveURL string
+	pages      map[string]*template.Template
+}
+
+// page is the data every template receives.
+type page struct {
+	Title      string
+	Nav        string
+	ArchiveURL string
+
+	// Candidate browser filters, echoed from the query string.
+	Q, SourceAbbr, Since, Until string
+
+	FetchID     string
+	CandidateID string
+}
+
+// New parses the embedded templates.
+func New(logger *slog.Logger, opts Options) (*Dashboard, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("dashboard: logger is required")
+	}
+	if opts.ArchiveURL == "" {
+		opts.ArchiveURL = DefaultArchiveURL
+	}
+	u, err := url.Parse(strings.NewReplacer("{url}", "", "{trace_id}", "").Replace(opts.ArchiveURL))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("dashboard: archive url %q must be an absolute http(s) URL", opts.ArchiveURL)
+	}
+
+	d := &Dashboard{logger: logger, archiveURL: opts.ArchiveURL, pages: map[string]*template.Template{}}
+	for _, name := range []string{"candidates", "fetch", "content", "status"} {
+		t, err := template.ParseFS(templateFS, "templates/layout.html", "templates/"+name+".html")
+		if err != nil {
+			return nil, fmt.Errorf("dashboard: parse %s template: %w", name, err)
+		}
+		d.pages[name] = t
+	}
+	return d, nil
+}
+
+// Register wires the dashboard onto mux under /dashboard/. The routes are
+// deliberately outside the API auth middleware: they serve no data.
+func (d *Dashboard) Register(mux *http.ServeMux) {
+	static, _ := fs.Sub(staticFS, "static")
+	mux.Handle("GET /dashboard/static/", http.StripPrefix("/dashboard/static/", http.FileServerFS(static)))
+	mux.Handle("GET /dashboard", http.RedirectHandler("/dashboard/", http.StatusMovedPermanently))
+	mux.HandleFunc("GET /dashboard/{$}", d.candidates)
+	mux.HandleFunc("GET /dashboard/fetches", d.fetch)
+	mux.HandleFunc("GET /dashboard/contents/{candidate_id}", d.content)
+	mux.HandleFunc("GET /dashboard/status", d.status)
+}
+
+func (d *Dashboard) candidates(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	d.render(w, "candidates", page{
+		Title:      "Candidates",
+		Q:          q.Get("q"),
+		SourceAbbr: q.Get("source_abbr"),
+		Since:      q.Get("since"),
+		Until:      q.Get("until"),
+	})
+}
+
+func (d *Dashboard) fetch(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	if id != "" {
+		if _, err := uuid.Parse(id); err != nil {
+			http.Error(w, "invalid fetch id", http.StatusBadRequest)
+			return
+		}
+	}
+	d.render(w, "fetch", page{Title: "Fetch progress", FetchID: id})
+}
+
+func (d *Dashboard) content(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("candidate_id"))
+	if err != nil {
+		http.Error(w, "invalid candidate id", http.StatusBadRequest)
+		return
+	}
+	d.render(w, "content", page{Title: "Content", CandidateID: id.String(), ArchiveURL: d.archiveURL})
+}
+
+func (d *Dashboard) status(w http.ResponseWriter, r *http.Request) {
+	d.render(w, "status", page{Title: "Service status"})
+}
+
+// render executes into a buffer first so a template error becomes a clean
+// 500 instead of a truncated page.
+func (d *Dashboard) render(w http.ResponseWriter, name string, p page) {
+	p.Nav = name
+	var buf bytes.Buffer
+	if err := d.pages[name].ExecuteTemplate(&buf, "layout", p); err != nil {
+		d.logger.Error("render dashboard page failed", slog.String("page", name), slog.Any("error", err))
+		http.Error(w, "failed to render page", http.StatusInternalServerError)
+		return
+	}
+	h := w.Header()
+	h.Set("Content-Type", "text/html; charset=utf-8")
+	h.Set("Content-Security-Policy", contentSecurityPolicy)
+	h.Set("X-Content-Type-Options", "nosniff")
+	h.Set("Referrer-Policy", "no-referrer")
+	h.Set("Cache-Control", "no-store")
+	_, _ = w.Write(buf.Bytes())
+}
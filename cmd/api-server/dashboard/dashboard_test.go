@@ -0,0 +1,123 @@
+package dashboard
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMux(t *testing.T, opts Options) *http.ServeMux {
+	t.Helper()
+	d, err := New(slog.New(slog.DiscardHandler), opts)
+	require.NoError(t, err)
+	mux := http.NewServeMux()
+	d.Register(mux)
+	return mux
+}
+
+func get(t *testing.T, mux *http.ServeMux, target string) (*httptest.ResponseRecorder, string) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+	return rec, string(body)
+}
+
+func TestPages(t *testing.T) {
+	mux := newTestMux(t, Options{})
+	candidateID := uuid.NewString()
+
+	for _, tc := range []struct {
+		target string
+		want   []string
+	}{
+		{"/dashboard/", []string{`data-page="candidates"`, `id="candidates"`, `aria-current="page">Candidates`}},
+		{"/dashboard/fetches", []string{`data-page="fetch"`, `name="id" value=""`}},
+		{"/dashboard/status", []string{`data-page="status"`, `id="status"`}},
+		{"/dashboard/contents/" + candidateID, []string{
+			`data-candidate-id="` + candidateID + `"`,
+			`data-archive-template="https://web.archive.org/web/{url}"`,
+		}},
+	} {
+		t.Run(tc.target, func(t *testing.T) {
+			rec, body := get(t, mux, tc.target)
+			require.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, "text/html; charset=utf-8", rec.Header().Get("Content-Type"))
+			assert.Contains(t, rec.Header().Get("Content-Security-Policy"), "script-src 'self'")
+			assert.Contains(t, body, `<script src="/dashboard/static/dashboard.js" defer></script>`)
+			for _, want := range tc.want {
+				assert.Contains(t, body, want)
+			}
+		})
+	}
+}
+
+func TestCandidatesEchoesFiltersEscaped(t *testing.T) {
+	mux := newTestMux(t, Options{})
+
+	_, body := get(t, mux, `/dashboard/?q=%22%3E%3Cscript%3Ealert(1)%3C%2Fscript%3E&source_abbr=dpp&since=2026-05-01`)
+	assert.NotContains(t, body, "<script>alert(1)</script>")
+	assert.Contains(t, body, `value="&#34;&gt;&lt;script&gt;alert(1)&lt;/script&gt;"`)
+	assert.Contains(t, body, `name="source_abbr" value="dpp"`)
+	assert.Contains(t, body, `name="since" value="2026-05-01"`)
+}
+
+func TestFetchPage(t *testing.T) {
+	mux := newTestMux(t, Options{})
+	id := uuid.NewString()
+
+	_, body := get(t, mux, "/dashboard/fetches?id="+id)
+	assert.Contains(t, body, `data-fetch-id="`+id+`"`)
+
+	rec, _ := get(t, mux, "/dashboard/fetches?id=nope")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestContentRejectsInvalidID(t *testing.T) {
+	mux := newTestMux(t, Options{})
+
+	rec, _ := get(t, mux, "/dashboard/contents/not-a-uuid")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestArchiveURL(t *testing.T) {
+	mux := newTestMux(t, Options{ArchiveURL: "https://archive.internal/browse?trace={trace_id}"})
+	_, body := get(t, mux, "/dashboard/contents/"+uuid.NewString())
+	assert.Contains(t, body, `data-archive-template="https://archive.internal/browse?trace={trace_id}"`)
+
+	for _, bad := range []string{"javascript:alert(1)//{url}", "/relative/{url}", "{url}"} {
+		_, err := New(slog.New(slog.DiscardHandler), Options{ArchiveURL: bad})
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestStaticAssets(t *testing.T) {
+	mux := newTestMux(t, Options{})
+
+	rec, body := get(t, mux, "/dashboard/static/dashboard.js")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "javascript")
+	assert.Contains(t, body, `"/api/v1"`)
+
+	rec, _ = get(t, mux, "/dashboard/static/dashboard.css")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/css")
+
+	rec, _ = get(t, mux, "/dashboard/static/missing.js")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestRedirectsBarePath(t *testing.T) {
+	mux := newTestMux(t, Options{})
+
+	rec, _ := get(t, mux, "/dashboard")
+	assert.Equal(t, http.StatusMovedPermanently, rec.Code)
+	assert.Equal(t, "/dashboard/", rec.Header().Get("Location"))
+}
@@ -0,0 +1,345 @@
+// Prism dashboard: fills the server-rendered pages from /api/v1.
+//
+// Every API value is inserted with textContent or as an attribute, never as
+// HTML, so article bodies and titles cannot inject markup.
+(function () {
+  "use strict";
+
+  const TOKEN_KEY = "prism.token";
+  const FETCH_POLL_MS = 5000;
+  const STATUS_POLL_MS = 15000;
+  const CONTENT_RETRY_MAX_MS = 30000;
+  const PAGE_SIZE = 50;
+
+  // ---- API -------------------------------------------------------------
+
+  class APIError extends Error {
+    constructor(status, message) {
+      super(status + ": " + message);
+      this.status = status;
+    }
+  }
+
+  async function api(method, path, body) {
+    const headers = { Accept: "application/json" };
+    const token = localStorage.getItem(TOKEN_KEY);
+    if (token) headers["X-PRISM-TOKEN"] = token;
+    if (body !== undefined) headers["Content-Type"] = "application/json";
+    const resp = await fetch("/api/v1" + path, {
+      method,
+      headers,
+      body: body === undefined ? undefined : JSON.stringify(body),
+    });
+    if (!resp.ok) {
+      let message = resp.statusText;
+      try {
+        const data = await resp.json();
+        if (data && data.error) message = data.error;
+      } catch (_) {
+        // Non-JSON error body: keep the status text.
+      }
+      throw new APIError(resp.status, message);
+    }
+    return resp.json();
+  }
+
+  // ---- helpers ---------------------------------------------------------
+
+  function $(sel, root) {
+    return (root || document).querySelector(sel);
+  }
+
+  function el(tag, text, attrs) {
+    const node = document.createElement(tag);
+    if (text !== undefined && text !== null) node.textContent = String(text);
+    for (const [k, v] of Object.entries(attrs || {})) node.setAttribute(k, v);
+    return node;
+  }
+
+  function row(cells) {
+    const tr = document.createElement("tr");
+    for (const cell of cells) {
+      const td = document.createElement("td");
+      if (cell instanceof Node) td.appendChild(cell);
+      else td.textContent = cell === undefined || cell === null ? "" : String(cell);
+      tr.appendChild(td);
+    }
+    return tr;
+  }
+
+  function showError(err) {
+    const box = $("#error");
+    if (!err) {
+      box.hidden = true;
+      return;
+    }
+    let msg = err.message || String(err);
+    if (err.status === 401) msg += " — enter an API token above.";
+    if (err.status === 403) msg += " — the token lacks the required scope.";
+    box.textContent = msg;
+    box.hidden = false;
+  }
+
+  function formatDate(s) {
+    return s ? s.slice(0, 10) : "";
+  }
+
+  function safeHref(u) {
+    return /^https?:\/\//i.test(u || "") ? u : null;
+  }
+
+  // ---- token -----------------------------------------------------------
+
+  function initToken() {
+    const form = $("#token-form");
+    const input = form.elements.token;
+    if (localStorage.getItem(TOKEN_KEY)) input.placeholder = "token saved";
+    form.addEventListener("submit", (ev) => {
+      ev.preventDefault();
+      const v = input.value.trim();
+      if (v) localStorage.setItem(TOKEN_KEY, v);
+      location.reload();
+    });
+    $("#token-clear").addEventListener("click", () => {
+      localStorage.removeItem(TOKEN_KEY);
+      location.reload();
+    });
+  }
+
+  // ---- candidates ------------------------------------------------------
+
+  function initCandidates() {
+    const params = new URLSearchParams(location.search);
+    const filter = new URLSearchParams();
+    if (params.get("q")) filter.set("q", params.get("q"));
+    if (params.get("source_abbr")) filter.set("source_abbr", params.get("source_abbr"));
+    // Date inputs give calendar days; the API wants RFC3339 bounds.
+    if (params.get("since")) filter.set("since", params.get("since") + "T00:00:00Z");
+    if (params.get("until")) filter.set("until", params.get("until") + "T23:59:59Z");
+    filter.set("limit", String(PAGE_SIZE));
+
+    const tbody = $("#candidates tbody");
+    const selected = new Map(); // id -> title, kept across pages
+    const cursors = [""];
+    let next = "";
+    let rows = [];
+
+    function updateSelection() {
+      $("#selected-count").textContent = String(selected.size);
+      $("#submit-fetch").disabled = selected.size === 0 || selected.size > 100;
+    }
+
+    function render() {
+      tbody.replaceChildren();
+      for (const c of rows) {
+        const box = el("input", null, { type: "checkbox" });
+        box.checked = selected.has(c.id);
+        box.addEventListener("change", () => {
+          if (box.checked) selected.set(c.id, c.title);
+          else selected.delete(c.id);
+          updateSelection();
+        });
+        const title = el("a", c.title, { href: safeHref(c.url) || "#", rel: "noopener noreferrer", target: "_blank" });
+        const read = el("a", "Read", { href: "/dashboard/contents/" + encodeURIComponent(c.id) });
+        tbody.appendChild(row([box, formatDate(c.published_at || c.discovered_at), c.source_abbr, title, read]));
+      }
+      $(".empty").hidden = rows.length > 0;
+      $("#prev-page").disabled = cursors.length < 2;
+      $("#next-page").disabled = !next;
+      $("#select-page").checked = rows.length > 0 && rows.every((c) => selected.has(c.id));
+    }
+
+    async function load(stack) {
+      const q = new URLSearchParams(filter);
+      const cursor = stack[stack.length - 1];
+      if (cursor) q.set("cursor", cursor);
+      try {
+        const resp = await api("GET", "/candidates?" + q.toString());
+        cursors.splice(0, cursors.length, ...stack);
+        rows = resp.items || [];
+        next = resp.next_cursor || "";
+        showError(null);
+        render();
+      } catch (err) {
+        showError(err);
+      }
+    }
+
+    $("#next-page").addEventListener("click", () => next && load(cursors.concat(next)));
+    $("#prev-page").addEventListener("click", () => cursors.length > 1 && load(cursors.slice(0, -1)));
+    $("#select-page").addEventListener("change", (ev) => {
+      for (const c of rows) {
+        if (ev.target.checked) selected.set(c.id, c.title);
+        else selected.delete(c.id);
+      }
+      updateSelection();
+      render();
+    });
+
+    $("#submit-fetch").addEventListener("click", async () => {
+      const ids = Array.from(selected.keys());
+      const titles = new Map(selected);
+      $("#submit-fetch").disabled = true;
+      try {
+        const resp = await api("POST", "/page_fetch", { candidate_ids: ids });
+        selected.clear();
+        updateSelection();
+        render();
+        showSubmitted(resp, titles);
+        showError(null);
+      } catch (err) {
+        showError(err);
+        updateSelection();
+      }
+    });
+
+    function showSubmitted(resp, titles) {
+      const section = $("#submitted");
+      const link = $("#submitted-link");
+      link.textContent = resp.fetch_id;
+      link.href = "/dashboard/fetches?id=" + encodeURIComponent(resp.fetch_id);
+      const body = $("tbody", section);
+      body.replaceChildren();
+      for (const item of resp.items || []) {
+        body.appendChild(row([el("span", item.status, { class: "status-" + item.status }),
+          titles.get(item.candidate_id) || item.candidate_id]));
+      }
+      section.hidden = false;
+    }
+
+    updateSelection();
+    load(cursors);
+  }
+
+  // ---- fetch progress --------------------------------------------------
+
+  function initFetch() {
+    const section = $("#fetch");
+    if (!section) return;
+    const id = section.dataset.fetchId;
+    const tbody = $("tbody", section);
+
+    async function poll() {
+      let resp;
+      try {
+        resp = await api("GET", "/fetches/" + encodeURIComponent(id));
+        showError(null);
+      } catch (err) {
+        showError(err);
+        // Client errors (unknown fetch, bad token) do not heal by retrying.
+        if (!(err instanceof APIError) || err.status >= 500) setTimeout(poll, FETCH_POLL_MS);
+        return;
+      }
+      const done = resp.completed.count + resp.failed.count + resp.already_complete.count;
+      const bar = $("#fetch-progress");
+      bar.max = Math.max(resp.total, 1);
+      bar.value = resp.total ? done : 1;
+      tbody.replaceChildren();
+      for (const name of ["pending", "running", "completed", "failed", "already_complete"]) {
+        const links = el("span");
+        for (const cid of resp[name].candidate_ids || []) {
+          links.appendChild(el("a", cid.slice(0, 8), { href: "/dashboard/contents/" + encodeURIComponent(cid) }));
+          links.appendChild(document.createTextNode(" "));
+        }
+        tbody.appendChild(row([el("span", name, { class: "status-" + name }), resp[name].count, links]));
+      }
+      const at = new Date().toLocaleTimeString();
+      if (resp.terminal) {
+        $("#fetch-state").textContent = "Done (" + done + " of " + resp.total + ") · " + at;
+        return;
+      }
+      $("#fetch-state").textContent = done + " of " + resp.total + " finished · refreshed " + at;
+      setTimeout(poll, FETCH_POLL_MS);
+    }
+    poll();
+  }
+
+  // ---- content reader --------------------------------------------------
+
+  function initContent() {
+    const article = $("#content");
+    const id = article.dataset.candidateId;
+    const state = $("#content-state");
+    let delay = 1000;
+
+    async function load() {
+      let c;
+      try {
+        c = await api("GET", "/contents/" + encodeURIComponent(id));
+      } catch (err) {
+        if (err instanceof APIError && err.status === 404) {
+          // Not fetched yet: wait for the collector, backing off to 30s.
+          state.textContent = "This article has not been fetched yet. Submit it from the candidate list; " +
+            "retrying in " + Math.round(delay / 1000) + "s…";
+          setTimeout(load, delay);
+          delay = Math.min(delay * 2, CONTENT_RETRY_MAX_MS);
+          return;
+        }
+        state.textContent = "";
+        showError(err);
+        return;
+      }
+      showError(null);
+      state.hidden = true;
+      const header = $("header", article);
+      $(".title", header).textContent = c.title;
+      const meta = [c.source_abbr, formatDate(c.published_at)];
+      if (c.author) meta.unshift(c.author);
+      $(".meta", header).textContent = meta.join(" · ");
+      const original = safeHref(c.url);
+      if (original) {
+        $(".original", header).href = original;
+        const archive = article.dataset.archiveTemplate
+          .replace("{url}", () => original)
+          .replace("{trace_id}", () => encodeURIComponent(c.trace_id || ""));
+        $(".archive", header).href = archive;
+      }
+      header.hidden = false;
+      const body = $(".body", article);
+      body.replaceChildren();
+      for (const para of (c.content || "").split(/\n{2,}/)) {
+        if (para.trim()) body.appendChild(el("p", para.trim()));
+      }
+      document.title = c.title + " · Prism";
+    }
+    load();
+  }
+
+  // ---- status ----------------------------------------------------------
+
+  function initStatus() {
+    const tbody = $("#status tbody");
+
+    async function poll() {
+      try {
+        const statuses = await api("GET", "/status");
+        tbody.replaceChildren();
+        for (const name of Object.keys(statuses).sort()) {
+          const s = statuses[name];
+          tbody.appendChild(row([name, el("span", s.level, { class: "level-" + String(s.level).toLowerCase() }),
+            s.message, s.uptime, s.timestamp ? new Date(s.timestamp).toLocaleString() : ""]));
+        }
+        $("#status-updated").textContent = "Updated " + new Date().toLocaleTimeString();
+        showError(null);
+      } catch (err) {
+        showError(err);
+      }
+      setTimeout(poll, STATUS_POLL_MS);
+    }
+    poll();
+  }
+
+  document.addEventListener("DOMContentLoaded", () => {
+    initToken();
+    switch (document.body.dataset.page) {
+      case "candidates":
+        return initCandidates();
+      case "fetch":
+        return initFetch();
+      case "content":
+        return initContent();
+      case "status":
+        return initStatus();
+    }
+  });
+})();
@@ -15,58 +15,66 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/candidates": {
+        "/admin/aliases": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "candidates"
+                    "admin"
                 ],
-                "summary": "List candidate article briefs",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Keyword matched against title/description (ILIKE)",
-                        "name": "q",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by source abbreviation (e.g. dpp, tpp, yahoo)",
-                        "name": "source_abbr",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Lower bound on published_at/discovered_at (RFC3339)",
-                        "name": "since",
-                        "in": "query"
+                "summary": "List search alias groups",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.ListSearchAliasesResponse"
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/aliases/{canonical}": {
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Create or replace a search alias group",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Upper bound on published_at/discovered_at (RFC3339)",
-                        "name": "until",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page size (default 50, max 200)",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Canonical name",
+                        "name": "canonical",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Pagination offset (default 0)",
-                        "name": "offset",
-                        "in": "query"
+                        "description": "Aliases",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.PutSearchAliasesRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.ListCandidatesResponse"
+                            "$ref": "#/definitions/api.SearchAliasGroup"
                         }
                     },
                     "400": {
@@ -82,32 +90,58 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/contents/{candidate_id}": {
-            "get": {
-                "produces": [
-                    "application/json"
-                ],
+            },
+            "delete": {
                 "tags": [
-                    "contents"
+                    "admin"
                 ],
-                "summary": "Get fetched content for a candidate",
+                "summary": "Delete a search alias group",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Candidate UUID",
-                        "name": "candidate_id",
+                        "description": "Canonical name",
+                        "name": "canonical",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/api.Content"
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
                         }
+                    }
+                }
+            }
+        },
+        "/admin/api_keys/{id}": {
+            "delete": {
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Revoke an API key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -130,29 +164,66 @@ const docTemplate = `{
                 }
             }
         },
-        "/fetches/{id}": {
+        "/admin/parsers": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "fetches"
+                    "admin"
                 ],
-                "summary": "Get progress for a user fetch",
+                "summary": "List parser rules",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.ListParserRulesResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/parsers/{host}": {
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Create or replace a host's parser rule",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User fetch ID",
-                        "name": "id",
+                        "description": "Host name, e.g. www.example.com",
+                        "name": "host",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Parser config (parsers.yaml entry)",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.FetchProgressResponse"
+                            "$ref": "#/definitions/api.ParserRule"
                         }
                     },
                     "400": {
@@ -161,6 +232,32 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Delete a host's parser rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Host name",
+                        "name": "host",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -176,27 +273,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/healthz": {
+        "/admin/scouts": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "health"
+                    "admin"
                 ],
-                "summary": "Liveness probe",
+                "summary": "List scout configs",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/obs.HealthStatus"
+                            "$ref": "#/definitions/api.ListScoutConfigsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/page_fetch": {
-            "post": {
+        "/admin/scouts/{name}": {
+            "put": {
                 "consumes": [
                     "application/json"
                 ],
@@ -204,25 +307,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "candidates"
+                    "admin"
                 ],
-                "summary": "Request full-article fetch for candidates",
+                "summary": "Create or replace a scout config",
                 "parameters": [
                     {
-                        "description": "Candidate IDs to promote to contents",
+                        "type": "string",
+                        "description": "Scout name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Scout config",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.PageFetchRequest"
+                            "$ref": "#/definitions/api.PutScoutConfigRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "202": {
-                        "description": "Accepted",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.PageFetchResponse"
+                            "$ref": "#/definitions/api.ScoutConfig"
                         }
                     },
                     "400": {
@@ -238,215 +348,1895 @@ const docTemplate = `{
                         }
                     }
                 }
+            },
+            "delete": {
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Delete a scout config",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Scout name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
             }
         },
-        "/readyz": {
-            "get": {
+        "/admin/sources/{abbr}": {
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "health"
+                    "admin"
+                ],
+                "summary": "Create or replace a source",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Source abbreviation",
+                        "name": "abbr",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Source",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.PutSourceRequest"
+                        }
+                    }
                 ],
-                "summary": "Readiness probe",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/obs.HealthStatus"
+                            "$ref": "#/definitions/api.Source"
                         }
                     },
-                    "503": {
-                        "description": "Service Unavailable",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/obs.HealthStatus"
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "api.Candidate": {
-            "type": "object",
-            "properties": {
-                "batch_id": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "discovered_at": {
-                    "type": "string"
+            },
+            "delete": {
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Delete a source",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Source abbreviation",
+                        "name": "abbr",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/task_runs/yield": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Summarise discovery yield by source, task or search provider",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "source (default), task or provider",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Window start (RFC3339 or YYYY-MM-DD, default until-30d)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Window end, exclusive (RFC3339 or YYYY-MM-DD, default now)",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "DIRECTORY_FETCH or KEYWORD_SEARCH; ignored for provider",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by source abbreviation",
+                        "name": "source_abbr",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max groups (default 100, max 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.TaskRunYieldResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/tasks/{id}/runs": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List the discovery runs of a task",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, newest first (default 50, max 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor from the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.ListTaskRunsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Create an API user",
+                "parameters": [
+                    {
+                        "description": "User to create",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.CreateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/api.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/api_keys": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List a user's API keys",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.ListAPIKeysResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Issue an API key for a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Key to issue",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.CreateAPIKeyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/api.CreateAPIKeyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/candidates": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "candidates"
+                ],
+                "summary": "List candidate article briefs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Keyword matched against title/description (ILIKE)",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by source abbreviation (e.g. dpp, tpp, yahoo)",
+                        "name": "source_abbr",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Lower bound on published_at/discovered_at (RFC3339)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Upper bound on published_at/discovered_at (RFC3339)",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 50, max 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor from the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Deprecated: pagination offset (default 0); use cursor",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.ListCandidatesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/candidates/stream": {
+            "get": {
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "candidates"
+                ],
+                "summary": "Stream newly discovered candidates (Server-Sent Events)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by source abbreviation",
+                        "name": "source_abbr",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by ingestion method (DIRECTORY, SEARCH, SUBSCRIPTION, MANUAL)",
+                        "name": "ingestion_method",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Id of the last event received",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "event: candidate",
+                        "schema": {
+                            "$ref": "#/definitions/api.Candidate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/contents": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contents"
+                ],
+                "summary": "List and search fetched contents",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Full-text query over title/content; CJK text is matched by bigrams",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by source abbreviation (e.g. dpp, tpp, yahoo)",
+                        "name": "source_abbr",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "PARTY_RELEASE",
+                            "GOVERNMENT_RELEASE",
+                            "ARTICLE",
+                            "SOCIAL"
+                        ],
+                        "type": "string",
+                        "description": "Filter by content type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by batch UUID",
+                        "name": "batch_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Lower bound on published_at (RFC3339)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Upper bound on published_at (RFC3339)",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 50, max 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor from the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.ListContentsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/contents/export": {
+            "get": {
+                "produces": [
+                    "application/x-ndjson",
+                    "text/csv",
+                    "application/vnd.apache.parquet"
+                ],
+                "tags": [
+                    "contents"
+                ],
+                "summary": "Export fetched contents as NDJSON, CSV or Parquet",
+                "parameters": [
+                    {
+                        "enum": [
+                            "ndjson",
+                            "csv",
+                            "parquet"
+                        ],
+                        "type": "string",
+                        "description": "Output format (default ndjson)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Full-text query over title/content; CJK text is matched by bigrams",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by source abbreviation (e.g. dpp, tpp, yahoo)",
+                        "name": "source_abbr",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "PARTY_RELEASE",
+                            "GOVERNMENT_RELEASE",
+                            "ARTICLE",
+                            "SOCIAL"
+                        ],
+                        "type": "string",
+                        "description": "Filter by content type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by batch UUID",
+                        "name": "batch_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Lower bound on published_at (RFC3339)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Upper bound on published_at (RFC3339)",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resume after this row (same encoding as next_cursor)",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/contents/{candidate_id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contents"
+                ],
+                "summary": "Get fetched content for a candidate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Candidate UUID",
+                        "name": "candidate_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.Content"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/fetches/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fetches"
+                ],
+                "summary": "Get progress for a user fetch",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User fetch ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.FetchProgressResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/fetches/{id}/events": {
+            "get": {
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "fetches"
+                ],
+                "summary": "Stream progress for a user fetch (Server-Sent Events)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User fetch ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Id of the last event received",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "event: progress",
+                        "schema": {
+                            "$ref": "#/definitions/api.FetchProgressResponse"
+                        }
+                    },
+                    "204": {
+                        "description": "Terminal state already delivered"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/healthz": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/obs.HealthStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/llm/spend": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "llm"
+                ],
+                "summary": "Summarise LLM spend by day and component",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Window start (RFC3339 or YYYY-MM-DD, default until-30d)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Window end, exclusive (RFC3339 or YYYY-MM-DD, default now)",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by calling component (e.g. planner, collector)",
+                        "name": "component",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.LLMSpendResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/page_fetch": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "candidates"
+                ],
+                "summary": "Request full-article fetch for candidates",
+                "parameters": [
+                    {
+                        "description": "Candidate IDs to promote to contents",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.PageFetchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.PageFetchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/page_fetch/query": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "candidates"
+                ],
+                "summary": "Request full-article fetch for candidates matching a query",
+                "parameters": [
+                    {
+                        "description": "Candidate filters, cap and dry-run flag",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.PageFetchQueryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Dry run or no match",
+                        "schema": {
+                            "$ref": "#/definitions/api.PageFetchQueryResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.PageFetchQueryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/obs.HealthStatus"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/obs.HealthStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/sources": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sources"
+                ],
+                "summary": "List sources",
+                "parameters": [
+                    {
+                        "enum": [
+                            "PARTY",
+                            "GOVERNMENT",
+                            "MEDIA"
+                        ],
+                        "type": "string",
+                        "description": "Only this source type",
+                        "name": "type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.ListSourcesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "api.APIKey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "rate_limit_burst": {
+                    "type": "integer"
+                },
+                "rate_limit_rps": {
+                    "type": "number"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.Candidate": {
+            "type": "object",
+            "properties": {
+                "batch_id": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "discovered_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ingestion_method": {
+                    "type": "string"
+                },
+                "published_at": {
+                    "type": "string"
+                },
+                "source_abbr": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "trace_id": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.Content": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "batch_id": {
+                    "type": "string"
+                },
+                "candidate_id": {
+                    "type": "string"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "fetched_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "published_at": {
+                    "type": "string"
+                },
+                "source_abbr": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "trace_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.CreateAPIKeyRequest": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rate_limit_burst": {
+                    "type": "integer"
+                },
+                "rate_limit_rps": {
+                    "type": "number"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "api.CreateAPIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "rate_limit_burst": {
+                    "type": "integer"
+                },
+                "rate_limit_rps": {
+                    "type": "number"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.CreateUserRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.FetchProgressResponse": {
+            "type": "object",
+            "properties": {
+                "already_complete": {
+                    "$ref": "#/definitions/api.FetchProgressStatus"
+                },
+                "completed": {
+                    "$ref": "#/definitions/api.FetchProgressStatus"
+                },
+                "failed": {
+                    "$ref": "#/definitions/api.FetchProgressStatus"
+                },
+                "fetch_id": {
+                    "type": "string"
+                },
+                "pending": {
+                    "$ref": "#/definitions/api.FetchProgressStatus"
+                },
+                "running": {
+                    "$ref": "#/definitions/api.FetchProgressStatus"
+                },
+                "terminal": {
+                    "type": "boolean"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.FetchProgressStatus": {
+            "type": "object",
+            "properties": {
+                "candidate_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.LLMComponentSpend": {
+            "type": "object",
+            "properties": {
+                "calls": {
+                    "type": "integer"
+                },
+                "component": {
+                    "type": "string"
+                },
+                "cost_usd": {
+                    "type": "number"
+                },
+                "total_tokens": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.LLMDailySpend": {
+            "type": "object",
+            "properties": {
+                "cached_tokens": {
+                    "type": "integer"
+                },
+                "calls": {
+                    "type": "integer"
+                },
+                "component": {
+                    "type": "string"
+                },
+                "cost_usd": {
+                    "type": "number"
+                },
+                "day": {
+                    "type": "string"
+                },
+                "input_tokens": {
+                    "type": "integer"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "output_tokens": {
+                    "type": "integer"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "total_tokens": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.LLMSpendResponse": {
+            "type": "object",
+            "properties": {
+                "components": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.LLMComponentSpend"
+                    }
+                },
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.LLMDailySpend"
+                    }
+                },
+                "since": {
+                    "type": "string"
+                },
+                "total_cost_usd": {
+                    "type": "number"
+                },
+                "until": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.ListAPIKeysResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.APIKey"
+                    }
+                }
+            }
+        },
+        "api.ListCandidatesResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.Candidate"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "description": "NextCursor is set when more rows may follow; pass it back as ?cursor=.",
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.ListContentsResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.Content"
+                    }
+                },
+                "next_cursor": {
+                    "description": "NextCursor is set when more rows may follow; pass it back as ?cursor=.",
+                    "type": "string"
+                }
+            }
+        },
+        "api.ListParserRulesResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.ParserRule"
+                    }
+                }
+            }
+        },
+        "api.ListScoutConfigsResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.ScoutConfig"
+                    }
+                }
+            }
+        },
+        "api.ListSearchAliasesResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.SearchAliasGroup"
+                    }
+                }
+            }
+        },
+        "api.ListSourcesResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.Source"
+                    }
+                }
+            }
+        },
+        "api.ListTaskRunsResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
                 },
-                "id": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.TaskRun"
+                    }
+                },
+                "next_cursor": {
+                    "description": "NextCursor is set when more rows may follow; pass it back as ?cursor=.",
+                    "type": "string"
+                }
+            }
+        },
+        "api.PageFetchItem": {
+            "type": "object",
+            "properties": {
+                "candidate_id": {
                     "type": "string"
                 },
-                "ingestion_method": {
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.PageFetchNotify": {
+            "type": "object",
+            "properties": {
+                "secret": {
                     "type": "string"
                 },
-                "published_at": {
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.PageFetchQueryRequest": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "description": "DryRun only counts the matches; no fetch is created.",
+                    "type": "boolean"
+                },
+                "max_candidates": {
+                    "description": "MaxCandidates caps how many matches are promoted, newest first\n(default 100, max 1000).",
+                    "type": "integer"
+                },
+                "notify": {
+                    "$ref": "#/definitions/api.PageFetchNotify"
+                },
+                "q": {
+                    "type": "string"
+                },
+                "since": {
                     "type": "string"
                 },
                 "source_abbr": {
                     "type": "string"
                 },
-                "title": {
+                "until": {
                     "type": "string"
+                }
+            }
+        },
+        "api.PageFetchQueryResponse": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean"
                 },
-                "trace_id": {
+                "fetch_id": {
                     "type": "string"
                 },
-                "url": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.PageFetchItem"
+                    }
+                },
+                "matched": {
+                    "type": "integer"
+                },
+                "selected": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.PageFetchRequest": {
+            "type": "object",
+            "properties": {
+                "candidate_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "notify": {
+                    "$ref": "#/definitions/api.PageFetchNotify"
+                }
+            }
+        },
+        "api.PageFetchResponse": {
+            "type": "object",
+            "properties": {
+                "fetch_id": {
                     "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.PageFetchItem"
+                    }
                 }
             }
         },
-        "api.Content": {
+        "api.ParserRule": {
             "type": "object",
             "properties": {
-                "author": {
+                "config": {
+                    "type": "object"
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "batch_id": {
+                "host": {
                     "type": "string"
                 },
-                "candidate_id": {
+                "updated_at": {
                     "type": "string"
+                }
+            }
+        },
+        "api.PutScoutConfigRequest": {
+            "type": "object",
+            "properties": {
+                "config": {
+                    "type": "object"
                 },
-                "content": {
+                "kind": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.PutSearchAliasesRequest": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.SearchAlias"
+                    }
+                }
+            }
+        },
+        "api.PutSourceRequest": {
+            "type": "object",
+            "properties": {
+                "base_url": {
                     "type": "string"
                 },
-                "fetched_at": {
+                "name": {
                     "type": "string"
                 },
-                "id": {
+                "type": {
                     "type": "string"
+                }
+            }
+        },
+        "api.ScoutConfig": {
+            "type": "object",
+            "properties": {
+                "config": {
+                    "type": "object"
                 },
-                "published_at": {
+                "created_at": {
                     "type": "string"
                 },
-                "source_abbr": {
+                "kind": {
                     "type": "string"
                 },
-                "title": {
+                "name": {
                     "type": "string"
                 },
-                "trace_id": {
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.SearchAlias": {
+            "type": "object",
+            "properties": {
+                "alias": {
                     "type": "string"
                 },
-                "type": {
+                "kind": {
                     "type": "string"
                 },
-                "url": {
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "api.ErrorResponse": {
+        "api.SearchAliasGroup": {
             "type": "object",
             "properties": {
-                "error": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.SearchAlias"
+                    }
+                },
+                "canonical": {
                     "type": "string"
                 }
             }
         },
-        "api.FetchProgressResponse": {
+        "api.Source": {
             "type": "object",
             "properties": {
-                "already_complete": {
-                    "$ref": "#/definitions/api.FetchProgressStatus"
+                "abbr": {
+                    "type": "string"
                 },
-                "completed": {
-                    "$ref": "#/definitions/api.FetchProgressStatus"
+                "base_url": {
+                    "type": "string"
                 },
-                "failed": {
-                    "$ref": "#/definitions/api.FetchProgressStatus"
+                "name": {
+                    "type": "string"
                 },
-                "fetch_id": {
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.TaskRun": {
+            "type": "object",
+            "properties": {
+                "batch_id": {
                     "type": "string"
                 },
-                "pending": {
-                    "$ref": "#/definitions/api.FetchProgressStatus"
+                "duration_ms": {
+                    "type": "integer"
                 },
-                "running": {
-                    "$ref": "#/definitions/api.FetchProgressStatus"
+                "error_class": {
+                    "type": "string"
                 },
-                "terminal": {
-                    "type": "boolean"
+                "id": {
+                    "type": "integer"
                 },
-                "total": {
+                "items_duplicate": {
+                    "type": "integer"
+                },
+                "items_new": {
+                    "type": "integer"
+                },
+                "items_seen": {
                     "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "providers": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/api.TaskRunProvider"
+                    }
+                },
+                "query": {
+                    "type": "string"
+                },
+                "source_abbr": {
+                    "type": "string"
+                },
+                "source_type": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "task_id": {
+                    "type": "string"
+                },
+                "trace_id": {
+                    "type": "string"
                 }
             }
         },
-        "api.FetchProgressStatus": {
+        "api.TaskRunProvider": {
             "type": "object",
             "properties": {
-                "candidate_ids": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "error_class": {
+                    "type": "string"
                 },
-                "count": {
+                "seen": {
                     "type": "integer"
                 }
             }
         },
-        "api.ListCandidatesResponse": {
+        "api.TaskRunYield": {
             "type": "object",
             "properties": {
-                "count": {
+                "avg_duration_ms": {
                     "type": "integer"
                 },
-                "items": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/api.Candidate"
-                    }
+                "failed_runs": {
+                    "type": "integer"
                 },
-                "limit": {
+                "items_duplicate": {
                     "type": "integer"
                 },
-                "offset": {
+                "items_new": {
                     "type": "integer"
-                }
-            }
-        },
-        "api.PageFetchItem": {
-            "type": "object",
-            "properties": {
-                "candidate_id": {
+                },
+                "items_seen": {
+                    "type": "integer"
+                },
+                "kind": {
                     "type": "string"
                 },
-                "status": {
+                "last_new_at": {
+                    "type": "string"
+                },
+                "last_run_at": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "query": {
+                    "type": "string"
+                },
+                "runs": {
+                    "type": "integer"
+                },
+                "source_abbr": {
+                    "type": "string"
+                },
+                "task_id": {
                     "type": "string"
                 }
             }
         },
-        "api.PageFetchRequest": {
+        "api.TaskRunYieldResponse": {
             "type": "object",
             "properties": {
-                "candidate_ids": {
+                "count": {
+                    "type": "integer"
+                },
+                "group_by": {
+                    "type": "string"
+                },
+                "items": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/api.TaskRunYield"
                     }
+                },
+                "since": {
+                    "type": "string"
+                },
+                "until": {
+                    "type": "string"
                 }
             }
         },
-        "api.PageFetchResponse": {
+        "api.User": {
             "type": "object",
             "properties": {
-                "fetch_id": {
+                "created_at": {
                     "type": "string"
                 },
-                "items": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/api.PageFetchItem"
-                    }
+                "disabled_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
                 }
             }
         },
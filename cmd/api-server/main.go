@@ -19,12 +19,15 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/ChiaYuChang/prism/cmd/api-server/dashboard"
 	_ "github.com/ChiaYuChang/prism/cmd/api-server/docs"
 	"github.com/ChiaYuChang/prism/internal/appconfig"
 	"github.com/ChiaYuChang/prism/internal/http/api"
+	"github.com/ChiaYuChang/prism/internal/http/apikey"
 	"github.com/ChiaYuChang/prism/internal/http/middleware"
 	"github.com/ChiaYuChang/prism/internal/infra"
 	"github.com/ChiaYuChang/prism/internal/obs"
+	"github.com/ChiaYuChang/prism/internal/repo"
 	"github.com/ChiaYuChang/prism/internal/repo/pg"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/redis/go-redis/v9"
@@ -136,27 +139,72 @@ func main() {
 	}
 
 	if config.RateLimit.Enabled {
-		limiter := middleware.NewInMemoryIPLimiter(
+		limiter := middleware.NewInMemoryKeyLimiter(
 			config.RateLimit.RPS,
 			config.RateLimit.Burst,
-			config.RateLimit.IPCacheSize,
+			config.RateLimit.KeyCacheSize,
 		)
-		serverOpts = append(serverOpts, api.WithGetFetchLimiter(limiter))
-		logger.Info("get-fetch rate limit enabled",
+		serverOpts = append(serverOpts, api.WithRateLimiter(limiter))
+		logger.Info("per-key rate limit enabled",
 			"rps", config.RateLimit.RPS,
 			"burst", config.RateLimit.Burst,
-			"ip_cache_size", config.RateLimit.IPCacheSize)
+			"key_cache_size", config.RateLimit.KeyCacheSize)
 	}
+	if config.Stream.Enabled {
+		listener, err := pg.NewListener(logger, config.Postgres.ConnString(),
+			repo.ChangeChannelCandidates, repo.ChangeChannelFetchProgress)
+		if err != nil {
+			logger.Error("failed to construct change feed listener", "error", err)
+			os.Exit(1)
+		}
+		// Run closes every subscription when ctx ends, which also ends open
+		// SSE streams so server.Shutdown does not wait on them.
+		go func() {
+			if err := listener.Run(ctx); err != nil {
+				logger.Error("change feed listener stopped", "error", err)
+			}
+		}()
+		serverOpts = append(serverOpts, api.WithChangeFeed(listener, api.StreamConfig{
+			Settle:    config.Stream.Settle,
+			Poll:      config.Stream.Poll,
+			Heartbeat: config.Stream.Heartbeat,
+		}))
+		logger.Info("sse streams enabled",
+			"settle", config.Stream.Settle,
+			"poll_interval", config.Stream.Poll,
+			"heartbeat", config.Stream.Heartbeat)
+	}
+
 	authTokens, err := config.Auth.Token.TokenSet()
 	if err != nil {
 		logger.Error("failed to load auth tokens", "error", err)
 		os.Exit(1)
 	}
 	var apiMiddleware []middleware.Middleware
-	if len(authTokens) > 0 {
+	switch {
+	case config.Auth.APIKeys.Enabled:
+		store, err := apikey.NewStore(repository.Users(), authTokens, apikey.Config{
+			CacheTTL: config.Auth.APIKeys.CacheTTL,
+		})
+		if err != nil {
+			logger.Error("failed to construct api key store", "error", err)
+			os.Exit(1)
+		}
+		apiMiddleware = append(apiMiddleware, middleware.APIKeyAuth(store))
+		serverOpts = append(serverOpts, api.WithUsers(repository.Users()), api.WithCatalog(repository.Catalog()),
+			api.WithAliases(repository.Aliases()), api.WithTaskRuns(repository.TaskRuns()))
+		logger.Info("api key auth enabled",
+			"static_admin_tokens", len(authTokens),
+			"cache_ttl", config.Auth.APIKeys.CacheTTL)
+	case len(authTokens) > 0:
 		apiMiddleware = append(apiMiddleware, middleware.TokenListAuth(authTokens))
 		logger.Info("api token auth enabled", "tokens", len(authTokens))
 	}
+	if len(apiMiddleware) > 0 {
+		serverOpts = append(serverOpts, api.WithLLMSpend(repository.LLMUsage()))
+	} else {
+		logger.Warn("api auth disabled; GET /api/v1/llm/spend is not registered")
+	}
 
 	apiServer, err := api.NewServer(logger, repository.Scout(), repository.Tasks(), repository.Pipeline(), repository.UserFetches(), serverOpts...)
 	if err != nil {
@@ -186,6 +234,15 @@ func main() {
 	mux.HandleFunc("GET /readyz", readinessHandler(monitor))
 	mux.Handle("GET /swagger/", httpSwagger.Handler(httpSwagger.URL("/swagger/doc.json")))
 	apiServer.RegisterPublic(mux, apiMiddleware...)
+	if config.Dashboard.Enabled {
+		dash, err := dashboard.New(logger, dashboard.Options{ArchiveURL: config.Dashboard.ArchiveURL})
+		if err != nil {
+			logger.Error("failed to construct dashboard", "error", err)
+			os.Exit(1)
+		}
+		dash.Register(mux)
+		logger.Info("dashboard enabled", "path", "/dashboard/", "archive_url", config.Dashboard.ArchiveURL)
+	}
 
 	chain := middleware.Chain(
 		middleware.RequestID(),
@@ -196,7 +253,7 @@ func main() {
 		middleware.CORS(middleware.CORSOptions{
 			AllowOrigins: config.CORSOrigins,
 			AllowMethods: []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodOptions},
-			AllowHeaders: []string{"Content-Type", "Authorization", middleware.RequestIDHeader, middleware.TokenAuthHeader},
+			AllowHeaders: []string{"Content-Type", "Authorization", "Last-Event-ID", middleware.RequestIDHeader, middleware.TokenAuthHeader},
 			MaxAgeSecs:   600,
 		}),
 	)
@@ -37,6 +37,7 @@ type cliOptions struct {
 	source               string
 	until                time.Time
 	maxPages             int
+	restart              bool
 	scoutConfigFile      string
 	backfillerConfigFile string
 	timeout              time.Duration
@@ -169,7 +170,7 @@ func main() {
 		os.Exit(1)
 	}
 	backfiller, err := backfiller.BuildBackfiller(
-		srcSpec, scoutRepo, logger, tracer, httpclient.NewPublicClient(DefaultHTTPTimeout), sink)
+		srcSpec, scoutRepo, logger, tracer, httpclient.NewPublicClient(DefaultHTTPTimeout), sink, repository.Backfills())
 
 	if err != nil {
 		logger.Error("failed to build backfiller", "source", opts.source, "error", err)
@@ -196,9 +197,11 @@ func main() {
 		"until", opts.until.Format("2006-01-02"))
 
 	result, err := backfiller.Run(ctx, discovery.BackfillRequest{
-		BatchID:  batchID,
-		Until:    opts.until,
-		MaxPages: opts.maxPages,
+		BatchID:    batchID,
+		Until:      opts.until,
+		MaxPages:   opts.maxPages,
+		SourceType: srcSpec.SourceType,
+		Restart:    opts.restart,
 	})
 	if err != nil {
 		logger.Error("backfill failed", "source", opts.source, "error", err)
@@ -208,9 +211,12 @@ func main() {
 	logger.Info("backfill completed",
 		"source", opts.source,
 		"source_abbr", srcSpec.Name,
-		"batch_id", batchID.String(),
+		"batch_id", result.BatchID.String(),
 		"base_url", srcSpec.BaseURL,
 		"until", opts.until.Format("2006-01-02"),
+		"resumed_from", result.ResumedFrom,
+		"last_page", result.LastPage,
+		"completed", result.Completed,
 		"pages_visited", result.PagesVisited,
 		"candidates_seen", result.CandidatesSeen,
 		"candidates_processed", result.CandidatesProcessed,
@@ -227,7 +233,8 @@ func parseCLI(args []string, output io.Writer) (cliOptions, error) {
 	opts.telemetry = telemetryDefaults
 	untilRaw := ""
 	fs.StringVar(&untilRaw, "until", "", "stop when listing items become older than this date (YYYY-MM-DD)")
-	fs.IntVar(&opts.maxPages, "max-pages", 0, "maximum number of listing pages to visit (0 means unlimited)")
+	fs.IntVar(&opts.maxPages, "max-pages", 0, "maximum number of listing pages to visit in this run (0 means unlimited)")
+	fs.BoolVar(&opts.restart, "restart", false, "ignore the source's saved checkpoint and start from the first page")
 	fs.StringVar(&opts.scoutConfigFile, "scout-config", DefaultScoutConfigPath, "path to scout config file")
 	fs.StringVar(&opts.backfillerConfigFile, "backfill-config", DefaultBackfillerConfigPath, "path to backfiller config file")
 	fs.DurationVar(&opts.timeout, "timeout", 0, "timeout for all backfill process (0 means unlimited)")
@@ -262,6 +269,8 @@ func parseCLI(args []string, output io.Writer) (cliOptions, error) {
 		_, _ = fmt.Fprintf(fs.Output(), "  %s --source dpp --until 2026-01-01\n", CommandName)
 		_, _ = fmt.Fprintf(fs.Output(), "  %s --source kmt --until 2024-01-01 --max-pages 50\n\n", CommandName)
 		_, _ = fmt.Fprintf(fs.Output(), "  %s --source kmt --until 2024-01-01 --timeout 10m\n\n", CommandName)
+		_, _ = fmt.Fprintln(fs.Output(), "An unfinished run of the same source resumes after its last finished page; --restart starts over.")
+		_, _ = fmt.Fprintln(fs.Output())
 		fs.PrintDefaults()
 	}
 
@@ -34,6 +34,15 @@ func TestParseCLIParsesRequiredArguments(t *testing.T) {
 	assert.Equal(t, "dev", opts.telemetry.ServiceVersion)
 	assert.Equal(t, "collector:4317", opts.telemetry.Endpoint)
 	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local), opts.until)
+	assert.False(t, opts.restart)
+}
+
+func TestParseCLIParsesRestart(t *testing.T) {
+	var out bytes.Buffer
+
+	opts, err := parseCLI([]string{"--source=dpp", "--until=2026-01-01", "--restart"}, &out)
+	require.NoError(t, err)
+	assert.True(t, opts.restart)
 }
 
 func TestParseCLIReturnsUsageErrorWhenRequiredFlagsMissing(t *testing.T) {
@@ -95,7 +95,7 @@ type bridgeSink struct {
 	httpClient *http.Client
 }
 
-func (s *bridgeSink) Handle(ctx context.Context, req discoverysink.CandidateSinkRequest) error {
+func (s *bridgeSink) Handle(ctx context.Context, req discoverysink.CandidateSinkRequest) (discoverysink.CandidateSinkResult, error) {
 	// 1. First, download and mirror the directory page itself if not already done.
 	if err := s.downloadAndMirror(ctx, req.SourceURL); err != nil {
 		s.logger.Error("failed to mirror directory page", "url", req.SourceURL, "error", err)
@@ -117,7 +117,7 @@ func (s *bridgeSink) Handle(ctx context.Context, req discoverysink.CandidateSink
 			}
 		}
 	}
-	return nil
+	return discoverysink.CandidateSinkResult{}, nil
 }
 
 func (s *bridgeSink) downloadAndMirror(ctx context.Context, rawURL string) error {
@@ -3,16 +3,26 @@ package main
 import (
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/notify"
 )
 
+// webhookPath receives fetch.completed webhooks from cmd/fetch/notifier so
+// the notify flow can be exercised without an external endpoint.
+const webhookPath = "/_prism/webhook"
+
 func main() {
 	port := flag.Int("port", 8080, "Port to listen on")
 	baseDir := flag.String("base", "tmp/mirror", "Base directory for mirrored data")
+	webhookSecret := flag.String("webhook-secret", "", "Secret to verify webhook signatures with (empty skips verification)")
+	webhookStatus := flag.Int("webhook-status", http.StatusNoContent, "Status returned to verified webhooks (e.g. 503 to exercise retries)")
 	flag.Parse()
 
 	// Ensure the directory exists
@@ -58,12 +68,40 @@ func main() {
 	})
 
 	mux.Handle("/", handler)
+	mux.Handle("POST "+webhookPath, webhookHandler(*webhookSecret, *webhookStatus))
 
 	fmt.Printf(">> Mock Multi-Host Server started at :%d\n", *port)
 	fmt.Printf(">> Serving multi-host data from: %s\n", *baseDir)
 	fmt.Printf(">> Example usage: curl -H \"Host: www.dpp.org.tw\" http://localhost:%d/media/00\n", *port)
+	fmt.Printf(">> Webhook receiver: POST http://localhost:%d%s\n", *port, webhookPath)
 
 	if err := http.ListenAndServe(fmt.Sprintf(":%d", *port), mux); err != nil {
 		log.Fatal(err)
 	}
 }
+
+// webhookHandler logs each delivery and, when secret is set, rejects those
+// whose signature does not verify with 401.
+func webhookHandler(secret string, status int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "read body", http.StatusBadRequest)
+			return
+		}
+		delivery := r.Header.Get(notify.HeaderDelivery)
+		if secret != "" {
+			if err := notify.Verify(secret,
+				r.Header.Get(notify.HeaderTimestamp),
+				r.Header.Get(notify.HeaderSignature),
+				body, 5*time.Minute, time.Now()); err != nil {
+				log.Printf("[Webhook] REJECTED delivery=%s: %v", delivery, err)
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+		}
+		log.Printf("[Webhook] %s delivery=%s -> %d\n%s",
+			r.Header.Get(notify.HeaderEvent), delivery, status, body)
+		w.WriteHeader(status)
+	})
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	app "github.com/ChiaYuChang/prism/internal/appconfig"
+	"github.com/ChiaYuChang/prism/internal/obs"
+	"github.com/go-playground/validator/v10"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+type Config struct {
+	Interval     time.Duration       `mapstructure:"interval"       validate:"required,min=1s"`
+	Once         bool                `mapstructure:"once"`
+	SweepLimit   int32               `mapstructure:"sweep-limit"    validate:"required,min=1,max=500"`
+	DeliverLimit int32               `mapstructure:"deliver-limit"  validate:"required,min=1,max=500"`
+	MaxAttempts  int                 `mapstructure:"max-attempts"   validate:"required,min=1,max=50"`
+	BaseBackoff  time.Duration       `mapstructure:"base-backoff"   validate:"required,min=1s"`
+	MaxBackoff   time.Duration       `mapstructure:"max-backoff"    validate:"required,gtefield=BaseBackoff"`
+	Timeout      time.Duration       `mapstructure:"timeout"        validate:"required,min=1s"`
+	Lease        time.Duration       `mapstructure:"lease"          validate:"required,gtfield=Timeout"`
+	AllowPrivate bool                `mapstructure:"allow-private-targets"`
+	HealthPort   int                 `mapstructure:"health-port"    validate:"required,min=1024,max=65535"`
+	Logger       obs.LoggingConfig   `mapstructure:"logger"`
+	Telemetry    obs.TelemetryConfig `mapstructure:"telemetry"`
+	Postgres     app.PostgresConfig  `mapstructure:"postgres"`
+}
+
+func LoadConfig(args []string) (*Config, error) {
+	v := viper.New()
+	v.SetEnvPrefix("PRISM_FETCH_NOTIFIER")
+	v.SetEnvKeyReplacer(strings.NewReplacer("-", "_", ".", "_"))
+	v.AutomaticEnv()
+
+	fs := pflag.NewFlagSet("fetch-notifier", pflag.ContinueOnError)
+	fs.StringP("config", "c", "", "Path to the configuration file (YAML or JSON)")
+	fs.Duration("interval", 15*time.Second, "Polling interval for fetch completion and webhook delivery")
+	fs.Bool("once", false, "Execute once and exit (for Lambda/Cron)")
+	fs.Int32("sweep-limit", 100, "Maximum terminal fetches to complete per tick")
+	fs.Int32("deliver-limit", 100, "Maximum due webhooks to deliver per tick")
+	fs.Int("max-attempts", 8, "Delivery attempts before a webhook is marked FAILED")
+	fs.Duration("base-backoff", 30*time.Second, "Wait before the first retry; doubles per attempt")
+	fs.Duration("max-backoff", time.Hour, "Upper bound on the wait between retries")
+	fs.Duration("timeout", 10*time.Second, "HTTP timeout for one webhook delivery")
+	fs.Duration("lease", 2*time.Minute, "How long a claimed delivery stays hidden from other instances")
+	fs.Bool("allow-private-targets", false, "Allow webhooks to loopback, private and link-local addresses (development only)")
+	fs.Int("health-port", 8085, "The port for the health check server")
+
+	obs.RegisterLoggingFlags(fs, obs.DefaultLoggingConfig("prism.fetch.notifier"))
+	obs.RegisterTelemetryFlags(fs, obs.DefaultTelemetryConfig("prism.fetch.notifier"))
+
+	fs.String("pg-host", "localhost", "Postgres host")
+	fs.Int("pg-port", 5432, "Postgres port")
+	fs.String("pg-username", "postgres", "Postgres username")
+	fs.String("pg-password", "postgres", "Postgres password")
+	fs.String("pg-db", "prism", "Postgres database name")
+	fs.String("pg-sslmode", "disable", "Postgres SSL mode")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, fmt.Errorf("failed to parse flags: %w", err)
+	}
+
+	configPath, _ := fs.GetString("config")
+	if configPath != "" {
+		if err := app.ReadConfigFile(v, configPath); err != nil {
+			return nil, fmt.Errorf("failed to read config file: %w", err)
+		}
+	}
+	if err := v.BindPFlags(fs); err != nil {
+		return nil, fmt.Errorf("failed to bind flags: %w", err)
+	}
+
+	var cfg Config
+	if err := cfg.Postgres.BindFlags(v, fs); err != nil {
+		return nil, err
+	}
+	if err := obs.BindLoggingFlags(v, fs); err != nil {
+		return nil, err
+	}
+	if err := obs.BindTelemetryFlags(v, fs); err != nil {
+		return nil, err
+	}
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+	loggerCfg, err := obs.LoadLoggingConfig(v)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Logger = loggerCfg
+	telemetryCfg, err := obs.LoadTelemetryConfig(v)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Telemetry = telemetryCfg
+
+	validate := validator.New()
+	if err := validate.Struct(&cfg); err != nil {
+		return nil, fmt.Errorf("config validation failed: %v", err)
+	}
+	return &cfg, nil
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig_Defaults(t *testing.T) {
+	cfg, err := LoadConfig([]string{})
+	require.NoError(t, err)
+
+	assert.Equal(t, 15*time.Second, cfg.Interval)
+	assert.False(t, cfg.Once)
+	assert.Equal(t, int32(100), cfg.SweepLimit)
+	assert.Equal(t, int32(100), cfg.DeliverLimit)
+	assert.Equal(t, 8, cfg.MaxAttempts)
+	assert.Equal(t, 30*time.Second, cfg.BaseBackoff)
+	assert.Equal(t, time.Hour, cfg.MaxBackoff)
+	assert.Equal(t, 10*time.Second, cfg.Timeout)
+	assert.Equal(t, 2*time.Minute, cfg.Lease)
+	assert.False(t, cfg.AllowPrivate)
+	assert.Equal(t, 8085, cfg.HealthPort)
+	assert.Equal(t, "localhost", cfg.Postgres.Host)
+}
+
+func TestLoadConfig_ShippedConfig(t *testing.T) {
+	t.Setenv("POSTGRES_HOST", "postgres")
+	t.Setenv("POSTGRES_PORT", "5432")
+	t.Setenv("POSTGRES_APP_USER", "prism")
+	t.Setenv("POSTGRES_APP_DB", "prism")
+	t.Setenv("PRISM_FETCH_INTERVAL", "20s")
+	t.Setenv("PRISM_WORKER_OTEL_ENABLED", "true")
+	t.Setenv("OTEL_COLLECTOR_ENDPOINT", "otel-collector:4317")
+
+	cfg, err := LoadConfig([]string{"--config", filepath.Join("..", "..", "..", "configs", "fetch", "notifier", "config.yaml")})
+	require.NoError(t, err)
+
+	assert.Equal(t, 20*time.Second, cfg.Interval)
+	assert.Equal(t, 8, cfg.MaxAttempts)
+	assert.Equal(t, "postgres", cfg.Postgres.Host)
+	assert.Equal(t, "prism.fetch.notifier", cfg.Telemetry.ServiceName)
+}
+
+func TestLoadConfig_EnvironmentVariables(t *testing.T) {
+	t.Setenv("PRISM_FETCH_NOTIFIER_MAX_ATTEMPTS", "3")
+	t.Setenv("PRISM_FETCH_NOTIFIER_BASE_BACKOFF", "5s")
+
+	cfg, err := LoadConfig([]string{})
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, cfg.MaxAttempts)
+	assert.Equal(t, 5*time.Second, cfg.BaseBackoff)
+}
+
+func TestLoadConfig_ValidationFailed(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"sweep-limit zero", []string{"--sweep-limit=0"}},
+		{"deliver-limit above max", []string{"--deliver-limit=501"}},
+		{"max-attempts zero", []string{"--max-attempts=0"}},
+		{"max-backoff below base", []string{"--base-backoff=1m", "--max-backoff=30s"}},
+		{"lease not above timeout", []string{"--timeout=30s", "--lease=30s"}},
+		{"health-port below range", []string{"--health-port=80"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadConfig(tt.args)
+			assert.Error(t, err)
+		})
+	}
+}
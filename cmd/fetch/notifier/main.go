@@ -0,0 +1,130 @@
+// Command notifier completes user fetches and delivers their fetch.completed
+// webhooks. Each tick sweeps for fetches whose items are all terminal, then
+// sends due deliveries; see internal/notify.
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/appconfig"
+	"github.com/ChiaYuChang/prism/internal/infra"
+	"github.com/ChiaYuChang/prism/internal/notify"
+	"github.com/ChiaYuChang/prism/internal/obs"
+	"github.com/ChiaYuChang/prism/internal/repo/pg"
+)
+
+const (
+	TracerName = "prism.fetch.notifier"
+)
+
+func main() {
+	config, err := LoadConfig(os.Args[1:])
+	if err != nil {
+		slog.Error("failed to load config", "error", err)
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	handlers, logFile, shutdownLogger, err := obs.BuildLoggingHandlers(ctx, config.Logger)
+	if err != nil {
+		slog.Error("failed to initialize logger", "error", err)
+		os.Exit(1)
+	}
+	logger := obs.NewLoggerFromHandlers(handlers)
+	slog.SetDefault(logger)
+	appconfig.FlushPendingLogs()
+	defer func() {
+		if err := shutdownLogger(context.Background()); err != nil {
+			logger.Error("failed to shutdown logger", "error", err)
+		}
+	}()
+	if logFile != nil {
+		defer func() { _ = logFile.Close() }()
+	}
+
+	telemetry, err := obs.InitTelemetry(ctx, config.Telemetry)
+	if err != nil {
+		logger.Error("failed to initialize telemetry", "error", err)
+		os.Exit(1)
+	}
+	defer func() {
+		if err := telemetry.Shutdown(context.Background()); err != nil {
+			logger.Error("failed to shutdown telemetry", "error", err)
+		}
+	}()
+	tracer := telemetry.Tracer(TracerName)
+	infra.SetTracer(tracer)
+
+	monitor := obs.NewHealthMonitor()
+
+	repository, repositoryCloser, err := pg.NewRepositoryBuilder(config.Postgres).NewRepository(ctx)
+	if err != nil {
+		logger.Error("failed to initialize repository", "backend", "postgres", "host", config.Postgres.Host, "error", err)
+		os.Exit(1)
+	}
+	defer func() { _ = repositoryCloser.Close() }()
+
+	// Webhook targets are user-supplied: the client never follows redirects
+	// and refuses private addresses unless explicitly allowed.
+	client := notify.NewHTTPClient(config.Timeout, config.AllowPrivate)
+	if config.AllowPrivate {
+		logger.Warn("webhook delivery to private addresses is allowed")
+	}
+
+	dispatcher, err := notify.NewDispatcher(logger, tracer, repository.UserFetches(), client, notify.Config{
+		MaxAttempts: config.MaxAttempts,
+		BaseBackoff: config.BaseBackoff,
+		MaxBackoff:  config.MaxBackoff,
+		Lease:       config.Lease,
+	})
+	if err != nil {
+		logger.Error("failed to build fetch notifier", "error", err)
+		os.Exit(1)
+	}
+
+	tick := func() error {
+		if _, err := dispatcher.Sweep(ctx, config.SweepLimit); err != nil {
+			return err
+		}
+		_, err := dispatcher.Deliver(ctx, config.DeliverLimit)
+		return err
+	}
+
+	if config.Once {
+		logger.Info("running fetch notifier once")
+		if err := tick(); err != nil {
+			logger.Error("fetch notifier failed", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	obs.StartHealthServer(ctx, config.HealthPort, monitor)
+	ticker := time.NewTicker(config.Interval)
+	defer ticker.Stop()
+	monitor.OK()
+	logger.Info("fetch notifier started",
+		"interval", config.Interval,
+		"sweep_limit", config.SweepLimit,
+		"deliver_limit", config.DeliverLimit,
+		"max_attempts", config.MaxAttempts)
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("shutting down fetch notifier")
+			return
+		case <-ticker.C:
+			if err := tick(); err != nil {
+				logger.Error("fetch notifier tick failed", "error", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"slices"
+
+	"github.com/ChiaYuChang/prism/pkg/prismclient"
+)
+
+type tokenKey struct{}
+
+// withToken makes tool calls made with ctx authenticate as token instead of
+// the configured one. The HTTP transport uses it to act with the MCP
+// caller's own API key.
+func withToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, tokenKey{}, token)
+}
+
+// apiClients hands each tool call a prismclient.Client that authenticates
+// as the caller. All of them share one connection pool.
+type apiClients struct {
+	baseURL string
+	token   string
+	opts    []prismclient.Option
+}
+
+// newAPIClients returns the client factory for the API at baseURL. token
+// is used only for calls whose context carries no caller token, which on
+// the HTTP transport never happens because requireCallerToken refuses
+// them first; pass "" there.
+func newAPIClients(baseURL, token string, opts ...prismclient.Option) (*apiClients, error) {
+	opts = append([]prismclient.Option{
+		prismclient.WithHTTPClient(&http.Client{}),
+		prismclient.WithUserAgent(serverName + "-mcp/" + serverVersion),
+	}, opts...)
+	if _, err := prismclient.New(baseURL, opts...); err != nil {
+		return nil, err
+	}
+	return &apiClients{baseURL: baseURL, token: token, opts: opts}, nil
+}
+
+// forCall returns the client for the tool call running with ctx.
+func (a *apiClients) forCall(ctx context.Context) *prismclient.Client {
+	token := a.token
+	if t, ok := ctx.Value(tokenKey{}).(string); ok && t != "" {
+		token = t
+	}
+	// New only fails on the base URL, which newAPIClients already checked.
+	c, _ := prismclient.New(a.baseURL, slices.Concat(a.opts, []prismclient.Option{prismclient.WithToken(token)})...)
+	return c
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+const (
+	TransportStdio = "stdio"
+	TransportHTTP  = "http"
+)
+
+type Config struct {
+	Transport string        `mapstructure:"transport"  validate:"required,oneof=stdio http"`
+	Listen    string        `mapstructure:"listen"     validate:"required_if=Transport http"`
+	APIURL    string        `mapstructure:"api-url"    validate:"required,http_url"`
+	Token     string        `mapstructure:"token"`
+	TokenFile string        `mapstructure:"token-file"`
+	Timeout   time.Duration `mapstructure:"timeout"    validate:"required,min=1s"`
+	LogLevel  string        `mapstructure:"log-level"  validate:"required,oneof=debug info warn error"`
+}
+
+func LoadConfig(args []string) (*Config, error) {
+	v := viper.New()
+	v.SetEnvPrefix("PRISM_MCP")
+	v.SetEnvKeyReplacer(strings.NewReplacer("-", "_", ".", "_"))
+	v.AutomaticEnv()
+	_ = v.BindEnv("token", "PRISM_MCP_AUTH_TOKEN")
+	_ = v.BindEnv("token-file", "PRISM_MCP_AUTH_TOKEN_FILE")
+
+	fs := pflag.NewFlagSet("prism-mcp", pflag.ContinueOnError)
+	fs.String("transport", TransportStdio, "MCP transport: stdio or http (streamable HTTP)")
+	fs.String("listen", "127.0.0.1:8091", "Listen address for --transport=http")
+	fs.String("api-url", "http://localhost:8090", "Base URL of the Prism API server")
+	fs.String("token", "", "API token sent as X-PRISM-TOKEN (stdio only; HTTP callers must send their own)")
+	fs.String("token-file", "", "File holding the API token (used when --token is empty)")
+	fs.Duration("timeout", 30*time.Second, "HTTP timeout for one API request")
+	fs.String("log-level", "info", "Log level (logs go to stderr)")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, fmt.Errorf("failed to parse flags: %w", err)
+	}
+	if err := v.BindPFlags(fs); err != nil {
+		return nil, fmt.Errorf("failed to bind flags: %w", err)
+	}
+
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+	cfg.APIURL = strings.TrimRight(cfg.APIURL, "/")
+	if cfg.Token == "" && cfg.TokenFile != "" {
+		b, err := os.ReadFile(cfg.TokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read token file: %w", err)
+		}
+		cfg.Token = strings.TrimSpace(string(b))
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(&cfg); err != nil {
+		return nil, fmt.Errorf("config validation failed: %v", err)
+	}
+	return &cfg, nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig_Defaults(t *testing.T) {
+	cfg, err := LoadConfig([]string{})
+	require.NoError(t, err)
+
+	assert.Equal(t, TransportStdio, cfg.Transport)
+	assert.Equal(t, "127.0.0.1:8091", cfg.Listen)
+	assert.Equal(t, "http://localhost:8090", cfg.APIURL)
+	assert.Empty(t, cfg.Token)
+	assert.Equal(t, 30*time.Second, cfg.Timeout)
+	assert.Equal(t, "info", cfg.LogLevel)
+}
+
+func TestLoadConfig_FlagsAndEnv(t *testing.T) {
+	t.Setenv("PRISM_MCP_API_URL", "http://prism.internal:8090/")
+	t.Setenv("PRISM_MCP_AUTH_TOKEN", "env-token")
+	t.Setenv("PRISM_MCP_TRANSPORT", "http")
+
+	cfg, err := LoadConfig([]string{"--listen=127.0.0.1:9000"})
+	require.NoError(t, err)
+	assert.Equal(t, "http://prism.internal:8090", cfg.APIURL)
+	assert.Equal(t, "env-token", cfg.Token)
+	assert.Equal(t, TransportHTTP, cfg.Transport)
+	assert.Equal(t, "127.0.0.1:9000", cfg.Listen)
+
+	cfg, err = LoadConfig([]string{"--transport=stdio", "--token=flag-token"})
+	require.NoError(t, err)
+	assert.Equal(t, TransportStdio, cfg.Transport)
+	assert.Equal(t, "flag-token", cfg.Token)
+}
+
+func TestLoadConfig_TokenFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("file-token\n"), 0o600))
+
+	cfg, err := LoadConfig([]string{"--token-file", path})
+	require.NoError(t, err)
+	assert.Equal(t, "file-token", cfg.Token)
+
+	cfg, err = LoadConfig([]string{"--token-file", path, "--token", "flag-token"})
+	require.NoError(t, err)
+	assert.Equal(t, "flag-token", cfg.Token, "explicit token wins over the file")
+
+	_, err = LoadConfig([]string{"--token-file", filepath.Join(t.TempDir(), "missing")})
+	require.Error(t, err)
+}
+
+func TestLoadConfig_Invalid(t *testing.T) {
+	for _, args := range [][]string{
+		{"--transport=sse"},
+		{"--transport=http", "--listen="},
+		{"--api-url=not a url"},
+		{"--timeout=0s"},
+		{"--log-level=trace"},
+	} {
+		_, err := LoadConfig(args)
+		assert.Error(t, err, args)
+	}
+}
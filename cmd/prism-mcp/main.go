@@ -0,0 +1,140 @@
+// Command prism-mcp is a Model Context Protocol server that lets LLM agents
+// search candidates, request page fetches, follow their progress and read
+// stored content. It is an API client only: every tool call goes through
+// /api/v1, so API keys, scopes and per-user fetch ownership still apply.
+//
+// With --transport=stdio (the default) it serves one agent with the
+// configured token. With --transport=http it serves the streamable HTTP
+// transport on --listen, and each request authenticates with the caller's
+// own X-PRISM-TOKEN or bearer token. Requests without one are refused with
+// 401; the configured token is never used over HTTP.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/http/middleware"
+	"github.com/ChiaYuChang/prism/pkg/prismclient"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/spf13/pflag"
+)
+
+const mcpEndpoint = "/mcp"
+
+func main() {
+	cfg, err := LoadConfig(os.Args[1:])
+	if err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			os.Exit(0)
+		}
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	// stdout carries the stdio transport, so logs always go to stderr.
+	var level slog.Level
+	_ = level.UnmarshalText([]byte(cfg.LogLevel))
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level}))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	token := cfg.Token
+	if cfg.Transport == TransportHTTP && token != "" {
+		logger.Warn("--token is ignored with --transport=http; every caller must send its own API token")
+		token = ""
+	}
+	api, err := newAPIClients(cfg.APIURL, token, prismclient.WithTimeout(cfg.Timeout))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	s := newServer(api, logger)
+
+	switch cfg.Transport {
+	case TransportHTTP:
+		err = serveHTTP(ctx, s, cfg.Listen, logger)
+	default:
+		logger.Info("serving mcp over stdio", "api_url", cfg.APIURL)
+		err = server.NewStdioServer(s).Listen(ctx, os.Stdin, os.Stdout)
+	}
+	if err != nil && !errors.Is(err, context.Canceled) {
+		logger.Error("prism-mcp stopped", "error", err)
+		os.Exit(1)
+	}
+}
+
+func serveHTTP(ctx context.Context, s *server.MCPServer, addr string, logger *slog.Logger) error {
+	srv := &http.Server{Addr: addr, Handler: httpHandler(s), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			logger.Error("failed to shut down mcp http server", "error", err)
+		}
+	}()
+
+	logger.Info("serving mcp over http", "addr", addr, "endpoint", mcpEndpoint)
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// httpHandler serves the streamable HTTP transport on mcpEndpoint, behind
+// requireCallerToken, and an unauthenticated /healthz.
+func httpHandler(s *server.MCPServer) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(mcpEndpoint, requireCallerToken(server.NewStreamableHTTPServer(s,
+		server.WithEndpointPath(mcpEndpoint),
+		server.WithHTTPContextFunc(callerToken),
+	)))
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	return mux
+}
+
+// requireCallerToken refuses MCP requests that carry no API token, so an
+// HTTP caller can only ever act as itself.
+func requireCallerToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requestToken(r) == "" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="prism-mcp"`)
+			http.Error(w, "missing API token: send X-PRISM-TOKEN or Authorization: Bearer", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// callerToken carries the HTTP caller's API key into tool calls.
+func callerToken(ctx context.Context, r *http.Request) context.Context {
+	if token := requestToken(r); token != "" {
+		return withToken(ctx, token)
+	}
+	return ctx
+}
+
+// requestToken reads the caller's API key the same way the API server
+// does: X-PRISM-TOKEN first, then a bearer token.
+func requestToken(r *http.Request) string {
+	if key := strings.TrimSpace(r.Header.Get(middleware.TokenAuthHeader)); key != "" {
+		return key
+	}
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if ok && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(token)
+	}
+	return ""
+}
@@ -0,0 +1,267 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ChiaYuChang/prism/pkg/prismclient"
+	"github.com/google/uuid"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	serverName    = "prism"
+	serverVersion = "0.1.0"
+
+	defaultSearchLimit   = 20
+	maxSearchLimit       = 100
+	maxPageFetchIDs      = 100
+	defaultContentMaxLen = 20000
+)
+
+const instructions = `Prism collects Taiwanese party press releases and news articles.
+Typical flow: list_sources to learn source abbreviations, search_candidates to
+find discovered articles, request_page_fetch to have the collector download
+the ones you need, get_fetch_progress until it is terminal, then get_content
+to read each article.`
+
+// tools adapts the Prism API to MCP tool handlers.
+type tools struct {
+	api    *apiClients
+	logger *slog.Logger
+}
+
+// newServer builds the MCP server with every Prism tool registered.
+func newServer(api *apiClients, logger *slog.Logger) *server.MCPServer {
+	t := &tools{api: api, logger: logger}
+	s := server.NewMCPServer(serverName, serverVersion,
+		server.WithToolCapabilities(false),
+		server.WithInstructions(instructions),
+		server.WithRecovery(),
+	)
+
+	s.AddTool(mcp.NewTool("search_candidates",
+		mcp.WithDescription("Search discovered articles (candidates), newest first. "+
+			"Candidates carry title, URL and publish date only; use request_page_fetch to get the full text."),
+		mcp.WithString("q", mcp.Description("Full-text query over title and description")),
+		mcp.WithString("source_abbr", mcp.Description("Only this source (see list_sources)")),
+		mcp.WithString("since", mcp.Description("Published on or after: RFC3339 or YYYY-MM-DD")),
+		mcp.WithString("until", mcp.Description("Published on or before: RFC3339 or YYYY-MM-DD")),
+		mcp.WithNumber("limit", mcp.Description("Page size"), mcp.DefaultNumber(defaultSearchLimit),
+			mcp.Min(1), mcp.Max(maxSearchLimit)),
+		mcp.WithString("cursor", mcp.Description("next_cursor from a previous call, for the next page")),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(false),
+	), t.searchCandidates)
+
+	s.AddTool(mcp.NewTool("request_page_fetch",
+		mcp.WithDescription("Ask the collector to download and extract the full text of candidates. "+
+			"Returns a fetch_id to poll with get_fetch_progress. Re-requesting already fetched candidates is harmless."),
+		mcp.WithArray("candidate_ids", mcp.Required(), mcp.Description("Candidate IDs from search_candidates"),
+			mcp.WithStringItems(), mcp.MinItems(1), mcp.MaxItems(maxPageFetchIDs)),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(false),
+	), t.requestPageFetch)
+
+	s.AddTool(mcp.NewTool("get_fetch_progress",
+		mcp.WithDescription("Per-status progress of a page fetch. Poll until terminal is true."),
+		mcp.WithString("fetch_id", mcp.Required(), mcp.Description("fetch_id from request_page_fetch")),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(false),
+	), t.getFetchProgress)
+
+	s.AddTool(mcp.NewTool("get_content",
+		mcp.WithDescription("Full text of a fetched article, by candidate ID."),
+		mcp.WithString("candidate_id", mcp.Required(), mcp.Description("Candidate ID")),
+		mcp.WithNumber("max_chars", mcp.Description("Truncate the body to this many characters; 0 returns it whole"),
+			mcp.DefaultNumber(defaultContentMaxLen), mcp.Min(0)),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(false),
+	), t.getContent)
+
+	s.AddTool(mcp.NewTool("list_sources",
+		mcp.WithDescription("Active sources with the abbr used as source_abbr in search_candidates."),
+		mcp.WithString("type", mcp.Description("Only this source type"), mcp.Enum("PARTY", "GOVERNMENT", "MEDIA")),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(false),
+	), t.listSources)
+
+	return s
+}
+
+func (t *tools) searchCandidates(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	since, err := parseDay(req.GetString("since", ""), false)
+	if err != nil {
+		return mcp.NewToolResultError("since: " + err.Error()), nil
+	}
+	until, err := parseDay(req.GetString("until", ""), true)
+	if err != nil {
+		return mcp.NewToolResultError("until: " + err.Error()), nil
+	}
+	limit := req.GetInt("limit", defaultSearchLimit)
+	if limit < 1 || limit > maxSearchLimit {
+		return mcp.NewToolResultError(fmt.Sprintf("limit must be between 1 and %d", maxSearchLimit)), nil
+	}
+
+	out, err := t.api.forCall(ctx).ListCandidates(ctx, prismclient.ListCandidatesParams{
+		Q:          strings.TrimSpace(req.GetString("q", "")),
+		SourceAbbr: strings.TrimSpace(req.GetString("source_abbr", "")),
+		Since:      since,
+		Until:      until,
+		Limit:      limit,
+		Cursor:     req.GetString("cursor", ""),
+	})
+	if err != nil {
+		return t.apiFailure(ctx, "search_candidates", err), nil
+	}
+	return structured(out)
+}
+
+func (t *tools) requestPageFetch(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	raw, err := req.RequireStringSlice("candidate_ids")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if len(raw) == 0 || len(raw) > maxPageFetchIDs {
+		return mcp.NewToolResultError(fmt.Sprintf("candidate_ids must hold 1 to %d IDs", maxPageFetchIDs)), nil
+	}
+	ids := make([]uuid.UUID, 0, len(raw))
+	for _, s := range raw {
+		id, err := uuid.Parse(strings.TrimSpace(s))
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("invalid candidate id %q", s)), nil
+		}
+		ids = append(ids, id)
+	}
+
+	out, err := t.api.forCall(ctx).PageFetch(ctx, prismclient.PageFetchRequest{CandidateIDs: ids})
+	if err != nil {
+		return t.apiFailure(ctx, "request_page_fetch", err), nil
+	}
+	return structured(out)
+}
+
+func (t *tools) getFetchProgress(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	id, errResult := requireUUID(req, "fetch_id")
+	if errResult != nil {
+		return errResult, nil
+	}
+	out, err := t.api.forCall(ctx).GetFetch(ctx, id)
+	if err != nil {
+		if prismclient.IsNotFound(err) {
+			return mcp.NewToolResultError(fmt.Sprintf("fetch %s not found (or not yours)", id)), nil
+		}
+		return t.apiFailure(ctx, "get_fetch_progress", err), nil
+	}
+	return structured(out)
+}
+
+// contentResult is get_content's payload: the API content plus whether
+// the body was cut at max_chars.
+type contentResult struct {
+	prismclient.Content
+	Truncated bool `json:"truncated"`
+}
+
+func (t *tools) getContent(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	id, errResult := requireUUID(req, "candidate_id")
+	if errResult != nil {
+		return errResult, nil
+	}
+	maxChars := req.GetInt("max_chars", defaultContentMaxLen)
+	if maxChars < 0 {
+		return mcp.NewToolResultError("max_chars must not be negative"), nil
+	}
+
+	c, err := t.api.forCall(ctx).GetContent(ctx, id)
+	if err != nil {
+		if prismclient.IsNotFound(err) {
+			return mcp.NewToolResultError(fmt.Sprintf(
+				"candidate %s has not been fetched yet; call request_page_fetch with it and poll get_fetch_progress", id)), nil
+		}
+		return t.apiFailure(ctx, "get_content", err), nil
+	}
+	out := contentResult{Content: c}
+	if r := []rune(c.Content); maxChars > 0 && len(r) > maxChars {
+		out.Content.Content = string(r[:maxChars])
+		out.Truncated = true
+	}
+	return structured(out)
+}
+
+func (t *tools) listSources(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	out, err := t.api.forCall(ctx).ListSources(ctx, strings.ToUpper(strings.TrimSpace(req.GetString("type", ""))))
+	if err != nil {
+		return t.apiFailure(ctx, "list_sources", err), nil
+	}
+	return structured(out)
+}
+
+// apiFailure turns an API error into a tool error the model can act on.
+// Client errors carry the server's message; anything else is logged and
+// reported generically.
+func (t *tools) apiFailure(ctx context.Context, tool string, err error) *mcp.CallToolResult {
+	var apiErr *prismclient.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode < http.StatusInternalServerError {
+		switch apiErr.StatusCode {
+		case http.StatusUnauthorized:
+			return mcp.NewToolResultError("prism rejected the API token: " + apiErr.Message)
+		case http.StatusForbidden:
+			return mcp.NewToolResultError("the API token lacks the scope for " + tool + ": " + apiErr.Message)
+		}
+		return mcp.NewToolResultError(apiErr.Message)
+	}
+	t.logger.ErrorContext(ctx, "prism api call failed", slog.String("tool", tool), slog.Any("error", err))
+	return mcp.NewToolResultError("prism api is unavailable; try again later")
+}
+
+// structured returns v as structured content with its JSON as the text
+// block, for clients that only read text.
+func structured(v any) (*mcp.CallToolResult, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("encode result: %w", err)
+	}
+	return mcp.NewToolResultStructured(v, string(b)), nil
+}
+
+func requireUUID(req mcp.CallToolRequest, name string) (uuid.UUID, *mcp.CallToolResult) {
+	s, err := req.RequireString(name)
+	if err != nil {
+		return uuid.Nil, mcp.NewToolResultError(err.Error())
+	}
+	id, err := uuid.Parse(strings.TrimSpace(s))
+	if err != nil {
+		return uuid.Nil, mcp.NewToolResultError(fmt.Sprintf("invalid %s %q", name, s))
+	}
+	return id, nil
+}
+
+// parseDay accepts RFC3339 or a calendar day; empty yields the zero time.
+// A bare day is widened to its first second, or its last when endOfDay is
+// set, so "until 2026-05-01" includes that day.
+func parseDay(s string, endOfDay bool) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected RFC3339 or YYYY-MM-DD, got %q", s)
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Second)
+	}
+	return t, nil
+}
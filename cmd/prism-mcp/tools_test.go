@@ -0,0 +1,352 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/http/api"
+	"github.com/ChiaYuChang/prism/pkg/prismclient"
+	"github.com/google/uuid"
+	mcpclient "github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAPI stands in for the API server and records what the tools sent.
+type fakeAPI struct {
+	mu     sync.Mutex
+	tokens []string
+	query  url.Values
+	body   []byte
+	status int // non-zero overrides every response
+}
+
+func (f *fakeAPI) handler(t *testing.T) http.Handler {
+	t.Helper()
+	candidateID := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	fetchID := uuid.MustParse("22222222-2222-2222-2222-222222222222")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/candidates", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		f.query = r.URL.Query()
+		f.mu.Unlock()
+		writeTestJSON(w, api.ListCandidatesResponse{
+			Items:      []api.Candidate{{ID: candidateID, SourceAbbr: "dpp", Title: "Press release", URL: "https://dpp.example/1"}},
+			Count:      1,
+			NextCursor: "next",
+		})
+	})
+	mux.HandleFunc("POST /api/v1/page_fetch", func(w http.ResponseWriter, r *http.Request) {
+		var req api.PageFetchRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		f.mu.Lock()
+		f.body, _ = json.Marshal(req)
+		f.mu.Unlock()
+		writeTestJSON(w, api.PageFetchResponse{FetchID: fetchID})
+	})
+	mux.HandleFunc("GET /api/v1/fetches/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") != fetchID.String() {
+			w.WriteHeader(http.StatusNotFound)
+			writeTestJSON(w, api.ErrorResponse{Error: "fetch not found"})
+			return
+		}
+		writeTestJSON(w, api.FetchProgressResponse{FetchID: fetchID, Total: 1,
+			Completed: api.FetchProgressStatus{Count: 1, CandidateIDs: []uuid.UUID{candidateID}}, Terminal: true})
+	})
+	mux.HandleFunc("GET /api/v1/contents/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") != candidateID.String() {
+			w.WriteHeader(http.StatusNotFound)
+			writeTestJSON(w, api.ErrorResponse{Error: "content not found"})
+			return
+		}
+		writeTestJSON(w, api.Content{CandidateID: candidateID, Title: "Press release", Content: "民主進步黨今日發布新聞稿",
+			PublishedAt: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)})
+	})
+	mux.HandleFunc("GET /api/v1/sources", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		f.query = r.URL.Query()
+		f.mu.Unlock()
+		writeTestJSON(w, api.ListSourcesResponse{Items: []api.Source{{Abbr: "dpp", Name: "DPP", Type: "PARTY"}}, Count: 1})
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		f.tokens = append(f.tokens, r.Header.Get("X-PRISM-TOKEN"))
+		status := f.status
+		f.mu.Unlock()
+		if status != 0 {
+			w.WriteHeader(status)
+			writeTestJSON(w, api.ErrorResponse{Error: http.StatusText(status)})
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func writeTestJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func newTestMCP(t *testing.T) (*fakeAPI, *server.MCPServer) {
+	t.Helper()
+	fake := &fakeAPI{}
+	srv := httptest.NewServer(fake.handler(t))
+	t.Cleanup(srv.Close)
+	// No retries, so the 503 case answers at once.
+	api, err := newAPIClients(srv.URL, "config-token",
+		prismclient.WithTimeout(5*time.Second), prismclient.WithRetryPolicy(prismclient.RetryPolicy{}))
+	require.NoError(t, err)
+	return fake, newServer(api, slog.New(slog.DiscardHandler))
+}
+
+func startClient(t *testing.T, c *mcpclient.Client) {
+	t.Helper()
+	ctx := context.Background()
+	require.NoError(t, c.Start(ctx))
+	t.Cleanup(func() { _ = c.Close() })
+	req := mcp.InitializeRequest{}
+	req.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	req.Params.ClientInfo = mcp.Implementation{Name: "test", Version: "0"}
+	_, err := c.Initialize(ctx, req)
+	require.NoError(t, err)
+}
+
+func newInProcess(t *testing.T, s *server.MCPServer) *mcpclient.Client {
+	t.Helper()
+	c, err := mcpclient.NewInProcessClient(s)
+	require.NoError(t, err)
+	startClient(t, c)
+	return c
+}
+
+func call(t *testing.T, c *mcpclient.Client, name string, args map[string]any) *mcp.CallToolResult {
+	t.Helper()
+	req := mcp.CallToolRequest{}
+	req.Params.Name = name
+	req.Params.Arguments = args
+	res, err := c.CallTool(context.Background(), req)
+	require.NoError(t, err)
+	return res
+}
+
+func resultText(t *testing.T, res *mcp.CallToolResult) string {
+	t.Helper()
+	require.NotEmpty(t, res.Content)
+	text, ok := res.Content[0].(mcp.TextContent)
+	require.True(t, ok)
+	return text.Text
+}
+
+func TestListTools(t *testing.T) {
+	_, s := newTestMCP(t)
+	c := newInProcess(t, s)
+
+	res, err := c.ListTools(context.Background(), mcp.ListToolsRequest{})
+	require.NoError(t, err)
+	var names []string
+	for _, tool := range res.Tools {
+		names = append(names, tool.Name)
+	}
+	assert.ElementsMatch(t, []string{
+		"search_candidates", "request_page_fetch", "get_fetch_progress", "get_content", "list_sources",
+	}, names)
+}
+
+func TestSearchCandidates(t *testing.T) {
+	fake, s := newTestMCP(t)
+	c := newInProcess(t, s)
+
+	res := call(t, c, "search_candidates", map[string]any{
+		"q": "能源", "source_abbr": "dpp", "since": "2026-05-01", "until": "2026-05-31", "limit": 5, "cursor": "abc",
+	})
+	require.False(t, res.IsError, resultText(t, res))
+
+	var out prismclient.CandidatePage
+	require.NoError(t, json.Unmarshal([]byte(resultText(t, res)), &out))
+	assert.Len(t, out.Items, 1)
+	assert.Equal(t, "next", out.NextCursor)
+
+	assert.Equal(t, "能源", fake.query.Get("q"))
+	assert.Equal(t, "dpp", fake.query.Get("source_abbr"))
+	assert.Equal(t, "2026-05-01T00:00:00Z", fake.query.Get("since"))
+	assert.Equal(t, "2026-05-31T23:59:59Z", fake.query.Get("until"))
+	assert.Equal(t, "5", fake.query.Get("limit"))
+	assert.Equal(t, "abc", fake.query.Get("cursor"))
+	assert.Equal(t, []string{"config-token"}, fake.tokens)
+
+	res = call(t, c, "search_candidates", map[string]any{"since": "last week"})
+	assert.True(t, res.IsError)
+	res = call(t, c, "search_candidates", map[string]any{"limit": 500})
+	assert.True(t, res.IsError)
+}
+
+func TestRequestPageFetchAndProgress(t *testing.T) {
+	fake, s := newTestMCP(t)
+	c := newInProcess(t, s)
+
+	id := "11111111-1111-1111-1111-111111111111"
+	res := call(t, c, "request_page_fetch", map[string]any{"candidate_ids": []any{id}})
+	require.False(t, res.IsError, resultText(t, res))
+	assert.JSONEq(t, `{"candidate_ids":["`+id+`"]}`, string(fake.body))
+
+	var submitted prismclient.PageFetchResponse
+	require.NoError(t, json.Unmarshal([]byte(resultText(t, res)), &submitted))
+
+	res = call(t, c, "get_fetch_progress", map[string]any{"fetch_id": submitted.FetchID.String()})
+	require.False(t, res.IsError, resultText(t, res))
+	var progress prismclient.FetchProgress
+	require.NoError(t, json.Unmarshal([]byte(resultText(t, res)), &progress))
+	assert.True(t, progress.Terminal)
+
+	res = call(t, c, "get_fetch_progress", map[string]any{"fetch_id": uuid.NewString()})
+	assert.True(t, res.IsError)
+	assert.Contains(t, resultText(t, res), "not found")
+
+	res = call(t, c, "request_page_fetch", map[string]any{"candidate_ids": []any{"nope"}})
+	assert.True(t, res.IsError)
+	res = call(t, c, "request_page_fetch", map[string]any{"candidate_ids": []any{}})
+	assert.True(t, res.IsError)
+}
+
+func TestGetContent(t *testing.T) {
+	_, s := newTestMCP(t)
+	c := newInProcess(t, s)
+
+	id := "11111111-1111-1111-1111-111111111111"
+	res := call(t, c, "get_content", map[string]any{"candidate_id": id, "max_chars": 5})
+	require.False(t, res.IsError, resultText(t, res))
+	var out contentResult
+	require.NoError(t, json.Unmarshal([]byte(resultText(t, res)), &out))
+	assert.Equal(t, "民主進步黨", out.Content.Content, "truncation counts characters, not bytes")
+	assert.True(t, out.Truncated)
+
+	res = call(t, c, "get_content", map[string]any{"candidate_id": id, "max_chars": 0})
+	require.NoError(t, json.Unmarshal([]byte(resultText(t, res)), &out))
+	assert.Equal(t, "民主進步黨今日發布新聞稿", out.Content.Content)
+	assert.False(t, out.Truncated)
+
+	res = call(t, c, "get_content", map[string]any{"candidate_id": uuid.NewString()})
+	assert.True(t, res.IsError)
+	assert.Contains(t, resultText(t, res), "request_page_fetch")
+}
+
+func TestListSources(t *testing.T) {
+	fake, s := newTestMCP(t)
+	c := newInProcess(t, s)
+
+	res := call(t, c, "list_sources", map[string]any{"type": "party"})
+	require.False(t, res.IsError, resultText(t, res))
+	assert.Equal(t, "PARTY", fake.query.Get("type"))
+
+	var out prismclient.SourceList
+	require.NoError(t, json.Unmarshal([]byte(resultText(t, res)), &out))
+	assert.Equal(t, "dpp", out.Items[0].Abbr)
+}
+
+func TestAPIFailures(t *testing.T) {
+	fake, s := newTestMCP(t)
+	c := newInProcess(t, s)
+
+	fake.status = http.StatusUnauthorized
+	res := call(t, c, "list_sources", nil)
+	assert.True(t, res.IsError)
+	assert.Contains(t, resultText(t, res), "token")
+
+	fake.status = http.StatusServiceUnavailable
+	res = call(t, c, "list_sources", nil)
+	assert.True(t, res.IsError)
+	assert.Contains(t, resultText(t, res), "unavailable")
+}
+
+func TestHTTPTransportForwardsCallerToken(t *testing.T) {
+	fake, s := newTestMCP(t)
+	mcpSrv := httptest.NewServer(httpHandler(s))
+	t.Cleanup(mcpSrv.Close)
+
+	for _, tc := range []struct {
+		name    string
+		headers map[string]string
+		want    string
+	}{
+		{"prism header", map[string]string{"X-PRISM-TOKEN": "caller-token"}, "caller-token"},
+		{"bearer", map[string]string{"Authorization": "Bearer bearer-token"}, "bearer-token"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := mcpclient.NewStreamableHttpClient(mcpSrv.URL+mcpEndpoint, transport.WithHTTPHeaders(tc.headers))
+			require.NoError(t, err)
+			startClient(t, c)
+
+			fake.mu.Lock()
+			fake.tokens = nil
+			fake.mu.Unlock()
+			res := call(t, c, "list_sources", nil)
+			require.False(t, res.IsError, resultText(t, res))
+			assert.Equal(t, []string{tc.want}, fake.tokens)
+		})
+	}
+}
+
+func TestHTTPTransportRejectsCallsWithoutToken(t *testing.T) {
+	// The tools' client holds a configured token; an anonymous HTTP caller
+	// must not get to use it.
+	fake, s := newTestMCP(t)
+	mcpSrv := httptest.NewServer(httpHandler(s))
+	t.Cleanup(mcpSrv.Close)
+
+	for _, tc := range []struct {
+		name   string
+		header http.Header
+	}{
+		{"none", nil},
+		{"empty prism header", http.Header{"X-Prism-Token": {" "}}},
+		{"basic auth", http.Header{"Authorization": {"Basic dXNlcjpwYXNz"}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			body := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"list_sources","arguments":{}}}`
+			req, err := http.NewRequest(http.MethodPost, mcpSrv.URL+mcpEndpoint, strings.NewReader(body))
+			require.NoError(t, err)
+			for k, v := range tc.header {
+				req.Header[k] = v
+			}
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Accept", "application/json, text/event-stream")
+
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+			assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		})
+	}
+
+	c, err := mcpclient.NewStreamableHttpClient(mcpSrv.URL + mcpEndpoint)
+	require.NoError(t, err)
+	require.NoError(t, c.Start(context.Background()))
+	t.Cleanup(func() { _ = c.Close() })
+	req := mcp.InitializeRequest{}
+	req.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	req.Params.ClientInfo = mcp.Implementation{Name: "test", Version: "0"}
+	_, err = c.Initialize(context.Background(), req)
+	require.Error(t, err)
+
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	assert.Empty(t, fake.tokens, "no upstream request may be made with the configured token")
+
+	resp, err := http.Get(mcpSrv.URL + "/healthz")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "healthz stays open")
+}
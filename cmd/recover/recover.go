@@ -92,9 +92,12 @@ func runRecover(ctx context.Context, arch archiver.Archiver, pipeline repo.Pipel
 			continue
 		}
 
-		contentType := "ARTICLE"
-		if m.SourceType == repo.SourceTypeParty {
-			contentType = "PARTY_RELEASE"
+		contentType := repo.ContentTypeArticle
+		switch m.SourceType {
+		case repo.SourceTypeParty:
+			contentType = repo.ContentTypePartyRelease
+		case repo.SourceTypeGovernment:
+			contentType = repo.ContentTypeGovernmentRelease
 		}
 
 		fetchedAt := time.Now()
@@ -31,7 +31,8 @@ type Config struct {
 	LockKey string `mapstructure:"lock-key"`
 
 	// MediaQuota is the number of PAGE_FETCH+MEDIA slots reserved per tick.
-	// When > 0, the tick uses a two-step claim: MEDIA first, PARTY fills the rest.
+	// When > 0, the tick uses a two-step claim: MEDIA first, seed sources
+	// (PARTY, GOVERNMENT) fill the rest.
 	// Only meaningful when kinds includes PAGE_FETCH.
 	MediaQuota int `mapstructure:"media-quota" validate:"min=0"`
 
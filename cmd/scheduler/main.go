@@ -140,7 +140,8 @@ func newScheduler(logger *slog.Logger, tracer trace.Tracer, metrics *schedulerMe
 // release excess, and return the approved dispatch list.
 //
 // When MediaQuota > 0 and PAGE_FETCH is in Kinds, two sequential ClaimTasks
-// calls are made: MEDIA first (user-waiting), PARTY second (fills remainder).
+// calls are made: MEDIA first (user-waiting), seed sources (PARTY,
+// GOVERNMENT) second (fills remainder).
 // Otherwise a single call claims all kinds without source_type filtering.
 func (s *Scheduler) RunTick(ctx context.Context, cfg *Config) []repo.Task {
 	started := time.Now()
@@ -200,7 +201,7 @@ func (s *Scheduler) runSimpleTick(ctx context.Context, n, buf int, kinds []strin
 
 // runPriorityTick implements the two-step claim:
 //  1. Claim up to (mdQuota + buf) PAGE_FETCH+MEDIA tasks.
-//  2. Claim up to (n - mediaActual + buf) tasks for all kinds + PARTY source.
+//  2. Claim up to (n - mediaActual + buf) tasks for all kinds + seed sources.
 //
 // Rate limiting is applied to both groups; excess tasks are released.
 func (s *Scheduler) runPriorityTick(ctx context.Context, n, mdQuota, buf int, kinds []string) []repo.Task {
@@ -221,9 +222,9 @@ func (s *Scheduler) runPriorityTick(ctx context.Context, n, mdQuota, buf int, ki
 	mdPass, mdRelease := applyRateLimit(mdClaimed, s.rl, mdQuota)
 	s.ReleaseAll(ctx, mdRelease)
 
-	// Step 2: remaining capacity filled by PARTY + background kinds.
+	// Step 2: remaining capacity filled by seed sources + background kinds.
 	remaining := n - len(mdPass)
-	bgClaimed, err := s.scheduler.ClaimTasks(ctx, int32(remaining+buf), kinds, []string{repo.SourceTypeParty})
+	bgClaimed, err := s.scheduler.ClaimTasks(ctx, int32(remaining+buf), kinds, repo.SeedSourceTypes)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "claim background tasks")
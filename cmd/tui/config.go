@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+type Config struct {
+	APIURL            string        `mapstructure:"api-url"             validate:"required,http_url"`
+	Token             string        `mapstructure:"token"`
+	TokenFile         string        `mapstructure:"token-file"`
+	PageSize          int32         `mapstructure:"page-size"           validate:"required,min=1,max=200"`
+	FetchPollInterval time.Duration `mapstructure:"fetch-poll-interval" validate:"required,min=1s"`
+	Timeout           time.Duration `mapstructure:"timeout"             validate:"required,min=1s"`
+}
+
+func LoadConfig(args []string) (*Config, error) {
+	v := viper.New()
+	v.SetEnvPrefix("PRISM_TUI")
+	v.SetEnvKeyReplacer(strings.NewReplacer("-", "_", ".", "_"))
+	v.AutomaticEnv()
+	// Token env names follow docs/tui-client-contract.md.
+	_ = v.BindEnv("token", "PRISM_TUI_AUTH_TOKEN")
+	_ = v.BindEnv("token-file", "PRISM_TUI_AUTH_TOKEN_FILE")
+
+	fs := pflag.NewFlagSet("tui", pflag.ContinueOnError)
+	fs.String("api-url", "http://localhost:8090", "Base URL of the Prism API server")
+	fs.String("token", "", "API token sent as X-PRISM-TOKEN")
+	fs.String("token-file", "", "File holding the API token (used when --token is empty)")
+	fs.Int32("page-size", 25, "Candidates per page in the list view")
+	fs.Duration("fetch-poll-interval", 5*time.Second, "How often the fetch monitor polls GET /fetches/{id}")
+	fs.Duration("timeout", 10*time.Second, "HTTP timeout for one API request")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, fmt.Errorf("failed to parse flags: %w", err)
+	}
+	if err := v.BindPFlags(fs); err != nil {
+		return nil, fmt.Errorf("failed to bind flags: %w", err)
+	}
+
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+	cfg.APIURL = strings.TrimRight(cfg.APIURL, "/")
+	if cfg.Token == "" && cfg.TokenFile != "" {
+		b, err := os.ReadFile(cfg.TokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read token file: %w", err)
+		}
+		cfg.Token = strings.TrimSpace(string(b))
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(&cfg); err != nil {
+		return nil, fmt.Errorf("config validation failed: %v", err)
+	}
+	return &cfg, nil
+}
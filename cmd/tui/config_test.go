@@ -0,0 +1,66 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig_Defaults(t *testing.T) {
+	cfg, err := LoadConfig([]string{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "http://localhost:8090", cfg.APIURL)
+	assert.Empty(t, cfg.Token)
+	assert.Equal(t, int32(25), cfg.PageSize)
+	assert.Equal(t, 5*time.Second, cfg.FetchPollInterval)
+	assert.Equal(t, 10*time.Second, cfg.Timeout)
+}
+
+func TestLoadConfig_FlagsAndEnv(t *testing.T) {
+	t.Setenv("PRISM_TUI_API_URL", "http://prism.internal:8090/")
+	t.Setenv("PRISM_TUI_AUTH_TOKEN", "env-token")
+
+	cfg, err := LoadConfig([]string{"--page-size=50"})
+	require.NoError(t, err)
+	assert.Equal(t, "http://prism.internal:8090", cfg.APIURL)
+	assert.Equal(t, "env-token", cfg.Token)
+	assert.Equal(t, int32(50), cfg.PageSize)
+
+	cfg, err = LoadConfig([]string{"--api-url=http://other:9000", "--token=flag-token"})
+	require.NoError(t, err)
+	assert.Equal(t, "http://other:9000", cfg.APIURL)
+	assert.Equal(t, "flag-token", cfg.Token)
+}
+
+func TestLoadConfig_TokenFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("file-token\n"), 0o600))
+
+	cfg, err := LoadConfig([]string{"--token-file", path})
+	require.NoError(t, err)
+	assert.Equal(t, "file-token", cfg.Token)
+
+	cfg, err = LoadConfig([]string{"--token-file", path, "--token", "flag-token"})
+	require.NoError(t, err)
+	assert.Equal(t, "flag-token", cfg.Token, "explicit token wins over the file")
+
+	_, err = LoadConfig([]string{"--token-file", filepath.Join(t.TempDir(), "missing")})
+	require.Error(t, err)
+}
+
+func TestLoadConfig_Invalid(t *testing.T) {
+	for _, args := range [][]string{
+		{"--api-url=not a url"},
+		{"--page-size=0"},
+		{"--page-size=201"},
+		{"--fetch-poll-interval=10ms"},
+	} {
+		_, err := LoadConfig(args)
+		assert.Error(t, err, "%v", args)
+	}
+}
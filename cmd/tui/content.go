@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ChiaYuChang/prism/pkg/prismclient"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/google/uuid"
+)
+
+// openContent switches to the content view for a candidate. Bumping
+// contentSeq orphans the retries of any previous candidate.
+func (m model) openContent(id uuid.UUID) (tea.Model, tea.Cmd) {
+	m.view = viewContent
+	m.contentID = id
+	m.contentSeq++
+	m.content = nil
+	m.contentWaiting = false
+	m.contentAttempt = 0
+	m.err = nil
+	return m, m.loadContent(id, m.contentSeq)
+}
+
+// onContent handles GET /contents/{candidate_id}. A 404 means the page has
+// not been fetched (yet), so the view waits and retries with backoff.
+func (m model) onContent(msg contentMsg) (tea.Model, tea.Cmd) {
+	if m.view != viewContent || msg.id != m.contentID || msg.seq != m.contentSeq {
+		return m, nil
+	}
+	if prismclient.IsNotFound(msg.err) {
+		m.contentWaiting = true
+		m.contentAttempt++
+		id, seq := msg.id, msg.seq
+		return m, tea.Tick(contentBackoff(m.contentAttempt), func(time.Time) tea.Msg {
+			return contentTickMsg{id: id, seq: seq}
+		})
+	}
+	m.contentWaiting = false
+	if msg.err != nil {
+		m.err = msg.err
+		return m, nil
+	}
+	m.err = nil
+	m.content = &msg.content
+	m.renderContent()
+	m.viewport.GotoTop()
+	return m, nil
+}
+
+// contentBackoff doubles from contentRetryBase per attempt, capped at
+// contentRetryMax.
+func contentBackoff(attempt int) time.Duration {
+	d := contentRetryBase
+	for i := 1; i < attempt && d < contentRetryMax; i++ {
+		d *= 2
+	}
+	return min(d, contentRetryMax)
+}
+
+// renderContent re-wraps the article body to the current width.
+func (m *model) renderContent() {
+	if m.content == nil {
+		return
+	}
+	style := lipgloss.NewStyle().Width(max(m.width, 20))
+	m.viewport.SetContent(style.Render(m.content.Content))
+}
+
+func (m model) updateContentKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "r":
+		return m.openContent(m.contentID)
+	case "esc":
+		m.contentSeq++
+		m.view = viewList
+		m.err = nil
+		return m, nil
+	case "q":
+		return m, tea.Quit
+	}
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+func (m model) contentView() string {
+	var b strings.Builder
+	switch {
+	case m.content != nil:
+		c := m.content
+		b.WriteString(titleStyle.Render(truncate(c.Title, m.width)))
+		b.WriteString("\n")
+		meta := fmt.Sprintf("%s · %s · %s", c.SourceAbbr, c.PublishedAt.Format(time.DateOnly), c.URL)
+		if c.Author != nil {
+			meta = *c.Author + " · " + meta
+		}
+		b.WriteString(dimStyle.Render(truncate(meta, m.width)))
+		b.WriteString("\n\n")
+		b.WriteString(m.viewport.View())
+		b.WriteString("\n")
+		b.WriteString(dimStyle.Render(fmt.Sprintf("%3.f%% · ↑/↓ scroll · r reload · esc back · q quit", m.viewport.ScrollPercent()*100)))
+		return b.String()
+	case m.contentWaiting:
+		b.WriteString(titleStyle.Render("Waiting for content"))
+		b.WriteString("\n\n")
+		b.WriteString(fmt.Sprintf("Candidate %s has not been fetched yet; retrying (attempt %d).\n",
+			m.contentID, m.contentAttempt))
+		b.WriteString(dimStyle.Render("Submit it from the list with space + f if no fetch is pending."))
+		b.WriteString("\n")
+	case m.err != nil:
+		b.WriteString(errorStyle.Render(m.err.Error()) + "\n")
+	default:
+		b.WriteString(dimStyle.Render("loading…") + "\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(dimStyle.Render("r retry · esc back · q quit"))
+	return b.String()
+}
@@ -0,0 +1,271 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ChiaYuChang/prism/pkg/prismclient"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+)
+
+// maxSubmitSelection mirrors the POST /page_fetch batch limit so an
+// oversized selection is rejected before the round trip.
+const maxSubmitSelection = 100
+
+var prompts = map[field]string{
+	fieldQuery:   "q: ",
+	fieldSource:  "source_abbr: ",
+	fieldSince:   "since (YYYY-MM-DD or RFC3339): ",
+	fieldUntil:   "until (YYYY-MM-DD or RFC3339): ",
+	fieldFetchID: "fetch id: ",
+}
+
+func (m model) updateListKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.editing != fieldNone {
+		return m.updatePrompt(msg)
+	}
+	switch msg.String() {
+	case "q":
+		return m, tea.Quit
+	case "j", "down":
+		if m.row < len(m.rows)-1 {
+			m.row++
+		}
+	case "k", "up":
+		if m.row > 0 {
+			m.row--
+		}
+	case " ", "x":
+		if c, ok := m.current(); ok {
+			if i := slices.Index(m.selected, c.ID); i >= 0 {
+				m.selected = slices.Delete(m.selected, i, i+1)
+			} else {
+				m.selected = append(m.selected, c.ID)
+			}
+		}
+	case "n":
+		if m.next != "" && !m.loading {
+			return m.reload(append(slices.Clone(m.cursors), m.next))
+		}
+	case "p":
+		if len(m.cursors) > 1 && !m.loading {
+			return m.reload(m.cursors[:len(m.cursors)-1])
+		}
+	case "r":
+		return m.reload(m.cursors)
+	case "/":
+		return m.prompt(fieldQuery, m.filter.Q)
+	case "s":
+		return m.prompt(fieldSource, m.filter.SourceAbbr)
+	case "d":
+		return m.prompt(fieldSince, formatDate(m.filter.Since))
+	case "u":
+		return m.prompt(fieldUntil, formatDate(m.filter.Until))
+	case "b":
+		return m.prompt(fieldFetchID, "")
+	case "c":
+		m.filter = prismclient.ListCandidatesParams{}
+		return m.reload([]string{""})
+	case "enter":
+		if c, ok := m.current(); ok {
+			return m.openContent(c.ID)
+		}
+	case "f":
+		switch {
+		case len(m.selected) == 0:
+			m.err = errors.New("nothing selected: press space to select candidates")
+		case len(m.selected) > maxSubmitSelection:
+			m.err = fmt.Errorf("%d selected: at most %d candidates per fetch", len(m.selected), maxSubmitSelection)
+		default:
+			m.err, m.loading = nil, true
+			return m, m.submitSelection(slices.Clone(m.selected))
+		}
+	}
+	return m, nil
+}
+
+// reload requests the page at the top of cursors. Replies to earlier
+// requests are dropped by sequence number.
+func (m model) reload(cursors []string) (tea.Model, tea.Cmd) {
+	m.listSeq++
+	m.loading = true
+	return m, m.loadCandidates(m.listSeq, cursors)
+}
+
+func (m model) prompt(f field, value string) (tea.Model, tea.Cmd) {
+	m.editing = f
+	m.input.Prompt = prompts[f]
+	m.input.SetValue(value)
+	m.input.CursorEnd()
+	return m, m.input.Focus()
+}
+
+func (m model) updatePrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.editing = fieldNone
+		m.input.Blur()
+		return m, nil
+	case tea.KeyEnter:
+		value := strings.TrimSpace(m.input.Value())
+		f := m.editing
+		m.editing = fieldNone
+		m.input.Blur()
+		return m.applyPrompt(f, value)
+	}
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// applyPrompt commits a prompt value. Filter changes restart paging from
+// the first page because cursors are only valid for the filters that
+// produced them.
+func (m model) applyPrompt(f field, value string) (tea.Model, tea.Cmd) {
+	switch f {
+	case fieldQuery:
+		m.filter.Q = value
+	case fieldSource:
+		m.filter.SourceAbbr = value
+	case fieldSince, fieldUntil:
+		ts, err := parseDate(value)
+		if err != nil {
+			m.err = err
+			return m, nil
+		}
+		if f == fieldSince {
+			m.filter.Since = ts
+		} else {
+			m.filter.Until = ts
+		}
+	case fieldFetchID:
+		id, err := uuid.Parse(value)
+		if err != nil {
+			m.err = fmt.Errorf("invalid fetch id %q", value)
+			return m, nil
+		}
+		return m.openMonitor(id)
+	default:
+		return m, nil
+	}
+	m.err = nil
+	return m.reload([]string{""})
+}
+
+// parseDate accepts a calendar date (midnight UTC) or an RFC3339 timestamp
+// and returns it in the RFC3339 form the API expects. Empty clears.
+func parseDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q: use YYYY-MM-DD or RFC3339", value)
+}
+
+// formatDate renders a date filter for the prompt and the filter summary;
+// an unset filter is empty.
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func (m model) onCandidates(msg candidatesMsg) (tea.Model, tea.Cmd) {
+	if msg.seq != m.listSeq {
+		return m, nil
+	}
+	m.loading = false
+	if msg.err != nil {
+		m.err = msg.err
+		if prismclient.IsStatus(msg.err, http.StatusUnauthorized) {
+			m.err = fmt.Errorf("%w (set --token or PRISM_TUI_AUTH_TOKEN)", msg.err)
+		}
+		return m, nil
+	}
+	m.err = nil
+	m.cursors = msg.cursors
+	m.rows = msg.resp.Items
+	m.next = msg.resp.NextCursor
+	m.row = min(m.row, max(len(m.rows)-1, 0))
+	return m, nil
+}
+
+func (m model) current() (prismclient.Candidate, bool) {
+	if m.row < 0 || m.row >= len(m.rows) {
+		return prismclient.Candidate{}, false
+	}
+	return m.rows[m.row], true
+}
+
+func (m model) listView() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Prism candidates"))
+	b.WriteString(dimStyle.Render(fmt.Sprintf("  page %d · %d selected", len(m.cursors), len(m.selected))))
+	if m.loading {
+		b.WriteString(dimStyle.Render(" · loading…"))
+	}
+	b.WriteString("\n")
+	b.WriteString(dimStyle.Render(m.filterSummary()))
+	b.WriteString("\n\n")
+
+	if len(m.rows) == 0 && !m.loading {
+		b.WriteString(dimStyle.Render("no candidates match"))
+		b.WriteString("\n")
+	}
+	for i, c := range m.rows {
+		mark := "[ ]"
+		if slices.Contains(m.selected, c.ID) {
+			mark = selectedStyle.Render("[x]")
+		}
+		date := "          "
+		if c.PublishedAt != nil {
+			date = c.PublishedAt.Format(time.DateOnly)
+		}
+		status := ""
+		if s, ok := m.statuses[c.ID]; ok {
+			status = " " + renderStatus(s)
+		}
+		line := fmt.Sprintf("%s %s %-6s %s", mark, date, truncate(c.SourceAbbr, 6),
+			truncate(c.Title, m.width-len(date)-20))
+		if i == m.row {
+			line = cursorStyle.Render(line)
+		}
+		b.WriteString(line + status + "\n")
+	}
+
+	b.WriteString("\n")
+	if m.editing != fieldNone {
+		b.WriteString(m.input.View() + "\n")
+	}
+	if m.err != nil {
+		b.WriteString(errorStyle.Render(m.err.Error()) + "\n")
+	}
+	b.WriteString(dimStyle.Render("space select · f fetch · enter content · n/p page · / q · s source · d since · u until · c clear · b monitor fetch · r reload · q quit"))
+	return b.String()
+}
+
+func (m model) filterSummary() string {
+	var parts []string
+	for _, kv := range [][2]string{
+		{"q", m.filter.Q}, {"source", m.filter.SourceAbbr}, {"since", formatDate(m.filter.Since)}, {"until", formatDate(m.filter.Until)},
+	} {
+		if kv[1] != "" {
+			parts = append(parts, kv[0]+"="+kv[1])
+		}
+	}
+	if len(parts) == 0 {
+		return "no filters"
+	}
+	return strings.Join(parts, " ")
+}
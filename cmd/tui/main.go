@@ -0,0 +1,36 @@
+// Command tui is the Prism operator terminal UI. It is an API client only:
+// it browses candidates, submits page fetches, watches fetch progress and
+// reads stored content through /api/v1 with pkg/prismclient.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ChiaYuChang/prism/pkg/prismclient"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/pflag"
+)
+
+func main() {
+	cfg, err := LoadConfig(os.Args[1:])
+	if err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			os.Exit(0)
+		}
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	c, err := prismclient.New(cfg.APIURL, prismclient.WithToken(cfg.Token), prismclient.WithTimeout(cfg.Timeout))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	p := tea.NewProgram(newModel(c, int(cfg.PageSize), cfg.FetchPollInterval), tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/ChiaYuChang/prism/pkg/prismclient"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+)
+
+type view int
+
+const (
+	viewList view = iota
+	viewSubmit
+	viewMonitor
+	viewContent
+)
+
+// field is what the single-line prompt of the list view is editing.
+type field int
+
+const (
+	fieldNone field = iota
+	fieldQuery
+	fieldSource
+	fieldSince
+	fieldUntil
+	fieldFetchID
+)
+
+const (
+	defaultWidth  = 100
+	defaultHeight = 30
+
+	// Content polling backs off from contentRetryBase up to contentRetryMax
+	// while the collector has not stored the article yet.
+	contentRetryBase = time.Second
+	contentRetryMax  = 30 * time.Second
+)
+
+// Messages. Every asynchronous result carries the request it answers
+// (sequence number, plus the fetch or candidate id) so a reply that arrives
+// after the user moved on is dropped instead of overwriting newer state.
+type (
+	candidatesMsg struct {
+		seq     int
+		cursors []string
+		resp    prismclient.CandidatePage
+		err     error
+	}
+	submitMsg struct {
+		resp prismclient.PageFetchResponse
+		err  error
+	}
+	fetchMsg struct {
+		id   uuid.UUID
+		seq  int
+		resp prismclient.FetchProgress
+		err  error
+		at   time.Time
+	}
+	fetchTickMsg struct {
+		id  uuid.UUID
+		seq int
+	}
+	contentMsg struct {
+		id      uuid.UUID
+		seq     int
+		content prismclient.Content
+		err     error
+	}
+	contentTickMsg struct {
+		id  uuid.UUID
+		seq int
+	}
+)
+
+// model is the root Bubble Tea model. The four views share it; view
+// selects which part of the state is rendered and which keys apply.
+type model struct {
+	client       *prismclient.Client
+	pageSize     int
+	pollInterval time.Duration
+
+	view          view
+	width, height int
+	err           error
+
+	// List view.
+	filter   prismclient.ListCandidatesParams
+	rows     []prismclient.Candidate
+	row      int
+	selected []uuid.UUID          // in selection order, across pages
+	statuses map[uuid.UUID]string // last page_fetch status per candidate
+	cursors  []string             // cursor of each page visited; last is current
+	next     string
+	listSeq  int
+	loading  bool
+	editing  field
+	input    textinput.Model
+
+	// Submit modal.
+	submit *prismclient.PageFetchResponse
+
+	// Fetch monitor.
+	fetchID     uuid.UUID
+	fetchSeq    int
+	progress    *prismclient.FetchProgress
+	refreshedAt time.Time
+
+	// Content view.
+	contentID      uuid.UUID
+	contentSeq     int
+	content        *prismclient.Content
+	contentWaiting bool
+	contentAttempt int
+	viewport       viewport.Model
+}
+
+func newModel(c *prismclient.Client, pageSize int, pollInterval time.Duration) model {
+	in := textinput.New()
+	in.CharLimit = 256
+	return model{
+		client:       c,
+		pageSize:     pageSize,
+		pollInterval: pollInterval,
+		width:        defaultWidth,
+		height:       defaultHeight,
+		statuses:     map[uuid.UUID]string{},
+		cursors:      []string{""},
+		loading:      true,
+		input:        in,
+		viewport:     viewport.New(defaultWidth, defaultHeight-6),
+	}
+}
+
+func (m model) Init() tea.Cmd {
+	return m.loadCandidates(m.listSeq, m.cursors)
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.viewport.Width, m.viewport.Height = msg.Width, max(msg.Height-6, 1)
+		m.renderContent()
+		return m, nil
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			return m, tea.Quit
+		}
+		switch m.view {
+		case viewSubmit:
+			return m.updateSubmitKey(msg)
+		case viewMonitor:
+			return m.updateMonitorKey(msg)
+		case viewContent:
+			return m.updateContentKey(msg)
+		default:
+			return m.updateListKey(msg)
+		}
+	case candidatesMsg:
+		return m.onCandidates(msg)
+	case submitMsg:
+		return m.onSubmit(msg)
+	case fetchMsg:
+		return m.onFetch(msg)
+	case fetchTickMsg:
+		if m.view != viewMonitor || msg.id != m.fetchID || msg.seq != m.fetchSeq {
+			return m, nil
+		}
+		return m, m.loadFetch(msg.id, msg.seq)
+	case contentMsg:
+		return m.onContent(msg)
+	case contentTickMsg:
+		if m.view != viewContent || msg.id != m.contentID || msg.seq != m.contentSeq {
+			return m, nil
+		}
+		return m, m.loadContent(msg.id, msg.seq)
+	}
+	return m, nil
+}
+
+func (m model) View() string {
+	switch m.view {
+	case viewSubmit:
+		return m.submitView()
+	case viewMonitor:
+		return m.monitorView()
+	case viewContent:
+		return m.contentView()
+	default:
+		return m.listView()
+	}
+}
+
+// Commands. Each one performs a single API call with the client timeout.
+
+// loadCandidates loads the page at the top of cursors; on success the
+// list adopts cursors as its page history.
+func (m model) loadCandidates(seq int, cursors []string) tea.Cmd {
+	c, p := m.client, m.filter
+	p.Limit, p.Cursor = m.pageSize, cursors[len(cursors)-1]
+	return func() tea.Msg {
+		resp, err := c.ListCandidates(context.Background(), p)
+		return candidatesMsg{seq: seq, cursors: cursors, resp: resp, err: err}
+	}
+}
+
+func (m model) submitSelection(ids []uuid.UUID) tea.Cmd {
+	c := m.client
+	return func() tea.Msg {
+		resp, err := c.PageFetch(context.Background(), prismclient.PageFetchRequest{CandidateIDs: ids})
+		return submitMsg{resp: resp, err: err}
+	}
+}
+
+func (m model) loadFetch(id uuid.UUID, seq int) tea.Cmd {
+	c := m.client
+	return func() tea.Msg {
+		resp, err := c.GetFetch(context.Background(), id)
+		return fetchMsg{id: id, seq: seq, resp: resp, err: err, at: time.Now()}
+	}
+}
+
+func (m model) loadContent(id uuid.UUID, seq int) tea.Cmd {
+	c := m.client
+	return func() tea.Msg {
+		content, err := c.GetContent(context.Background(), id)
+		return contentMsg{id: id, seq: seq, content: content, err: err}
+	}
+}
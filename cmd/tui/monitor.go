@@ -0,0 +1,117 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ChiaYuChang/prism/pkg/prismclient"
+	"github.com/charmbracelet/bubbles/progress"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+)
+
+// openMonitor switches to the fetch monitor and starts polling id. Bumping
+// fetchSeq orphans the ticks of any previous monitor session.
+func (m model) openMonitor(id uuid.UUID) (tea.Model, tea.Cmd) {
+	m.view = viewMonitor
+	m.fetchID = id
+	m.fetchSeq++
+	m.progress = nil
+	m.err = nil
+	return m, m.loadFetch(id, m.fetchSeq)
+}
+
+func (m model) onFetch(msg fetchMsg) (tea.Model, tea.Cmd) {
+	if m.view != viewMonitor || msg.id != m.fetchID || msg.seq != m.fetchSeq {
+		return m, nil
+	}
+	if msg.err != nil {
+		m.err = msg.err
+		// A client error (unknown fetch, bad token, someone else's fetch)
+		// will not fix itself; only transport and 5xx errors keep polling.
+		if isClientError(msg.err) {
+			return m, nil
+		}
+		return m, m.scheduleFetch()
+	}
+	m.err = nil
+	m.progress = &msg.resp
+	m.refreshedAt = msg.at
+	if msg.resp.Terminal {
+		return m, nil
+	}
+	return m, m.scheduleFetch()
+}
+
+// isClientError reports a 4xx response, which retrying will not fix.
+func isClientError(err error) bool {
+	var apiErr *prismclient.APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode >= http.StatusBadRequest && apiErr.StatusCode < http.StatusInternalServerError
+}
+
+func (m model) scheduleFetch() tea.Cmd {
+	id, seq := m.fetchID, m.fetchSeq
+	return tea.Tick(m.pollInterval, func(time.Time) tea.Msg {
+		return fetchTickMsg{id: id, seq: seq}
+	})
+}
+
+func (m model) updateMonitorKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "r":
+		return m.openMonitor(m.fetchID)
+	case "esc":
+		m.fetchSeq++
+		m.view = viewList
+		m.err = nil
+	case "q":
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m model) monitorView() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Fetch " + m.fetchID.String()))
+	b.WriteString("\n\n")
+	if p := m.progress; p != nil {
+		done := p.Completed.Count + p.Failed.Count + p.AlreadyComplete.Count
+		ratio := 1.0
+		if p.Total > 0 {
+			ratio = float64(done) / float64(p.Total)
+		}
+		bar := progress.New(progress.WithSolidFill("10"), progress.WithWidth(min(max(m.width-4, 10), 60)))
+		b.WriteString(bar.ViewAs(ratio))
+		b.WriteString("\n\n")
+		for _, row := range []struct {
+			name string
+			st   prismclient.FetchStatusGroup
+		}{
+			{"pending", p.Pending},
+			{"running", p.Running},
+			{"completed", p.Completed},
+			{"failed", p.Failed},
+			{"already_complete", p.AlreadyComplete},
+		} {
+			b.WriteString(fmt.Sprintf("%-18s %d\n", renderStatus(row.name), row.st.Count))
+		}
+		b.WriteString(fmt.Sprintf("%-18s %d\n\n", "total", p.Total))
+		state := "polling every " + m.pollInterval.String()
+		if p.Terminal {
+			state = "done"
+		}
+		b.WriteString(dimStyle.Render(fmt.Sprintf("%s · refreshed %s", state, m.refreshedAt.Format(time.TimeOnly))))
+		b.WriteString("\n")
+	} else if m.err == nil {
+		b.WriteString(dimStyle.Render("loading…") + "\n")
+	}
+	if m.err != nil {
+		b.WriteString(errorStyle.Render(m.err.Error()) + "\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(dimStyle.Render("r refresh · esc back · q quit"))
+	return b.String()
+}
@@ -0,0 +1,40 @@
+package main
+
+import "github.com/charmbracelet/lipgloss"
+
+var (
+	titleStyle    = lipgloss.NewStyle().Bold(true)
+	dimStyle      = lipgloss.NewStyle().Faint(true)
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	cursorStyle   = lipgloss.NewStyle().Reverse(true)
+	selectedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+)
+
+// statusStyles colours the public page_fetch / progress statuses.
+var statusStyles = map[string]lipgloss.Style{
+	"created":          lipgloss.NewStyle().Foreground(lipgloss.Color("12")),
+	"already_complete": lipgloss.NewStyle().Foreground(lipgloss.Color("10")),
+	"not_found":        lipgloss.NewStyle().Foreground(lipgloss.Color("9")),
+}
+
+func renderStatus(status string) string {
+	if st, ok := statusStyles[status]; ok {
+		return st.Render(status)
+	}
+	return status
+}
+
+// truncate shortens s to at most n display cells, marking the cut.
+func truncate(s string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	if lipgloss.Width(s) <= n {
+		return s
+	}
+	r := []rune(s)
+	for len(r) > 0 && lipgloss.Width(string(r))+1 > n {
+		r = r[:len(r)-1]
+	}
+	return string(r) + "…"
+}
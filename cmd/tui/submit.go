@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func (m model) onSubmit(msg submitMsg) (tea.Model, tea.Cmd) {
+	m.loading = false
+	if msg.err != nil {
+		m.err = msg.err
+		return m, nil
+	}
+	m.err = nil
+	for _, item := range msg.resp.Items {
+		m.statuses[item.CandidateID] = item.Status
+	}
+	m.selected = nil
+	m.submit = &msg.resp
+	m.view = viewSubmit
+	return m, nil
+}
+
+func (m model) updateSubmitKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "m":
+		return m.openMonitor(m.submit.FetchID)
+	case "enter", "esc":
+		m.view = viewList
+	case "q":
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// submitView lists the per-candidate outcome of POST /page_fetch in the
+// order the server returned it.
+func (m model) submitView() string {
+	titles := make(map[string]string, len(m.rows))
+	for _, c := range m.rows {
+		titles[c.ID.String()] = c.Title
+	}
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Fetch submitted"))
+	b.WriteString(dimStyle.Render("  " + m.submit.FetchID.String()))
+	b.WriteString("\n\n")
+	for _, item := range m.submit.Items {
+		id := item.CandidateID.String()
+		title := titles[id]
+		if title == "" {
+			title = id
+		}
+		b.WriteString(fmt.Sprintf("%-18s %s\n", renderStatus(item.Status), truncate(title, m.width-20)))
+	}
+	b.WriteString("\n")
+	b.WriteString(dimStyle.Render("m monitor fetch · enter/esc back · q quit"))
+	return b.String()
+}
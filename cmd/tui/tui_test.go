@@ -0,0 +1,388 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/http/api"
+	"github.com/ChiaYuChang/prism/internal/http/middleware"
+	"github.com/ChiaYuChang/prism/internal/repo"
+	"github.com/ChiaYuChang/prism/internal/repo/mocks"
+	"github.com/ChiaYuChang/prism/pkg/prismclient"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/exp/teatest"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testToken   = "tui-test-token"
+	waitTimeout = 3 * time.Second
+)
+
+// backend is the repository behind a real api.Server for the TUI tests. It
+// holds three candidates, accepts every page fetch, reports the fetch as
+// running once before it turns terminal, and has no content until
+// contentReady is set.
+type backend struct {
+	candidates []repo.Candidate
+	fetchID    uuid.UUID
+
+	mu           sync.Mutex
+	queries      []repo.ListCandidatesParams
+	submitted    []uuid.UUID
+	fetchPolls   int
+	contentReady bool
+}
+
+// newBackend serves the /api/v1 routes of api.Server over httptest behind
+// static token auth, with the repository mocks answering from b.
+func newBackend(t *testing.T) (*backend, *httptest.Server) {
+	t.Helper()
+	b := &backend{fetchID: uuid.Must(uuid.NewV7())}
+	published := time.Date(2026, 5, 20, 0, 0, 0, 0, time.UTC)
+	for i, title := range []string{"Budget vote", "Typhoon warning", "Council recap"} {
+		b.candidates = append(b.candidates, repo.Candidate{
+			ID: uuid.Must(uuid.NewV7()), BatchID: uuid.Must(uuid.NewV7()), SourceAbbr: "dpp",
+			Title: title, URL: "https://www.dpp.org.tw/media/" + title, PublishedAt: &published,
+			DiscoveredAt: published.Add(-time.Duration(i) * time.Hour), TraceID: "trace",
+		})
+	}
+
+	scout := mocks.NewMockScout(t)
+	scout.EXPECT().ListCandidates(mock.Anything, mock.Anything).RunAndReturn(b.listCandidates).Maybe()
+	scout.EXPECT().GetCandidatesByIDs(mock.Anything, mock.Anything).RunAndReturn(b.getCandidates).Maybe()
+	tasks := mocks.NewMockTasks(t)
+	tasks.EXPECT().CreateTask(mock.Anything, mock.Anything).
+		Return(repo.Task{ID: uuid.Must(uuid.NewV7())}, nil).Maybe()
+	fetches := mocks.NewMockUserFetches(t)
+	fetches.EXPECT().Create(mock.Anything, mock.Anything).Return(repo.UserFetch{ID: b.fetchID}, nil).Maybe()
+	fetches.EXPECT().CreateItem(mock.Anything, mock.Anything).Return(repo.UserFetchItem{}, nil).Maybe()
+	fetches.EXPECT().Get(mock.Anything, mock.Anything).RunAndReturn(b.getFetch).Maybe()
+	fetches.EXPECT().GetProgress(mock.Anything, mock.Anything).RunAndReturn(b.getProgress).Maybe()
+	pipeline := mocks.NewMockPipeline(t)
+	pipeline.EXPECT().GetContentByCandidateID(mock.Anything, mock.Anything).RunAndReturn(b.getContent).Maybe()
+
+	srv, err := api.NewServer(slog.New(slog.DiscardHandler), scout, tasks, pipeline, fetches)
+	require.NoError(t, err)
+	mux := http.NewServeMux()
+	srv.RegisterPublic(mux, middleware.TokenListAuth(map[string]struct{}{testToken: {}}))
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+	return b, ts
+}
+
+func (b *backend) listCandidates(_ context.Context, arg repo.ListCandidatesParams) ([]repo.Candidate, error) {
+	b.mu.Lock()
+	b.queries = append(b.queries, arg)
+	b.mu.Unlock()
+
+	var rows []repo.Candidate
+	for _, c := range b.candidates {
+		if arg.Query != nil && !strings.Contains(strings.ToLower(c.Title), strings.ToLower(*arg.Query)) {
+			continue
+		}
+		if arg.AfterDiscoveredAt != nil && !c.DiscoveredAt.Before(*arg.AfterDiscoveredAt) {
+			continue
+		}
+		rows = append(rows, c)
+	}
+	return rows[:min(len(rows), int(arg.Limit))], nil
+}
+
+func (b *backend) getCandidates(_ context.Context, ids []uuid.UUID) ([]repo.Candidate, error) {
+	b.mu.Lock()
+	b.submitted = ids
+	b.mu.Unlock()
+
+	var rows []repo.Candidate
+	for _, c := range b.candidates {
+		if slices.Contains(ids, c.ID) {
+			rows = append(rows, c)
+		}
+	}
+	return rows, nil
+}
+
+func (b *backend) getFetch(_ context.Context, id uuid.UUID) (repo.UserFetch, error) {
+	if id != b.fetchID {
+		return repo.UserFetch{}, pgx.ErrNoRows
+	}
+	return repo.UserFetch{ID: id}, nil
+}
+
+func (b *backend) getProgress(_ context.Context, _ uuid.UUID) (repo.UserFetchProgress, error) {
+	b.mu.Lock()
+	b.fetchPolls++
+	polls := b.fetchPolls
+	b.mu.Unlock()
+
+	ids := []uuid.UUID{b.candidates[0].ID, b.candidates[1].ID}
+	if polls == 1 {
+		return repo.UserFetchProgress{Total: 2, RunningCandidateIDs: ids}, nil
+	}
+	return repo.UserFetchProgress{Total: 2, CompletedCandidateIDs: ids, Terminal: true}, nil
+}
+
+func (b *backend) getContent(_ context.Context, candidateID uuid.UUID) (repo.Content, error) {
+	b.mu.Lock()
+	ready := b.contentReady
+	b.mu.Unlock()
+	if !ready {
+		return repo.Content{}, pgx.ErrNoRows
+	}
+	return repo.Content{
+		ID: uuid.Must(uuid.NewV7()), CandidateID: candidateID, Type: repo.ContentTypePartyRelease,
+		SourceAbbr: "dpp", Title: "Budget vote", Content: "The legislature passed the budget.",
+		PublishedAt: time.Now(), FetchedAt: time.Now(), TraceID: "trace",
+	}, nil
+}
+
+func (b *backend) lastQuery() repo.ListCandidatesParams {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.queries[len(b.queries)-1]
+}
+
+// startProgram runs the TUI against srv with a page size of 2.
+func startProgram(t *testing.T, srv *httptest.Server, token string) *teatest.TestModel {
+	t.Helper()
+	m := newModel(newTestClient(t, srv, token), 2, 10*time.Millisecond)
+	return teatest.NewTestModel(t, m, teatest.WithInitialTermSize(defaultWidth, defaultHeight))
+}
+
+func newTestClient(t *testing.T, srv *httptest.Server, token string) *prismclient.Client {
+	t.Helper()
+	c, err := prismclient.New(srv.URL, prismclient.WithToken(token), prismclient.WithTimeout(time.Second))
+	require.NoError(t, err)
+	return c
+}
+
+// waitFor blocks until the rendered output contains want. Each call only
+// sees output produced since the previous one.
+func waitFor(t *testing.T, tm *teatest.TestModel, want string) {
+	t.Helper()
+	teatest.WaitFor(t, tm.Output(), func(out []byte) bool {
+		return bytes.Contains(out, []byte(want))
+	}, teatest.WithDuration(waitTimeout), teatest.WithCheckInterval(5*time.Millisecond))
+}
+
+// send delivers one key press to the program.
+func send(tm *teatest.TestModel, key string) {
+	tm.Send(keyMsg(key))
+}
+
+// enterText replaces the open prompt's value with s and submits it.
+func enterText(tm *teatest.TestModel, s string) {
+	tm.Send(tea.KeyMsg{Type: tea.KeyCtrlU})
+	tm.Type(s)
+	send(tm, "enter")
+}
+
+// finalModel quits the program and returns its last model.
+func finalModel(t *testing.T, tm *teatest.TestModel) model {
+	t.Helper()
+	require.NoError(t, tm.Quit())
+	return tm.FinalModel(t, teatest.WithFinalTimeout(waitTimeout)).(model)
+}
+
+func keyMsg(key string) tea.KeyMsg {
+	switch key {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case " ":
+		return tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+	default:
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	}
+}
+
+func TestListPagingAndFilters(t *testing.T) {
+	b, srv := newBackend(t)
+	tm := startProgram(t, srv, testToken)
+	waitFor(t, tm, "Typhoon warning")
+	assert.Equal(t, int32(3), b.lastQuery().Limit, "page size plus the look-ahead row")
+
+	send(tm, "n")
+	waitFor(t, tm, "Council recap")
+	assert.Equal(t, b.candidates[1].ID, *b.lastQuery().AfterID)
+
+	send(tm, "p")
+	waitFor(t, tm, "page 1")
+	assert.Nil(t, b.lastQuery().AfterID)
+
+	send(tm, "/")
+	enterText(tm, "vote")
+	waitFor(t, tm, "q=vote")
+	send(tm, "d")
+	enterText(tm, "2026-05-01")
+	waitFor(t, tm, "q=vote since=2026-05-01T00:00:00Z")
+	last := b.lastQuery()
+	assert.Equal(t, "vote", *last.Query)
+	assert.Equal(t, time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC), last.Since.UTC())
+	assert.Nil(t, last.AfterID)
+
+	send(tm, "u")
+	enterText(tm, "yesterday")
+	waitFor(t, tm, "use YYYY-MM-DD or RFC3339")
+
+	m := finalModel(t, tm)
+	assert.Equal(t, []string{""}, m.cursors)
+	require.Len(t, m.rows, 1)
+	assert.Equal(t, "Budget vote", m.rows[0].Title)
+	assert.Empty(t, m.next)
+	assert.Equal(t, prismclient.ListCandidatesParams{Q: "vote", Since: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)}, m.filter)
+	require.Error(t, m.err)
+}
+
+// TestListDropsStalePages forces a reply order the runtime cannot be made
+// to produce on demand, so it feeds the model directly.
+func TestListDropsStalePages(t *testing.T) {
+	_, srv := newBackend(t)
+	m := newModel(newTestClient(t, srv, testToken), 2, time.Millisecond)
+	m = step(t, m, m.Init()())
+
+	next, stale := m.Update(keyMsg("n"))
+	m = next.(model)
+	next, fresh := m.Update(keyMsg("r"))
+	m = next.(model)
+	staleMsg, freshMsg := stale(), fresh()
+
+	m = step(t, m, freshMsg)
+	m = step(t, m, staleMsg)
+	assert.Equal(t, []string{""}, m.cursors)
+	assert.Len(t, m.rows, 2)
+}
+
+func TestListUnauthorized(t *testing.T) {
+	_, srv := newBackend(t)
+	tm := startProgram(t, srv, "wrong")
+	waitFor(t, tm, "PRISM_TUI_AUTH_TOKEN")
+
+	m := finalModel(t, tm)
+	assert.True(t, prismclient.IsStatus(m.err, http.StatusUnauthorized))
+	assert.Empty(t, m.rows)
+}
+
+func TestSubmitAndMonitor(t *testing.T) {
+	b, srv := newBackend(t)
+	tm := startProgram(t, srv, testToken)
+	waitFor(t, tm, "Typhoon warning")
+
+	send(tm, "f")
+	waitFor(t, tm, "nothing selected")
+
+	for _, key := range []string{"j", " ", "k", " ", "f"} {
+		send(tm, key)
+	}
+	waitFor(t, tm, "Fetch submitted")
+	b.mu.Lock()
+	assert.Equal(t, []uuid.UUID{b.candidates[1].ID, b.candidates[0].ID}, b.submitted)
+	b.mu.Unlock()
+
+	send(tm, "m")
+	waitFor(t, tm, "done")
+	b.mu.Lock()
+	assert.Equal(t, 2, b.fetchPolls, "polling stops once the fetch is terminal")
+	b.mu.Unlock()
+
+	send(tm, "esc")
+	waitFor(t, tm, "created")
+
+	m := finalModel(t, tm)
+	assert.Equal(t, viewList, m.view)
+	assert.Empty(t, m.selected)
+	assert.Equal(t, "created", m.statuses[b.candidates[0].ID])
+	require.NotNil(t, m.progress)
+	assert.True(t, m.progress.Terminal)
+	assert.Equal(t, 2, m.progress.Completed.Count)
+}
+
+func TestMonitorStopsOnClientError(t *testing.T) {
+	_, srv := newBackend(t)
+	tm := startProgram(t, srv, testToken)
+	waitFor(t, tm, "Typhoon warning")
+
+	send(tm, "b")
+	enterText(tm, uuid.NewString())
+	waitFor(t, tm, "fetch not found")
+
+	m := finalModel(t, tm)
+	assert.Equal(t, viewMonitor, m.view)
+	assert.True(t, prismclient.IsNotFound(m.err))
+	assert.Nil(t, m.progress)
+}
+
+// TestMonitorDropsTicksAfterLeaving delivers a tick after the user left
+// the monitor, which the runtime only does by timing luck.
+func TestMonitorDropsTicksAfterLeaving(t *testing.T) {
+	b, srv := newBackend(t)
+	m := newModel(newTestClient(t, srv, testToken), 2, time.Millisecond)
+	m = step(t, m, m.Init()())
+
+	next, cmd := m.openMonitor(b.fetchID)
+	m = next.(model)
+	next, tick := m.Update(cmd())
+	m = next.(model)
+	require.NotNil(t, tick)
+
+	m = step(t, m, keyMsg("esc"))
+	_, load := m.Update(tick())
+	assert.Nil(t, load)
+}
+
+func TestContentWaitsForFetch(t *testing.T) {
+	b, srv := newBackend(t)
+	tm := startProgram(t, srv, testToken)
+	waitFor(t, tm, "Typhoon warning")
+
+	send(tm, "enter")
+	waitFor(t, tm, "has not been fetched yet")
+
+	b.mu.Lock()
+	b.contentReady = true
+	b.mu.Unlock()
+	waitFor(t, tm, "The legislature passed the budget.")
+
+	send(tm, "esc")
+	waitFor(t, tm, "Prism candidates")
+
+	m := finalModel(t, tm)
+	assert.Equal(t, viewList, m.view)
+	require.NotNil(t, m.content)
+	assert.Equal(t, b.candidates[0].ID, m.content.CandidateID)
+}
+
+// step feeds msg to the model and returns the updated model.
+func step(t *testing.T, m model, msg tea.Msg) model {
+	t.Helper()
+	next, _ := m.Update(msg)
+	return next.(model)
+}
+
+func TestContentBackoff(t *testing.T) {
+	assert.Equal(t, time.Second, contentBackoff(1))
+	assert.Equal(t, 2*time.Second, contentBackoff(2))
+	assert.Equal(t, 16*time.Second, contentBackoff(5))
+	assert.Equal(t, 30*time.Second, contentBackoff(6))
+	assert.Equal(t, 30*time.Second, contentBackoff(50))
+}
+
+func TestIsClientError(t *testing.T) {
+	assert.True(t, isClientError(&prismclient.APIError{StatusCode: http.StatusNotFound}))
+	assert.False(t, isClientError(&prismclient.APIError{StatusCode: http.StatusBadGateway}))
+	assert.False(t, isClientError(context.DeadlineExceeded))
+}
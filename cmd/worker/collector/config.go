@@ -28,6 +28,12 @@ type Config struct {
 	Archive           string `mapstructure:"archive"`
 	ParsersConfigPath string `mapstructure:"parsers-config"`
 
+	// RegistrySource selects where per-host parser rules come from: "file"
+	// reads the parsers: map in ParsersConfigPath; "postgres" reads
+	// parser_rules (seeded from that map when empty) and reloads on change.
+	// The fallback section always comes from ParsersConfigPath.
+	RegistrySource string `mapstructure:"registry-source" validate:"oneof=file postgres"`
+
 	// Prompt, when non-empty, overrides the parsers.yaml
 	// fallback.prompt_file path. Useful for one-off operator overrides
 	// without editing the baked parsers.yaml.
@@ -48,6 +54,15 @@ type Config struct {
 	// that always errors. Dev-only; integration test plan Phase 3 — exercises
 	// the errorSaver / cmd/recover replay path.
 	ForceMinifyError bool `mapstructure:"force-minify-error"`
+
+	// SocialPlatforms lists the platforms whose post URLs are fetched
+	// through their exporter instead of the HTML pipeline. Empty disables
+	// social collection.
+	SocialPlatforms []string `mapstructure:"social-platforms" validate:"dive,oneof=youtube"`
+
+	// SocialCaptionLanguages is the caption preference order for video
+	// platforms; the first available track wins.
+	SocialCaptionLanguages []string `mapstructure:"social-caption-languages"`
 }
 
 func LoadConfig(args []string) (*Config, error) {
@@ -66,9 +81,12 @@ func LoadConfig(args []string) (*Config, error) {
 	fs.Duration("max-processing-time", 2*time.Minute, "Maximum wall-clock time for handling a single message (ctx timeout passed to handler)")
 	fs.String("archive", "", "Archive URI for error payloads (file:///path or s3://bucket/prefix); empty disables archiving")
 	fs.String("parsers-config", "configs/worker/collector/parsers.yaml", "Path to the parsers configuration file (YAML)")
+	fs.String("registry-source", "file", "Where parser rules come from: file (--parsers-config) or postgres (parser_rules, seeded from --parsers-config when empty, reloaded on change)")
 	fs.String("prompt", "", "Override path to the LLM fallback system-instruction file (defaults to fallback.prompt_file in parsers.yaml)")
 	fs.String("capture-dir", "", "Dev-only: tee successful response bodies to <dir>/<host>/<path> for fixture capture")
 	fs.String("fixture-base", "", "Dev-only: rewrite outbound requests to this fixture-server URL (mutually exclusive with --capture-dir)")
+	fs.StringSlice("social-platforms", []string{"youtube"}, "Social platforms collected through their exporter (comma-separated; empty disables)")
+	fs.StringSlice("social-caption-languages", []string{"zh-TW", "zh", "en"}, "Caption language preference for video platforms (comma-separated)")
 	fs.Bool("force-minify-error", false, "Dev-only: replace minifier with always-failing shim to exercise errorSaver / cmd/recover (Phase 3)")
 
 	fs.String("pg-host", "localhost", "Postgres host")
@@ -19,7 +19,10 @@ func TestLoadConfigDefaults(t *testing.T) {
 	assert.Equal(t, 2*time.Minute, cfg.MaxProcessingTime)
 	assert.Equal(t, "localhost", cfg.Postgres.Host)
 	assert.Equal(t, "nats", cfg.MessengerType)
+	assert.Equal(t, "file", cfg.RegistrySource)
 	assert.Equal(t, "", cfg.Archive)
+	assert.Equal(t, []string{"youtube"}, cfg.SocialPlatforms)
+	assert.Equal(t, []string{"zh-TW", "zh", "en"}, cfg.SocialCaptionLanguages)
 	require.NotNil(t, cfg.Messenger)
 }
 
@@ -35,6 +38,7 @@ func TestLoadConfigShippedConfig(t *testing.T) {
 	assert.Equal(t, "postgres", cfg.Postgres.Host)
 	assert.Equal(t, "prism.collector", cfg.Telemetry.ServiceName)
 	assert.Equal(t, "/logs/app.log", cfg.Logger.File.File)
+	assert.Equal(t, []string{"youtube"}, cfg.SocialPlatforms)
 }
 
 func setShippedConfigEnv(t *testing.T) {
@@ -134,6 +138,8 @@ func TestLoadConfigValidationFailed(t *testing.T) {
 		{name: "http-timeout too short", args: []string{"--http-timeout=0s"}},
 		{name: "max-processing-time too short", args: []string{"--max-processing-time=0s"}},
 		{name: "invalid messenger type", args: []string{"--messenger-type=invalid"}},
+		{name: "invalid registry source", args: []string{"--registry-source=etcd"}},
+		{name: "unsupported social platform", args: []string{"--social-platforms=threads"}},
 	}
 
 	for _, tt := range tests {
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"strings"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/ChiaYuChang/prism/internal/message"
 	"github.com/ChiaYuChang/prism/internal/obs"
 	"github.com/ChiaYuChang/prism/internal/repo"
+	"github.com/ChiaYuChang/prism/internal/social"
 	"github.com/ChiaYuChang/prism/pkg/archivecodec"
 	wm "github.com/ThreeDotsLabs/watermill/message"
 	"github.com/google/uuid"
@@ -25,8 +27,10 @@ import (
 const (
 	SpanNameHandleMessage = "worker.collector.handle_message"
 
-	ContentTypePartyRelease = "PARTY_RELEASE"
-	ContentTypeArticle      = "ARTICLE"
+	ContentTypePartyRelease      = "PARTY_RELEASE"
+	ContentTypeGovernmentRelease = "GOVERNMENT_RELEASE"
+	ContentTypeArticle           = "ARTICLE"
+	ContentTypeSocial            = "SOCIAL"
 )
 
 var (
@@ -158,6 +162,7 @@ func (h *Handler) HandleMessage(ctx context.Context, msg *wm.Message) (bool, err
 		return true, fmt.Errorf("extract trace context: %w", traceErr)
 	}
 	ctx = obs.WithTraceID(ctx, sig.TraceID)
+	ctx = obs.WithBatchID(ctx, sig.BatchID)
 	ctx, span := h.tracer.Start(ctx, SpanNameHandleMessage)
 	defer span.End()
 
@@ -180,7 +185,7 @@ func (h *Handler) HandleMessage(ctx context.Context, msg *wm.Message) (bool, err
 		return true, err
 	}
 
-	if err := h.reporter.CompleteTask(ctx, sig.TaskID); err != nil {
+	if err := h.reporter.CompleteTask(ctx, repo.CompleteTaskParams{ID: sig.TaskID}); err != nil {
 		h.metrics.recordTask(ctx, sig, "nacked", started)
 		return false, fmt.Errorf("complete task %s: %w", sig.TaskID, err)
 	}
@@ -252,10 +257,16 @@ func (h *Handler) process(ctx context.Context, logger *slog.Logger, sig message.
 	canonical := result.Canonical
 
 	contentType := sourceTypeToContentType(sig.SourceType)
+	metadata := map[string]any{}
+	// Social posts keep the platform metadata (channel, post ID, caption
+	// track) on the content row; other parsers' metadata stays internal.
+	if platform, _ := art.Metadata[social.MetadataPlatform].(string); platform != "" {
+		contentType = ContentTypeSocial
+		maps.Copy(metadata, art.Metadata)
+	}
 	fetchedAt := time.Now()
 
 	publishedAt := art.PublishedAt
-	metadata := map[string]any{}
 	if publishedAt.IsZero() {
 		publishedAt = fetchedAt
 		metadata["published_at_estimated"] = true
@@ -343,6 +354,8 @@ func sourceTypeToContentType(sourceType string) string {
 	switch sourceType {
 	case repo.SourceTypeParty:
 		return ContentTypePartyRelease
+	case repo.SourceTypeGovernment:
+		return ContentTypeGovernmentRelease
 	default:
 		return ContentTypeArticle
 	}
@@ -2,10 +2,12 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"io"
 	"log/slog"
 	"testing"
+	"time"
 
 	collector "github.com/ChiaYuChang/prism/internal/collector"
 	"github.com/ChiaYuChang/prism/internal/collector/archiver"
@@ -13,6 +15,7 @@ import (
 	"github.com/ChiaYuChang/prism/internal/message"
 	"github.com/ChiaYuChang/prism/internal/repo"
 	repomocks "github.com/ChiaYuChang/prism/internal/repo/mocks"
+	"github.com/ChiaYuChang/prism/internal/social"
 	wm "github.com/ThreeDotsLabs/watermill/message"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -255,6 +258,91 @@ func TestHandlerHandleMessageRecordsMetrics(t *testing.T) {
 	}
 }
 
+func TestHandlerProcess_ContentType(t *testing.T) {
+	const url = "https://www.youtube.com/watch?v=synVideo001"
+
+	tests := []struct {
+		name       string
+		sourceType string
+		article    *collector.Article
+		wantType   string
+		wantMeta   map[string]any
+	}{
+		{
+			name:       "party release",
+			sourceType: repo.SourceTypeParty,
+			article:    &collector.Article{Title: "Title", Content: "Body", PublishedAt: time.Now(), Metadata: map[string]any{"extractor": "jsonld"}},
+			wantType:   ContentTypePartyRelease,
+			wantMeta:   map[string]any{},
+		},
+		{
+			name:       "government release",
+			sourceType: repo.SourceTypeGovernment,
+			article:    &collector.Article{Title: "Title", Content: "Body", PublishedAt: time.Now()},
+			wantType:   ContentTypeGovernmentRelease,
+			wantMeta:   map[string]any{},
+		},
+		{
+			name:       "social post keeps platform metadata",
+			sourceType: repo.SourceTypeParty,
+			article: &collector.Article{Title: "Title", Content: "Body", PublishedAt: time.Now(), Metadata: map[string]any{
+				social.MetadataPlatform: social.PlatformYouTube,
+				social.MetadataPostID:   "synVideo001",
+			}},
+			wantType: ContentTypeSocial,
+			wantMeta: map[string]any{social.MetadataPlatform: social.PlatformYouTube, social.MetadataPostID: "synVideo001"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetcher := mocks.NewMockFetcher(t)
+			minifier := mocks.NewMockTransformer(t)
+			parser := mocks.NewMockParser(t)
+			fetcher.EXPECT().Fetch(mock.Anything, url).Return("raw", nil).Once()
+			minifier.EXPECT().Transform(mock.Anything, "raw").Return("raw", nil).Once()
+			parser.EXPECT().Parse(mock.Anything, url, "raw").Return(tt.article, nil).Once()
+
+			dispatcher, err := collector.NewDispatcher(
+				slog.New(slog.NewTextHandler(io.Discard, nil)),
+				noop.NewTracerProvider().Tracer("test"),
+				collector.NewPipelineRegistry(collector.Pipeline{Fetcher: fetcher, Minifier: minifier, Parser: parser}),
+			)
+			require.NoError(t, err)
+
+			var got repo.CreateContentParams
+			pipeline := repomocks.NewMockPipeline(t)
+			pipeline.EXPECT().GetContentByURL(mock.Anything, url).Return(repo.Content{}, errContentNotFound).Once()
+			pipeline.EXPECT().CreateContent(mock.Anything, mock.Anything).
+				Run(func(_ context.Context, params repo.CreateContentParams) { got = params }).
+				Return(repo.Content{ID: uuid.Must(uuid.NewV7())}, nil).Once()
+
+			h, err := NewHandler(
+				slog.New(slog.NewTextHandler(io.Discard, nil)),
+				noop.NewTracerProvider().Tracer("test"),
+				dispatcher, nil, nil, pipeline, stubReporter{}, nil,
+			)
+			require.NoError(t, err)
+
+			sig := message.TaskSignal{
+				TaskID:     uuid.New(),
+				BatchID:    uuid.New(),
+				TraceID:    "trace-social",
+				Kind:       repo.TaskKindPageFetch,
+				SourceType: tt.sourceType,
+				SourceAbbr: "dpp-youtube",
+				URL:        url,
+			}
+			require.NoError(t, h.process(context.Background(), slog.New(slog.NewTextHandler(io.Discard, nil)), sig))
+			require.Equal(t, tt.wantType, got.Type)
+
+			var meta map[string]any
+			require.NoError(t, json.Unmarshal(got.Metadata, &meta))
+			require.Equal(t, tt.wantMeta, meta)
+		})
+	}
+}
+
 func collectorTaskPayload(t *testing.T, taskID uuid.UUID, kind, sourceType string) []byte {
 	t.Helper()
 	payload, err := (&message.TaskSignal{
@@ -379,7 +467,7 @@ func (s *capturingSaver) Save(_ context.Context, record collector.Archive) error
 
 type stubReporter struct{}
 
-func (stubReporter) CompleteTask(context.Context, uuid.UUID) error { return nil }
+func (stubReporter) CompleteTask(context.Context, repo.CompleteTaskParams) error { return nil }
 
 func (stubReporter) FailTask(context.Context, uuid.UUID) error { return nil }
 
@@ -388,6 +476,8 @@ type metricsReporter struct {
 	failErr     error
 }
 
-func (r metricsReporter) CompleteTask(context.Context, uuid.UUID) error { return r.completeErr }
+func (r metricsReporter) CompleteTask(context.Context, repo.CompleteTaskParams) error {
+	return r.completeErr
+}
 
 func (r metricsReporter) FailTask(context.Context, uuid.UUID) error { return r.failErr }
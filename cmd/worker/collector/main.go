@@ -10,11 +10,14 @@ import (
 	"time"
 
 	"github.com/ChiaYuChang/prism/internal/appconfig"
+	"github.com/ChiaYuChang/prism/internal/catalog"
 	"github.com/ChiaYuChang/prism/internal/collector"
 	"github.com/ChiaYuChang/prism/internal/collector/fetcher"
 	"github.com/ChiaYuChang/prism/internal/collector/minifier"
+	"github.com/ChiaYuChang/prism/internal/collector/parser"
 	parserconfig "github.com/ChiaYuChang/prism/internal/collector/parser/config"
 	parserllm "github.com/ChiaYuChang/prism/internal/collector/parser/llm"
+	socialparser "github.com/ChiaYuChang/prism/internal/collector/parser/social"
 	"github.com/ChiaYuChang/prism/internal/collector/transformer"
 	"github.com/ChiaYuChang/prism/internal/dev"
 	httpclient "github.com/ChiaYuChang/prism/internal/http/client"
@@ -22,11 +25,15 @@ import (
 	llmfactory "github.com/ChiaYuChang/prism/internal/llm/factory"
 	"github.com/ChiaYuChang/prism/internal/message"
 	"github.com/ChiaYuChang/prism/internal/obs"
+	"github.com/ChiaYuChang/prism/internal/repo"
 	"github.com/ChiaYuChang/prism/internal/repo/pg"
+	"github.com/ChiaYuChang/prism/internal/social/exporters"
 )
 
 const (
 	TracerName = "prism.worker.collector"
+	// LedgerComponent names this worker in llm_usage rows and budgets.
+	LedgerComponent = "collector"
 )
 
 func main() {
@@ -159,17 +166,22 @@ func main() {
 		if config.Prompt != "" {
 			pCfg.Fallback.PromptFile = config.Prompt
 		}
-		prompt, perr := parserconfig.LoadFallbackPrompt(pCfg.Fallback)
+		prompts, perr := parserconfig.LoadFallbackPrompts(pCfg.Fallback)
+		if perr == nil {
+			prompts, perr = prompts.Register(ctx, dbRepo.Analysis(), map[string]any{"component": LedgerComponent})
+		}
 		if perr != nil {
 			logger.Error(
 				"failed to load fallback prompt",
 				"path", pCfg.Fallback.PromptFile,
+				"candidate", pCfg.Fallback.PromptCandidate,
 				"error", perr,
 			)
 			monitor.SetStatus(obs.LevelError, "Failed to load fallback prompt")
 			os.Exit(1)
 		}
-		gen, gerr := llmfactory.NewGenerator(ctx, pCfg.Fallback.LLM, logger)
+		gen, gerr := llmfactory.NewGenerator(ctx, pCfg.Fallback.LLM, logger,
+			llmfactory.WithUsageLedger(llmfactory.NewRepoLedger(dbRepo.LLMUsage()), LedgerComponent))
 		if gerr != nil {
 			logger.Error(
 				"failed to initialize fallback LLM generator",
@@ -181,11 +193,32 @@ func main() {
 		}
 		model := pCfg.Fallback.LLM.Model
 		llmFactory = func() (collector.Parser, error) {
-			return parserllm.NewParser(gen, logger, model, prompt)
+			return parserllm.NewSplitParser(gen, logger, model, prompts)
 		}
 		logger.Info("parser fallback enabled",
 			"provider", pCfg.Fallback.LLM.Provider, "model", model,
-			"prompt_file", pCfg.Fallback.PromptFile)
+			"prompt_file", pCfg.Fallback.PromptFile,
+			"prompt_version", prompts.Control.Version,
+			"prompt_candidate", pCfg.Fallback.PromptCandidate,
+			"prompt_percent", pCfg.Fallback.PromptPercent)
+	}
+
+	if config.RegistrySource == catalog.SourcePostgres {
+		seeded, err := catalog.SeedParserRules(ctx, dbRepo.Catalog(), pCfg.Parsers)
+		if err != nil {
+			logger.Error("failed to seed parser rules", "error", err)
+			monitor.SetStatus(obs.LevelError, "Failed to seed parser rules")
+			os.Exit(1)
+		}
+		if seeded > 0 {
+			logger.Info("seeded parser rules from file", "path", config.ParsersConfigPath, "count", seeded)
+		}
+		pCfg.Parsers, err = catalog.LoadParserRules(ctx, dbRepo.Catalog())
+		if err != nil {
+			logger.Error("failed to load parser rules", "error", err)
+			monitor.SetStatus(obs.LevelError, "Failed to load parser rules")
+			os.Exit(1)
+		}
 	}
 
 	registry, err := parserconfig.BuildRegistry(pCfg, logger, tracer, llmFactory)
@@ -194,6 +227,35 @@ func main() {
 		monitor.SetStatus(obs.LevelError, "Failed to build parser registry")
 		os.Exit(1)
 	}
+	parsers, err := parser.NewSwappable(registry)
+	if err != nil {
+		logger.Error("failed to build parser registry", "error", err)
+		monitor.SetStatus(obs.LevelError, "Failed to build parser registry")
+		os.Exit(1)
+	}
+	if config.RegistrySource == catalog.SourcePostgres {
+		// Only the per-host rules reload; the fallback keeps the LLM
+		// generator built above.
+		reload := func(ctx context.Context) error {
+			rules, err := catalog.LoadParserRules(ctx, dbRepo.Catalog())
+			if err != nil {
+				return err
+			}
+			cfg := pCfg
+			cfg.Parsers = rules
+			registry, err := parserconfig.BuildRegistry(cfg, logger, tracer, llmFactory)
+			if err != nil {
+				return err
+			}
+			parsers.Swap(registry)
+			return nil
+		}
+		if err := catalog.Listen(ctx, logger, config.Postgres.ConnString(), repo.CatalogParserRules, reload); err != nil {
+			logger.Error("failed to watch parser rules", "error", err)
+			monitor.SetStatus(obs.LevelError, "Failed to watch parser rules")
+			os.Exit(1)
+		}
+	}
 
 	var pageMinifier collector.Transformer = minifier.New()
 	if config.ForceMinifyError {
@@ -205,9 +267,31 @@ func main() {
 		Fetcher:      pageFetcher,
 		Minifier:     pageMinifier,
 		Transformers: []collector.Transformer{transformer.NewNoOpTransformer()},
-		Parser:       registry,
+		Parser:       parsers,
 	})
 
+	// Social post URLs go through the platform exporter: the archived
+	// payload is the post JSON (transcript included), not the watch page.
+	for _, platform := range config.SocialPlatforms {
+		exporter, err := exporters.New(platform, httpClient, exporters.Options{
+			CaptionLanguages: config.SocialCaptionLanguages,
+		})
+		if err != nil {
+			logger.Error("failed to build social exporter", "platform", platform, "error", err)
+			monitor.SetStatus(obs.LevelError, "Failed to build social exporter")
+			os.Exit(1)
+		}
+		socialPipeline := collector.Pipeline{
+			Fetcher:  fetcher.NewSocialFetcher(exporter),
+			Minifier: transformer.NewNoOpTransformer(),
+			Parser:   socialparser.New(),
+		}
+		for _, host := range exporter.Hosts() {
+			pipelineRegistry.RegisterHost(host, socialPipeline)
+		}
+		logger.Info("social pipeline registered", "platform", platform, "hosts", exporter.Hosts())
+	}
+
 	dispatcher, err := collector.NewDispatcher(logger, tracer, pipelineRegistry)
 	if err != nil {
 		logger.Error("failed to build collector dispatcher", "error", err)
@@ -244,6 +328,7 @@ func main() {
 		"messenger", config.MessengerType,
 		"health_port", config.HealthPort,
 		"http_timeout", config.HTTPTimeout,
+		"registry_source", config.RegistrySource,
 		"started", started,
 	)
 	defer func() {
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/ChiaYuChang/prism/internal/appconfig"
+	"github.com/ChiaYuChang/prism/internal/discovery/cadence"
 	searchconfig "github.com/ChiaYuChang/prism/internal/discovery/search/config"
 	"github.com/ChiaYuChang/prism/internal/obs"
 	"github.com/go-playground/validator/v10"
@@ -22,11 +23,18 @@ type Config struct {
 	Logger          obs.LoggingConfig         `mapstructure:"logger"`
 	Telemetry       obs.TelemetryConfig       `mapstructure:"telemetry"`
 	ScoutConfigPath string                    `mapstructure:"scout-config"   validate:"required"`
+	RegistrySource  string                    `mapstructure:"registry-source" validate:"oneof=file postgres"`
 	HTTPTimeout     time.Duration             `mapstructure:"http-timeout"   validate:"required,min=1s"`
 	Postgres        appconfig.PostgresConfig  `mapstructure:"postgres"`
 	MessengerType   string                    `mapstructure:"messenger-type" validate:"oneof=nats gochannel"`
 	Messenger       appconfig.MessengerConfig `mapstructure:"-"`
 	Search          searchconfig.Config       `mapstructure:"search"`
+	Cadence         CadenceConfig             `mapstructure:"cadence"`
+	Relevance       RelevanceConfig           `mapstructure:"relevance"`
+
+	// LLM is the embedder of the relevance embedding signal. It is only
+	// read, and validated, when relevance.embedding-enabled is set.
+	LLM appconfig.LLMConfig `mapstructure:"llm" validate:"-"`
 
 	// CaptureDir, when non-empty, tees successful HTTP response bodies into
 	// <dir>/<host>/<path>. Dev-only; used to build local fixtures during
@@ -40,6 +48,61 @@ type Config struct {
 	FixtureBase string `mapstructure:"fixture-base"`
 }
 
+// CadenceConfig configures adaptive polling of recurring DIRECTORY_FETCH
+// tasks. When disabled, tasks.frequency alone sets the next run.
+type CadenceConfig struct {
+	Enabled    bool          `mapstructure:"enabled"`
+	Min        time.Duration `mapstructure:"min"`
+	Max        time.Duration `mapstructure:"max"`
+	Burst      int           `mapstructure:"burst"`
+	SpeedUp    float64       `mapstructure:"speed-up"`
+	BackOff    float64       `mapstructure:"back-off"`
+	QuietHours string        `mapstructure:"quiet-hours"`
+	QuietMin   time.Duration `mapstructure:"quiet-min"`
+	Timezone   string        `mapstructure:"timezone"`
+}
+
+// Policy builds the cadence policy, or returns nil when disabled.
+func (c CadenceConfig) Policy() (*cadence.Policy, error) {
+	if !c.Enabled {
+		return nil, nil
+	}
+	start, end, err := cadence.ParseQuietHours(c.QuietHours)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("load cadence timezone %q: %w", c.Timezone, err)
+	}
+	return cadence.New(cadence.Config{
+		Min:        c.Min,
+		Max:        c.Max,
+		Burst:      c.Burst,
+		SpeedUp:    c.SpeedUp,
+		BackOff:    c.BackOff,
+		QuietStart: start,
+		QuietEnd:   end,
+		QuietMin:   c.QuietMin,
+		Location:   loc,
+	})
+}
+
+// RelevanceConfig configures candidate relevance scoring of KEYWORD_SEARCH
+// results. A score is the weighted mean of the keyword, embedding and
+// source prior signals available for a candidate; new MEDIA candidates
+// scoring at least Threshold are promoted to PAGE_FETCH.
+type RelevanceConfig struct {
+	Enabled          bool               `mapstructure:"enabled"`
+	Threshold        float64            `mapstructure:"threshold"         validate:"min=0,max=1"`
+	KeywordWeight    float64            `mapstructure:"keyword-weight"    validate:"min=0"`
+	EmbeddingEnabled bool               `mapstructure:"embedding-enabled"`
+	EmbeddingWeight  float64            `mapstructure:"embedding-weight"  validate:"min=0"`
+	PriorWeight      float64            `mapstructure:"prior-weight"      validate:"min=0"`
+	DefaultPrior     float64            `mapstructure:"default-prior"     validate:"min=0,max=1"`
+	Priors           map[string]float64 `mapstructure:"priors"            validate:"dive,min=0,max=1"`
+}
+
 func LoadConfig(args []string) (*Config, error) {
 	v := viper.New()
 	v.SetEnvPrefix("PRISM_DISCOVERY_WORKER")
@@ -53,6 +116,7 @@ func LoadConfig(args []string) (*Config, error) {
 	obs.RegisterTelemetryFlags(fs, obs.DefaultTelemetryConfig("prism.worker.discovery"))
 	fs.String("messenger-type", "nats", "The messenger backend type (nats, gochannel)")
 	fs.String("scout-config", DefaultScoutConfigPath, "path to scout config file")
+	fs.String("registry-source", "file", "Where scouts come from: file (--scout-config) or postgres (scout_configs, seeded from --scout-config when empty, reloaded on change)")
 	fs.Duration("http-timeout", 30*time.Second, "HTTP timeout for outbound discovery requests")
 	fs.Bool("search-provider-brave-enable", false, "Enable Brave Search provider for KEYWORD_SEARCH")
 	fs.String("search-provider-brave-api-key", "", "Brave Search API subscription token")
@@ -95,6 +159,28 @@ func LoadConfig(args []string) (*Config, error) {
 	fs.String("search-provider-serpapi-google-news-geolocation", "tw", "SerpAPI Google News geolocation, e.g. tw")
 	fs.String("search-provider-serpapi-google-news-host-language", "zh-tw", "SerpAPI Google News host language, e.g. zh-tw")
 	fs.Int("search-provider-serpapi-google-news-sort-order", 0, "SerpAPI Google News sort: 0 relevance, 1 date")
+	fs.Bool("cadence-enabled", false, "Pick the next run of recurring DIRECTORY_FETCH tasks from their yield instead of tasks.frequency")
+	fs.Duration("cadence-min", 5*time.Minute, "Shortest adaptive polling interval")
+	fs.Duration("cadence-max", 6*time.Hour, "Longest adaptive polling interval")
+	fs.Int("cadence-burst", 5, "New candidates in one run that reset the interval to --cadence-min")
+	fs.Float64("cadence-speed-up", 2, "Divide the interval by this after a run with new candidates")
+	fs.Float64("cadence-back-off", 1.5, "Multiply the interval by this after a run without new candidates")
+	fs.String("cadence-quiet-hours", "0-7", "Local hours start-end during which runs are at least --cadence-quiet-min apart; empty disables")
+	fs.Duration("cadence-quiet-min", time.Hour, "Shortest interval during quiet hours")
+	fs.String("cadence-timezone", "Asia/Taipei", "Time zone of --cadence-quiet-hours")
+	fs.Bool("relevance-enabled", false, "Score KEYWORD_SEARCH candidates and promote relevant MEDIA ones to PAGE_FETCH")
+	fs.Float64("relevance-threshold", 0.6, "Relevance score (0-1) at which a new MEDIA candidate is promoted; 0 only records scores")
+	fs.Float64("relevance-keyword-weight", 1, "Weight of the seed phrase keyword overlap signal (0 disables)")
+	fs.Bool("relevance-embedding-enabled", false, "Score similarity to the seed release embedding with the --llm-* embedder")
+	fs.Float64("relevance-embedding-weight", 1, "Weight of the seed embedding similarity signal")
+	fs.Float64("relevance-prior-weight", 0.5, "Weight of the source prior signal (0 disables)")
+	fs.Float64("relevance-default-prior", 0.5, "Source prior of outlets missing from relevance.priors")
+	fs.String("llm-provider", "gemini", "Embedding provider of the relevance embedding signal (gemini, openai, ollama, openai-compatible)")
+	fs.String("llm-key", "", "Embedding provider API key")
+	fs.String("llm-model", "", "Embedding model name; must be registered in models as an EMBEDDER")
+	fs.Duration("llm-timeout", 30*time.Second, "Embedding request timeout")
+	fs.String("llm-url", "", "Base URL of an openai-compatible embedding server")
+	fs.Bool("llm-embeddings", false, "Whether the openai-compatible server serves /embeddings")
 	fs.String("capture-dir", "", "Dev-only: tee successful response bodies to <dir>/<host>/<path> for fixture capture")
 	fs.String("fixture-base", "", "Dev-only: rewrite outbound requests to this fixture-server URL (mutually exclusive with --capture-dir)")
 
@@ -135,7 +221,16 @@ func LoadConfig(args []string) (*Config, error) {
 	if err := bindSearchFlags(v, fs); err != nil {
 		return nil, err
 	}
+	if err := bindCadenceFlags(v, fs); err != nil {
+		return nil, err
+	}
+	if err := bindRelevanceFlags(v, fs); err != nil {
+		return nil, err
+	}
 	var config Config
+	if err := config.LLM.BindFlags(v, fs); err != nil {
+		return nil, err
+	}
 	if err := config.Postgres.BindFlags(v, fs); err != nil {
 		return nil, err
 	}
@@ -198,6 +293,17 @@ func LoadConfig(args []string) (*Config, error) {
 	if err := validate.Struct(&config); err != nil {
 		return nil, fmt.Errorf("config validation failed: %v", err)
 	}
+	if _, err := config.Cadence.Policy(); err != nil {
+		return nil, fmt.Errorf("cadence config: %w", err)
+	}
+	if config.Relevance.Enabled && config.Relevance.EmbeddingEnabled {
+		if err := config.LLM.ResolveSecrets(); err != nil {
+			return nil, fmt.Errorf("llm secrets: %w", err)
+		}
+		if err := validate.Struct(&config.LLM); err != nil {
+			return nil, fmt.Errorf("llm config validation failed: %v", err)
+		}
+	}
 	if config.Messenger != nil {
 		if err := validate.Struct(config.Messenger); err != nil {
 			return nil, fmt.Errorf("messenger config validation failed: %v", err)
@@ -207,6 +313,30 @@ func LoadConfig(args []string) (*Config, error) {
 	return &config, nil
 }
 
+func bindCadenceFlags(v *viper.Viper, fs *pflag.FlagSet) error {
+	for _, key := range []string{
+		"enabled", "min", "max", "burst", "speed-up", "back-off",
+		"quiet-hours", "quiet-min", "timezone",
+	} {
+		if err := v.BindPFlag("cadence."+key, fs.Lookup("cadence-"+key)); err != nil {
+			return fmt.Errorf("failed to bind cadence-%s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+func bindRelevanceFlags(v *viper.Viper, fs *pflag.FlagSet) error {
+	for _, key := range []string{
+		"enabled", "threshold", "keyword-weight", "embedding-enabled",
+		"embedding-weight", "prior-weight", "default-prior",
+	} {
+		if err := v.BindPFlag("relevance."+key, fs.Lookup("relevance-"+key)); err != nil {
+			return fmt.Errorf("failed to bind relevance-%s: %w", key, err)
+		}
+	}
+	return nil
+}
+
 func bindSearchFlags(v *viper.Viper, fs *pflag.FlagSet) error {
 	bindings := map[string]string{
 		"search.provider.brave.enable":                                     "search-provider-brave-enable",
@@ -19,7 +19,14 @@ func TestLoadConfigDefaults(t *testing.T) {
 	assert.Equal(t, 30*time.Second, cfg.HTTPTimeout)
 	assert.Equal(t, "localhost", cfg.Postgres.Host)
 	assert.Equal(t, "nats", cfg.MessengerType)
+	assert.Equal(t, "file", cfg.RegistrySource)
 	require.NotNil(t, cfg.Messenger)
+	assert.False(t, cfg.Cadence.Enabled)
+	assert.Equal(t, 5*time.Minute, cfg.Cadence.Min)
+	assert.Equal(t, "0-7", cfg.Cadence.QuietHours)
+	assert.False(t, cfg.Relevance.Enabled)
+	assert.Equal(t, 0.6, cfg.Relevance.Threshold)
+	assert.Equal(t, 0.5, cfg.Relevance.PriorWeight)
 }
 
 func TestLoadConfigShippedConfig(t *testing.T) {
@@ -33,6 +40,39 @@ func TestLoadConfigShippedConfig(t *testing.T) {
 	assert.Equal(t, "postgres", cfg.Postgres.Host)
 	assert.Equal(t, "prism.discovery", cfg.Telemetry.ServiceName)
 	assert.Equal(t, "/logs/app.log", cfg.Logger.File.File)
+	assert.True(t, cfg.Cadence.Enabled)
+	assert.Equal(t, 6*time.Hour, cfg.Cadence.Max)
+	assert.Equal(t, 5, cfg.Cadence.Burst, "unset keys fall back to flag defaults")
+	policy, err := cfg.Cadence.Policy()
+	require.NoError(t, err)
+	assert.NotNil(t, policy)
+	assert.True(t, cfg.Relevance.Enabled)
+	assert.False(t, cfg.Relevance.EmbeddingEnabled)
+	assert.Equal(t, 0.8, cfg.Relevance.Priors["cna"])
+	assert.Equal(t, 1.0, cfg.Relevance.EmbeddingWeight, "unset keys fall back to flag defaults")
+}
+
+func TestLoadConfigRejectsInvalidCadence(t *testing.T) {
+	_, err := LoadConfig([]string{"--cadence-enabled", "--cadence-quiet-hours=night"})
+	require.ErrorContains(t, err, "cadence config")
+
+	_, err = LoadConfig([]string{"--cadence-enabled", "--cadence-min=2h", "--cadence-max=1h"})
+	require.ErrorContains(t, err, "cadence config")
+}
+
+func TestLoadConfigRelevance(t *testing.T) {
+	_, err := LoadConfig([]string{"--relevance-threshold=1.5"})
+	require.ErrorContains(t, err, "Threshold")
+
+	_, err = LoadConfig([]string{"--relevance-enabled", "--relevance-embedding-enabled"})
+	require.ErrorContains(t, err, "llm config")
+
+	cfg, err := LoadConfig([]string{
+		"--relevance-enabled", "--relevance-embedding-enabled",
+		"--llm-provider=ollama", "--llm-model=embeddinggemma",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "embeddinggemma", cfg.LLM.Model)
 }
 
 func setShippedConfigEnv(t *testing.T) {
@@ -158,6 +198,7 @@ func TestLoadConfigValidationFailed(t *testing.T) {
 		{name: "health-port too low", args: []string{"--health-port=1"}},
 		{name: "http-timeout too short", args: []string{"--http-timeout=0s"}},
 		{name: "invalid messenger type", args: []string{"--messenger-type=invalid"}},
+		{name: "invalid registry source", args: []string{"--registry-source=etcd"}},
 	}
 
 	for _, tt := range tests {
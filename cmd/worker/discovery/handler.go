@@ -6,12 +6,15 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/url"
 	"strings"
 	"time"
 
 	"github.com/ChiaYuChang/prism/internal/discovery"
+	"github.com/ChiaYuChang/prism/internal/discovery/cadence"
 	"github.com/ChiaYuChang/prism/internal/discovery/planner"
+	"github.com/ChiaYuChang/prism/internal/discovery/search"
 	discoverysink "github.com/ChiaYuChang/prism/internal/discovery/sink"
 	"github.com/ChiaYuChang/prism/internal/message"
 	"github.com/ChiaYuChang/prism/internal/model"
@@ -26,6 +29,10 @@ import (
 
 const (
 	SpanNameHandleMessage = "worker.discovery.handle_message"
+
+	// recordRunTimeout bounds the task_runs write, which runs detached from
+	// the message context.
+	recordRunTimeout = 5 * time.Second
 )
 
 var (
@@ -35,6 +42,9 @@ var (
 	ErrUnsupportedSourceType                    = errors.New("unsupported source type")
 	ErrUnsupportedTaskKindSourceTypeCombination = errors.New("unsupported task kind/source type combination")
 	ErrSourceMismatch                           = errors.New("source mismatch")
+	ErrScoutFailed                              = errors.New("scout failed")
+	ErrSearchFailed                             = errors.New("search failed")
+	ErrSinkFailed                               = errors.New("sink failed")
 )
 
 type Handler struct {
@@ -46,6 +56,37 @@ type Handler struct {
 	scoutRepo repo.Scout
 	reporter  repo.TaskReporter
 	metrics   *metrics
+	cadence   *cadence.Policy
+	taskRuns  repo.TaskRuns
+}
+
+// HandlerOption configures optional Handler behaviour.
+type HandlerOption func(*Handler)
+
+// WithCadence lets policy pick the next run of recurring DIRECTORY_FETCH
+// tasks from the number of new candidates each run found, instead of
+// tasks.frequency. The decision is merged into tasks.meta.
+func WithCadence(policy *cadence.Policy) HandlerOption {
+	return func(h *Handler) {
+		h.cadence = policy
+	}
+}
+
+// WithTaskRuns records every DIRECTORY_FETCH and KEYWORD_SEARCH execution in
+// runs, so yield per source, task and provider can be summarised later. A
+// failed write is logged and does not affect the task.
+func WithTaskRuns(runs repo.TaskRuns) HandlerOption {
+	return func(h *Handler) {
+		h.taskRuns = runs
+	}
+}
+
+// taskRun is what one execution saw, filled by process as far as it got.
+// Providers is only set for KEYWORD_SEARCH.
+type taskRun struct {
+	seen      int
+	query     string
+	providers map[string]repo.TaskRunProvider
 }
 
 type metrics struct {
@@ -184,6 +225,7 @@ func NewHandler(
 	scoutRepo repo.Scout,
 	reporter repo.TaskReporter,
 	metrics *metrics,
+	opts ...HandlerOption,
 ) (*Handler, error) {
 	if logger == nil {
 		return nil, fmt.Errorf("%w: logger", ErrParamMissing)
@@ -206,7 +248,7 @@ func NewHandler(
 	if searchProviders == nil {
 		searchProviders = map[string]discovery.SearchClient{}
 	}
-	return &Handler{
+	h := &Handler{
 		logger:    logger,
 		tracer:    tracer,
 		scout:     scout,
@@ -215,7 +257,11 @@ func NewHandler(
 		scoutRepo: scoutRepo,
 		reporter:  reporter,
 		metrics:   metrics,
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h, nil
 }
 
 // HandleMessage handles incoming task signals for discovery tasks.
@@ -256,7 +302,10 @@ func (h *Handler) HandleMessage(ctx context.Context, msg *wm.Message) (bool, err
 		slog.String("url", sig.URL),
 	)
 
-	if err := h.process(ctx, sig); err != nil {
+	var run taskRun
+	sunk, err := h.process(ctx, sig, &run)
+	h.recordRun(ctx, logger, sig, run, sunk, err, started)
+	if err != nil {
 		logger.ErrorContext(ctx, "discovery task failed", "error", err)
 		if failErr := h.reporter.FailTask(ctx, sig.TaskID); failErr != nil {
 			h.metrics.recordTask(ctx, sig, "nacked", started)
@@ -268,48 +317,155 @@ func (h *Handler) HandleMessage(ctx context.Context, msg *wm.Message) (bool, err
 		return true, err
 	}
 
-	if err := h.reporter.CompleteTask(ctx, sig.TaskID); err != nil {
+	if err := h.reporter.CompleteTask(ctx, h.completion(ctx, logger, sig, sunk)); err != nil {
 		h.metrics.recordTask(ctx, sig, "nacked", started)
 		return false, fmt.Errorf("complete task %s: %w", sig.TaskID, err)
 	}
 
 	h.metrics.recordTask(ctx, sig, "ok", started)
-	logger.InfoContext(ctx, "discovery task completed")
+	logger.InfoContext(ctx, "discovery task completed",
+		slog.Int("seen", run.seen),
+		slog.Int("stored", sunk.Stored),
+		slog.Int("new", sunk.New),
+		slog.Int("promoted", sunk.Promoted),
+	)
 	return true, nil
 }
 
-func (h *Handler) process(ctx context.Context, sig message.TaskSignal) error {
+// completion builds the CompleteTask call for sig. With a cadence policy, a
+// DIRECTORY_FETCH task's next run follows from its yield; a task meta the
+// policy cannot read restarts the task's cadence rather than failing it.
+func (h *Handler) completion(ctx context.Context, logger *slog.Logger, sig message.TaskSignal, sunk discoverysink.CandidateSinkResult) repo.CompleteTaskParams {
+	arg := repo.CompleteTaskParams{ID: sig.TaskID}
+	if h.cadence == nil || sig.Kind != repo.TaskKindDirectoryFetch {
+		return arg
+	}
+
+	prev, err := cadence.FromMeta(sig.Meta)
+	if err != nil {
+		logger.WarnContext(ctx, "ignoring unreadable cadence state", "error", err)
+	}
+	next := h.cadence.Next(prev, sunk.New, time.Now())
+	meta, err := next.Meta()
+	if err != nil {
+		logger.WarnContext(ctx, "encode cadence state; keeping task frequency", "error", err)
+		return arg
+	}
+	nextRunIn := next.NextRunIn()
+	arg.NextRunIn = &nextRunIn
+	arg.Meta = meta
+
+	logger.InfoContext(ctx, "directory fetch rescheduled",
+		slog.Int("new_candidates", sunk.New),
+		slog.Duration("next_run_in", nextRunIn),
+		slog.String("reason", next.Reason),
+	)
+	return arg
+}
+
+// recordRun writes one task_runs row for sig. Duplicates are stored
+// candidates that were already known, which UpsertCandidate only re-sees.
+func (h *Handler) recordRun(ctx context.Context, logger *slog.Logger, sig message.TaskSignal, run taskRun, sunk discoverysink.CandidateSinkResult, runErr error, started time.Time) {
+	if h.taskRuns == nil {
+		return
+	}
+	arg := repo.CreateTaskRunParams{
+		TaskID:         sig.TaskID,
+		BatchID:        sig.BatchID,
+		Kind:           sig.Kind,
+		SourceType:     sig.SourceType,
+		SourceAbbr:     sig.SourceAbbr,
+		Status:         repo.TaskRunStatusOK,
+		ItemsSeen:      int32(run.seen),
+		ItemsNew:       int32(sunk.New),
+		ItemsDuplicate: int32(max(sunk.Stored-sunk.New, 0)),
+		Providers:      run.providers,
+		Duration:       time.Since(started),
+		TraceID:        sig.TraceID,
+		StartedAt:      started,
+	}
+	if run.query != "" {
+		arg.Query = &run.query
+	}
+	if runErr != nil {
+		class := classifyRunError(runErr)
+		arg.Status = repo.TaskRunStatusFailed
+		arg.ErrorClass = &class
+	}
+	// The run already happened; record it even if the message context
+	// timed out or shutdown began, which is when a failed run is most
+	// worth keeping.
+	recordCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), recordRunTimeout)
+	defer cancel()
+	if _, err := h.taskRuns.Record(recordCtx, arg); err != nil {
+		logger.WarnContext(ctx, "record task run", "error", err)
+	}
+}
+
+// classifyRunError maps a run failure to a low-cardinality task_runs
+// error class. Causes are checked before stages, so a scout that timed out
+// is classed as a timeout rather than a scout failure.
+func classifyRunError(err error) string {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return "timeout"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, search.ErrRateLimited):
+		return "rate_limited"
+	case errors.Is(err, ErrInvalidTaskSignal):
+		return "invalid_task"
+	case errors.Is(err, ErrUnsupportedTaskKind),
+		errors.Is(err, ErrUnsupportedSourceType),
+		errors.Is(err, ErrUnsupportedTaskKindSourceTypeCombination):
+		return "unsupported"
+	case errors.Is(err, ErrSourceMismatch):
+		return "source_mismatch"
+	case errors.Is(err, ErrScoutFailed):
+		return "scout"
+	case errors.Is(err, ErrSearchFailed):
+		return "search"
+	case errors.Is(err, ErrSinkFailed):
+		return "sink"
+	default:
+		return "other"
+	}
+}
+
+func (h *Handler) process(ctx context.Context, sig message.TaskSignal, run *taskRun) (discoverysink.CandidateSinkResult, error) {
 	switch {
 	case sig.Kind == repo.TaskKindDirectoryFetch &&
-		(sig.SourceType == repo.SourceTypeParty || sig.SourceType == repo.SourceTypeMedia):
-		return h.handleDirectoryFetch(ctx, sig)
+		(repo.IsSeedSourceType(sig.SourceType) || sig.SourceType == repo.SourceTypeMedia):
+		return h.handleDirectoryFetch(ctx, sig, run)
 	case sig.Kind == repo.TaskKindKeywordSearch && sig.SourceType == repo.SourceTypeMedia:
-		return h.handleKeywordSearch(ctx, sig)
+		return h.handleKeywordSearch(ctx, sig, run)
 	default:
-		return fmt.Errorf("%w: kind=%s source_type=%s",
+		return discoverysink.CandidateSinkResult{}, fmt.Errorf("%w: kind=%s source_type=%s",
 			ErrUnsupportedTaskKindSourceTypeCombination, sig.Kind, sig.SourceType)
 	}
 }
 
-func (h *Handler) handleDirectoryFetch(ctx context.Context, sig message.TaskSignal) error {
+func (h *Handler) handleDirectoryFetch(ctx context.Context, sig message.TaskSignal, run *taskRun) (discoverysink.CandidateSinkResult, error) {
 	source, err := h.scoutRepo.GetSourceByAbbr(ctx, sig.SourceAbbr)
 	if err != nil {
-		return fmt.Errorf("get source by abbr %s: %w", sig.SourceAbbr, err)
+		return discoverysink.CandidateSinkResult{}, fmt.Errorf("get source by abbr %s: %w", sig.SourceAbbr, err)
 	}
 	if source.Type != sig.SourceType {
-		return fmt.Errorf("%w: db source type %s != signal source type %s",
+		return discoverysink.CandidateSinkResult{}, fmt.Errorf("%w: db source type %s != signal source type %s",
 			ErrSourceMismatch, source.Type, sig.SourceType)
 	}
 	if err := validateTaskURL(source.BaseURL, sig.URL); err != nil {
-		return err
+		return discoverysink.CandidateSinkResult{}, err
 	}
 
 	candidates, err := h.scout.Discover(ctx, sig.URL)
 	if err != nil {
-		return fmt.Errorf("discover candidates from %s: %w", sig.URL, err)
+		return discoverysink.CandidateSinkResult{}, fmt.Errorf("%w: discover candidates from %s: %w", ErrScoutFailed, sig.URL, err)
 	}
+	run.seen = len(candidates)
 
-	if err := h.sink.Handle(ctx, discoverysink.CandidateSinkRequest{
+	sunk, err := h.sink.Handle(ctx, discoverysink.CandidateSinkRequest{
 		SourceURL:       sig.URL,
 		SourceAbbr:      sig.SourceAbbr,
 		SourceType:      sig.SourceType,
@@ -322,25 +478,28 @@ func (h *Handler) handleDirectoryFetch(ctx context.Context, sig message.TaskSign
 			"source_type": sig.SourceType,
 		},
 		Candidates: candidates,
-	}); err != nil {
-		return fmt.Errorf("sink candidates from %s: %w", sig.URL, err)
+	})
+	if err != nil {
+		return sunk, fmt.Errorf("%w: candidates from %s: %w", ErrSinkFailed, sig.URL, err)
 	}
 
-	return nil
+	return sunk, nil
 }
 
-func (h *Handler) handleKeywordSearch(ctx context.Context, sig message.TaskSignal) error {
+func (h *Handler) handleKeywordSearch(ctx context.Context, sig message.TaskSignal, run *taskRun) (discoverysink.CandidateSinkResult, error) {
 	if len(h.providers) == 0 {
-		return fmt.Errorf("%w: no search providers enabled", ErrUnsupportedSourceType)
+		return discoverysink.CandidateSinkResult{}, fmt.Errorf("%w: no search providers enabled", ErrUnsupportedSourceType)
 	}
 
 	var payload planner.MediaTaskPayload
 	if err := json.Unmarshal(sig.Payload, &payload); err != nil {
-		return fmt.Errorf("decode keyword search payload: %w", err)
+		return discoverysink.CandidateSinkResult{}, fmt.Errorf("%w: decode keyword search payload: %w", ErrInvalidTaskSignal, err)
 	}
 	if strings.TrimSpace(payload.Query) == "" {
-		return fmt.Errorf("%w: empty query in payload", ErrInvalidTaskSignal)
+		return discoverysink.CandidateSinkResult{}, fmt.Errorf("%w: empty query in payload", ErrInvalidTaskSignal)
 	}
+	run.query = payload.Query
+	run.providers = make(map[string]repo.TaskRunProvider, len(h.providers))
 
 	var (
 		candidates []model.Candidates
@@ -348,7 +507,7 @@ func (h *Handler) handleKeywordSearch(ctx context.Context, sig message.TaskSigna
 	)
 	for provider, client := range h.providers {
 		started := time.Now()
-		found, err := client.DiscoverNews(ctx, payload.Query, payload.Site)
+		found, err := client.DiscoverNews(ctx, payload.SearchQuery())
 		duration := time.Since(started)
 		providerLabel, configLabel := normalizeSearchProvider(provider)
 		if err != nil {
@@ -359,10 +518,14 @@ func (h *Handler) handleKeywordSearch(ctx context.Context, sig message.TaskSigna
 				slog.String("query", payload.Query),
 				slog.Any("error", err),
 			)
-			failures = append(failures, fmt.Errorf("%s: %w", provider, err))
+			err = fmt.Errorf("%w: %s: %w", ErrSearchFailed, provider, err)
+			run.providers[provider] = repo.TaskRunProvider{ErrorClass: classifyRunError(err)}
+			failures = append(failures, err)
 			continue
 		}
 		h.metrics.recordSearch(ctx, providerLabel, configLabel, "ok", duration, len(found))
+		run.providers[provider] = repo.TaskRunProvider{Seen: int32(len(found))}
+		run.seen += len(found)
 		for i := range found {
 			if found[i].Metadata == nil {
 				found[i].Metadata = map[string]any{}
@@ -372,14 +535,17 @@ func (h *Handler) handleKeywordSearch(ctx context.Context, sig message.TaskSigna
 			if payload.Site != "" {
 				found[i].Metadata["site_filter"] = payload.Site
 			}
+			if len(payload.Alternatives) > 0 {
+				found[i].Metadata["alternatives"] = payload.Alternatives
+			}
 		}
 		candidates = append(candidates, found...)
 	}
 	if len(failures) == len(h.providers) {
-		return fmt.Errorf("search %q via enabled providers: %w", payload.Query, errors.Join(failures...))
+		return discoverysink.CandidateSinkResult{}, fmt.Errorf("search %q via enabled providers: %w", payload.Query, errors.Join(failures...))
 	}
 
-	if err := h.sink.Handle(ctx, discoverysink.CandidateSinkRequest{
+	sunk, err := h.sink.Handle(ctx, discoverysink.CandidateSinkRequest{
 		SourceURL:       sig.URL,
 		SourceAbbr:      sig.SourceAbbr,
 		SourceType:      sig.SourceType,
@@ -393,12 +559,15 @@ func (h *Handler) handleKeywordSearch(ctx context.Context, sig message.TaskSigna
 			"query":       payload.Query,
 			"site":        payload.Site,
 		},
-		Candidates: candidates,
-	}); err != nil {
-		return fmt.Errorf("sink search candidates for %q: %w", payload.Query, err)
+		Candidates:    candidates,
+		SeedPhrases:   payload.SearchQuery().Variants(),
+		SeedContentID: payload.SeedContentID,
+	})
+	if err != nil {
+		return sunk, fmt.Errorf("%w: search candidates for %q: %w", ErrSinkFailed, payload.Query, err)
 	}
 
-	return nil
+	return sunk, nil
 }
 
 // normalizeSearchProvider maps raw provider map keys to low-cardinality metric labels.
@@ -434,13 +603,14 @@ func searchProviderConfig(key, prefix string) string {
 //
 // - TaskKindDirectoryFetch:
 //   - SourceTypeParty
+//   - SourceTypeGovernment
 //   - SourceTypeMedia
 //
 // - TaskKindKeywordSearch:
 //   - SourceTypeMedia
 func ownsTask(sig message.TaskSignal) bool {
 	if sig.Kind == repo.TaskKindDirectoryFetch {
-		return sig.SourceType == repo.SourceTypeParty || sig.SourceType == repo.SourceTypeMedia
+		return repo.IsSeedSourceType(sig.SourceType) || sig.SourceType == repo.SourceTypeMedia
 	}
 	return sig.Kind == repo.TaskKindKeywordSearch && sig.SourceType == repo.SourceTypeMedia
 }
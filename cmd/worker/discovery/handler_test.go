@@ -4,13 +4,18 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 	"testing"
+	"time"
 
 	"github.com/ChiaYuChang/prism/internal/discovery"
+	"github.com/ChiaYuChang/prism/internal/discovery/cadence"
 	discoverymocks "github.com/ChiaYuChang/prism/internal/discovery/mocks"
 	"github.com/ChiaYuChang/prism/internal/discovery/planner"
+	"github.com/ChiaYuChang/prism/internal/discovery/search"
+	"github.com/ChiaYuChang/prism/internal/discovery/search/brave"
 	discoverysink "github.com/ChiaYuChang/prism/internal/discovery/sink"
 	sinkmocks "github.com/ChiaYuChang/prism/internal/discovery/sink/mocks"
 	"github.com/ChiaYuChang/prism/internal/message"
@@ -64,9 +69,9 @@ func TestHandlerHandleMessageCompletesTask(t *testing.T) {
 	sink.EXPECT().Handle(mock.Anything, mock.Anything).
 		Run(func(_ context.Context, req discoverysink.CandidateSinkRequest) {
 			last = &req
-		}).Return(nil)
+		}).Return(discoverysink.CandidateSinkResult{}, nil)
 
-	scheduler.EXPECT().CompleteTask(mock.Anything, taskID).Return(nil)
+	scheduler.EXPECT().CompleteTask(mock.Anything, repo.CompleteTaskParams{ID: taskID}).Return(nil)
 
 	payload, err := (&message.TaskSignal{
 		TaskID:     taskID,
@@ -89,6 +94,76 @@ func TestHandlerHandleMessageCompletesTask(t *testing.T) {
 	require.Equal(t, batchID, last.BatchID)
 }
 
+func TestHandlerHandleMessageAdaptsDirectoryFetchCadence(t *testing.T) {
+	taskID := uuid.Must(uuid.NewV7())
+
+	scout := discoverymocks.NewMockScout(t)
+	scoutRepo := repomocks.NewMockScout(t)
+	scheduler := repomocks.NewMockScheduler(t)
+	sink := sinkmocks.NewMockCandidateSink(t)
+
+	cfg := cadence.DefaultConfig()
+	cfg.QuietStart, cfg.QuietEnd = 0, 0
+	policy, err := cadence.New(cfg)
+	require.NoError(t, err)
+
+	h, err := NewHandler(
+		testLogger(),
+		noop.NewTracerProvider().Tracer("test"),
+		scout,
+		nil,
+		sink,
+		scoutRepo,
+		scheduler,
+		nil,
+		WithCadence(policy),
+	)
+	require.NoError(t, err)
+
+	scoutRepo.EXPECT().GetSourceByAbbr(mock.Anything, "dpp").Return(repo.Source{
+		Abbr:    "dpp",
+		Type:    repo.SourceTypeParty,
+		BaseURL: "https://www.dpp.org.tw",
+	}, nil)
+	scout.EXPECT().Discover(mock.Anything, "https://www.dpp.org.tw/media/00").Return(nil, nil)
+	sink.EXPECT().Handle(mock.Anything, mock.Anything).
+		Return(discoverysink.CandidateSinkResult{Stored: 3, New: 2}, nil)
+
+	var got repo.CompleteTaskParams
+	scheduler.EXPECT().CompleteTask(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, arg repo.CompleteTaskParams) {
+			got = arg
+		}).Return(nil)
+
+	prev, err := cadence.State{IntervalSeconds: 3600, IdleRuns: 4}.Meta()
+	require.NoError(t, err)
+	payload, err := (&message.TaskSignal{
+		TaskID:     taskID,
+		BatchID:    uuid.Must(uuid.NewV7()),
+		Kind:       repo.TaskKindDirectoryFetch,
+		SourceType: repo.SourceTypeParty,
+		SourceAbbr: "dpp",
+		URL:        "https://www.dpp.org.tw/media/00",
+		Meta:       prev,
+		TraceID:    "trace-123",
+	}).Marshal()
+	require.NoError(t, err)
+
+	ack, err := h.HandleMessage(context.Background(), wm.NewMessage("id", payload))
+	require.NoError(t, err)
+	require.True(t, ack)
+
+	require.Equal(t, taskID, got.ID)
+	require.NotNil(t, got.NextRunIn)
+	require.Equal(t, 30*time.Minute, *got.NextRunIn)
+	state, err := cadence.FromMeta(got.Meta)
+	require.NoError(t, err)
+	require.NotNil(t, state)
+	require.Equal(t, 2, state.NewCandidates)
+	require.Zero(t, state.IdleRuns)
+	require.Equal(t, "active: 2 new candidates, interval / 2", state.Reason)
+}
+
 func TestHandlerHandleMessageIgnoresUnsupportedTask(t *testing.T) {
 	taskID := uuid.Must(uuid.NewV7())
 
@@ -159,10 +234,10 @@ func TestHandlerHandleMessageNacksWhenCompleteFails(t *testing.T) {
 	scout.EXPECT().
 		Discover(mock.Anything, "https://www.dpp.org.tw/media/00").
 		Return([]model.Candidates{}, nil)
-	sink.EXPECT().Handle(mock.Anything, mock.Anything).Return(nil)
+	sink.EXPECT().Handle(mock.Anything, mock.Anything).Return(discoverysink.CandidateSinkResult{}, nil)
 
 	scheduler.EXPECT().
-		CompleteTask(mock.Anything, taskID).
+		CompleteTask(mock.Anything, repo.CompleteTaskParams{ID: taskID}).
 		Return(errors.New("db down"))
 
 	payload, err := (&message.TaskSignal{
@@ -208,7 +283,12 @@ func TestHandlerHandleMessageKeywordSearch(t *testing.T) {
 	require.NoError(t, err)
 
 	searchClient.EXPECT().
-		DiscoverNews(mock.Anything, "台灣半導體政策", "cna.com.tw").
+		DiscoverNews(mock.Anything, discovery.SearchQuery{
+			Text:         "台灣半導體政策",
+			Site:         "cna.com.tw",
+			Term:         "台灣",
+			Alternatives: []string{"臺灣"},
+		}).
 		Return([]model.Candidates{
 			{Title: "TSMC expands", URL: "https://example.com/tsmc"},
 			{Title: "Chip policy", URL: "https://example.com/chip"},
@@ -216,12 +296,16 @@ func TestHandlerHandleMessageKeywordSearch(t *testing.T) {
 	var last *discoverysink.CandidateSinkRequest
 	sink.EXPECT().Handle(mock.Anything, mock.Anything).Run(func(_ context.Context, req discoverysink.CandidateSinkRequest) {
 		last = &req
-	}).Return(nil)
-	scheduler.EXPECT().CompleteTask(mock.Anything, taskID).Return(nil)
+	}).Return(discoverysink.CandidateSinkResult{}, nil)
+	scheduler.EXPECT().CompleteTask(mock.Anything, repo.CompleteTaskParams{ID: taskID}).Return(nil)
 
+	seedID := uuid.Must(uuid.NewV7())
 	payloadBytes, err := json.Marshal(planner.MediaTaskPayload{
-		Query: "台灣半導體政策",
-		Site:  "cna.com.tw",
+		Query:         "台灣半導體政策",
+		Site:          "cna.com.tw",
+		Term:          "台灣",
+		Alternatives:  []string{"臺灣"},
+		SeedContentID: seedID,
 	})
 	require.NoError(t, err)
 
@@ -248,6 +332,9 @@ func TestHandlerHandleMessageKeywordSearch(t *testing.T) {
 	require.Len(t, last.Candidates, 2)
 	require.Equal(t, "TSMC expands", last.Candidates[0].Title)
 	require.Equal(t, "brave", last.Candidates[0].Metadata["search_provider"])
+	require.Equal(t, []string{"臺灣"}, last.Candidates[0].Metadata["alternatives"])
+	require.Equal(t, []string{"台灣半導體政策", "臺灣半導體政策"}, last.SeedPhrases)
+	require.Equal(t, seedID, last.SeedContentID)
 }
 
 func TestHandlerHandleMessageKeywordSearchNoProviders(t *testing.T) {
@@ -321,21 +408,21 @@ func TestHandlerHandleMessageKeywordSearchCompletesWhenOneProviderSucceeds(t *te
 	require.NoError(t, err)
 
 	braveClient.EXPECT().
-		DiscoverNews(mock.Anything, "台灣半導體政策", "tw.news.yahoo.com").
+		DiscoverNews(mock.Anything, discovery.SearchQuery{Text: "台灣半導體政策", Site: "tw.news.yahoo.com"}).
 		Return(nil, errors.New("rate limited"))
 
 	googleClient.EXPECT().
-		DiscoverNews(mock.Anything, "台灣半導體政策", "tw.news.yahoo.com").
+		DiscoverNews(mock.Anything, discovery.SearchQuery{Text: "台灣半導體政策", Site: "tw.news.yahoo.com"}).
 		Return([]model.Candidates{
 			{Title: "Yahoo article", URL: "https://tw.news.yahoo.com/a"},
 		}, nil)
 	var last *discoverysink.CandidateSinkRequest
 	sink.EXPECT().Handle(mock.Anything, mock.Anything).Run(func(_ context.Context, req discoverysink.CandidateSinkRequest) {
 		last = &req
-	}).Return(nil)
+	}).Return(discoverysink.CandidateSinkResult{}, nil)
 
 	scheduler.EXPECT().
-		CompleteTask(mock.Anything, taskID).
+		CompleteTask(mock.Anything, repo.CompleteTaskParams{ID: taskID}).
 		Return(nil)
 
 	payloadBytes, err := json.Marshal(planner.MediaTaskPayload{Query: "台灣半導體政策", Site: "tw.news.yahoo.com"})
@@ -395,10 +482,10 @@ func TestHandlerHandleMessageKeywordSearchRecordsSearchMetrics(t *testing.T) {
 	require.NoError(t, err)
 
 	braveClient.EXPECT().
-		DiscoverNews(mock.Anything, "台灣半導體政策", "tw.news.yahoo.com").
+		DiscoverNews(mock.Anything, discovery.SearchQuery{Text: "台灣半導體政策", Site: "tw.news.yahoo.com"}).
 		Return(nil, errors.New("rate limited"))
 	serpClient.EXPECT().
-		DiscoverNews(mock.Anything, "台灣半導體政策", "tw.news.yahoo.com").
+		DiscoverNews(mock.Anything, discovery.SearchQuery{Text: "台灣半導體政策", Site: "tw.news.yahoo.com"}).
 		Return([]model.Candidates{
 			{Title: "Yahoo article 1", URL: "https://tw.news.yahoo.com/a"},
 			{Title: "Yahoo article 2", URL: "https://tw.news.yahoo.com/b"},
@@ -409,9 +496,9 @@ func TestHandlerHandleMessageKeywordSearchRecordsSearchMetrics(t *testing.T) {
 		Handle(mock.Anything, mock.Anything).
 		Run(func(_ context.Context, req discoverysink.CandidateSinkRequest) {
 			last = &req
-		}).Return(nil)
+		}).Return(discoverysink.CandidateSinkResult{}, nil)
 	scheduler.EXPECT().
-		CompleteTask(mock.Anything, taskID).
+		CompleteTask(mock.Anything, repo.CompleteTaskParams{ID: taskID}).
 		Return(nil)
 
 	payloadBytes, err := json.Marshal(planner.MediaTaskPayload{
@@ -512,8 +599,8 @@ func TestHandlerHandleMessageDirectoryFetchMedia(t *testing.T) {
 	var last *discoverysink.CandidateSinkRequest
 	sink.EXPECT().Handle(mock.Anything, mock.Anything).Run(func(_ context.Context, req discoverysink.CandidateSinkRequest) {
 		last = &req
-	}).Return(nil)
-	scheduler.EXPECT().CompleteTask(mock.Anything, taskID).Return(nil)
+	}).Return(discoverysink.CandidateSinkResult{}, nil)
+	scheduler.EXPECT().CompleteTask(mock.Anything, repo.CompleteTaskParams{ID: taskID}).Return(nil)
 
 	payload, err := (&message.TaskSignal{
 		TaskID:     taskID,
@@ -535,6 +622,47 @@ func TestHandlerHandleMessageDirectoryFetchMedia(t *testing.T) {
 	require.Equal(t, repo.IngestionMethodDirectory, last.IngestionMethod)
 }
 
+func TestHandlerHandleMessageDirectoryFetchGovernment(t *testing.T) {
+	taskID := uuid.Must(uuid.NewV7())
+
+	scout := discoverymocks.NewMockScout(t)
+	scoutRepo := repomocks.NewMockScout(t)
+	scheduler := repomocks.NewMockScheduler(t)
+	sink := sinkmocks.NewMockCandidateSink(t)
+
+	h, err := NewHandler(testLogger(), noop.NewTracerProvider().Tracer("test"), scout, nil, sink, scoutRepo, scheduler, nil)
+	require.NoError(t, err)
+
+	const datasetURL = "https://www.ey.gov.tw/OpenData/api/ExecutiveYuan/NewsEy"
+	source := repo.Source{Abbr: "ey", Type: repo.SourceTypeGovernment, BaseURL: "https://www.ey.gov.tw"}
+	scoutRepo.EXPECT().GetSourceByAbbr(mock.Anything, "ey").Return(source, nil)
+	scout.EXPECT().Discover(mock.Anything, datasetURL).Return([]model.Candidates{
+		{Title: "Synthetic Cabinet release", URL: "https://www.ey.gov.tw/Page/9277F759E41CCD91/synthetic-0001"},
+	}, nil)
+	var last *discoverysink.CandidateSinkRequest
+	sink.EXPECT().Handle(mock.Anything, mock.Anything).Run(func(_ context.Context, req discoverysink.CandidateSinkRequest) {
+		last = &req
+	}).Return(discoverysink.CandidateSinkResult{}, nil)
+	scheduler.EXPECT().CompleteTask(mock.Anything, repo.CompleteTaskParams{ID: taskID}).Return(nil)
+
+	payload, err := (&message.TaskSignal{
+		TaskID:     taskID,
+		BatchID:    uuid.Must(uuid.NewV7()),
+		Kind:       repo.TaskKindDirectoryFetch,
+		SourceType: repo.SourceTypeGovernment,
+		SourceAbbr: "ey",
+		URL:        datasetURL,
+		TraceID:    "trace-government-dir",
+	}).Marshal()
+	require.NoError(t, err)
+
+	ack, err := h.HandleMessage(context.Background(), wm.NewMessage("id", payload))
+	require.NoError(t, err)
+	require.True(t, ack)
+	require.NotNil(t, last)
+	require.Equal(t, repo.SourceTypeGovernment, last.SourceType)
+}
+
 func TestHandlerHandleMessageRecordsMetrics(t *testing.T) {
 	reader := sdkmetric.NewManualReader()
 	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
@@ -557,8 +685,8 @@ func TestHandlerHandleMessageRecordsMetrics(t *testing.T) {
 	scoutRepo.EXPECT().GetSourceByAbbr(mock.Anything, "dpp").Return(source, nil).Twice()
 	scout.EXPECT().Discover(mock.Anything, "https://www.dpp.org.tw/media/ok").Return([]model.Candidates{}, nil)
 	scout.EXPECT().Discover(mock.Anything, "https://www.dpp.org.tw/media/fail").Return(nil, failedErr)
-	sink.EXPECT().Handle(mock.Anything, mock.Anything).Return(nil).Once()
-	scheduler.EXPECT().CompleteTask(mock.Anything, okTaskID).Return(nil)
+	sink.EXPECT().Handle(mock.Anything, mock.Anything).Return(discoverysink.CandidateSinkResult{}, nil).Once()
+	scheduler.EXPECT().CompleteTask(mock.Anything, repo.CompleteTaskParams{ID: okTaskID}).Return(nil)
 	scheduler.EXPECT().FailTask(mock.Anything, failTaskID).Return(nil)
 
 	tcs := []struct {
@@ -621,6 +749,176 @@ func TestHandlerHandleMessageRecordsMetrics(t *testing.T) {
 	require.Equal(t, uint64(len(tcs)), discoveryHistogramCount(t, rm, "prism.discovery.task.duration"))
 }
 
+func TestHandlerHandleMessageRecordsKeywordSearchRun(t *testing.T) {
+	taskID := uuid.Must(uuid.NewV7())
+	batchID := uuid.Must(uuid.NewV7())
+
+	braveClient := discoverymocks.NewMockSearchClient(t)
+	googleClient := discoverymocks.NewMockSearchClient(t)
+	scheduler := repomocks.NewMockScheduler(t)
+	sink := sinkmocks.NewMockCandidateSink(t)
+	taskRuns := repomocks.NewMockTaskRuns(t)
+
+	h, err := NewHandler(
+		testLogger(),
+		noop.NewTracerProvider().Tracer("test"),
+		discoverymocks.NewMockScout(t),
+		map[string]discovery.SearchClient{
+			"brave":      braveClient,
+			"google-cse": googleClient,
+		},
+		sink,
+		repomocks.NewMockScout(t),
+		scheduler,
+		nil,
+		WithTaskRuns(taskRuns),
+	)
+	require.NoError(t, err)
+
+	query := discovery.SearchQuery{Text: "NCC 開罰", Site: "tw.news.yahoo.com"}
+	braveClient.EXPECT().DiscoverNews(mock.Anything, query).Return(nil, brave.ErrRateLimited)
+	googleClient.EXPECT().DiscoverNews(mock.Anything, query).Return([]model.Candidates{
+		{Title: "A", URL: "https://tw.news.yahoo.com/a"},
+		{Title: "B", URL: "https://tw.news.yahoo.com/b"},
+		{Title: "C", URL: "https://tw.news.yahoo.com/c"},
+	}, nil)
+	sink.EXPECT().Handle(mock.Anything, mock.Anything).
+		Return(discoverysink.CandidateSinkResult{Stored: 3, New: 1}, nil)
+	scheduler.EXPECT().CompleteTask(mock.Anything, repo.CompleteTaskParams{ID: taskID}).Return(nil)
+
+	var got repo.CreateTaskRunParams
+	taskRuns.EXPECT().Record(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, arg repo.CreateTaskRunParams) {
+			got = arg
+		}).Return(repo.TaskRun{}, nil).Once()
+
+	payloadBytes, err := json.Marshal(planner.MediaTaskPayload{Query: "NCC 開罰", Site: "tw.news.yahoo.com"})
+	require.NoError(t, err)
+	sigPayload, err := (&message.TaskSignal{
+		TaskID:     taskID,
+		BatchID:    batchID,
+		Kind:       repo.TaskKindKeywordSearch,
+		SourceType: repo.SourceTypeMedia,
+		SourceAbbr: "yahoo",
+		URL:        "https://tw.news.yahoo.com",
+		Payload:    payloadBytes,
+		TraceID:    "trace-kw-run",
+	}).Marshal()
+	require.NoError(t, err)
+
+	ack, err := h.HandleMessage(context.Background(), wm.NewMessage("id", sigPayload))
+	require.NoError(t, err)
+	require.True(t, ack)
+
+	require.Equal(t, taskID, got.TaskID)
+	require.Equal(t, batchID, got.BatchID)
+	require.Equal(t, repo.TaskKindKeywordSearch, got.Kind)
+	require.Equal(t, "yahoo", got.SourceAbbr)
+	require.Equal(t, repo.TaskRunStatusOK, got.Status)
+	require.Nil(t, got.ErrorClass)
+	require.NotNil(t, got.Query)
+	require.Equal(t, "NCC 開罰", *got.Query)
+	require.Equal(t, int32(3), got.ItemsSeen)
+	require.Equal(t, int32(1), got.ItemsNew)
+	require.Equal(t, int32(2), got.ItemsDuplicate)
+	require.Equal(t, map[string]repo.TaskRunProvider{
+		"brave":      {ErrorClass: "rate_limited"},
+		"google-cse": {Seen: 3},
+	}, got.Providers)
+	require.Equal(t, "trace-kw-run", got.TraceID)
+	require.False(t, got.StartedAt.IsZero())
+}
+
+func TestHandlerHandleMessageRecordsFailedRun(t *testing.T) {
+	taskID := uuid.Must(uuid.NewV7())
+
+	scout := discoverymocks.NewMockScout(t)
+	scoutRepo := repomocks.NewMockScout(t)
+	scheduler := repomocks.NewMockScheduler(t)
+	taskRuns := repomocks.NewMockTaskRuns(t)
+
+	h, err := NewHandler(
+		testLogger(),
+		noop.NewTracerProvider().Tracer("test"),
+		scout,
+		nil,
+		sinkmocks.NewMockCandidateSink(t),
+		scoutRepo,
+		scheduler,
+		nil,
+		WithTaskRuns(taskRuns),
+	)
+	require.NoError(t, err)
+
+	scoutRepo.EXPECT().GetSourceByAbbr(mock.Anything, "dpp").Return(repo.Source{
+		Abbr:    "dpp",
+		Type:    repo.SourceTypeParty,
+		BaseURL: "https://www.dpp.org.tw",
+	}, nil)
+	scout.EXPECT().Discover(mock.Anything, "https://www.dpp.org.tw/media/00").
+		Return(nil, errors.New("site down"))
+	scheduler.EXPECT().FailTask(mock.Anything, taskID).Return(nil)
+
+	var got repo.CreateTaskRunParams
+	taskRuns.EXPECT().Record(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, arg repo.CreateTaskRunParams) {
+			got = arg
+		}).Return(repo.TaskRun{}, errors.New("db down")).Once()
+
+	payload := discoveryTaskPayload(t, taskID, repo.TaskKindDirectoryFetch, repo.SourceTypeParty, "https://www.dpp.org.tw/media/00")
+	ack, err := h.HandleMessage(context.Background(), wm.NewMessage("id", payload))
+	require.ErrorIs(t, err, ErrScoutFailed)
+	require.True(t, ack, "a failed history write must not change the ack")
+
+	require.Equal(t, repo.TaskRunStatusFailed, got.Status)
+	require.NotNil(t, got.ErrorClass)
+	require.Equal(t, "scout", *got.ErrorClass)
+	require.Nil(t, got.Query)
+	require.Nil(t, got.Providers)
+	require.Zero(t, got.ItemsSeen)
+}
+
+func TestRecordRunOutlivesMessageContext(t *testing.T) {
+	taskRuns := repomocks.NewMockTaskRuns(t)
+	h := &Handler{taskRuns: taskRuns}
+
+	taskRuns.EXPECT().Record(mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, _ repo.CreateTaskRunParams) {
+			require.NoError(t, ctx.Err(), "the write must not inherit the message cancellation")
+			_, ok := ctx.Deadline()
+			require.True(t, ok, "the write must still be bounded")
+		}).Return(repo.TaskRun{}, nil).Once()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	h.recordRun(ctx, testLogger(), message.TaskSignal{TaskID: uuid.Must(uuid.NewV7())}, taskRun{},
+		discoverysink.CandidateSinkResult{}, context.Canceled, time.Now())
+}
+
+func TestClassifyRunError(t *testing.T) {
+	tcs := []struct {
+		err  error
+		want string
+	}{
+		{err: fmt.Errorf("%w: discover: %w", ErrScoutFailed, context.DeadlineExceeded), want: "timeout"},
+		{err: context.Canceled, want: "canceled"},
+		{err: fmt.Errorf("%w: brave: %w", ErrSearchFailed, brave.ErrRateLimited), want: "rate_limited"},
+		{err: errors.Join(fmt.Errorf("%w: serpapi: %w", ErrSearchFailed, fmt.Errorf("serpapi: %w", search.ErrRateLimited))), want: "rate_limited"},
+		{err: fmt.Errorf("%w: empty query in payload", ErrInvalidTaskSignal), want: "invalid_task"},
+		{err: ErrUnsupportedTaskKindSourceTypeCombination, want: "unsupported"},
+		{err: fmt.Errorf("%w: base host a != task host b", ErrSourceMismatch), want: "source_mismatch"},
+		{err: fmt.Errorf("%w: discover: boom", ErrScoutFailed), want: "scout"},
+		{err: errors.Join(fmt.Errorf("%w: brave: boom", ErrSearchFailed)), want: "search"},
+		{err: fmt.Errorf("%w: upsert: boom", ErrSinkFailed), want: "sink"},
+		{err: errors.New("get source by abbr dpp: no rows"), want: "other"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.want, func(t *testing.T) {
+			require.Equal(t, tc.want, classifyRunError(tc.err))
+		})
+	}
+}
+
 // discoveryTaskPayload returns a marshaled TaskSignal payload for testing.
 func discoveryTaskPayload(t *testing.T, taskID uuid.UUID, kind, sourceType, rawURL string) []byte {
 	t.Helper()
@@ -12,8 +12,10 @@ import (
 	"time"
 
 	"github.com/ChiaYuChang/prism/internal/appconfig"
+	"github.com/ChiaYuChang/prism/internal/catalog"
 	"github.com/ChiaYuChang/prism/internal/dev"
 	"github.com/ChiaYuChang/prism/internal/discovery"
+	"github.com/ChiaYuChang/prism/internal/discovery/scout"
 	scoutconfig "github.com/ChiaYuChang/prism/internal/discovery/scout/config"
 	"github.com/ChiaYuChang/prism/internal/discovery/search/brave"
 	searchconfig "github.com/ChiaYuChang/prism/internal/discovery/search/config"
@@ -22,13 +24,17 @@ import (
 	discoverysink "github.com/ChiaYuChang/prism/internal/discovery/sink"
 	httpclient "github.com/ChiaYuChang/prism/internal/http/client"
 	"github.com/ChiaYuChang/prism/internal/infra"
+	llmfactory "github.com/ChiaYuChang/prism/internal/llm/factory"
 	"github.com/ChiaYuChang/prism/internal/message"
 	"github.com/ChiaYuChang/prism/internal/obs"
+	"github.com/ChiaYuChang/prism/internal/repo"
 	"github.com/ChiaYuChang/prism/internal/repo/pg"
 )
 
 const (
 	TracerName = "prism.worker.discovery"
+	// LedgerComponent names this worker in llm_usage rows and budgets.
+	LedgerComponent = "discovery"
 )
 
 func main() {
@@ -119,6 +125,23 @@ func main() {
 		monitor.SetStatus(obs.LevelError, "Failed to initialize scout config repository")
 		os.Exit(1)
 	}
+	if config.RegistrySource == catalog.SourcePostgres {
+		seeded, err := catalog.SeedScouts(ctx, dbRepo.Catalog(), scoutCfg)
+		if err != nil {
+			logger.Error("failed to seed scout configs", "error", err)
+			monitor.SetStatus(obs.LevelError, "Failed to seed scout configs")
+			os.Exit(1)
+		}
+		if seeded > 0 {
+			logger.Info("seeded scout configs from file", "path", config.ScoutConfigPath, "count", seeded)
+		}
+		scoutRepo, err = catalog.LoadScouts(ctx, dbRepo.Catalog())
+		if err != nil {
+			logger.Error("failed to load scout configs", "error", err)
+			monitor.SetStatus(obs.LevelError, "Failed to load scout configs")
+			os.Exit(1)
+		}
+	}
 
 	httpClientOptions := []httpclient.Option(nil)
 	if config.FixtureBase != "" {
@@ -140,8 +163,43 @@ func main() {
 		monitor.SetStatus(obs.LevelError, "Failed to build scout registry")
 		os.Exit(1)
 	}
+	scouts, err := scout.NewSwappable(scoutRegistry)
+	if err != nil {
+		logger.Error("failed to build scout registry", "error", err)
+		monitor.SetStatus(obs.LevelError, "Failed to build scout registry")
+		os.Exit(1)
+	}
+	if config.RegistrySource == catalog.SourcePostgres {
+		reload := func(ctx context.Context) error {
+			scoutRepo, err := catalog.LoadScouts(ctx, dbRepo.Catalog())
+			if err != nil {
+				return err
+			}
+			registry, err := scoutconfig.BuildRegistry(scoutRepo, logger, tracer, httpClient)
+			if err != nil {
+				return err
+			}
+			scouts.Swap(registry)
+			return nil
+		}
+		if err := catalog.Listen(ctx, logger, config.Postgres.ConnString(), repo.CatalogScoutConfigs, reload); err != nil {
+			logger.Error("failed to watch scout configs", "error", err)
+			monitor.SetStatus(obs.LevelError, "Failed to watch scout configs")
+			os.Exit(1)
+		}
+	}
 
-	sink, err := discoverysink.NewPersistingCandidateSink(logger, tracer, dbRepo.Scout(), dbRepo.Tasks())
+	var sinkOpts []discoverysink.Option
+	if config.Relevance.Enabled {
+		scorer, err := buildRelevanceScorer(ctx, config, dbRepo, logger)
+		if err != nil {
+			logger.Error("failed to build relevance scorer", "error", err)
+			monitor.SetStatus(obs.LevelError, "Failed to build relevance scorer")
+			os.Exit(1)
+		}
+		sinkOpts = append(sinkOpts, discoverysink.WithRelevance(scorer, config.Relevance.Threshold))
+	}
+	sink, err := discoverysink.NewPersistingCandidateSink(logger, tracer, dbRepo.Scout(), dbRepo.Tasks(), sinkOpts...)
 	if err != nil {
 		logger.Error("failed to build candidate sink", "error", err)
 		monitor.SetStatus(obs.LevelError, "Failed to build candidate sink")
@@ -155,15 +213,27 @@ func main() {
 		os.Exit(1)
 	}
 
+	handlerOpts := []HandlerOption{WithTaskRuns(dbRepo.TaskRuns())}
+	policy, err := config.Cadence.Policy()
+	if err != nil {
+		logger.Error("failed to build cadence policy", "error", err)
+		monitor.SetStatus(obs.LevelError, "Failed to build cadence policy")
+		os.Exit(1)
+	}
+	if policy != nil {
+		handlerOpts = append(handlerOpts, WithCadence(policy))
+	}
+
 	handler, err := NewHandler(
 		logger,
 		tracer,
-		scoutRegistry,
+		scouts,
 		searchProviders,
 		sink,
 		dbRepo.Scout(),
 		dbRepo.Scheduler(),
 		metrics,
+		handlerOpts...,
 	)
 	if err != nil {
 		logger.Error("failed to build discovery handler", "error", err)
@@ -184,7 +254,11 @@ func main() {
 		"topic", message.TaskTopic,
 		"messenger", config.MessengerType,
 		"scout_config", config.ScoutConfigPath,
+		"registry_source", config.RegistrySource,
 		"http_timeout", config.HTTPTimeout,
+		"cadence", config.Cadence.Enabled,
+		"relevance", config.Relevance.Enabled,
+		"relevance_threshold", config.Relevance.Threshold,
 		"started_at", started,
 	)
 	defer func() {
@@ -279,6 +353,36 @@ func buildSearchProviders(config *Config, httpClient *http.Client, logger *slog.
 	return providers, nil
 }
 
+// buildRelevanceScorer combines the enabled relevance signals. The
+// embedding signal needs the --llm-model embedder registered in models;
+// when it is not, the worker scores without it.
+func buildRelevanceScorer(ctx context.Context, config *Config, dbRepo repo.Repository, logger *slog.Logger) (discoverysink.Scorer, error) {
+	cfg := config.Relevance
+	signals := []discoverysink.WeightedSignal{
+		{Signal: discoverysink.KeywordSignal{}, Weight: cfg.KeywordWeight},
+		{Signal: discoverysink.SourcePriorSignal{Priors: cfg.Priors, Default: cfg.DefaultPrior}, Weight: cfg.PriorWeight},
+	}
+	if cfg.EmbeddingEnabled {
+		m, err := dbRepo.Embedding().GetModelByNameAndType(ctx, config.LLM.Model, repo.ModelTypeEmbedder)
+		if err != nil {
+			logger.Warn("embedding model is not registered in models; relevance scoring will skip seed similarity",
+				"model", config.LLM.Model, "error", err)
+		} else {
+			embedder, err := llmfactory.NewEmbedder(ctx, config.LLM, logger,
+				llmfactory.WithUsageLedger(llmfactory.NewRepoLedger(dbRepo.LLMUsage()), LedgerComponent))
+			if err != nil {
+				return nil, fmt.Errorf("initialize embedder: %w", err)
+			}
+			signal, err := discoverysink.NewEmbeddingSignal(embedder, dbRepo.Embedding(), config.LLM.Model, m.ID)
+			if err != nil {
+				return nil, err
+			}
+			signals = append(signals, discoverysink.WeightedSignal{Signal: signal, Weight: cfg.EmbeddingWeight})
+		}
+	}
+	return discoverysink.NewWeightedScorer(logger, signals...)
+}
+
 func addSerpAPIProviders(providers map[string]discovery.SearchClient, cfg searchconfig.SerpAPIConfig, httpClient *http.Client, logger *slog.Logger) error {
 	if !cfg.Enable {
 		return nil
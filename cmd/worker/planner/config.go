@@ -26,7 +26,22 @@ type Config struct {
 	Messenger     app.MessengerConfig `mapstructure:"-"`
 	LLM           app.LLMConfig       `mapstructure:"llm"`
 	PromptPath    string              `mapstructure:"prompt-path"    validate:"required"`
-	Search        searchconfig.Config `mapstructure:"search"`
+	// PromptCandidate names a sibling version of PromptPath
+	// ("extractor@v2.md" for "v2") that receives PromptPercent percent of
+	// extractions. Empty disables the A/B split.
+	PromptCandidate string              `mapstructure:"prompt-candidate"`
+	PromptPercent   int                 `mapstructure:"prompt-percent" validate:"min=0,max=100"`
+	Search          searchconfig.Config `mapstructure:"search"`
+	Expansion       ExpansionConfig     `mapstructure:"expansion"`
+}
+
+// ExpansionConfig configures planner query expansion. Enabled expands
+// phrases with the search_aliases dictionary; MaxQueriesPerSeed caps every
+// seed's phrases whether or not expansion is enabled.
+type ExpansionConfig struct {
+	Enabled           bool `mapstructure:"enabled"`
+	MaxAlternatives   int  `mapstructure:"max-alternatives"     validate:"min=0"`
+	MaxQueriesPerSeed int  `mapstructure:"max-queries-per-seed" validate:"min=0"`
 }
 
 func LoadConfig(args []string) (*Config, error) {
@@ -59,12 +74,33 @@ func LoadConfig(args []string) (*Config, error) {
 	fs.Int64("channel-buffer", 100, "GoChannel output buffer size")
 	fs.Bool("persistent", true, "Whether GoChannel should persist messages in memory")
 
-	fs.String("llm-provider", "gemini", "LLM provider (gemini, openai, ollama)")
+	fs.String("llm-provider", "gemini", "LLM provider (gemini, openai, ollama, anthropic, openai-compatible)")
 	fs.String("llm-key", "", "LLM API key")
 	fs.String("llm-model", "", "LLM model name (e.g. gemini-2.0-flash)")
 	fs.Duration("llm-timeout", 30*time.Second, "LLM request timeout")
+	fs.String("llm-url", "", "Base URL of an openai-compatible LLM server (e.g. http://vllm:8000/v1)")
+	fs.String("llm-header", "", "Header carrying the LLM key for openai-compatible servers (default Authorization: Bearer)")
+	fs.Bool("llm-embeddings", false, "Whether the openai-compatible server serves /embeddings")
+	fs.Int("llm-retry-attempts", 3, "Attempts per LLM provider on 429/5xx/timeouts (1 disables retries)")
+	fs.Duration("llm-retry-backoff", 500*time.Millisecond, "Initial LLM retry backoff")
+	fs.Duration("llm-retry-cap", 10*time.Second, "Maximum LLM retry backoff")
+	fs.Int("llm-breaker-threshold", 0, "Consecutive LLM provider failures that open its circuit breaker (0 disables)")
+	fs.Duration("llm-breaker-cooldown", time.Minute, "How long an open LLM circuit breaker rejects calls")
+	fs.Bool("llm-ledger-enabled", false, "Record priced LLM usage in Postgres and enforce the planner budget")
+	fs.Float64("llm-ledger-daily", 0, "Planner LLM budget per UTC day in USD (0 disables)")
+	fs.Float64("llm-ledger-monthly", 0, "Planner LLM budget per UTC month in USD (0 disables)")
+	fs.Bool("llm-cache-enabled", false, "Enable the LLM response cache")
+	fs.String("llm-cache-backend", "memory", "LLM response cache backend (memory, valkey)")
+	fs.Duration("llm-cache-ttl", 7*24*time.Hour, "LLM response cache entry TTL")
+	fs.Bool("llm-cache-bypass", false, "Skip LLM cache lookups but keep writing fresh responses")
+	fs.String("llm-cache-prefix", "prism:", "Key prefix for the Valkey LLM response cache")
 
 	fs.String("prompt-path", DefaultPromptPath, "Path to the extractor prompt file")
+	fs.String("prompt-candidate", "", "Candidate extractor prompt version for A/B evaluation (sibling file <name>@<version>.md)")
+	fs.Int("prompt-percent", 0, "Percent of extractions routed to the candidate prompt version (0-100)")
+	fs.Bool("expansion-enabled", false, "Expand keyword phrases with the search_aliases dictionary")
+	fs.Int("expansion-max-alternatives", 3, "Alias alternatives per expanded phrase (0 keeps all)")
+	fs.Int("expansion-max-queries-per-seed", 0, "Keyword phrases one seed content may add to a plan (0 disables the cap)")
 	fs.Bool("search-target-yahoo-enable", false, "Enable Yahoo News keyword-search target")
 	fs.String("search-target-yahoo-source-abbr", "yahoo", "Yahoo News source abbreviation for search candidates")
 	fs.String("search-target-yahoo-url", "https://tw.news.yahoo.com", "Yahoo News target URL")
@@ -87,6 +123,9 @@ func LoadConfig(args []string) (*Config, error) {
 	if err := bindSearchFlags(v, fs); err != nil {
 		return nil, err
 	}
+	if err := bindExpansionFlags(v, fs); err != nil {
+		return nil, err
+	}
 
 	var config Config
 	if err := config.Postgres.BindFlags(v, fs); err != nil {
@@ -167,3 +206,12 @@ func bindSearchFlags(v *viper.Viper, fs *pflag.FlagSet) error {
 	}
 	return nil
 }
+
+func bindExpansionFlags(v *viper.Viper, fs *pflag.FlagSet) error {
+	for _, key := range []string{"enabled", "max-alternatives", "max-queries-per-seed"} {
+		if err := v.BindPFlag("expansion."+key, fs.Lookup("expansion-"+key)); err != nil {
+			return fmt.Errorf("failed to bind expansion-%s: %w", key, err)
+		}
+	}
+	return nil
+}
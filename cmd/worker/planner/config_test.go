@@ -21,6 +21,31 @@ func TestLoadConfigShippedConfig(t *testing.T) {
 	require.Equal(t, "gemini", cfg.LLM.Provider)
 	require.Equal(t, "gemini-2.0-flash", cfg.LLM.Model)
 	require.Equal(t, "prism.planner", cfg.Telemetry.ServiceName)
+	require.Equal(t, 3, cfg.LLM.Retry.Attempts)
+	require.Equal(t, 5, cfg.LLM.Breaker.Threshold)
+	require.Equal(t, time.Minute, cfg.LLM.Breaker.Cooldown)
+	require.True(t, cfg.LLM.Ledger.Enabled)
+	require.Equal(t, 5.0, cfg.LLM.Ledger.Daily)
+	require.Equal(t, "gemini-2.0-flash", cfg.LLM.Ledger.Prices[0].Model)
+	require.Equal(t, ExpansionConfig{Enabled: true, MaxAlternatives: 3, MaxQueriesPerSeed: 8}, cfg.Expansion)
+}
+
+func TestLoadConfigExpansionFlags(t *testing.T) {
+	cfg, err := LoadConfig([]string{"--llm-model", "gemini-test"})
+	require.NoError(t, err)
+	require.Equal(t, ExpansionConfig{MaxAlternatives: 3}, cfg.Expansion)
+
+	cfg, err = LoadConfig([]string{
+		"--llm-model", "gemini-test",
+		"--expansion-enabled",
+		"--expansion-max-alternatives", "0",
+		"--expansion-max-queries-per-seed", "5",
+	})
+	require.NoError(t, err)
+	require.Equal(t, ExpansionConfig{Enabled: true, MaxQueriesPerSeed: 5}, cfg.Expansion)
+
+	_, err = LoadConfig([]string{"--llm-model", "gemini-test", "--expansion-max-queries-per-seed", "-1"})
+	require.Error(t, err)
 }
 
 func setShippedConfigEnv(t *testing.T) {
@@ -100,3 +125,51 @@ func TestLoadConfigSearchTargetsFromJSON(t *testing.T) {
 	require.Len(t, targets, 1)
 	require.Equal(t, "https://tw.news.yahoo.com", targets[0].URL)
 }
+
+func TestLoadConfigLLMFallbacksFromYAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	body := []byte(`
+llm:
+  model: gemini-test
+  fallbacks:
+    - provider: openai
+      model: gpt-4o-mini
+    - provider: ollama
+      model: llama3.1:8b
+      structured-output: false
+`)
+	require.NoError(t, os.WriteFile(path, body, 0600))
+
+	cfg, err := LoadConfig([]string{"--config", path, "--llm-breaker-threshold", "2"})
+	require.NoError(t, err)
+	require.Len(t, cfg.LLM.Fallbacks, 2)
+	require.Equal(t, "openai", cfg.LLM.Fallbacks[0].Provider)
+	require.Nil(t, cfg.LLM.Fallbacks[0].StructuredOutput)
+	require.NotNil(t, cfg.LLM.Fallbacks[1].StructuredOutput)
+	require.False(t, *cfg.LLM.Fallbacks[1].StructuredOutput)
+	require.Equal(t, 2, cfg.LLM.Breaker.Threshold)
+	require.True(t, cfg.LLM.Chained())
+}
+
+func TestLoadConfigLLMLedgerFromYAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	body := []byte(`
+llm:
+  model: gemini-test
+  ledger:
+    enabled: true
+    prices:
+      - model: gemini-2.0-flash
+        input: 0.1
+        output: 0.4
+`)
+	require.NoError(t, os.WriteFile(path, body, 0600))
+
+	cfg, err := LoadConfig([]string{"--config", path, "--llm-ledger-daily", "2.5"})
+	require.NoError(t, err)
+	require.True(t, cfg.LLM.Ledger.Enabled)
+	require.Equal(t, 2.5, cfg.LLM.Ledger.Daily)
+	require.Zero(t, cfg.LLM.Ledger.Monthly)
+	require.Len(t, cfg.LLM.Ledger.Prices, 1)
+	require.Equal(t, 0.4, cfg.LLM.Ledger.Prices[0].Output)
+}
@@ -80,6 +80,8 @@ func (h *Handler) HandleMessage(ctx context.Context, msg *wm.Message) (bool, err
 		slog.Int("seed_contents", result.SeedContents),
 		slog.Int("extractions", result.Extractions),
 		slog.Int("unique_phrases", result.UniquePhrases),
+		slog.Int("expanded_phrases", result.ExpandedPhrases),
+		slog.Int("capped_phrases", result.CappedPhrases),
 		slog.Int("tasks_created", result.TasksCreated),
 	)
 	return true, nil
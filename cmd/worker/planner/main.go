@@ -14,11 +14,15 @@ import (
 	llmfactory "github.com/ChiaYuChang/prism/internal/llm/factory"
 	"github.com/ChiaYuChang/prism/internal/message"
 	"github.com/ChiaYuChang/prism/internal/obs"
+	"github.com/ChiaYuChang/prism/internal/prompt"
+	"github.com/ChiaYuChang/prism/internal/repo"
 	"github.com/ChiaYuChang/prism/internal/repo/pg"
 )
 
 const (
 	TracerName = "prism.worker.planner"
+	// LedgerComponent names this worker in llm_usage rows and budgets.
+	LedgerComponent = "planner"
 )
 
 func main() {
@@ -80,28 +84,50 @@ func main() {
 	}
 	defer func() { _ = dbRepoCloser.Close() }()
 
-	generator, err := llmfactory.NewGenerator(ctx, config.LLM, logger)
+	generator, err := llmfactory.NewGenerator(ctx, config.LLM, logger,
+		llmfactory.WithUsageLedger(llmfactory.NewRepoLedger(dbRepo.LLMUsage()), LedgerComponent))
 	if err != nil {
 		logger.Error("failed to initialize LLM generator", "provider", config.LLM.Provider, "error", err)
 		monitor.SetStatus(obs.LevelError, "Failed to initialize LLM generator")
 		os.Exit(1)
 	}
 
-	prompt, err := os.ReadFile(config.PromptPath)
+	prompts, err := prompt.LoadFileSplit(config.PromptPath, config.PromptCandidate, config.PromptPercent)
 	if err != nil {
-		logger.Error("failed to read prompt file", "path", config.PromptPath, "error", err)
-		monitor.SetStatus(obs.LevelError, "Failed to read prompt file")
+		logger.Error("failed to load prompt", "path", config.PromptPath, "candidate", config.PromptCandidate, "error", err)
+		monitor.SetStatus(obs.LevelError, "Failed to load prompt")
+		os.Exit(1)
+	}
+	// Registration pins every extraction to the exact prompt text; a
+	// version whose file changed since it was registered must be renamed.
+	prompts, err = prompts.Register(ctx, dbRepo.Analysis(), map[string]any{"component": LedgerComponent})
+	if err != nil {
+		logger.Error("failed to register prompt", "path", config.PromptPath, "error", err)
+		monitor.SetStatus(obs.LevelError, "Failed to register prompt")
 		os.Exit(1)
 	}
 
-	ext, err := extractor.NewExtractor(generator, logger, tracer, config.LLM.Model, string(prompt))
+	ext, err := extractor.NewSplitExtractor(generator, logger, tracer, config.LLM.Model, prompts)
 	if err != nil {
 		logger.Error("failed to initialize extractor", "error", err)
 		monitor.SetStatus(obs.LevelError, "Failed to initialize extractor")
 		os.Exit(1)
 	}
 
-	plan, err := planner.New(logger, tracer, ext, dbRepo.Tasks(), dbRepo.Pipeline())
+	var planOpts []planner.Option
+	if m, err := dbRepo.Embedding().GetModelByNameAndType(ctx, config.LLM.Model, repo.ModelTypeExtractor); err != nil {
+		logger.Warn("extractor model is not registered in models; extractions will not be recorded",
+			"model", config.LLM.Model, "error", err)
+	} else {
+		planOpts = append(planOpts, planner.WithExtractionStore(dbRepo.Analysis(), m.ID))
+	}
+
+	if config.Expansion.Enabled {
+		planOpts = append(planOpts, planner.WithAliases(dbRepo.Aliases(), config.Expansion.MaxAlternatives))
+	}
+	planOpts = append(planOpts, planner.WithMaxQueriesPerSeed(config.Expansion.MaxQueriesPerSeed))
+
+	plan, err := planner.New(logger, tracer, ext, dbRepo.Tasks(), dbRepo.Pipeline(), planOpts...)
 	if err != nil {
 		logger.Error("failed to initialize planner", "error", err)
 		monitor.SetStatus(obs.LevelError, "Failed to initialize planner")
@@ -129,6 +155,9 @@ func main() {
 		"llm_provider", config.LLM.Provider,
 		"llm_model", config.LLM.Model,
 		"prompt_path", config.PromptPath,
+		"prompt_version", prompts.Control.Version,
+		"prompt_candidate", config.PromptCandidate,
+		"prompt_percent", config.PromptPercent,
 	)
 	monitor.OK()
 
@@ -0,0 +1,28 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS llm_usage (
+    id            BIGSERIAL PRIMARY KEY,
+    provider      VARCHAR(32) NOT NULL,
+    model         VARCHAR(64) NOT NULL,
+    operation     VARCHAR(16) NOT NULL,
+    component     VARCHAR(32) NOT NULL,
+    input_tokens  INTEGER NOT NULL DEFAULT 0,
+    output_tokens INTEGER NOT NULL DEFAULT 0,
+    cached_tokens INTEGER NOT NULL DEFAULT 0,
+    total_tokens  INTEGER NOT NULL DEFAULT 0,
+    cost_micros   BIGINT NOT NULL DEFAULT 0,
+    trace_id      VARCHAR(100) NOT NULL,
+    batch_id      UUID,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE llm_usage IS 'Append-only ledger of LLM provider calls. One row per upstream call; cache hits are not recorded.';
+COMMENT ON COLUMN llm_usage.cost_micros IS 'Priced cost in micro-USD at record time. Zero when the model has no configured price.';
+COMMENT ON COLUMN llm_usage.batch_id IS 'Batch being processed when known. No FK: the ledger outlives batch cleanup.';
+
+CREATE INDEX IF NOT EXISTS idx_llm_usage_component_created_at ON llm_usage(component, created_at);
+CREATE INDEX IF NOT EXISTS idx_llm_usage_created_at ON llm_usage(created_at);
+CREATE INDEX IF NOT EXISTS idx_llm_usage_batch_id ON llm_usage(batch_id) WHERE batch_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_llm_usage_trace_id ON llm_usage(trace_id);
+
+COMMIT;
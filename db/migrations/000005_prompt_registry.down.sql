@@ -0,0 +1,34 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_content_extractions_prompt_version;
+ALTER TABLE content_extractions DROP COLUMN IF EXISTS prompt_version;
+
+DROP INDEX IF EXISTS idx_prompts_hash;
+ALTER TABLE prompts DROP CONSTRAINT IF EXISTS prompts_name_version_key;
+-- Several versions may share a body; fold them into the oldest row so the
+-- hash can be unique again.
+WITH keep AS (
+    SELECT id, FIRST_VALUE(id) OVER (PARTITION BY hash ORDER BY created_at, id) AS keep_id
+    FROM prompts
+)
+UPDATE content_extractions e
+SET prompt_id = keep.keep_id
+FROM keep
+WHERE e.prompt_id = keep.id
+  AND keep.id <> keep.keep_id;
+
+DELETE FROM prompts p
+USING prompts q
+WHERE p.hash = q.hash
+  AND (p.created_at, p.id) > (q.created_at, q.id);
+ALTER TABLE prompts ADD CONSTRAINT prompts_hash_key UNIQUE (hash);
+
+ALTER TABLE prompts
+    DROP COLUMN IF EXISTS metadata,
+    DROP COLUMN IF EXISTS content,
+    DROP COLUMN IF EXISTS version,
+    DROP COLUMN IF EXISTS name;
+
+COMMENT ON TABLE prompts IS 'Prompt asset registry. hash = SHA-256(body), used to pin extraction provenance.';
+
+COMMIT;
@@ -0,0 +1,36 @@
+BEGIN;
+
+-- Prompts become a name+version registry. Rows written before the registry
+-- existed only carried (hash, path): they keep the path as their name and
+-- the hash prefix as their version.
+ALTER TABLE prompts
+    ADD COLUMN IF NOT EXISTS name     TEXT,
+    ADD COLUMN IF NOT EXISTS version  VARCHAR(64),
+    ADD COLUMN IF NOT EXISTS content  TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS metadata JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+UPDATE prompts
+SET name = path,
+    version = LEFT(hash, 12)
+WHERE name IS NULL;
+
+ALTER TABLE prompts
+    ALTER COLUMN name SET NOT NULL,
+    ALTER COLUMN version SET NOT NULL;
+
+-- The same body may be registered under several names or versions.
+ALTER TABLE prompts DROP CONSTRAINT IF EXISTS prompts_hash_key;
+ALTER TABLE prompts ADD CONSTRAINT prompts_name_version_key UNIQUE (name, version);
+CREATE INDEX IF NOT EXISTS idx_prompts_hash ON prompts(hash);
+
+COMMENT ON TABLE prompts IS 'Prompt registry. One immutable row per (name, version); hash = SHA-256(content), used to pin extraction provenance.';
+COMMENT ON COLUMN prompts.metadata IS 'Free-form registration metadata (registering component, source file size, ...).';
+
+ALTER TABLE content_extractions
+    ADD COLUMN IF NOT EXISTS prompt_version VARCHAR(64) NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN content_extractions.prompt_version IS 'prompts.version at extraction time, denormalised so A/B results can be grouped without a join. Empty for rows written before the registry.';
+
+CREATE INDEX IF NOT EXISTS idx_content_extractions_prompt_version ON content_extractions(prompt_version, created_at);
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+DROP TABLE IF EXISTS fetch_notifications;
+
+ALTER TABLE fetches
+    DROP COLUMN IF EXISTS notify_secret,
+    DROP COLUMN IF EXISTS notify_url;
+
+COMMENT ON COLUMN fetches.completed_at IS
+    'Persisted in v1 but unused; v2 notification dispatcher will set on transition.';
+
+COMMIT;
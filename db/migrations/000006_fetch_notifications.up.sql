@@ -0,0 +1,46 @@
+BEGIN;
+
+-- Webhook notifications for user fetches. A fetch submitted with a notify
+-- block carries its webhook target; cmd/fetch/notifier sets completed_at when
+-- every item reaches a terminal status and delivers one signed
+-- fetch.completed payload per fetch through fetch_notifications.
+
+ALTER TABLE fetches
+    ADD COLUMN IF NOT EXISTS notify_url    TEXT,
+    ADD COLUMN IF NOT EXISTS notify_secret TEXT;
+
+COMMENT ON COLUMN fetches.completed_at IS
+    'Set by cmd/fetch/notifier when every item reaches COMPLETED / FAILED / ALREADY_COMPLETE.';
+COMMENT ON COLUMN fetches.notify_url IS
+    'Webhook target for fetch.completed. NULL when the client polls GET /fetches/{id} instead.';
+COMMENT ON COLUMN fetches.notify_secret IS
+    'HMAC-SHA256 key for X-Prism-Signature. Never returned by the API.';
+
+CREATE TABLE IF NOT EXISTS fetch_notifications (
+    id               UUID PRIMARY KEY DEFAULT uuidv7(),
+    fetch_id         UUID NOT NULL REFERENCES fetches(id) ON DELETE CASCADE,
+    url              TEXT NOT NULL,
+    payload          JSONB NOT NULL,
+    status           VARCHAR(16) NOT NULL DEFAULT 'PENDING',
+    attempts         INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_status_code INTEGER,
+    last_error       TEXT,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    delivered_at     TIMESTAMPTZ,
+    CONSTRAINT fetch_notifications_fetch_id_key UNIQUE (fetch_id)
+);
+
+COMMENT ON TABLE fetch_notifications IS
+    'Webhook delivery log: one row per notified fetch, carrying the frozen payload and retry state.';
+COMMENT ON COLUMN fetch_notifications.status IS
+    'PENDING until a 2xx response (DELIVERED) or the attempt limit is reached (FAILED).';
+COMMENT ON COLUMN fetch_notifications.next_attempt_at IS
+    'Due time of the next attempt. Also leased forward while an attempt is in flight.';
+
+CREATE INDEX IF NOT EXISTS idx_fetch_notifications_due
+    ON fetch_notifications(next_attempt_at)
+    WHERE status = 'PENDING';
+
+COMMIT;
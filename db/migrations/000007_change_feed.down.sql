@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP TRIGGER IF EXISTS trg_tasks_notify_fetch_progress ON tasks;
+DROP TRIGGER IF EXISTS trg_candidates_notify ON candidates;
+DROP FUNCTION IF EXISTS notify_fetch_progress();
+DROP FUNCTION IF EXISTS notify_candidate_upserted();
+
+COMMIT;
@@ -0,0 +1,48 @@
+BEGIN;
+
+-- Change feed for the API server's SSE streams. Triggers only wake
+-- listeners: every stream re-reads its state from the tables, so a dropped
+-- or coalesced notification costs latency, never data.
+
+-- prism_candidates carries the candidate's source_abbr so streams filtered by
+-- source can skip unrelated wake-ups. UpsertCandidate bumps discovered_at on
+-- a re-seen fingerprint, which counts as a new sighting.
+CREATE OR REPLACE FUNCTION notify_candidate_upserted() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    PERFORM pg_notify('prism_candidates', NEW.source_abbr);
+    RETURN NULL;
+END;
+$$;
+
+COMMENT ON FUNCTION notify_candidate_upserted() IS
+    'Wakes GET /candidates/stream listeners. Payload is source_abbr.';
+
+CREATE TRIGGER trg_candidates_notify
+    AFTER INSERT OR UPDATE OF discovered_at ON candidates
+    FOR EACH ROW EXECUTE FUNCTION notify_candidate_upserted();
+
+-- prism_fetch_progress carries the fetch_id of every fetch that references
+-- the task. A task shared by several fetches wakes each of them.
+CREATE OR REPLACE FUNCTION notify_fetch_progress() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    PERFORM pg_notify('prism_fetch_progress', fi.fetch_id::text)
+    FROM fetch_items fi
+    WHERE fi.task_id = NEW.id;
+    RETURN NULL;
+END;
+$$;
+
+COMMENT ON FUNCTION notify_fetch_progress() IS
+    'Wakes GET /fetches/{id}/events listeners. Payload is fetch_id.';
+
+CREATE TRIGGER trg_tasks_notify_fetch_progress
+    AFTER UPDATE OF status ON tasks
+    FOR EACH ROW
+    WHEN (NEW.kind = 'PAGE_FETCH' AND OLD.status IS DISTINCT FROM NEW.status)
+    EXECUTE FUNCTION notify_fetch_progress();
+
+COMMIT;
@@ -0,0 +1,11 @@
+BEGIN;
+
+ALTER TABLE fetches DROP CONSTRAINT IF EXISTS fetches_user_id_fkey;
+
+COMMENT ON COLUMN fetches.user_id IS
+    'Nullable in v1 (single-user dev). Filter target for multi-user RBAC.';
+
+DROP TABLE IF EXISTS api_keys;
+DROP TABLE IF EXISTS users;
+
+COMMIT;
@@ -0,0 +1,60 @@
+BEGIN;
+
+-- Multi-user access. Each caller authenticates with an API key; the key
+-- carries its scopes and optional per-key rate limit, and fetches record the
+-- submitting user so GET /fetches/{id} can enforce ownership. Only the
+-- SHA-256 of a key is stored; the plaintext is returned once at creation.
+
+CREATE TABLE IF NOT EXISTS users (
+    id          UUID PRIMARY KEY DEFAULT uuidv7(),
+    name        VARCHAR(128) NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    disabled_at TIMESTAMPTZ,
+    CONSTRAINT users_name_key UNIQUE (name)
+);
+
+COMMENT ON TABLE users IS
+    'API callers. Owners of fetches and api_keys.';
+COMMENT ON COLUMN users.disabled_at IS
+    'Set to reject every key of the user without revoking them one by one.';
+
+CREATE TABLE IF NOT EXISTS api_keys (
+    id               UUID PRIMARY KEY DEFAULT uuidv7(),
+    user_id          UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name             VARCHAR(128) NOT NULL,
+    prefix           VARCHAR(16) NOT NULL,
+    key_hash         CHAR(64) NOT NULL,
+    scopes           TEXT[] NOT NULL,
+    rate_limit_rps   DOUBLE PRECISION,
+    rate_limit_burst INTEGER,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_used_at     TIMESTAMPTZ,
+    expires_at       TIMESTAMPTZ,
+    revoked_at       TIMESTAMPTZ,
+    CONSTRAINT api_keys_key_hash_key UNIQUE (key_hash),
+    CONSTRAINT api_keys_scopes_check CHECK (scopes <@ ARRAY['read', 'page_fetch', 'admin']::text[])
+);
+
+COMMENT ON TABLE api_keys IS
+    'Hashed API keys. A key authenticates as its user with the listed scopes.';
+COMMENT ON COLUMN api_keys.prefix IS
+    'Leading characters of the plaintext key, shown in listings to tell keys apart.';
+COMMENT ON COLUMN api_keys.key_hash IS
+    'SHA-256 of the plaintext key, hex. The plaintext is never stored.';
+COMMENT ON COLUMN api_keys.scopes IS
+    'Subset of read, page_fetch, admin. admin implies the others.';
+COMMENT ON COLUMN api_keys.rate_limit_rps IS
+    'Per-key request budget. NULL falls back to the server default.';
+COMMENT ON COLUMN api_keys.last_used_at IS
+    'Refreshed when the API server resolves the key (at most once per cache TTL).';
+
+CREATE INDEX IF NOT EXISTS idx_api_keys_user_id ON api_keys(user_id);
+
+COMMENT ON COLUMN fetches.user_id IS
+    'Submitting user. NULL when auth is disabled or the caller used a static token.';
+
+ALTER TABLE fetches
+    ADD CONSTRAINT fetches_user_id_fkey
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL;
+
+COMMIT;
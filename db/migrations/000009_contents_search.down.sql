@@ -0,0 +1,10 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_contents_published_at_id;
+DROP INDEX IF EXISTS idx_contents_search;
+
+DROP FUNCTION IF EXISTS contents_search_query(TEXT);
+DROP FUNCTION IF EXISTS contents_search_document(TEXT, TEXT);
+DROP FUNCTION IF EXISTS cjk_bigrams(TEXT);
+
+COMMIT;
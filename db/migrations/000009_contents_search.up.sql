@@ -0,0 +1,62 @@
+BEGIN;
+
+-- Full-text search over contents for GET /api/v1/contents?q=. The built-in
+-- text search parsers do not segment Chinese/Japanese/Korean, so CJK runs
+-- are rewritten into overlapping bigrams before the 'simple' configuration
+-- tokenises them ("能源政策" -> "能源 源政 政策"). The query side applies
+-- the same rewrite, so a term matches whenever its bigrams all appear.
+-- Latin text is left as-is and tokenised on whitespace/punctuation.
+
+CREATE OR REPLACE FUNCTION cjk_bigrams(input TEXT) RETURNS TEXT
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE
+    AS $$
+SELECT COALESCE(string_agg(
+    CASE
+        WHEN cjk AND next_cjk THEN ' ' || c || next_c || ' '
+        WHEN cjk AND NOT prev_cjk THEN ' ' || c || ' '
+        WHEN cjk THEN ' '
+        ELSE c
+    END, '' ORDER BY i), '')
+FROM (
+    SELECT i, c, cjk,
+           lead(c) OVER w AS next_c,
+           COALESCE(lead(cjk) OVER w, false) AS next_cjk,
+           COALESCE(lag(cjk) OVER w, false) AS prev_cjk
+    FROM (
+        -- Kana, CJK ideographs (incl. ext. A and compatibility), Hangul.
+        SELECT i, c, c ~ '[\u3040-\u30ff\u3400-\u4dbf\u4e00-\u9fff\uf900-\ufaff\uac00-\ud7af]' AS cjk
+        FROM unnest(regexp_split_to_array(input, '')) WITH ORDINALITY AS t(c, i)
+    ) chars
+    WINDOW w AS (ORDER BY i)
+) s;
+$$;
+
+COMMENT ON FUNCTION cjk_bigrams(TEXT) IS
+    'Rewrites CJK runs into space-separated overlapping bigrams; other text is kept.';
+
+CREATE OR REPLACE FUNCTION contents_search_document(title TEXT, content TEXT) RETURNS tsvector
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE
+    AS $$
+SELECT to_tsvector('simple'::regconfig, public.cjk_bigrams(title) || ' ' || public.cjk_bigrams(content));
+$$;
+
+COMMENT ON FUNCTION contents_search_document(TEXT, TEXT) IS
+    'Indexed search document for contents. Must match contents_search_query.';
+
+CREATE OR REPLACE FUNCTION contents_search_query(q TEXT) RETURNS tsquery
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE
+    AS $$
+SELECT plainto_tsquery('simple'::regconfig, public.cjk_bigrams(q));
+$$;
+
+COMMENT ON FUNCTION contents_search_query(TEXT) IS
+    'AND of every token (CJK bigram or word) in q.';
+
+CREATE INDEX IF NOT EXISTS idx_contents_search
+    ON contents USING gin (contents_search_document(title, content));
+
+-- Keyset pagination for GET /api/v1/contents walks (published_at, id) DESC.
+CREATE INDEX IF NOT EXISTS idx_contents_published_at_id
+    ON contents (published_at, id);
+
+COMMIT;
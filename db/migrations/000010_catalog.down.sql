@@ -0,0 +1,10 @@
+BEGIN;
+
+DROP TRIGGER IF EXISTS trg_parser_rules_notify ON parser_rules;
+DROP TRIGGER IF EXISTS trg_scout_configs_notify ON scout_configs;
+DROP FUNCTION IF EXISTS notify_catalog_changed();
+
+DROP TABLE IF EXISTS parser_rules;
+DROP TABLE IF EXISTS scout_configs;
+
+COMMIT;
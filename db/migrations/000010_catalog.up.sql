@@ -0,0 +1,56 @@
+BEGIN;
+
+-- Outlet catalog. Scout configs and parser rules move from the baked
+-- scouts.yaml / parsers.yaml into Postgres so an outlet can be added through
+-- the admin API without a redeploy. config holds the same entry shape as the
+-- YAML files (scoutconfig.HTMLScoutConfig / FeedScoutConfig /
+-- CustomScoutConfig and parserconfig.ParserConfig); the API validates it with
+-- the YAML validators before writing.
+
+CREATE TABLE IF NOT EXISTS scout_configs (
+    name        VARCHAR(64) PRIMARY KEY,
+    kind        VARCHAR(16) NOT NULL,
+    config      JSONB NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT scout_configs_kind_check CHECK (kind IN ('html', 'rss', 'atom', 'custom'))
+);
+
+COMMENT ON TABLE scout_configs IS
+    'Discovery scouts, one per outlet listing. Replaces scouts.yaml when workers run with --registry-source=postgres.';
+COMMENT ON COLUMN scout_configs.config IS
+    'One scouts.yaml entry (name, hosts, headers, rules, ...) with section defaults already applied.';
+
+CREATE TABLE IF NOT EXISTS parser_rules (
+    host        VARCHAR(255) PRIMARY KEY,
+    config      JSONB NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE parser_rules IS
+    'Per-host article parser rules. Replaces the parsers: map of parsers.yaml when workers run with --registry-source=postgres.';
+
+-- prism_catalog carries the changed table name. Workers rebuild the whole
+-- registry on a wake-up, so one notification per statement is enough.
+CREATE OR REPLACE FUNCTION notify_catalog_changed() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    PERFORM pg_notify('prism_catalog', TG_TABLE_NAME);
+    RETURN NULL;
+END;
+$$;
+
+COMMENT ON FUNCTION notify_catalog_changed() IS
+    'Wakes workers holding a scout or parser registry. Payload is the table name.';
+
+CREATE TRIGGER trg_scout_configs_notify
+    AFTER INSERT OR UPDATE OR DELETE ON scout_configs
+    FOR EACH STATEMENT EXECUTE FUNCTION notify_catalog_changed();
+
+CREATE TRIGGER trg_parser_rules_notify
+    AFTER INSERT OR UPDATE OR DELETE ON parser_rules
+    FOR EACH STATEMENT EXECUTE FUNCTION notify_catalog_changed();
+
+COMMIT;
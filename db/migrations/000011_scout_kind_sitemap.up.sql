@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE scout_configs DROP CONSTRAINT scout_configs_kind_check;
+ALTER TABLE scout_configs ADD CONSTRAINT scout_configs_kind_check
+    CHECK (kind IN ('html', 'rss', 'atom', 'sitemap', 'custom'));
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+DELETE FROM scout_configs WHERE kind = 'json';
+ALTER TABLE scout_configs DROP CONSTRAINT scout_configs_kind_check;
+ALTER TABLE scout_configs ADD CONSTRAINT scout_configs_kind_check
+    CHECK (kind IN ('html', 'rss', 'atom', 'sitemap', 'custom'));
+
+COMMIT;
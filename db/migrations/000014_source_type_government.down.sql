@@ -0,0 +1,35 @@
+-- Postgres cannot drop an enum value, so both types are rebuilt without
+-- it. Rows that use the value, and the GOVERNMENT sources' rows, are
+-- removed first.
+BEGIN;
+
+CREATE TEMP TABLE government_sources ON COMMIT DROP AS
+    SELECT abbr FROM sources WHERE type = 'GOVERNMENT';
+
+DELETE FROM contents
+WHERE type = 'GOVERNMENT_RELEASE'
+   OR source_abbr IN (SELECT abbr FROM government_sources);
+DELETE FROM fetch_items
+WHERE candidate_id IN (
+    SELECT id FROM candidates WHERE source_abbr IN (SELECT abbr FROM government_sources)
+);
+DELETE FROM candidates WHERE source_abbr IN (SELECT abbr FROM government_sources);
+DELETE FROM tasks
+WHERE source_type = 'GOVERNMENT'
+   OR source_abbr IN (SELECT abbr FROM government_sources);
+DELETE FROM batches WHERE source_type = 'GOVERNMENT';
+DELETE FROM sources WHERE type = 'GOVERNMENT';
+
+ALTER TYPE source_type RENAME TO source_type_old;
+CREATE TYPE source_type AS ENUM ('PARTY', 'MEDIA');
+ALTER TABLE sources ALTER COLUMN type TYPE source_type USING type::text::source_type;
+ALTER TABLE tasks ALTER COLUMN source_type TYPE source_type USING source_type::text::source_type;
+ALTER TABLE batches ALTER COLUMN source_type TYPE source_type USING source_type::text::source_type;
+DROP TYPE source_type_old;
+
+ALTER TYPE content_type RENAME TO content_type_old;
+CREATE TYPE content_type AS ENUM ('PARTY_RELEASE', 'ARTICLE', 'SOCIAL');
+ALTER TABLE contents ALTER COLUMN type TYPE content_type USING type::text::content_type;
+DROP TYPE content_type_old;
+
+COMMIT;
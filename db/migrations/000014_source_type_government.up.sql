@@ -0,0 +1,4 @@
+-- ALTER TYPE ... ADD VALUE cannot be used by later statements in the same
+-- transaction, so the GOVERNMENT seed sources live in 000015.
+ALTER TYPE source_type ADD VALUE IF NOT EXISTS 'GOVERNMENT';
+ALTER TYPE content_type ADD VALUE IF NOT EXISTS 'GOVERNMENT_RELEASE';
@@ -0,0 +1,5 @@
+BEGIN;
+
+DELETE FROM sources WHERE abbr IN ('ly', 'ey');
+
+COMMIT;
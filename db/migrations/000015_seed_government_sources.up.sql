@@ -0,0 +1,8 @@
+BEGIN;
+
+INSERT INTO sources (abbr, name, type, base_url) VALUES
+    ('ly', '立法院', 'GOVERNMENT', 'https://data.ly.gov.tw'),
+    ('ey', '行政院', 'GOVERNMENT', 'https://www.ey.gov.tw')
+ON CONFLICT (abbr) DO NOTHING;
+
+COMMIT;
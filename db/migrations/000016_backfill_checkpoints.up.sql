@@ -0,0 +1,31 @@
+BEGIN;
+
+-- Where each source's historical backfill stopped. cmd/backfiller writes a
+-- row after every listing page it hands to the sink, so a killed run picks
+-- up after the last finished page instead of replaying the listing.
+
+CREATE TABLE IF NOT EXISTS backfill_checkpoints (
+    source_abbr          VARCHAR(16) PRIMARY KEY REFERENCES sources(abbr) ON DELETE CASCADE,
+    pager                VARCHAR(16) NOT NULL,
+    last_page            TEXT NOT NULL,
+    batch_id             UUID NOT NULL,
+    until                TIMESTAMPTZ NOT NULL,
+    pages_visited        INTEGER NOT NULL DEFAULT 0,
+    candidates_seen      INTEGER NOT NULL DEFAULT 0,
+    candidates_processed INTEGER NOT NULL DEFAULT 0,
+    oldest_published_at  TIMESTAMPTZ,
+    completed_at         TIMESTAMPTZ,
+    created_at           TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at           TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE backfill_checkpoints IS
+    'Last finished listing page of each source''s backfill run. A run resumes from it unless completed_at is set or the pager type changed.';
+COMMENT ON COLUMN backfill_checkpoints.pager IS
+    'Pager type (index, offset, next_link, date_window, sitemap) last_page belongs to.';
+COMMENT ON COLUMN backfill_checkpoints.last_page IS
+    'Pager position of the last finished page: a page value, a URL or a window start.';
+COMMENT ON COLUMN backfill_checkpoints.batch_id IS
+    'Batch of the run. A resumed run keeps it so all its candidates share one batch. No FK: batches are created by the sink.';
+
+COMMIT;
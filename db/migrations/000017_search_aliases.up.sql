@@ -0,0 +1,35 @@
+BEGIN;
+
+-- Alias dictionary for planner query expansion. A group is one canonical
+-- name plus its aliases; the planner searches a phrase naming any member
+-- of a group under every member (see internal/discovery/planner).
+
+CREATE TABLE IF NOT EXISTS search_aliases (
+    canonical  TEXT NOT NULL,
+    alias      TEXT NOT NULL,
+    kind       VARCHAR(16) NOT NULL DEFAULT 'ALIAS',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (canonical, alias),
+    CONSTRAINT search_aliases_kind_check CHECK (kind IN ('ALIAS', 'ABBREVIATION', 'VARIANT')),
+    CONSTRAINT search_aliases_distinct_check CHECK (alias <> canonical)
+);
+
+COMMENT ON TABLE search_aliases IS
+    'Alias groups for planner query expansion, managed through /api/v1/admin/aliases.';
+COMMENT ON COLUMN search_aliases.canonical IS
+    'Name the group is keyed by. Planner tasks are deduplicated on it.';
+COMMENT ON COLUMN search_aliases.kind IS
+    'ALIAS (nickname or other name), ABBREVIATION (short form) or VARIANT (script or spelling variant).';
+
+INSERT INTO search_aliases (canonical, alias, kind) VALUES
+    ('民主進步黨', '民進黨', 'ABBREVIATION'),
+    ('中國國民黨', '國民黨', 'ABBREVIATION'),
+    ('台灣民眾黨', '民眾黨', 'ABBREVIATION'),
+    ('台灣民眾黨', '臺灣民眾黨', 'VARIANT'),
+    ('立法院', '立院', 'ABBREVIATION'),
+    ('國家通訊傳播委員會', 'NCC', 'ABBREVIATION'),
+    ('國家通訊傳播委員會', '通傳會', 'ABBREVIATION')
+ON CONFLICT (canonical, alias) DO NOTHING;
+
+COMMIT;
@@ -0,0 +1,48 @@
+BEGIN;
+
+-- Execution history of discovery tasks. The discovery worker appends one row
+-- per DIRECTORY_FETCH or KEYWORD_SEARCH run; GET /api/v1/admin/task_runs/yield
+-- summarises it so unproductive keyword tasks can be pruned.
+
+CREATE TABLE IF NOT EXISTS task_runs (
+    id              BIGSERIAL PRIMARY KEY,
+    task_id         UUID NOT NULL,
+    batch_id        UUID NOT NULL,
+    kind            task_kind NOT NULL,
+    source_type     source_type NOT NULL,
+    source_abbr     VARCHAR(16) NOT NULL,
+    query           TEXT,
+    status          VARCHAR(16) NOT NULL,
+    error_class     VARCHAR(32),
+    items_seen      INTEGER NOT NULL DEFAULT 0,
+    items_new       INTEGER NOT NULL DEFAULT 0,
+    items_duplicate INTEGER NOT NULL DEFAULT 0,
+    providers       JSONB NOT NULL DEFAULT '{}'::jsonb,
+    duration_ms     INTEGER NOT NULL,
+    trace_id        VARCHAR(100) NOT NULL,
+    started_at      TIMESTAMPTZ NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT task_runs_status_check CHECK (status IN ('OK', 'FAILED')),
+    CONSTRAINT task_runs_error_class_check CHECK ((status = 'FAILED') = (error_class IS NOT NULL))
+);
+
+COMMENT ON TABLE task_runs IS
+    'Append-only history of discovery task executions, one row per run.';
+COMMENT ON COLUMN task_runs.task_id IS
+    'Task that ran. No FK: the history outlives task cleanup.';
+COMMENT ON COLUMN task_runs.query IS
+    'KEYWORD_SEARCH phrase; NULL for DIRECTORY_FETCH.';
+COMMENT ON COLUMN task_runs.error_class IS
+    'Low-cardinality failure class, e.g. timeout, rate_limited, scout, search, sink. Set exactly when status is FAILED.';
+COMMENT ON COLUMN task_runs.items_seen IS
+    'Candidates the scout or search providers returned.';
+COMMENT ON COLUMN task_runs.items_duplicate IS
+    'Stored candidates whose fingerprint was already known; UpsertCandidate only bumped discovered_at.';
+COMMENT ON COLUMN task_runs.providers IS
+    'KEYWORD_SEARCH result per search provider: {"<provider>": {"seen": n}} or {"<provider>": {"error_class": "..."}}.';
+
+CREATE INDEX IF NOT EXISTS idx_task_runs_task_id_started_at ON task_runs(task_id, started_at DESC);
+CREATE INDEX IF NOT EXISTS idx_task_runs_started_at ON task_runs(started_at);
+CREATE INDEX IF NOT EXISTS idx_task_runs_source_abbr_started_at ON task_runs(source_abbr, started_at);
+
+COMMIT;
@@ -0,0 +1,42 @@
+-- name: GetBackfillCheckpoint :one
+SELECT *
+FROM backfill_checkpoints
+WHERE source_abbr = sqlc.arg(source_abbr);
+
+-- name: UpsertBackfillCheckpoint :one
+-- One row per source: a new run overwrites the previous run's checkpoint.
+INSERT INTO backfill_checkpoints (
+    source_abbr,
+    pager,
+    last_page,
+    batch_id,
+    until,
+    pages_visited,
+    candidates_seen,
+    candidates_processed,
+    oldest_published_at,
+    completed_at
+) VALUES (
+    sqlc.arg(source_abbr),
+    sqlc.arg(pager),
+    sqlc.arg(last_page),
+    sqlc.arg(batch_id),
+    sqlc.arg(until),
+    sqlc.arg(pages_visited),
+    sqlc.arg(candidates_seen),
+    sqlc.arg(candidates_processed),
+    sqlc.narg(oldest_published_at),
+    sqlc.narg(completed_at)
+)
+ON CONFLICT (source_abbr) DO UPDATE
+SET pager                = EXCLUDED.pager,
+    last_page            = EXCLUDED.last_page,
+    batch_id             = EXCLUDED.batch_id,
+    until                = EXCLUDED.until,
+    pages_visited        = EXCLUDED.pages_visited,
+    candidates_seen      = EXCLUDED.candidates_seen,
+    candidates_processed = EXCLUDED.candidates_processed,
+    oldest_published_at  = EXCLUDED.oldest_published_at,
+    completed_at         = EXCLUDED.completed_at,
+    updated_at           = NOW()
+RETURNING *;
@@ -81,12 +81,26 @@ ORDER BY published_at DESC NULLS LAST, discovered_at DESC, created_at DESC
 LIMIT $2
 OFFSET $3;
 
+-- name: CountCandidates :one
+-- Same filters as ListCandidates, without paging.
+SELECT COUNT(*)
+FROM candidates
+WHERE (sqlc.narg(query)::text IS NULL
+       OR title ILIKE '%' || sqlc.narg(query)::text || '%'
+       OR COALESCE(description, '') ILIKE '%' || sqlc.narg(query)::text || '%')
+  AND (sqlc.narg(source_abbr)::varchar IS NULL OR source_abbr = sqlc.narg(source_abbr)::varchar)
+  AND (sqlc.narg(since)::timestamptz IS NULL OR COALESCE(published_at, discovered_at) >= sqlc.narg(since)::timestamptz)
+  AND (sqlc.narg(until)::timestamptz IS NULL OR COALESCE(published_at, discovered_at) <= sqlc.narg(until)::timestamptz);
+
 -- name: CountCandidatesByBatchID :one
 SELECT COUNT(*)
 FROM candidates
 WHERE batch_id = $1;
 
 -- name: ListCandidates :many
+-- Pages in (published_at DESC NULLS LAST, discovered_at DESC, id DESC) order.
+-- after_* is the last row of the previous page (keyset); rows without
+-- published_at come after every dated row. off is kept for legacy clients.
 SELECT *
 FROM candidates
 WHERE (sqlc.narg(query)::text IS NULL
@@ -95,7 +109,15 @@ WHERE (sqlc.narg(query)::text IS NULL
   AND (sqlc.narg(source_abbr)::varchar IS NULL OR source_abbr = sqlc.narg(source_abbr)::varchar)
   AND (sqlc.narg(since)::timestamptz IS NULL OR COALESCE(published_at, discovered_at) >= sqlc.narg(since)::timestamptz)
   AND (sqlc.narg(until)::timestamptz IS NULL OR COALESCE(published_at, discovered_at) <= sqlc.narg(until)::timestamptz)
-ORDER BY published_at DESC NULLS LAST, discovered_at DESC, created_at DESC
+  AND (sqlc.narg(after_discovered_at)::timestamptz IS NULL
+       OR (sqlc.narg(after_published_at)::timestamptz IS NOT NULL
+           AND (published_at IS NULL
+                OR (published_at, discovered_at, id)
+                   < (sqlc.narg(after_published_at)::timestamptz, sqlc.narg(after_discovered_at)::timestamptz, sqlc.narg(after_id)::uuid)))
+       OR (sqlc.narg(after_published_at)::timestamptz IS NULL
+           AND published_at IS NULL
+           AND (discovered_at, id) < (sqlc.narg(after_discovered_at)::timestamptz, sqlc.narg(after_id)::uuid)))
+ORDER BY published_at DESC NULLS LAST, discovered_at DESC, id DESC
 LIMIT sqlc.arg(lim)::int
 OFFSET sqlc.arg(off)::int;
 
@@ -103,3 +125,21 @@ OFFSET sqlc.arg(off)::int;
 SELECT *
 FROM candidates
 WHERE id = ANY(sqlc.arg(ids)::uuid[]);
+
+-- name: GetCandidatesByFingerprints :many
+SELECT *
+FROM candidates
+WHERE fingerprint = ANY(sqlc.arg(fingerprints)::text[]);
+
+-- name: ListCandidatesDiscoveredAfter :many
+-- Keyset scan in (discovered_at, id) order for GET /candidates/stream.
+-- UpsertCandidate bumps discovered_at, so a re-seen candidate sorts again.
+SELECT *
+FROM candidates
+WHERE (discovered_at, id) > (sqlc.arg(after_discovered_at)::timestamptz, sqlc.arg(after_id)::uuid)
+  AND discovered_at <= sqlc.arg(until)::timestamptz
+  AND (sqlc.narg(source_abbr)::varchar IS NULL OR source_abbr = sqlc.narg(source_abbr)::varchar)
+  AND (sqlc.narg(ingestion_method)::candidate_ingestion_method IS NULL
+       OR ingestion_method = sqlc.narg(ingestion_method)::candidate_ingestion_method)
+ORDER BY discovered_at, id
+LIMIT sqlc.arg(lim)::int;
@@ -0,0 +1,52 @@
+-- name: UpsertSource :one
+-- Re-creating a soft-deleted source revives it.
+INSERT INTO sources (abbr, name, type, base_url)
+VALUES (sqlc.arg(abbr), sqlc.arg(name), sqlc.arg(type), sqlc.arg(base_url))
+ON CONFLICT (abbr) DO UPDATE
+SET name       = EXCLUDED.name,
+    type       = EXCLUDED.type,
+    base_url   = EXCLUDED.base_url,
+    deleted_at = NULL
+RETURNING *;
+
+-- name: SoftDeleteSource :execrows
+-- Sources stay referenced by candidates and tasks, so they are only hidden.
+UPDATE sources
+SET deleted_at = NOW()
+WHERE abbr = sqlc.arg(abbr)
+  AND deleted_at IS NULL;
+
+-- name: ListScoutConfigs :many
+SELECT *
+FROM scout_configs
+ORDER BY name ASC;
+
+-- name: UpsertScoutConfig :one
+INSERT INTO scout_configs (name, kind, config)
+VALUES (sqlc.arg(name), sqlc.arg(kind), sqlc.arg(config))
+ON CONFLICT (name) DO UPDATE
+SET kind       = EXCLUDED.kind,
+    config     = EXCLUDED.config,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: DeleteScoutConfig :execrows
+DELETE FROM scout_configs
+WHERE name = sqlc.arg(name);
+
+-- name: ListParserRules :many
+SELECT *
+FROM parser_rules
+ORDER BY host ASC;
+
+-- name: UpsertParserRule :one
+INSERT INTO parser_rules (host, config)
+VALUES (sqlc.arg(host), sqlc.arg(config))
+ON CONFLICT (host) DO UPDATE
+SET config     = EXCLUDED.config,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: DeleteParserRule :execrows
+DELETE FROM parser_rules
+WHERE host = sqlc.arg(host);
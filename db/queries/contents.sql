@@ -57,7 +57,7 @@ RETURNING *;
 -- name: ListRecentSeedContents :many
 SELECT *
 FROM contents
-WHERE type = 'PARTY_RELEASE'
+WHERE type IN ('PARTY_RELEASE', 'GOVERNMENT_RELEASE')
   AND deleted_at IS NULL
 ORDER BY published_at DESC, created_at DESC
 LIMIT $1;
@@ -68,3 +68,22 @@ FROM contents
 WHERE batch_id = $1
   AND deleted_at IS NULL
 ORDER BY published_at ASC, created_at ASC;
+
+-- name: ListContents :many
+-- Keyset page over (published_at, id) DESC. after_published_at/after_id are
+-- the last row of the previous page; q is matched through the CJK-aware
+-- contents_search_document index (see migration 000009).
+SELECT *
+FROM contents
+WHERE deleted_at IS NULL
+  AND (sqlc.narg(query)::text IS NULL
+       OR contents_search_document(title, content) @@ contents_search_query(sqlc.narg(query)::text))
+  AND (sqlc.narg(source_abbr)::varchar IS NULL OR source_abbr = sqlc.narg(source_abbr)::varchar)
+  AND (sqlc.narg(type)::content_type IS NULL OR type = sqlc.narg(type)::content_type)
+  AND (sqlc.narg(batch_id)::uuid IS NULL OR batch_id = sqlc.narg(batch_id)::uuid)
+  AND (sqlc.narg(since)::timestamptz IS NULL OR published_at >= sqlc.narg(since)::timestamptz)
+  AND (sqlc.narg(until)::timestamptz IS NULL OR published_at <= sqlc.narg(until)::timestamptz)
+  AND (sqlc.narg(after_published_at)::timestamptz IS NULL
+       OR (published_at, id) < (sqlc.narg(after_published_at)::timestamptz, sqlc.narg(after_id)::uuid))
+ORDER BY published_at DESC, id DESC
+LIMIT sqlc.arg(lim)::int;
@@ -23,7 +23,8 @@ INSERT INTO content_extractions (
     title,
     summary,
     raw_result,
-    trace_id
+    trace_id,
+    prompt_version
 ) VALUES (
     sqlc.arg(content_id),
     sqlc.arg(model_id),
@@ -33,7 +34,8 @@ INSERT INTO content_extractions (
     sqlc.arg(title),
     sqlc.arg(summary),
     sqlc.arg(raw_result),
-    sqlc.arg(trace_id)
+    sqlc.arg(trace_id),
+    sqlc.arg(prompt_version)
 )
 RETURNING *;
 
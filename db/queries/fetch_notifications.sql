@@ -0,0 +1,48 @@
+-- name: CreateFetchNotification :execrows
+-- Freezes the fetch.completed payload for delivery. Idempotent per fetch, so
+-- a sweep that dies between enqueue and MarkUserFetchCompleted simply
+-- re-runs on the next tick.
+INSERT INTO fetch_notifications (
+    fetch_id,
+    url,
+    payload
+) VALUES (
+    $1,
+    $2,
+    $3
+)
+ON CONFLICT (fetch_id) DO NOTHING;
+
+-- name: ClaimDueFetchNotifications :many
+-- Leases due PENDING deliveries by pushing next_attempt_at forward so
+-- concurrent notifiers skip them. RecordFetchNotificationAttempt replaces the
+-- lease with the real retry time; a crashed attempt is retried once the lease
+-- expires.
+UPDATE fetch_notifications n
+SET next_attempt_at = NOW() + sqlc.arg(lease_seconds)::int * INTERVAL '1 second',
+    updated_at = NOW()
+FROM fetches f
+WHERE f.id = n.fetch_id
+  AND n.id IN (
+      SELECT d.id
+      FROM fetch_notifications d
+      WHERE d.status = 'PENDING'
+        AND d.next_attempt_at <= NOW()
+      ORDER BY d.next_attempt_at ASC
+      LIMIT sqlc.arg(row_limit)
+      FOR UPDATE SKIP LOCKED
+  )
+RETURNING n.id, n.fetch_id, n.url, n.payload, n.attempts, f.notify_secret;
+
+-- name: RecordFetchNotificationAttempt :exec
+-- Records the outcome of one delivery attempt. DELIVERED also stamps
+-- delivered_at; PENDING rows are retried at next_attempt_at.
+UPDATE fetch_notifications
+SET attempts = attempts + 1,
+    status = sqlc.arg(status),
+    last_status_code = sqlc.narg(last_status_code),
+    last_error = sqlc.narg(last_error),
+    next_attempt_at = sqlc.arg(next_attempt_at),
+    delivered_at = CASE WHEN sqlc.arg(status) = 'DELIVERED' THEN NOW() ELSE delivered_at END,
+    updated_at = NOW()
+WHERE id = sqlc.arg(id);
@@ -0,0 +1,54 @@
+-- name: CreateLLMUsage :one
+INSERT INTO llm_usage (
+    provider,
+    model,
+    operation,
+    component,
+    input_tokens,
+    output_tokens,
+    cached_tokens,
+    total_tokens,
+    cost_micros,
+    trace_id,
+    batch_id
+) VALUES (
+    sqlc.arg(provider),
+    sqlc.arg(model),
+    sqlc.arg(operation),
+    sqlc.arg(component),
+    sqlc.arg(input_tokens),
+    sqlc.arg(output_tokens),
+    sqlc.arg(cached_tokens),
+    sqlc.arg(total_tokens),
+    sqlc.arg(cost_micros),
+    sqlc.arg(trace_id),
+    sqlc.narg(batch_id)
+)
+RETURNING *;
+
+-- name: SumLLMCostSince :one
+-- Budget check: micro-USD spent by one component since a window start.
+SELECT COALESCE(SUM(cost_micros), 0)::bigint AS cost_micros
+FROM llm_usage
+WHERE component = sqlc.arg(component)
+  AND created_at >= sqlc.arg(since);
+
+-- name: SummarizeLLMSpend :many
+-- Spend per UTC day, component, provider and model in [since, until).
+SELECT
+    (created_at AT TIME ZONE 'UTC')::date       AS day,
+    component,
+    provider,
+    model,
+    COUNT(*)                                    AS calls,
+    COALESCE(SUM(input_tokens), 0)::bigint      AS input_tokens,
+    COALESCE(SUM(output_tokens), 0)::bigint     AS output_tokens,
+    COALESCE(SUM(cached_tokens), 0)::bigint     AS cached_tokens,
+    COALESCE(SUM(total_tokens), 0)::bigint      AS total_tokens,
+    COALESCE(SUM(cost_micros), 0)::bigint       AS cost_micros
+FROM llm_usage
+WHERE created_at >= sqlc.arg(since)
+  AND created_at < sqlc.arg(until)
+  AND (sqlc.narg(component)::varchar IS NULL OR component = sqlc.narg(component)::varchar)
+GROUP BY day, component, provider, model
+ORDER BY day, component, provider, model;
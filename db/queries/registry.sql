@@ -35,16 +35,43 @@ LIMIT 1;
 SELECT *
 FROM prompts
 WHERE hash = $1
+ORDER BY created_at ASC
 LIMIT 1;
 
+-- name: GetPromptByNameAndVersion :one
+SELECT *
+FROM prompts
+WHERE name = $1
+  AND version = $2
+LIMIT 1;
+
+-- name: ListPromptVersions :many
+SELECT *
+FROM prompts
+WHERE name = $1
+ORDER BY created_at ASC;
+
 -- name: UpsertPrompt :one
+-- Registers (name, version). A version is immutable: re-registering the
+-- same content refreshes path and metadata, while different content updates
+-- nothing and returns no row. Adapter maps that to repo.ErrPromptVersionConflict.
 INSERT INTO prompts (
+    name,
+    version,
     hash,
-    path
+    path,
+    content,
+    metadata
 ) VALUES (
+    sqlc.arg(name),
+    sqlc.arg(version),
     sqlc.arg(hash),
-    sqlc.arg(path)
+    sqlc.arg(path),
+    sqlc.arg(content),
+    sqlc.arg(metadata)
 )
-ON CONFLICT (hash) DO UPDATE
-SET path = EXCLUDED.path
+ON CONFLICT (name, version) DO UPDATE
+SET path = EXCLUDED.path,
+    metadata = EXCLUDED.metadata
+WHERE prompts.hash = EXCLUDED.hash
 RETURNING *;
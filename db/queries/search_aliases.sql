@@ -0,0 +1,24 @@
+-- name: ListSearchAliases :many
+SELECT *
+FROM search_aliases
+ORDER BY canonical ASC, alias ASC;
+
+-- name: ReplaceSearchAliases :many
+-- Replaces the canonical's group in one statement: aliases missing from the
+-- new list are dropped, the rest are inserted or have their kind updated.
+WITH deleted AS (
+    DELETE FROM search_aliases
+    WHERE canonical = sqlc.arg(canonical)
+      AND alias <> ALL(sqlc.arg(aliases)::text[])
+)
+INSERT INTO search_aliases (canonical, alias, kind)
+SELECT sqlc.arg(canonical), a.alias, a.kind
+FROM unnest(sqlc.arg(aliases)::text[], sqlc.arg(kinds)::text[]) AS a(alias, kind)
+ON CONFLICT (canonical, alias) DO UPDATE
+SET kind       = EXCLUDED.kind,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: DeleteSearchAliases :execrows
+DELETE FROM search_aliases
+WHERE canonical = sqlc.arg(canonical);
@@ -0,0 +1,111 @@
+-- name: CreateTaskRun :one
+INSERT INTO task_runs (
+    task_id,
+    batch_id,
+    kind,
+    source_type,
+    source_abbr,
+    query,
+    status,
+    error_class,
+    items_seen,
+    items_new,
+    items_duplicate,
+    providers,
+    duration_ms,
+    trace_id,
+    started_at
+) VALUES (
+    sqlc.arg(task_id),
+    sqlc.arg(batch_id),
+    sqlc.arg(kind),
+    sqlc.arg(source_type),
+    sqlc.arg(source_abbr),
+    sqlc.narg(query),
+    sqlc.arg(status),
+    sqlc.narg(error_class),
+    sqlc.arg(items_seen),
+    sqlc.arg(items_new),
+    sqlc.arg(items_duplicate),
+    sqlc.arg(providers),
+    sqlc.arg(duration_ms),
+    sqlc.arg(trace_id),
+    sqlc.arg(started_at)
+)
+RETURNING *;
+
+-- name: ListTaskRunsByTaskID :many
+-- Keyset page over (started_at, id) DESC. after_started_at/after_id are the
+-- last row of the previous page.
+SELECT *
+FROM task_runs
+WHERE task_id = sqlc.arg(task_id)
+  AND (sqlc.narg(after_started_at)::timestamptz IS NULL
+       OR (started_at, id) < (sqlc.narg(after_started_at)::timestamptz, sqlc.narg(after_id)::bigint))
+ORDER BY started_at DESC, id DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: SummarizeTaskRunsBySource :many
+-- Yield per task kind and source for runs started in [since, until).
+SELECT
+    kind,
+    source_abbr,
+    COUNT(*)                                              AS runs,
+    COUNT(*) FILTER (WHERE status = 'FAILED')             AS failed_runs,
+    COALESCE(SUM(items_seen), 0)::bigint                  AS items_seen,
+    COALESCE(SUM(items_new), 0)::bigint                   AS items_new,
+    COALESCE(SUM(items_duplicate), 0)::bigint             AS items_duplicate,
+    COALESCE(AVG(duration_ms), 0)::bigint                 AS avg_duration_ms,
+    MAX(started_at)::timestamptz                          AS last_run_at,
+    MAX(started_at) FILTER (WHERE items_new > 0)::timestamptz AS last_new_at
+FROM task_runs
+WHERE started_at >= sqlc.arg(since)
+  AND started_at < sqlc.arg(until)
+  AND (sqlc.narg(kind)::task_kind IS NULL OR kind = sqlc.narg(kind)::task_kind)
+  AND (sqlc.narg(source_abbr)::varchar IS NULL OR source_abbr = sqlc.narg(source_abbr)::varchar)
+GROUP BY kind, source_abbr
+ORDER BY kind, source_abbr
+LIMIT sqlc.arg(row_limit);
+
+-- name: SummarizeTaskRunsByTask :many
+-- Yield per task (one phrase and site for KEYWORD_SEARCH) for runs started
+-- in [since, until), least productive first: the pruning candidates.
+SELECT
+    task_id,
+    kind,
+    source_abbr,
+    COALESCE(MAX(query), '')::text                        AS query,
+    COUNT(*)                                              AS runs,
+    COUNT(*) FILTER (WHERE status = 'FAILED')             AS failed_runs,
+    COALESCE(SUM(items_seen), 0)::bigint                  AS items_seen,
+    COALESCE(SUM(items_new), 0)::bigint                   AS items_new,
+    COALESCE(SUM(items_duplicate), 0)::bigint             AS items_duplicate,
+    COALESCE(AVG(duration_ms), 0)::bigint                 AS avg_duration_ms,
+    MAX(started_at)::timestamptz                          AS last_run_at,
+    MAX(started_at) FILTER (WHERE items_new > 0)::timestamptz AS last_new_at
+FROM task_runs
+WHERE started_at >= sqlc.arg(since)
+  AND started_at < sqlc.arg(until)
+  AND (sqlc.narg(kind)::task_kind IS NULL OR kind = sqlc.narg(kind)::task_kind)
+  AND (sqlc.narg(source_abbr)::varchar IS NULL OR source_abbr = sqlc.narg(source_abbr)::varchar)
+GROUP BY task_id, kind, source_abbr
+ORDER BY items_new, runs DESC, task_id
+LIMIT sqlc.arg(row_limit);
+
+-- name: SummarizeTaskRunsByProvider :many
+-- Search provider results of KEYWORD_SEARCH runs started in [since, until).
+-- Only seen counts are per provider; new and duplicate are per run.
+SELECT
+    p.key::text                                           AS provider,
+    COUNT(*)                                              AS runs,
+    COUNT(*) FILTER (WHERE p.value ? 'error_class')       AS failed_runs,
+    COALESCE(SUM((p.value->>'seen')::int), 0)::bigint     AS items_seen,
+    MAX(r.started_at)::timestamptz                        AS last_run_at
+FROM task_runs AS r
+CROSS JOIN LATERAL jsonb_each(r.providers) AS p
+WHERE r.started_at >= sqlc.arg(since)
+  AND r.started_at < sqlc.arg(until)
+  AND (sqlc.narg(source_abbr)::varchar IS NULL OR r.source_abbr = sqlc.narg(source_abbr)::varchar)
+GROUP BY p.key
+ORDER BY p.key
+LIMIT sqlc.arg(row_limit);
@@ -105,19 +105,26 @@ WHERE id IN (
 RETURNING *;
 
 -- name: CompleteTask :exec
+-- next_run_in, when set, replaces frequency as the delay before the next run
+-- of a recurring task (adaptive polling); meta, when set, is merged into the
+-- task's meta.
 UPDATE tasks
 SET status = CASE
         WHEN frequency IS NOT NULL
-         AND (expires_at IS NULL OR NOW() + frequency <= expires_at)
+         AND (expires_at IS NULL OR NOW() + COALESCE(sqlc.narg(next_run_in)::interval, frequency) <= expires_at)
             THEN 'PENDING'::task_status
         ELSE 'COMPLETED'::task_status
     END,
     next_run_at = CASE
         WHEN frequency IS NOT NULL
-         AND (expires_at IS NULL OR NOW() + frequency <= expires_at)
-            THEN NOW() + frequency
+         AND (expires_at IS NULL OR NOW() + COALESCE(sqlc.narg(next_run_in)::interval, frequency) <= expires_at)
+            THEN NOW() + COALESCE(sqlc.narg(next_run_in)::interval, frequency)
         ELSE next_run_at
     END,
+    meta = CASE
+        WHEN sqlc.narg(meta)::jsonb IS NULL THEN meta
+        ELSE COALESCE(meta, '{}'::jsonb) || sqlc.narg(meta)::jsonb
+    END,
     last_run_at = NOW(),
     updated_at = NOW()
 WHERE id = sqlc.arg(id)
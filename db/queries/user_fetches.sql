@@ -1,6 +1,6 @@
 -- name: CreateUserFetch :one
-INSERT INTO fetches (user_id)
-VALUES (sqlc.narg(user_id))
+INSERT INTO fetches (user_id, notify_url, notify_secret)
+VALUES (sqlc.narg(user_id), sqlc.narg(notify_url), sqlc.narg(notify_secret))
 RETURNING *;
 
 -- name: GetUserFetch :one
@@ -79,10 +79,28 @@ SELECT
         WHERE status IN ('COMPLETED', 'FAILED', 'ALREADY_COMPLETE')
     ) = (SELECT COUNT(*) FROM resolved))                                        AS terminal;
 
--- name: MarkUserFetchCompleted :exec
--- Sets completed_at on transition to terminal. Idempotent (WHERE clause
--- guards against double-set). v1 callers may skip this — progress endpoint
--- computes terminal on-the-fly. Reserved for v2 notification dispatcher.
+-- name: ListTerminalUserFetches :many
+-- Open fetches whose items have all reached COMPLETED / FAILED /
+-- ALREADY_COMPLETE, oldest first. Resolves item status exactly like
+-- GetUserFetchProgress; fetches without items never qualify.
+SELECT f.*
+FROM fetches f
+WHERE f.completed_at IS NULL
+  AND EXISTS (SELECT 1 FROM fetch_items i WHERE i.fetch_id = f.id)
+  AND NOT EXISTS (
+      SELECT 1
+      FROM fetch_items i
+      LEFT JOIN tasks t ON t.id = i.task_id
+      WHERE i.fetch_id = f.id
+        AND COALESCE(i.snapshot_status, t.status::text, '') NOT IN ('COMPLETED', 'FAILED', 'ALREADY_COMPLETE')
+  )
+ORDER BY f.created_at ASC
+LIMIT $1;
+
+-- name: MarkUserFetchCompleted :execrows
+-- Sets completed_at on transition to terminal. Optimistic-concurrency claim:
+-- returns rows-affected so only the notifier instance that wins (1) logs the
+-- transition; the progress endpoint still computes terminal on-the-fly.
 UPDATE fetches
 SET completed_at = NOW()
 WHERE id = $1
@@ -0,0 +1,59 @@
+-- name: CreateUser :one
+-- A taken name inserts nothing and returns no row. Adapter maps that to
+-- repo.ErrUserExists.
+INSERT INTO users (name)
+VALUES (sqlc.arg(name))
+ON CONFLICT (name) DO NOTHING
+RETURNING *;
+
+-- name: GetUser :one
+SELECT *
+FROM users
+WHERE id = sqlc.arg(id);
+
+-- name: CreateAPIKey :one
+INSERT INTO api_keys (
+    user_id,
+    name,
+    prefix,
+    key_hash,
+    scopes,
+    rate_limit_rps,
+    rate_limit_burst,
+    expires_at
+) VALUES (
+    sqlc.arg(user_id),
+    sqlc.arg(name),
+    sqlc.arg(prefix),
+    sqlc.arg(key_hash),
+    sqlc.arg(scopes),
+    sqlc.narg(rate_limit_rps),
+    sqlc.narg(rate_limit_burst),
+    sqlc.narg(expires_at)
+)
+RETURNING *;
+
+-- name: TouchActiveAPIKeyByHash :one
+-- Resolves a presented key: returns it only while it is unrevoked, unexpired
+-- and its user is enabled, refreshing last_used_at on the way.
+UPDATE api_keys AS k
+SET last_used_at = NOW()
+FROM users AS u
+WHERE k.key_hash = sqlc.arg(key_hash)
+  AND u.id = k.user_id
+  AND u.disabled_at IS NULL
+  AND k.revoked_at IS NULL
+  AND (k.expires_at IS NULL OR k.expires_at > NOW())
+RETURNING k.*;
+
+-- name: ListAPIKeysByUserID :many
+SELECT *
+FROM api_keys
+WHERE user_id = sqlc.arg(user_id)
+ORDER BY created_at, id;
+
+-- name: RevokeAPIKey :execrows
+UPDATE api_keys
+SET revoked_at = NOW()
+WHERE id = sqlc.arg(id)
+  AND revoked_at IS NULL;
@@ -53,7 +53,8 @@ ALTER TYPE public.candidate_ingestion_method OWNER TO postgres;
 CREATE TYPE public.content_type AS ENUM (
     'PARTY_RELEASE',
     'ARTICLE',
-    'SOCIAL'
+    'SOCIAL',
+    'GOVERNMENT_RELEASE'
 );
 
 
@@ -113,7 +114,8 @@ ALTER TYPE public.model_type OWNER TO postgres;
 
 CREATE TYPE public.source_type AS ENUM (
     'PARTY',
-    'MEDIA'
+    'MEDIA',
+    'GOVERNMENT'
 );
 
 
@@ -146,6 +148,273 @@ CREATE TYPE public.task_status AS ENUM (
 
 ALTER TYPE public.task_status OWNER TO postgres;
 
+--
+-- Name: api_keys; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.api_keys (
+    id uuid DEFAULT uuidv7() NOT NULL,
+    user_id uuid NOT NULL,
+    name character varying(128) NOT NULL,
+    prefix character varying(16) NOT NULL,
+    key_hash character(64) NOT NULL,
+    scopes text[] NOT NULL,
+    rate_limit_rps double precision,
+    rate_limit_burst integer,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    last_used_at timestamp with time zone,
+    expires_at timestamp with time zone,
+    revoked_at timestamp with time zone,
+    CONSTRAINT api_keys_scopes_check CHECK ((scopes <@ ARRAY['read'::text, 'page_fetch'::text, 'admin'::text]))
+);
+
+
+ALTER TABLE public.api_keys OWNER TO postgres;
+
+
+--
+-- Name: TABLE api_keys; Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON TABLE public.api_keys IS 'Hashed API keys. A key authenticates as its user with the listed scopes.';
+
+
+--
+-- Name: COLUMN api_keys.prefix; Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON COLUMN public.api_keys.prefix IS 'Leading characters of the plaintext key, shown in listings to tell keys apart.';
+
+
+--
+-- Name: COLUMN api_keys.key_hash; Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON COLUMN public.api_keys.key_hash IS 'SHA-256 of the plaintext key, hex. The plaintext is never stored.';
+
+
+--
+-- Name: COLUMN api_keys.scopes; Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON COLUMN public.api_keys.scopes IS 'Subset of read, page_fetch, admin. admin implies the others.';
+
+
+--
+-- Name: COLUMN api_keys.rate_limit_rps; Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON COLUMN public.api_keys.rate_limit_rps IS 'Per-key request budget. NULL falls back to the server default.';
+
+
+--
+-- Name: COLUMN api_keys.last_used_at; Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON COLUMN public.api_keys.last_used_at IS 'Refreshed when the API server resolves the key (at most once per cache TTL).';
+
+
+--
+-- Name: backfill_checkpoints; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.backfill_checkpoints (
+    source_abbr character varying(16) NOT NULL,
+    pager character varying(16) NOT NULL,
+    last_page text NOT NULL,
+    batch_id uuid NOT NULL,
+    until timestamp with time zone NOT NULL,
+    pages_visited integer DEFAULT 0 NOT NULL,
+    candidates_seen integer DEFAULT 0 NOT NULL,
+    candidates_processed integer DEFAULT 0 NOT NULL,
+    oldest_published_at timestamp with time zone,
+    completed_at timestamp with time zone,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
+ALTER TABLE public.backfill_checkpoints OWNER TO postgres;
+
+
+--
+-- Name: TABLE backfill_checkpoints; Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON TABLE public.backfill_checkpoints IS 'Last finished listing page of each source''s backfill run. A run resumes from it unless completed_at is set or the pager type changed.';
+
+
+--
+-- Name: COLUMN backfill_checkpoints.pager; Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON COLUMN public.backfill_checkpoints.pager IS 'Pager type (index, offset, next_link, date_window, sitemap) last_page belongs to.';
+
+
+--
+-- Name: COLUMN backfill_checkpoints.last_page; Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON COLUMN public.backfill_checkpoints.last_page IS 'Pager position of the last finished page: a page value, a URL or a window start.';
+
+
+--
+-- Name: COLUMN backfill_checkpoints.batch_id; Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON COLUMN public.backfill_checkpoints.batch_id IS 'Batch of the run. A resumed run keeps it so all its candidates share one batch. No FK: batches are created by the sink.';
+
+
+--
+-- Name: cjk_bigrams(input text); Type: FUNCTION; Schema: public; Owner: postgres
+--
+
+CREATE FUNCTION public.cjk_bigrams(input text) RETURNS text
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE
+    AS $$
+SELECT COALESCE(string_agg(
+    CASE
+        WHEN cjk AND next_cjk THEN ' ' || c || next_c || ' '
+        WHEN cjk AND NOT prev_cjk THEN ' ' || c || ' '
+        WHEN cjk THEN ' '
+        ELSE c
+    END, '' ORDER BY i), '')
+FROM (
+    SELECT i, c, cjk,
+           lead(c) OVER w AS next_c,
+           COALESCE(lead(cjk) OVER w, false) AS next_cjk,
+           COALESCE(lag(cjk) OVER w, false) AS prev_cjk
+    FROM (
+        -- Kana, CJK ideographs (incl. ext. A and compatibility), Hangul.
+        SELECT i, c, c ~ '[\u3040-\u30ff\u3400-\u4dbf\u4e00-\u9fff\uf900-\ufaff\uac00-\ud7af]' AS cjk
+        FROM unnest(regexp_split_to_array(input, '')) WITH ORDINALITY AS t(c, i)
+    ) chars
+    WINDOW w AS (ORDER BY i)
+) s;
+$$;
+
+
+ALTER FUNCTION public.cjk_bigrams(input text) OWNER TO postgres;
+
+--
+-- Name: FUNCTION cjk_bigrams(input text); Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON FUNCTION public.cjk_bigrams(input text) IS 'Rewrites CJK runs into space-separated overlapping bigrams; other text is kept.';
+
+
+--
+-- Name: contents_search_document(title text, content text); Type: FUNCTION; Schema: public; Owner: postgres
+--
+
+CREATE FUNCTION public.contents_search_document(title text, content text) RETURNS tsvector
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE
+    AS $$
+SELECT to_tsvector('simple'::regconfig, public.cjk_bigrams(title) || ' ' || public.cjk_bigrams(content));
+$$;
+
+
+ALTER FUNCTION public.contents_search_document(title text, content text) OWNER TO postgres;
+
+--
+-- Name: FUNCTION contents_search_document(title text, content text); Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON FUNCTION public.contents_search_document(title text, content text) IS 'Indexed search document for contents. Must match contents_search_query.';
+
+
+--
+-- Name: contents_search_query(q text); Type: FUNCTION; Schema: public; Owner: postgres
+--
+
+CREATE FUNCTION public.contents_search_query(q text) RETURNS tsquery
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE
+    AS $$
+SELECT plainto_tsquery('simple'::regconfig, public.cjk_bigrams(q));
+$$;
+
+
+ALTER FUNCTION public.contents_search_query(q text) OWNER TO postgres;
+
+--
+-- Name: FUNCTION contents_search_query(q text); Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON FUNCTION public.contents_search_query(q text) IS 'AND of every token (CJK bigram or word) in q.';
+
+
+--
+-- Name: notify_candidate_upserted(); Type: FUNCTION; Schema: public; Owner: postgres
+--
+
+CREATE FUNCTION public.notify_candidate_upserted() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    PERFORM pg_notify('prism_candidates', NEW.source_abbr);
+    RETURN NULL;
+END;
+$$;
+
+
+ALTER FUNCTION public.notify_candidate_upserted() OWNER TO postgres;
+
+--
+-- Name: FUNCTION notify_candidate_upserted(); Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON FUNCTION public.notify_candidate_upserted() IS 'Wakes GET /candidates/stream listeners. Payload is source_abbr.';
+
+
+--
+-- Name: notify_catalog_changed(); Type: FUNCTION; Schema: public; Owner: postgres
+--
+
+CREATE FUNCTION public.notify_catalog_changed() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    PERFORM pg_notify('prism_catalog', TG_TABLE_NAME);
+    RETURN NULL;
+END;
+$$;
+
+
+ALTER FUNCTION public.notify_catalog_changed() OWNER TO postgres;
+
+
+--
+-- Name: FUNCTION notify_catalog_changed(); Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON FUNCTION public.notify_catalog_changed() IS 'Wakes workers holding a scout or parser registry. Payload is the table name.';
+
+
+--
+-- Name: notify_fetch_progress(); Type: FUNCTION; Schema: public; Owner: postgres
+--
+
+CREATE FUNCTION public.notify_fetch_progress() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    PERFORM pg_notify('prism_fetch_progress', fi.fetch_id::text)
+    FROM fetch_items fi
+    WHERE fi.task_id = NEW.id;
+    RETURN NULL;
+END;
+$$;
+
+
+ALTER FUNCTION public.notify_fetch_progress() OWNER TO postgres;
+
+--
+-- Name: FUNCTION notify_fetch_progress(); Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON FUNCTION public.notify_fetch_progress() IS 'Wakes GET /fetches/{id}/events listeners. Payload is fetch_id.';
+
+
 SET default_tablespace = '';
 
 SET default_table_access_method = heap;
@@ -369,7 +638,8 @@ CREATE TABLE public.content_extractions (
     summary text NOT NULL,
     raw_result jsonb NOT NULL,
     trace_id character varying(100) NOT NULL,
-    created_at timestamp with time zone DEFAULT now() NOT NULL
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    prompt_version character varying(64) DEFAULT ''::character varying NOT NULL
 );
 
 
@@ -382,6 +652,13 @@ ALTER TABLE public.content_extractions OWNER TO postgres;
 COMMENT ON TABLE public.content_extractions IS 'One structured extraction per (content, model, prompt, schema_version). Append-only snapshot.';
 
 
+--
+-- Name: COLUMN content_extractions.prompt_version; Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON COLUMN public.content_extractions.prompt_version IS 'prompts.version at extraction time, denormalised so A/B results can be grouped without a join. Empty for rows written before the registry.';
+
+
 --
 -- Name: contents; Type: TABLE; Schema: public; Owner: postgres
 --
@@ -479,6 +756,50 @@ COMMENT ON TABLE public.fetch_items IS 'One row per (fetch, candidate). task_id
 COMMENT ON COLUMN public.fetch_items.snapshot_status IS 'NULL for live items (status comes from tasks.status). Set to ALREADY_COMPLETE when the candidate already had contents at submit time.';
 
 
+--
+-- Name: fetch_notifications; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.fetch_notifications (
+    id uuid DEFAULT uuidv7() NOT NULL,
+    fetch_id uuid NOT NULL,
+    url text NOT NULL,
+    payload jsonb NOT NULL,
+    status character varying(16) DEFAULT 'PENDING'::character varying NOT NULL,
+    attempts integer DEFAULT 0 NOT NULL,
+    next_attempt_at timestamp with time zone DEFAULT now() NOT NULL,
+    last_status_code integer,
+    last_error text,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    delivered_at timestamp with time zone
+);
+
+
+ALTER TABLE public.fetch_notifications OWNER TO postgres;
+
+
+--
+-- Name: TABLE fetch_notifications; Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON TABLE public.fetch_notifications IS 'Webhook delivery log: one row per notified fetch, carrying the frozen payload and retry state.';
+
+
+--
+-- Name: COLUMN fetch_notifications.status; Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON COLUMN public.fetch_notifications.status IS 'PENDING until a 2xx response (DELIVERED) or the attempt limit is reached (FAILED).';
+
+
+--
+-- Name: COLUMN fetch_notifications.next_attempt_at; Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON COLUMN public.fetch_notifications.next_attempt_at IS 'Due time of the next attempt. Also leased forward while an attempt is in flight.';
+
+
 --
 -- Name: fetches; Type: TABLE; Schema: public; Owner: postgres
 --
@@ -487,7 +808,9 @@ CREATE TABLE public.fetches (
     id uuid DEFAULT uuidv7() NOT NULL,
     user_id uuid,
     created_at timestamp with time zone DEFAULT now() NOT NULL,
-    completed_at timestamp with time zone
+    completed_at timestamp with time zone,
+    notify_url text,
+    notify_secret text
 );
 
 
@@ -504,14 +827,95 @@ COMMENT ON TABLE public.fetches IS 'User-facing observation layer for POST /page
 -- Name: COLUMN fetches.user_id; Type: COMMENT; Schema: public; Owner: postgres
 --
 
-COMMENT ON COLUMN public.fetches.user_id IS 'Nullable in v1 (single-user dev). Filter target for multi-user RBAC.';
+COMMENT ON COLUMN public.fetches.user_id IS 'Submitting user. NULL when auth is disabled or the caller used a static token.';
 
 
 --
 -- Name: COLUMN fetches.completed_at; Type: COMMENT; Schema: public; Owner: postgres
 --
 
-COMMENT ON COLUMN public.fetches.completed_at IS 'Persisted in v1 but unused; v2 notification dispatcher will set on transition.';
+COMMENT ON COLUMN public.fetches.completed_at IS 'Set by cmd/fetch/notifier when every item reaches COMPLETED / FAILED / ALREADY_COMPLETE.';
+
+
+--
+-- Name: COLUMN fetches.notify_url; Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON COLUMN public.fetches.notify_url IS 'Webhook target for fetch.completed. NULL when the client polls GET /fetches/{id} instead.';
+
+
+--
+-- Name: COLUMN fetches.notify_secret; Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON COLUMN public.fetches.notify_secret IS 'HMAC-SHA256 key for X-Prism-Signature. Never returned by the API.';
+
+
+--
+-- Name: llm_usage; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.llm_usage (
+    id bigint NOT NULL,
+    provider character varying(32) NOT NULL,
+    model character varying(64) NOT NULL,
+    operation character varying(16) NOT NULL,
+    component character varying(32) NOT NULL,
+    input_tokens integer DEFAULT 0 NOT NULL,
+    output_tokens integer DEFAULT 0 NOT NULL,
+    cached_tokens integer DEFAULT 0 NOT NULL,
+    total_tokens integer DEFAULT 0 NOT NULL,
+    cost_micros bigint DEFAULT 0 NOT NULL,
+    trace_id character varying(100) NOT NULL,
+    batch_id uuid,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
+ALTER TABLE public.llm_usage OWNER TO postgres;
+
+
+--
+-- Name: TABLE llm_usage; Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON TABLE public.llm_usage IS 'Append-only ledger of LLM provider calls. One row per upstream call; cache hits are not recorded.';
+
+
+--
+-- Name: COLUMN llm_usage.cost_micros; Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON COLUMN public.llm_usage.cost_micros IS 'Priced cost in micro-USD at record time. Zero when the model has no configured price.';
+
+
+--
+-- Name: COLUMN llm_usage.batch_id; Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON COLUMN public.llm_usage.batch_id IS 'Batch being processed when known. No FK: the ledger outlives batch cleanup.';
+
+
+--
+-- Name: llm_usage_id_seq; Type: SEQUENCE; Schema: public; Owner: postgres
+--
+
+CREATE SEQUENCE public.llm_usage_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+ALTER SEQUENCE public.llm_usage_id_seq OWNER TO postgres;
+
+
+--
+-- Name: llm_usage_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: postgres
+--
+
+ALTER SEQUENCE public.llm_usage_id_seq OWNED BY public.llm_usage.id;
 
 
 --
@@ -555,6 +959,28 @@ ALTER SEQUENCE public.models_id_seq OWNER TO postgres;
 ALTER SEQUENCE public.models_id_seq OWNED BY public.models.id;
 
 
+--
+-- Name: parser_rules; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.parser_rules (
+    host character varying(255) NOT NULL,
+    config jsonb NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
+ALTER TABLE public.parser_rules OWNER TO postgres;
+
+
+--
+-- Name: TABLE parser_rules; Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON TABLE public.parser_rules IS 'Per-host article parser rules. Replaces the parsers: map of parsers.yaml when workers run with --registry-source=postgres.';
+
+
 --
 -- Name: prompts; Type: TABLE; Schema: public; Owner: postgres
 --
@@ -563,7 +989,11 @@ CREATE TABLE public.prompts (
     id uuid DEFAULT uuidv7() NOT NULL,
     hash character(64) NOT NULL,
     path text NOT NULL,
-    created_at timestamp with time zone DEFAULT now() NOT NULL
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    name text NOT NULL,
+    version character varying(64) NOT NULL,
+    content text DEFAULT ''::text NOT NULL,
+    metadata jsonb DEFAULT '{}'::jsonb NOT NULL
 );
 
 
@@ -573,7 +1003,14 @@ ALTER TABLE public.prompts OWNER TO postgres;
 -- Name: TABLE prompts; Type: COMMENT; Schema: public; Owner: postgres
 --
 
-COMMENT ON TABLE public.prompts IS 'Prompt asset registry. hash = SHA-256(body), used to pin extraction provenance.';
+COMMENT ON TABLE public.prompts IS 'Prompt registry. One immutable row per (name, version); hash = SHA-256(content), used to pin extraction provenance.';
+
+
+--
+-- Name: COLUMN prompts.metadata; Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON COLUMN public.prompts.metadata IS 'Free-form registration metadata (registering component, source file size, ...).';
 
 
 --
@@ -588,6 +1025,76 @@ CREATE TABLE public.schema_migrations (
 
 ALTER TABLE public.schema_migrations OWNER TO postgres;
 
+--
+-- Name: scout_configs; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.scout_configs (
+    name character varying(64) NOT NULL,
+    kind character varying(16) NOT NULL,
+    config jsonb NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT scout_configs_kind_check CHECK (((kind)::text = ANY ((ARRAY['html'::character varying, 'rss'::character varying, 'atom'::character varying, 'sitemap'::character varying, 'json'::character varying, 'social'::character varying, 'custom'::character varying])::text[])))
+);
+
+
+ALTER TABLE public.scout_configs OWNER TO postgres;
+
+
+--
+-- Name: TABLE scout_configs; Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON TABLE public.scout_configs IS 'Discovery scouts, one per outlet listing. Replaces scouts.yaml when workers run with --registry-source=postgres.';
+
+
+--
+-- Name: COLUMN scout_configs.config; Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON COLUMN public.scout_configs.config IS 'One scouts.yaml entry (name, hosts, headers, rules, ...) with section defaults already applied.';
+
+
+--
+-- Name: search_aliases; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.search_aliases (
+    canonical text NOT NULL,
+    alias text NOT NULL,
+    kind character varying(16) DEFAULT 'ALIAS'::character varying NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT search_aliases_distinct_check CHECK ((alias <> canonical)),
+    CONSTRAINT search_aliases_kind_check CHECK (((kind)::text = ANY ((ARRAY['ALIAS'::character varying, 'ABBREVIATION'::character varying, 'VARIANT'::character varying])::text[])))
+);
+
+
+ALTER TABLE public.search_aliases OWNER TO postgres;
+
+
+--
+-- Name: TABLE search_aliases; Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON TABLE public.search_aliases IS 'Alias groups for planner query expansion, managed through /api/v1/admin/aliases.';
+
+
+--
+-- Name: COLUMN search_aliases.canonical; Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON COLUMN public.search_aliases.canonical IS 'Name the group is keyed by. Planner tasks are deduplicated on it.';
+
+
+--
+-- Name: COLUMN search_aliases.kind; Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON COLUMN public.search_aliases.kind IS 'ALIAS (nickname or other name), ABBREVIATION (short form) or VARIANT (script or spelling variant).';
+
+
 --
 -- Name: sources; Type: TABLE; Schema: public; Owner: postgres
 --
@@ -602,7 +1109,108 @@ CREATE TABLE public.sources (
 );
 
 
-ALTER TABLE public.sources OWNER TO postgres;
+ALTER TABLE public.sources OWNER TO postgres;
+
+--
+-- Name: task_runs; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.task_runs (
+    id bigint NOT NULL,
+    task_id uuid NOT NULL,
+    batch_id uuid NOT NULL,
+    kind public.task_kind NOT NULL,
+    source_type public.source_type NOT NULL,
+    source_abbr character varying(16) NOT NULL,
+    query text,
+    status character varying(16) NOT NULL,
+    error_class character varying(32),
+    items_seen integer DEFAULT 0 NOT NULL,
+    items_new integer DEFAULT 0 NOT NULL,
+    items_duplicate integer DEFAULT 0 NOT NULL,
+    providers jsonb DEFAULT '{}'::jsonb NOT NULL,
+    duration_ms integer NOT NULL,
+    trace_id character varying(100) NOT NULL,
+    started_at timestamp with time zone NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT task_runs_error_class_check CHECK (((((status)::text = 'FAILED'::text)) = (error_class IS NOT NULL))),
+    CONSTRAINT task_runs_status_check CHECK (((status)::text = ANY ((ARRAY['OK'::character varying, 'FAILED'::character varying])::text[])))
+);
+
+
+ALTER TABLE public.task_runs OWNER TO postgres;
+
+
+--
+-- Name: TABLE task_runs; Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON TABLE public.task_runs IS 'Append-only history of discovery task executions, one row per run.';
+
+
+--
+-- Name: COLUMN task_runs.task_id; Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON COLUMN public.task_runs.task_id IS 'Task that ran. No FK: the history outlives task cleanup.';
+
+
+--
+-- Name: COLUMN task_runs.query; Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON COLUMN public.task_runs.query IS 'KEYWORD_SEARCH phrase; NULL for DIRECTORY_FETCH.';
+
+
+--
+-- Name: COLUMN task_runs.error_class; Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON COLUMN public.task_runs.error_class IS 'Low-cardinality failure class, e.g. timeout, rate_limited, scout, search, sink. Set exactly when status is FAILED.';
+
+
+--
+-- Name: COLUMN task_runs.items_seen; Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON COLUMN public.task_runs.items_seen IS 'Candidates the scout or search providers returned.';
+
+
+--
+-- Name: COLUMN task_runs.items_duplicate; Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON COLUMN public.task_runs.items_duplicate IS 'Stored candidates whose fingerprint was already known; UpsertCandidate only bumped discovered_at.';
+
+
+--
+-- Name: COLUMN task_runs.providers; Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON COLUMN public.task_runs.providers IS 'KEYWORD_SEARCH result per search provider: {"<provider>": {"seen": n}} or {"<provider>": {"error_class": "..."}}.';
+
+
+--
+-- Name: task_runs_id_seq; Type: SEQUENCE; Schema: public; Owner: postgres
+--
+
+CREATE SEQUENCE public.task_runs_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+ALTER SEQUENCE public.task_runs_id_seq OWNER TO postgres;
+
+
+--
+-- Name: task_runs_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: postgres
+--
+
+ALTER SEQUENCE public.task_runs_id_seq OWNED BY public.task_runs.id;
+
 
 --
 -- Name: tasks; Type: TABLE; Schema: public; Owner: postgres
@@ -654,6 +1262,35 @@ COMMENT ON COLUMN public.tasks.payload IS 'Request details (e.g. {query, site} f
 COMMENT ON COLUMN public.tasks.payload_hash IS 'SHA-256(canonical JSON payload), hex. KEYWORD_SEARCH dedup via uq_tasks_active_payload. PAGE_FETCH dedups on url instead.';
 
 
+--
+-- Name: users; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.users (
+    id uuid DEFAULT uuidv7() NOT NULL,
+    name character varying(128) NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    disabled_at timestamp with time zone
+);
+
+
+ALTER TABLE public.users OWNER TO postgres;
+
+
+--
+-- Name: TABLE users; Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON TABLE public.users IS 'API callers. Owners of fetches and api_keys.';
+
+
+--
+-- Name: COLUMN users.disabled_at; Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON COLUMN public.users.disabled_at IS 'Set to reject every key of the user without revoking them one by one.';
+
+
 --
 -- Name: candidate_embeddings_gemma_2025 id; Type: DEFAULT; Schema: public; Owner: postgres
 --
@@ -682,6 +1319,13 @@ ALTER TABLE ONLY public.content_extraction_phrases ALTER COLUMN id SET DEFAULT n
 ALTER TABLE ONLY public.entities ALTER COLUMN id SET DEFAULT nextval('public.entities_id_seq'::regclass);
 
 
+--
+-- Name: llm_usage id; Type: DEFAULT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.llm_usage ALTER COLUMN id SET DEFAULT nextval('public.llm_usage_id_seq'::regclass);
+
+
 --
 -- Name: models id; Type: DEFAULT; Schema: public; Owner: postgres
 --
@@ -689,6 +1333,37 @@ ALTER TABLE ONLY public.entities ALTER COLUMN id SET DEFAULT nextval('public.ent
 ALTER TABLE ONLY public.models ALTER COLUMN id SET DEFAULT nextval('public.models_id_seq'::regclass);
 
 
+--
+-- Name: task_runs id; Type: DEFAULT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.task_runs ALTER COLUMN id SET DEFAULT nextval('public.task_runs_id_seq'::regclass);
+
+
+--
+-- Name: api_keys api_keys_key_hash_key; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.api_keys
+    ADD CONSTRAINT api_keys_key_hash_key UNIQUE (key_hash);
+
+
+--
+-- Name: api_keys api_keys_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.api_keys
+    ADD CONSTRAINT api_keys_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: backfill_checkpoints backfill_checkpoints_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.backfill_checkpoints
+    ADD CONSTRAINT backfill_checkpoints_pkey PRIMARY KEY (source_abbr);
+
+
 --
 -- Name: batches batches_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
 --
@@ -801,6 +1476,22 @@ ALTER TABLE ONLY public.fetch_items
     ADD CONSTRAINT fetch_items_pkey PRIMARY KEY (fetch_id, candidate_id);
 
 
+--
+-- Name: fetch_notifications fetch_notifications_fetch_id_key; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.fetch_notifications
+    ADD CONSTRAINT fetch_notifications_fetch_id_key UNIQUE (fetch_id);
+
+
+--
+-- Name: fetch_notifications fetch_notifications_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.fetch_notifications
+    ADD CONSTRAINT fetch_notifications_pkey PRIMARY KEY (id);
+
+
 --
 -- Name: fetches fetches_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
 --
@@ -809,6 +1500,14 @@ ALTER TABLE ONLY public.fetches
     ADD CONSTRAINT fetches_pkey PRIMARY KEY (id);
 
 
+--
+-- Name: llm_usage llm_usage_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.llm_usage
+    ADD CONSTRAINT llm_usage_pkey PRIMARY KEY (id);
+
+
 --
 -- Name: models models_name_key; Type: CONSTRAINT; Schema: public; Owner: postgres
 --
@@ -826,11 +1525,19 @@ ALTER TABLE ONLY public.models
 
 
 --
--- Name: prompts prompts_hash_key; Type: CONSTRAINT; Schema: public; Owner: postgres
+-- Name: parser_rules parser_rules_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.parser_rules
+    ADD CONSTRAINT parser_rules_pkey PRIMARY KEY (host);
+
+
+--
+-- Name: prompts prompts_name_version_key; Type: CONSTRAINT; Schema: public; Owner: postgres
 --
 
 ALTER TABLE ONLY public.prompts
-    ADD CONSTRAINT prompts_hash_key UNIQUE (hash);
+    ADD CONSTRAINT prompts_name_version_key UNIQUE (name, version);
 
 
 --
@@ -849,6 +1556,22 @@ ALTER TABLE ONLY public.schema_migrations
     ADD CONSTRAINT schema_migrations_pkey PRIMARY KEY (version);
 
 
+--
+-- Name: scout_configs scout_configs_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.scout_configs
+    ADD CONSTRAINT scout_configs_pkey PRIMARY KEY (name);
+
+
+--
+-- Name: search_aliases search_aliases_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.search_aliases
+    ADD CONSTRAINT search_aliases_pkey PRIMARY KEY (canonical, alias);
+
+
 --
 -- Name: sources sources_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
 --
@@ -857,6 +1580,14 @@ ALTER TABLE ONLY public.sources
     ADD CONSTRAINT sources_pkey PRIMARY KEY (abbr);
 
 
+--
+-- Name: task_runs task_runs_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.task_runs
+    ADD CONSTRAINT task_runs_pkey PRIMARY KEY (id);
+
+
 --
 -- Name: tasks tasks_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
 --
@@ -865,6 +1596,29 @@ ALTER TABLE ONLY public.tasks
     ADD CONSTRAINT tasks_pkey PRIMARY KEY (id);
 
 
+--
+-- Name: users users_name_key; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.users
+    ADD CONSTRAINT users_name_key UNIQUE (name);
+
+
+--
+-- Name: users users_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.users
+    ADD CONSTRAINT users_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: idx_api_keys_user_id; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX idx_api_keys_user_id ON public.api_keys USING btree (user_id);
+
+
 --
 -- Name: idx_batches_open_created_at; Type: INDEX; Schema: public; Owner: postgres
 --
@@ -1019,6 +1773,13 @@ CREATE INDEX idx_content_extractions_model_id ON public.content_extractions USIN
 CREATE INDEX idx_content_extractions_prompt_id ON public.content_extractions USING btree (prompt_id);
 
 
+--
+-- Name: idx_content_extractions_prompt_version; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX idx_content_extractions_prompt_version ON public.content_extractions USING btree (prompt_version, created_at);
+
+
 --
 -- Name: idx_content_extractions_trace_id; Type: INDEX; Schema: public; Owner: postgres
 --
@@ -1054,6 +1815,20 @@ CREATE INDEX idx_contents_deleted_at ON public.contents USING btree (deleted_at)
 CREATE INDEX idx_contents_published_at ON public.contents USING btree (published_at);
 
 
+--
+-- Name: idx_contents_published_at_id; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX idx_contents_published_at_id ON public.contents USING btree (published_at, id);
+
+
+--
+-- Name: idx_contents_search; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX idx_contents_search ON public.contents USING gin (public.contents_search_document(title, content));
+
+
 --
 -- Name: idx_contents_source_abbr; Type: INDEX; Schema: public; Owner: postgres
 --
@@ -1124,6 +1899,13 @@ CREATE INDEX idx_entities_type ON public.entities USING btree (type);
 CREATE INDEX idx_fetch_items_task_id ON public.fetch_items USING btree (task_id) WHERE (task_id IS NOT NULL);
 
 
+--
+-- Name: idx_fetch_notifications_due; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX idx_fetch_notifications_due ON public.fetch_notifications USING btree (next_attempt_at) WHERE ((status)::text = 'PENDING'::text);
+
+
 --
 -- Name: idx_fetches_open; Type: INDEX; Schema: public; Owner: postgres
 --
@@ -1138,6 +1920,34 @@ CREATE INDEX idx_fetches_open ON public.fetches USING btree (created_at) WHERE (
 CREATE INDEX idx_fetches_user_id ON public.fetches USING btree (user_id, created_at DESC) WHERE (user_id IS NOT NULL);
 
 
+--
+-- Name: idx_llm_usage_batch_id; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX idx_llm_usage_batch_id ON public.llm_usage USING btree (batch_id) WHERE (batch_id IS NOT NULL);
+
+
+--
+-- Name: idx_llm_usage_component_created_at; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX idx_llm_usage_component_created_at ON public.llm_usage USING btree (component, created_at);
+
+
+--
+-- Name: idx_llm_usage_created_at; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX idx_llm_usage_created_at ON public.llm_usage USING btree (created_at);
+
+
+--
+-- Name: idx_llm_usage_trace_id; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX idx_llm_usage_trace_id ON public.llm_usage USING btree (trace_id);
+
+
 --
 -- Name: idx_models_type_name; Type: INDEX; Schema: public; Owner: postgres
 --
@@ -1145,6 +1955,13 @@ CREATE INDEX idx_fetches_user_id ON public.fetches USING btree (user_id, created
 CREATE INDEX idx_models_type_name ON public.models USING btree (type, name);
 
 
+--
+-- Name: idx_prompts_hash; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX idx_prompts_hash ON public.prompts USING btree (hash);
+
+
 --
 -- Name: idx_prompts_path; Type: INDEX; Schema: public; Owner: postgres
 --
@@ -1166,6 +1983,27 @@ CREATE INDEX idx_sources_base_url ON public.sources USING btree (base_url);
 CREATE INDEX idx_sources_deleted_at ON public.sources USING btree (deleted_at);
 
 
+--
+-- Name: idx_task_runs_source_abbr_started_at; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX idx_task_runs_source_abbr_started_at ON public.task_runs USING btree (source_abbr, started_at);
+
+
+--
+-- Name: idx_task_runs_started_at; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX idx_task_runs_started_at ON public.task_runs USING btree (started_at);
+
+
+--
+-- Name: idx_task_runs_task_id_started_at; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX idx_task_runs_task_id_started_at ON public.task_runs USING btree (task_id, started_at DESC);
+
+
 --
 -- Name: idx_tasks_batch_id; Type: INDEX; Schema: public; Owner: postgres
 --
@@ -1257,6 +2095,50 @@ CREATE UNIQUE INDEX uq_tasks_active_page_fetch ON public.tasks USING btree (kind
 CREATE UNIQUE INDEX uq_tasks_active_payload ON public.tasks USING btree (source_abbr, kind, payload_hash) WHERE ((status = ANY (ARRAY['PENDING'::public.task_status, 'RUNNING'::public.task_status])) AND (payload_hash IS NOT NULL));
 
 
+--
+-- Name: candidates trg_candidates_notify; Type: TRIGGER; Schema: public; Owner: postgres
+--
+
+CREATE TRIGGER trg_candidates_notify AFTER INSERT OR UPDATE OF discovered_at ON public.candidates FOR EACH ROW EXECUTE FUNCTION public.notify_candidate_upserted();
+
+
+--
+-- Name: parser_rules trg_parser_rules_notify; Type: TRIGGER; Schema: public; Owner: postgres
+--
+
+CREATE TRIGGER trg_parser_rules_notify AFTER INSERT OR DELETE OR UPDATE ON public.parser_rules FOR EACH STATEMENT EXECUTE FUNCTION public.notify_catalog_changed();
+
+
+--
+-- Name: scout_configs trg_scout_configs_notify; Type: TRIGGER; Schema: public; Owner: postgres
+--
+
+CREATE TRIGGER trg_scout_configs_notify AFTER INSERT OR DELETE OR UPDATE ON public.scout_configs FOR EACH STATEMENT EXECUTE FUNCTION public.notify_catalog_changed();
+
+
+--
+-- Name: tasks trg_tasks_notify_fetch_progress; Type: TRIGGER; Schema: public; Owner: postgres
+--
+
+CREATE TRIGGER trg_tasks_notify_fetch_progress AFTER UPDATE OF status ON public.tasks FOR EACH ROW WHEN (((new.kind = 'PAGE_FETCH'::public.task_kind) AND (old.status IS DISTINCT FROM new.status))) EXECUTE FUNCTION public.notify_fetch_progress();
+
+
+--
+-- Name: api_keys api_keys_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.api_keys
+    ADD CONSTRAINT api_keys_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+
+--
+-- Name: backfill_checkpoints backfill_checkpoints_source_abbr_fkey; Type: FK CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.backfill_checkpoints
+    ADD CONSTRAINT backfill_checkpoints_source_abbr_fkey FOREIGN KEY (source_abbr) REFERENCES public.sources(abbr) ON DELETE CASCADE;
+
+
 --
 -- Name: candidate_embeddings_gemma_2025 candidate_embeddings_gemma_2025_candidate_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: postgres
 --
@@ -1393,6 +2275,22 @@ ALTER TABLE ONLY public.fetch_items
     ADD CONSTRAINT fetch_items_task_id_fkey FOREIGN KEY (task_id) REFERENCES public.tasks(id) ON DELETE SET NULL;
 
 
+--
+-- Name: fetch_notifications fetch_notifications_fetch_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.fetch_notifications
+    ADD CONSTRAINT fetch_notifications_fetch_id_fkey FOREIGN KEY (fetch_id) REFERENCES public.fetches(id) ON DELETE CASCADE;
+
+
+--
+-- Name: fetches fetches_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.fetches
+    ADD CONSTRAINT fetches_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE SET NULL;
+
+
 --
 -- Name: tasks tasks_source_abbr_fkey; Type: FK CONSTRAINT; Schema: public; Owner: postgres
 --
@@ -1408,6 +2306,20 @@ ALTER TABLE ONLY public.tasks
 GRANT USAGE ON SCHEMA public TO prism;
 
 
+--
+-- Name: TABLE api_keys; Type: ACL; Schema: public; Owner: postgres
+--
+
+GRANT ALL ON TABLE public.api_keys TO prism;
+
+
+--
+-- Name: TABLE backfill_checkpoints; Type: ACL; Schema: public; Owner: postgres
+--
+
+GRANT ALL ON TABLE public.backfill_checkpoints TO prism;
+
+
 --
 -- Name: TABLE batches; Type: ACL; Schema: public; Owner: postgres
 --
@@ -1513,6 +2425,13 @@ GRANT ALL ON SEQUENCE public.entities_id_seq TO prism;
 GRANT ALL ON TABLE public.fetch_items TO prism;
 
 
+--
+-- Name: TABLE fetch_notifications; Type: ACL; Schema: public; Owner: postgres
+--
+
+GRANT ALL ON TABLE public.fetch_notifications TO prism;
+
+
 --
 -- Name: TABLE fetches; Type: ACL; Schema: public; Owner: postgres
 --
@@ -1520,6 +2439,20 @@ GRANT ALL ON TABLE public.fetch_items TO prism;
 GRANT ALL ON TABLE public.fetches TO prism;
 
 
+--
+-- Name: TABLE llm_usage; Type: ACL; Schema: public; Owner: postgres
+--
+
+GRANT ALL ON TABLE public.llm_usage TO prism;
+
+
+--
+-- Name: SEQUENCE llm_usage_id_seq; Type: ACL; Schema: public; Owner: postgres
+--
+
+GRANT ALL ON SEQUENCE public.llm_usage_id_seq TO prism;
+
+
 --
 -- Name: TABLE models; Type: ACL; Schema: public; Owner: postgres
 --
@@ -1534,6 +2467,13 @@ GRANT ALL ON TABLE public.models TO prism;
 GRANT ALL ON SEQUENCE public.models_id_seq TO prism;
 
 
+--
+-- Name: TABLE parser_rules; Type: ACL; Schema: public; Owner: postgres
+--
+
+GRANT ALL ON TABLE public.parser_rules TO prism;
+
+
 --
 -- Name: TABLE prompts; Type: ACL; Schema: public; Owner: postgres
 --
@@ -1548,6 +2488,20 @@ GRANT ALL ON TABLE public.prompts TO prism;
 GRANT ALL ON TABLE public.schema_migrations TO prism;
 
 
+--
+-- Name: TABLE scout_configs; Type: ACL; Schema: public; Owner: postgres
+--
+
+GRANT ALL ON TABLE public.scout_configs TO prism;
+
+
+--
+-- Name: TABLE search_aliases; Type: ACL; Schema: public; Owner: postgres
+--
+
+GRANT ALL ON TABLE public.search_aliases TO prism;
+
+
 --
 -- Name: TABLE sources; Type: ACL; Schema: public; Owner: postgres
 --
@@ -1555,6 +2509,20 @@ GRANT ALL ON TABLE public.schema_migrations TO prism;
 GRANT ALL ON TABLE public.sources TO prism;
 
 
+--
+-- Name: TABLE task_runs; Type: ACL; Schema: public; Owner: postgres
+--
+
+GRANT ALL ON TABLE public.task_runs TO prism;
+
+
+--
+-- Name: SEQUENCE task_runs_id_seq; Type: ACL; Schema: public; Owner: postgres
+--
+
+GRANT ALL ON SEQUENCE public.task_runs_id_seq TO prism;
+
+
 --
 -- Name: TABLE tasks; Type: ACL; Schema: public; Owner: postgres
 --
@@ -1562,6 +2530,13 @@ GRANT ALL ON TABLE public.sources TO prism;
 GRANT ALL ON TABLE public.tasks TO prism;
 
 
+--
+-- Name: TABLE users; Type: ACL; Schema: public; Owner: postgres
+--
+
+GRANT ALL ON TABLE public.users TO prism;
+
+
 --
 -- Name: DEFAULT PRIVILEGES FOR SEQUENCES; Type: DEFAULT ACL; Schema: public; Owner: postgres
 --
@@ -16,11 +16,21 @@ import (
 // block in parsers.yaml). Both are present so a single LLMConfig can be loaded
 // either way without translation.
 type LLMConfig struct {
-	Provider string        `mapstructure:"provider" yaml:"provider" validate:"required,oneof=gemini openai ollama"`
+	Provider string        `mapstructure:"provider" yaml:"provider" validate:"required,oneof=gemini openai ollama anthropic openai-compatible"`
 	Key      string        `mapstructure:"key"      yaml:"key"`
 	Model    string        `mapstructure:"model"    yaml:"model"    validate:"required"`
 	Timeout  time.Duration `mapstructure:"timeout"  yaml:"timeout"`
 
+	// URL, Header, Structured and Embeddings only apply to the
+	// openai-compatible provider: the server base URL (e.g.
+	// http://vllm:8000/v1), the header carrying Key when it is not a Bearer
+	// token, whether the server honours response_format=json_schema
+	// (default true) and whether it serves /embeddings.
+	URL        string `mapstructure:"url"        yaml:"url"        validate:"required_if=Provider openai-compatible,omitempty,url"`
+	Header     string `mapstructure:"header"     yaml:"header"`
+	Structured *bool  `mapstructure:"structured" yaml:"structured"`
+	Embeddings bool   `mapstructure:"embeddings" yaml:"embeddings"`
+
 	// KeyFile is an optional path to a file containing the LLM API key.
 	// When non-empty, ResolveSecrets reads the file and overrides Key,
 	// matching the PostgresConfig.PasswordFile / ValkeyConfig.PasswordFile
@@ -28,6 +38,122 @@ type LLMConfig struct {
 	// volume / docker secrets / .secrets/) so the literal key never lands
 	// in argv, env vars, or yaml committed to source.
 	KeyFile string `mapstructure:"key-file" yaml:"key_file"`
+
+	// Cache configures the read-through response cache wrapped around the
+	// provider by llm/factory. Disabled by default.
+	Cache LLMCacheConfig `mapstructure:"cache" yaml:"cache"`
+
+	// Fallbacks lists providers tried in order after the primary one above
+	// when it fails or its circuit breaker is open. Empty keeps the single
+	// provider behaviour. YAML/config file only; there is no flag form.
+	Fallbacks []LLMFallbackConfig `mapstructure:"fallbacks" yaml:"fallbacks" validate:"dive"`
+
+	// Retry and Breaker apply to every provider in the chain, primary
+	// included. They only take effect when Fallbacks is non-empty or
+	// Breaker.Threshold is set.
+	Retry   LLMRetryConfig   `mapstructure:"retry"   yaml:"retry"`
+	Breaker LLMBreakerConfig `mapstructure:"breaker" yaml:"breaker"`
+
+	// Ledger prices every upstream call, writes it to the llm_usage table
+	// and enforces the calling component's budget. It needs a Postgres
+	// usage ledger from the command, so commands without one ignore it.
+	Ledger LLMLedgerConfig `mapstructure:"ledger" yaml:"ledger"`
+}
+
+// LLMFallbackConfig is one secondary provider in the LLM fallback chain.
+//
+// StructuredOutput defaults to true for the built-in providers; set it to
+// false for models that cannot honour a JSON schema so structured requests
+// skip them instead of failing validation. URL, Header and Embeddings mirror
+// the LLMConfig fields of the same name.
+type LLMFallbackConfig struct {
+	Provider         string        `mapstructure:"provider"          yaml:"provider"          validate:"required,oneof=gemini openai ollama anthropic openai-compatible"`
+	Key              string        `mapstructure:"key"               yaml:"key"`
+	KeyFile          string        `mapstructure:"key-file"          yaml:"key_file"`
+	Model            string        `mapstructure:"model"             yaml:"model"             validate:"required"`
+	Timeout          time.Duration `mapstructure:"timeout"           yaml:"timeout"`
+	StructuredOutput *bool         `mapstructure:"structured-output" yaml:"structured_output"`
+	URL              string        `mapstructure:"url"               yaml:"url"               validate:"required_if=Provider openai-compatible,omitempty,url"`
+	Header           string        `mapstructure:"header"            yaml:"header"`
+	Embeddings       bool          `mapstructure:"embeddings"        yaml:"embeddings"`
+}
+
+// LLMRetryConfig tunes retry-with-backoff on throttling, timeouts and 5xx.
+// Attempts counts the first call; Backoff is the initial delay, doubled per
+// retry up to Cap. Zero values fall back to the llm package defaults.
+// Flag prefix: llm-retry-*  →  viper key prefix: llm.retry.*
+type LLMRetryConfig struct {
+	Attempts int           `mapstructure:"attempts" yaml:"attempts" validate:"min=0"`
+	Backoff  time.Duration `mapstructure:"backoff"  yaml:"backoff"  validate:"min=0"`
+	Cap      time.Duration `mapstructure:"cap"      yaml:"cap"      validate:"min=0"`
+}
+
+// LLMBreakerConfig tunes the per-provider circuit breaker: Threshold
+// consecutive retryable failures open it for Cooldown. A zero Threshold
+// disables it.
+// Flag prefix: llm-breaker-*  →  viper key prefix: llm.breaker.*
+type LLMBreakerConfig struct {
+	Threshold int           `mapstructure:"threshold" yaml:"threshold" validate:"min=0"`
+	Cooldown  time.Duration `mapstructure:"cooldown"  yaml:"cooldown"  validate:"min=0"`
+}
+
+// LLMLedgerConfig toggles usage accounting and sets the budget of the
+// component that owns this LLMConfig. Daily and Monthly are in USD over UTC
+// calendar windows; zero disables a limit. Prices is YAML/config file only
+// because model names contain dots, which viper would split into keys.
+// Flag prefix: llm-ledger-*  →  viper key prefix: llm.ledger.*
+type LLMLedgerConfig struct {
+	Enabled bool             `mapstructure:"enabled" yaml:"enabled"`
+	Daily   float64          `mapstructure:"daily"   yaml:"daily"   validate:"min=0"`
+	Monthly float64          `mapstructure:"monthly" yaml:"monthly" validate:"min=0"`
+	Prices  []LLMPriceConfig `mapstructure:"prices"  yaml:"prices"  validate:"dive"`
+}
+
+// LLMPriceConfig is the list price of one model in USD per million tokens.
+// Cached applies to cached input tokens; zero bills them at the Input rate.
+type LLMPriceConfig struct {
+	Model  string  `mapstructure:"model"  yaml:"model"  validate:"required"`
+	Input  float64 `mapstructure:"input"  yaml:"input"  validate:"min=0"`
+	Output float64 `mapstructure:"output" yaml:"output" validate:"min=0"`
+	Cached float64 `mapstructure:"cached" yaml:"cached" validate:"min=0"`
+}
+
+// Primary returns the top-level provider settings as the first chain entry.
+func (c LLMConfig) Primary() LLMFallbackConfig {
+	return LLMFallbackConfig{
+		Provider: c.Provider,
+		Key:      c.Key,
+		KeyFile:  c.KeyFile,
+		Model:    c.Model,
+		Timeout:  c.Timeout,
+
+		StructuredOutput: c.Structured,
+		URL:              c.URL,
+		Header:           c.Header,
+		Embeddings:       c.Embeddings,
+	}
+}
+
+// Chained reports whether the provider should be wrapped in a fallback chain.
+func (c LLMConfig) Chained() bool {
+	return len(c.Fallbacks) > 0 || c.Breaker.Threshold > 0
+}
+
+// LLMCacheConfig toggles and tunes the LLM response cache. Flag prefix:
+// llm-cache-*  →  viper key prefix: llm.cache.*
+//
+// Bypass keeps writing fresh responses but skips lookups, which is how an
+// evaluation run forces a re-query without dropping the cache for everyone
+// else.
+type LLMCacheConfig struct {
+	Enabled bool          `mapstructure:"enabled" yaml:"enabled"`
+	Backend string        `mapstructure:"backend" yaml:"backend" validate:"omitempty,oneof=memory valkey"`
+	TTL     time.Duration `mapstructure:"ttl"     yaml:"ttl"     validate:"min=0"`
+	Bypass  bool          `mapstructure:"bypass"  yaml:"bypass"`
+	Prefix  string        `mapstructure:"prefix"  yaml:"prefix"`
+
+	// Valkey is required when Backend is "valkey".
+	Valkey *ValkeyConfig `mapstructure:"valkey" yaml:"valkey" validate:"required_if=Backend valkey,omitempty"`
 }
 
 // ResolveSecrets loads KeyFile if set, replacing Key. Call after viper
@@ -40,17 +166,51 @@ func (c *LLMConfig) ResolveSecrets() error {
 	if v != "" {
 		c.Key = v
 	}
+	for i := range c.Fallbacks {
+		v, err := LoadFromFile(c.Fallbacks[i].KeyFile)
+		if err != nil {
+			return err
+		}
+		if v != "" {
+			c.Fallbacks[i].Key = v
+		}
+	}
+	if c.Cache.Valkey != nil {
+		if err := c.Cache.Valkey.ResolveSecrets(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
 // String renders a human-readable summary with the API key redacted.
 func (c LLMConfig) String() string {
+	return fmt.Sprintf("provider=%s model=%s key=%s timeout=%s cache_enabled=%t cache_backend=%s fallbacks=%d ledger_enabled=%t",
+		c.Provider, c.Model, prismlogger.SecretMask(c.Key), c.Timeout, c.Cache.Enabled, c.Cache.Backend, len(c.Fallbacks), c.Ledger.Enabled)
+}
+
+// LogValue redacts the API key when the config is logged via slog.Any.
+func (c LLMConfig) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("provider", c.Provider),
+		slog.String("model", c.Model),
+		slog.String("key", prismlogger.SecretMask(c.Key)),
+		slog.Duration("timeout", c.Timeout),
+		slog.Bool("cache_enabled", c.Cache.Enabled),
+		slog.String("cache_backend", c.Cache.Backend),
+		slog.Int("fallbacks", len(c.Fallbacks)),
+		slog.Bool("ledger_enabled", c.Ledger.Enabled),
+	)
+}
+
+// String renders a human-readable summary with the API key redacted.
+func (c LLMFallbackConfig) String() string {
 	return fmt.Sprintf("provider=%s model=%s key=%s timeout=%s",
 		c.Provider, c.Model, prismlogger.SecretMask(c.Key), c.Timeout)
 }
 
 // LogValue redacts the API key when the config is logged via slog.Any.
-func (c LLMConfig) LogValue() slog.Value {
+func (c LLMFallbackConfig) LogValue() slog.Value {
 	return slog.GroupValue(
 		slog.String("provider", c.Provider),
 		slog.String("model", c.Model),
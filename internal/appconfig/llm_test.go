@@ -56,3 +56,29 @@ func TestLLMConfig_ResolveSecrets_MissingFile_Errors(t *testing.T) {
 	err := cfg.ResolveSecrets()
 	require.Error(t, err)
 }
+
+func TestLLMConfig_Fallbacks_NoSecretLeak(t *testing.T) {
+	const apiKey = "sk-fallback-0123456789"
+	fallback := LLMFallbackConfig{Provider: "openai", Key: apiKey, Model: "gpt-4o-mini"}
+	cfg := LLMConfig{Provider: "gemini", Model: "gemini-2.5-flash", Fallbacks: []LLMFallbackConfig{fallback}}
+
+	for _, v := range []any{cfg, fallback} {
+		for _, verb := range []string{"%v", "%+v", "%s"} {
+			out := fmt.Sprintf(verb, v)
+			assert.NotContains(t, out, apiKey, "verb %q leaked fallback api key", verb)
+		}
+	}
+
+	var buf strings.Builder
+	slog.New(slog.NewTextHandler(&buf, nil)).Info("llm", slog.Any("fallback", fallback))
+	assert.NotContains(t, buf.String(), apiKey)
+}
+
+func TestLLMConfig_ResolveSecrets_FallbackKeyFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fallback-key")
+	require.NoError(t, os.WriteFile(path, []byte("fallback-from-file\n"), 0o600))
+
+	cfg := LLMConfig{Fallbacks: []LLMFallbackConfig{{Provider: "openai", Model: "gpt-4o-mini", KeyFile: path}}}
+	require.NoError(t, cfg.ResolveSecrets())
+	assert.Equal(t, "fallback-from-file", cfg.Fallbacks[0].Key)
+}
@@ -8,23 +8,23 @@ import (
 )
 
 type ValkeyConfig struct {
-	Host     string `mapstructure:"host"     validate:"required"`
-	Port     int    `mapstructure:"port"     validate:"required,min=1,max=65535"`
-	Username string `mapstructure:"username"`
-	Password string `mapstructure:"password"`
-	DB       int    `mapstructure:"db"       validate:"min=0"`
+	Host     string `mapstructure:"host"     yaml:"host"     validate:"required"`
+	Port     int    `mapstructure:"port"     yaml:"port"     validate:"required,min=1,max=65535"`
+	Username string `mapstructure:"username" yaml:"username"`
+	Password string `mapstructure:"password" yaml:"password"`
+	DB       int    `mapstructure:"db"       yaml:"db"       validate:"min=0"`
 
 	// PasswordFile: see PostgresConfig.PasswordFile.
-	PasswordFile string `mapstructure:"password-file"`
+	PasswordFile string `mapstructure:"password-file" yaml:"password_file"`
 
 	// ClientName is a stable logical name for metrics/traces, e.g. api-shared.
-	ClientName string `mapstructure:"client-name" validate:"omitempty"`
+	ClientName string `mapstructure:"client-name" yaml:"client_name" validate:"omitempty"`
 
 	// TracingEnabled enables Redis OpenTelemetry tracing for this client.
-	TracingEnabled bool `mapstructure:"tracing-enabled"`
+	TracingEnabled bool `mapstructure:"tracing-enabled" yaml:"tracing_enabled"`
 
 	// MetricsEnabled enables Redis Prometheus client metrics for this client.
-	MetricsEnabled bool `mapstructure:"metrics-enabled"`
+	MetricsEnabled bool `mapstructure:"metrics-enabled" yaml:"metrics_enabled"`
 }
 
 // ResolveSecrets loads PasswordFile if set, replacing Password.
@@ -35,7 +35,9 @@ func (f *fakeBatchCompletedPublisher) PublishBatchCompleted(_ context.Context, s
 
 func TestDetector_Detect(t *testing.T) {
 	batchID := uuid.Must(uuid.NewV7())
+	govBatchID := uuid.Must(uuid.NewV7())
 	traceID := "trace-123"
+	govTraceID := "trace-gov"
 	limit := int32(10)
 
 	mRepo := mocks.NewMockBatchTrigger(t)
@@ -44,18 +46,28 @@ func TestDetector_Detect(t *testing.T) {
 		Return([]repo.Batch{
 			{ID: batchID, SourceType: repo.SourceTypeParty, TraceID: &traceID},
 		}, nil)
+	mRepo.EXPECT().
+		FindNewlyCompletedBatches(mock.Anything, limit-1, repo.SourceTypeGovernment).
+		Return([]repo.Batch{
+			{ID: govBatchID, SourceType: repo.SourceTypeGovernment, TraceID: &govTraceID},
+		}, nil)
 
 	mRepo.EXPECT().
 		MarkBatchCompleted(mock.Anything, batchID, traceID).
 		Return(int64(1), nil)
+	mRepo.EXPECT().
+		MarkBatchCompleted(mock.Anything, govBatchID, govTraceID).
+		Return(int64(1), nil)
 
 	d, err := NewDetector(testutils.Logger(), noop.NewTracerProvider().Tracer("test"), mRepo)
 	require.NoError(t, err)
 
 	got, err := d.Detect(context.Background(), limit)
 	require.NoError(t, err)
-	require.Len(t, got, 1)
+	require.Len(t, got, 2)
 	require.Equal(t, batchID, got[0].BatchID)
+	require.Equal(t, govBatchID, got[1].BatchID)
+	require.Equal(t, repo.SourceTypeGovernment, got[1].SourceType)
 }
 
 // TestDetector_Detect_LoserDropsBatch verifies that when MarkBatchCompleted
@@ -73,6 +85,9 @@ func TestDetector_Detect_LoserDropsBatch(t *testing.T) {
 		Return([]repo.Batch{
 			{ID: batchID, SourceType: repo.SourceTypeParty, TraceID: &traceID},
 		}, nil)
+	mRepo.EXPECT().
+		FindNewlyCompletedBatches(mock.Anything, limit-1, repo.SourceTypeGovernment).
+		Return(nil, nil)
 	mRepo.EXPECT().
 		MarkBatchCompleted(mock.Anything, batchID, traceID).
 		Return(int64(0), nil)
@@ -85,6 +100,34 @@ func TestDetector_Detect_LoserDropsBatch(t *testing.T) {
 	require.Empty(t, got)
 }
 
+// TestDetector_Detect_SharesLimit verifies that limit caps the whole scan:
+// once the first seed source type fills it, the rest are not queried.
+func TestDetector_Detect_SharesLimit(t *testing.T) {
+	first := uuid.Must(uuid.NewV7())
+	second := uuid.Must(uuid.NewV7())
+	limit := int32(2)
+
+	// The mock fails the test on any unexpected call, so a government
+	// query would be caught.
+	mRepo := mocks.NewMockBatchTrigger(t)
+	mRepo.EXPECT().
+		FindNewlyCompletedBatches(mock.Anything, limit, repo.SourceTypeParty).
+		Return([]repo.Batch{
+			{ID: first, SourceType: repo.SourceTypeParty},
+			{ID: second, SourceType: repo.SourceTypeParty},
+		}, nil)
+	mRepo.EXPECT().
+		MarkBatchCompleted(mock.Anything, mock.Anything, "").
+		Return(int64(1), nil).Times(2)
+
+	d, err := NewDetector(testutils.Logger(), noop.NewTracerProvider().Tracer("test"), mRepo)
+	require.NoError(t, err)
+
+	got, err := d.Detect(context.Background(), limit)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+}
+
 func TestGetBatchProgressTracksTaskIDsByStatus(t *testing.T) {
 	batchID := uuid.Must(uuid.NewV7())
 	pendingID := uuid.Must(uuid.NewV7())
@@ -123,6 +166,7 @@ func TestGetBatchProgressTracksTaskIDsByStatus(t *testing.T) {
 
 	progress, err := d.GetBatchProgress(context.Background(), batchID)
 	require.NoError(t, err)
+	require.Equal(t, repo.SourceTypeParty, progress.SourceType)
 	require.Equal(t, []uuid.UUID{pendingID}, progress.TaskIDsByStatus[repo.TaskStatusPending])
 	require.Equal(t, []uuid.UUID{completedID}, progress.TaskIDsByStatus[repo.TaskStatusCompleted])
 }
@@ -141,6 +185,9 @@ func TestPublisher_Publish_Success(t *testing.T) {
 	batchB := repo.Batch{ID: uuid.Must(uuid.NewV7()), SourceType: repo.SourceTypeParty, TraceID: &traceB}
 
 	mRepo := mocks.NewMockBatchTrigger(t)
+	mRepo.EXPECT().
+		ListReadyToPublishBatches(mock.Anything, limit, repo.SourceTypeGovernment).
+		Return(nil, nil)
 	mRepo.EXPECT().
 		ListReadyToPublishBatches(mock.Anything, limit, repo.SourceTypeParty).
 		Return([]repo.Batch{batchA, batchB}, nil)
@@ -162,6 +209,9 @@ func TestPublisher_Publish_Success(t *testing.T) {
 func TestPublisher_Publish_Empty(t *testing.T) {
 	limit := int32(10)
 	mRepo := mocks.NewMockBatchTrigger(t)
+	mRepo.EXPECT().
+		ListReadyToPublishBatches(mock.Anything, limit, repo.SourceTypeGovernment).
+		Return(nil, nil)
 	mRepo.EXPECT().
 		ListReadyToPublishBatches(mock.Anything, limit, repo.SourceTypeParty).
 		Return([]repo.Batch{}, nil)
@@ -184,6 +234,9 @@ func TestPublisher_Publish_MQFailure_RecordsAndContinues(t *testing.T) {
 	mqErr := errors.New("nats connection reset")
 
 	mRepo := mocks.NewMockBatchTrigger(t)
+	mRepo.EXPECT().
+		ListReadyToPublishBatches(mock.Anything, limit, repo.SourceTypeGovernment).
+		Return(nil, nil)
 	mRepo.EXPECT().
 		ListReadyToPublishBatches(mock.Anything, limit, repo.SourceTypeParty).
 		Return([]repo.Batch{batchA, batchB}, nil)
@@ -210,6 +263,9 @@ func TestPublisher_Publish_RecordFailureError_Aborts(t *testing.T) {
 	dbErr := errors.New("db unavailable")
 
 	mRepo := mocks.NewMockBatchTrigger(t)
+	mRepo.EXPECT().
+		ListReadyToPublishBatches(mock.Anything, limit, repo.SourceTypeGovernment).
+		Return(nil, nil)
 	mRepo.EXPECT().
 		ListReadyToPublishBatches(mock.Anything, limit, repo.SourceTypeParty).
 		Return([]repo.Batch{batchA}, nil)
@@ -234,6 +290,9 @@ func TestPublisher_Publish_MarkPublishedError_Aborts(t *testing.T) {
 	dbErr := errors.New("db write failed")
 
 	mRepo := mocks.NewMockBatchTrigger(t)
+	mRepo.EXPECT().
+		ListReadyToPublishBatches(mock.Anything, limit, repo.SourceTypeGovernment).
+		Return(nil, nil)
 	mRepo.EXPECT().
 		ListReadyToPublishBatches(mock.Anything, limit, repo.SourceTypeParty).
 		Return([]repo.Batch{batchA, batchB}, nil)
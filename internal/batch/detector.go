@@ -37,15 +37,27 @@ func NewDetector(logger *slog.Logger, tracer trace.Tracer, r repo.BatchTrigger)
 	}, nil
 }
 
-// Detect scans for batches that are functionally complete using an efficient bulk query.
+// Detect scans for batches that are functionally complete using an efficient
+// bulk query, returning at most limit batches across all seed source types.
 func (d *Detector) Detect(ctx context.Context, limit int32) ([]CompletedBatch, error) {
 	ctx, span := d.tracer.Start(ctx, "batch.detector.detect")
 	defer span.End()
 
-	// Use the optimized bulk query for efficient detection.
-	batches, err := d.repo.FindNewlyCompletedBatches(ctx, limit, repo.SourceTypeParty)
-	if err != nil {
-		return nil, fmt.Errorf("find newly completed batches: %w", err)
+	// Use the optimized bulk query for efficient detection, once per seed
+	// source type since the query is keyed on a single type. limit caps the
+	// whole scan, so each type only gets what the earlier ones left over.
+	var batches []repo.Batch
+	remaining := limit
+	for _, sourceType := range repo.SeedSourceTypes {
+		if remaining <= 0 {
+			break
+		}
+		found, err := d.repo.FindNewlyCompletedBatches(ctx, remaining, sourceType)
+		if err != nil {
+			return nil, fmt.Errorf("find newly completed %s batches: %w", sourceType, err)
+		}
+		batches = append(batches, found...)
+		remaining -= int32(len(found))
 	}
 
 	completed := []CompletedBatch{}
@@ -91,7 +103,6 @@ func (d *Detector) Detect(ctx context.Context, limit int32) ([]CompletedBatch, e
 func (d *Detector) GetBatchProgress(ctx context.Context, batchID uuid.UUID) (BatchProgress, error) {
 	var progress = BatchProgress{
 		BatchID:         batchID,
-		SourceType:      repo.SourceTypeParty,
 		TaskIDsByStatus: map[repo.TaskStatus][]uuid.UUID{},
 	}
 
@@ -101,9 +112,12 @@ func (d *Detector) GetBatchProgress(ctx context.Context, batchID uuid.UUID) (Bat
 	}
 
 	for _, task := range tasks {
-		if task.SourceType != repo.SourceTypeParty {
+		if !repo.IsSeedSourceType(task.SourceType) {
 			continue
 		}
+		if progress.SourceType == "" {
+			progress.SourceType = task.SourceType
+		}
 		progress.TotalTasks++
 		progress.TaskIDsByStatus[task.Status] = append(progress.TaskIDsByStatus[task.Status], task.ID)
 		if progress.TraceID == "" {
@@ -49,9 +49,13 @@ func (p *Publisher) Publish(ctx context.Context, limit int32) (int, error) {
 	ctx, span := p.tracer.Start(ctx, "batch.publisher.publish")
 	defer span.End()
 
-	batches, err := p.repo.ListReadyToPublishBatches(ctx, limit, repo.SourceTypeParty)
-	if err != nil {
-		return 0, fmt.Errorf("list ready to publish batches: %w", err)
+	var batches []repo.Batch
+	for _, sourceType := range repo.SeedSourceTypes {
+		ready, err := p.repo.ListReadyToPublishBatches(ctx, limit, sourceType)
+		if err != nil {
+			return 0, fmt.Errorf("list ready to publish %s batches: %w", sourceType, err)
+		}
+		batches = append(batches, ready...)
 	}
 
 	publishedCount := 0
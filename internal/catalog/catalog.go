@@ -0,0 +1,175 @@
+// Package catalog reads the outlet catalog (scout configs and parser rules)
+// from repo.Catalog and keeps worker registries in step with it.
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	parserconfig "github.com/ChiaYuChang/prism/internal/collector/parser/config"
+	scoutconfig "github.com/ChiaYuChang/prism/internal/discovery/scout/config"
+	"github.com/ChiaYuChang/prism/internal/repo"
+	"github.com/ChiaYuChang/prism/internal/repo/pg"
+)
+
+// Registry sources a worker can build its scout or parser registry from,
+// selected by its --registry-source flag.
+const (
+	SourceFile     = "file"
+	SourcePostgres = "postgres"
+)
+
+// ScoutRecords converts scout_configs rows for scoutconfig.FromRecords.
+func ScoutRecords(rows []repo.ScoutConfig) []scoutconfig.Record {
+	out := make([]scoutconfig.Record, len(rows))
+	for i, row := range rows {
+		out[i] = scoutconfig.Record{Name: row.Name, Kind: row.Kind, Entry: row.Config}
+	}
+	return out
+}
+
+// ValidateScouts checks rows the way the discovery worker loads them: each
+// entry decodes, and the set passes the scouts.yaml validation, host
+// conflicts across scouts included.
+func ValidateScouts(rows []repo.ScoutConfig) error {
+	cfg, err := scoutconfig.FromRecords(ScoutRecords(rows))
+	if err != nil {
+		return err
+	}
+	_, err = scoutconfig.New(cfg)
+	return err
+}
+
+// LoadScouts reads every scout config and returns them as a validated
+// scout config repository.
+func LoadScouts(ctx context.Context, c repo.Catalog) (*scoutconfig.Repository, error) {
+	rows, err := c.ListScoutConfigs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list scout configs: %w", err)
+	}
+	cfg, err := scoutconfig.FromRecords(ScoutRecords(rows))
+	if err != nil {
+		return nil, err
+	}
+	return scoutconfig.New(cfg)
+}
+
+// LoadParserRules reads every parser rule, keyed by host, in the shape of
+// the parsers.yaml `parsers:` map.
+func LoadParserRules(ctx context.Context, c repo.Catalog) (map[string]parserconfig.ParserConfig, error) {
+	rows, err := c.ListParserRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list parser rules: %w", err)
+	}
+	out := make(map[string]parserconfig.ParserConfig, len(rows))
+	for _, row := range rows {
+		pc, err := parserconfig.DecodeParserConfig(row.Config)
+		if err != nil {
+			return nil, fmt.Errorf("parser rule %s: %w", row.Host, err)
+		}
+		out[row.Host] = pc
+	}
+	return out, nil
+}
+
+// SeedScouts copies cfg into an empty scout_configs table and returns the
+// number of scouts written. A table with any row is left alone, so the
+// baked scouts.yaml only bootstraps a fresh database.
+func SeedScouts(ctx context.Context, c repo.Catalog, cfg scoutconfig.Config) (int, error) {
+	rows, err := c.ListScoutConfigs(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("list scout configs: %w", err)
+	}
+	if len(rows) > 0 {
+		return 0, nil
+	}
+	records, err := cfg.Records()
+	if err != nil {
+		return 0, err
+	}
+	for _, rec := range records {
+		if _, err := c.UpsertScoutConfig(ctx, repo.UpsertScoutConfigParams{
+			Name:   rec.Name,
+			Kind:   rec.Kind,
+			Config: rec.Entry,
+		}); err != nil {
+			return 0, fmt.Errorf("seed scout %s: %w", rec.Name, err)
+		}
+	}
+	return len(records), nil
+}
+
+// SeedParserRules copies parsers into an empty parser_rules table and
+// returns the number of rules written; see SeedScouts.
+func SeedParserRules(ctx context.Context, c repo.Catalog, parsers map[string]parserconfig.ParserConfig) (int, error) {
+	rows, err := c.ListParserRules(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("list parser rules: %w", err)
+	}
+	if len(rows) > 0 {
+		return 0, nil
+	}
+	hosts := make([]string, 0, len(parsers))
+	for host := range parsers {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	for _, host := range hosts {
+		data, err := json.Marshal(parsers[host])
+		if err != nil {
+			return 0, fmt.Errorf("encode parser rule %s: %w", host, err)
+		}
+		if _, err := c.UpsertParserRule(ctx, repo.UpsertParserRuleParams{Host: host, Config: data}); err != nil {
+			return 0, fmt.Errorf("seed parser rule %s: %w", host, err)
+		}
+	}
+	return len(hosts), nil
+}
+
+// Watch calls reload whenever table (repo.CatalogScoutConfigs or
+// repo.CatalogParserRules) changes, until ctx ends or feed shuts down. The
+// feed also wakes subscribers after a reconnect, which covers changes made
+// while it was down. A failed reload is logged and the caller keeps its
+// current registry.
+func Watch(ctx context.Context, logger *slog.Logger, feed repo.ChangeFeed, table string, reload func(context.Context) error) {
+	wake, unsubscribe := feed.Subscribe(repo.ChangeChannelCatalog, table)
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case _, ok := <-wake:
+			if !ok {
+				return
+			}
+			if err := reload(ctx); err != nil {
+				logger.ErrorContext(ctx, "catalog reload failed, keeping current registry",
+					slog.String("table", table), slog.Any("error", err))
+				continue
+			}
+			logger.InfoContext(ctx, "catalog reloaded", slog.String("table", table))
+		}
+	}
+}
+
+// Listen starts a Postgres change feed listener on repo.ChangeChannelCatalog
+// and runs Watch on it for table in the background, until ctx ends. It only
+// fails if the listener cannot be configured; connection errors after that
+// are retried by the listener and logged.
+func Listen(ctx context.Context, logger *slog.Logger, connString, table string, reload func(context.Context) error) error {
+	listener, err := pg.NewListener(logger, connString, repo.ChangeChannelCatalog)
+	if err != nil {
+		return fmt.Errorf("catalog listener: %w", err)
+	}
+	go func() {
+		if err := listener.Run(ctx); err != nil {
+			logger.Error("catalog listener stopped", "error", err)
+		}
+	}()
+	go Watch(ctx, logger, listener, table, reload)
+	return nil
+}
@@ -0,0 +1,152 @@
+package catalog_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/catalog"
+	parserconfig "github.com/ChiaYuChang/prism/internal/collector/parser/config"
+	"github.com/ChiaYuChang/prism/internal/collector/parser/html"
+	scoutconfig "github.com/ChiaYuChang/prism/internal/discovery/scout/config"
+	"github.com/ChiaYuChang/prism/internal/repo"
+	"github.com/ChiaYuChang/prism/internal/repo/mocks"
+	"github.com/ChiaYuChang/prism/internal/repo/pg"
+	"github.com/ChiaYuChang/prism/pkg/testutils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func readScouts(t *testing.T) scoutconfig.Config {
+	t.Helper()
+	cfg, err := scoutconfig.ReadFile(filepath.Join("..", "..", "configs", "worker", "discovery", "scouts.yaml"))
+	require.NoError(t, err)
+	return cfg
+}
+
+func TestSeedScouts_ThenLoad(t *testing.T) {
+	ctx := context.Background()
+	c := mocks.NewMockCatalog(t)
+
+	var stored []repo.ScoutConfig
+	c.EXPECT().ListScoutConfigs(mock.Anything).Return(nil, nil).Once()
+	c.EXPECT().UpsertScoutConfig(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, arg repo.UpsertScoutConfigParams) (repo.ScoutConfig, error) {
+			row := repo.ScoutConfig{Name: arg.Name, Kind: arg.Kind, Config: arg.Config}
+			stored = append(stored, row)
+			return row, nil
+		})
+
+	n, err := catalog.SeedScouts(ctx, c, readScouts(t))
+	require.NoError(t, err)
+	require.Equal(t, len(stored), n)
+	require.NotZero(t, n)
+
+	c.EXPECT().ListScoutConfigs(mock.Anything).Return(stored, nil).Once()
+	scouts, err := catalog.LoadScouts(ctx, c)
+	require.NoError(t, err)
+	dpp, ok := scouts.HTML("dpp")
+	require.True(t, ok)
+	require.Equal(t, []string{"www.dpp.org.tw"}, dpp.Hosts)
+
+	require.NoError(t, catalog.ValidateScouts(stored))
+}
+
+func TestSeedScouts_NonEmptyTableUntouched(t *testing.T) {
+	c := mocks.NewMockCatalog(t)
+	c.EXPECT().ListScoutConfigs(mock.Anything).
+		Return([]repo.ScoutConfig{{Name: "dpp", Kind: scoutconfig.KindHTML}}, nil)
+
+	n, err := catalog.SeedScouts(context.Background(), c, readScouts(t))
+	require.NoError(t, err)
+	require.Zero(t, n)
+}
+
+func TestValidateScouts_HostConflict(t *testing.T) {
+	err := catalog.ValidateScouts([]repo.ScoutConfig{
+		{Name: "a", Kind: scoutconfig.KindRSS, Config: []byte(`{"name":"a","hosts":["x.example"]}`)},
+		{Name: "b", Kind: scoutconfig.KindRSS, Config: []byte(`{"name":"b","hosts":["x.example"]}`)},
+	})
+	require.ErrorIs(t, err, scoutconfig.ErrDuplicateScoutHost)
+}
+
+func TestSeedParserRules_ThenLoad(t *testing.T) {
+	ctx := context.Background()
+	c := mocks.NewMockCatalog(t)
+
+	var stored []repo.ParserRule
+	c.EXPECT().ListParserRules(mock.Anything).Return(nil, nil).Once()
+	c.EXPECT().UpsertParserRule(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, arg repo.UpsertParserRuleParams) (repo.ParserRule, error) {
+			row := repo.ParserRule{Host: arg.Host, Config: arg.Config}
+			stored = append(stored, row)
+			return row, nil
+		})
+
+	n, err := catalog.SeedParserRules(ctx, c, map[string]parserconfig.ParserConfig{
+		"b.example": {JSONLD: true, HTML: &html.RuleConfig{Title: []string{"h1"}}},
+		"a.example": {HTML: &html.RuleConfig{Content: []string{"article"}}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	require.Equal(t, "a.example", stored[0].Host)
+
+	c.EXPECT().ListParserRules(mock.Anything).Return(stored, nil).Once()
+	rules, err := catalog.LoadParserRules(ctx, c)
+	require.NoError(t, err)
+	require.True(t, rules["b.example"].JSONLD)
+	require.Equal(t, []string{"article"}, rules["a.example"].HTML.Content)
+}
+
+func TestLoadParserRules_InvalidRow(t *testing.T) {
+	c := mocks.NewMockCatalog(t)
+	c.EXPECT().ListParserRules(mock.Anything).
+		Return([]repo.ParserRule{{Host: "bad.example", Config: []byte(`{"jsonld":true}`)}}, nil)
+
+	_, err := catalog.LoadParserRules(context.Background(), c)
+	require.ErrorContains(t, err, "bad.example")
+}
+
+func TestWatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	wake := make(chan struct{}, 1)
+	feed := mocks.NewMockChangeFeed(t)
+	feed.EXPECT().Subscribe(repo.ChangeChannelCatalog, repo.CatalogParserRules).
+		Return((<-chan struct{})(wake), func() {})
+
+	reloads := make(chan error, 2)
+	results := []error{errors.New("boom"), nil}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		catalog.Watch(ctx, testutils.Logger(), feed, repo.CatalogParserRules, func(context.Context) error {
+			err := results[0]
+			results = results[1:]
+			reloads <- err
+			return err
+		})
+	}()
+
+	// A failed reload does not stop the watcher.
+	wake <- struct{}{}
+	require.Error(t, <-reloads)
+	wake <- struct{}{}
+	require.NoError(t, <-reloads)
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Watch did not return after ctx cancel")
+	}
+}
+
+func TestListen_InvalidConfig(t *testing.T) {
+	err := catalog.Listen(context.Background(), testutils.Logger(), "", repo.CatalogScoutConfigs,
+		func(context.Context) error { return nil })
+	require.ErrorIs(t, err, pg.ErrParamMissing)
+}
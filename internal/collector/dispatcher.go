@@ -62,13 +62,13 @@ type DispatchResult struct {
 }
 
 // Dispatch runs F-M-T[]-P for a single URL using the Pipeline registered
-// for sourceID (fallback used when none is registered). Stage failures
-// return *StageError with the intermediate value attached.
+// for sourceID or the URL's host (fallback used when neither is registered).
+// Stage failures return *StageError with the intermediate value attached.
 func (d *Dispatcher) Dispatch(ctx context.Context, sourceID, url string) (*DispatchResult, error) {
 	ctx, span := d.tracer.Start(ctx, "collector.dispatcher.dispatch")
 	defer span.End()
 
-	p := d.registry.For(sourceID)
+	p := d.registry.ForURL(sourceID, url)
 
 	raw, err := p.Fetcher.Fetch(ctx, url)
 	if err != nil {
@@ -0,0 +1,39 @@
+package fetcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ChiaYuChang/prism/internal/collector"
+	"github.com/ChiaYuChang/prism/internal/social"
+)
+
+// SocialFetcher fetches one post through a platform exporter and returns it
+// as JSON, so the archive keeps the exporter's view of the post (transcript
+// included) and the social parser can read it back.
+type SocialFetcher struct {
+	exporter social.Exporter
+}
+
+var _ collector.Fetcher = (*SocialFetcher)(nil)
+
+func NewSocialFetcher(exporter social.Exporter) *SocialFetcher {
+	return &SocialFetcher{exporter: exporter}
+}
+
+func (f *SocialFetcher) String() string {
+	return "SocialFetcher(" + f.exporter.Platform() + ")"
+}
+
+func (f *SocialFetcher) Fetch(ctx context.Context, url string) (string, error) {
+	post, err := f.exporter.Post(ctx, url)
+	if err != nil {
+		return "", fmt.Errorf("fetch %s: %w", url, err)
+	}
+	raw, err := json.Marshal(post)
+	if err != nil {
+		return "", fmt.Errorf("encode %s post %s: %w", f.exporter.Platform(), url, err)
+	}
+	return string(raw), nil
+}
@@ -0,0 +1,47 @@
+package fetcher_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/collector/fetcher"
+	"github.com/ChiaYuChang/prism/internal/social"
+	"github.com/stretchr/testify/require"
+)
+
+type stubExporter struct {
+	post social.Post
+	err  error
+}
+
+func (stubExporter) Platform() string { return social.PlatformYouTube }
+func (stubExporter) Hosts() []string  { return []string{"www.youtube.com"} }
+func (stubExporter) Channel(context.Context, string) ([]social.Post, error) {
+	return nil, nil
+}
+func (e stubExporter) Post(context.Context, string) (social.Post, error) { return e.post, e.err }
+
+func TestSocialFetcher(t *testing.T) {
+	post := social.Post{
+		Platform:    social.PlatformYouTube,
+		ID:          "synVideo001",
+		URL:         "https://www.youtube.com/watch?v=synVideo001",
+		Title:       "Synthetic Press Conference",
+		Transcript:  "Line one.",
+		PublishedAt: time.Date(2026, 3, 29, 2, 0, 0, 0, time.UTC),
+	}
+	f := fetcher.NewSocialFetcher(stubExporter{post: post})
+	require.Equal(t, "SocialFetcher(youtube)", f.String())
+
+	raw, err := f.Fetch(context.Background(), post.URL)
+	require.NoError(t, err)
+
+	var got social.Post
+	require.NoError(t, json.Unmarshal([]byte(raw), &got))
+	require.Equal(t, post, got)
+
+	_, err = fetcher.NewSocialFetcher(stubExporter{err: social.ErrPostNotFound}).Fetch(context.Background(), post.URL)
+	require.ErrorIs(t, err, social.ErrPostNotFound)
+}
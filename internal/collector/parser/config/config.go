@@ -1,12 +1,16 @@
 package config
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
 
 	"github.com/ChiaYuChang/prism/internal/appconfig"
+	"github.com/ChiaYuChang/prism/internal/collector"
 	"github.com/ChiaYuChang/prism/internal/collector/parser/html"
+	"github.com/ChiaYuChang/prism/internal/prompt"
 	"github.com/go-playground/validator/v10"
 	"gopkg.in/yaml.v3"
 )
@@ -35,6 +39,12 @@ type FallbackConfig struct {
 	// out of the binary so operators can iterate on extraction quality
 	// without rebuilding. Required when Enable=true.
 	PromptFile string `yaml:"prompt_file" json:"prompt_file,omitempty"`
+
+	// PromptCandidate names a sibling version of PromptFile
+	// ("article_parser@v2.md" for "v2") that serves PromptPercent percent
+	// of fallback parses, keyed by URL. Empty disables the A/B split.
+	PromptCandidate string `yaml:"prompt_candidate" json:"prompt_candidate,omitempty"`
+	PromptPercent   int    `yaml:"prompt_percent"   json:"prompt_percent,omitempty"`
 }
 
 type ParserConfig struct {
@@ -44,6 +54,29 @@ type ParserConfig struct {
 	HTML        *html.RuleConfig `yaml:"html,omitempty" json:"html,omitempty"`
 }
 
+// Validate reports whether BuildRegistry can build a parser from p.
+func (p ParserConfig) Validate() error {
+	if p.HTML == nil {
+		return fmt.Errorf("%w: missing html rules", collector.ErrUnsupportedFallbackType)
+	}
+	return nil
+}
+
+// DecodeParserConfig strictly decodes the JSON form of one `parsers:` entry
+// (a parser_rules row) and validates it.
+func DecodeParserConfig(data []byte) (ParserConfig, error) {
+	var p ParserConfig
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&p); err != nil {
+		return ParserConfig{}, fmt.Errorf("decode parser config: %w", err)
+	}
+	if err := p.Validate(); err != nil {
+		return ParserConfig{}, err
+	}
+	return p, nil
+}
+
 func LoadConfig(path string) (cfg Config, err error) {
 	f, err := os.Open(path)
 	if err != nil {
@@ -74,6 +107,20 @@ func LoadConfig(path string) (cfg Config, err error) {
 	return cfg, nil
 }
 
+// LoadFallbackPrompts loads FallbackConfig.PromptFile as the control prompt
+// and, when PromptCandidate is set, its candidate version through the
+// prompt registry.
+func LoadFallbackPrompts(cfg FallbackConfig) (prompt.Split, error) {
+	if cfg.PromptFile == "" {
+		return prompt.Split{}, fmt.Errorf("fallback.prompt_file is empty")
+	}
+	split, err := prompt.LoadFileSplit(cfg.PromptFile, cfg.PromptCandidate, cfg.PromptPercent)
+	if err != nil {
+		return prompt.Split{}, fmt.Errorf("load fallback prompts: %w", err)
+	}
+	return split, nil
+}
+
 // LoadFallbackPrompt reads the system-instruction file pointed to by
 // FallbackConfig.PromptFile. Trim trailing whitespace so an editor's
 // auto-newline doesn't confuse the LLM. Errors if PromptFile is empty.
@@ -284,6 +284,27 @@ func TestLoadFallbackPrompt_MissingFile_Errors(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestLoadFallbackPrompts_Split(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "article_parser.md")
+	require.NoError(t, os.WriteFile(path, []byte("control\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "article_parser@v2.md"), []byte("candidate\n"), 0o600))
+
+	got, err := config.LoadFallbackPrompts(config.FallbackConfig{
+		PromptFile:      path,
+		PromptCandidate: "v2",
+		PromptPercent:   25,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "control", got.Control.Content)
+	require.NotNil(t, got.Candidate)
+	assert.Equal(t, "v2", got.Candidate.Version)
+	assert.Equal(t, 25, got.Percent)
+
+	_, err = config.LoadFallbackPrompts(config.FallbackConfig{PromptFile: path, PromptCandidate: "v3", PromptPercent: 25})
+	require.Error(t, err)
+}
+
 func TestLoadConfig_FallbackDisabled_SkipsLLMValidation(t *testing.T) {
 	// Empty LLM block must NOT error when fallback is disabled — the LLM
 	// fields are only required when fallback.enable=true.
@@ -297,3 +318,17 @@ parsers: {}
 	require.NoError(t, err)
 	assert.False(t, cfg.Fallback.Enable)
 }
+
+func TestDecodeParserConfig(t *testing.T) {
+	pc, err := config.DecodeParserConfig([]byte(`{"jsonld":true,"date_layouts":["2006-01-02"],"html":{"title":["h1"]}}`))
+	require.NoError(t, err)
+	assert.True(t, pc.JSONLD)
+	require.NotNil(t, pc.HTML)
+	assert.Equal(t, []string{"h1"}, pc.HTML.Title)
+
+	_, err = config.DecodeParserConfig([]byte(`{"jsonld":true}`))
+	assert.ErrorIs(t, err, collector.ErrUnsupportedFallbackType)
+
+	_, err = config.DecodeParserConfig([]byte(`{"html":{"titel":["h1"]}}`))
+	assert.ErrorContains(t, err, "unknown field")
+}
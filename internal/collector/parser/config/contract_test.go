@@ -44,7 +44,9 @@ var hostFixtures = map[string]struct {
 	"www.dpp.org.tw": {"dpp_11545.html", "https://www.dpp.org.tw/media/contents/11545"},
 	"www.tpp.org.tw": {"tpp_4530.html", "https://www.tpp.org.tw/newsdetail/4530"},
 	"www.kmt.org.tw": {"kmt_blog-post_20.html", "https://www.kmt.org.tw/2026/04/blog-post_20.html"},
-	// tw.news.yahoo.com: no parser fixture yet; discovery uses a custom scout.
+	"ppg.ly.gov.tw":  {"ly_bill_202603290001.html", "https://ppg.ly.gov.tw/ppg/bills/202603290001/details"},
+	"www.ey.gov.tw":  {"ey_synthetic-0001.html", "https://www.ey.gov.tw/Page/9277F759E41CCD91/synthetic-0001"},
+	// tw.news.yahoo.com: no parser fixture yet; discovery uses a json scout.
 }
 
 func TestParsersConfig_ContractEachHost(t *testing.T) {
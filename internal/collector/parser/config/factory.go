@@ -36,8 +36,8 @@ func BuildRegistry(cfg Config, logger *slog.Logger, tracer trace.Tracer, llmFact
 			continue
 		}
 
-		if pCfg.HTML == nil {
-			return nil, fmt.Errorf("%w: missing html rules for host %s", collector.ErrUnsupportedFallbackType, host)
+		if err := pCfg.Validate(); err != nil {
+			return nil, fmt.Errorf("%w for host %s", err, host)
 		}
 
 		var hParser collector.Parser
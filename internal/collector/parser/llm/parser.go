@@ -14,6 +14,7 @@ import (
 
 	"github.com/ChiaYuChang/prism/internal/collector"
 	"github.com/ChiaYuChang/prism/internal/llm"
+	"github.com/ChiaYuChang/prism/internal/prompt"
 )
 
 var (
@@ -34,7 +35,7 @@ type Parser struct {
 	generator llm.Generator
 	logger    *slog.Logger
 	model     string
-	prompt    string
+	prompts   prompt.Split
 }
 
 var _ collector.Parser = (*Parser)(nil)
@@ -43,17 +44,27 @@ var _ collector.Parser = (*Parser)(nil)
 // model identifier (e.g. "gemini-2.0-flash"); prompt is the system
 // instruction text — load it from disk (see
 // assets/prompts/collector/article_parser.md) and pass through unchanged.
-func NewParser(generator llm.Generator, logger *slog.Logger, model, prompt string) (*Parser, error) {
+func NewParser(generator llm.Generator, logger *slog.Logger, model, systemPrompt string) (*Parser, error) {
+	return NewSplitParser(generator, logger, model, prompt.Single(prompt.Prompt{
+		Version: prompt.DefaultVersion,
+		Content: systemPrompt,
+	}))
+}
+
+// NewSplitParser is NewParser with a prompt Split. Each URL is routed to
+// the control or candidate prompt version, and the version used is stamped
+// into Article.Metadata["prompt_version"] so parses can be compared.
+func NewSplitParser(generator llm.Generator, logger *slog.Logger, model string, prompts prompt.Split) (*Parser, error) {
 	if generator == nil {
 		return nil, fmt.Errorf("%w: generator", ErrParamMissing)
 	}
 	if logger == nil {
 		return nil, fmt.Errorf("%w: logger", ErrParamMissing)
 	}
-	if prompt == "" {
+	if prompts.Control.Content == "" {
 		return nil, fmt.Errorf("%w: prompt", ErrParamMissing)
 	}
-	return &Parser{generator: generator, logger: logger, model: model, prompt: prompt}, nil
+	return &Parser{generator: generator, logger: logger, model: model, prompts: prompts}, nil
 }
 
 func (*Parser) String() string { return "LLMParser" }
@@ -76,9 +87,11 @@ func (p *Parser) Parse(ctx context.Context, url string, data string) (*collector
 		return nil, fmt.Errorf("%w: XML payload detected", collector.ErrUnsupportedFallbackType)
 	}
 
+	// Route on the URL so a re-parse of the same page stays on one arm.
+	pr := p.prompts.Choose(url)
 	req := &llm.GenerateRequest{
 		Model:             p.model,
-		SystemInstruction: p.prompt,
+		SystemInstruction: pr.Content,
 		Prompt:            "URL: " + url + "\n\nHTML:\n" + data,
 		Format:            llm.ResponseFormatJsonSchema,
 		JSONSchema:        ParserConfigJSONSchema,
@@ -100,9 +113,18 @@ func (p *Parser) Parse(ctx context.Context, url string, data string) (*collector
 		slog.Int("content_nodes", len(out.Content)),
 		slog.Int("input_tokens", resp.Usage.Input),
 		slog.Int("output_tokens", resp.Usage.Output),
+		slog.String("prompt_version", pr.Version),
 	)
 
-	return out.ToArticleContent(url), nil
+	article := out.ToArticleContent(url)
+	if article.Metadata == nil {
+		article.Metadata = make(map[string]any)
+	}
+	if pr.Name != "" {
+		article.Metadata["prompt_name"] = pr.Name
+	}
+	article.Metadata["prompt_version"] = pr.Version
+	return article, nil
 }
 
 func isJSONPayload(data string) bool {
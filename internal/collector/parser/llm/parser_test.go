@@ -10,6 +10,7 @@ import (
 	"github.com/ChiaYuChang/prism/internal/collector"
 	parserllm "github.com/ChiaYuChang/prism/internal/collector/parser/llm"
 	"github.com/ChiaYuChang/prism/internal/llm"
+	"github.com/ChiaYuChang/prism/internal/prompt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -17,9 +18,11 @@ import (
 type fakeGenerator struct {
 	resp *llm.GenerateResponse
 	err  error
+	req  *llm.GenerateRequest
 }
 
-func (f *fakeGenerator) Generate(_ context.Context, _ *llm.GenerateRequest) (*llm.GenerateResponse, error) {
+func (f *fakeGenerator) Generate(_ context.Context, req *llm.GenerateRequest) (*llm.GenerateResponse, error) {
+	f.req = req
 	if f.err != nil {
 		return nil, f.err
 	}
@@ -78,6 +81,29 @@ func TestParser_Parse_Success(t *testing.T) {
 	assert.Equal(t, "First paragraph body.", article.Content)
 	assert.False(t, article.PublishedAt.IsZero(), "published_at should parse via supplied layout")
 	assert.Equal(t, 2026, article.PublishedAt.Year())
+	assert.Equal(t, prompt.DefaultVersion, article.Metadata["prompt_version"])
+}
+
+func TestParser_Parse_PromptSplit(t *testing.T) {
+	gen := &fakeGenerator{
+		resp: &llm.GenerateResponse{
+			Text:       `{"title": [{"selector": "h1", "value": "T"}], "author": [], "published_at": [], "date_layouts": [], "content": [{"selector": "p", "value": "C"}]}`,
+			JsonSchema: parserllm.ParserConfigJSONSchema,
+		},
+	}
+	control := prompt.Prompt{Name: "collector/article_parser", Version: "v1", Content: "control prompt"}
+	candidate := prompt.Prompt{Name: "collector/article_parser", Version: "v2", Content: "candidate prompt"}
+	split, err := prompt.NewSplit(control, &candidate, 100)
+	require.NoError(t, err)
+
+	p, err := parserllm.NewSplitParser(gen, discardLogger(), "test-model", split)
+	require.NoError(t, err)
+
+	article, err := p.Parse(context.Background(), "https://example.com/post/1", "<html><body>...</body></html>")
+	require.NoError(t, err)
+	assert.Equal(t, "candidate prompt", gen.req.SystemInstruction)
+	assert.Equal(t, "v2", article.Metadata["prompt_version"])
+	assert.Equal(t, "collector/article_parser", article.Metadata["prompt_name"])
 }
 
 func TestParser_Parse_GeneratorError(t *testing.T) {
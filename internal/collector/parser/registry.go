@@ -7,6 +7,7 @@ import (
 	"log/slog"
 	"net/url"
 	"strings"
+	"sync/atomic"
 
 	"github.com/ChiaYuChang/prism/internal/collector"
 	"go.opentelemetry.io/otel/trace"
@@ -72,3 +73,32 @@ func (r *Registry) Parse(ctx context.Context, rawURL string, data string) (*coll
 
 	return nil, fmt.Errorf("%w: %s", ErrNoMatchingParser, host)
 }
+
+// Swappable is a collector.Parser backed by a Registry that can be replaced
+// while in use, so a worker can pick up parser rule changes without a
+// restart. A Parse call runs to completion on the registry it started with.
+type Swappable struct {
+	current atomic.Pointer[Registry]
+}
+
+var _ collector.Parser = (*Swappable)(nil)
+
+func NewSwappable(r *Registry) (*Swappable, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w: registry", ErrParamMissing)
+	}
+	s := &Swappable{}
+	s.current.Store(r)
+	return s, nil
+}
+
+// Swap installs r for subsequent Parse calls. A nil r is ignored.
+func (s *Swappable) Swap(r *Registry) {
+	if r != nil {
+		s.current.Store(r)
+	}
+}
+
+func (s *Swappable) Parse(ctx context.Context, rawURL string, data string) (*collector.Article, error) {
+	return s.current.Load().Parse(ctx, rawURL, data)
+}
@@ -100,3 +100,28 @@ func TestNewRegistry_NilTracer(t *testing.T) {
 	_, err := parser.NewRegistry(testutils.Logger(), nil, nil, nil)
 	require.ErrorIs(t, err, parser.ErrParamMissing)
 }
+
+func TestSwappable_Parse(t *testing.T) {
+	tracer := noop.NewTracerProvider().Tracer("test")
+	p := mocks.NewMockParser(t)
+	first, err := parser.NewRegistry(testutils.Logger(), tracer, map[string]collector.Parser{"a.example": p}, nil)
+	require.NoError(t, err)
+	second, err := parser.NewRegistry(testutils.Logger(), tracer, map[string]collector.Parser{"b.example": p}, nil)
+	require.NoError(t, err)
+
+	_, err = parser.NewSwappable(nil)
+	require.ErrorIs(t, err, parser.ErrParamMissing)
+
+	s, err := parser.NewSwappable(first)
+	require.NoError(t, err)
+	p.On("Parse", mock.Anything, mock.Anything, "data").Return(&collector.Article{}, nil)
+
+	_, err = s.Parse(context.Background(), "https://a.example/x", "data")
+	require.NoError(t, err)
+
+	s.Swap(second)
+	_, err = s.Parse(context.Background(), "https://a.example/x", "data")
+	require.ErrorIs(t, err, parser.ErrNoMatchingParser)
+	_, err = s.Parse(context.Background(), "https://b.example/x", "data")
+	require.NoError(t, err)
+}
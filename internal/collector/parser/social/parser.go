@@ -0,0 +1,48 @@
+// Package social parses the post JSON produced by fetcher.SocialFetcher
+// into an Article.
+package social
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ChiaYuChang/prism/internal/collector"
+	"github.com/ChiaYuChang/prism/internal/social"
+)
+
+type Parser struct{}
+
+var _ collector.Parser = (*Parser)(nil)
+
+func New() *Parser {
+	return &Parser{}
+}
+
+func (*Parser) String() string { return "SocialParser" }
+
+// Parse maps the post onto an Article: the description and transcript
+// become the content, the channel the author, and the platform metadata is
+// carried in Article.Metadata. A post without any text keeps its title as
+// content so short clips still produce a row.
+func (p *Parser) Parse(_ context.Context, url string, data string) (*collector.Article, error) {
+	var post social.Post
+	if err := json.Unmarshal([]byte(data), &post); err != nil {
+		return nil, fmt.Errorf("decode social post %s: %w", url, err)
+	}
+
+	title := strings.TrimSpace(post.Title)
+	content := post.Text()
+	if content == "" {
+		content = title
+	}
+	return &collector.Article{
+		URL:         url,
+		Title:       title,
+		Content:     content,
+		Author:      strings.TrimSpace(post.Channel),
+		PublishedAt: post.PublishedAt,
+		Metadata:    post.CommonMetadata(),
+	}, nil
+}
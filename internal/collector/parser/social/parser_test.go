@@ -0,0 +1,54 @@
+package social_test
+
+import (
+	"context"
+	"testing"
+
+	socialparser "github.com/ChiaYuChang/prism/internal/collector/parser/social"
+	"github.com/ChiaYuChang/prism/internal/social"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser(t *testing.T) {
+	const url = "https://www.youtube.com/watch?v=synVideo001"
+	p := socialparser.New()
+
+	tests := []struct {
+		name        string
+		data        string
+		wantContent string
+	}{
+		{
+			name: "description and transcript",
+			data: `{"platform":"youtube","id":"synVideo001","url":"` + url + `","channel_id":"UCsyntheticParty0000001",` +
+				`"channel":"Synthetic Party Channel","title":" Synthetic Press Conference ","description":"Statement.",` +
+				`"transcript":"Line one.\nLine two.","published_at":"2026-03-29T02:00:00Z","metadata":{"caption_language":"zh-TW"}}`,
+			wantContent: "Statement.\n\nLine one.\nLine two.",
+		},
+		{
+			name: "title only",
+			data: `{"platform":"youtube","id":"synVideo001","url":"` + url + `","channel_id":"UCsyntheticParty0000001",` +
+				`"channel":"Synthetic Party Channel","title":"Synthetic Press Conference","published_at":"2026-03-29T02:00:00Z"}`,
+			wantContent: "Synthetic Press Conference",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			art, err := p.Parse(context.Background(), url, tt.data)
+			require.NoError(t, err)
+			require.Equal(t, url, art.URL)
+			require.Equal(t, "Synthetic Press Conference", art.Title)
+			require.Equal(t, tt.wantContent, art.Content)
+			require.Equal(t, "Synthetic Party Channel", art.Author)
+			require.Equal(t, "2026-03-29", art.PublishedAt.Format("2006-01-02"))
+			require.Equal(t, social.PlatformYouTube, art.Metadata[social.MetadataPlatform])
+			require.Equal(t, "synVideo001", art.Metadata[social.MetadataPostID])
+			require.Equal(t, "UCsyntheticParty0000001", art.Metadata[social.MetadataChannelID])
+		})
+	}
+}
+
+func TestParser_InvalidJSON(t *testing.T) {
+	_, err := socialparser.New().Parse(context.Background(), "https://www.youtube.com/watch?v=synVideo001", "<html></html>")
+	require.Error(t, err)
+}
@@ -1,5 +1,10 @@
 package collector
 
+import (
+	"net/url"
+	"strings"
+)
+
 // Pipeline bundles the per-source stage implementations: F, a Minifier slot
 // (first transformer whose output is the archive point), zero or more
 // post-archive Transformers, and a Parser. Minifier is a role — not a
@@ -14,10 +19,13 @@ type Pipeline struct {
 
 // PipelineRegistry maps source IDs (typically sources.abbr) to Pipelines,
 // falling back to a default when no source-specific entry is registered.
-// Today every source is HTML, so the fallback covers all traffic; per-source
-// entries are added opportunistically as non-HTML sources land.
+// Most sources are HTML, so the fallback covers most traffic; per-source
+// entries are added opportunistically as non-HTML sources land. Host
+// entries cover platforms shared by many sources, such as a video site
+// hosting every party's channel.
 type PipelineRegistry struct {
 	bySource map[string]Pipeline
+	byHost   map[string]Pipeline
 	fallback Pipeline
 }
 
@@ -26,6 +34,7 @@ type PipelineRegistry struct {
 func NewPipelineRegistry(fallback Pipeline) *PipelineRegistry {
 	return &PipelineRegistry{
 		bySource: map[string]Pipeline{},
+		byHost:   map[string]Pipeline{},
 		fallback: fallback,
 	}
 }
@@ -36,6 +45,12 @@ func (r *PipelineRegistry) Register(sourceID string, p Pipeline) {
 	r.bySource[sourceID] = p
 }
 
+// RegisterHost associates a Pipeline with a URL host, matched case
+// insensitively. A later call for the same host overwrites the prior entry.
+func (r *PipelineRegistry) RegisterHost(host string, p Pipeline) {
+	r.byHost[strings.ToLower(strings.TrimSpace(host))] = p
+}
+
 // For returns the Pipeline registered for sourceID, or the fallback if none
 // is registered. Empty sourceID always resolves to the fallback.
 func (r *PipelineRegistry) For(sourceID string) Pipeline {
@@ -44,3 +59,17 @@ func (r *PipelineRegistry) For(sourceID string) Pipeline {
 	}
 	return r.fallback
 }
+
+// ForURL is For with a host lookup between the source entry and the
+// fallback: a source entry wins, then the entry for rawURL's host.
+func (r *PipelineRegistry) ForURL(sourceID, rawURL string) Pipeline {
+	if p, ok := r.bySource[sourceID]; ok {
+		return p
+	}
+	if u, err := url.Parse(rawURL); err == nil {
+		if p, ok := r.byHost[strings.ToLower(u.Hostname())]; ok {
+			return p
+		}
+	}
+	return r.fallback
+}
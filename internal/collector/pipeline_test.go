@@ -48,3 +48,17 @@ func TestPipelineRegistry_ReRegisterOverwrites(t *testing.T) {
 
 	assert.Same(t, second.Fetcher, reg.For("dpp").Fetcher)
 }
+
+func TestPipelineRegistry_ForURL(t *testing.T) {
+	fallback := buildPipeline(t)
+	bySource := buildPipeline(t)
+	byHost := buildPipeline(t)
+	reg := collector.NewPipelineRegistry(fallback)
+	reg.Register("dpp", bySource)
+	reg.RegisterHost("WWW.YouTube.com", byHost)
+
+	assert.Same(t, byHost.Fetcher, reg.ForURL("kmt", "https://www.youtube.com/watch?v=synVideo001").Fetcher)
+	assert.Same(t, bySource.Fetcher, reg.ForURL("dpp", "https://www.youtube.com/watch?v=synVideo001").Fetcher, "source entry wins over host")
+	assert.Same(t, fallback.Fetcher, reg.ForURL("kmt", "https://www.kmt.org.tw/a").Fetcher)
+	assert.Same(t, fallback.Fetcher, reg.ForURL("kmt", "://bad").Fetcher)
+}
@@ -22,9 +22,11 @@ const (
 )
 
 var (
-	ErrZeroUntil      = errors.New("until is zero")
-	ErrNotImplemented = errors.New("not yet implemented")
-	ErrParamMissing   = errors.New("param missing")
+	ErrZeroUntil         = errors.New("until is zero")
+	ErrNotImplemented    = errors.New("not yet implemented")
+	ErrParamMissing      = errors.New("param missing")
+	ErrPagerNotResumable = errors.New("pager cannot resume from a checkpoint")
+	ErrInvalidPosition   = errors.New("invalid pager position")
 )
 
 // A Pager is an interface that provides a way to get the next page of a
@@ -33,6 +35,24 @@ type Pager interface {
 	Next(ctx context.Context) (string, error)
 }
 
+// A ResumablePager can report where it is and continue from there, so a
+// killed run picks up after its last finished page.
+type ResumablePager interface {
+	Pager
+	// Position identifies the page last returned by Next.
+	Position() string
+	// Resume makes the next Next call return the page after position.
+	Resume(position string) error
+}
+
+// A BoundedPager ends the listing itself once its pages reach past the
+// request's Until. Its pages may be empty before the end (a quiet date
+// window), so Backfiller keeps paging through them.
+type BoundedPager interface {
+	Pager
+	Bound(until time.Time)
+}
+
 // Backfiller orchestrates historical data ingestion by replaying older listing pages.
 // It iterates through past directory pages via a Pager, executes discovery using a Scout,
 // filters the discovered briefs against a lower-bound date, and pushes them into
@@ -47,15 +67,32 @@ type Backfiller struct {
 	sink       discoverysink.CandidateSink
 	sourceAbbr string
 	timeout    time.Duration
+
+	checkpoints repo.Backfills
+	pagerType   string
 }
 
 var _ discovery.Backfiller = (*Backfiller)(nil)
 
+// Option configures optional Backfiller behaviour.
+type Option func(*Backfiller)
+
+// WithCheckpoints saves the pager position to store after every page and
+// resumes an unfinished run from it. pagerType names the pager config; a
+// checkpoint left by another pager type is ignored rather than misread.
+// The pager must implement ResumablePager.
+func WithCheckpoints(store repo.Backfills, pagerType string) Option {
+	return func(b *Backfiller) {
+		b.checkpoints = store
+		b.pagerType = pagerType
+	}
+}
+
 // New creates a new Backfiller instance, binding it to a specific Scout, Pager, and
 // CandidateSink. It requires a sourceAbbr matching sources.abbr (PK) in the database.
 func New(logger *slog.Logger, tracer trace.Tracer,
 	scout discovery.Scout, pager Pager, sink discoverysink.CandidateSink,
-	sourceAbbr string, timeout time.Duration) (*Backfiller, error) {
+	sourceAbbr string, timeout time.Duration, opts ...Option) (*Backfiller, error) {
 	if logger == nil {
 		return nil, fmt.Errorf("%w: logger", ErrParamMissing)
 	}
@@ -74,7 +111,7 @@ func New(logger *slog.Logger, tracer trace.Tracer,
 	if strings.TrimSpace(sourceAbbr) == "" {
 		return nil, fmt.Errorf("%w: source_abbr", ErrParamMissing)
 	}
-	return &Backfiller{
+	b := &Backfiller{
 		logger:     logger,
 		tracer:     tracer,
 		scout:      scout,
@@ -82,12 +119,24 @@ func New(logger *slog.Logger, tracer trace.Tracer,
 		sink:       sink,
 		sourceAbbr: sourceAbbr,
 		timeout:    timeout,
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	if b.checkpoints != nil {
+		if _, ok := pager.(ResumablePager); !ok {
+			return nil, fmt.Errorf("%w: %T", ErrPagerNotResumable, pager)
+		}
+	}
+	return b, nil
 }
 
 // Run executes the synchronous backfill process according to req parameters.
 // It pages through using the provided Pager, invokes the Scout, and passes retrieved
 // candidate briefs to the CandidateSink. The BatchID assigned to req groups the ingestion.
+// With checkpoints, an unfinished run of the source resumes after its last
+// finished page under the checkpoint's batch, and the result counters carry
+// on from the checkpoint.
 func (r *Backfiller) Run(ctx context.Context, req discovery.BackfillRequest) (discovery.BackfillResult, error) {
 	ctx, span := r.tracer.Start(ctx, SpanNameBackfillerRun)
 	defer span.End()
@@ -97,15 +146,31 @@ func (r *Backfiller) Run(ctx context.Context, req discovery.BackfillRequest) (di
 	if req.Until.IsZero() {
 		return result, ErrZeroUntil
 	}
+	sourceType := req.SourceType
+	if sourceType == "" {
+		sourceType = repo.SourceTypeParty
+	}
+	result.BatchID = req.BatchID
+	if err := r.resume(ctx, req, &result); err != nil {
+		return result, err
+	}
+	bounded := false
+	if b, ok := r.pager.(BoundedPager); ok {
+		b.Bound(req.Until)
+		bounded = true
+	}
 
 	r.logger.InfoContext(ctx, "backfill started",
 		slog.String("trace_id", traceID),
 		slog.String("source_abbr", r.sourceAbbr),
 		slog.Time("until", req.Until),
 		slog.Int("max_pages", req.MaxPages),
+		slog.String("batch_id", result.BatchID.String()),
+		slog.String("resumed_from", result.ResumedFrom),
 	)
 
 	oldest := time.Time{}
+	completed := false
 	for page := 1; ; page++ {
 		if req.MaxPages > 0 && page > req.MaxPages {
 			r.logger.InfoContext(ctx,
@@ -159,11 +224,11 @@ func (r *Backfiller) Run(ctx context.Context, req discovery.BackfillRequest) (di
 
 			if len(filtered) > 0 {
 				result.OldestPublishedAt = oldest
-				if err := r.sink.Handle(pageCtx, discoverysink.CandidateSinkRequest{
+				if _, err := r.sink.Handle(pageCtx, discoverysink.CandidateSinkRequest{
 					SourceURL:       currentURL,
 					SourceAbbr:      r.sourceAbbr,
-					SourceType:      repo.SourceTypeParty,
-					BatchID:         req.BatchID,
+					SourceType:      sourceType,
+					BatchID:         result.BatchID,
 					TraceID:         traceID,
 					IngestionMethod: "DIRECTORY",
 					Candidates:      filtered,
@@ -172,6 +237,7 @@ func (r *Backfiller) Run(ctx context.Context, req discovery.BackfillRequest) (di
 				}
 				result.CandidatesProcessed += len(filtered)
 			}
+			result.LastPage = r.position(currentURL)
 			return nil
 		}()
 
@@ -179,13 +245,18 @@ func (r *Backfiller) Run(ctx context.Context, req discovery.BackfillRequest) (di
 			return result, err
 		}
 		if stop {
+			completed = true
 			break
 		}
+		if err := r.saveCheckpoint(ctx, req, result, false); err != nil {
+			return result, err
+		}
 
 		r.logger.DebugContext(ctx, "processed backfill page",
 			slog.String("trace_id", traceID),
 			slog.Int("page", page),
 			slog.String("url", currentURL),
+			slog.String("position", result.LastPage),
 			slog.Int("seen", len(candidates)),
 			slog.Int("filtered", len(filtered)),
 		)
@@ -196,18 +267,107 @@ func (r *Backfiller) Run(ctx context.Context, req discovery.BackfillRequest) (di
 				slog.Time("oldest", oldest),
 				slog.Time("until", req.Until),
 			)
+			completed = true
 			break
 		}
-		if len(candidates) == 0 {
+		if len(candidates) == 0 && !bounded {
+			completed = true
 			break
 		}
 	}
 
+	result.Completed = completed
+	if completed {
+		if err := r.saveCheckpoint(ctx, req, result, true); err != nil {
+			return result, err
+		}
+	}
+
 	r.logger.InfoContext(ctx, "backfill completed",
 		slog.String("trace_id", traceID),
 		slog.Int("pages_visited", result.PagesVisited),
 		slog.Int("candidates_processed", result.CandidatesProcessed),
+		slog.Bool("completed", result.Completed),
 	)
 
 	return result, nil
 }
+
+// resume loads the source's checkpoint into result and moves the pager past
+// its last page. A missing or finished checkpoint, one from another pager
+// type or for another Until cutoff, or req.Restart starts from the pager's
+// first page.
+func (r *Backfiller) resume(ctx context.Context, req discovery.BackfillRequest, result *discovery.BackfillResult) error {
+	if r.checkpoints == nil || req.Restart {
+		return nil
+	}
+	cp, err := r.checkpoints.GetCheckpoint(ctx, r.sourceAbbr)
+	if errors.Is(err, repo.ErrCheckpointNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get checkpoint: %w", err)
+	}
+	if cp.CompletedAt != nil || cp.Pager != r.pagerType || !cp.Until.Equal(req.Until) {
+		r.logger.InfoContext(ctx, "previous backfill checkpoint not resumable; starting over",
+			slog.String("source_abbr", r.sourceAbbr),
+			slog.String("pager", cp.Pager),
+			slog.Time("until", cp.Until),
+			slog.Bool("completed", cp.CompletedAt != nil),
+		)
+		return nil
+	}
+
+	if err := r.pager.(ResumablePager).Resume(cp.LastPage); err != nil {
+		return fmt.Errorf("resume from %q: %w", cp.LastPage, err)
+	}
+	result.BatchID = cp.BatchID
+	result.ResumedFrom = cp.LastPage
+	result.LastPage = cp.LastPage
+	result.PagesVisited = int(cp.PagesVisited)
+	result.CandidatesSeen = int(cp.CandidatesSeen)
+	result.CandidatesProcessed = int(cp.CandidatesProcessed)
+	if cp.OldestPublishedAt != nil {
+		result.OldestPublishedAt = *cp.OldestPublishedAt
+	}
+	return nil
+}
+
+// saveCheckpoint records result as the source's checkpoint. Nothing is
+// saved before the first finished page.
+func (r *Backfiller) saveCheckpoint(ctx context.Context, req discovery.BackfillRequest, result discovery.BackfillResult, completed bool) error {
+	if r.checkpoints == nil || result.LastPage == "" {
+		return nil
+	}
+	arg := repo.SaveBackfillCheckpointParams{
+		SourceAbbr:          r.sourceAbbr,
+		Pager:               r.pagerType,
+		LastPage:            result.LastPage,
+		BatchID:             result.BatchID,
+		Until:               req.Until,
+		PagesVisited:        int32(result.PagesVisited),
+		CandidatesSeen:      int32(result.CandidatesSeen),
+		CandidatesProcessed: int32(result.CandidatesProcessed),
+	}
+	if !result.OldestPublishedAt.IsZero() {
+		oldest := result.OldestPublishedAt
+		arg.OldestPublishedAt = &oldest
+	}
+	if completed {
+		now := time.Now()
+		arg.CompletedAt = &now
+	}
+	if _, err := r.checkpoints.SaveCheckpoint(ctx, arg); err != nil {
+		return fmt.Errorf("save checkpoint after %q: %w", result.LastPage, err)
+	}
+	return nil
+}
+
+// position is the pager's position of the page just processed, or its URL
+// for pagers that cannot resume.
+func (r *Backfiller) position(pageURL string) string {
+	if p, ok := r.pager.(ResumablePager); ok {
+		return p.Position()
+	}
+	return pageURL
+}
@@ -12,6 +12,7 @@ import (
 	discoverysink "github.com/ChiaYuChang/prism/internal/discovery/sink"
 	"github.com/ChiaYuChang/prism/internal/model"
 	"github.com/ChiaYuChang/prism/internal/repo"
+	repomocks "github.com/ChiaYuChang/prism/internal/repo/mocks"
 	"github.com/ChiaYuChang/prism/pkg/testutils"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
@@ -23,11 +24,11 @@ type stubCandidateSink struct {
 	handle func(ctx context.Context, req discoverysink.CandidateSinkRequest) error
 }
 
-func (s stubCandidateSink) Handle(ctx context.Context, req discoverysink.CandidateSinkRequest) error {
+func (s stubCandidateSink) Handle(ctx context.Context, req discoverysink.CandidateSinkRequest) (discoverysink.CandidateSinkResult, error) {
 	if s.handle != nil {
-		return s.handle(ctx, req)
+		return discoverysink.CandidateSinkResult{}, s.handle(ctx, req)
 	}
-	return nil
+	return discoverysink.CandidateSinkResult{}, nil
 }
 
 func TestBackfillerTimeout(t *testing.T) {
@@ -169,3 +170,245 @@ func TestBackfillerRunBuildsCandidateSinkRequest(t *testing.T) {
 	require.Len(t, got[1].Candidates, 1)
 	require.Equal(t, "https://example.com/c", got[1].Candidates[0].URL)
 }
+
+func TestBackfillerRunUsesRequestSourceType(t *testing.T) {
+	const page = "https://data.ly.gov.tw/page-1"
+	scout := discoverymocks.NewMockScout(t)
+	pager := mocks.NewMockPager(t)
+	pager.On("Next", mock.Anything).Return(page, nil).Once()
+	scout.On("Discover", mock.Anything, page).Return([]model.Candidates{
+		{URL: "https://ppg.ly.gov.tw/ppg/bills/202603290001/details", PublishedAt: time.Date(2026, 3, 29, 0, 0, 0, 0, time.UTC)},
+	}, nil).Once()
+
+	var got []discoverysink.CandidateSinkRequest
+	sink := stubCandidateSink{
+		handle: func(_ context.Context, req discoverysink.CandidateSinkRequest) error {
+			got = append(got, req)
+			return nil
+		},
+	}
+	runner, err := backfiller.New(testutils.Logger(), noop.NewTracerProvider().Tracer("test"), scout, pager, sink, "ly", 0)
+	require.NoError(t, err)
+
+	_, err = runner.Run(context.Background(), discovery.BackfillRequest{
+		Until:      time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		MaxPages:   1,
+		SourceType: repo.SourceTypeGovernment,
+	})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Equal(t, repo.SourceTypeGovernment, got[0].SourceType)
+}
+
+func newCheckpointPager(t *testing.T) *backfiller.IndexPager {
+	t.Helper()
+	pager, err := backfiller.NewIndexPager(testutils.Logger(), noop.NewTracerProvider().Tracer("test"), backfiller.IndexPagerConfig{
+		BaseURL:     "https://www.tpp.org.tw",
+		URLTemplate: "{{.BaseURL}}/media",
+		First:       1,
+		Step:        1,
+		Mode:        backfiller.PageModeIndex,
+		Params:      map[string]string{"page": "{{.Value}}"},
+	})
+	require.NoError(t, err)
+	return pager
+}
+
+func TestBackfillerRunSavesCheckpointPerPage(t *testing.T) {
+	scout := discoverymocks.NewMockScout(t)
+	checkpoints := repomocks.NewMockBackfills(t)
+	until := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	batchID := uuid.New()
+
+	scout.On("Discover", mock.Anything, "https://www.tpp.org.tw/media?page=1").
+		Return([]model.Candidates{{Title: "new", PublishedAt: until.Add(time.Hour)}}, nil).Once()
+	scout.On("Discover", mock.Anything, "https://www.tpp.org.tw/media?page=2").
+		Return([]model.Candidates{{Title: "old", PublishedAt: until.Add(-time.Hour)}}, nil).Once()
+
+	checkpoints.On("GetCheckpoint", mock.Anything, "tpp").
+		Return(repo.BackfillCheckpoint{}, repo.ErrCheckpointNotFound).Once()
+	var saved []repo.SaveBackfillCheckpointParams
+	checkpoints.On("SaveCheckpoint", mock.Anything, mock.AnythingOfType("repo.SaveBackfillCheckpointParams")).
+		Run(func(args mock.Arguments) {
+			saved = append(saved, args.Get(1).(repo.SaveBackfillCheckpointParams))
+		}).Return(repo.BackfillCheckpoint{}, nil)
+
+	runner, err := backfiller.New(
+		testutils.Logger(),
+		noop.NewTracerProvider().Tracer("test"),
+		scout, newCheckpointPager(t), stubCandidateSink{}, "tpp", time.Second,
+		backfiller.WithCheckpoints(checkpoints, "index"))
+	require.NoError(t, err)
+
+	result, err := runner.Run(context.Background(), discovery.BackfillRequest{
+		BatchID: batchID,
+		Until:   until,
+	})
+	require.NoError(t, err)
+	require.True(t, result.Completed)
+	require.Equal(t, batchID, result.BatchID)
+	require.Equal(t, "2", result.LastPage)
+	require.Empty(t, result.ResumedFrom)
+
+	require.Len(t, saved, 3, "one checkpoint per page and one on completion")
+	require.Equal(t, "1", saved[0].LastPage)
+	require.Nil(t, saved[0].CompletedAt)
+	require.Equal(t, "2", saved[1].LastPage)
+	require.Nil(t, saved[1].CompletedAt)
+	require.Equal(t, "2", saved[2].LastPage)
+	require.NotNil(t, saved[2].CompletedAt)
+	require.Equal(t, "index", saved[2].Pager)
+	require.Equal(t, batchID, saved[2].BatchID)
+	require.EqualValues(t, 2, saved[2].PagesVisited)
+	require.EqualValues(t, 1, saved[2].CandidatesProcessed)
+}
+
+func TestBackfillerRunResumesFromCheckpoint(t *testing.T) {
+	scout := discoverymocks.NewMockScout(t)
+	checkpoints := repomocks.NewMockBackfills(t)
+	until := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	checkpointBatch := uuid.New()
+
+	checkpoints.On("GetCheckpoint", mock.Anything, "tpp").Return(repo.BackfillCheckpoint{
+		SourceAbbr:          "tpp",
+		Pager:               "index",
+		LastPage:            "4",
+		BatchID:             checkpointBatch,
+		Until:               until,
+		PagesVisited:        4,
+		CandidatesSeen:      40,
+		CandidatesProcessed: 38,
+	}, nil).Once()
+	checkpoints.On("SaveCheckpoint", mock.Anything, mock.AnythingOfType("repo.SaveBackfillCheckpointParams")).
+		Return(repo.BackfillCheckpoint{}, nil)
+
+	scout.On("Discover", mock.Anything, "https://www.tpp.org.tw/media?page=5").
+		Return([]model.Candidates{{Title: "older", PublishedAt: until.Add(-time.Hour)}}, nil).Once()
+
+	var sinkBatch uuid.UUID
+	sink := stubCandidateSink{handle: func(_ context.Context, req discoverysink.CandidateSinkRequest) error {
+		sinkBatch = req.BatchID
+		return nil
+	}}
+
+	runner, err := backfiller.New(
+		testutils.Logger(),
+		noop.NewTracerProvider().Tracer("test"),
+		scout, newCheckpointPager(t), sink, "tpp", time.Second,
+		backfiller.WithCheckpoints(checkpoints, "index"))
+	require.NoError(t, err)
+
+	result, err := runner.Run(context.Background(), discovery.BackfillRequest{
+		BatchID: uuid.New(),
+		Until:   until,
+	})
+	require.NoError(t, err)
+	require.Equal(t, checkpointBatch, result.BatchID)
+	require.Equal(t, "4", result.ResumedFrom)
+	require.Equal(t, "5", result.LastPage)
+	require.Equal(t, 5, result.PagesVisited)
+	require.Equal(t, 41, result.CandidatesSeen)
+	require.Equal(t, 38, result.CandidatesProcessed)
+	require.True(t, result.Completed)
+	require.Equal(t, uuid.Nil, sinkBatch, "nothing on the resumed page is newer than until")
+}
+
+func TestBackfillerRunIgnoresCheckpoint(t *testing.T) {
+	tcs := []struct {
+		name       string
+		restart    bool
+		checkpoint repo.BackfillCheckpoint
+	}{
+		{
+			name:       "restart",
+			restart:    true,
+			checkpoint: repo.BackfillCheckpoint{Pager: "index", LastPage: "4"},
+		},
+		{
+			name:       "completed",
+			checkpoint: repo.BackfillCheckpoint{Pager: "index", LastPage: "4", CompletedAt: new(time.Time)},
+		},
+		{
+			name:       "other pager",
+			checkpoint: repo.BackfillCheckpoint{Pager: "sitemap", LastPage: "https://www.tpp.org.tw/sitemap-1.xml"},
+		},
+		{
+			name:       "other until",
+			checkpoint: repo.BackfillCheckpoint{Pager: "index", LastPage: "4", Until: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			scout := discoverymocks.NewMockScout(t)
+			checkpoints := repomocks.NewMockBackfills(t)
+			until := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+			batchID := uuid.New()
+
+			if !tc.restart {
+				checkpoints.On("GetCheckpoint", mock.Anything, "tpp").Return(tc.checkpoint, nil).Once()
+			}
+			checkpoints.On("SaveCheckpoint", mock.Anything, mock.AnythingOfType("repo.SaveBackfillCheckpointParams")).
+				Return(repo.BackfillCheckpoint{}, nil)
+			scout.On("Discover", mock.Anything, "https://www.tpp.org.tw/media?page=1").
+				Return([]model.Candidates{{Title: "old", PublishedAt: until.Add(-time.Hour)}}, nil).Once()
+
+			runner, err := backfiller.New(
+				testutils.Logger(),
+				noop.NewTracerProvider().Tracer("test"),
+				scout, newCheckpointPager(t), stubCandidateSink{}, "tpp", time.Second,
+				backfiller.WithCheckpoints(checkpoints, "index"))
+			require.NoError(t, err)
+
+			result, err := runner.Run(context.Background(), discovery.BackfillRequest{
+				BatchID: batchID,
+				Until:   until,
+				Restart: tc.restart,
+			})
+			require.NoError(t, err)
+			require.Equal(t, batchID, result.BatchID)
+			require.Empty(t, result.ResumedFrom)
+			require.Equal(t, 1, result.PagesVisited)
+		})
+	}
+}
+
+func TestBackfillerCheckpointsRequireResumablePager(t *testing.T) {
+	_, err := backfiller.New(
+		testutils.Logger(),
+		noop.NewTracerProvider().Tracer("test"),
+		discoverymocks.NewMockScout(t), mocks.NewMockPager(t), stubCandidateSink{}, "tpp", time.Second,
+		backfiller.WithCheckpoints(repomocks.NewMockBackfills(t), "index"))
+	require.ErrorIs(t, err, backfiller.ErrPagerNotResumable)
+}
+
+func TestBackfillerRunBoundedPagerSkipsEmptyWindows(t *testing.T) {
+	scout := discoverymocks.NewMockScout(t)
+	until := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	pager, err := backfiller.NewDateWindowPager(testutils.Logger(), noop.NewTracerProvider().Tracer("test"), backfiller.DateWindowPagerConfig{
+		URLTemplate: "https://www.ey.gov.tw/search",
+		Params:      map[string]string{"since": `{{.Since.Format "2006-01-02"}}`},
+		Window:      7 * 24 * time.Hour,
+		Before:      until.AddDate(0, 0, 21),
+	})
+	require.NoError(t, err)
+
+	scout.On("Discover", mock.Anything, "https://www.ey.gov.tw/search?since=2026-03-15").
+		Return([]model.Candidates{{Title: "recent", PublishedAt: until.AddDate(0, 0, 16)}}, nil).Once()
+	scout.On("Discover", mock.Anything, "https://www.ey.gov.tw/search?since=2026-03-08").
+		Return(nil, nil).Once()
+	scout.On("Discover", mock.Anything, "https://www.ey.gov.tw/search?since=2026-03-01").
+		Return([]model.Candidates{{Title: "early", PublishedAt: until.AddDate(0, 0, 2)}}, nil).Once()
+
+	runner, err := backfiller.New(
+		testutils.Logger(),
+		noop.NewTracerProvider().Tracer("test"),
+		scout, pager, stubCandidateSink{}, "ey-news", time.Second)
+	require.NoError(t, err)
+
+	result, err := runner.Run(context.Background(), discovery.BackfillRequest{Until: until})
+	require.NoError(t, err)
+	require.True(t, result.Completed)
+	require.Equal(t, 3, result.PagesVisited)
+	require.Equal(t, 2, result.CandidatesProcessed)
+}
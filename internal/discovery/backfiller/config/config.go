@@ -25,19 +25,47 @@ type BackfillSection struct {
 
 type SourceConfig struct {
 	Name    string        `yaml:"-"         json:"-"`
-	Format  string        `yaml:"format"    json:"format"    validate:"required,oneof=html rss atom custom"`
+	Format  string        `yaml:"format"    json:"format"    validate:"required,oneof=html rss atom sitemap json social custom"`
 	BaseURL string        `yaml:"base_url"  json:"base_url"  validate:"required,url"`
 	Pager   PagerConfig   `yaml:"pager"     json:"pager"     validate:"required"`
 	Timeout time.Duration `yaml:"timeout"   json:"timeout"   validate:"min=0"`
+
+	// SourceType is sources.type of the source; PARTY when empty. It sets
+	// the candidates' source type, which decides whether the sink queues
+	// PAGE_FETCH tasks for them.
+	SourceType string `yaml:"source_type" json:"source_type" validate:"omitempty,oneof=PARTY MEDIA GOVERNMENT"`
 }
 
+// PagerConfig selects how a source's past pages are enumerated:
+//
+//   - index renders URLTemplate and Params with a page value running from
+//     First in steps of Step (page numbers, path offsets).
+//   - offset sets OffsetParam (default offset) from First in steps of Limit
+//     and LimitParam (default limit) to Limit; URLTemplate defaults to
+//     BaseURL.
+//   - next_link starts at StartURL (default BaseURL) and follows the
+//     Selector link's Attr (default href) on each page.
+//   - date_window renders URLTemplate and Params with .Since and .Until of
+//     Window-long windows, from Before (default end of today) back to
+//     --until.
+//   - sitemap walks the child sitemaps of IndexURL (default BaseURL),
+//     newest first, skipping those modified after Before.
 type PagerConfig struct {
-	Type        string            `yaml:"type"         json:"type"         validate:"required,oneof=index"`
-	URLTemplate string            `yaml:"url_template" json:"url_template" validate:"required"`
+	Type        string            `yaml:"type"         json:"type"         validate:"required,oneof=index offset next_link date_window sitemap"`
+	URLTemplate string            `yaml:"url_template" json:"url_template" validate:"required_if=Type index,required_if=Type date_window"`
 	First       int               `yaml:"first"        json:"first"        validate:"min=0"`
-	Step        int               `yaml:"step"         json:"step"         validate:"required,min=1"`
-	Mode        string            `yaml:"mode"         json:"mode"         validate:"required,oneof=index cursor date-range"`
+	Step        int               `yaml:"step"         json:"step"         validate:"required_if=Type index,omitempty,min=1"`
+	Mode        string            `yaml:"mode"         json:"mode"         validate:"required_if=Type index,omitempty,oneof=index cursor date-range"`
 	Params      map[string]string `yaml:"params"       json:"params"`
+	IndexURL    string            `yaml:"index_url"    json:"index_url"    validate:"omitempty,url"`
+	Before      time.Time         `yaml:"before"       json:"before"`
+	Limit       int               `yaml:"limit"        json:"limit"        validate:"required_if=Type offset,omitempty,min=1"`
+	OffsetParam string            `yaml:"offset_param" json:"offset_param"`
+	LimitParam  string            `yaml:"limit_param"  json:"limit_param"`
+	StartURL    string            `yaml:"start_url"    json:"start_url"    validate:"omitempty,url"`
+	Selector    string            `yaml:"selector"     json:"selector"     validate:"required_if=Type next_link"`
+	Attr        string            `yaml:"attr"         json:"attr"`
+	Window      time.Duration     `yaml:"window"       json:"window"       validate:"required_if=Type date_window,min=0"`
 }
 
 // Write writes the Config to an io.Writer in the specified format (json, yaml, yml).
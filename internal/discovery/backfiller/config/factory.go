@@ -7,13 +7,16 @@ import (
 	"net/url"
 	"slices"
 
+	"github.com/ChiaYuChang/prism/internal/discovery"
 	"github.com/ChiaYuChang/prism/internal/discovery/backfiller"
 	scoutconfig "github.com/ChiaYuChang/prism/internal/discovery/scout/config"
+	sitemapscout "github.com/ChiaYuChang/prism/internal/discovery/scout/sitemap"
 	discoverysink "github.com/ChiaYuChang/prism/internal/discovery/sink"
+	"github.com/ChiaYuChang/prism/internal/repo"
 	"go.opentelemetry.io/otel/trace"
 )
 
-func BuildPager(logger *slog.Logger, tracer trace.Tracer, spec SourceConfig) (backfiller.Pager, error) {
+func BuildPager(logger *slog.Logger, tracer trace.Tracer, client *http.Client, spec SourceConfig, headers map[string]string) (backfiller.Pager, error) {
 	switch spec.Pager.Type {
 	case "index":
 		mode := backfiller.PagerMode(spec.Pager.Mode)
@@ -29,11 +32,53 @@ func BuildPager(logger *slog.Logger, tracer trace.Tracer, spec SourceConfig) (ba
 			Mode:        mode,
 			Params:      spec.Pager.Params,
 		})
+	case "offset":
+		return backfiller.NewOffsetPager(logger, tracer, backfiller.OffsetPagerConfig{
+			BaseURL:     spec.BaseURL,
+			URLTemplate: spec.Pager.URLTemplate,
+			First:       spec.Pager.First,
+			Limit:       spec.Pager.Limit,
+			OffsetParam: spec.Pager.OffsetParam,
+			LimitParam:  spec.Pager.LimitParam,
+			Params:      spec.Pager.Params,
+		})
+	case "next_link":
+		startURL := spec.Pager.StartURL
+		if startURL == "" {
+			startURL = spec.BaseURL
+		}
+		return backfiller.NewNextLinkPager(logger, tracer, client, backfiller.NextLinkPagerConfig{
+			StartURL: startURL,
+			Selector: spec.Pager.Selector,
+			Attr:     spec.Pager.Attr,
+			Headers:  headers,
+		})
+	case "date_window":
+		return backfiller.NewDateWindowPager(logger, tracer, backfiller.DateWindowPagerConfig{
+			BaseURL:     spec.BaseURL,
+			URLTemplate: spec.Pager.URLTemplate,
+			Params:      spec.Pager.Params,
+			Window:      spec.Pager.Window,
+			Before:      spec.Pager.Before,
+		})
+	case "sitemap":
+		indexURL := spec.Pager.IndexURL
+		if indexURL == "" {
+			indexURL = spec.BaseURL
+		}
+		return backfiller.NewSitemapPager(logger, tracer, client, backfiller.SitemapPagerConfig{
+			IndexURL: indexURL,
+			Headers:  headers,
+			Before:   spec.Pager.Before,
+		})
 	default:
 		return nil, fmt.Errorf("unknown pager type: %s", spec.Pager.Type)
 	}
 }
 
+// BuildBackfiller builds the scout and pager of spec. With a non-nil
+// checkpoints store the run saves its position after every page and resumes
+// an unfinished run of the same pager type.
 func BuildBackfiller(
 	spec SourceConfig,
 	scoutRepo *scoutconfig.Repository,
@@ -41,18 +86,49 @@ func BuildBackfiller(
 	tracer trace.Tracer,
 	client *http.Client,
 	sink discoverysink.CandidateSink,
+	checkpoints repo.Backfills,
 ) (*backfiller.Backfiller, error) {
-	scout, err := scoutconfig.BuildScoutByName(scoutRepo, spec.Name, logger, tracer, client)
-	if err != nil {
-		return nil, fmt.Errorf("build scout %s: %w", spec.Name, err)
+	var scout discovery.Scout
+	var headers map[string]string
+	if sitemap, ok := sitemapScout(scoutRepo, spec); ok {
+		// The pager bounds the historical range, so the scout's lastmod
+		// window, sized for daily runs, would only drop wanted entries.
+		cfg := sitemap.Config
+		cfg.LastmodWindow = 0
+		s, err := sitemapscout.New(logger, tracer, client, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("build scout %s: %w", spec.Name, err)
+		}
+		scout, headers = s, cfg.Headers
+	} else {
+		s, err := scoutconfig.BuildScoutByName(scoutRepo, spec.Name, logger, tracer, client)
+		if err != nil {
+			return nil, fmt.Errorf("build scout %s: %w", spec.Name, err)
+		}
+		scout = s
+		if html, ok := scoutRepo.HTML(spec.Name); ok && spec.Format == "html" {
+			headers = html.Config.Headers
+		}
 	}
 
-	pager, err := BuildPager(logger, tracer, spec)
+	pager, err := BuildPager(logger, tracer, client, spec, headers)
 	if err != nil {
 		return nil, fmt.Errorf("build pager for %s: %w", spec.Name, err)
 	}
 
-	return backfiller.New(logger, tracer, scout, pager, sink, spec.Name, spec.Timeout)
+	var opts []backfiller.Option
+	if checkpoints != nil {
+		opts = append(opts, backfiller.WithCheckpoints(checkpoints, spec.Pager.Type))
+	}
+	return backfiller.New(logger, tracer, scout, pager, sink, spec.Name, spec.Timeout, opts...)
+}
+
+func sitemapScout(repo *scoutconfig.Repository, spec SourceConfig) (scoutconfig.SitemapSpec, bool) {
+	if repo == nil || spec.Format != "sitemap" {
+		return scoutconfig.SitemapSpec{}, false
+	}
+	sitemap, ok := repo.Sitemap(spec.Name)
+	return sitemap, ok && sitemap.Enabled
 }
 
 func ConfirmSourceAgainstScout(spec SourceConfig, repo *scoutconfig.Repository) error {
@@ -88,6 +164,24 @@ func ConfirmSourceAgainstScout(spec SourceConfig, repo *scoutconfig.Repository)
 			return fmt.Errorf("atom scout %q not found or disabled", spec.Name)
 		}
 		hosts = scoutSpec.Hosts
+	case "sitemap":
+		scoutSpec, ok := repo.Sitemap(spec.Name)
+		if !ok || !scoutSpec.Enabled {
+			return fmt.Errorf("sitemap scout %q not found or disabled", spec.Name)
+		}
+		hosts = scoutSpec.Hosts
+	case "json":
+		scoutSpec, ok := repo.JSON(spec.Name)
+		if !ok || !scoutSpec.Enabled {
+			return fmt.Errorf("json scout %q not found or disabled", spec.Name)
+		}
+		hosts = scoutSpec.Hosts
+	case "social":
+		scoutSpec, ok := repo.Social(spec.Name)
+		if !ok || !scoutSpec.Enabled {
+			return fmt.Errorf("social scout %q not found or disabled", spec.Name)
+		}
+		hosts = scoutSpec.Hosts
 	case "custom":
 		scoutSpec, ok := repo.Custom(spec.Name)
 		if !ok || !scoutSpec.Enabled {
@@ -93,6 +93,13 @@ func New(cfg Config) (*Repository, error) {
 		source.Pager.Type = strings.TrimSpace(strings.ToLower(source.Pager.Type))
 		source.Pager.URLTemplate = strings.TrimSpace(source.Pager.URLTemplate)
 		source.Pager.Mode = strings.TrimSpace(strings.ToLower(source.Pager.Mode))
+		source.Pager.IndexURL = strings.TrimSpace(source.Pager.IndexURL)
+		source.Pager.OffsetParam = strings.TrimSpace(source.Pager.OffsetParam)
+		source.Pager.LimitParam = strings.TrimSpace(source.Pager.LimitParam)
+		source.Pager.StartURL = strings.TrimSpace(source.Pager.StartURL)
+		source.Pager.Selector = strings.TrimSpace(source.Pager.Selector)
+		source.Pager.Attr = strings.TrimSpace(source.Pager.Attr)
+		source.SourceType = strings.TrimSpace(strings.ToUpper(source.SourceType))
 		repo.bySource[name] = source
 	}
 
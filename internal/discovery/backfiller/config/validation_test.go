@@ -2,6 +2,7 @@ package config_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/ChiaYuChang/prism/internal/discovery/backfiller/config"
 	"github.com/stretchr/testify/assert"
@@ -75,6 +76,38 @@ func TestConfig_Validation(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "sitemap pager without url template",
+			cfg: config.Config{
+				Version: 1,
+				Backfiller: config.BackfillSection{
+					Sources: map[string]config.SourceConfig{
+						"cna": {
+							Format:  "sitemap",
+							BaseURL: "https://www.cna.com.tw/sitemap/news-index.xml",
+							Pager:   config.PagerConfig{Type: "sitemap"},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "sitemap pager invalid index url",
+			cfg: config.Config{
+				Version: 1,
+				Backfiller: config.BackfillSection{
+					Sources: map[string]config.SourceConfig{
+						"cna": {
+							Format:  "sitemap",
+							BaseURL: "https://www.cna.com.tw",
+							Pager:   config.PagerConfig{Type: "sitemap", IndexURL: "not a url"},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalid format",
 			cfg: config.Config{
@@ -117,6 +150,118 @@ func TestConfig_Validation(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "offset pager",
+			cfg: config.Config{
+				Version: 1,
+				Backfiller: config.BackfillSection{
+					Sources: map[string]config.SourceConfig{
+						"ey-news": {
+							Format:  "json",
+							BaseURL: "https://www.ey.gov.tw",
+							Pager:   config.PagerConfig{Type: "offset", Limit: 20, OffsetParam: "skip"},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "offset pager without limit",
+			cfg: config.Config{
+				Version: 1,
+				Backfiller: config.BackfillSection{
+					Sources: map[string]config.SourceConfig{
+						"ey-news": {
+							Format:  "json",
+							BaseURL: "https://www.ey.gov.tw",
+							Pager:   config.PagerConfig{Type: "offset"},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "next link pager",
+			cfg: config.Config{
+				Version: 1,
+				Backfiller: config.BackfillSection{
+					Sources: map[string]config.SourceConfig{
+						"ey-news": {
+							Format:  "html",
+							BaseURL: "https://www.ey.gov.tw",
+							Pager:   config.PagerConfig{Type: "next_link", Selector: "a.next"},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "next link pager without selector",
+			cfg: config.Config{
+				Version: 1,
+				Backfiller: config.BackfillSection{
+					Sources: map[string]config.SourceConfig{
+						"ey-news": {
+							Format:  "html",
+							BaseURL: "https://www.ey.gov.tw",
+							Pager:   config.PagerConfig{Type: "next_link", StartURL: "https://www.ey.gov.tw/news"},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "date window pager",
+			cfg: config.Config{
+				Version: 1,
+				Backfiller: config.BackfillSection{
+					Sources: map[string]config.SourceConfig{
+						"ey-news": {
+							Format:  "html",
+							BaseURL: "https://www.ey.gov.tw",
+							Pager:   config.PagerConfig{Type: "date_window", URLTemplate: "{{.BaseURL}}/search", Window: 7 * 24 * time.Hour},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "date window pager without window",
+			cfg: config.Config{
+				Version: 1,
+				Backfiller: config.BackfillSection{
+					Sources: map[string]config.SourceConfig{
+						"ey-news": {
+							Format:  "html",
+							BaseURL: "https://www.ey.gov.tw",
+							Pager:   config.PagerConfig{Type: "date_window", URLTemplate: "{{.BaseURL}}/search"},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "date window pager without url template",
+			cfg: config.Config{
+				Version: 1,
+				Backfiller: config.BackfillSection{
+					Sources: map[string]config.SourceConfig{
+						"ey-news": {
+							Format:  "html",
+							BaseURL: "https://www.ey.gov.tw",
+							Pager:   config.PagerConfig{Type: "date_window", Window: time.Hour},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "missing base url",
 			cfg: config.Config{
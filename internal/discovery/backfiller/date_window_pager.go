@@ -0,0 +1,175 @@
+package backfiller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/obs"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SpanNameDateWindowPagerNext = "discovery.backfiller.date_window_pager.next"
+
+var ErrZeroPagerWindow = fmt.Errorf("%w: window", ErrParamMissing)
+
+// DateWindowPagerConfig walks a date-filtered listing, typically a site
+// search, one window at a time from Before back to the request's Until.
+// URLTemplate and Params render DateWindowVars, e.g.
+// {{.Since.Format "2006-01-02"}}. Before defaults to the end of today.
+type DateWindowPagerConfig struct {
+	BaseURL     string            `json:"base_url,omitempty"`
+	URLTemplate string            `json:"url_template,omitempty"`
+	Params      map[string]string `json:"params,omitempty"`
+	Window      time.Duration     `json:"window,omitempty"`
+	Before      time.Time         `json:"before,omitempty"`
+}
+
+// DateWindowVars is the data the date window templates render with. The
+// window is [Since, Until).
+type DateWindowVars struct {
+	BaseURL string    `json:"base_url,omitempty"`
+	Since   time.Time `json:"since"`
+	Until   time.Time `json:"until"`
+}
+
+// DateWindowPager returns one URL per window, newest first, and ends once a
+// window would start at or before the bound set by Bound. Positions are
+// window ends in RFC 3339.
+type DateWindowPager struct {
+	logger      *slog.Logger
+	tracer      trace.Tracer
+	cfg         DateWindowPagerConfig
+	urlTmpl     *template.Template
+	paramsTmpls map[string]*template.Template
+	bound       time.Time
+	next        time.Time
+	last        time.Time
+}
+
+var (
+	_ ResumablePager = (*DateWindowPager)(nil)
+	_ BoundedPager   = (*DateWindowPager)(nil)
+)
+
+func NewDateWindowPager(logger *slog.Logger, tracer trace.Tracer, cfg DateWindowPagerConfig) (*DateWindowPager, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("%w: logger", ErrParamMissing)
+	}
+	if tracer == nil {
+		return nil, fmt.Errorf("%w: tracer", ErrParamMissing)
+	}
+
+	cfg.URLTemplate = strings.TrimSpace(cfg.URLTemplate)
+	if cfg.URLTemplate == "" {
+		return nil, ErrEmptyPagerURLTemplate
+	}
+	if cfg.Window <= 0 {
+		return nil, ErrZeroPagerWindow
+	}
+	if cfg.Before.IsZero() {
+		y, m, d := time.Now().Date()
+		cfg.Before = time.Date(y, m, d+1, 0, 0, 0, 0, time.Local)
+	}
+
+	fMap := template.FuncMap(TemplateFuncMap)
+	urlTmpl, err := template.New("url").Funcs(fMap).Parse(cfg.URLTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("parse url template: %w", err)
+	}
+	paramsTmpls := make(map[string]*template.Template)
+	for k, v := range cfg.Params {
+		t, err := template.New(k).Funcs(fMap).Parse(v)
+		if err != nil {
+			return nil, fmt.Errorf("parse param template [%s]: %w", k, err)
+		}
+		paramsTmpls[k] = t
+	}
+
+	return &DateWindowPager{
+		logger:      logger,
+		tracer:      tracer,
+		cfg:         cfg,
+		urlTmpl:     urlTmpl,
+		paramsTmpls: paramsTmpls,
+		next:        cfg.Before,
+	}, nil
+}
+
+// Bound stops the pager once a window would start at or before until.
+func (p *DateWindowPager) Bound(until time.Time) {
+	p.bound = until
+}
+
+func (p *DateWindowPager) Next(ctx context.Context) (string, error) {
+	if p == nil {
+		return "", nil
+	}
+	ctx, span := p.tracer.Start(ctx, SpanNameDateWindowPagerNext)
+	defer span.End()
+	traceID := obs.ExtractTraceID(ctx)
+
+	end := p.next
+	if !p.bound.IsZero() && !end.After(p.bound) {
+		return "", nil
+	}
+	vars := DateWindowVars{
+		BaseURL: p.cfg.BaseURL,
+		Since:   end.Add(-p.cfg.Window),
+		Until:   end,
+	}
+
+	var buf bytes.Buffer
+	if err := p.urlTmpl.Execute(&buf, vars); err != nil {
+		return "", fmt.Errorf("execute url template: %w", err)
+	}
+	u, err := url.Parse(buf.String())
+	if err != nil {
+		return "", fmt.Errorf("parse rendered url: %w", err)
+	}
+	query := u.Query()
+	for k, tmpl := range p.paramsTmpls {
+		var pBuf bytes.Buffer
+		if err := tmpl.Execute(&pBuf, vars); err != nil {
+			return "", fmt.Errorf("execute param template [%s]: %w", k, err)
+		}
+		query.Set(k, pBuf.String())
+	}
+	u.RawQuery = query.Encode()
+
+	p.last = end
+	p.next = vars.Since
+	finalURL := u.String()
+
+	p.logger.DebugContext(ctx, "date window pager resolved next url",
+		slog.String("trace_id", traceID),
+		slog.String("url", finalURL),
+		slog.Time("since", vars.Since),
+		slog.Time("until", vars.Until),
+	)
+	return finalURL, nil
+}
+
+// Position returns the end of the window last returned by Next.
+func (p *DateWindowPager) Position() string {
+	if p.last.IsZero() {
+		return ""
+	}
+	return p.last.Format(time.RFC3339)
+}
+
+// Resume continues with the window before the one ending at position.
+func (p *DateWindowPager) Resume(position string) error {
+	end, err := time.Parse(time.RFC3339, strings.TrimSpace(position))
+	if err != nil {
+		return fmt.Errorf("%w: %q", ErrInvalidPosition, position)
+	}
+	p.last = end
+	p.next = end.Add(-p.cfg.Window)
+	return nil
+}
@@ -0,0 +1,67 @@
+package backfiller_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/discovery/backfiller"
+	"github.com/ChiaYuChang/prism/pkg/testutils"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func newDateWindowPager(t *testing.T) *backfiller.DateWindowPager {
+	t.Helper()
+	loc := time.FixedZone("Asia/Taipei", 8*60*60)
+	pager, err := backfiller.NewDateWindowPager(testutils.Logger(), noop.NewTracerProvider().Tracer("test"), backfiller.DateWindowPagerConfig{
+		BaseURL:     "https://www.ey.gov.tw",
+		URLTemplate: "{{.BaseURL}}/Page/6485009ABEC1CB9C",
+		Params: map[string]string{
+			"start": `{{.Since.Format "2006/01/02"}}`,
+			"end":   `{{(.Until.AddDate 0 0 -1).Format "2006/01/02"}}`,
+		},
+		Window: 7 * 24 * time.Hour,
+		Before: time.Date(2026, 3, 15, 0, 0, 0, 0, loc),
+	})
+	require.NoError(t, err)
+	return pager
+}
+
+func TestDateWindowPager(t *testing.T) {
+	pager := newDateWindowPager(t)
+	pager.Bound(time.Date(2026, 3, 1, 0, 0, 0, 0, time.FixedZone("Asia/Taipei", 8*60*60)))
+
+	var got []string
+	for {
+		next, err := pager.Next(context.Background())
+		require.NoError(t, err)
+		if next == "" {
+			break
+		}
+		got = append(got, next)
+	}
+	require.Equal(t, []string{
+		"https://www.ey.gov.tw/Page/6485009ABEC1CB9C?end=2026%2F03%2F14&start=2026%2F03%2F08",
+		"https://www.ey.gov.tw/Page/6485009ABEC1CB9C?end=2026%2F03%2F07&start=2026%2F03%2F01",
+	}, got)
+	require.Equal(t, "2026-03-08T00:00:00+08:00", pager.Position())
+}
+
+func TestDateWindowPager_Resume(t *testing.T) {
+	pager := newDateWindowPager(t)
+	require.NoError(t, pager.Resume("2026-03-08T00:00:00+08:00"))
+
+	next, err := pager.Next(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "https://www.ey.gov.tw/Page/6485009ABEC1CB9C?end=2026%2F02%2F28&start=2026%2F02%2F22", next)
+
+	require.ErrorIs(t, pager.Resume("2026-03-08"), backfiller.ErrInvalidPosition)
+}
+
+func TestDateWindowPager_MissingWindow(t *testing.T) {
+	_, err := backfiller.NewDateWindowPager(testutils.Logger(), noop.NewTracerProvider().Tracer("test"), backfiller.DateWindowPagerConfig{
+		URLTemplate: "{{.BaseURL}}",
+	})
+	require.ErrorIs(t, err, backfiller.ErrZeroPagerWindow)
+}
@@ -2,10 +2,13 @@ package backfiller
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"fmt"
 	"log/slog"
+	"maps"
 	"net/url"
+	"strconv"
 	"strings"
 	"text/template"
 
@@ -59,7 +62,47 @@ type IndexPager struct {
 	first       bool
 }
 
-var _ Pager = (*IndexPager)(nil)
+var _ ResumablePager = (*IndexPager)(nil)
+
+// OffsetPagerConfig pages an offset/limit listing: OffsetParam runs from
+// First in steps of Limit and LimitParam is fixed to Limit. URLTemplate
+// defaults to BaseURL; Params are extra query templates.
+type OffsetPagerConfig struct {
+	BaseURL     string            `json:"base_url,omitempty"`
+	URLTemplate string            `json:"url_template,omitempty"`
+	First       int               `json:"first,omitempty"`
+	Limit       int               `json:"limit,omitempty"`
+	OffsetParam string            `json:"offset_param,omitempty"`
+	LimitParam  string            `json:"limit_param,omitempty"`
+	Params      map[string]string `json:"params,omitempty"`
+}
+
+// NewOffsetPager builds an IndexPager for an offset/limit listing.
+func NewOffsetPager(logger *slog.Logger, tracer trace.Tracer, cfg OffsetPagerConfig) (*IndexPager, error) {
+	if cfg.Limit <= 0 {
+		return nil, fmt.Errorf("%w: limit", ErrParamMissing)
+	}
+	urlTemplate := strings.TrimSpace(cfg.URLTemplate)
+	if urlTemplate == "" {
+		urlTemplate = "{{.BaseURL}}"
+	}
+	offsetParam := cmp.Or(strings.TrimSpace(cfg.OffsetParam), "offset")
+	limitParam := cmp.Or(strings.TrimSpace(cfg.LimitParam), "limit")
+
+	params := make(map[string]string, len(cfg.Params)+2)
+	maps.Copy(params, cfg.Params)
+	params[offsetParam] = "{{.Value}}"
+	params[limitParam] = strconv.Itoa(cfg.Limit)
+
+	return NewIndexPager(logger, tracer, IndexPagerConfig{
+		BaseURL:     cfg.BaseURL,
+		URLTemplate: urlTemplate,
+		First:       cfg.First,
+		Step:        cfg.Limit,
+		Mode:        PageModeIndex,
+		Params:      params,
+	})
+}
 
 func NewIndexPager(logger *slog.Logger, tracer trace.Tracer, cfg IndexPagerConfig) (*IndexPager, error) {
 	if logger == nil {
@@ -161,3 +204,19 @@ func (p *IndexPager) Next(ctx context.Context) (string, error) {
 
 	return finalURL, nil
 }
+
+// Position returns the value of the page last returned by Next.
+func (p *IndexPager) Position() string {
+	return strconv.Itoa(p.state)
+}
+
+// Resume continues after the page whose value is position.
+func (p *IndexPager) Resume(position string) error {
+	v, err := strconv.Atoi(strings.TrimSpace(position))
+	if err != nil {
+		return fmt.Errorf("%w: %q", ErrInvalidPosition, position)
+	}
+	p.state = v
+	p.first = false
+	return nil
+}
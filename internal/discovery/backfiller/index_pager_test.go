@@ -101,3 +101,50 @@ func TestIndexPagerAlwaysAppliesParams(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "https://example.com/news?p=2", second)
 }
+
+func TestOffsetPager(t *testing.T) {
+	pager, err := backfiller.NewOffsetPager(testutils.Logger(), noop.NewTracerProvider().Tracer("test"), backfiller.OffsetPagerConfig{
+		BaseURL:     "https://www.ey.gov.tw",
+		URLTemplate: "{{.BaseURL}}/api/news",
+		Limit:       20,
+		OffsetParam: "skip",
+		Params:      map[string]string{"lang": "zh-TW"},
+	})
+	require.NoError(t, err)
+
+	first, err := pager.Next(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "https://www.ey.gov.tw/api/news?lang=zh-TW&limit=20&skip=0", first)
+
+	second, err := pager.Next(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "https://www.ey.gov.tw/api/news?lang=zh-TW&limit=20&skip=20", second)
+	require.Equal(t, "20", pager.Position())
+}
+
+func TestOffsetPager_MissingLimit(t *testing.T) {
+	_, err := backfiller.NewOffsetPager(testutils.Logger(), noop.NewTracerProvider().Tracer("test"), backfiller.OffsetPagerConfig{
+		BaseURL: "https://www.ey.gov.tw",
+	})
+	require.ErrorIs(t, err, backfiller.ErrParamMissing)
+}
+
+func TestIndexPagerResume(t *testing.T) {
+	pager, err := backfiller.NewIndexPager(testutils.Logger(), noop.NewTracerProvider().Tracer("test"), backfiller.IndexPagerConfig{
+		BaseURL:     "https://www.tpp.org.tw",
+		URLTemplate: "{{.BaseURL}}/media",
+		First:       1,
+		Step:        1,
+		Mode:        backfiller.PageModeIndex,
+		Params:      map[string]string{"page": "{{.Value}}"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, pager.Resume("7"))
+
+	next, err := pager.Next(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "https://www.tpp.org.tw/media?page=8", next)
+	require.Equal(t, "8", pager.Position())
+
+	require.ErrorIs(t, pager.Resume("page-7"), backfiller.ErrInvalidPosition)
+}
@@ -0,0 +1,139 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockBoundedPager creates a new instance of MockBoundedPager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockBoundedPager(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockBoundedPager {
+	mock := &MockBoundedPager{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockBoundedPager is an autogenerated mock type for the BoundedPager type
+type MockBoundedPager struct {
+	mock.Mock
+}
+
+type MockBoundedPager_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockBoundedPager) EXPECT() *MockBoundedPager_Expecter {
+	return &MockBoundedPager_Expecter{mock: &_m.Mock}
+}
+
+// Bound provides a mock function for the type MockBoundedPager
+func (_mock *MockBoundedPager) Bound(until time.Time) {
+	_mock.Called(until)
+	return
+}
+
+// MockBoundedPager_Bound_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Bound'
+type MockBoundedPager_Bound_Call struct {
+	*mock.Call
+}
+
+// Bound is a helper method to define mock.On call
+//   - until time.Time
+func (_e *MockBoundedPager_Expecter) Bound(until interface{}) *MockBoundedPager_Bound_Call {
+	return &MockBoundedPager_Bound_Call{Call: _e.mock.On("Bound", until)}
+}
+
+func (_c *MockBoundedPager_Bound_Call) Run(run func(until time.Time)) *MockBoundedPager_Bound_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 time.Time
+		if args[0] != nil {
+			arg0 = args[0].(time.Time)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBoundedPager_Bound_Call) Return() *MockBoundedPager_Bound_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockBoundedPager_Bound_Call) RunAndReturn(run func(until time.Time)) *MockBoundedPager_Bound_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Next provides a mock function for the type MockBoundedPager
+func (_mock *MockBoundedPager) Next(ctx context.Context) (string, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Next")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (string, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) string); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBoundedPager_Next_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Next'
+type MockBoundedPager_Next_Call struct {
+	*mock.Call
+}
+
+// Next is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockBoundedPager_Expecter) Next(ctx interface{}) *MockBoundedPager_Next_Call {
+	return &MockBoundedPager_Next_Call{Call: _e.mock.On("Next", ctx)}
+}
+
+func (_c *MockBoundedPager_Next_Call) Run(run func(ctx context.Context)) *MockBoundedPager_Next_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBoundedPager_Next_Call) Return(s string, err error) *MockBoundedPager_Next_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockBoundedPager_Next_Call) RunAndReturn(run func(ctx context.Context) (string, error)) *MockBoundedPager_Next_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,193 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockResumablePager creates a new instance of MockResumablePager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockResumablePager(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockResumablePager {
+	mock := &MockResumablePager{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockResumablePager is an autogenerated mock type for the ResumablePager type
+type MockResumablePager struct {
+	mock.Mock
+}
+
+type MockResumablePager_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockResumablePager) EXPECT() *MockResumablePager_Expecter {
+	return &MockResumablePager_Expecter{mock: &_m.Mock}
+}
+
+// Next provides a mock function for the type MockResumablePager
+func (_mock *MockResumablePager) Next(ctx context.Context) (string, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Next")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (string, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) string); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockResumablePager_Next_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Next'
+type MockResumablePager_Next_Call struct {
+	*mock.Call
+}
+
+// Next is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockResumablePager_Expecter) Next(ctx interface{}) *MockResumablePager_Next_Call {
+	return &MockResumablePager_Next_Call{Call: _e.mock.On("Next", ctx)}
+}
+
+func (_c *MockResumablePager_Next_Call) Run(run func(ctx context.Context)) *MockResumablePager_Next_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockResumablePager_Next_Call) Return(s string, err error) *MockResumablePager_Next_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockResumablePager_Next_Call) RunAndReturn(run func(ctx context.Context) (string, error)) *MockResumablePager_Next_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Position provides a mock function for the type MockResumablePager
+func (_mock *MockResumablePager) Position() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Position")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// MockResumablePager_Position_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Position'
+type MockResumablePager_Position_Call struct {
+	*mock.Call
+}
+
+// Position is a helper method to define mock.On call
+func (_e *MockResumablePager_Expecter) Position() *MockResumablePager_Position_Call {
+	return &MockResumablePager_Position_Call{Call: _e.mock.On("Position")}
+}
+
+func (_c *MockResumablePager_Position_Call) Run(run func()) *MockResumablePager_Position_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockResumablePager_Position_Call) Return(s string) *MockResumablePager_Position_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *MockResumablePager_Position_Call) RunAndReturn(run func() string) *MockResumablePager_Position_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Resume provides a mock function for the type MockResumablePager
+func (_mock *MockResumablePager) Resume(position string) error {
+	ret := _mock.Called(position)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Resume")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string) error); ok {
+		r0 = returnFunc(position)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockResumablePager_Resume_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Resume'
+type MockResumablePager_Resume_Call struct {
+	*mock.Call
+}
+
+// Resume is a helper method to define mock.On call
+//   - position string
+func (_e *MockResumablePager_Expecter) Resume(position interface{}) *MockResumablePager_Resume_Call {
+	return &MockResumablePager_Resume_Call{Call: _e.mock.On("Resume", position)}
+}
+
+func (_c *MockResumablePager_Resume_Call) Run(run func(position string)) *MockResumablePager_Resume_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockResumablePager_Resume_Call) Return(err error) *MockResumablePager_Resume_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockResumablePager_Resume_Call) RunAndReturn(run func(position string) error) *MockResumablePager_Resume_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,144 @@
+package backfiller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/ChiaYuChang/prism/internal/discovery/scout"
+	htmlscout "github.com/ChiaYuChang/prism/internal/discovery/scout/html"
+	"github.com/ChiaYuChang/prism/internal/obs"
+	"github.com/PuerkitoBio/goquery"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SpanNameNextLinkPagerNext = "discovery.backfiller.next_link_pager.next"
+
+var (
+	ErrEmptyNextLinkStartURL = fmt.Errorf("%w: start_url", ErrParamMissing)
+	ErrEmptyNextLinkSelector = fmt.Errorf("%w: selector", ErrParamMissing)
+)
+
+// NextLinkPagerConfig follows a listing's "older posts" link. Selector picks
+// the link on each page and Attr (default href) holds its URL, resolved
+// against the page.
+type NextLinkPagerConfig struct {
+	StartURL string            `json:"start_url,omitempty"`
+	Selector string            `json:"selector,omitempty"`
+	Attr     string            `json:"attr,omitempty"`
+	Headers  map[string]string `json:"headers,omitempty"`
+}
+
+// NextLinkPager pages through a listing whose page URLs cannot be computed,
+// e.g. cursor tokens in the query string. Each Next after the first fetches
+// the previous page to read its next link, so every page is fetched twice:
+// once here and once by the scout. The listing ends when a page has no next
+// link or links back to itself. Positions are page URLs.
+type NextLinkPager struct {
+	logger  *slog.Logger
+	tracer  trace.Tracer
+	client  *http.Client
+	cfg     NextLinkPagerConfig
+	current string
+	started bool
+}
+
+var _ ResumablePager = (*NextLinkPager)(nil)
+
+func NewNextLinkPager(logger *slog.Logger, tracer trace.Tracer, client *http.Client, cfg NextLinkPagerConfig) (*NextLinkPager, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("%w: logger", ErrParamMissing)
+	}
+	if tracer == nil {
+		return nil, fmt.Errorf("%w: tracer", ErrParamMissing)
+	}
+
+	cfg.StartURL = strings.TrimSpace(cfg.StartURL)
+	if cfg.StartURL == "" {
+		return nil, ErrEmptyNextLinkStartURL
+	}
+	cfg.Selector = strings.TrimSpace(cfg.Selector)
+	if cfg.Selector == "" {
+		return nil, ErrEmptyNextLinkSelector
+	}
+	cfg.Attr = cmp.Or(strings.TrimSpace(cfg.Attr), "href")
+
+	return &NextLinkPager{
+		logger: logger,
+		tracer: tracer,
+		client: client,
+		cfg:    cfg,
+	}, nil
+}
+
+func (p *NextLinkPager) Next(ctx context.Context) (string, error) {
+	if p == nil {
+		return "", nil
+	}
+	ctx, span := p.tracer.Start(ctx, SpanNameNextLinkPagerNext)
+	defer span.End()
+	traceID := obs.ExtractTraceID(ctx)
+
+	if !p.started {
+		p.started = true
+		p.current = p.cfg.StartURL
+		return p.current, nil
+	}
+	if p.current == "" {
+		return "", nil
+	}
+
+	next, err := p.nextLink(ctx, p.current)
+	if err != nil {
+		return "", err
+	}
+	if next == p.current {
+		next = ""
+	}
+
+	p.logger.DebugContext(ctx, "next link pager resolved next url",
+		slog.String("trace_id", traceID),
+		slog.String("from", p.current),
+		slog.String("url", next),
+	)
+	p.current = next
+	return next, nil
+}
+
+func (p *NextLinkPager) nextLink(ctx context.Context, pageURL string) (string, error) {
+	body, err := htmlscout.Fetch(ctx, p.client, pageURL, p.cfg.Headers)
+	if err != nil {
+		return "", fmt.Errorf("read next link: %w", err)
+	}
+	defer func() { _ = body.Close() }()
+
+	doc, err := goquery.NewDocumentFromReader(body)
+	if err != nil {
+		return "", fmt.Errorf("parse html %s: %w", pageURL, err)
+	}
+	href, ok := doc.Find(p.cfg.Selector).First().Attr(p.cfg.Attr)
+	href = strings.TrimSpace(href)
+	if !ok || href == "" {
+		return "", nil
+	}
+	return scout.ResolveURL(pageURL, href), nil
+}
+
+// Position returns the URL of the page last returned by Next.
+func (p *NextLinkPager) Position() string {
+	return p.current
+}
+
+// Resume continues with the page that position links to.
+func (p *NextLinkPager) Resume(position string) error {
+	position = strings.TrimSpace(position)
+	if position == "" {
+		return fmt.Errorf("%w: empty page url", ErrInvalidPosition)
+	}
+	p.current = position
+	p.started = true
+	return nil
+}
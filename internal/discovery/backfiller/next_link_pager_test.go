@@ -0,0 +1,97 @@
+package backfiller_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ChiaYuChang/prism/internal/discovery/backfiller"
+	"github.com/ChiaYuChang/prism/pkg/testutils"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// nextLinkPages maps a listing page to its HTML; the last page has no
+// older link.
+var nextLinkPages = map[string]string{
+	"https://www.tpp.org.tw/news":               `<ul><li>a</li></ul><a class="older" href="/news?cursor=c2">older</a>`,
+	"https://www.tpp.org.tw/news?cursor=c2":     `<ul><li>b</li></ul><a class="older" href="https://www.tpp.org.tw/news?cursor=c3">older</a>`,
+	"https://www.tpp.org.tw/news?cursor=c3":     `<ul><li>c</li></ul>`,
+	"https://www.tpp.org.tw/news?cursor=c-loop": `<a class="older" href="?cursor=c-loop">older</a>`,
+}
+
+func nextLinkClient(t *testing.T, calls *[]string) *http.Client {
+	return &http.Client{
+		Transport: testutils.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+			*calls = append(*calls, req.URL.String())
+			require.Equal(t, "prism", req.Header.Get("User-Agent"))
+			body, ok := nextLinkPages[req.URL.String()]
+			status := http.StatusOK
+			if !ok {
+				status = http.StatusNotFound
+			}
+			return &http.Response{
+				StatusCode: status,
+				Header:     make(http.Header),
+				Body:       io.NopCloser(strings.NewReader(body)),
+				Request:    req,
+			}, nil
+		}),
+	}
+}
+
+func TestNextLinkPager(t *testing.T) {
+	var calls []string
+	pager, err := backfiller.NewNextLinkPager(testutils.Logger(), noop.NewTracerProvider().Tracer("test"), nextLinkClient(t, &calls), backfiller.NextLinkPagerConfig{
+		StartURL: "https://www.tpp.org.tw/news",
+		Selector: "a.older",
+		Headers:  map[string]string{"User-Agent": "prism"},
+	})
+	require.NoError(t, err)
+
+	var got []string
+	for {
+		next, err := pager.Next(context.Background())
+		require.NoError(t, err)
+		if next == "" {
+			break
+		}
+		got = append(got, next)
+	}
+	require.Equal(t, []string{
+		"https://www.tpp.org.tw/news",
+		"https://www.tpp.org.tw/news?cursor=c2",
+		"https://www.tpp.org.tw/news?cursor=c3",
+	}, got)
+	require.Equal(t, got, calls, "each page is read once for its next link")
+}
+
+func TestNextLinkPager_ResumeAndSelfLink(t *testing.T) {
+	var calls []string
+	pager, err := backfiller.NewNextLinkPager(testutils.Logger(), noop.NewTracerProvider().Tracer("test"), nextLinkClient(t, &calls), backfiller.NextLinkPagerConfig{
+		StartURL: "https://www.tpp.org.tw/news",
+		Selector: "a.older",
+		Headers:  map[string]string{"User-Agent": "prism"},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, pager.Resume("https://www.tpp.org.tw/news?cursor=c2"))
+	next, err := pager.Next(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "https://www.tpp.org.tw/news?cursor=c3", next)
+	require.Equal(t, next, pager.Position())
+
+	require.NoError(t, pager.Resume("https://www.tpp.org.tw/news?cursor=c-loop"))
+	next, err = pager.Next(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, next, "a page linking to itself ends the listing")
+}
+
+func TestNextLinkPager_MissingSelector(t *testing.T) {
+	_, err := backfiller.NewNextLinkPager(testutils.Logger(), noop.NewTracerProvider().Tracer("test"), nil, backfiller.NextLinkPagerConfig{
+		StartURL: "https://www.tpp.org.tw/news",
+	})
+	require.ErrorIs(t, err, backfiller.ErrEmptyNextLinkSelector)
+}
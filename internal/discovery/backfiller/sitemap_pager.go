@@ -0,0 +1,133 @@
+package backfiller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	sitemapscout "github.com/ChiaYuChang/prism/internal/discovery/scout/sitemap"
+	"github.com/ChiaYuChang/prism/internal/obs"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SpanNameSitemapPagerNext = "discovery.backfiller.sitemap_pager.next"
+
+var ErrEmptySitemapIndexURL = fmt.Errorf("%w: index_url", ErrParamMissing)
+
+// SitemapPagerConfig selects the child sitemaps of IndexURL to replay.
+// Before, when set, skips sitemaps last modified after it, so a run can
+// start in the past; the lower bound is the backfill request's Until.
+type SitemapPagerConfig struct {
+	IndexURL string            `json:"index_url,omitempty"`
+	Headers  map[string]string `json:"headers,omitempty"`
+	Before   time.Time         `json:"before,omitempty"`
+}
+
+// SitemapPager pages through a sitemap index, newest child sitemap first,
+// so Backfiller stops once a page reaches past Until. Undated children come
+// last in index order. The index is read on the first Next call. Positions
+// are child sitemap URLs.
+type SitemapPager struct {
+	logger *slog.Logger
+	tracer trace.Tracer
+	client *http.Client
+	cfg    SitemapPagerConfig
+	refs   []sitemapscout.Ref
+	loaded bool
+	last   string
+	after  string
+}
+
+var _ ResumablePager = (*SitemapPager)(nil)
+
+func NewSitemapPager(logger *slog.Logger, tracer trace.Tracer, client *http.Client, cfg SitemapPagerConfig) (*SitemapPager, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("%w: logger", ErrParamMissing)
+	}
+	if tracer == nil {
+		return nil, fmt.Errorf("%w: tracer", ErrParamMissing)
+	}
+
+	cfg.IndexURL = strings.TrimSpace(cfg.IndexURL)
+	if cfg.IndexURL == "" {
+		return nil, ErrEmptySitemapIndexURL
+	}
+
+	return &SitemapPager{
+		logger: logger,
+		tracer: tracer,
+		client: client,
+		cfg:    cfg,
+	}, nil
+}
+
+func (p *SitemapPager) Next(ctx context.Context) (string, error) {
+	if p == nil {
+		return "", nil
+	}
+	ctx, span := p.tracer.Start(ctx, SpanNameSitemapPagerNext)
+	defer span.End()
+	traceID := obs.ExtractTraceID(ctx)
+
+	if !p.loaded {
+		refs, err := sitemapscout.Index(ctx, p.client, p.cfg.IndexURL, p.cfg.Headers, time.Local)
+		if err != nil {
+			return "", fmt.Errorf("read sitemap index: %w", err)
+		}
+		p.refs = sitemapscout.SelectRefs(refs, p.cfg.Before, time.Time{})
+		p.loaded = true
+		if p.after != "" {
+			i := slices.IndexFunc(p.refs, func(ref sitemapscout.Ref) bool { return ref.Loc == p.after })
+			if i >= 0 {
+				p.refs = p.refs[i+1:]
+			} else {
+				p.logger.WarnContext(ctx, "resume sitemap no longer in index; starting from the newest",
+					slog.String("trace_id", traceID),
+					slog.String("sitemap", p.after),
+				)
+			}
+		}
+
+		p.logger.InfoContext(ctx, "sitemap pager loaded index",
+			slog.String("trace_id", traceID),
+			slog.String("url", p.cfg.IndexURL),
+			slog.Int("sitemaps", len(refs)),
+			slog.Int("selected", len(p.refs)),
+		)
+	}
+
+	if len(p.refs) == 0 {
+		return "", nil
+	}
+	next := p.refs[0]
+	p.refs = p.refs[1:]
+	p.last = next.Loc
+
+	p.logger.DebugContext(ctx, "sitemap pager resolved next url",
+		slog.String("trace_id", traceID),
+		slog.String("url", next.Loc),
+		slog.Time("lastmod", next.LastMod),
+	)
+	return next.Loc, nil
+}
+
+// Position returns the child sitemap last returned by Next.
+func (p *SitemapPager) Position() string {
+	return p.last
+}
+
+// Resume skips the child sitemaps up to and including position once the
+// index is read. If position is no longer listed, paging starts over.
+func (p *SitemapPager) Resume(position string) error {
+	position = strings.TrimSpace(position)
+	if position == "" {
+		return fmt.Errorf("%w: empty sitemap url", ErrInvalidPosition)
+	}
+	p.after = position
+	p.last = position
+	return nil
+}
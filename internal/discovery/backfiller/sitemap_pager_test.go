@@ -0,0 +1,93 @@
+package backfiller_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/discovery/backfiller"
+	"github.com/ChiaYuChang/prism/pkg/testutils"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const sitemapIndex = `<?xml version="1.0" encoding="UTF-8"?>
+<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <sitemap><loc>https://news.example/sitemap-2026-01.xml</loc><lastmod>2026-01-31</lastmod></sitemap>
+  <sitemap><loc>https://news.example/sitemap-archive.xml</loc></sitemap>
+  <sitemap><loc>https://news.example/sitemap-2026-03.xml</loc><lastmod>2026-03-31</lastmod></sitemap>
+  <sitemap><loc>https://news.example/sitemap-2026-02.xml</loc><lastmod>2026-02-28</lastmod></sitemap>
+</sitemapindex>`
+
+func TestSitemapPager(t *testing.T) {
+	var calls int
+	client := &http.Client{
+		Transport: testutils.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+			calls++
+			require.Equal(t, "prism", req.Header.Get("User-Agent"))
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     make(http.Header),
+				Body:       io.NopCloser(strings.NewReader(sitemapIndex)),
+				Request:    req,
+			}, nil
+		}),
+	}
+
+	pager, err := backfiller.NewSitemapPager(testutils.Logger(), noop.NewTracerProvider().Tracer("test"), client, backfiller.SitemapPagerConfig{
+		IndexURL: "https://news.example/sitemap-index.xml",
+		Headers:  map[string]string{"User-Agent": "prism"},
+		Before:   time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local),
+	})
+	require.NoError(t, err)
+
+	var got []string
+	for {
+		next, err := pager.Next(context.Background())
+		require.NoError(t, err)
+		if next == "" {
+			break
+		}
+		got = append(got, next)
+	}
+	require.Equal(t, []string{
+		"https://news.example/sitemap-2026-02.xml",
+		"https://news.example/sitemap-2026-01.xml",
+		"https://news.example/sitemap-archive.xml",
+	}, got)
+	require.Equal(t, 1, calls, "the index is read once")
+}
+
+func TestSitemapPager_MissingIndexURL(t *testing.T) {
+	_, err := backfiller.NewSitemapPager(testutils.Logger(), noop.NewTracerProvider().Tracer("test"), nil, backfiller.SitemapPagerConfig{})
+	require.ErrorIs(t, err, backfiller.ErrEmptySitemapIndexURL)
+}
+
+func TestSitemapPager_Resume(t *testing.T) {
+	client := &http.Client{
+		Transport: testutils.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     make(http.Header),
+				Body:       io.NopCloser(strings.NewReader(sitemapIndex)),
+				Request:    req,
+			}, nil
+		}),
+	}
+
+	pager, err := backfiller.NewSitemapPager(testutils.Logger(), noop.NewTracerProvider().Tracer("test"), client, backfiller.SitemapPagerConfig{
+		IndexURL: "https://news.example/sitemap-index.xml",
+	})
+	require.NoError(t, err)
+	require.NoError(t, pager.Resume("https://news.example/sitemap-2026-02.xml"))
+
+	next, err := pager.Next(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "https://news.example/sitemap-2026-01.xml", next)
+	require.Equal(t, next, pager.Position())
+
+	require.ErrorIs(t, pager.Resume(" "), backfiller.ErrInvalidPosition)
+}
@@ -0,0 +1,217 @@
+// Package cadence picks when a recurring DIRECTORY_FETCH task runs next.
+// Runs that find new candidates shorten the interval, empty runs lengthen
+// it, and quiet hours hold it at a floor, all within [Min, Max]. The
+// decision is kept in tasks.meta so the next run continues from it.
+package cadence
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MetaKey is the tasks.meta key the last decision is stored under.
+const MetaKey = "cadence"
+
+var (
+	ErrInvalidConfig     = errors.New("invalid cadence config")
+	ErrInvalidQuietHours = errors.New("invalid quiet hours")
+)
+
+// Config bounds and tunes the interval.
+type Config struct {
+	// Min and Max bound every interval.
+	Min time.Duration
+	Max time.Duration
+	// Burst is the number of new candidates in one run that resets the
+	// interval to Min.
+	Burst int
+	// SpeedUp divides the interval after a run with new candidates; BackOff
+	// multiplies it after a run without any.
+	SpeedUp float64
+	BackOff float64
+	// QuietStart and QuietEnd are the local hours [start, end) during which
+	// the next run is at least QuietMin away. The window may wrap midnight;
+	// equal hours disable it.
+	QuietStart int
+	QuietEnd   int
+	QuietMin   time.Duration
+	Location   *time.Location
+}
+
+// DefaultConfig polls between 5 minutes and 6 hours, and at most hourly
+// from midnight to 7am Taipei time.
+func DefaultConfig() Config {
+	return Config{
+		Min:        5 * time.Minute,
+		Max:        6 * time.Hour,
+		Burst:      5,
+		SpeedUp:    2,
+		BackOff:    1.5,
+		QuietStart: 0,
+		QuietEnd:   7,
+		QuietMin:   time.Hour,
+		Location:   time.FixedZone("Asia/Taipei", 8*60*60),
+	}
+}
+
+// ParseQuietHours parses "start-end" in whole hours, e.g. "0-7" or "23-6".
+// An empty string disables quiet hours.
+func ParseQuietHours(s string) (start, end int, err error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, 0, nil
+	}
+	from, to, ok := strings.Cut(s, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("%w: %q, want start-end", ErrInvalidQuietHours, s)
+	}
+	start, err = strconv.Atoi(strings.TrimSpace(from))
+	if err != nil || start < 0 || start > 23 {
+		return 0, 0, fmt.Errorf("%w: start hour in %q", ErrInvalidQuietHours, s)
+	}
+	end, err = strconv.Atoi(strings.TrimSpace(to))
+	if err != nil || end < 0 || end > 23 {
+		return 0, 0, fmt.Errorf("%w: end hour in %q", ErrInvalidQuietHours, s)
+	}
+	return start, end, nil
+}
+
+// State is one decision, stored under MetaKey. IntervalSeconds is the
+// yield-driven interval the next decision starts from; NextRunInSeconds is
+// what was applied after quiet hours.
+type State struct {
+	IntervalSeconds  int64     `json:"interval_seconds"`
+	NextRunInSeconds int64     `json:"next_run_in_seconds"`
+	NewCandidates    int       `json:"new_candidates"`
+	IdleRuns         int       `json:"idle_runs"`
+	Reason           string    `json:"reason"`
+	DecidedAt        time.Time `json:"decided_at"`
+}
+
+// NextRunIn is the delay before the task's next run.
+func (s State) NextRunIn() time.Duration {
+	return time.Duration(s.NextRunInSeconds) * time.Second
+}
+
+// Meta encodes s as a tasks.meta patch.
+func (s State) Meta() ([]byte, error) {
+	return json.Marshal(map[string]State{MetaKey: s})
+}
+
+// FromMeta reads the last decision from a task's meta. It returns nil when
+// the task has none yet.
+func FromMeta(meta []byte) (*State, error) {
+	if len(meta) == 0 {
+		return nil, nil
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(meta, &m); err != nil {
+		return nil, fmt.Errorf("decode task meta: %w", err)
+	}
+	raw, ok := m[MetaKey]
+	if !ok || string(raw) == "null" {
+		return nil, nil
+	}
+	var s State
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, fmt.Errorf("decode %s meta: %w", MetaKey, err)
+	}
+	return &s, nil
+}
+
+// Policy turns run yields into intervals.
+type Policy struct {
+	cfg Config
+}
+
+func New(cfg Config) (*Policy, error) {
+	switch {
+	case cfg.Min <= 0:
+		return nil, fmt.Errorf("%w: min must be positive", ErrInvalidConfig)
+	case cfg.Max < cfg.Min:
+		return nil, fmt.Errorf("%w: max %s below min %s", ErrInvalidConfig, cfg.Max, cfg.Min)
+	case cfg.Burst < 1:
+		return nil, fmt.Errorf("%w: burst must be at least 1", ErrInvalidConfig)
+	case cfg.SpeedUp < 1 || cfg.BackOff < 1:
+		return nil, fmt.Errorf("%w: speed-up and back-off must be at least 1", ErrInvalidConfig)
+	case cfg.QuietStart < 0 || cfg.QuietStart > 23 || cfg.QuietEnd < 0 || cfg.QuietEnd > 23:
+		return nil, fmt.Errorf("%w: quiet hours must be within 0-23", ErrInvalidConfig)
+	case cfg.QuietMin > cfg.Max:
+		return nil, fmt.Errorf("%w: quiet min %s above max %s", ErrInvalidConfig, cfg.QuietMin, cfg.Max)
+	}
+	if cfg.Location == nil {
+		cfg.Location = time.UTC
+	}
+	return &Policy{cfg: cfg}, nil
+}
+
+// Next decides the interval after a run at now that found newCandidates.
+// prev is the previous decision, nil for a task's first adaptive run, which
+// starts from Min.
+func (p *Policy) Next(prev *State, newCandidates int, now time.Time) State {
+	interval := p.cfg.Min
+	idle := 0
+	if prev != nil {
+		if prev.IntervalSeconds > 0 {
+			interval = time.Duration(prev.IntervalSeconds) * time.Second
+		}
+		idle = prev.IdleRuns
+	}
+
+	var reason string
+	switch {
+	case newCandidates >= p.cfg.Burst:
+		interval = p.cfg.Min
+		idle = 0
+		reason = fmt.Sprintf("burst: %d new candidates, reset to min", newCandidates)
+	case newCandidates > 0:
+		interval = time.Duration(float64(interval) / p.cfg.SpeedUp)
+		idle = 0
+		reason = fmt.Sprintf("active: %d new candidates, interval / %g", newCandidates, p.cfg.SpeedUp)
+	default:
+		interval = time.Duration(float64(interval) * p.cfg.BackOff)
+		idle++
+		reason = fmt.Sprintf("idle run %d: no new candidates, interval x %g", idle, p.cfg.BackOff)
+	}
+	if interval < p.cfg.Min {
+		interval = p.cfg.Min
+		reason += fmt.Sprintf(", held at min %s", p.cfg.Min)
+	}
+	if interval > p.cfg.Max {
+		interval = p.cfg.Max
+		reason += fmt.Sprintf(", held at max %s", p.cfg.Max)
+	}
+	interval = interval.Round(time.Second)
+
+	nextRunIn := interval
+	if p.quiet(now) && nextRunIn < p.cfg.QuietMin {
+		nextRunIn = p.cfg.QuietMin
+		reason += fmt.Sprintf("; quiet hours %02d-%02d, next run in %s",
+			p.cfg.QuietStart, p.cfg.QuietEnd, p.cfg.QuietMin)
+	}
+
+	return State{
+		IntervalSeconds:  int64(interval / time.Second),
+		NextRunInSeconds: int64(nextRunIn / time.Second),
+		NewCandidates:    newCandidates,
+		IdleRuns:         idle,
+		Reason:           reason,
+		DecidedAt:        now.UTC(),
+	}
+}
+
+func (p *Policy) quiet(now time.Time) bool {
+	start, end := p.cfg.QuietStart, p.cfg.QuietEnd
+	if start == end {
+		return false
+	}
+	h := now.In(p.cfg.Location).Hour()
+	if start < end {
+		return h >= start && h < end
+	}
+	return h >= start || h < end
+}
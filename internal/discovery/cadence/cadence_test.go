@@ -0,0 +1,165 @@
+package cadence_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/discovery/cadence"
+	"github.com/stretchr/testify/require"
+)
+
+var taipei = time.FixedZone("Asia/Taipei", 8*60*60)
+
+func newPolicy(t *testing.T) *cadence.Policy {
+	t.Helper()
+	p, err := cadence.New(cadence.DefaultConfig())
+	require.NoError(t, err)
+	return p
+}
+
+func TestPolicyNext(t *testing.T) {
+	noon := time.Date(2026, 4, 4, 12, 0, 0, 0, taipei)
+	prev := &cadence.State{IntervalSeconds: int64((40 * time.Minute).Seconds()), IdleRuns: 2}
+
+	tcs := []struct {
+		name     string
+		prev     *cadence.State
+		found    int
+		interval time.Duration
+		idle     int
+		reason   string
+	}{
+		{
+			name:     "first run idle",
+			found:    0,
+			interval: 7*time.Minute + 30*time.Second,
+			idle:     1,
+			reason:   "idle run 1: no new candidates, interval x 1.5",
+		},
+		{
+			name:     "idle backs off",
+			prev:     prev,
+			found:    0,
+			interval: time.Hour,
+			idle:     3,
+			reason:   "idle run 3: no new candidates, interval x 1.5",
+		},
+		{
+			name:     "active speeds up",
+			prev:     prev,
+			found:    2,
+			interval: 20 * time.Minute,
+			reason:   "active: 2 new candidates, interval / 2",
+		},
+		{
+			name:     "burst resets to min",
+			prev:     prev,
+			found:    8,
+			interval: 5 * time.Minute,
+			reason:   "burst: 8 new candidates, reset to min",
+		},
+		{
+			name:     "held at max",
+			prev:     &cadence.State{IntervalSeconds: int64((5 * time.Hour).Seconds())},
+			found:    0,
+			interval: 6 * time.Hour,
+			idle:     1,
+			reason:   "idle run 1: no new candidates, interval x 1.5, held at max 6h0m0s",
+		},
+		{
+			name:     "held at min",
+			prev:     &cadence.State{IntervalSeconds: int64((6 * time.Minute).Seconds())},
+			found:    1,
+			interval: 5 * time.Minute,
+			reason:   "active: 1 new candidates, interval / 2, held at min 5m0s",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got := newPolicy(t).Next(tc.prev, tc.found, noon)
+			require.Equal(t, tc.interval, got.NextRunIn())
+			require.Equal(t, int64(tc.interval/time.Second), got.IntervalSeconds)
+			require.Equal(t, tc.idle, got.IdleRuns)
+			require.Equal(t, tc.found, got.NewCandidates)
+			require.Equal(t, tc.reason, got.Reason)
+			require.Equal(t, noon.UTC(), got.DecidedAt)
+		})
+	}
+}
+
+func TestPolicyNextQuietHours(t *testing.T) {
+	p := newPolicy(t)
+	night := time.Date(2026, 4, 4, 2, 0, 0, 0, taipei)
+
+	got := p.Next(nil, 10, night)
+	require.Equal(t, time.Hour, got.NextRunIn())
+	require.Equal(t, int64((5 * time.Minute).Seconds()), got.IntervalSeconds,
+		"the quiet-hour floor does not carry into the next decision")
+	require.Equal(t, "burst: 10 new candidates, reset to min; quiet hours 00-07, next run in 1h0m0s", got.Reason)
+
+	morning := time.Date(2026, 4, 4, 7, 0, 0, 0, taipei)
+	got = p.Next(&got, 10, morning)
+	require.Equal(t, 5*time.Minute, got.NextRunIn())
+}
+
+func TestPolicyNextQuietHoursWrapMidnight(t *testing.T) {
+	cfg := cadence.DefaultConfig()
+	cfg.QuietStart, cfg.QuietEnd = 23, 6
+	p, err := cadence.New(cfg)
+	require.NoError(t, err)
+
+	require.Equal(t, time.Hour, p.Next(nil, 10, time.Date(2026, 4, 4, 23, 30, 0, 0, taipei)).NextRunIn())
+	require.Equal(t, time.Hour, p.Next(nil, 10, time.Date(2026, 4, 5, 5, 59, 0, 0, taipei)).NextRunIn())
+	require.Equal(t, 5*time.Minute, p.Next(nil, 10, time.Date(2026, 4, 5, 6, 0, 0, 0, taipei)).NextRunIn())
+}
+
+func TestStateMetaRoundTrip(t *testing.T) {
+	state := newPolicy(t).Next(nil, 3, time.Date(2026, 4, 4, 12, 0, 0, 0, taipei))
+	meta, err := state.Meta()
+	require.NoError(t, err)
+
+	got, err := cadence.FromMeta(meta)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	require.Equal(t, state, *got)
+
+	got, err = cadence.FromMeta([]byte(`{"candidate_id":"x"}`))
+	require.NoError(t, err)
+	require.Nil(t, got)
+
+	got, err = cadence.FromMeta(nil)
+	require.NoError(t, err)
+	require.Nil(t, got)
+
+	_, err = cadence.FromMeta([]byte(`{"cadence":"fast"}`))
+	require.Error(t, err)
+}
+
+func TestParseQuietHours(t *testing.T) {
+	start, end, err := cadence.ParseQuietHours("23-6")
+	require.NoError(t, err)
+	require.Equal(t, 23, start)
+	require.Equal(t, 6, end)
+
+	start, end, err = cadence.ParseQuietHours("")
+	require.NoError(t, err)
+	require.Equal(t, start, end)
+
+	for _, bad := range []string{"7", "a-7", "0-24", "-1-7"} {
+		_, _, err := cadence.ParseQuietHours(bad)
+		require.ErrorIs(t, err, cadence.ErrInvalidQuietHours, bad)
+	}
+}
+
+func TestNewRejectsInvalidConfig(t *testing.T) {
+	cfg := cadence.DefaultConfig()
+	cfg.Max = time.Minute
+	_, err := cadence.New(cfg)
+	require.ErrorIs(t, err, cadence.ErrInvalidConfig)
+
+	cfg = cadence.DefaultConfig()
+	cfg.BackOff = 0.5
+	_, err = cadence.New(cfg)
+	require.ErrorIs(t, err, cadence.ErrInvalidConfig)
+}
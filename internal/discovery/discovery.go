@@ -3,6 +3,7 @@ package discovery
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/ChiaYuChang/prism/internal/model"
@@ -35,6 +36,10 @@ type BackfillRequest struct {
 	Until time.Time
 	// MaxPages is the maximum number of pages to crawl.
 	MaxPages int
+	// SourceType is the source's sources.type; PARTY when empty.
+	SourceType string
+	// Restart ignores a saved checkpoint and starts from the first page.
+	Restart bool
 }
 
 // BackfillResult summarizes one historical backfill run, tracking progress and the
@@ -44,6 +49,16 @@ type BackfillResult struct {
 	CandidatesSeen      int       `json:"candidates_seen,omitempty"`
 	CandidatesProcessed int       `json:"candidates_processed,omitempty"`
 	OldestPublishedAt   time.Time `json:"oldest_published_at,omitempty"`
+	// BatchID is the batch the candidates went to: the request's, or the
+	// checkpoint's when the run resumed.
+	BatchID uuid.UUID `json:"batch_id,omitempty"`
+	// ResumedFrom is the checkpoint position the run continued after.
+	ResumedFrom string `json:"resumed_from,omitempty"`
+	// LastPage is the pager position of the last finished page.
+	LastPage string `json:"last_page,omitempty"`
+	// Completed reports that the run reached the end of the listing or
+	// Until, rather than stopping at MaxPages.
+	Completed bool `json:"completed,omitempty"`
 }
 
 // Backfiller coordinates the execution of historical backfill runs. It iterates
@@ -90,6 +105,10 @@ type PlannerResult struct {
 	Extractions int
 	// Number of unique keyword phrases generated.
 	UniquePhrases int
+	// Number of UniquePhrases carrying search alias alternatives.
+	ExpandedPhrases int
+	// Number of phrases dropped by the per-seed query cap.
+	CappedPhrases int
 	// Number of MEDIA tasks created.
 	TasksCreated int
 }
@@ -105,5 +124,54 @@ type Planner interface {
 // step in the discovery loop, resolving short keyword groups into candidate briefs.
 type SearchClient interface {
 	// DiscoverNews executes a media API search and returns initial candidate briefs.
-	DiscoverNews(ctx context.Context, query string, site string) ([]model.Candidates, error)
+	DiscoverNews(ctx context.Context, q SearchQuery) ([]model.Candidates, error)
+}
+
+// SearchQuery is one keyword search. When the planner matched a search alias
+// group in Text, Term is the matched name and Alternatives are the group's
+// other names; each client renders them in its own boolean syntax.
+type SearchQuery struct {
+	Text         string
+	Site         string
+	Term         string
+	Alternatives []string
+}
+
+// Expanded reports whether q carries alias alternatives for Term.
+func (q SearchQuery) Expanded() bool {
+	return q.Term != "" && len(q.Alternatives) > 0 && strings.Contains(q.Text, q.Term)
+}
+
+// Variants returns Text followed by Text with Term replaced by each
+// alternative.
+func (q SearchQuery) Variants() []string {
+	out := []string{q.Text}
+	if !q.Expanded() {
+		return out
+	}
+	for _, alt := range q.Alternatives {
+		out = append(out, strings.ReplaceAll(q.Text, q.Term, alt))
+	}
+	return out
+}
+
+// OrText joins Variants with OR, parenthesising multi-word variants, e.g.
+// `(民進黨 立委) OR (民主進步黨 立委)`. It returns Text when q is not
+// expanded.
+func (q SearchQuery) OrText() string {
+	variants := q.Variants()
+	if len(variants) == 1 {
+		return variants[0]
+	}
+	for i, v := range variants {
+		variants[i] = orOperand(v)
+	}
+	return strings.Join(variants, " OR ")
+}
+
+func orOperand(s string) string {
+	if strings.ContainsAny(s, " \t") {
+		return "(" + s + ")"
+	}
+	return s
 }
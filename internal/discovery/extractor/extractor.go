@@ -12,6 +12,7 @@ import (
 	"github.com/ChiaYuChang/prism/internal/llm"
 	"github.com/ChiaYuChang/prism/internal/model"
 	"github.com/ChiaYuChang/prism/internal/obs"
+	"github.com/ChiaYuChang/prism/internal/prompt"
 	"github.com/ChiaYuChang/prism/pkg/logger"
 	"github.com/ChiaYuChang/prism/pkg/utils"
 	"go.opentelemetry.io/otel/trace"
@@ -32,7 +33,7 @@ var (
 type Extractor struct {
 	generator llm.Generator
 	model     string
-	prompt    string
+	prompts   prompt.Split
 	logger    *slog.Logger
 	tracer    trace.Tracer
 }
@@ -41,7 +42,17 @@ var _ discovery.Extractor = (*Extractor)(nil)
 
 // NewExtractor creates a new Extractor instance, binding it to a specific LLM generator,
 // model, and prompt contract.
-func NewExtractor(generator llm.Generator, logger *slog.Logger, tracer trace.Tracer, model, prompt string) (*Extractor, error) {
+func NewExtractor(generator llm.Generator, logger *slog.Logger, tracer trace.Tracer, model, systemPrompt string) (*Extractor, error) {
+	return NewSplitExtractor(generator, logger, tracer, model, prompt.Single(prompt.Prompt{
+		Version: prompt.DefaultVersion,
+		Content: systemPrompt,
+	}))
+}
+
+// NewSplitExtractor is NewExtractor with a prompt Split: each input is
+// routed to the control or the candidate prompt version, and the version
+// used is reported in ExtractionOutput.Provenance.
+func NewSplitExtractor(generator llm.Generator, logger *slog.Logger, tracer trace.Tracer, model string, prompts prompt.Split) (*Extractor, error) {
 	if generator == nil {
 		return nil, fmt.Errorf("%w: generator", ErrParamMissing)
 	}
@@ -50,7 +61,7 @@ func NewExtractor(generator llm.Generator, logger *slog.Logger, tracer trace.Tra
 		return nil, fmt.Errorf("%w: model", ErrParamMissing)
 	}
 
-	if prompt == "" {
+	if prompts.Control.Content == "" {
 		return nil, fmt.Errorf("%w: prompt", ErrParamMissing)
 	}
 
@@ -65,7 +76,7 @@ func NewExtractor(generator llm.Generator, logger *slog.Logger, tracer trace.Tra
 	return &Extractor{
 		generator: generator,
 		model:     model,
-		prompt:    prompt,
+		prompts:   prompts,
 		logger:    logger,
 		tracer:    tracer,
 	}, nil
@@ -91,8 +102,13 @@ func (e *Extractor) Extract(ctx context.Context, in *model.ExtractionInput) (*mo
 		return nil, ErrNilExtractionInput
 	}
 
+	// Route on the input itself so re-extracting the same content stays on
+	// the same arm.
+	p := e.prompts.Choose(in.Title + "\n" + in.Body)
+
 	l.DebugContext(ctx, "extractor started",
 		slog.String("model", e.model),
+		slog.String("prompt_version", p.Version),
 		slog.Int("title_len", len(in.Title)),
 		slog.Int("body_len", len(in.Body)),
 	)
@@ -104,7 +120,7 @@ func (e *Extractor) Extract(ctx context.Context, in *model.ExtractionInput) (*mo
 
 	req := &llm.GenerateRequest{
 		Model:             e.model,
-		SystemInstruction: e.prompt,
+		SystemInstruction: p.Content,
 		Prompt:            string(content),
 		Temperature:       utils.Ptr(float32(DefaultTemperature)),
 		Format:            llm.ResponseFormatJsonSchema,
@@ -120,9 +136,17 @@ func (e *Extractor) Extract(ctx context.Context, in *model.ExtractionInput) (*mo
 	if err := resp.DecodeJSONSchema(&out); err != nil {
 		return nil, fmt.Errorf("%w: %w", ErrFailedToDecodeOutput, err)
 	}
+	out.Provenance = model.ExtractionProvenance{
+		Model:         e.model,
+		PromptID:      p.ID,
+		PromptVersion: p.Version,
+		SchemaName:    req.JSONSchema.Name,
+		SchemaVersion: int32(req.JSONSchema.Version),
+	}
 
 	l.DebugContext(ctx, "extractor completed",
 		slog.String("model", e.model),
+		slog.String("prompt_version", p.Version),
 		slog.Int("entity_count", len(out.Entities)),
 		slog.Int("topic_count", len(out.Topics)),
 		slog.Int("phrase_count", len(out.Phrases)),
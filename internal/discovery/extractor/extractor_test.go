@@ -9,7 +9,9 @@ import (
 	"github.com/ChiaYuChang/prism/internal/llm"
 	llmmocks "github.com/ChiaYuChang/prism/internal/llm/mocks"
 	"github.com/ChiaYuChang/prism/internal/model"
+	"github.com/ChiaYuChang/prism/internal/prompt"
 	"github.com/ChiaYuChang/prism/pkg/testutils"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/trace/noop"
@@ -57,6 +59,42 @@ func TestExtractor_Extract_Success(t *testing.T) {
 	require.Equal(t, expectedOutput.Topics, got.Topics)
 	require.Equal(t, expectedOutput.Entities, got.Entities)
 	require.Equal(t, expectedOutput.Phrases, got.Phrases)
+	require.Equal(t, model.ExtractionProvenance{
+		Model:         "test-model",
+		PromptVersion: prompt.DefaultVersion,
+		SchemaName:    "extraction_result",
+		SchemaVersion: 1,
+	}, got.Provenance)
+}
+
+func TestExtractor_Extract_PromptSplit(t *testing.T) {
+	control := prompt.Prompt{ID: uuid.New(), Name: "extractor", Version: "v1", Content: "control-prompt"}
+	candidate := prompt.Prompt{ID: uuid.New(), Name: "extractor", Version: "v2", Content: "candidate-prompt"}
+	split, err := prompt.NewSplit(control, &candidate, 100)
+	require.NoError(t, err)
+
+	generator := llmmocks.NewMockGenerator(t)
+	ext, err := extractor.NewSplitExtractor(
+		generator,
+		testutils.Logger(),
+		noop.NewTracerProvider().Tracer("test"),
+		"test-model",
+		split,
+	)
+	require.NoError(t, err)
+
+	outputJSON, _ := json.Marshal(model.ExtractionOutput{Title: "T", Summary: "S"})
+	generator.EXPECT().Generate(mock.Anything, mock.MatchedBy(func(req *llm.GenerateRequest) bool {
+		return req.SystemInstruction == "candidate-prompt"
+	})).Return(&llm.GenerateResponse{
+		Text:       string(outputJSON),
+		JsonSchema: extractor.ExtractionResultJSONSchema,
+	}, nil).Once()
+
+	got, err := ext.Extract(context.Background(), &model.ExtractionInput{Title: "A", Body: "B"})
+	require.NoError(t, err)
+	require.Equal(t, candidate.ID, got.Provenance.PromptID)
+	require.Equal(t, "v2", got.Provenance.PromptVersion)
 }
 
 func TestExtractor_Extract_NilInput(t *testing.T) {
@@ -7,6 +7,7 @@ package mocks
 import (
 	"context"
 
+	"github.com/ChiaYuChang/prism/internal/discovery"
 	"github.com/ChiaYuChang/prism/internal/model"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -39,8 +40,8 @@ func (_m *MockSearchClient) EXPECT() *MockSearchClient_Expecter {
 }
 
 // DiscoverNews provides a mock function for the type MockSearchClient
-func (_mock *MockSearchClient) DiscoverNews(ctx context.Context, query string, site string) ([]model.Candidates, error) {
-	ret := _mock.Called(ctx, query, site)
+func (_mock *MockSearchClient) DiscoverNews(ctx context.Context, q discovery.SearchQuery) ([]model.Candidates, error) {
+	ret := _mock.Called(ctx, q)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DiscoverNews")
@@ -48,18 +49,18 @@ func (_mock *MockSearchClient) DiscoverNews(ctx context.Context, query string, s
 
 	var r0 []model.Candidates
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]model.Candidates, error)); ok {
-		return returnFunc(ctx, query, site)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, discovery.SearchQuery) ([]model.Candidates, error)); ok {
+		return returnFunc(ctx, q)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []model.Candidates); ok {
-		r0 = returnFunc(ctx, query, site)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, discovery.SearchQuery) []model.Candidates); ok {
+		r0 = returnFunc(ctx, q)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]model.Candidates)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, query, site)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, discovery.SearchQuery) error); ok {
+		r1 = returnFunc(ctx, q)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -73,30 +74,24 @@ type MockSearchClient_DiscoverNews_Call struct {
 
 // DiscoverNews is a helper method to define mock.On call
 //   - ctx context.Context
-//   - query string
-//   - site string
-func (_e *MockSearchClient_Expecter) DiscoverNews(ctx interface{}, query interface{}, site interface{}) *MockSearchClient_DiscoverNews_Call {
-	return &MockSearchClient_DiscoverNews_Call{Call: _e.mock.On("DiscoverNews", ctx, query, site)}
+//   - q discovery.SearchQuery
+func (_e *MockSearchClient_Expecter) DiscoverNews(ctx interface{}, q interface{}) *MockSearchClient_DiscoverNews_Call {
+	return &MockSearchClient_DiscoverNews_Call{Call: _e.mock.On("DiscoverNews", ctx, q)}
 }
 
-func (_c *MockSearchClient_DiscoverNews_Call) Run(run func(ctx context.Context, query string, site string)) *MockSearchClient_DiscoverNews_Call {
+func (_c *MockSearchClient_DiscoverNews_Call) Run(run func(ctx context.Context, q discovery.SearchQuery)) *MockSearchClient_DiscoverNews_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 discovery.SearchQuery
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg1 = args[1].(discovery.SearchQuery)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
@@ -107,7 +102,7 @@ func (_c *MockSearchClient_DiscoverNews_Call) Return(candidatess []model.Candida
 	return _c
 }
 
-func (_c *MockSearchClient_DiscoverNews_Call) RunAndReturn(run func(ctx context.Context, query string, site string) ([]model.Candidates, error)) *MockSearchClient_DiscoverNews_Call {
+func (_c *MockSearchClient_DiscoverNews_Call) RunAndReturn(run func(ctx context.Context, q discovery.SearchQuery) ([]model.Candidates, error)) *MockSearchClient_DiscoverNews_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,99 @@
+package planner
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ChiaYuChang/prism/internal/repo"
+)
+
+// Dictionary is the search alias dictionary: every name of a group (its
+// canonical and aliases) maps to the whole group. A name listed under
+// several canonicals belongs to the first canonical in ListAliases order.
+type Dictionary struct {
+	groups map[string][]string
+	// names holds every dictionary name, longest first, so a phrase
+	// matches "中國國民黨" before "國民黨".
+	names []string
+}
+
+// NewDictionary groups rows by canonical. Each group lists its canonical
+// first, then its aliases in row order.
+func NewDictionary(rows []repo.SearchAlias) *Dictionary {
+	byCanonical := make(map[string][]string)
+	var canonicals []string
+	for _, row := range rows {
+		if _, ok := byCanonical[row.Canonical]; !ok {
+			canonicals = append(canonicals, row.Canonical)
+			byCanonical[row.Canonical] = []string{row.Canonical}
+		}
+		byCanonical[row.Canonical] = append(byCanonical[row.Canonical], row.Alias)
+	}
+
+	d := &Dictionary{groups: make(map[string][]string)}
+	for _, canonical := range canonicals {
+		group := byCanonical[canonical]
+		for _, name := range group {
+			if name == "" {
+				continue
+			}
+			if _, taken := d.groups[name]; taken {
+				continue
+			}
+			d.groups[name] = group
+			d.names = append(d.names, name)
+		}
+	}
+	sort.SliceStable(d.names, func(i, j int) bool {
+		return len(d.names[i]) > len(d.names[j])
+	})
+	return d
+}
+
+// Len returns the number of names in the dictionary.
+func (d *Dictionary) Len() int {
+	if d == nil {
+		return 0
+	}
+	return len(d.names)
+}
+
+// Expansion is the alias match of one phrase.
+type Expansion struct {
+	// Term is the longest dictionary name found in the phrase, empty when
+	// none matched.
+	Term string
+	// Alternatives are the other names of Term's group, canonical first.
+	Alternatives []string
+	// Key is the phrase with Term replaced by its canonical, so phrases
+	// that differ only by alias plan a single task.
+	Key string
+}
+
+// Expand matches phrase against the dictionary, keeping at most
+// maxAlternatives alternatives; zero or less keeps all of them.
+func (d *Dictionary) Expand(phrase string, maxAlternatives int) Expansion {
+	out := Expansion{Key: phrase}
+	if d == nil {
+		return out
+	}
+	for _, name := range d.names {
+		if !strings.Contains(phrase, name) {
+			continue
+		}
+		group := d.groups[name]
+		out.Term = name
+		out.Key = strings.ReplaceAll(phrase, name, group[0])
+		for _, alt := range group {
+			if alt == name {
+				continue
+			}
+			if maxAlternatives > 0 && len(out.Alternatives) == maxAlternatives {
+				break
+			}
+			out.Alternatives = append(out.Alternatives, alt)
+		}
+		return out
+	}
+	return out
+}
@@ -0,0 +1,85 @@
+package planner
+
+import (
+	"testing"
+
+	"github.com/ChiaYuChang/prism/internal/repo"
+	"github.com/stretchr/testify/require"
+)
+
+func testAliasRows() []repo.SearchAlias {
+	return []repo.SearchAlias{
+		{Canonical: "中國國民黨", Alias: "國民黨", Kind: repo.AliasKindAbbreviation},
+		{Canonical: "國家通訊傳播委員會", Alias: "NCC", Kind: repo.AliasKindAbbreviation},
+		{Canonical: "國家通訊傳播委員會", Alias: "通傳會", Kind: repo.AliasKindAlias},
+		{Canonical: "民主進步黨", Alias: "民進黨", Kind: repo.AliasKindAbbreviation},
+		{Canonical: "綠營", Alias: "民進黨", Kind: repo.AliasKindAlias},
+	}
+}
+
+func TestDictionaryExpand(t *testing.T) {
+	d := NewDictionary(testAliasRows())
+	require.Equal(t, 8, d.Len(), "民進黨 is counted once")
+
+	tcs := []struct {
+		name   string
+		phrase string
+		max    int
+		want   Expansion
+	}{
+		{
+			name:   "alias expands to canonical first",
+			phrase: "NCC 裁罰",
+			want: Expansion{
+				Term:         "NCC",
+				Alternatives: []string{"國家通訊傳播委員會", "通傳會"},
+				Key:          "國家通訊傳播委員會 裁罰",
+			},
+		},
+		{
+			name:   "longest name wins",
+			phrase: "中國國民黨團 提案",
+			want: Expansion{
+				Term:         "中國國民黨",
+				Alternatives: []string{"國民黨"},
+				Key:          "中國國民黨團 提案",
+			},
+		},
+		{
+			name:   "alternatives capped",
+			phrase: "通傳會 委員",
+			max:    1,
+			want: Expansion{
+				Term:         "通傳會",
+				Alternatives: []string{"國家通訊傳播委員會"},
+				Key:          "國家通訊傳播委員會 委員",
+			},
+		},
+		{
+			name:   "name claimed by first canonical",
+			phrase: "民進黨 立委",
+			want: Expansion{
+				Term:         "民進黨",
+				Alternatives: []string{"民主進步黨"},
+				Key:          "民主進步黨 立委",
+			},
+		},
+		{
+			name:   "no match",
+			phrase: "半導體 政策",
+			want:   Expansion{Key: "半導體 政策"},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, d.Expand(tc.phrase, tc.max))
+		})
+	}
+}
+
+func TestDictionaryNilExpandsNothing(t *testing.T) {
+	var d *Dictionary
+	require.Zero(t, d.Len())
+	require.Equal(t, Expansion{Key: "NCC 裁罰"}, d.Expand("NCC 裁罰", 0))
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/ChiaYuChang/prism/internal/discovery"
 	"github.com/ChiaYuChang/prism/internal/model"
+	"github.com/ChiaYuChang/prism/internal/obs"
 	"github.com/ChiaYuChang/prism/internal/repo"
 	"github.com/google/uuid"
 	"go.opentelemetry.io/otel/trace"
@@ -26,17 +27,87 @@ var (
 	ErrNoSeedContents = errors.New("seed contents are missing")
 )
 
+// MediaTaskPayload is the payload of a planned KEYWORD_SEARCH task. Term
+// and Alternatives are set when Query names a search alias group.
+// SeedContentID is the seed release the phrase was first extracted from;
+// the candidate sink scores results against its embedding.
 type MediaTaskPayload struct {
-	Query string `json:"query"`
-	Site  string `json:"site,omitempty"`
+	Query         string    `json:"query"`
+	Site          string    `json:"site,omitempty"`
+	Term          string    `json:"term,omitempty"`
+	Alternatives  []string  `json:"alternatives,omitempty"`
+	SeedContentID uuid.UUID `json:"seed_content_id,omitzero"`
+}
+
+// hash identifies the search for active-task dedup. It leaves out
+// SeedContentID so a phrase planned again from a later release extends the
+// active task instead of adding a second one.
+func (p MediaTaskPayload) hash() (string, error) {
+	p.SeedContentID = uuid.Nil
+	b, err := json.Marshal(p)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// SearchQuery returns the search the payload asks for.
+func (p MediaTaskPayload) SearchQuery() discovery.SearchQuery {
+	return discovery.SearchQuery{
+		Text:         p.Query,
+		Site:         p.Site,
+		Term:         p.Term,
+		Alternatives: p.Alternatives,
+	}
 }
 
 type Planner struct {
-	logger    *slog.Logger
-	tracer    trace.Tracer
-	extractor discovery.Extractor
-	tasks     repo.Tasks
-	pipeline  repo.Pipeline
+	logger      *slog.Logger
+	tracer      trace.Tracer
+	extractor   discovery.Extractor
+	tasks       repo.Tasks
+	pipeline    repo.Pipeline
+	extractions repo.Analysis
+	modelID     int16
+
+	aliases           repo.Aliases
+	maxAlternatives   int
+	maxQueriesPerSeed int
+}
+
+// Option configures optional Planner behaviour.
+type Option func(*Planner)
+
+// WithExtractionStore persists every extraction to content_extractions
+// (with its topics, phrases and entities) under modelID, so prompt versions
+// can be compared on real traffic. Persistence failures are logged and do
+// not fail the plan.
+func WithExtractionStore(analysis repo.Analysis, modelID int16) Option {
+	return func(p *Planner) {
+		p.extractions = analysis
+		p.modelID = modelID
+	}
+}
+
+// WithAliases expands every phrase naming a search alias group with up to
+// maxAlternatives other names of the group (all when zero), and plans
+// phrases that differ only by alias as one task. The dictionary is read at
+// the start of each plan; a failed read plans without expansion.
+func WithAliases(aliases repo.Aliases, maxAlternatives int) Option {
+	return func(p *Planner) {
+		p.aliases = aliases
+		p.maxAlternatives = maxAlternatives
+	}
+}
+
+// WithMaxQueriesPerSeed caps the phrases one seed content adds to a plan at
+// n; zero or less leaves them uncapped. Each phrase becomes one task per
+// target, so the cap bounds a seed's search fan-out.
+func WithMaxQueriesPerSeed(n int) Option {
+	return func(p *Planner) {
+		p.maxQueriesPerSeed = n
+	}
 }
 
 var _ discovery.Planner = (*Planner)(nil)
@@ -47,6 +118,7 @@ func New(
 	extractor discovery.Extractor,
 	tasks repo.Tasks,
 	pipeline repo.Pipeline,
+	opts ...Option,
 ) (*Planner, error) {
 	if logger == nil {
 		return nil, fmt.Errorf("%w: logger", ErrParamMissing)
@@ -64,13 +136,17 @@ func New(
 		return nil, fmt.Errorf("%w: pipeline", ErrParamMissing)
 	}
 
-	return &Planner{
+	p := &Planner{
 		logger:    logger,
 		tracer:    tracer,
 		extractor: extractor,
 		tasks:     tasks,
 		pipeline:  pipeline,
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p, nil
 }
 
 func (p *Planner) Plan(ctx context.Context, req discovery.PlannerRequest) (discovery.PlannerResult, error) {
@@ -87,6 +163,8 @@ func (p *Planner) Plan(ctx context.Context, req discovery.PlannerRequest) (disco
 	if len(req.Targets) == 0 {
 		return result, ErrNoTargets
 	}
+	// Lets the LLM usage ledger attribute extraction spend to this batch.
+	ctx = obs.WithBatchID(ctx, req.BatchID)
 
 	contents, err := p.pipeline.ListContentsByBatchID(ctx, req.BatchID)
 	if err != nil {
@@ -97,7 +175,9 @@ func (p *Planner) Plan(ctx context.Context, req discovery.PlannerRequest) (disco
 	}
 	result.SeedContents = len(contents)
 
-	phrases := make(map[string]struct{})
+	dict := p.loadDictionary(ctx)
+	var queries []MediaTaskPayload
+	seen := make(map[string]struct{})
 	for _, content := range contents {
 		out, err := p.extractor.Extract(ctx, &model.ExtractionInput{
 			Title: content.Title,
@@ -107,30 +187,51 @@ func (p *Planner) Plan(ctx context.Context, req discovery.PlannerRequest) (disco
 			return result, fmt.Errorf("extract content %s: %w", content.ID, err)
 		}
 		result.Extractions++
+		p.recordExtraction(ctx, content.ID, req.TraceID, out)
+		added := 0
 		for _, phrase := range out.Phrases {
 			normalized := normalizePhrase(phrase)
 			if normalized == "" {
 				continue
 			}
-			phrases[normalized] = struct{}{}
+			exp := dict.Expand(normalized, p.maxAlternatives)
+			if _, ok := seen[exp.Key]; ok {
+				continue
+			}
+			if p.maxQueriesPerSeed > 0 && added == p.maxQueriesPerSeed {
+				result.CappedPhrases++
+				continue
+			}
+			seen[exp.Key] = struct{}{}
+			added++
+			if len(exp.Alternatives) > 0 {
+				result.ExpandedPhrases++
+			}
+			queries = append(queries, MediaTaskPayload{
+				Query:         normalized,
+				Term:          exp.Term,
+				Alternatives:  exp.Alternatives,
+				SeedContentID: content.ID,
+			})
 		}
 	}
 
-	result.UniquePhrases = len(phrases)
+	result.UniquePhrases = len(queries)
 	for _, target := range req.Targets {
 		if err := validateTarget(target); err != nil {
 			return result, err
 		}
-		for phrase := range phrases {
-			payload, err := json.Marshal(MediaTaskPayload{
-				Query: phrase,
-				Site:  strings.TrimSpace(target.Site),
-			})
+		for _, query := range queries {
+			phrase := query.Query
+			query.Site = strings.TrimSpace(target.Site)
+			payload, err := json.Marshal(query)
 			if err != nil {
 				return result, fmt.Errorf("marshal task payload for source %s: %w", target.SourceAbbr, err)
 			}
-			sum := sha256.Sum256(payload)
-			hash := hex.EncodeToString(sum[:])
+			hash, err := query.hash()
+			if err != nil {
+				return result, fmt.Errorf("hash task payload for source %s: %w", target.SourceAbbr, err)
+			}
 			if _, createErr := p.tasks.CreateTask(ctx, repo.CreateTaskParams{
 				BatchID:     req.BatchID,
 				Kind:        repo.TaskKindKeywordSearch,
@@ -167,11 +268,97 @@ func (p *Planner) Plan(ctx context.Context, req discovery.PlannerRequest) (disco
 		slog.String("batch_id", req.BatchID.String()),
 		slog.Int("seed_contents", result.SeedContents),
 		slog.Int("unique_phrases", result.UniquePhrases),
+		slog.Int("expanded_phrases", result.ExpandedPhrases),
+		slog.Int("capped_phrases", result.CappedPhrases),
 		slog.Int("tasks_created", result.TasksCreated),
 	)
 	return result, nil
 }
 
+// loadDictionary reads the search alias dictionary when WithAliases is set.
+// It returns nil, which expands nothing, otherwise or when the read fails.
+func (p *Planner) loadDictionary(ctx context.Context) *Dictionary {
+	if p.aliases == nil {
+		return nil
+	}
+	rows, err := p.aliases.ListAliases(ctx)
+	if err != nil {
+		p.logger.WarnContext(ctx, "load search aliases failed; planning without expansion",
+			slog.String("error", err.Error()))
+		return nil
+	}
+	return NewDictionary(rows)
+}
+
+// recordExtraction writes out to content_extractions when a store is
+// configured. Extractions from an unregistered prompt cannot satisfy the
+// prompt_id foreign key and are skipped.
+func (p *Planner) recordExtraction(ctx context.Context, contentID uuid.UUID, traceID string, out *model.ExtractionOutput) {
+	if p.extractions == nil {
+		return
+	}
+	prov := out.Provenance
+	if prov.PromptID == uuid.Nil {
+		p.logger.DebugContext(ctx, "extraction not recorded: prompt is not registered",
+			slog.String("content_id", contentID.String()))
+		return
+	}
+	l := p.logger.With(
+		slog.String("content_id", contentID.String()),
+		slog.String("prompt_version", prov.PromptVersion))
+
+	raw, err := json.Marshal(out)
+	if err != nil {
+		l.WarnContext(ctx, "marshal extraction failed", slog.String("error", err.Error()))
+		return
+	}
+	row, err := p.extractions.CreateContentExtraction(ctx, repo.CreateContentExtractionParams{
+		ContentID:     contentID,
+		ModelID:       p.modelID,
+		PromptID:      prov.PromptID,
+		SchemaName:    prov.SchemaName,
+		SchemaVersion: prov.SchemaVersion,
+		Title:         out.Title,
+		Summary:       out.Summary,
+		RawResult:     raw,
+		TraceID:       traceID,
+		PromptVersion: prov.PromptVersion,
+	})
+	if err != nil {
+		l.WarnContext(ctx, "record extraction failed", slog.String("error", err.Error()))
+		return
+	}
+	if err := p.extractions.ReplaceContentExtractionTopics(ctx, row.ID, out.Topics); err != nil {
+		l.WarnContext(ctx, "record extraction topics failed", slog.String("error", err.Error()))
+	}
+	if err := p.extractions.ReplaceContentExtractionPhrases(ctx, row.ID, out.Phrases); err != nil {
+		l.WarnContext(ctx, "record extraction phrases failed", slog.String("error", err.Error()))
+	}
+	for i, e := range out.Entities {
+		entity, err := p.extractions.UpsertEntity(ctx, repo.UpsertEntityParams{
+			Canonical: e.Canonical,
+			Type:      e.Type,
+		})
+		if err != nil {
+			l.WarnContext(ctx, "record extraction entity failed",
+				slog.String("canonical", e.Canonical),
+				slog.String("error", err.Error()))
+			continue
+		}
+		ordinal := int16(i)
+		if err := p.extractions.CreateContentExtractionEntity(ctx, repo.CreateContentExtractionEntityParams{
+			ExtractionID: row.ID,
+			EntityID:     entity.ID,
+			Surface:      e.Surface,
+			Ordinal:      &ordinal,
+		}); err != nil {
+			l.WarnContext(ctx, "link extraction entity failed",
+				slog.String("canonical", e.Canonical),
+				slog.String("error", err.Error()))
+		}
+	}
+}
+
 func normalizePhrase(in string) string {
 	return strings.TrimSpace(in)
 }
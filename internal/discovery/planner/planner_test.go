@@ -2,6 +2,8 @@ package planner
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"io"
 	"log/slog"
 	"testing"
@@ -90,6 +92,166 @@ func TestPlannerPlanReturnsNoSeedContents(t *testing.T) {
 	require.ErrorIs(t, err, ErrNoSeedContents)
 }
 
+func TestPlannerPlanRecordsExtractionWithPromptVersion(t *testing.T) {
+	extractor := discoverymocks.NewMockExtractor(t)
+	tasks := repomocks.NewMockTasks(t)
+	pipeline := repomocks.NewMockPipeline(t)
+	analysis := repomocks.NewMockAnalysis(t)
+
+	batchID := uuid.Must(uuid.NewV7())
+	contentID := uuid.Must(uuid.NewV7())
+	promptID := uuid.Must(uuid.NewV7())
+	extractionID := uuid.Must(uuid.NewV7())
+
+	p, err := New(testPlannerLogger(), noop.NewTracerProvider().Tracer("test"), extractor, tasks, pipeline,
+		WithExtractionStore(analysis, 3))
+	require.NoError(t, err)
+
+	pipeline.EXPECT().ListContentsByBatchID(mock.Anything, batchID).Return([]repo.Content{
+		{ID: contentID, Title: "A", Content: "Body A"},
+	}, nil)
+	extractor.EXPECT().Extract(mock.Anything, mock.Anything).Return(&model.ExtractionOutput{
+		Title:    "T",
+		Summary:  "S",
+		Topics:   []string{"topic"},
+		Phrases:  []string{"phrase"},
+		Entities: []model.ExtractionEntity{{Canonical: "賴清德", Surface: "賴總統", Type: "person"}},
+		Provenance: model.ExtractionProvenance{
+			Model:         "m",
+			PromptID:      promptID,
+			PromptVersion: "v2",
+			SchemaName:    "extraction_result",
+			SchemaVersion: 1,
+		},
+	}, nil)
+	analysis.EXPECT().CreateContentExtraction(mock.Anything, mock.MatchedBy(func(arg repo.CreateContentExtractionParams) bool {
+		return arg.ContentID == contentID &&
+			arg.ModelID == 3 &&
+			arg.PromptID == promptID &&
+			arg.PromptVersion == "v2" &&
+			arg.SchemaName == "extraction_result" &&
+			arg.TraceID == "trace-123"
+	})).Return(repo.ContentExtraction{ID: extractionID}, nil).Once()
+	analysis.EXPECT().ReplaceContentExtractionTopics(mock.Anything, extractionID, []string{"topic"}).Return(nil).Once()
+	analysis.EXPECT().ReplaceContentExtractionPhrases(mock.Anything, extractionID, []string{"phrase"}).Return(nil).Once()
+	analysis.EXPECT().UpsertEntity(mock.Anything, repo.UpsertEntityParams{Canonical: "賴清德", Type: "person"}).
+		Return(repo.Entity{ID: 7}, nil).Once()
+	analysis.EXPECT().CreateContentExtractionEntity(mock.Anything, mock.MatchedBy(func(arg repo.CreateContentExtractionEntityParams) bool {
+		return arg.ExtractionID == extractionID && arg.EntityID == 7 && arg.Surface == "賴總統"
+	})).Return(nil).Once()
+	tasks.EXPECT().CreateTask(mock.Anything, mock.Anything).Return(repo.Task{}, nil).Once()
+
+	_, err = p.Plan(context.Background(), discovery.PlannerRequest{
+		BatchID: batchID,
+		TraceID: "trace-123",
+		Targets: []discovery.PlannerTarget{{SourceAbbr: "cna", URL: "https://example.com/search"}},
+	})
+	require.NoError(t, err)
+}
+
+func TestPlannerPlanExpandsAliasesAndCapsSeedFanOut(t *testing.T) {
+	extractor := discoverymocks.NewMockExtractor(t)
+	tasks := repomocks.NewMockTasks(t)
+	pipeline := repomocks.NewMockPipeline(t)
+	aliases := repomocks.NewMockAliases(t)
+	batchID := uuid.Must(uuid.NewV7())
+
+	p, err := New(testPlannerLogger(), noop.NewTracerProvider().Tracer("test"), extractor, tasks, pipeline,
+		WithAliases(aliases, 1), WithMaxQueriesPerSeed(2))
+	require.NoError(t, err)
+
+	seedA, seedB := uuid.Must(uuid.NewV7()), uuid.Must(uuid.NewV7())
+	aliases.EXPECT().ListAliases(mock.Anything).Return(testAliasRows(), nil).Once()
+	pipeline.EXPECT().ListContentsByBatchID(mock.Anything, batchID).Return([]repo.Content{
+		{ID: seedA, Title: "A", Content: "Body A"},
+		{ID: seedB, Title: "B", Content: "Body B"},
+	}, nil)
+	extractor.EXPECT().Extract(mock.Anything, &model.ExtractionInput{Title: "A", Body: "Body A"}).Return(&model.ExtractionOutput{
+		Phrases: []string{"NCC 裁罰", "半導體 政策", "能源 轉型"},
+	}, nil)
+	// "國家通訊傳播委員會 裁罰" is "NCC 裁罰" under another name, so it
+	// neither plans a task nor counts against seed B's cap.
+	extractor.EXPECT().Extract(mock.Anything, &model.ExtractionInput{Title: "B", Body: "Body B"}).Return(&model.ExtractionOutput{
+		Phrases: []string{"國家通訊傳播委員會 裁罰", "民進黨 立委"},
+	}, nil)
+
+	var payloads []MediaTaskPayload
+	tasks.EXPECT().CreateTask(mock.Anything, mock.Anything).RunAndReturn(
+		func(_ context.Context, arg repo.CreateTaskParams) (repo.Task, error) {
+			var payload MediaTaskPayload
+			require.NoError(t, json.Unmarshal(arg.Payload, &payload))
+			payloads = append(payloads, payload)
+			return repo.Task{ID: uuid.Must(uuid.NewV7())}, nil
+		},
+	).Times(3)
+
+	result, err := p.Plan(context.Background(), discovery.PlannerRequest{
+		BatchID: batchID,
+		TraceID: "trace-123",
+		Targets: []discovery.PlannerTarget{{SourceAbbr: "yahoo", URL: "https://tw.news.yahoo.com", Site: "tw.news.yahoo.com"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, result.UniquePhrases)
+	require.Equal(t, 2, result.ExpandedPhrases)
+	require.Equal(t, 1, result.CappedPhrases)
+	require.Equal(t, 3, result.TasksCreated)
+	require.Equal(t, []MediaTaskPayload{
+		{Query: "NCC 裁罰", Site: "tw.news.yahoo.com", Term: "NCC", Alternatives: []string{"國家通訊傳播委員會"}, SeedContentID: seedA},
+		{Query: "半導體 政策", Site: "tw.news.yahoo.com", SeedContentID: seedA},
+		{Query: "民進黨 立委", Site: "tw.news.yahoo.com", Term: "民進黨", Alternatives: []string{"民主進步黨"}, SeedContentID: seedB},
+	}, payloads)
+}
+
+func TestPlannerPlanWithoutAliasesWhenDictionaryFails(t *testing.T) {
+	extractor := discoverymocks.NewMockExtractor(t)
+	tasks := repomocks.NewMockTasks(t)
+	pipeline := repomocks.NewMockPipeline(t)
+	aliases := repomocks.NewMockAliases(t)
+	batchID := uuid.Must(uuid.NewV7())
+
+	p, err := New(testPlannerLogger(), noop.NewTracerProvider().Tracer("test"), extractor, tasks, pipeline,
+		WithAliases(aliases, 0))
+	require.NoError(t, err)
+
+	seedID := uuid.MustParse("01a153a3-eb36-79f0-a5b6-06169b70c9e0")
+	aliases.EXPECT().ListAliases(mock.Anything).Return(nil, errors.New("db down")).Once()
+	pipeline.EXPECT().ListContentsByBatchID(mock.Anything, batchID).Return([]repo.Content{
+		{ID: seedID, Title: "A", Content: "Body A"},
+	}, nil)
+	extractor.EXPECT().Extract(mock.Anything, mock.Anything).Return(&model.ExtractionOutput{
+		Phrases: []string{"NCC 裁罰"},
+	}, nil)
+	tasks.EXPECT().CreateTask(mock.Anything, mock.MatchedBy(func(arg repo.CreateTaskParams) bool {
+		return string(arg.Payload) == `{"query":"NCC 裁罰","seed_content_id":"01a153a3-eb36-79f0-a5b6-06169b70c9e0"}`
+	})).Return(repo.Task{}, nil).Once()
+
+	result, err := p.Plan(context.Background(), discovery.PlannerRequest{
+		BatchID: batchID,
+		TraceID: "trace-123",
+		Targets: []discovery.PlannerTarget{{SourceAbbr: "cna", URL: "https://example.com/search"}},
+	})
+	require.NoError(t, err)
+	require.Zero(t, result.ExpandedPhrases)
+	require.Equal(t, 1, result.TasksCreated)
+}
+
+func TestMediaTaskPayloadHashIgnoresSeedContentID(t *testing.T) {
+	a := MediaTaskPayload{Query: "NCC 裁罰", Site: "tw.news.yahoo.com", SeedContentID: uuid.Must(uuid.NewV7())}
+	b := a
+	b.SeedContentID = uuid.Must(uuid.NewV7())
+
+	hashA, err := a.hash()
+	require.NoError(t, err)
+	hashB, err := b.hash()
+	require.NoError(t, err)
+	require.Equal(t, hashA, hashB)
+
+	b.Site = "news.ltn.com.tw"
+	hashB, err = b.hash()
+	require.NoError(t, err)
+	require.NotEqual(t, hashA, hashB)
+}
+
 func testPlannerLogger() *slog.Logger {
 	return slog.New(slog.NewTextHandler(io.Discard, nil))
 }
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	htmlscout "github.com/ChiaYuChang/prism/internal/discovery/scout/html"
+	jsonscout "github.com/ChiaYuChang/prism/internal/discovery/scout/json"
 	"gopkg.in/yaml.v3"
 )
 
@@ -56,10 +57,13 @@ func (c Config) WriteFile(path string) error {
 }
 
 type ScoutConfig struct {
-	HTML   HTMLSection   `yaml:"html"   json:"html"`
-	RSS    FeedSection   `yaml:"rss"    json:"rss"`
-	Atom   FeedSection   `yaml:"atom"   json:"atom"`
-	Custom CustomSection `yaml:"custom" json:"custom"`
+	HTML    HTMLSection    `yaml:"html"    json:"html"`
+	RSS     FeedSection    `yaml:"rss"     json:"rss"`
+	Atom    FeedSection    `yaml:"atom"    json:"atom"`
+	Sitemap SitemapSection `yaml:"sitemap" json:"sitemap"`
+	JSON    JSONSection    `yaml:"json"    json:"json"`
+	Social  SocialSection  `yaml:"social"  json:"social"`
+	Custom  CustomSection  `yaml:"custom"  json:"custom"`
 }
 
 type HTMLSection struct {
@@ -72,6 +76,21 @@ type FeedSection struct {
 	Scouts   []FeedScoutConfig `yaml:"scouts"   json:"scouts" validate:"dive"`
 }
 
+type SitemapSection struct {
+	Defaults FeedDefaults         `yaml:"defaults" json:"defaults"`
+	Scouts   []SitemapScoutConfig `yaml:"scouts"   json:"scouts" validate:"dive"`
+}
+
+type JSONSection struct {
+	Defaults FeedDefaults      `yaml:"defaults" json:"defaults"`
+	Scouts   []JSONScoutConfig `yaml:"scouts"   json:"scouts" validate:"dive"`
+}
+
+type SocialSection struct {
+	Defaults FeedDefaults        `yaml:"defaults" json:"defaults"`
+	Scouts   []SocialScoutConfig `yaml:"scouts"   json:"scouts" validate:"dive"`
+}
+
 type CustomSection struct {
 	Defaults CustomDefaults      `yaml:"defaults" json:"defaults"`
 	Scouts   []CustomScoutConfig `yaml:"scouts"   json:"scouts" validate:"dive"`
@@ -110,6 +129,53 @@ type FeedScoutConfig struct {
 	Headers  map[string]string `yaml:"headers"   json:"headers"`
 }
 
+// SitemapScoutConfig configures an XML sitemap / Google News sitemap scout.
+// LastmodWindow is a Go duration string such as "48h"; empty disables the
+// window. MaxSitemaps caps the child sitemaps read per index.
+type SitemapScoutConfig struct {
+	Enabled       *bool             `yaml:"enabled"        json:"enabled"`
+	Name          string            `yaml:"name"           json:"name"           validate:"required"`
+	Format        string            `yaml:"format"         json:"format"         validate:"omitempty,oneof=sitemap"`
+	SpanName      string            `yaml:"span_name"      json:"span_name"`
+	Hosts         []string          `yaml:"hosts"          json:"hosts"          validate:"required,min=1"`
+	Headers       map[string]string `yaml:"headers"        json:"headers"`
+	LastmodWindow string            `yaml:"lastmod_window" json:"lastmod_window"`
+	MaxSitemaps   int               `yaml:"max_sitemaps"   json:"max_sitemaps"   validate:"min=0"`
+}
+
+// JSONScoutConfig configures a JSON listing scout: where the items are,
+// which paths feed each candidate field and how dates are written. Paths
+// and the embedded pattern are checked by jsonscout.Config.Validate.
+type JSONScoutConfig struct {
+	Enabled     *bool                `yaml:"enabled"      json:"enabled"`
+	Name        string               `yaml:"name"         json:"name"         validate:"required"`
+	Format      string               `yaml:"format"       json:"format"       validate:"omitempty,oneof=json"`
+	SpanName    string               `yaml:"span_name"    json:"span_name"`
+	Hosts       []string             `yaml:"hosts"        json:"hosts"        validate:"required,min=1"`
+	Headers     map[string]string    `yaml:"headers"      json:"headers"`
+	Embedded    string               `yaml:"embedded"     json:"embedded"`
+	Decode      string               `yaml:"decode"       json:"decode"       validate:"omitempty,oneof=json csv"`
+	Items       string               `yaml:"items"        json:"items"`
+	Fields      jsonscout.Fields     `yaml:"fields"       json:"fields"`
+	DateLayouts []string             `yaml:"date_layouts" json:"date_layouts"`
+	EpochUnit   string               `yaml:"epoch_unit"   json:"epoch_unit"   validate:"omitempty,oneof=s ms"`
+	Location    string               `yaml:"location"     json:"location"`
+	Pagination  jsonscout.Pagination `yaml:"pagination"   json:"pagination"`
+}
+
+// SocialScoutConfig configures a SUBSCRIPTION scout for one platform. The
+// hosts are the platform's; each DIRECTORY_FETCH task URL picks a channel
+// feed.
+type SocialScoutConfig struct {
+	Enabled  *bool             `yaml:"enabled"   json:"enabled"`
+	Name     string            `yaml:"name"      json:"name"      validate:"required"`
+	Format   string            `yaml:"format"    json:"format"    validate:"omitempty,oneof=social"`
+	SpanName string            `yaml:"span_name" json:"span_name"`
+	Platform string            `yaml:"platform"  json:"platform"  validate:"required,oneof=youtube"`
+	Hosts    []string          `yaml:"hosts"     json:"hosts"     validate:"required,min=1"`
+	Headers  map[string]string `yaml:"headers"   json:"headers"`
+}
+
 type CustomScoutConfig struct {
 	Enabled  *bool             `yaml:"enabled"   json:"enabled"`
 	Name     string            `yaml:"name"      json:"name"      validate:"required"`
@@ -11,7 +11,10 @@ import (
 	atomscout "github.com/ChiaYuChang/prism/internal/discovery/scout/atom"
 	yahooscout "github.com/ChiaYuChang/prism/internal/discovery/scout/custom/yahoo"
 	htmlscout "github.com/ChiaYuChang/prism/internal/discovery/scout/html"
+	jsonscout "github.com/ChiaYuChang/prism/internal/discovery/scout/json"
 	rssscout "github.com/ChiaYuChang/prism/internal/discovery/scout/rss"
+	sitemapscout "github.com/ChiaYuChang/prism/internal/discovery/scout/sitemap"
+	socialscout "github.com/ChiaYuChang/prism/internal/discovery/scout/social"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -65,6 +68,45 @@ func BuildRegistry(repo *Repository, logger *slog.Logger, tracer trace.Tracer, c
 		}
 	}
 
+	for _, spec := range repo.sitemap {
+		if !spec.Enabled {
+			continue
+		}
+		scout, err := sitemapscout.New(logger, tracer, client, spec.Config)
+		if err != nil {
+			return nil, fmt.Errorf("build sitemap scout %s: %w", spec.Config.Name, err)
+		}
+		for _, host := range spec.Hosts {
+			scouts[host] = scout
+		}
+	}
+
+	for _, spec := range repo.json {
+		if !spec.Enabled {
+			continue
+		}
+		scout, err := jsonscout.New(logger, tracer, client, spec.Config)
+		if err != nil {
+			return nil, fmt.Errorf("build json scout %s: %w", spec.Config.Name, err)
+		}
+		for _, host := range spec.Hosts {
+			scouts[host] = scout
+		}
+	}
+
+	for _, spec := range repo.social {
+		if !spec.Enabled {
+			continue
+		}
+		scout, err := socialscout.New(logger, tracer, client, spec.Config)
+		if err != nil {
+			return nil, fmt.Errorf("build social scout %s: %w", spec.Config.Name, err)
+		}
+		for _, host := range spec.Hosts {
+			scouts[host] = scout
+		}
+	}
+
 	for _, spec := range repo.custom {
 		if !spec.Enabled {
 			continue
@@ -102,6 +144,15 @@ func BuildScoutByName(repo *Repository, name string, logger *slog.Logger, tracer
 		cfg := spec.Config.(atomscout.Config)
 		return atomscout.New(logger, tracer, client, cfg)
 	}
+	if spec, ok := repo.Sitemap(name); ok && spec.Enabled {
+		return sitemapscout.New(logger, tracer, client, spec.Config)
+	}
+	if spec, ok := repo.JSON(name); ok && spec.Enabled {
+		return jsonscout.New(logger, tracer, client, spec.Config)
+	}
+	if spec, ok := repo.Social(name); ok && spec.Enabled {
+		return socialscout.New(logger, tracer, client, spec.Config)
+	}
 	if spec, ok := repo.Custom(name); ok && spec.Enabled {
 		switch cfg := spec.Config.(type) {
 		case yahooscout.Config:
@@ -78,6 +78,11 @@ func TestBuildRegistry(t *testing.T) {
 			title: "Synthetic Yahoo Politics Item 1",
 			date:  "2026-03-29",
 		},
+		{
+			url:   "https://www.youtube.com/feeds/videos.xml?channel_id=UCsyntheticParty0000001",
+			title: "Synthetic Press Conference on Energy Policy",
+			date:  "2026-03-29",
+		},
 	}
 
 	for _, tt := range tests {
@@ -117,6 +122,9 @@ func fixtureForRequest(req *http.Request) ([]byte, string, error) {
 	case "tw.news.yahoo.com":
 		body, err := os.ReadFile(filepath.Join("..", "..", "..", "..", "testdata", "synthetic", "discovery", "scout", "yahoo_politics.html"))
 		return body, "text/html; charset=UTF-8", err
+	case "www.youtube.com":
+		body, err := os.ReadFile(filepath.Join("..", "..", "..", "..", "testdata", "synthetic", "social", "youtube", "channel_feed.xml"))
+		return body, "application/atom+xml; charset=UTF-8", err
 	default:
 		return nil, "", fmt.Errorf("unsupported host: %s", req.URL.Hostname())
 	}
@@ -0,0 +1,197 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Scout kinds, one per scouts.yaml section.
+const (
+	KindHTML    = "html"
+	KindRSS     = "rss"
+	KindAtom    = "atom"
+	KindSitemap = "sitemap"
+	KindJSON    = "json"
+	KindSocial  = "social"
+	KindCustom  = "custom"
+)
+
+var (
+	ErrUnknownScoutKind  = errors.New("unknown scout kind")
+	ErrScoutNameMismatch = errors.New("scout entry name does not match record name")
+)
+
+// Record is one scout kept outside scouts.yaml, e.g. a scout_configs row.
+// Entry is the JSON form of a single section entry (HTMLScoutConfig,
+// FeedScoutConfig, SitemapScoutConfig, JSONScoutConfig, SocialScoutConfig
+// or CustomScoutConfig, by Kind) with the section defaults already applied,
+// so a record stands on its own.
+type Record struct {
+	Name  string
+	Kind  string
+	Entry []byte
+}
+
+// Records flattens c into one Record per scout, folding each section's
+// defaults into its entries. FromRecords reverses it.
+func (c Config) Records() ([]Record, error) {
+	var out []Record
+	add := func(kind, name string, entry any) error {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return fmt.Errorf("encode %s scout %s: %w", kind, name, err)
+		}
+		out = append(out, Record{Name: strings.TrimSpace(name), Kind: kind, Entry: data})
+		return nil
+	}
+
+	html := c.Scout.HTML
+	for _, entry := range html.Scouts {
+		entry.Enabled = boolPtr(resolveEnabled(html.Defaults.Enabled, entry.Enabled))
+		entry.Headers = mergeHeaders(html.Defaults.Headers, entry.Headers)
+		if err := add(KindHTML, entry.Name, entry); err != nil {
+			return nil, err
+		}
+	}
+	feeds := []struct {
+		kind    string
+		section FeedSection
+	}{{KindRSS, c.Scout.RSS}, {KindAtom, c.Scout.Atom}}
+	for _, feed := range feeds {
+		kind, section := feed.kind, feed.section
+		for _, entry := range section.Scouts {
+			entry.Enabled = boolPtr(resolveEnabled(section.Defaults.Enabled, entry.Enabled))
+			entry.Headers = mergeHeaders(section.Defaults.Headers, entry.Headers)
+			if err := add(kind, entry.Name, entry); err != nil {
+				return nil, err
+			}
+		}
+	}
+	sitemap := c.Scout.Sitemap
+	for _, entry := range sitemap.Scouts {
+		entry.Enabled = boolPtr(resolveEnabled(sitemap.Defaults.Enabled, entry.Enabled))
+		entry.Headers = mergeHeaders(sitemap.Defaults.Headers, entry.Headers)
+		if err := add(KindSitemap, entry.Name, entry); err != nil {
+			return nil, err
+		}
+	}
+	jsonSection := c.Scout.JSON
+	for _, entry := range jsonSection.Scouts {
+		entry.Enabled = boolPtr(resolveEnabled(jsonSection.Defaults.Enabled, entry.Enabled))
+		entry.Headers = mergeHeaders(jsonSection.Defaults.Headers, entry.Headers)
+		if err := add(KindJSON, entry.Name, entry); err != nil {
+			return nil, err
+		}
+	}
+	socialSection := c.Scout.Social
+	for _, entry := range socialSection.Scouts {
+		entry.Enabled = boolPtr(resolveEnabled(socialSection.Defaults.Enabled, entry.Enabled))
+		entry.Headers = mergeHeaders(socialSection.Defaults.Headers, entry.Headers)
+		if err := add(KindSocial, entry.Name, entry); err != nil {
+			return nil, err
+		}
+	}
+	custom := c.Scout.Custom
+	for _, entry := range custom.Scouts {
+		entry.Enabled = boolPtr(resolveEnabled(custom.Defaults.Enabled, entry.Enabled))
+		if err := add(KindCustom, entry.Name, entry); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// FromRecords assembles a Config from records. Entries are decoded
+// strictly: unknown fields fail. Pass the result to New, which runs the same
+// validation as scouts.yaml, host conflicts across scouts included.
+func FromRecords(records []Record) (Config, error) {
+	cfg := Config{Version: CurrentVersion}
+	for _, rec := range records {
+		switch rec.Kind {
+		case KindHTML:
+			var entry HTMLScoutConfig
+			if err := decodeEntry(rec, &entry); err != nil {
+				return Config{}, err
+			}
+			cfg.Scout.HTML.Scouts = append(cfg.Scout.HTML.Scouts, entry)
+		case KindRSS, KindAtom:
+			var entry FeedScoutConfig
+			if err := decodeEntry(rec, &entry); err != nil {
+				return Config{}, err
+			}
+			if rec.Kind == KindRSS {
+				cfg.Scout.RSS.Scouts = append(cfg.Scout.RSS.Scouts, entry)
+			} else {
+				cfg.Scout.Atom.Scouts = append(cfg.Scout.Atom.Scouts, entry)
+			}
+		case KindSitemap:
+			var entry SitemapScoutConfig
+			if err := decodeEntry(rec, &entry); err != nil {
+				return Config{}, err
+			}
+			cfg.Scout.Sitemap.Scouts = append(cfg.Scout.Sitemap.Scouts, entry)
+		case KindJSON:
+			var entry JSONScoutConfig
+			if err := decodeEntry(rec, &entry); err != nil {
+				return Config{}, err
+			}
+			cfg.Scout.JSON.Scouts = append(cfg.Scout.JSON.Scouts, entry)
+		case KindSocial:
+			var entry SocialScoutConfig
+			if err := decodeEntry(rec, &entry); err != nil {
+				return Config{}, err
+			}
+			cfg.Scout.Social.Scouts = append(cfg.Scout.Social.Scouts, entry)
+		case KindCustom:
+			var entry CustomScoutConfig
+			if err := decodeEntry(rec, &entry); err != nil {
+				return Config{}, err
+			}
+			cfg.Scout.Custom.Scouts = append(cfg.Scout.Custom.Scouts, entry)
+		default:
+			return Config{}, fmt.Errorf("%w: %q (scout %s)", ErrUnknownScoutKind, rec.Kind, rec.Name)
+		}
+	}
+	return cfg, nil
+}
+
+// decodeEntry decodes rec.Entry into dst and checks that the entry's name
+// matches the record's; the name is the record key, so a mismatch would
+// register the scout under two names.
+func decodeEntry[T interface {
+	HTMLScoutConfig | FeedScoutConfig | SitemapScoutConfig | JSONScoutConfig |
+		SocialScoutConfig | CustomScoutConfig
+}](rec Record, dst *T) error {
+	dec := json.NewDecoder(bytes.NewReader(rec.Entry))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(dst); err != nil {
+		return fmt.Errorf("decode %s scout %s: %w", rec.Kind, rec.Name, err)
+	}
+
+	var name string
+	switch entry := any(dst).(type) {
+	case *HTMLScoutConfig:
+		name = entry.Name
+	case *FeedScoutConfig:
+		name = entry.Name
+	case *SitemapScoutConfig:
+		name = entry.Name
+	case *JSONScoutConfig:
+		name = entry.Name
+	case *SocialScoutConfig:
+		name = entry.Name
+	case *CustomScoutConfig:
+		name = entry.Name
+	}
+	if strings.TrimSpace(name) != rec.Name {
+		return fmt.Errorf("%w: %q != %q", ErrScoutNameMismatch, name, rec.Name)
+	}
+	return nil
+}
+
+func boolPtr(v bool) *bool {
+	return &v
+}
@@ -0,0 +1,78 @@
+package config_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	scoutconfig "github.com/ChiaYuChang/prism/internal/discovery/scout/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecords_RoundTrip(t *testing.T) {
+	cfg, err := scoutconfig.ReadFile(filepath.Join("..", "..", "..", "..", "configs", "worker", "discovery", "scouts.yaml"))
+	require.NoError(t, err)
+
+	records, err := cfg.Records()
+	require.NoError(t, err)
+	require.NotEmpty(t, records)
+
+	rebuilt, err := scoutconfig.FromRecords(records)
+	require.NoError(t, err)
+	repo, err := scoutconfig.New(rebuilt)
+	require.NoError(t, err)
+
+	// Section defaults travel with each record.
+	dpp, ok := repo.HTML("dpp")
+	require.True(t, ok)
+	require.True(t, dpp.Enabled)
+	require.NotEmpty(t, dpp.Config.Headers["User-Agent"])
+
+	cnaNews, ok := repo.Sitemap("cna-news")
+	require.True(t, ok)
+	require.False(t, cnaNews.Enabled)
+
+	yahoo, ok := repo.JSON("yahoo")
+	require.True(t, ok)
+	require.Equal(t, []string{"tw.news.yahoo.com"}, yahoo.Hosts)
+}
+
+func TestFromRecords_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		record  scoutconfig.Record
+		wantErr error
+	}{
+		{
+			name:    "unknown kind",
+			record:  scoutconfig.Record{Name: "x", Kind: "pdf", Entry: []byte(`{"name":"x"}`)},
+			wantErr: scoutconfig.ErrUnknownScoutKind,
+		},
+		{
+			name:    "name mismatch",
+			record:  scoutconfig.Record{Name: "x", Kind: scoutconfig.KindRSS, Entry: []byte(`{"name":"y","hosts":["a.example"]}`)},
+			wantErr: scoutconfig.ErrScoutNameMismatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := scoutconfig.FromRecords([]scoutconfig.Record{tt.record})
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+
+	_, err := scoutconfig.FromRecords([]scoutconfig.Record{
+		{Name: "x", Kind: scoutconfig.KindRSS, Entry: []byte(`{"name":"x","hostz":["a.example"]}`)},
+	})
+	require.ErrorContains(t, err, "unknown field")
+}
+
+func TestFromRecords_HostConflictFailsNew(t *testing.T) {
+	cfg, err := scoutconfig.FromRecords([]scoutconfig.Record{
+		{Name: "a", Kind: scoutconfig.KindRSS, Entry: []byte(`{"name":"a","hosts":["feeds.example"]}`)},
+		{Name: "b", Kind: scoutconfig.KindAtom, Entry: []byte(`{"name":"b","hosts":["FEEDS.example"]}`)},
+	})
+	require.NoError(t, err)
+
+	_, err = scoutconfig.New(cfg)
+	require.ErrorIs(t, err, scoutconfig.ErrDuplicateScoutHost)
+}
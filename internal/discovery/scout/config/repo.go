@@ -8,12 +8,16 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/ChiaYuChang/prism/internal/discovery"
 	atomscout "github.com/ChiaYuChang/prism/internal/discovery/scout/atom"
 	yahooscout "github.com/ChiaYuChang/prism/internal/discovery/scout/custom/yahoo"
 	htmlscout "github.com/ChiaYuChang/prism/internal/discovery/scout/html"
+	jsonscout "github.com/ChiaYuChang/prism/internal/discovery/scout/json"
 	rssscout "github.com/ChiaYuChang/prism/internal/discovery/scout/rss"
+	sitemapscout "github.com/ChiaYuChang/prism/internal/discovery/scout/sitemap"
+	socialscout "github.com/ChiaYuChang/prism/internal/discovery/scout/social"
 	"github.com/ChiaYuChang/prism/internal/infra"
 	"gopkg.in/yaml.v3"
 )
@@ -26,12 +30,15 @@ var (
 )
 
 type Repository struct {
-	cfg    Config
-	html   map[string]HTMLSpec
-	rss    map[string]FeedSpec
-	atom   map[string]FeedSpec
-	custom map[string]CustomSpec
-	byHost map[string]string
+	cfg     Config
+	html    map[string]HTMLSpec
+	rss     map[string]FeedSpec
+	atom    map[string]FeedSpec
+	sitemap map[string]SitemapSpec
+	json    map[string]JSONSpec
+	social  map[string]SocialSpec
+	custom  map[string]CustomSpec
+	byHost  map[string]string
 }
 
 type HTMLSpec struct {
@@ -46,6 +53,24 @@ type FeedSpec struct {
 	Config  any
 }
 
+type SitemapSpec struct {
+	Enabled bool
+	Hosts   []string
+	Config  sitemapscout.Config
+}
+
+type JSONSpec struct {
+	Enabled bool
+	Hosts   []string
+	Config  jsonscout.Config
+}
+
+type SocialSpec struct {
+	Enabled bool
+	Hosts   []string
+	Config  socialscout.Config
+}
+
 type CustomSpec struct {
 	Enabled bool
 	Hosts   []string
@@ -100,12 +125,15 @@ func New(cfg Config) (*Repository, error) {
 	}
 
 	repo := &Repository{
-		cfg:    cfg,
-		html:   make(map[string]HTMLSpec),
-		rss:    make(map[string]FeedSpec),
-		atom:   make(map[string]FeedSpec),
-		custom: make(map[string]CustomSpec),
-		byHost: make(map[string]string),
+		cfg:     cfg,
+		html:    make(map[string]HTMLSpec),
+		rss:     make(map[string]FeedSpec),
+		atom:    make(map[string]FeedSpec),
+		sitemap: make(map[string]SitemapSpec),
+		json:    make(map[string]JSONSpec),
+		social:  make(map[string]SocialSpec),
+		custom:  make(map[string]CustomSpec),
+		byHost:  make(map[string]string),
 	}
 
 	if err := repo.loadHTML(cfg.Scout.HTML); err != nil {
@@ -117,6 +145,15 @@ func New(cfg Config) (*Repository, error) {
 	if err := repo.loadAtom(cfg.Scout.Atom); err != nil {
 		return nil, err
 	}
+	if err := repo.loadSitemap(cfg.Scout.Sitemap); err != nil {
+		return nil, err
+	}
+	if err := repo.loadJSON(cfg.Scout.JSON); err != nil {
+		return nil, err
+	}
+	if err := repo.loadSocial(cfg.Scout.Social); err != nil {
+		return nil, err
+	}
 	if err := repo.loadCustom(cfg.Scout.Custom); err != nil {
 		return nil, err
 	}
@@ -143,6 +180,21 @@ func (r *Repository) Atom(name string) (FeedSpec, bool) {
 	return spec, ok
 }
 
+func (r *Repository) Sitemap(name string) (SitemapSpec, bool) {
+	spec, ok := r.sitemap[name]
+	return spec, ok
+}
+
+func (r *Repository) JSON(name string) (JSONSpec, bool) {
+	spec, ok := r.json[name]
+	return spec, ok
+}
+
+func (r *Repository) Social(name string) (SocialSpec, bool) {
+	spec, ok := r.social[name]
+	return spec, ok
+}
+
 func (r *Repository) Custom(name string) (CustomSpec, bool) {
 	spec, ok := r.custom[name]
 	return spec, ok
@@ -246,6 +298,124 @@ func (r *Repository) loadAtom(section FeedSection) error {
 	return nil
 }
 
+func (r *Repository) loadSitemap(section SitemapSection) error {
+	for _, entry := range section.Scouts {
+		enabled := resolveEnabled(section.Defaults.Enabled, entry.Enabled)
+		var window time.Duration
+		if raw := strings.TrimSpace(entry.LastmodWindow); raw != "" {
+			d, err := time.ParseDuration(raw)
+			if err != nil {
+				return fmt.Errorf("%s: lastmod_window: %w", strings.TrimSpace(entry.Name), err)
+			}
+			window = d
+		}
+		cfg := sitemapscout.Config{
+			Name:          strings.TrimSpace(entry.Name),
+			Format:        firstNonEmpty(entry.Format, "sitemap"),
+			SpanName:      firstNonEmpty(entry.SpanName, discovery.ScoutDiscoverSpanName("sitemap", entry.Name)),
+			Headers:       mergeHeaders(section.Defaults.Headers, entry.Headers),
+			LastmodWindow: window,
+			MaxSitemaps:   entry.MaxSitemaps,
+		}.Normalize()
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+
+		hosts, err := normalizeHosts(entry.Hosts)
+		if err != nil {
+			return fmt.Errorf("%s: %w", cfg.Name, err)
+		}
+		if enabled {
+			if err := r.registerHosts("sitemap", cfg.Name, hosts); err != nil {
+				return err
+			}
+		}
+
+		r.sitemap[cfg.Name] = SitemapSpec{
+			Enabled: enabled,
+			Hosts:   hosts,
+			Config:  cfg,
+		}
+	}
+
+	return nil
+}
+
+func (r *Repository) loadJSON(section JSONSection) error {
+	for _, entry := range section.Scouts {
+		enabled := resolveEnabled(section.Defaults.Enabled, entry.Enabled)
+		cfg := jsonscout.Config{
+			Name:        strings.TrimSpace(entry.Name),
+			Format:      firstNonEmpty(entry.Format, "json"),
+			SpanName:    firstNonEmpty(entry.SpanName, discovery.ScoutDiscoverSpanName("json", entry.Name)),
+			Headers:     mergeHeaders(section.Defaults.Headers, entry.Headers),
+			Embedded:    entry.Embedded,
+			Decode:      entry.Decode,
+			Items:       entry.Items,
+			Fields:      entry.Fields,
+			DateLayouts: entry.DateLayouts,
+			EpochUnit:   entry.EpochUnit,
+			Location:    entry.Location,
+			Pagination:  entry.Pagination,
+		}.Normalize()
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+
+		hosts, err := normalizeHosts(entry.Hosts)
+		if err != nil {
+			return fmt.Errorf("%s: %w", cfg.Name, err)
+		}
+		if enabled {
+			if err := r.registerHosts("json", cfg.Name, hosts); err != nil {
+				return err
+			}
+		}
+
+		r.json[cfg.Name] = JSONSpec{
+			Enabled: enabled,
+			Hosts:   hosts,
+			Config:  cfg,
+		}
+	}
+
+	return nil
+}
+
+func (r *Repository) loadSocial(section SocialSection) error {
+	for _, entry := range section.Scouts {
+		enabled := resolveEnabled(section.Defaults.Enabled, entry.Enabled)
+		cfg := socialscout.Config{
+			Name:     strings.TrimSpace(entry.Name),
+			Format:   firstNonEmpty(entry.Format, "social"),
+			SpanName: firstNonEmpty(entry.SpanName, discovery.ScoutDiscoverSpanName("social", entry.Name)),
+			Platform: entry.Platform,
+			Headers:  mergeHeaders(section.Defaults.Headers, entry.Headers),
+		}.Normalize()
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+
+		hosts, err := normalizeHosts(entry.Hosts)
+		if err != nil {
+			return fmt.Errorf("%s: %w", cfg.Name, err)
+		}
+		if enabled {
+			if err := r.registerHosts("social", cfg.Name, hosts); err != nil {
+				return err
+			}
+		}
+
+		r.social[cfg.Name] = SocialSpec{
+			Enabled: enabled,
+			Hosts:   hosts,
+			Config:  cfg,
+		}
+	}
+
+	return nil
+}
+
 func (r *Repository) loadCustom(section CustomSection) error {
 	for _, entry := range section.Scouts {
 		enabled := resolveEnabled(section.Defaults.Enabled, entry.Enabled)
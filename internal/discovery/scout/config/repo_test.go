@@ -3,8 +3,11 @@ package config_test
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	scoutconfig "github.com/ChiaYuChang/prism/internal/discovery/scout/config"
+	jsonscout "github.com/ChiaYuChang/prism/internal/discovery/scout/json"
+	sitemapscout "github.com/ChiaYuChang/prism/internal/discovery/scout/sitemap"
 	"github.com/stretchr/testify/require"
 )
 
@@ -42,7 +45,96 @@ func TestLoad(t *testing.T) {
 	require.True(t, ok)
 	require.Equal(t, []string{"www.kmt.org.tw"}, kmt.Hosts)
 
-	yahoo, ok := repo.Custom("yahoo")
+	cnaNews, ok := repo.Sitemap("cna-news")
 	require.True(t, ok)
+	require.False(t, cnaNews.Enabled)
+	require.Equal(t, 48*time.Hour, cnaNews.Config.LastmodWindow)
+	require.Equal(t, 3, cnaNews.Config.MaxSitemaps)
+
+	yahoo, ok := repo.JSON("yahoo")
+	require.True(t, ok)
+	require.True(t, yahoo.Enabled)
 	require.Equal(t, []string{"tw.news.yahoo.com"}, yahoo.Hosts)
+	require.Equal(t, "json", yahoo.Config.Format)
+	require.Equal(t, "ms", yahoo.Config.EpochUnit)
+	require.NotEmpty(t, yahoo.Config.Headers["User-Agent"], "section defaults reuse the html headers")
+
+	lyBills, ok := repo.JSON("ly-bills")
+	require.True(t, ok)
+	require.False(t, lyBills.Enabled)
+	require.Equal(t, jsonscout.DecodeCSV, lyBills.Config.Decode)
+	require.Equal(t, "https://ppg.ly.gov.tw/ppg/bills/{}/details", lyBills.Config.Fields.LinkTemplate)
+
+	eyNews, ok := repo.JSON("ey-news")
+	require.True(t, ok)
+	require.False(t, eyNews.Enabled)
+	require.Equal(t, jsonscout.DecodeJSON, eyNews.Config.Decode)
+
+	youtube, ok := repo.Social("youtube")
+	require.True(t, ok)
+	require.True(t, youtube.Enabled)
+	require.Equal(t, "youtube", youtube.Config.Platform)
+	require.Equal(t, []string{"www.youtube.com"}, youtube.Hosts)
+}
+
+func TestLoad_SitemapScout(t *testing.T) {
+	cfg := scoutconfig.Config{
+		Version: scoutconfig.CurrentVersion,
+		Scout: scoutconfig.ScoutConfig{
+			Sitemap: scoutconfig.SitemapSection{
+				Defaults: scoutconfig.FeedDefaults{Headers: map[string]string{"User-Agent": "prism"}},
+				Scouts: []scoutconfig.SitemapScoutConfig{
+					{Name: "news", Hosts: []string{"News.Example"}},
+				},
+			},
+		},
+	}
+	repo, err := scoutconfig.New(cfg)
+	require.NoError(t, err)
+
+	news, ok := repo.Sitemap("news")
+	require.True(t, ok)
+	require.True(t, news.Enabled)
+	require.Equal(t, []string{"news.example"}, news.Hosts)
+	require.Equal(t, "sitemap", news.Config.Format)
+	require.Equal(t, "prism", news.Config.Headers["User-Agent"])
+	require.Equal(t, sitemapscout.DefaultMaxSitemaps, news.Config.MaxSitemaps)
+
+	cfg.Scout.Sitemap.Scouts[0].LastmodWindow = "two days"
+	_, err = scoutconfig.New(cfg)
+	require.ErrorContains(t, err, "lastmod_window")
+}
+
+func TestLoad_JSONScout(t *testing.T) {
+	cfg := scoutconfig.Config{
+		Version: scoutconfig.CurrentVersion,
+		Scout: scoutconfig.ScoutConfig{
+			JSON: scoutconfig.JSONSection{
+				Defaults: scoutconfig.FeedDefaults{Headers: map[string]string{"Accept": "application/json"}},
+				Scouts: []scoutconfig.JSONScoutConfig{
+					{
+						Name:   "api",
+						Hosts:  []string{"API.Example"},
+						Items:  "$.data.items",
+						Fields: jsonscout.Fields{Link: "$.url", Title: "$.title"},
+					},
+				},
+			},
+		},
+	}
+	repo, err := scoutconfig.New(cfg)
+	require.NoError(t, err)
+
+	api, ok := repo.JSON("api")
+	require.True(t, ok)
+	require.True(t, api.Enabled)
+	require.Equal(t, []string{"api.example"}, api.Hosts)
+	require.Equal(t, "json", api.Config.Format)
+	require.Equal(t, "discovery.scout.json.api.discover", api.Config.SpanName)
+	require.Equal(t, "application/json", api.Config.Headers["Accept"])
+	require.Equal(t, 1, api.Config.Pagination.MaxPages)
+
+	cfg.Scout.JSON.Scouts[0].Fields.Title = "title"
+	_, err = scoutconfig.New(cfg)
+	require.ErrorIs(t, err, jsonscout.ErrInvalidPath)
 }
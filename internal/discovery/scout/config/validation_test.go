@@ -101,6 +101,24 @@ func TestConfig_Validation(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "unsupported social platform",
+			cfg: config.Config{
+				Version: 1,
+				Scout: config.ScoutConfig{
+					Social: config.SocialSection{
+						Scouts: []config.SocialScoutConfig{
+							{
+								Name:     "threads",
+								Platform: "threads",
+								Hosts:    []string{"www.threads.net"},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
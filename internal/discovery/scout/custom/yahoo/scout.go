@@ -1,3 +1,6 @@
+// Package yahoo is the hand-written Yahoo listing scout. scouts.yaml now
+// covers Yahoo with a json scout; this package stays so existing custom/yahoo
+// catalog records keep loading until they are migrated.
 package yahoo
 
 import (
@@ -0,0 +1,132 @@
+package jsonscout
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidPath = errors.New("invalid json path")
+
+// path is a compiled JSONPath expression. The supported subset covers what
+// listing endpoints need: the root $, dot and bracket children ($.a.b,
+// $['a b']), array indexes ($.items[0], $.items[-1]) and wildcards
+// ($.items[*], $.data.*). Filters, slices and recursive descent are not
+// supported. An object wildcard visits keys in sorted order so results are
+// stable.
+type path []step
+
+type step struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+func compilePath(expr string) (path, error) {
+	expr = strings.TrimSpace(expr)
+	if !strings.HasPrefix(expr, "$") {
+		return nil, fmt.Errorf("%w: %q must start with $", ErrInvalidPath, expr)
+	}
+
+	var out path
+	rest := expr[1:]
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			key := rest[:end]
+			rest = rest[end:]
+			switch key {
+			case "":
+				return nil, fmt.Errorf("%w: %q has an empty segment", ErrInvalidPath, expr)
+			case "*":
+				out = append(out, step{wildcard: true})
+			default:
+				out = append(out, step{key: key})
+			}
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("%w: %q has an unclosed bracket", ErrInvalidPath, expr)
+			}
+			inner := strings.TrimSpace(rest[1:end])
+			rest = rest[end+1:]
+			switch {
+			case inner == "*":
+				out = append(out, step{wildcard: true})
+			case len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0]:
+				out = append(out, step{key: inner[1 : len(inner)-1]})
+			default:
+				n, err := strconv.Atoi(inner)
+				if err != nil {
+					return nil, fmt.Errorf("%w: %q has an unsupported selector [%s]", ErrInvalidPath, expr, inner)
+				}
+				out = append(out, step{index: n, isIndex: true})
+			}
+		default:
+			return nil, fmt.Errorf("%w: %q has an unexpected %q", ErrInvalidPath, expr, rest[0])
+		}
+	}
+	return out, nil
+}
+
+// eval returns every value p selects in doc. Missing keys, out-of-range
+// indexes and type mismatches select nothing rather than fail, since
+// listing items routinely omit optional fields.
+func (p path) eval(doc any) []any {
+	current := []any{doc}
+	for _, st := range p {
+		var next []any
+		for _, v := range current {
+			switch node := v.(type) {
+			case map[string]any:
+				switch {
+				case st.wildcard:
+					keys := make([]string, 0, len(node))
+					for key := range node {
+						keys = append(keys, key)
+					}
+					sort.Strings(keys)
+					for _, key := range keys {
+						next = append(next, node[key])
+					}
+				case !st.isIndex:
+					if child, ok := node[st.key]; ok {
+						next = append(next, child)
+					}
+				}
+			case []any:
+				switch {
+				case st.wildcard:
+					next = append(next, node...)
+				case st.isIndex:
+					i := st.index
+					if i < 0 {
+						i += len(node)
+					}
+					if i >= 0 && i < len(node) {
+						next = append(next, node[i])
+					}
+				}
+			}
+		}
+		current = next
+	}
+	return current
+}
+
+// first returns the first value p selects in doc.
+func (p path) first(doc any) (any, bool) {
+	got := p.eval(doc)
+	if len(got) == 0 || got[0] == nil {
+		return nil, false
+	}
+	return got[0], true
+}
@@ -0,0 +1,49 @@
+package jsonscout
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPathEval(t *testing.T) {
+	var doc any
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"data": {"items": [{"id": "a", "tags": ["x", "y"]}, {"id": "b"}]},
+		"meta": {"next page": 2},
+		"groups": {"b": {"id": "g2"}, "a": {"id": "g1"}}
+	}`), &doc))
+
+	tests := []struct {
+		expr string
+		want []any
+	}{
+		{expr: "$", want: []any{doc}},
+		{expr: "$.data.items[0].id", want: []any{"a"}},
+		{expr: "$.data.items[-1].id", want: []any{"b"}},
+		{expr: "$.data.items[*].id", want: []any{"a", "b"}},
+		{expr: "$['meta']['next page']", want: []any{float64(2)}},
+		{expr: `$["data"].items[0].tags[1]`, want: []any{"y"}},
+		{expr: "$.groups.*.id", want: []any{"g1", "g2"}},
+		{expr: "$.data.items[5].id", want: nil},
+		{expr: "$.data.missing", want: nil},
+		{expr: "$.data.items.id", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			p, err := compilePath(tt.expr)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, p.eval(doc))
+		})
+	}
+}
+
+func TestCompilePath_Errors(t *testing.T) {
+	for _, expr := range []string{"", "data", "$..items", "$.items[", "$.items[1:2]", "$.items[?(@.id)]", "$x"} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := compilePath(expr)
+			require.ErrorIs(t, err, ErrInvalidPath)
+		})
+	}
+}
@@ -0,0 +1,571 @@
+package jsonscout
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/discovery"
+	rootscout "github.com/ChiaYuChang/prism/internal/discovery/scout"
+	htmlscout "github.com/ChiaYuChang/prism/internal/discovery/scout/html"
+	"github.com/ChiaYuChang/prism/internal/model"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Epoch units accepted by Config.EpochUnit.
+const (
+	EpochSeconds      = "s"
+	EpochMilliseconds = "ms"
+)
+
+// Body encodings accepted by Config.Decode.
+const (
+	DecodeJSON = "json"
+	DecodeCSV  = "csv"
+)
+
+// linkPlaceholder marks where Fields.LinkTemplate takes the link value.
+const linkPlaceholder = "{}"
+
+// Config describes one JSON listing scout. Items selects the listing items
+// from the document; the Fields paths are then evaluated against each item,
+// where $ is the item itself. Paths use the JSONPath subset documented on
+// path.
+type Config struct {
+	Name     string            `yaml:"name"      json:"name"`
+	Format   string            `yaml:"format"    json:"format"`
+	SpanName string            `yaml:"span_name" json:"span_name"`
+	Headers  map[string]string `yaml:"headers"   json:"headers"`
+
+	// Embedded, when set, is a regular expression whose first capture
+	// group holds the JSON document, for listings that ship their data in
+	// an HTML page (a hydration script, a JS assignment) instead of an API.
+	Embedded string `yaml:"embedded" json:"embedded"`
+
+	// Decode is the body encoding: "json" (default) or "csv". A CSV body
+	// becomes an array of objects keyed by the header row, so open-data
+	// exports are mapped with the same paths ($['欄位'] for CJK headers).
+	Decode string `yaml:"decode" json:"decode"`
+
+	// Items selects the listing items; "$" when empty. A path that
+	// selects a single array is expanded to its elements.
+	Items string `yaml:"items" json:"items"`
+
+	Fields Fields `yaml:"fields" json:"fields"`
+
+	// DateLayouts are tried in order on string dates; RFC 3339 when empty.
+	// Numeric dates, and digit-only strings when EpochUnit is set, are
+	// read as Unix time in EpochUnit (seconds when empty).
+	DateLayouts []string `yaml:"date_layouts" json:"date_layouts"`
+	EpochUnit   string   `yaml:"epoch_unit"   json:"epoch_unit"`
+
+	// Location is the IANA zone for dates without an offset; time.Local
+	// when empty.
+	Location string `yaml:"location" json:"location"`
+
+	Pagination Pagination `yaml:"pagination" json:"pagination"`
+}
+
+// Fields maps candidate fields to paths relative to one item. Link and
+// Title are required; items missing either are skipped. Metadata maps
+// extra candidate metadata keys to paths. LinkTemplate, when set, builds
+// the link from an identifier: its "{}" is replaced by the path-escaped
+// Link value, for records that carry an ID but no page URL.
+type Fields struct {
+	Link         string            `yaml:"link"          json:"link"`
+	LinkTemplate string            `yaml:"link_template" json:"link_template"`
+	Title        string            `yaml:"title"         json:"title"`
+	Date         string            `yaml:"date"          json:"date"`
+	Description  string            `yaml:"description"   json:"description"`
+	Metadata     map[string]string `yaml:"metadata"      json:"metadata"`
+}
+
+// Pagination makes one Discover call read up to MaxPages pages by setting
+// the Param query parameter to Start, Start+Step, and so on. Reading stops
+// early at a page with no new items. An empty Param, or a rawURL that
+// already carries Param (a backfill pager walking pages itself), reads
+// rawURL once.
+type Pagination struct {
+	Param    string `yaml:"param"     json:"param"`
+	Start    int    `yaml:"start"     json:"start"`
+	Step     int    `yaml:"step"      json:"step"`
+	MaxPages int    `yaml:"max_pages" json:"max_pages"`
+}
+
+type Scout struct {
+	logger   *slog.Logger
+	tracer   trace.Tracer
+	client   *http.Client
+	now      func() time.Time
+	loc      *time.Location
+	embedded *regexp.Regexp
+	items    path
+	fields   compiledFields
+	cfg      Config
+}
+
+type compiledFields struct {
+	link        path
+	title       path
+	date        path
+	description path
+	metadata    map[string]path
+}
+
+var _ discovery.Scout = (*Scout)(nil)
+
+func New(logger *slog.Logger, tracer trace.Tracer, client *http.Client, cfg Config) (*Scout, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("%w: logger", rootscout.ErrParamMissing)
+	}
+	if tracer == nil {
+		return nil, fmt.Errorf("%w: tracer", rootscout.ErrParamMissing)
+	}
+
+	cfg = cfg.Normalize()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Validate has already compiled everything below, so the errors are
+	// unreachable.
+	s := &Scout{
+		logger: logger,
+		tracer: tracer,
+		client: client,
+		now:    time.Now,
+		loc:    time.Local,
+		cfg:    cfg,
+	}
+	if cfg.Location != "" {
+		s.loc, _ = time.LoadLocation(cfg.Location)
+	}
+	if cfg.Embedded != "" {
+		s.embedded = regexp.MustCompile(cfg.Embedded)
+	}
+	s.items, _ = compilePath(cfg.Items)
+	s.fields.link, _ = compilePath(cfg.Fields.Link)
+	s.fields.title, _ = compilePath(cfg.Fields.Title)
+	if cfg.Fields.Date != "" {
+		s.fields.date, _ = compilePath(cfg.Fields.Date)
+	}
+	if cfg.Fields.Description != "" {
+		s.fields.description, _ = compilePath(cfg.Fields.Description)
+	}
+	s.fields.metadata = make(map[string]path, len(cfg.Fields.Metadata))
+	for key, expr := range cfg.Fields.Metadata {
+		s.fields.metadata[key], _ = compilePath(expr)
+	}
+	return s, nil
+}
+
+func (s *Scout) Discover(ctx context.Context, rawURL string) ([]model.Candidates, error) {
+	ctx, span := s.tracer.Start(ctx, s.cfg.SpanName)
+	defer span.End()
+
+	pages, err := s.pageURLs(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{})
+	var out []model.Candidates
+	for i, pageURL := range pages {
+		got, err := s.page(ctx, pageURL)
+		if err != nil {
+			if i == 0 || ctx.Err() != nil {
+				return nil, err
+			}
+			s.logger.WarnContext(ctx, "failed to read json scout page, stopped paging",
+				slog.String("url", pageURL),
+				slog.String("scout", s.cfg.Name),
+				slog.String("error", err.Error()))
+			break
+		}
+
+		added := 0
+		for _, candidate := range got {
+			if _, dup := seen[candidate.URL]; dup {
+				continue
+			}
+			seen[candidate.URL] = struct{}{}
+			out = append(out, candidate)
+			added++
+		}
+		if added == 0 {
+			break
+		}
+	}
+
+	if len(out) == 0 {
+		return nil, fmt.Errorf("%s json scout: %w", s.cfg.Name, rootscout.ErrNoCandidatesFound)
+	}
+
+	s.logger.DebugContext(ctx, "json scout discovered candidates",
+		slog.String("url", rawURL),
+		slog.String("scout", s.cfg.Name),
+		slog.String("span_name", s.cfg.SpanName),
+		slog.Int("pages", len(pages)),
+		slog.Int("count", len(out)),
+	)
+	return out, nil
+}
+
+func (s *Scout) pageURLs(rawURL string) ([]string, error) {
+	p := s.cfg.Pagination
+	if p.Param == "" {
+		return []string{rawURL}, nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse url %s: %w", rawURL, err)
+	}
+	if u.Query().Has(p.Param) {
+		return []string{rawURL}, nil
+	}
+	out := make([]string, 0, p.MaxPages)
+	for i := 0; i < p.MaxPages; i++ {
+		q := u.Query()
+		q.Set(p.Param, strconv.Itoa(p.Start+i*p.Step))
+		u.RawQuery = q.Encode()
+		out = append(out, u.String())
+	}
+	return out, nil
+}
+
+func (s *Scout) page(ctx context.Context, pageURL string) ([]model.Candidates, error) {
+	body, err := htmlscout.Fetch(ctx, s.client, pageURL, s.cfg.Headers)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := body.Close(); err != nil {
+			s.logger.ErrorContext(ctx, "failed to close response body", slog.String("url", pageURL), slog.String("error", err.Error()))
+		}
+	}()
+
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("read response body: %w", err)
+	}
+
+	if s.embedded != nil {
+		match := s.embedded.FindSubmatch(content)
+		if len(match) < 2 {
+			return nil, fmt.Errorf("%s json scout: embedded json not found in %s", s.cfg.Name, pageURL)
+		}
+		content = match[1]
+	}
+
+	doc, err := s.decode(content)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s %s: %w", s.cfg.Decode, pageURL, err)
+	}
+
+	items := s.items.eval(doc)
+	if len(items) == 1 {
+		if arr, ok := items[0].([]any); ok {
+			items = arr
+		}
+	}
+
+	out := make([]model.Candidates, 0, len(items))
+	for _, item := range items {
+		if candidate, ok := s.candidate(item, pageURL); ok {
+			out = append(out, candidate)
+		}
+	}
+	return out, nil
+}
+
+func (s *Scout) decode(content []byte) (any, error) {
+	if s.cfg.Decode == DecodeCSV {
+		return decodeCSV(content)
+	}
+
+	// UseNumber keeps millisecond epochs exact.
+	var doc any
+	dec := json.NewDecoder(bytes.NewReader(content))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// decodeCSV turns a CSV body into an array of objects keyed by the header
+// row. Cells beyond the header and empty header names are dropped.
+func decodeCSV(content []byte) (any, error) {
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))))
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return []any{}, nil
+	}
+
+	header := rows[0]
+	for i := range header {
+		header[i] = strings.TrimSpace(header[i])
+	}
+	out := make([]any, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		item := make(map[string]any, len(header))
+		for i, cell := range row {
+			if i < len(header) && header[i] != "" {
+				item[header[i]] = cell
+			}
+		}
+		out = append(out, item)
+	}
+	return out, nil
+}
+
+func (s *Scout) candidate(item any, pageURL string) (model.Candidates, bool) {
+	title := rootscout.NormalizeText(text(s.fields.title, item))
+	link := strings.TrimSpace(text(s.fields.link, item))
+	if title == "" || link == "" {
+		return model.Candidates{}, false
+	}
+	if s.cfg.Fields.LinkTemplate != "" {
+		link = strings.ReplaceAll(s.cfg.Fields.LinkTemplate, linkPlaceholder, url.PathEscape(link))
+	}
+
+	candidate := model.Candidates{
+		URL:             rootscout.ResolveURL(pageURL, link),
+		Title:           title,
+		IngestionMethod: "DIRECTORY",
+		DiscoveredAt:    s.now().In(s.loc),
+		Metadata: map[string]any{
+			"scout":  s.cfg.Name,
+			"format": s.cfg.Format,
+		},
+	}
+	if s.cfg.Fields.Description != "" {
+		candidate.Description = rootscout.NormalizeText(text(s.fields.description, item))
+	}
+	if s.cfg.Fields.Date != "" {
+		if v, ok := s.fields.date.first(item); ok {
+			if t, ok := s.parseDate(v); ok {
+				candidate.PublishedAt = t
+			}
+		}
+	}
+	for key, p := range s.fields.metadata {
+		if v, ok := metadataValue(p, item); ok {
+			candidate.Metadata[key] = v
+		}
+	}
+	return candidate, true
+}
+
+func text(p path, item any) string {
+	v, ok := p.first(item)
+	if !ok {
+		return ""
+	}
+	switch v := v.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	default:
+		return ""
+	}
+}
+
+func (s *Scout) parseDate(v any) (time.Time, bool) {
+	switch v := v.(type) {
+	case json.Number:
+		n, err := v.Int64()
+		if err != nil {
+			f, err := v.Float64()
+			if err != nil {
+				return time.Time{}, false
+			}
+			n = int64(f)
+		}
+		return s.epoch(n), n > 0
+	case string:
+		raw := strings.TrimSpace(v)
+		if raw == "" {
+			return time.Time{}, false
+		}
+		if s.cfg.EpochUnit != "" {
+			if n, err := strconv.ParseInt(raw, 10, 64); err == nil {
+				return s.epoch(n), n > 0
+			}
+		}
+		layouts := s.cfg.DateLayouts
+		if len(layouts) == 0 {
+			layouts = []string{time.RFC3339}
+		}
+		for _, layout := range layouts {
+			if t, err := rootscout.ParseDateInLocation(layout, raw, s.loc); err == nil {
+				return t.In(s.loc), true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+func (s *Scout) epoch(n int64) time.Time {
+	if s.cfg.EpochUnit == EpochMilliseconds {
+		return time.UnixMilli(n).In(s.loc)
+	}
+	return time.Unix(n, 0).In(s.loc)
+}
+
+// metadataValue returns the value p selects in item, with strings
+// normalised and string arrays kept as []string. Empty values are dropped.
+func metadataValue(p path, item any) (any, bool) {
+	v, ok := p.first(item)
+	if !ok {
+		return nil, false
+	}
+	switch v := v.(type) {
+	case string:
+		v = rootscout.NormalizeText(v)
+		return v, v != ""
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, e := range v {
+			if str, ok := e.(string); ok {
+				if str = rootscout.NormalizeText(str); str != "" {
+					out = append(out, str)
+				}
+			}
+		}
+		if len(out) == len(v) && len(out) > 0 {
+			return out, true
+		}
+		return v, len(v) > 0
+	default:
+		return v, true
+	}
+}
+
+func (c Config) Normalize() Config {
+	c.Name = strings.TrimSpace(c.Name)
+	c.Format = strings.TrimSpace(c.Format)
+	c.SpanName = strings.TrimSpace(c.SpanName)
+	c.Headers = htmlscout.CloneHeaders(c.Headers)
+	c.Embedded = strings.TrimSpace(c.Embedded)
+	c.Decode = strings.ToLower(strings.TrimSpace(c.Decode))
+	if c.Decode == "" {
+		c.Decode = DecodeJSON
+	}
+	c.Items = strings.TrimSpace(c.Items)
+	if c.Items == "" {
+		c.Items = "$"
+	}
+	c.Fields.Link = strings.TrimSpace(c.Fields.Link)
+	c.Fields.LinkTemplate = strings.TrimSpace(c.Fields.LinkTemplate)
+	c.Fields.Title = strings.TrimSpace(c.Fields.Title)
+	c.Fields.Date = strings.TrimSpace(c.Fields.Date)
+	c.Fields.Description = strings.TrimSpace(c.Fields.Description)
+	if len(c.Fields.Metadata) > 0 {
+		metadata := make(map[string]string, len(c.Fields.Metadata))
+		for key, expr := range c.Fields.Metadata {
+			metadata[strings.TrimSpace(key)] = strings.TrimSpace(expr)
+		}
+		c.Fields.Metadata = metadata
+	}
+	c.EpochUnit = strings.ToLower(strings.TrimSpace(c.EpochUnit))
+	c.Location = strings.TrimSpace(c.Location)
+	c.Pagination.Param = strings.TrimSpace(c.Pagination.Param)
+	if c.Pagination.Step == 0 {
+		c.Pagination.Step = 1
+	}
+	if c.Pagination.MaxPages <= 0 {
+		c.Pagination.MaxPages = 1
+	}
+	return c
+}
+
+func (c Config) Validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("%w: %s", rootscout.ErrConfigFieldEmpty, "name")
+	}
+	if c.Format == "" {
+		return fmt.Errorf("%w: %s", rootscout.ErrConfigFieldEmpty, "format")
+	}
+	if c.SpanName == "" {
+		return fmt.Errorf("%w: %s", rootscout.ErrConfigFieldEmpty, "span_name")
+	}
+	if c.Fields.Link == "" {
+		return fmt.Errorf("%w: %s", rootscout.ErrConfigFieldEmpty, "fields.link")
+	}
+	if c.Fields.Title == "" {
+		return fmt.Errorf("%w: %s", rootscout.ErrConfigFieldEmpty, "fields.title")
+	}
+
+	paths := map[string]string{
+		"items":        c.Items,
+		"fields.link":  c.Fields.Link,
+		"fields.title": c.Fields.Title,
+	}
+	if c.Fields.Date != "" {
+		paths["fields.date"] = c.Fields.Date
+	}
+	if c.Fields.Description != "" {
+		paths["fields.description"] = c.Fields.Description
+	}
+	for key, expr := range c.Fields.Metadata {
+		if key == "" || key == "scout" || key == "format" {
+			return fmt.Errorf("%s: fields.metadata key %q is reserved or empty", c.Name, key)
+		}
+		paths["fields.metadata."+key] = expr
+	}
+	for field, expr := range paths {
+		if _, err := compilePath(expr); err != nil {
+			return fmt.Errorf("%s: %s: %w", c.Name, field, err)
+		}
+	}
+
+	switch c.Decode {
+	case DecodeJSON, DecodeCSV:
+	default:
+		return fmt.Errorf("%s: decode %q is not one of json, csv", c.Name, c.Decode)
+	}
+	if c.Fields.LinkTemplate != "" && !strings.Contains(c.Fields.LinkTemplate, linkPlaceholder) {
+		return fmt.Errorf("%s: fields.link_template has no %s placeholder", c.Name, linkPlaceholder)
+	}
+	if c.Embedded != "" {
+		re, err := regexp.Compile(c.Embedded)
+		if err != nil {
+			return fmt.Errorf("%s: embedded: %w", c.Name, err)
+		}
+		if re.NumSubexp() < 1 {
+			return fmt.Errorf("%s: embedded needs a capture group", c.Name)
+		}
+	}
+	switch c.EpochUnit {
+	case "", EpochSeconds, EpochMilliseconds:
+	default:
+		return fmt.Errorf("%s: epoch_unit %q is not one of s, ms", c.Name, c.EpochUnit)
+	}
+	if c.Location != "" {
+		if _, err := time.LoadLocation(c.Location); err != nil {
+			return fmt.Errorf("%s: location: %w", c.Name, err)
+		}
+	}
+	if c.Pagination.Param != "" && c.Pagination.Step < 0 {
+		return fmt.Errorf("%s: pagination.step must not be negative", c.Name)
+	}
+	return nil
+}
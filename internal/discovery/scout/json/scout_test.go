@@ -0,0 +1,330 @@
+package jsonscout_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ChiaYuChang/prism/internal/discovery"
+	rootscout "github.com/ChiaYuChang/prism/internal/discovery/scout"
+	jsonscout "github.com/ChiaYuChang/prism/internal/discovery/scout/json"
+	"github.com/ChiaYuChang/prism/internal/model"
+	"github.com/ChiaYuChang/prism/pkg/testutils"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const apiURL = "https://api.example.org/v1/articles?section=politics"
+
+// fixtureClient serves fixture files by full URL and records the requests.
+// Unknown URLs answer 404.
+func fixtureClient(t *testing.T, pages map[string]string, fetched *[]string) *http.Client {
+	t.Helper()
+	return &http.Client{
+		Transport: testutils.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if fetched != nil {
+				*fetched = append(*fetched, req.URL.String())
+			}
+			name, ok := pages[req.URL.String()]
+			if !ok {
+				return &http.Response{
+					StatusCode: http.StatusNotFound,
+					Header:     make(http.Header),
+					Body:       io.NopCloser(testutils.NewReader(nil)),
+					Request:    req,
+				}, nil
+			}
+			body, err := os.ReadFile(filepath.Join("..", "..", "..", "..", "testdata", "synthetic", "discovery", "scout", name))
+			require.NoError(t, err)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     make(http.Header),
+				Body:       io.NopCloser(testutils.NewReader(body)),
+				Request:    req,
+			}, nil
+		}),
+	}
+}
+
+func apiConfig() jsonscout.Config {
+	return jsonscout.Config{
+		Name:     "example",
+		Format:   "json",
+		SpanName: discovery.ScoutDiscoverSpanName("json", "example"),
+		Items:    "$.data.articles",
+		Fields: jsonscout.Fields{
+			Link:        "$.path",
+			Title:       "$.headline",
+			Date:        "$.published",
+			Description: "$.lead",
+			Metadata: map[string]string{
+				"section":  "$.section.name",
+				"keywords": "$.tags",
+			},
+		},
+		DateLayouts: []string{"2006-01-02 15:04"},
+		EpochUnit:   jsonscout.EpochSeconds,
+		Location:    "Asia/Taipei",
+	}
+}
+
+func byURL(got []model.Candidates) map[string]model.Candidates {
+	out := make(map[string]model.Candidates, len(got))
+	for _, c := range got {
+		out[c.URL] = c
+	}
+	return out
+}
+
+func TestScoutDiscover_API(t *testing.T) {
+	client := fixtureClient(t, map[string]string{apiURL: "json_api_page_1.json"}, nil)
+	s, err := jsonscout.New(testutils.Logger(), noop.NewTracerProvider().Tracer("test"), client, apiConfig())
+	require.NoError(t, err)
+
+	got, err := s.Discover(context.Background(), apiURL)
+	require.NoError(t, err)
+	require.Len(t, got, 2, "untitled items are skipped")
+
+	items := byURL(got)
+	first, ok := items["https://api.example.org/news/001"]
+	require.True(t, ok, "relative links resolve against the page URL")
+	require.Equal(t, "Synthetic JSON API Item 1", first.Title)
+	require.Equal(t, "Synthetic lead one.", first.Description)
+	require.Equal(t, "2026-03-29T08:30:00+08:00", first.PublishedAt.Format("2006-01-02T15:04:05Z07:00"))
+	require.Equal(t, "DIRECTORY", first.IngestionMethod)
+	require.Equal(t, "example", first.Metadata["scout"])
+	require.Equal(t, "json", first.Metadata["format"])
+	require.Equal(t, "Politics", first.Metadata["section"])
+	require.Equal(t, []string{"policy", "legislature"}, first.Metadata["keywords"])
+
+	second, ok := items["https://api.example.org/news/002"]
+	require.True(t, ok)
+	require.Equal(t, "2026-03-29T08:30:00+08:00", second.PublishedAt.Format("2006-01-02T15:04:05Z07:00"), "digit-only strings are epochs")
+	require.NotContains(t, second.Metadata, "keywords", "empty arrays are dropped")
+}
+
+func TestScoutDiscover_Pagination(t *testing.T) {
+	pages := map[string]string{
+		"https://api.example.org/v1/articles?page=1&section=politics": "json_api_page_1.json",
+		"https://api.example.org/v1/articles?page=2&section=politics": "json_api_page_2.json",
+		"https://api.example.org/v1/articles?page=3&section=politics": "json_api_page_3.json",
+	}
+
+	t.Run("reads pages until one adds nothing", func(t *testing.T) {
+		var fetched []string
+		cfg := apiConfig()
+		cfg.Pagination = jsonscout.Pagination{Param: "page", Start: 1, MaxPages: 5}
+		s, err := jsonscout.New(testutils.Logger(), noop.NewTracerProvider().Tracer("test"), fixtureClient(t, pages, &fetched), cfg)
+		require.NoError(t, err)
+
+		got, err := s.Discover(context.Background(), apiURL)
+		require.NoError(t, err)
+		require.Len(t, got, 3, "item 1 repeats on page 2 and is deduplicated")
+		require.Len(t, fetched, 3)
+		require.Contains(t, byURL(got), "https://api.example.org/news/003")
+	})
+
+	t.Run("max pages caps the walk", func(t *testing.T) {
+		var fetched []string
+		cfg := apiConfig()
+		cfg.Pagination = jsonscout.Pagination{Param: "page", Start: 1, MaxPages: 1}
+		s, err := jsonscout.New(testutils.Logger(), noop.NewTracerProvider().Tracer("test"), fixtureClient(t, pages, &fetched), cfg)
+		require.NoError(t, err)
+
+		got, err := s.Discover(context.Background(), apiURL)
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		require.Equal(t, []string{"https://api.example.org/v1/articles?page=1&section=politics"}, fetched)
+	})
+
+	t.Run("explicit page in the URL is read alone", func(t *testing.T) {
+		var fetched []string
+		cfg := apiConfig()
+		cfg.Pagination = jsonscout.Pagination{Param: "page", Start: 1, MaxPages: 5}
+		s, err := jsonscout.New(testutils.Logger(), noop.NewTracerProvider().Tracer("test"), fixtureClient(t, pages, &fetched), cfg)
+		require.NoError(t, err)
+
+		got, err := s.Discover(context.Background(), "https://api.example.org/v1/articles?page=2&section=politics")
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		require.Len(t, fetched, 1)
+	})
+
+	t.Run("a failing later page keeps earlier results", func(t *testing.T) {
+		cfg := apiConfig()
+		cfg.Pagination = jsonscout.Pagination{Param: "page", Start: 1, MaxPages: 5}
+		client := fixtureClient(t, map[string]string{
+			"https://api.example.org/v1/articles?page=1&section=politics": "json_api_page_1.json",
+		}, nil)
+		s, err := jsonscout.New(testutils.Logger(), noop.NewTracerProvider().Tracer("test"), client, cfg)
+		require.NoError(t, err)
+
+		got, err := s.Discover(context.Background(), apiURL)
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+	})
+}
+
+// TestScoutDiscover_EmbeddedYahoo checks that the Yahoo listing, formerly a
+// custom Go scout, is covered by configuration alone.
+func TestScoutDiscover_EmbeddedYahoo(t *testing.T) {
+	const pageURL = "https://tw.news.yahoo.com/politics/"
+	client := fixtureClient(t, map[string]string{pageURL: "yahoo_politics.html"}, nil)
+	s, err := jsonscout.New(testutils.Logger(), noop.NewTracerProvider().Tracer("test"), client, jsonscout.Config{
+		Name:     "yahoo",
+		Format:   "json",
+		SpanName: discovery.ScoutDiscoverSpanName("json", "yahoo"),
+		Embedded: `(?s)"stream_items"\s*:\s*(\[.*?\])\s*,\s*"stream_total"`,
+		Fields: jsonscout.Fields{
+			Link:        "$.url",
+			Title:       "$.title",
+			Date:        "$.pubtime",
+			Description: "$.summary",
+			Metadata:    map[string]string{"publisher": "$.publisher"},
+		},
+		EpochUnit: jsonscout.EpochMilliseconds,
+		Location:  "Asia/Taipei",
+	})
+	require.NoError(t, err)
+
+	got, err := s.Discover(context.Background(), pageURL)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+
+	items := byURL(got)
+	item, ok := items["https://tw.news.yahoo.com/synthetic-politics-001.html"]
+	require.True(t, ok)
+	require.Equal(t, "Synthetic Yahoo Politics Item 1", item.Title)
+	require.Equal(t, "Synthetic Yahoo summary one.", item.Description)
+	require.Equal(t, "2026-03-29", item.PublishedAt.Format("2006-01-02"))
+	require.Equal(t, "Synthetic Publisher A", item.Metadata["publisher"])
+}
+
+func TestScoutDiscover_CSV(t *testing.T) {
+	const datasetURL = "https://data.ly.gov.tw/odw/usageFile.action?id=20&type=CSV"
+	client := fixtureClient(t, map[string]string{datasetURL: "ly_bills.csv"}, nil)
+	s, err := jsonscout.New(testutils.Logger(), noop.NewTracerProvider().Tracer("test"), client, jsonscout.Config{
+		Name:     "ly-bills",
+		Format:   "json",
+		SpanName: discovery.ScoutDiscoverSpanName("json", "ly-bills"),
+		Decode:   jsonscout.DecodeCSV,
+		Fields: jsonscout.Fields{
+			Link:         "$.billNo",
+			LinkTemplate: "https://ppg.ly.gov.tw/ppg/bills/{}/details",
+			Title:        "$.billName",
+			Metadata: map[string]string{
+				"proposer": "$.billProposer",
+				"status":   "$.billStatus",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	got, err := s.Discover(context.Background(), datasetURL)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+
+	items := byURL(got)
+	bill, ok := items["https://ppg.ly.gov.tw/ppg/bills/202603290001/details"]
+	require.True(t, ok)
+	require.Equal(t, "Synthetic draft amendment to the Energy Administration Act", bill.Title)
+	require.Equal(t, "Synthetic Legislator One, Synthetic Legislator Two", bill.Metadata["proposer"])
+	require.Equal(t, "交付審查", bill.Metadata["status"])
+	require.Equal(t, `Synthetic draft act on "open" budget reporting`, items["https://ppg.ly.gov.tw/ppg/bills/202603290002/details"].Title)
+}
+
+func TestScoutDiscover_CJKKeys(t *testing.T) {
+	const datasetURL = "https://www.ey.gov.tw/OpenData/api/ExecutiveYuan/NewsEy"
+	client := fixtureClient(t, map[string]string{datasetURL: "ey_news.json"}, nil)
+	s, err := jsonscout.New(testutils.Logger(), noop.NewTracerProvider().Tracer("test"), client, jsonscout.Config{
+		Name:     "ey-news",
+		Format:   "json",
+		SpanName: discovery.ScoutDiscoverSpanName("json", "ey-news"),
+		Fields: jsonscout.Fields{
+			Link:        "$['連結']",
+			Title:       "$['標題']",
+			Date:        "$['發布日期']",
+			Description: "$['摘要']",
+			Metadata:    map[string]string{"agency": "$['主管機關']"},
+		},
+		DateLayouts: []string{"2006/01/02"},
+		Location:    "Asia/Taipei",
+	})
+	require.NoError(t, err)
+
+	got, err := s.Discover(context.Background(), datasetURL)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+
+	items := byURL(got)
+	release, ok := items["https://www.ey.gov.tw/Page/9277F759E41CCD91/synthetic-0002"]
+	require.True(t, ok, "relative links resolve against the dataset URL")
+	require.Equal(t, "Synthetic Premier remarks on budget transparency", release.Title)
+	require.Equal(t, "2026-03-28", release.PublishedAt.Format("2006-01-02"))
+	require.Equal(t, "Synthetic Spokesperson Office", release.Metadata["agency"])
+}
+
+func TestScoutDiscover_Errors(t *testing.T) {
+	t.Run("no candidates", func(t *testing.T) {
+		client := fixtureClient(t, map[string]string{apiURL: "json_api_page_3.json"}, nil)
+		s, err := jsonscout.New(testutils.Logger(), noop.NewTracerProvider().Tracer("test"), client, apiConfig())
+		require.NoError(t, err)
+
+		_, err = s.Discover(context.Background(), apiURL)
+		require.ErrorIs(t, err, rootscout.ErrNoCandidatesFound)
+	})
+
+	t.Run("embedded json missing", func(t *testing.T) {
+		cfg := apiConfig()
+		cfg.Embedded = `window\.__DATA__\s*=\s*(\{.*?\});`
+		client := fixtureClient(t, map[string]string{apiURL: "json_api_page_1.json"}, nil)
+		s, err := jsonscout.New(testutils.Logger(), noop.NewTracerProvider().Tracer("test"), client, cfg)
+		require.NoError(t, err)
+
+		_, err = s.Discover(context.Background(), apiURL)
+		require.ErrorContains(t, err, "embedded json not found")
+	})
+
+	t.Run("first page fails", func(t *testing.T) {
+		s, err := jsonscout.New(testutils.Logger(), noop.NewTracerProvider().Tracer("test"), fixtureClient(t, nil, nil), apiConfig())
+		require.NoError(t, err)
+
+		_, err = s.Discover(context.Background(), apiURL)
+		require.Error(t, err)
+	})
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*jsonscout.Config)
+		wantErr string
+	}{
+		{name: "valid", mutate: func(*jsonscout.Config) {}},
+		{name: "missing link", mutate: func(c *jsonscout.Config) { c.Fields.Link = "" }, wantErr: "fields.link"},
+		{name: "missing title", mutate: func(c *jsonscout.Config) { c.Fields.Title = "" }, wantErr: "fields.title"},
+		{name: "bad items path", mutate: func(c *jsonscout.Config) { c.Items = "data.articles" }, wantErr: "items"},
+		{name: "bad metadata path", mutate: func(c *jsonscout.Config) { c.Fields.Metadata["section"] = "$.a[?(@.x)]" }, wantErr: "fields.metadata.section"},
+		{name: "reserved metadata key", mutate: func(c *jsonscout.Config) { c.Fields.Metadata["scout"] = "$.x" }, wantErr: "reserved"},
+		{name: "embedded without group", mutate: func(c *jsonscout.Config) { c.Embedded = `"items":\[.*\]` }, wantErr: "capture group"},
+		{name: "bad epoch unit", mutate: func(c *jsonscout.Config) { c.EpochUnit = "ns" }, wantErr: "epoch_unit"},
+		{name: "bad location", mutate: func(c *jsonscout.Config) { c.Location = "Mars/Olympus" }, wantErr: "location"},
+		{name: "bad decode", mutate: func(c *jsonscout.Config) { c.Decode = "xml" }, wantErr: "decode"},
+		{name: "link template without placeholder", mutate: func(c *jsonscout.Config) { c.Fields.LinkTemplate = "https://example.org/a" }, wantErr: "link_template"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := apiConfig()
+			tt.mutate(&cfg)
+			err := cfg.Normalize().Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"log/slog"
 	"net/url"
 	"strings"
+	"sync/atomic"
 
 	"github.com/ChiaYuChang/prism/internal/discovery"
 	"github.com/ChiaYuChang/prism/internal/model"
@@ -80,3 +81,33 @@ func (r *Registry) Discover(ctx context.Context, rawURL string) ([]model.Candida
 
 	return scout.Discover(ctx, rawURL)
 }
+
+// Swappable is a discovery.Scout backed by a Registry that can be replaced
+// while in use, so a worker can pick up scout config changes without a
+// restart. A Discover call runs to completion on the registry it started
+// with.
+type Swappable struct {
+	current atomic.Pointer[Registry]
+}
+
+var _ discovery.Scout = (*Swappable)(nil)
+
+func NewSwappable(r *Registry) (*Swappable, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w: registry", ErrParamMissing)
+	}
+	s := &Swappable{}
+	s.current.Store(r)
+	return s, nil
+}
+
+// Swap installs r for subsequent Discover calls. A nil r is ignored.
+func (s *Swappable) Swap(r *Registry) {
+	if r != nil {
+		s.current.Store(r)
+	}
+}
+
+func (s *Swappable) Discover(ctx context.Context, rawURL string) ([]model.Candidates, error) {
+	return s.current.Load().Discover(ctx, rawURL)
+}
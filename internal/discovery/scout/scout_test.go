@@ -51,3 +51,29 @@ func TestRegistryDiscover_NoMatch(t *testing.T) {
 func testLogger() *slog.Logger {
 	return slog.New(slog.NewTextHandler(io.Discard, nil))
 }
+
+func TestSwappableDiscover(t *testing.T) {
+	tracer := noop.NewTracerProvider().Tracer("test")
+	first, err := root.NewRegistry(testLogger(), tracer, map[string]discovery.Scout{"a.example": stubScout{}})
+	require.NoError(t, err)
+	second, err := root.NewRegistry(testLogger(), tracer, map[string]discovery.Scout{"b.example": stubScout{}})
+	require.NoError(t, err)
+
+	_, err = root.NewSwappable(nil)
+	require.ErrorIs(t, err, root.ErrParamMissing)
+
+	s, err := root.NewSwappable(first)
+	require.NoError(t, err)
+	_, err = s.Discover(context.Background(), "https://a.example/")
+	require.NoError(t, err)
+
+	s.Swap(second)
+	_, err = s.Discover(context.Background(), "https://a.example/")
+	require.ErrorIs(t, err, root.ErrNoMatchingScout)
+	_, err = s.Discover(context.Background(), "https://b.example/")
+	require.NoError(t, err)
+
+	s.Swap(nil)
+	_, err = s.Discover(context.Background(), "https://b.example/")
+	require.NoError(t, err)
+}
@@ -0,0 +1,416 @@
+package sitemapscout
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/discovery"
+	rootscout "github.com/ChiaYuChang/prism/internal/discovery/scout"
+	htmlscout "github.com/ChiaYuChang/prism/internal/discovery/scout/html"
+	"github.com/ChiaYuChang/prism/internal/model"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// DefaultMaxSitemaps caps the child sitemaps one Discover call reads
+	// from a sitemap index, newest first.
+	DefaultMaxSitemaps = 5
+
+	// maxIndexDepth bounds index-of-index nesting.
+	maxIndexDepth = 2
+)
+
+// Config describes one sitemap scout. Discover accepts either a sitemap
+// index or a urlset, plain or gzipped. Entries need a title, from the
+// Google News extension or an image title; plain entries without one are
+// skipped like untitled RSS items.
+type Config struct {
+	Name     string            `yaml:"name"      json:"name"`
+	Format   string            `yaml:"format"    json:"format"`
+	SpanName string            `yaml:"span_name" json:"span_name"`
+	Headers  map[string]string `yaml:"headers"   json:"headers"`
+
+	// LastmodWindow, when positive, drops child sitemaps and entries whose
+	// lastmod or publication date is older than now minus the window.
+	// Undated ones are kept.
+	LastmodWindow time.Duration `yaml:"lastmod_window" json:"lastmod_window"`
+
+	// MaxSitemaps caps the child sitemaps read per index; zero means
+	// DefaultMaxSitemaps.
+	MaxSitemaps int `yaml:"max_sitemaps" json:"max_sitemaps"`
+}
+
+// Ref is one <sitemap> entry of a sitemap index.
+type Ref struct {
+	Loc     string
+	LastMod time.Time
+}
+
+type document struct {
+	XMLName  xml.Name
+	Sitemaps []sitemapEntry `xml:"sitemap"`
+	URLs     []urlEntry     `xml:"url"`
+}
+
+type sitemapEntry struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod"`
+}
+
+type urlEntry struct {
+	Loc     string       `xml:"loc"`
+	LastMod string       `xml:"lastmod"`
+	News    *newsEntry   `xml:"http://www.google.com/schemas/sitemap-news/0.9 news"`
+	Images  []imageEntry `xml:"http://www.google.com/schemas/sitemap-image/1.1 image"`
+}
+
+type newsEntry struct {
+	Publication struct {
+		Name     string `xml:"name"`
+		Language string `xml:"language"`
+	} `xml:"publication"`
+	PublicationDate string `xml:"publication_date"`
+	Title           string `xml:"title"`
+	Keywords        string `xml:"keywords"`
+}
+
+type imageEntry struct {
+	Title   string `xml:"title"`
+	Caption string `xml:"caption"`
+}
+
+type Scout struct {
+	logger *slog.Logger
+	tracer trace.Tracer
+	client *http.Client
+	now    func() time.Time
+	loc    *time.Location
+	cfg    Config
+}
+
+var _ discovery.Scout = (*Scout)(nil)
+
+func New(logger *slog.Logger, tracer trace.Tracer, client *http.Client, cfg Config) (*Scout, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("%w: logger", rootscout.ErrParamMissing)
+	}
+	if tracer == nil {
+		return nil, fmt.Errorf("%w: tracer", rootscout.ErrParamMissing)
+	}
+
+	cfg = cfg.Normalize()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &Scout{
+		logger: logger,
+		tracer: tracer,
+		client: client,
+		now:    time.Now,
+		loc:    time.Local,
+		cfg:    cfg,
+	}, nil
+}
+
+func (s *Scout) Discover(ctx context.Context, rawURL string) ([]model.Candidates, error) {
+	ctx, span := s.tracer.Start(ctx, s.cfg.SpanName)
+	defer span.End()
+
+	var cutoff time.Time
+	if s.cfg.LastmodWindow > 0 {
+		cutoff = s.now().Add(-s.cfg.LastmodWindow)
+	}
+
+	seen := make(map[string]struct{})
+	out, err := s.walk(ctx, rawURL, cutoff, seen, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("%s sitemap scout: %w", s.cfg.Name, rootscout.ErrNoCandidatesFound)
+	}
+
+	s.logger.DebugContext(ctx, "sitemap scout discovered candidates",
+		slog.String("url", rawURL),
+		slog.String("scout", s.cfg.Name),
+		slog.String("span_name", s.cfg.SpanName),
+		slog.Int("count", len(out)),
+	)
+	return out, nil
+}
+
+// walk reads rawURL and returns its candidates, descending into child
+// sitemaps when rawURL is an index. A failed child is logged and skipped so
+// one broken archive does not hide the rest.
+func (s *Scout) walk(ctx context.Context, rawURL string, cutoff time.Time, seen map[string]struct{}, depth int) ([]model.Candidates, error) {
+	doc, err := read(ctx, s.client, rawURL, s.cfg.Headers)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(doc.Sitemaps) > 0 {
+		if depth >= maxIndexDepth {
+			s.logger.WarnContext(ctx, "sitemap index nested too deep, skipped",
+				slog.String("url", rawURL), slog.String("scout", s.cfg.Name))
+			return nil, nil
+		}
+		refs := SelectRefs(indexRefs(doc, s.loc), time.Time{}, cutoff)
+		if len(refs) > s.cfg.MaxSitemaps {
+			refs = refs[:s.cfg.MaxSitemaps]
+		}
+
+		var out []model.Candidates
+		for _, ref := range refs {
+			got, err := s.walk(ctx, ref.Loc, cutoff, seen, depth+1)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil, ctx.Err()
+				}
+				s.logger.WarnContext(ctx, "failed to read child sitemap",
+					slog.String("url", ref.Loc),
+					slog.String("scout", s.cfg.Name),
+					slog.String("error", err.Error()))
+				continue
+			}
+			out = append(out, got...)
+		}
+		return out, nil
+	}
+
+	out := make([]model.Candidates, 0, len(doc.URLs))
+	for _, entry := range doc.URLs {
+		candidate, ok := s.candidate(entry, rawURL)
+		if !ok {
+			continue
+		}
+		if !cutoff.IsZero() && !candidate.PublishedAt.IsZero() && candidate.PublishedAt.Before(cutoff) {
+			continue
+		}
+		if _, dup := seen[candidate.URL]; dup {
+			continue
+		}
+		seen[candidate.URL] = struct{}{}
+		out = append(out, candidate)
+	}
+	return out, nil
+}
+
+func (s *Scout) candidate(entry urlEntry, sitemapURL string) (model.Candidates, bool) {
+	link := strings.TrimSpace(entry.Loc)
+	if link == "" {
+		return model.Candidates{}, false
+	}
+
+	var title, description string
+	if entry.News != nil {
+		title = rootscout.NormalizeText(entry.News.Title)
+	}
+	for _, img := range entry.Images {
+		if title == "" {
+			title = rootscout.NormalizeText(img.Title)
+		}
+		if description == "" {
+			description = rootscout.NormalizeText(img.Caption)
+		}
+	}
+	if title == "" {
+		return model.Candidates{}, false
+	}
+
+	candidate := model.Candidates{
+		URL:             link,
+		Title:           title,
+		Description:     description,
+		IngestionMethod: "DIRECTORY",
+		DiscoveredAt:    s.now().In(s.loc),
+		Metadata: map[string]any{
+			"scout":   s.cfg.Name,
+			"format":  s.cfg.Format,
+			"sitemap": sitemapURL,
+		},
+	}
+
+	if entry.News != nil {
+		if t, ok := parseW3CDate(entry.News.PublicationDate, s.loc); ok {
+			candidate.PublishedAt = t
+		}
+		if keywords := splitKeywords(entry.News.Keywords); len(keywords) > 0 {
+			candidate.Metadata["keywords"] = keywords
+		}
+		if name := rootscout.NormalizeText(entry.News.Publication.Name); name != "" {
+			candidate.Metadata["publication"] = name
+		}
+		if lang := strings.TrimSpace(entry.News.Publication.Language); lang != "" {
+			candidate.Metadata["language"] = lang
+		}
+	}
+	if candidate.PublishedAt.IsZero() {
+		if t, ok := parseW3CDate(entry.LastMod, s.loc); ok {
+			candidate.PublishedAt = t
+		}
+	}
+	return candidate, true
+}
+
+// Index reads rawURL and returns its child sitemaps in document order. A
+// urlset comes back as a single Ref to itself, so callers can treat both
+// shapes as a list of pages.
+func Index(ctx context.Context, client *http.Client, rawURL string, headers map[string]string, loc *time.Location) ([]Ref, error) {
+	doc, err := read(ctx, client, rawURL, headers)
+	if err != nil {
+		return nil, err
+	}
+	if len(doc.Sitemaps) == 0 {
+		return []Ref{{Loc: rawURL}}, nil
+	}
+	return indexRefs(doc, loc), nil
+}
+
+// SelectRefs returns the refs dated within [after, before], newest first,
+// with undated refs after the dated ones in their original order. A zero
+// bound is open.
+func SelectRefs(refs []Ref, before, after time.Time) []Ref {
+	out := make([]Ref, 0, len(refs))
+	for _, ref := range refs {
+		if !ref.LastMod.IsZero() {
+			if !before.IsZero() && ref.LastMod.After(before) {
+				continue
+			}
+			if !after.IsZero() && ref.LastMod.Before(after) {
+				continue
+			}
+		}
+		out = append(out, ref)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i].LastMod, out[j].LastMod
+		if a.IsZero() || b.IsZero() {
+			return !a.IsZero() && b.IsZero()
+		}
+		return a.After(b)
+	})
+	return out
+}
+
+func (c Config) Normalize() Config {
+	c.Name = strings.TrimSpace(c.Name)
+	c.Format = strings.TrimSpace(c.Format)
+	c.SpanName = strings.TrimSpace(c.SpanName)
+	c.Headers = htmlscout.CloneHeaders(c.Headers)
+	if c.MaxSitemaps <= 0 {
+		c.MaxSitemaps = DefaultMaxSitemaps
+	}
+	return c
+}
+
+func (c Config) Validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("%w: %s", rootscout.ErrConfigFieldEmpty, "name")
+	}
+	if c.Format == "" {
+		return fmt.Errorf("%w: %s", rootscout.ErrConfigFieldEmpty, "format")
+	}
+	if c.SpanName == "" {
+		return fmt.Errorf("%w: %s", rootscout.ErrConfigFieldEmpty, "span_name")
+	}
+	if c.LastmodWindow < 0 {
+		return fmt.Errorf("%s: lastmod_window must not be negative", c.Name)
+	}
+	return nil
+}
+
+func read(ctx context.Context, client *http.Client, rawURL string, headers map[string]string) (document, error) {
+	body, err := htmlscout.Fetch(ctx, client, rawURL, headers)
+	if err != nil {
+		return document{}, err
+	}
+	defer func() { _ = body.Close() }()
+
+	// Servers often send .xml.gz without Content-Encoding, so sniff the
+	// gzip magic instead of trusting headers.
+	br := bufio.NewReader(body)
+	var r io.Reader = br
+	if magic, _ := br.Peek(2); len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return document{}, fmt.Errorf("open gzip sitemap %s: %w", rawURL, err)
+		}
+		defer func() { _ = gz.Close() }()
+		r = gz
+	}
+
+	var doc document
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return document{}, fmt.Errorf("parse sitemap %s: %w", rawURL, err)
+	}
+	switch doc.XMLName.Local {
+	case "sitemapindex", "urlset":
+		return doc, nil
+	default:
+		return document{}, fmt.Errorf("parse sitemap %s: unexpected root element <%s>", rawURL, doc.XMLName.Local)
+	}
+}
+
+func indexRefs(doc document, loc *time.Location) []Ref {
+	refs := make([]Ref, 0, len(doc.Sitemaps))
+	for _, entry := range doc.Sitemaps {
+		link := strings.TrimSpace(entry.Loc)
+		if link == "" {
+			continue
+		}
+		ref := Ref{Loc: link}
+		if t, ok := parseW3CDate(entry.LastMod, loc); ok {
+			ref.LastMod = t
+		}
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+func splitKeywords(raw string) []string {
+	var out []string
+	for _, kw := range strings.Split(raw, ",") {
+		if kw = rootscout.NormalizeText(kw); kw != "" {
+			out = append(out, kw)
+		}
+	}
+	return out
+}
+
+// parseW3CDate parses the W3C Datetime profile used by sitemaps. Date-only
+// values are read in loc.
+func parseW3CDate(raw string, loc *time.Location) (time.Time, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return time.Time{}, false
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	layouts := []string{
+		time.RFC3339Nano,
+		"2006-01-02T15:04Z07:00",
+	}
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return t.In(loc), true
+		}
+	}
+	for _, layout := range []string{"2006-01-02T15:04:05", time.DateOnly} {
+		if t, err := time.ParseInLocation(layout, raw, loc); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
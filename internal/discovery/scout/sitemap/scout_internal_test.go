@@ -0,0 +1,55 @@
+package sitemapscout
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ChiaYuChang/prism/pkg/testutils"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestDiscover_LastmodWindow(t *testing.T) {
+	pages := map[string]string{
+		"https://www.cna.com.tw/sitemap/news-index.xml":      "cna_news_sitemap_index.xml",
+		"https://www.cna.com.tw/sitemap/news-2026-03-29.xml": "cna_news_sitemap_0329.xml",
+		"https://www.cna.com.tw/sitemap/news-2026-03-28.xml": "cna_news_sitemap_0328.xml",
+	}
+	var fetched []string
+	client := &http.Client{
+		Transport: testutils.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+			fetched = append(fetched, req.URL.String())
+			body, err := os.ReadFile(filepath.Join("..", "..", "..", "..", "testdata", "synthetic", "discovery", "scout", pages[req.URL.String()]))
+			require.NoError(t, err)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     make(http.Header),
+				Body:       io.NopCloser(testutils.NewReader(body)),
+				Request:    req,
+			}, nil
+		}),
+	}
+
+	s, err := New(testutils.Logger(), noop.NewTracerProvider().Tracer("test"), client, Config{
+		Name: "cna", Format: "sitemap", SpanName: "test", LastmodWindow: 48 * time.Hour,
+	})
+	require.NoError(t, err)
+	s.loc = time.UTC
+	s.now = func() time.Time { return time.Date(2026, 3, 30, 4, 0, 0, 0, time.UTC) }
+
+	got, err := s.Discover(context.Background(), "https://www.cna.com.tw/sitemap/news-index.xml")
+	require.NoError(t, err)
+
+	// The 01-01 child is outside the window and never fetched; 090 was
+	// published on 03-28 00:00 UTC, before the 03-28 04:00 cutoff.
+	require.NotContains(t, fetched, "https://www.cna.com.tw/sitemap/news-2026-01-01.xml")
+	require.Len(t, got, 2)
+	for _, c := range got {
+		require.NotEqual(t, "https://www.cna.com.tw/news/synthetic/090.aspx", c.URL)
+	}
+}
@@ -0,0 +1,148 @@
+package sitemapscout_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/discovery"
+	rootscout "github.com/ChiaYuChang/prism/internal/discovery/scout"
+	sitemapscout "github.com/ChiaYuChang/prism/internal/discovery/scout/sitemap"
+	"github.com/ChiaYuChang/prism/internal/model"
+	"github.com/ChiaYuChang/prism/pkg/testutils"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const indexURL = "https://www.cna.com.tw/sitemap/news-index.xml"
+
+// fixtureClient serves the synthetic CNA sitemap fixtures; the 03-28 child
+// is gzipped without Content-Encoding, as many servers send .xml.gz.
+func fixtureClient(t *testing.T) *http.Client {
+	t.Helper()
+	read := func(name string) []byte {
+		body, err := os.ReadFile(filepath.Join("..", "..", "..", "..", "testdata", "synthetic", "discovery", "scout", name))
+		require.NoError(t, err)
+		return body
+	}
+	var gz bytes.Buffer
+	w := gzip.NewWriter(&gz)
+	_, err := w.Write(read("cna_news_sitemap_0328.xml"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	pages := map[string][]byte{
+		indexURL: read("cna_news_sitemap_index.xml"),
+		"https://www.cna.com.tw/sitemap/news-2026-03-29.xml": read("cna_news_sitemap_0329.xml"),
+		"https://www.cna.com.tw/sitemap/news-2026-03-28.xml": gz.Bytes(),
+	}
+	return &http.Client{
+		Transport: testutils.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+			body, ok := pages[req.URL.String()]
+			status := http.StatusOK
+			if !ok {
+				status = http.StatusNotFound
+			}
+			return &http.Response{
+				StatusCode: status,
+				Header:     make(http.Header),
+				Body:       io.NopCloser(testutils.NewReader(body)),
+				Request:    req,
+			}, nil
+		}),
+	}
+}
+
+func newScout(t *testing.T, cfg sitemapscout.Config) *sitemapscout.Scout {
+	t.Helper()
+	cfg.Name = "cna"
+	cfg.Format = "sitemap"
+	cfg.SpanName = discovery.ScoutDiscoverSpanName("sitemap", "cna")
+	s, err := sitemapscout.New(testutils.Logger(), noop.NewTracerProvider().Tracer("test"), fixtureClient(t), cfg)
+	require.NoError(t, err)
+	return s
+}
+
+func byURL(got []model.Candidates) map[string]model.Candidates {
+	out := make(map[string]model.Candidates, len(got))
+	for _, c := range got {
+		out[c.URL] = c
+	}
+	return out
+}
+
+func TestScoutDiscover_Index(t *testing.T) {
+	got, err := newScout(t, sitemapscout.Config{}).Discover(context.Background(), indexURL)
+	require.NoError(t, err)
+
+	// The 01-01 child 404s and is skipped; 101 appears in two children but
+	// is returned once; 103 has no title.
+	require.Len(t, got, 3)
+	items := byURL(got)
+
+	news := items["https://www.cna.com.tw/news/synthetic/101.aspx"]
+	require.Equal(t, "Synthetic CNA Sitemap Item 1", news.Title)
+	require.Equal(t, "2026-03-29T02:00:00Z", news.PublishedAt.UTC().Format(time.RFC3339))
+	require.Equal(t, []string{"synthetic", "legislature", "budget"}, news.Metadata["keywords"])
+	require.Equal(t, "Synthetic CNA", news.Metadata["publication"])
+	require.Equal(t, "zh-tw", news.Metadata["language"])
+	require.Equal(t, "https://www.cna.com.tw/sitemap/news-2026-03-29.xml", news.Metadata["sitemap"])
+
+	image := items["https://www.cna.com.tw/news/synthetic/102.aspx"]
+	require.Equal(t, "Synthetic CNA Image Title", image.Title)
+	require.Equal(t, "Synthetic caption.", image.Description)
+	require.Equal(t, "2026-03-29T00:30:00Z", image.PublishedAt.UTC().Format(time.RFC3339))
+
+	_, ok := items["https://www.cna.com.tw/news/synthetic/090.aspx"]
+	require.True(t, ok, "gzipped child sitemap is read")
+}
+
+func TestScoutDiscover_MaxSitemaps(t *testing.T) {
+	got, err := newScout(t, sitemapscout.Config{MaxSitemaps: 1}).Discover(context.Background(), indexURL)
+	require.NoError(t, err)
+	require.Len(t, got, 2, "only the newest child is read")
+}
+
+func TestScoutDiscover_URLSetWithoutTitles(t *testing.T) {
+	s := newScout(t, sitemapscout.Config{})
+	_, err := s.Discover(context.Background(), "https://www.cna.com.tw/sitemap/missing.xml")
+	require.Error(t, err)
+
+	got, err := s.Discover(context.Background(), "https://www.cna.com.tw/sitemap/news-2026-03-28.xml")
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+}
+
+func TestIndexAndSelectRefs(t *testing.T) {
+	ctx := context.Background()
+	client := fixtureClient(t)
+
+	refs, err := sitemapscout.Index(ctx, client, indexURL, nil, time.UTC)
+	require.NoError(t, err)
+	require.Len(t, refs, 3)
+
+	selected := sitemapscout.SelectRefs(refs, time.Date(2026, 3, 29, 0, 0, 0, 0, time.UTC), time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC))
+	require.Len(t, selected, 1)
+	require.Equal(t, "https://www.cna.com.tw/sitemap/news-2026-03-28.xml", selected[0].Loc)
+
+	undated := sitemapscout.SelectRefs([]sitemapscout.Ref{
+		{Loc: "a"}, {Loc: "b", LastMod: time.Unix(1, 0)}, {Loc: "c", LastMod: time.Unix(2, 0)},
+	}, time.Time{}, time.Time{})
+	require.Equal(t, []string{"c", "b", "a"}, []string{undated[0].Loc, undated[1].Loc, undated[2].Loc})
+
+	// A urlset is its own single page.
+	refs, err = sitemapscout.Index(ctx, client, "https://www.cna.com.tw/sitemap/news-2026-03-29.xml", nil, time.UTC)
+	require.NoError(t, err)
+	require.Equal(t, []sitemapscout.Ref{{Loc: "https://www.cna.com.tw/sitemap/news-2026-03-29.xml"}}, refs)
+}
+
+func TestConfigValidate(t *testing.T) {
+	_, err := sitemapscout.New(testutils.Logger(), noop.NewTracerProvider().Tracer("test"), nil, sitemapscout.Config{Name: "cna", Format: "sitemap"})
+	require.ErrorIs(t, err, rootscout.ErrConfigFieldEmpty)
+}
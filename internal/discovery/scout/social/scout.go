@@ -0,0 +1,156 @@
+package socialscout
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/discovery"
+	rootscout "github.com/ChiaYuChang/prism/internal/discovery/scout"
+	htmlscout "github.com/ChiaYuChang/prism/internal/discovery/scout/html"
+	"github.com/ChiaYuChang/prism/internal/model"
+	"github.com/ChiaYuChang/prism/internal/social"
+	"github.com/ChiaYuChang/prism/internal/social/exporters"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Config describes one social scout: a platform exporter reading public
+// channel feeds. One scout serves every channel on the platform's hosts;
+// the DIRECTORY_FETCH task URL names the channel feed.
+type Config struct {
+	Name     string            `yaml:"name"      json:"name"`
+	Format   string            `yaml:"format"    json:"format"`
+	SpanName string            `yaml:"span_name" json:"span_name"`
+	Platform string            `yaml:"platform"  json:"platform"`
+	Headers  map[string]string `yaml:"headers"   json:"headers"`
+}
+
+// Scout turns channel feed posts into SUBSCRIPTION candidates carrying the
+// platform metadata.
+type Scout struct {
+	logger   *slog.Logger
+	tracer   trace.Tracer
+	exporter social.Exporter
+	now      func() time.Time
+	cfg      Config
+}
+
+var _ discovery.Scout = (*Scout)(nil)
+
+// New builds the platform exporter named by cfg.Platform.
+func New(logger *slog.Logger, tracer trace.Tracer, client *http.Client, cfg Config) (*Scout, error) {
+	cfg = cfg.Normalize()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Channel never downloads captions, so only the headers matter here.
+	exporter, err := exporters.New(cfg.Platform, client, exporters.Options{Headers: cfg.Headers})
+	if err != nil {
+		return nil, err
+	}
+	return NewWithExporter(logger, tracer, exporter, cfg)
+}
+
+// NewWithExporter wraps an existing exporter, for platforms built outside
+// exporters.New.
+func NewWithExporter(logger *slog.Logger, tracer trace.Tracer, exporter social.Exporter, cfg Config) (*Scout, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("%w: logger", rootscout.ErrParamMissing)
+	}
+	if tracer == nil {
+		return nil, fmt.Errorf("%w: tracer", rootscout.ErrParamMissing)
+	}
+	if exporter == nil {
+		return nil, fmt.Errorf("%w: exporter", rootscout.ErrParamMissing)
+	}
+
+	cfg = cfg.Normalize()
+	if cfg.Platform == "" {
+		cfg.Platform = exporter.Platform()
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &Scout{
+		logger:   logger,
+		tracer:   tracer,
+		exporter: exporter,
+		now:      time.Now,
+		cfg:      cfg,
+	}, nil
+}
+
+func (s *Scout) Discover(ctx context.Context, rawURL string) ([]model.Candidates, error) {
+	ctx, span := s.tracer.Start(ctx, s.cfg.SpanName)
+	defer span.End()
+
+	posts, err := s.exporter.Channel(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]model.Candidates, 0, len(posts))
+	for _, post := range posts {
+		title := rootscout.NormalizeText(post.Title)
+		link := strings.TrimSpace(post.URL)
+		if title == "" || link == "" {
+			continue
+		}
+
+		metadata := post.CommonMetadata()
+		metadata["scout"] = s.cfg.Name
+		metadata["format"] = s.cfg.Format
+		out = append(out, model.Candidates{
+			URL:             link,
+			Title:           title,
+			Description:     strings.TrimSpace(post.Text()),
+			IngestionMethod: "SUBSCRIPTION",
+			PublishedAt:     post.PublishedAt,
+			DiscoveredAt:    s.now(),
+			Metadata:        metadata,
+		})
+	}
+
+	if len(out) == 0 {
+		return nil, fmt.Errorf("%s social scout: %w", s.cfg.Name, rootscout.ErrNoCandidatesFound)
+	}
+
+	s.logger.DebugContext(ctx, "social scout discovered candidates",
+		slog.String("url", rawURL),
+		slog.String("scout", s.cfg.Name),
+		slog.String("platform", s.cfg.Platform),
+		slog.String("span_name", s.cfg.SpanName),
+		slog.Int("count", len(out)),
+	)
+	return out, nil
+}
+
+func (c Config) Normalize() Config {
+	c.Name = strings.TrimSpace(c.Name)
+	c.Format = strings.TrimSpace(c.Format)
+	c.SpanName = strings.TrimSpace(c.SpanName)
+	c.Platform = strings.ToLower(strings.TrimSpace(c.Platform))
+	c.Headers = htmlscout.CloneHeaders(c.Headers)
+	return c
+}
+
+func (c Config) Validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("%w: %s", rootscout.ErrConfigFieldEmpty, "name")
+	}
+	if c.Format == "" {
+		return fmt.Errorf("%w: %s", rootscout.ErrConfigFieldEmpty, "format")
+	}
+	if c.SpanName == "" {
+		return fmt.Errorf("%w: %s", rootscout.ErrConfigFieldEmpty, "span_name")
+	}
+	if c.Platform == "" {
+		return fmt.Errorf("%w: %s", rootscout.ErrConfigFieldEmpty, "platform")
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package socialscout_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ChiaYuChang/prism/internal/discovery"
+	rootscout "github.com/ChiaYuChang/prism/internal/discovery/scout"
+	socialscout "github.com/ChiaYuChang/prism/internal/discovery/scout/social"
+	"github.com/ChiaYuChang/prism/internal/social"
+	"github.com/ChiaYuChang/prism/pkg/testutils"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const feedURL = "https://www.youtube.com/feeds/videos.xml?channel_id=UCsyntheticParty0000001"
+
+func feedClient(t *testing.T, fixture string) *http.Client {
+	t.Helper()
+	return &http.Client{
+		Transport: testutils.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+			body, err := os.ReadFile(filepath.Join("..", "..", "..", "..", "testdata", "synthetic", "social", "youtube", fixture))
+			require.NoError(t, err)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     make(http.Header),
+				Body:       io.NopCloser(testutils.NewReader(body)),
+				Request:    req,
+			}, nil
+		}),
+	}
+}
+
+func newScout(t *testing.T, client *http.Client) *socialscout.Scout {
+	t.Helper()
+	s, err := socialscout.New(testutils.Logger(), noop.NewTracerProvider().Tracer("test"), client, socialscout.Config{
+		Name:     "youtube",
+		Format:   "social",
+		SpanName: discovery.ScoutDiscoverSpanName("social", "youtube"),
+		Platform: "YouTube",
+	})
+	require.NoError(t, err)
+	return s
+}
+
+func TestScoutDiscover(t *testing.T) {
+	got, err := newScout(t, feedClient(t, "channel_feed.xml")).Discover(context.Background(), feedURL)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+
+	video := got[0]
+	require.Equal(t, "https://www.youtube.com/watch?v=synVideo001", video.URL)
+	require.Equal(t, "Synthetic Press Conference on Energy Policy", video.Title)
+	require.Equal(t, "Synthetic spokesperson statement on the energy bill.\nFull remarks in the video.", video.Description)
+	require.Equal(t, "SUBSCRIPTION", video.IngestionMethod)
+	require.Equal(t, "2026-03-29", video.PublishedAt.Format("2006-01-02"))
+	require.Equal(t, "youtube", video.Metadata["scout"])
+	require.Equal(t, "social", video.Metadata["format"])
+	require.Equal(t, social.PlatformYouTube, video.Metadata[social.MetadataPlatform])
+	require.Equal(t, "synVideo001", video.Metadata[social.MetadataPostID])
+	require.Equal(t, "UCsyntheticParty0000001", video.Metadata[social.MetadataChannelID])
+	require.Equal(t, "Synthetic Party Channel", video.Metadata[social.MetadataChannel])
+	require.Equal(t, int64(1234), video.Metadata["view_count"])
+
+	require.Equal(t, "https://www.youtube.com/shorts/synShort002", got[1].URL)
+	require.Empty(t, got[1].Description)
+}
+
+func TestScoutDiscover_Empty(t *testing.T) {
+	client := &http.Client{
+		Transport: testutils.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+			body := []byte(`<feed xmlns="http://www.w3.org/2005/Atom"><title>Empty</title></feed>`)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     make(http.Header),
+				Body:       io.NopCloser(testutils.NewReader(body)),
+				Request:    req,
+			}, nil
+		}),
+	}
+	_, err := newScout(t, client).Discover(context.Background(), feedURL)
+	require.ErrorIs(t, err, rootscout.ErrNoCandidatesFound)
+}
+
+func TestNew_Errors(t *testing.T) {
+	_, err := socialscout.New(testutils.Logger(), noop.NewTracerProvider().Tracer("test"), nil, socialscout.Config{
+		Name: "threads", Format: "social", SpanName: "test", Platform: "threads",
+	})
+	require.ErrorIs(t, err, social.ErrUnsupportedPlatform)
+
+	_, err = socialscout.New(testutils.Logger(), noop.NewTracerProvider().Tracer("test"), nil, socialscout.Config{
+		Name: "youtube", Format: "social", SpanName: "test",
+	})
+	require.ErrorIs(t, err, rootscout.ErrConfigFieldEmpty)
+
+	_, err = socialscout.New(nil, noop.NewTracerProvider().Tracer("test"), nil, socialscout.Config{
+		Name: "youtube", Format: "social", SpanName: "test", Platform: "youtube",
+	})
+	require.ErrorIs(t, err, rootscout.ErrParamMissing)
+}
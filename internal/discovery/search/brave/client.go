@@ -102,10 +102,15 @@ type thumbnail struct {
 	Src string `json:"src"`
 }
 
-func (c *Client) DiscoverNews(ctx context.Context, query string, site string) ([]model.Candidates, error) {
-	q := query
-	if site != "" {
-		q = query + " site:" + site
+// DiscoverNews searches Brave. An expanded query is sent as an OR of its
+// variants unless Options.Operators disables search operators.
+func (c *Client) DiscoverNews(ctx context.Context, query discovery.SearchQuery) ([]model.Candidates, error) {
+	q := query.Text
+	if c.opts.Operators == nil || *c.opts.Operators {
+		q = query.OrText()
+	}
+	if query.Site != "" {
+		q += " site:" + query.Site
 	}
 
 	u, err := url.Parse(c.baseURL)
@@ -168,10 +173,10 @@ func (c *Client) DiscoverNews(ctx context.Context, query string, site string) ([
 
 		meta := map[string]any{
 			"search_provider": "brave",
-			"query":           query,
+			"query":           query.Text,
 		}
-		if site != "" {
-			meta["site_filter"] = site
+		if query.Site != "" {
+			meta["site_filter"] = query.Site
 		}
 		if r.Age != "" {
 			meta["age"] = r.Age
@@ -9,6 +9,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/ChiaYuChang/prism/internal/discovery"
+	"github.com/ChiaYuChang/prism/internal/discovery/search"
 	"github.com/ChiaYuChang/prism/internal/discovery/search/brave"
 	"github.com/stretchr/testify/require"
 )
@@ -59,7 +61,7 @@ func TestClient_DiscoverNews_HappyPath(t *testing.T) {
 
 	client := brave.NewClient(httpClient, "test-key", brave.DefaultOptions())
 
-	candidates, err := client.DiscoverNews(context.Background(), "台灣半導體", "")
+	candidates, err := client.DiscoverNews(context.Background(), discovery.SearchQuery{Text: "台灣半導體"})
 	require.NoError(t, err)
 	require.Len(t, candidates, 3)
 
@@ -84,11 +86,37 @@ func TestClient_DiscoverNews_WithSiteFilter(t *testing.T) {
 
 	client := brave.NewClient(httpClient, "key", brave.DefaultOptions())
 
-	candidates, err := client.DiscoverNews(context.Background(), "台灣政策", "cna.com.tw")
+	candidates, err := client.DiscoverNews(context.Background(), discovery.SearchQuery{Text: "台灣政策", Site: "cna.com.tw"})
 	require.NoError(t, err)
 	require.Empty(t, candidates)
 }
 
+func TestClient_DiscoverNews_ExpandedQuery(t *testing.T) {
+	query := discovery.SearchQuery{
+		Text:         "民進黨 立委",
+		Site:         "cna.com.tw",
+		Term:         "民進黨",
+		Alternatives: []string{"民主進步黨"},
+	}
+
+	httpClient := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, "(民進黨 立委) OR (民主進步黨 立委) site:cna.com.tw", r.URL.Query().Get("q"))
+		return jsonResponse(r, http.StatusOK, `{"type":"news","results":[]}`), nil
+	})}
+	_, err := brave.NewClient(httpClient, "key", brave.DefaultOptions()).DiscoverNews(context.Background(), query)
+	require.NoError(t, err)
+
+	off := false
+	opts := brave.DefaultOptions()
+	opts.Operators = &off
+	httpClient = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, "民進黨 立委 site:cna.com.tw", r.URL.Query().Get("q"))
+		return jsonResponse(r, http.StatusOK, `{"type":"news","results":[]}`), nil
+	})}
+	_, err = brave.NewClient(httpClient, "key", opts).DiscoverNews(context.Background(), query)
+	require.NoError(t, err)
+}
+
 func TestClient_DiscoverNews_WithOptionalParams(t *testing.T) {
 	spellcheck := true
 	includeFetchMetadata := true
@@ -128,7 +156,7 @@ func TestClient_DiscoverNews_WithOptionalParams(t *testing.T) {
 		UserAgent:            "prism-test",
 	})
 
-	candidates, err := client.DiscoverNews(context.Background(), "query", "")
+	candidates, err := client.DiscoverNews(context.Background(), discovery.SearchQuery{Text: "query"})
 	require.NoError(t, err)
 	require.Empty(t, candidates)
 }
@@ -140,7 +168,7 @@ func TestClient_DiscoverNews_EmptyResults(t *testing.T) {
 
 	client := brave.NewClient(httpClient, "key", brave.DefaultOptions())
 
-	candidates, err := client.DiscoverNews(context.Background(), "nonexistent query", "")
+	candidates, err := client.DiscoverNews(context.Background(), discovery.SearchQuery{Text: "nonexistent query"})
 	require.NoError(t, err)
 	require.Empty(t, candidates)
 }
@@ -152,9 +180,10 @@ func TestClient_DiscoverNews_RateLimited(t *testing.T) {
 
 	client := brave.NewClient(httpClient, "key", brave.DefaultOptions())
 
-	_, err := client.DiscoverNews(context.Background(), "query", "")
+	_, err := client.DiscoverNews(context.Background(), discovery.SearchQuery{Text: "query"})
 	require.Error(t, err)
 	require.True(t, errors.Is(err, brave.ErrRateLimited))
+	require.ErrorIs(t, err, search.ErrRateLimited)
 }
 
 func TestClient_DiscoverNews_ServerError(t *testing.T) {
@@ -164,7 +193,7 @@ func TestClient_DiscoverNews_ServerError(t *testing.T) {
 
 	client := brave.NewClient(httpClient, "key", brave.DefaultOptions())
 
-	_, err := client.DiscoverNews(context.Background(), "query", "")
+	_, err := client.DiscoverNews(context.Background(), discovery.SearchQuery{Text: "query"})
 	require.Error(t, err)
 	require.False(t, errors.Is(err, brave.ErrRateLimited))
 }
@@ -186,7 +215,7 @@ func TestClient_DiscoverNews_SkipsEmptyTitleOrURL(t *testing.T) {
 
 	client := brave.NewClient(httpClient, "key", brave.DefaultOptions())
 
-	candidates, err := client.DiscoverNews(context.Background(), "test", "")
+	candidates, err := client.DiscoverNews(context.Background(), discovery.SearchQuery{Text: "test"})
 	require.NoError(t, err)
 	require.Len(t, candidates, 1)
 	require.Equal(t, "Valid", candidates[0].Title)
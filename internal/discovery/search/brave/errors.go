@@ -1,5 +1,9 @@
 package brave
 
-import "errors"
+import (
+	"fmt"
 
-var ErrRateLimited = errors.New("brave search: rate limited (429)")
+	"github.com/ChiaYuChang/prism/internal/discovery/search"
+)
+
+var ErrRateLimited = fmt.Errorf("brave search: %w", search.ErrRateLimited)
@@ -0,0 +1,10 @@
+// Package search holds what the web search provider clients (brave,
+// googlecse, serpapi) have in common.
+package search
+
+import "errors"
+
+// ErrRateLimited is wrapped by every provider client when the provider
+// answers 429, so callers can tell a throttled search from a failed one
+// without knowing which provider ran it.
+var ErrRateLimited = errors.New("search: rate limited (429)")
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/ChiaYuChang/prism/internal/discovery"
+	"github.com/ChiaYuChang/prism/internal/discovery/search"
 	"github.com/ChiaYuChang/prism/internal/model"
 )
 
@@ -93,6 +94,28 @@ type item struct {
 	Pagemap     pagemap `json:"pagemap"`
 }
 
+// expandedParams returns the q and orTerms parameters for query. An
+// expanded query searches the rest of its text with orTerms listing Term and
+// its alternatives, so a result must match one of them. When the text is
+// only Term, or Options.OrTerms is already set, the variants are ORed in q.
+func (c *Client) expandedParams(query discovery.SearchQuery) (q, orTerms string) {
+	if !query.Expanded() {
+		return query.Text, c.opts.OrTerms
+	}
+	rest := strings.Join(strings.Fields(strings.ReplaceAll(query.Text, query.Term, " ")), " ")
+	if rest == "" || c.opts.OrTerms != "" {
+		return query.OrText(), c.opts.OrTerms
+	}
+	terms := make([]string, 0, len(query.Alternatives)+1)
+	for _, t := range append([]string{query.Term}, query.Alternatives...) {
+		if strings.ContainsAny(t, " \t") {
+			t = `"` + t + `"`
+		}
+		terms = append(terms, t)
+	}
+	return rest, strings.Join(terms, " ")
+}
+
 type pagemap struct {
 	Thumbnails []thumbnail `json:"cse_thumbnail"`
 }
@@ -102,7 +125,8 @@ type thumbnail struct {
 }
 
 // DiscoverNews executes a Custom Search query and maps results into candidates.
-func (c *Client) DiscoverNews(ctx context.Context, query string, site string) ([]model.Candidates, error) {
+// An expanded query moves its alias group into orTerms; see expandedParams.
+func (c *Client) DiscoverNews(ctx context.Context, query discovery.SearchQuery) ([]model.Candidates, error) {
 	u, err := url.Parse(c.baseURL)
 	if err != nil {
 		return nil, fmt.Errorf("parse google cse url: %w", err)
@@ -110,7 +134,8 @@ func (c *Client) DiscoverNews(ctx context.Context, query string, site string) ([
 	params := u.Query()
 	params.Set("key", c.apiKey)
 	params.Set("cx", c.cx)
-	params.Set("q", query)
+	q, orTerms := c.expandedParams(query)
+	params.Set("q", q)
 	params.Set("num", fmt.Sprintf("%d", c.opts.Count))
 	setQueryParam(params, "lr", c.opts.Language)
 	setQueryParam(params, "cr", c.opts.Country)
@@ -119,14 +144,14 @@ func (c *Client) DiscoverNews(ctx context.Context, query string, site string) ([
 	setQueryParam(params, "dateRestrict", c.opts.DateRestrict)
 	setQueryParam(params, "exactTerms", c.opts.ExactTerms)
 	setQueryParam(params, "excludeTerms", c.opts.ExcludeTerms)
-	setQueryParam(params, "orTerms", c.opts.OrTerms)
+	setQueryParam(params, "orTerms", orTerms)
 	setQueryParam(params, "hq", c.opts.HighQualityTerms)
 	setQueryParam(params, "safe", c.opts.Safe)
 	setQueryParam(params, "sort", c.opts.Sort)
 	setQueryParam(params, "filter", c.opts.Filter)
 	setQueryParam(params, "c2coff", c.opts.ChineseSearch)
-	if site != "" {
-		params.Set("siteSearch", site)
+	if query.Site != "" {
+		params.Set("siteSearch", query.Site)
 		params.Set("siteSearchFilter", "i")
 	}
 	u.RawQuery = params.Encode()
@@ -143,6 +168,9 @@ func (c *Client) DiscoverNews(ctx context.Context, query string, site string) ([
 	}
 	defer func() { _ = resp.Body.Close() }()
 
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, fmt.Errorf("google cse: %w", search.ErrRateLimited)
+	}
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
 		return nil, fmt.Errorf("google cse: status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
@@ -161,11 +189,11 @@ func (c *Client) DiscoverNews(ctx context.Context, query string, site string) ([
 		}
 		meta := map[string]any{
 			"search_provider": "google-cse",
-			"query":           query,
+			"query":           query.Text,
 			"rank":            i + 1,
 		}
-		if site != "" {
-			meta["site_filter"] = site
+		if query.Site != "" {
+			meta["site_filter"] = query.Site
 		}
 		if item.DisplayLink != "" {
 			meta["display_link"] = item.DisplayLink
@@ -8,6 +8,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/ChiaYuChang/prism/internal/discovery"
+	"github.com/ChiaYuChang/prism/internal/discovery/search"
 	"github.com/ChiaYuChang/prism/internal/discovery/search/googlecse"
 	"github.com/stretchr/testify/require"
 )
@@ -53,7 +55,7 @@ func TestClient_DiscoverNews_HappyPath(t *testing.T) {
 
 	client := googlecse.NewClient(httpClient, "test-key", "test-cx", googlecse.DefaultOptions())
 
-	candidates, err := client.DiscoverNews(context.Background(), "台灣半導體", "tw.news.yahoo.com")
+	candidates, err := client.DiscoverNews(context.Background(), discovery.SearchQuery{Text: "台灣半導體", Site: "tw.news.yahoo.com"})
 	require.NoError(t, err)
 	require.Len(t, candidates, 1)
 	require.Equal(t, "Yahoo result", candidates[0].Title)
@@ -65,6 +67,69 @@ func TestClient_DiscoverNews_HappyPath(t *testing.T) {
 	require.Equal(t, "https://example.com/thumb.jpg", candidates[0].Metadata["thumbnail"])
 }
 
+func TestClient_DiscoverNews_ExpandedQueryUsesOrTerms(t *testing.T) {
+	tcs := []struct {
+		name    string
+		query   discovery.SearchQuery
+		opts    func(*googlecse.Options)
+		q       string
+		orTerms string
+	}{
+		{
+			name:    "term moved to orTerms",
+			query:   discovery.SearchQuery{Text: "NCC 裁罰", Term: "NCC", Alternatives: []string{"通傳會", "國家通訊傳播委員會"}},
+			q:       "裁罰",
+			orTerms: "NCC 通傳會 國家通訊傳播委員會",
+		},
+		{
+			name:    "multi-word terms are quoted",
+			query:   discovery.SearchQuery{Text: "Executive Yuan 預算", Term: "Executive Yuan", Alternatives: []string{"行政院"}},
+			q:       "預算",
+			orTerms: `"Executive Yuan" 行政院`,
+		},
+		{
+			name:  "phrase is only the term",
+			query: discovery.SearchQuery{Text: "民進黨", Term: "民進黨", Alternatives: []string{"民主進步黨"}},
+			q:     "民進黨 OR 民主進步黨",
+		},
+		{
+			name:    "configured orTerms kept",
+			query:   discovery.SearchQuery{Text: "民進黨 立委", Term: "民進黨", Alternatives: []string{"民主進步黨"}},
+			opts:    func(o *googlecse.Options) { o.OrTerms = "立法院" },
+			q:       "(民進黨 立委) OR (民主進步黨 立委)",
+			orTerms: "立法院",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			httpClient := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+				require.Equal(t, tc.q, r.URL.Query().Get("q"))
+				require.Equal(t, tc.orTerms, r.URL.Query().Get("orTerms"))
+				return jsonResponse(r, http.StatusOK, `{"items":[]}`), nil
+			})}
+			opts := googlecse.DefaultOptions()
+			if tc.opts != nil {
+				tc.opts(&opts)
+			}
+
+			_, err := googlecse.NewClient(httpClient, "key", "cx", opts).DiscoverNews(context.Background(), tc.query)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestClient_DiscoverNews_RateLimited(t *testing.T) {
+	httpClient := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return jsonResponse(r, http.StatusTooManyRequests, ""), nil
+	})}
+
+	client := googlecse.NewClient(httpClient, "key", "cx", googlecse.DefaultOptions())
+
+	_, err := client.DiscoverNews(context.Background(), discovery.SearchQuery{Text: "query"})
+	require.ErrorIs(t, err, search.ErrRateLimited)
+}
+
 func TestClient_DiscoverNews_ServerError(t *testing.T) {
 	httpClient := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
 		return jsonResponse(r, http.StatusInternalServerError, ""), nil
@@ -72,7 +137,7 @@ func TestClient_DiscoverNews_ServerError(t *testing.T) {
 
 	client := googlecse.NewClient(httpClient, "key", "cx", googlecse.DefaultOptions())
 
-	_, err := client.DiscoverNews(context.Background(), "query", "")
+	_, err := client.DiscoverNews(context.Background(), discovery.SearchQuery{Text: "query"})
 	require.Error(t, err)
 }
 
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/ChiaYuChang/prism/internal/discovery"
+	"github.com/ChiaYuChang/prism/internal/discovery/search"
 	"github.com/ChiaYuChang/prism/internal/model"
 )
 
@@ -103,11 +104,12 @@ func (s *source) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-// DiscoverNews executes a Google News query via SerpAPI.
-func (c *Client) DiscoverNews(ctx context.Context, query string, site string) ([]model.Candidates, error) {
-	q := query
-	if site != "" {
-		q = query + " site:" + site
+// DiscoverNews executes a Google News query via SerpAPI. An expanded query
+// is sent as an OR of its variants.
+func (c *Client) DiscoverNews(ctx context.Context, query discovery.SearchQuery) ([]model.Candidates, error) {
+	q := query.OrText()
+	if query.Site != "" {
+		q += " site:" + query.Site
 	}
 
 	u, err := url.Parse(c.baseURL)
@@ -137,6 +139,9 @@ func (c *Client) DiscoverNews(ctx context.Context, query string, site string) ([
 	}
 	defer func() { _ = resp.Body.Close() }()
 
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, fmt.Errorf("serpapi: %w", search.ErrRateLimited)
+	}
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
 		return nil, fmt.Errorf("serpapi: status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
@@ -155,11 +160,11 @@ func (c *Client) DiscoverNews(ctx context.Context, query string, site string) ([
 		}
 		meta := map[string]any{
 			"search_provider": "serpapi",
-			"query":           query,
+			"query":           query.Text,
 			"rank":            result.Position,
 		}
-		if site != "" {
-			meta["site_filter"] = site
+		if query.Site != "" {
+			meta["site_filter"] = query.Site
 		}
 		if result.Source.Name != "" {
 			meta["source_name"] = result.Source.Name
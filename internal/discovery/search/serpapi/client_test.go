@@ -10,6 +10,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ChiaYuChang/prism/internal/discovery"
+	"github.com/ChiaYuChang/prism/internal/discovery/search"
 	"github.com/ChiaYuChang/prism/internal/discovery/search/serpapi"
 	"github.com/stretchr/testify/require"
 )
@@ -66,7 +68,7 @@ func TestClient_DiscoverNews_HappyPath(t *testing.T) {
 
 	client := serpapi.NewClient(httpClient, "test-key", serpapi.DefaultOptions())
 
-	candidates, err := client.DiscoverNews(context.Background(), "computex", "tw.news.yahoo.com")
+	candidates, err := client.DiscoverNews(context.Background(), discovery.SearchQuery{Text: "computex", Site: "tw.news.yahoo.com"})
 	require.NoError(t, err)
 	require.Len(t, candidates, 2)
 	require.Equal(t, "Yahoo article", candidates[0].Title)
@@ -80,6 +82,17 @@ func TestClient_DiscoverNews_HappyPath(t *testing.T) {
 	require.Equal(t, "Nested Source", candidates[1].Metadata["source_name"])
 }
 
+func TestClient_DiscoverNews_RateLimited(t *testing.T) {
+	httpClient := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return jsonResponse(r, http.StatusTooManyRequests, ""), nil
+	})}
+
+	client := serpapi.NewClient(httpClient, "key", serpapi.DefaultOptions())
+
+	_, err := client.DiscoverNews(context.Background(), discovery.SearchQuery{Text: "query"})
+	require.ErrorIs(t, err, search.ErrRateLimited)
+}
+
 func TestClient_DiscoverNews_ServerError(t *testing.T) {
 	httpClient := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
 		return jsonResponse(r, http.StatusUnauthorized, `{"error":"bad key"}`), nil
@@ -87,7 +100,7 @@ func TestClient_DiscoverNews_ServerError(t *testing.T) {
 
 	client := serpapi.NewClient(httpClient, "key", serpapi.DefaultOptions())
 
-	_, err := client.DiscoverNews(context.Background(), "query", "")
+	_, err := client.DiscoverNews(context.Background(), discovery.SearchQuery{Text: "query"})
 	require.Error(t, err)
 }
 
@@ -98,7 +111,7 @@ func TestClient_DiscoverNews_RequestErrorRedactsAPIKey(t *testing.T) {
 
 	client := serpapi.NewClient(httpClient, "secret-key", serpapi.DefaultOptions())
 
-	_, err := client.DiscoverNews(context.Background(), "query", "")
+	_, err := client.DiscoverNews(context.Background(), discovery.SearchQuery{Text: "query"})
 	require.Error(t, err)
 	require.NotContains(t, err.Error(), "secret-key")
 	require.True(t, strings.Contains(err.Error(), "api_key=REDACTED") || !strings.Contains(err.Error(), "api_key="))
@@ -126,7 +139,25 @@ func TestClient_DiscoverNews_DuckDuckGoParams(t *testing.T) {
 		MaxResults: 75,
 	})
 
-	candidates, err := client.DiscoverNews(context.Background(), "computex", "tw.news.yahoo.com")
+	candidates, err := client.DiscoverNews(context.Background(), discovery.SearchQuery{Text: "computex", Site: "tw.news.yahoo.com"})
+	require.NoError(t, err)
+	require.Empty(t, candidates)
+}
+
+func TestClient_DiscoverNews_ExpandedQuery(t *testing.T) {
+	httpClient := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, "國民黨團 OR 中國國民黨團 site:tw.news.yahoo.com", r.URL.Query().Get("q"))
+		return jsonResponse(r, http.StatusOK, `{"news_results":[]}`), nil
+	})}
+
+	client := serpapi.NewClient(httpClient, "test-key", serpapi.DefaultOptions())
+
+	candidates, err := client.DiscoverNews(context.Background(), discovery.SearchQuery{
+		Text:         "國民黨團",
+		Site:         "tw.news.yahoo.com",
+		Term:         "國民黨",
+		Alternatives: []string{"中國國民黨"},
+	})
 	require.NoError(t, err)
 	require.Empty(t, candidates)
 }
@@ -1,6 +1,6 @@
 //go:build smoke
 
-package search
+package search_test
 
 import (
 	"context"
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/ChiaYuChang/prism/internal/dev"
+	"github.com/ChiaYuChang/prism/internal/discovery"
 	"github.com/ChiaYuChang/prism/internal/discovery/search/brave"
 	searchconfig "github.com/ChiaYuChang/prism/internal/discovery/search/config"
 	"github.com/ChiaYuChang/prism/internal/discovery/search/googlecse"
@@ -47,7 +48,7 @@ func TestSearchProvidersSmokeCapture(t *testing.T) {
 				Freshness:  braveCfg.Freshness,
 			})
 
-			candidates, err := provider.DiscoverNews(ctx, smokeQuery, smokeSite)
+			candidates, err := provider.DiscoverNews(ctx, discovery.SearchQuery{Text: smokeQuery, Site: smokeSite})
 			require.NoError(t, err)
 			t.Logf("brave returned %d candidates", len(candidates))
 		})
@@ -68,7 +69,7 @@ func TestSearchProvidersSmokeCapture(t *testing.T) {
 				DateRestrict:  googleCfg.DateRestrict,
 			})
 
-			candidates, err := provider.DiscoverNews(ctx, smokeQuery, smokeSite)
+			candidates, err := provider.DiscoverNews(ctx, discovery.SearchQuery{Text: smokeQuery, Site: smokeSite})
 			require.NoError(t, err)
 			t.Logf("google-cse returned %d candidates", len(candidates))
 		})
@@ -158,7 +159,7 @@ func runSerpAPIGoogleNewsSmoke(t *testing.T, ctx context.Context, client *http.C
 				NoCache:   cfg.NoCache,
 			})
 
-			candidates, err := provider.DiscoverNews(ctx, smokeQuery, smokeSite)
+			candidates, err := provider.DiscoverNews(ctx, discovery.SearchQuery{Text: smokeQuery, Site: smokeSite})
 			require.NoError(t, err)
 			t.Logf("serpapi-google-news-%s returned %d candidates", name, len(candidates))
 		})
@@ -185,7 +186,7 @@ func runSerpAPIDuckDuckGoNewsSmoke(t *testing.T, ctx context.Context, client *ht
 				NoCache:    cfg.NoCache,
 			})
 
-			candidates, err := provider.DiscoverNews(ctx, smokeQuery, smokeSite)
+			candidates, err := provider.DiscoverNews(ctx, discovery.SearchQuery{Text: smokeQuery, Site: smokeSite})
 			require.NoError(t, err)
 			t.Logf("serpapi-duckduckgo-news-%s returned %d candidates", name, len(candidates))
 		})
@@ -213,7 +214,7 @@ func runSerpAPIBingNewsSmoke(t *testing.T, ctx context.Context, client *http.Cli
 				NoCache:    cfg.NoCache,
 			})
 
-			candidates, err := provider.DiscoverNews(ctx, smokeQuery, smokeSite)
+			candidates, err := provider.DiscoverNews(ctx, discovery.SearchQuery{Text: smokeQuery, Site: smokeSite})
 			require.NoError(t, err)
 			t.Logf("serpapi-bing-news-%s returned %d candidates", name, len(candidates))
 		})
@@ -26,11 +26,13 @@ var (
 // or synchronously before any promotion to full contents.
 type CandidateSink interface {
 	// Handle receives discovered candidates and processes them for storage.
-	Handle(ctx context.Context, req CandidateSinkRequest) error
+	Handle(ctx context.Context, req CandidateSinkRequest) (CandidateSinkResult, error)
 }
 
 // CandidateSinkRequest wraps the payloads and metadata needed to store candidates.
 // It carries batch_id and source_url mapping back to the executing task for auditability.
+// SeedPhrases and SeedContentID carry the seed a KEYWORD_SEARCH ran for; the
+// relevance scorer only runs for requests that have one.
 type CandidateSinkRequest struct {
 	SourceURL       string             `json:"source_url,omitempty"`
 	SourceAbbr      string             `json:"source_abbr,omitempty"`
@@ -40,27 +42,63 @@ type CandidateSinkRequest struct {
 	IngestionMethod string             `json:"ingestion_method,omitempty"`
 	DefaultMetadata map[string]any     `json:"default_metadata,omitempty"`
 	Candidates      []model.Candidates `json:"candidates,omitempty"`
+	SeedPhrases     []string           `json:"seed_phrases,omitempty"`
+	SeedContentID   uuid.UUID          `json:"seed_content_id,omitzero"`
+}
+
+func (r CandidateSinkRequest) hasSeed() bool {
+	return len(r.SeedPhrases) > 0 || r.SeedContentID != uuid.Nil
+}
+
+// CandidateSinkResult counts what Handle stored. New excludes candidates that
+// were already known (same fingerprint); it is the yield adaptive polling
+// works from. Promoted counts MEDIA candidates whose relevance earned them a
+// PAGE_FETCH task.
+type CandidateSinkResult struct {
+	Stored   int `json:"stored"`
+	New      int `json:"new"`
+	Promoted int `json:"promoted"`
 }
 
 // PersistingCandidateSink is the concrete implementation of CandidateSink.
 // In accordance with the system's normalization-first workflow, this sink ensures
 // that candidate briefs fetched by Scouts are inserted into the 'candidates'
 // PG repository. For PARTY sources, a PAGE_FETCH task is created in the tasks
-// table so the scheduler-fast can dispatch it to the Collector Worker.
+// table so the scheduler-fast can dispatch it to the Collector Worker. With
+// WithRelevance, new MEDIA candidates scoring at least the threshold get one
+// too.
 type PersistingCandidateSink struct {
 	logger *slog.Logger
 	tracer trace.Tracer
 	scout  repo.Scout
 	tasks  repo.Tasks
+
+	scorer    Scorer
+	threshold float64
 }
 
 var _ CandidateSink = (*PersistingCandidateSink)(nil)
 
+// Option configures optional PersistingCandidateSink behaviour.
+type Option func(*PersistingCandidateSink)
+
+// WithRelevance scores every new candidate of a request that carries a seed
+// and stores the result under metadata.relevance. New MEDIA candidates scoring
+// at least threshold are promoted to PAGE_FETCH; a threshold of zero or
+// less only records scores.
+func WithRelevance(scorer Scorer, threshold float64) Option {
+	return func(s *PersistingCandidateSink) {
+		s.scorer = scorer
+		s.threshold = threshold
+	}
+}
+
 func NewPersistingCandidateSink(
 	logger *slog.Logger,
 	tracer trace.Tracer,
 	scoutRepo repo.Scout,
 	tasks repo.Tasks,
+	opts ...Option,
 ) (*PersistingCandidateSink, error) {
 	if logger == nil {
 		return nil, fmt.Errorf("%w: logger", ErrParamMissing)
@@ -75,49 +113,82 @@ func NewPersistingCandidateSink(
 		return nil, fmt.Errorf("%w: tasks_repository", ErrParamMissing)
 	}
 
-	return &PersistingCandidateSink{
+	s := &PersistingCandidateSink{
 		logger: logger,
 		tracer: tracer,
 		scout:  scoutRepo,
 		tasks:  tasks,
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
 }
 
 // Handle executes the persistence of candidates into the database using UpsertCandidate.
 // Parameters are merged with request-level metadata to preserve batch context and TraceIDs.
-func (s *PersistingCandidateSink) Handle(ctx context.Context, req CandidateSinkRequest) error {
+func (s *PersistingCandidateSink) Handle(ctx context.Context, req CandidateSinkRequest) (CandidateSinkResult, error) {
 	ctx, span := s.tracer.Start(ctx, "discovery.sink.candidate.handle")
 	defer span.End()
 
-	for _, candidate := range req.Candidates {
+	var result CandidateSinkResult
+	enriched := make([]model.Candidates, len(req.Candidates))
+	for i, candidate := range req.Candidates {
 		enrichedCand, err := applyRequestDefaults(candidate, req)
 		if err != nil {
-			return err
+			return result, err
+		}
+		enriched[i] = enrichedCand
+	}
+	relevance := s.score(ctx, enriched, req)
+
+	for i, enrichedCand := range enriched {
+		rel, scored := relevance[i]
+		if scored {
+			enrichedCand.Metadata = utils.MergeMap(enrichedCand.Metadata, map[string]any{MetadataKeyRelevance: rel})
 		}
 
 		params, err := toUpsertCandidateParams(enrichedCand)
 		if err != nil {
-			return err
+			return result, err
 		}
 
 		stored, err := s.scout.UpsertCandidate(ctx, params)
 		if err != nil {
-			return fmt.Errorf("upsert candidate %s: %w", params.URL, err)
+			return result, fmt.Errorf("upsert candidate %s: %w", params.URL, err)
+		}
+		result.Stored++
+		isNew := isNewCandidate(stored)
+		if isNew {
+			result.New++
 		}
 
-		if shouldCreatePageFetch(req.SourceType) {
+		promoted := s.promote(req.SourceType, isNew, rel, scored)
+		if shouldCreatePageFetch(req.SourceType) || promoted {
 			if err := s.createPageFetchTask(ctx, stored, req); err != nil {
-				return fmt.Errorf("create page fetch task for %s: %w", stored.URL, err)
+				return result, fmt.Errorf("create page fetch task for %s: %w", stored.URL, err)
 			}
 		}
+		if promoted {
+			result.Promoted++
+		}
 	}
 
 	s.logger.DebugContext(ctx, "candidate sink persisted candidates",
 		slog.String("source_url", req.SourceURL),
 		slog.Int("count", len(req.Candidates)),
+		slog.Int("new", result.New),
+		slog.Int("promoted", result.Promoted),
 	)
 
-	return nil
+	return result, nil
+}
+
+// isNewCandidate reports whether UpsertCandidate inserted the row. Both
+// timestamps default to NOW() on insert; a conflict bumps only
+// discovered_at, to a later transaction's NOW().
+func isNewCandidate(c repo.Candidate) bool {
+	return c.DiscoveredAt.Equal(c.CreatedAt)
 }
 
 // applyRequestDefaults applies default values from the request to the candidate.
@@ -194,7 +265,79 @@ func mergeMetadata(defaultMetadata, candidateMetadata map[string]any) map[string
 }
 
 func shouldCreatePageFetch(sourceType string) bool {
-	return strings.EqualFold(strings.TrimSpace(sourceType), repo.SourceTypeParty)
+	return repo.IsSeedSourceType(sourceType)
+}
+
+// score rates the candidates of req that are not stored yet, when the sink
+// has a scorer and req carries a seed, keyed by index into candidates.
+// Directory polls have no seed to be relevant to and are left unscored. So
+// are known candidates: the upsert keeps their stored metadata and only new
+// candidates are promoted. A lookup or scorer error is logged and leaves the
+// request unscored.
+func (s *PersistingCandidateSink) score(ctx context.Context, candidates []model.Candidates, req CandidateSinkRequest) map[int]Relevance {
+	if s.scorer == nil || !req.hasSeed() || len(candidates) == 0 {
+		return nil
+	}
+	fingerprints := make([]string, len(candidates))
+	for i, candidate := range candidates {
+		fingerprints[i] = candidate.Fingerprint()
+	}
+	known, err := s.scout.GetCandidatesByFingerprints(ctx, fingerprints)
+	if err != nil {
+		s.logger.WarnContext(ctx, "look up known candidates failed",
+			slog.String("source_url", req.SourceURL),
+			slog.Any("error", err),
+		)
+		return nil
+	}
+	stored := make(map[string]struct{}, len(known))
+	for _, c := range known {
+		stored[c.Fingerprint] = struct{}{}
+	}
+
+	var fresh []model.Candidates
+	var index []int
+	for i, candidate := range candidates {
+		if _, ok := stored[fingerprints[i]]; ok {
+			continue
+		}
+		fresh = append(fresh, candidate)
+		index = append(index, i)
+	}
+	if len(fresh) == 0 {
+		return nil
+	}
+
+	rels, err := s.scorer.Score(ctx, RelevanceInput{
+		Candidates:    fresh,
+		SourceType:    req.SourceType,
+		SeedPhrases:   req.SeedPhrases,
+		SeedContentID: req.SeedContentID,
+	})
+	if err == nil && len(rels) != len(fresh) {
+		err = fmt.Errorf("got %d scores for %d candidates", len(rels), len(fresh))
+	}
+	if err != nil {
+		s.logger.WarnContext(ctx, "score candidate relevance failed",
+			slog.String("source_url", req.SourceURL),
+			slog.Any("error", err),
+		)
+		return nil
+	}
+	out := make(map[int]Relevance, len(rels))
+	for j, rel := range rels {
+		out[index[j]] = rel
+	}
+	return out
+}
+
+// promote reports whether a MEDIA candidate earned a PAGE_FETCH task. Only
+// new candidates qualify, so a search that keeps returning an article does
+// not fetch it again each run.
+func (s *PersistingCandidateSink) promote(sourceType string, isNew bool, rel Relevance, scored bool) bool {
+	return scored && isNew && s.threshold > 0 &&
+		strings.EqualFold(strings.TrimSpace(sourceType), repo.SourceTypeMedia) &&
+		rel.Score >= s.threshold
 }
 
 // createPageFetchTask inserts a PAGE_FETCH task for the given candidate.
@@ -3,10 +3,12 @@ package sink_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/ChiaYuChang/prism/internal/discovery/sink"
+	sinkmocks "github.com/ChiaYuChang/prism/internal/discovery/sink/mocks"
 	"github.com/ChiaYuChang/prism/internal/model"
 	"github.com/ChiaYuChang/prism/internal/repo"
 	repomocks "github.com/ChiaYuChang/prism/internal/repo/mocks"
@@ -39,7 +41,7 @@ func TestPersistingCandidateSinkHandleUsesRequestDefaults(t *testing.T) {
 		Return(repo.Candidate{}, nil).
 		Once()
 
-	err = s.Handle(context.Background(), sink.CandidateSinkRequest{
+	_, err = s.Handle(context.Background(), sink.CandidateSinkRequest{
 		SourceURL:       "https://example.com/listing",
 		SourceAbbr:      "kmt",
 		SourceType:      "MEDIA",
@@ -108,7 +110,7 @@ func TestPersistingCandidateSinkHandleUsesCandidateOverrides(t *testing.T) {
 		Return(repo.Candidate{}, nil).
 		Once()
 
-	err = s.Handle(context.Background(), sink.CandidateSinkRequest{
+	_, err = s.Handle(context.Background(), sink.CandidateSinkRequest{
 		SourceAbbr: "dpp",
 		TraceID:    "trace-default",
 		Candidates: []model.Candidates{
@@ -142,7 +144,7 @@ func TestPersistingCandidateSinkHandleReturnsErrorWhenSourceIDMissing(t *testing
 	)
 	require.NoError(t, err)
 
-	err = s.Handle(context.Background(), sink.CandidateSinkRequest{
+	_, err = s.Handle(context.Background(), sink.CandidateSinkRequest{
 		TraceID: "trace-default",
 		Candidates: []model.Candidates{
 			{URL: "https://example.com/a", Title: "Example"},
@@ -162,7 +164,7 @@ func TestPersistingCandidateSinkHandleReturnsErrorWhenTraceIDMissing(t *testing.
 	)
 	require.NoError(t, err)
 
-	err = s.Handle(context.Background(), sink.CandidateSinkRequest{
+	_, err = s.Handle(context.Background(), sink.CandidateSinkRequest{
 		SourceAbbr: "dpp",
 		Candidates: []model.Candidates{
 			{URL: "https://example.com/a", Title: "Example"},
@@ -172,6 +174,15 @@ func TestPersistingCandidateSinkHandleReturnsErrorWhenTraceIDMissing(t *testing.
 }
 
 func TestPersistingCandidateSinkCreatesPageFetchTaskForPartySource(t *testing.T) {
+	for _, sourceType := range []string{repo.SourceTypeParty, repo.SourceTypeGovernment} {
+		t.Run(sourceType, func(t *testing.T) {
+			testCreatesPageFetchTask(t, sourceType)
+		})
+	}
+}
+
+func testCreatesPageFetchTask(t *testing.T, sourceType string) {
+	t.Helper()
 	scoutRepo := repomocks.NewMockScout(t)
 	tasksRepo := repomocks.NewMockTasks(t)
 	s, err := sink.NewPersistingCandidateSink(
@@ -204,10 +215,10 @@ func TestPersistingCandidateSinkCreatesPageFetchTaskForPartySource(t *testing.T)
 		Return(repo.Task{}, nil).
 		Once()
 
-	err = s.Handle(context.Background(), sink.CandidateSinkRequest{
+	_, err = s.Handle(context.Background(), sink.CandidateSinkRequest{
 		SourceURL:       "https://example.com/listing",
 		SourceAbbr:      "dpp",
-		SourceType:      "PARTY",
+		SourceType:      sourceType,
 		BatchID:         batchID,
 		TraceID:         "trace-default",
 		IngestionMethod: repo.IngestionMethodDirectory,
@@ -220,8 +231,205 @@ func TestPersistingCandidateSinkCreatesPageFetchTaskForPartySource(t *testing.T)
 	})
 	require.NoError(t, err)
 	require.Equal(t, repo.TaskKindPageFetch, gotParams.Kind)
-	require.Equal(t, "PARTY", gotParams.SourceType)
+	require.Equal(t, sourceType, gotParams.SourceType)
 	require.Equal(t, "https://example.com/a", gotParams.URL)
 	require.Equal(t, "trace-default", gotParams.TraceID)
 	require.Equal(t, batchID, gotParams.BatchID)
 }
+
+func TestPersistingCandidateSinkHandleCountsNewCandidates(t *testing.T) {
+	scoutRepo := repomocks.NewMockScout(t)
+	tasksRepo := repomocks.NewMockTasks(t)
+	s, err := sink.NewPersistingCandidateSink(
+		testutils.Logger(),
+		noop.NewTracerProvider().Tracer("test"),
+		scoutRepo,
+		tasksRepo,
+	)
+	require.NoError(t, err)
+
+	inserted := time.Date(2026, 4, 4, 9, 0, 0, 0, time.UTC)
+	scoutRepo.On("UpsertCandidate", mock.Anything, mock.MatchedBy(func(p repo.UpsertCandidateParams) bool {
+		return p.URL == "https://example.com/new"
+	})).Return(repo.Candidate{CreatedAt: inserted, DiscoveredAt: inserted}, nil).Once()
+	scoutRepo.On("UpsertCandidate", mock.Anything, mock.MatchedBy(func(p repo.UpsertCandidateParams) bool {
+		return p.URL == "https://example.com/seen"
+	})).Return(repo.Candidate{CreatedAt: inserted.Add(-time.Hour), DiscoveredAt: inserted}, nil).Once()
+
+	result, err := s.Handle(context.Background(), sink.CandidateSinkRequest{
+		SourceAbbr: "kmt",
+		SourceType: repo.SourceTypeMedia,
+		TraceID:    "trace-default",
+		Candidates: []model.Candidates{
+			{URL: "https://example.com/new", Title: "New"},
+			{URL: "https://example.com/seen", Title: "Seen"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, sink.CandidateSinkResult{Stored: 2, New: 1}, result)
+}
+
+func TestPersistingCandidateSinkPromotesRelevantMediaCandidates(t *testing.T) {
+	scoutRepo := repomocks.NewMockScout(t)
+	tasksRepo := repomocks.NewMockTasks(t)
+	scorer, err := sink.NewWeightedScorer(testutils.Logger(),
+		sink.WeightedSignal{Signal: sink.KeywordSignal{}, Weight: 1},
+	)
+	require.NoError(t, err)
+	s, err := sink.NewPersistingCandidateSink(
+		testutils.Logger(),
+		noop.NewTracerProvider().Tracer("test"),
+		scoutRepo,
+		tasksRepo,
+		sink.WithRelevance(scorer, 0.6),
+	)
+	require.NoError(t, err)
+
+	inserted := time.Date(2026, 4, 4, 9, 0, 0, 0, time.UTC)
+	seen := model.Candidates{URL: "https://example.com/seen", Title: "NCC 裁罰案續報"}
+	scoutRepo.On("GetCandidatesByFingerprints", mock.Anything, mock.MatchedBy(func(fps []string) bool {
+		return len(fps) == 3
+	})).Return([]repo.Candidate{{URL: seen.URL, Fingerprint: seen.Fingerprint()}}, nil).Once()
+	relevance := map[string]float64{}
+	scoutRepo.On("UpsertCandidate", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, p repo.UpsertCandidateParams) (repo.Candidate, error) {
+			if p.Metadata != nil {
+				var metadata struct {
+					Relevance sink.Relevance `json:"relevance"`
+				}
+				require.NoError(t, json.Unmarshal(p.Metadata, &metadata))
+				relevance[p.URL] = metadata.Relevance.Score
+			}
+			created := inserted
+			if p.URL == "https://example.com/seen" {
+				created = inserted.Add(-time.Hour)
+			}
+			return repo.Candidate{URL: p.URL, SourceAbbr: p.SourceAbbr, CreatedAt: created, DiscoveredAt: inserted}, nil
+		}).
+		Times(3)
+	tasksRepo.On("CreateTask", mock.Anything, mock.MatchedBy(func(p repo.CreateTaskParams) bool {
+		return p.URL == "https://example.com/hit" && p.Kind == repo.TaskKindPageFetch && p.SourceType == repo.SourceTypeMedia
+	})).Return(repo.Task{}, nil).Once()
+
+	result, err := s.Handle(context.Background(), sink.CandidateSinkRequest{
+		SourceAbbr:  "yahoo",
+		SourceType:  repo.SourceTypeMedia,
+		TraceID:     "trace-default",
+		SeedPhrases: []string{"NCC 裁罰"},
+		Candidates: []model.Candidates{
+			{URL: "https://example.com/hit", Title: "NCC 裁罰兩家電視台"},
+			{URL: "https://example.com/miss", Title: "NCC 主委出訪"},
+			seen,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, sink.CandidateSinkResult{Stored: 3, New: 2, Promoted: 1}, result)
+	require.Equal(t, map[string]float64{
+		"https://example.com/hit":  1,
+		"https://example.com/miss": 0.5,
+	}, relevance, "a stored candidate is not scored again")
+}
+
+func TestPersistingCandidateSinkScoresRequestOnce(t *testing.T) {
+	scoutRepo := repomocks.NewMockScout(t)
+	tasksRepo := repomocks.NewMockTasks(t)
+	scorer := sinkmocks.NewMockScorer(t)
+	s, err := sink.NewPersistingCandidateSink(
+		testutils.Logger(),
+		noop.NewTracerProvider().Tracer("test"),
+		scoutRepo,
+		tasksRepo,
+		sink.WithRelevance(scorer, 0.5),
+	)
+	require.NoError(t, err)
+
+	scoutRepo.On("GetCandidatesByFingerprints", mock.Anything, mock.Anything).Return(nil, nil).Once()
+	scorer.EXPECT().Score(mock.Anything, mock.MatchedBy(func(in sink.RelevanceInput) bool {
+		return len(in.Candidates) == 2 && in.Candidates[1].URL == "https://example.com/b"
+	})).Return([]sink.Relevance{{Score: 0.2}, {Score: 0.9}}, nil).Once()
+
+	inserted := time.Date(2026, 4, 4, 9, 0, 0, 0, time.UTC)
+	scoutRepo.On("UpsertCandidate", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, p repo.UpsertCandidateParams) (repo.Candidate, error) {
+			return repo.Candidate{URL: p.URL, SourceAbbr: p.SourceAbbr, CreatedAt: inserted, DiscoveredAt: inserted}, nil
+		}).
+		Times(2)
+	tasksRepo.On("CreateTask", mock.Anything, mock.MatchedBy(func(p repo.CreateTaskParams) bool {
+		return p.URL == "https://example.com/b"
+	})).Return(repo.Task{}, nil).Once()
+
+	result, err := s.Handle(context.Background(), sink.CandidateSinkRequest{
+		SourceAbbr:  "yahoo",
+		SourceType:  repo.SourceTypeMedia,
+		TraceID:     "trace-default",
+		SeedPhrases: []string{"NCC"},
+		Candidates: []model.Candidates{
+			{URL: "https://example.com/a", Title: "A"},
+			{URL: "https://example.com/b", Title: "B"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, sink.CandidateSinkResult{Stored: 2, New: 2, Promoted: 1}, result)
+}
+
+func TestPersistingCandidateSinkLookupErrorLeavesRequestUnscored(t *testing.T) {
+	scoutRepo := repomocks.NewMockScout(t)
+	tasksRepo := repomocks.NewMockTasks(t)
+	scorer := sinkmocks.NewMockScorer(t)
+	s, err := sink.NewPersistingCandidateSink(
+		testutils.Logger(),
+		noop.NewTracerProvider().Tracer("test"),
+		scoutRepo,
+		tasksRepo,
+		sink.WithRelevance(scorer, 0.5),
+	)
+	require.NoError(t, err)
+
+	scoutRepo.On("GetCandidatesByFingerprints", mock.Anything, mock.Anything).
+		Return(nil, errors.New("db down")).Once()
+	inserted := time.Date(2026, 4, 4, 9, 0, 0, 0, time.UTC)
+	scoutRepo.On("UpsertCandidate", mock.Anything, mock.MatchedBy(func(p repo.UpsertCandidateParams) bool {
+		return p.Metadata == nil
+	})).Return(repo.Candidate{CreatedAt: inserted, DiscoveredAt: inserted}, nil).Once()
+
+	result, err := s.Handle(context.Background(), sink.CandidateSinkRequest{
+		SourceAbbr:  "yahoo",
+		SourceType:  repo.SourceTypeMedia,
+		TraceID:     "trace-default",
+		SeedPhrases: []string{"NCC"},
+		Candidates:  []model.Candidates{{URL: "https://example.com/a", Title: "A"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, sink.CandidateSinkResult{Stored: 1, New: 1}, result)
+}
+
+func TestPersistingCandidateSinkSkipsRelevanceWithoutSeed(t *testing.T) {
+	scoutRepo := repomocks.NewMockScout(t)
+	tasksRepo := repomocks.NewMockTasks(t)
+	scorer, err := sink.NewWeightedScorer(testutils.Logger(),
+		sink.WeightedSignal{Signal: sink.SourcePriorSignal{Default: 1}, Weight: 1},
+	)
+	require.NoError(t, err)
+	s, err := sink.NewPersistingCandidateSink(
+		testutils.Logger(),
+		noop.NewTracerProvider().Tracer("test"),
+		scoutRepo,
+		tasksRepo,
+		sink.WithRelevance(scorer, 0.5),
+	)
+	require.NoError(t, err)
+
+	inserted := time.Date(2026, 4, 4, 9, 0, 0, 0, time.UTC)
+	scoutRepo.On("UpsertCandidate", mock.Anything, mock.MatchedBy(func(p repo.UpsertCandidateParams) bool {
+		return p.Metadata == nil
+	})).Return(repo.Candidate{CreatedAt: inserted, DiscoveredAt: inserted}, nil).Once()
+
+	result, err := s.Handle(context.Background(), sink.CandidateSinkRequest{
+		SourceAbbr: "cna",
+		SourceType: repo.SourceTypeMedia,
+		TraceID:    "trace-default",
+		Candidates: []model.Candidates{{URL: "https://example.com/a", Title: "A"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, sink.CandidateSinkResult{Stored: 1, New: 1}, result)
+}
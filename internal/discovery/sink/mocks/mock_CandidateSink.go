@@ -39,20 +39,29 @@ func (_m *MockCandidateSink) EXPECT() *MockCandidateSink_Expecter {
 }
 
 // Handle provides a mock function for the type MockCandidateSink
-func (_mock *MockCandidateSink) Handle(ctx context.Context, req sink.CandidateSinkRequest) error {
+func (_mock *MockCandidateSink) Handle(ctx context.Context, req sink.CandidateSinkRequest) (sink.CandidateSinkResult, error) {
 	ret := _mock.Called(ctx, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Handle")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, sink.CandidateSinkRequest) error); ok {
+	var r0 sink.CandidateSinkResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sink.CandidateSinkRequest) (sink.CandidateSinkResult, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sink.CandidateSinkRequest) sink.CandidateSinkResult); ok {
 		r0 = returnFunc(ctx, req)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(sink.CandidateSinkResult)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, sink.CandidateSinkRequest) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
 	}
-	return r0
+	return r0, r1
 }
 
 // MockCandidateSink_Handle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Handle'
@@ -85,12 +94,12 @@ func (_c *MockCandidateSink_Handle_Call) Run(run func(ctx context.Context, req s
 	return _c
 }
 
-func (_c *MockCandidateSink_Handle_Call) Return(err error) *MockCandidateSink_Handle_Call {
-	_c.Call.Return(err)
+func (_c *MockCandidateSink_Handle_Call) Return(candidateSinkResult sink.CandidateSinkResult, err error) *MockCandidateSink_Handle_Call {
+	_c.Call.Return(candidateSinkResult, err)
 	return _c
 }
 
-func (_c *MockCandidateSink_Handle_Call) RunAndReturn(run func(ctx context.Context, req sink.CandidateSinkRequest) error) *MockCandidateSink_Handle_Call {
+func (_c *MockCandidateSink_Handle_Call) RunAndReturn(run func(ctx context.Context, req sink.CandidateSinkRequest) (sink.CandidateSinkResult, error)) *MockCandidateSink_Handle_Call {
 	_c.Call.Return(run)
 	return _c
 }
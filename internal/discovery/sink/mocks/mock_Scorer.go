@@ -0,0 +1,107 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/ChiaYuChang/prism/internal/discovery/sink"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockScorer creates a new instance of MockScorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockScorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockScorer {
+	mock := &MockScorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockScorer is an autogenerated mock type for the Scorer type
+type MockScorer struct {
+	mock.Mock
+}
+
+type MockScorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockScorer) EXPECT() *MockScorer_Expecter {
+	return &MockScorer_Expecter{mock: &_m.Mock}
+}
+
+// Score provides a mock function for the type MockScorer
+func (_mock *MockScorer) Score(ctx context.Context, in sink.RelevanceInput) ([]sink.Relevance, error) {
+	ret := _mock.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Score")
+	}
+
+	var r0 []sink.Relevance
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sink.RelevanceInput) ([]sink.Relevance, error)); ok {
+		return returnFunc(ctx, in)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sink.RelevanceInput) []sink.Relevance); ok {
+		r0 = returnFunc(ctx, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sink.Relevance)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, sink.RelevanceInput) error); ok {
+		r1 = returnFunc(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockScorer_Score_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Score'
+type MockScorer_Score_Call struct {
+	*mock.Call
+}
+
+// Score is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in sink.RelevanceInput
+func (_e *MockScorer_Expecter) Score(ctx interface{}, in interface{}) *MockScorer_Score_Call {
+	return &MockScorer_Score_Call{Call: _e.mock.On("Score", ctx, in)}
+}
+
+func (_c *MockScorer_Score_Call) Run(run func(ctx context.Context, in sink.RelevanceInput)) *MockScorer_Score_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 sink.RelevanceInput
+		if args[1] != nil {
+			arg1 = args[1].(sink.RelevanceInput)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockScorer_Score_Call) Return(relevances []sink.Relevance, err error) *MockScorer_Score_Call {
+	_c.Call.Return(relevances, err)
+	return _c
+}
+
+func (_c *MockScorer_Score_Call) RunAndReturn(run func(ctx context.Context, in sink.RelevanceInput) ([]sink.Relevance, error)) *MockScorer_Score_Call {
+	_c.Call.Return(run)
+	return _c
+}
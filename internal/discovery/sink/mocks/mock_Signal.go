@@ -0,0 +1,151 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/ChiaYuChang/prism/internal/discovery/sink"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockSignal creates a new instance of MockSignal. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSignal(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSignal {
+	mock := &MockSignal{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSignal is an autogenerated mock type for the Signal type
+type MockSignal struct {
+	mock.Mock
+}
+
+type MockSignal_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSignal) EXPECT() *MockSignal_Expecter {
+	return &MockSignal_Expecter{mock: &_m.Mock}
+}
+
+// Name provides a mock function for the type MockSignal
+func (_mock *MockSignal) Name() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Name")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// MockSignal_Name_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Name'
+type MockSignal_Name_Call struct {
+	*mock.Call
+}
+
+// Name is a helper method to define mock.On call
+func (_e *MockSignal_Expecter) Name() *MockSignal_Name_Call {
+	return &MockSignal_Name_Call{Call: _e.mock.On("Name")}
+}
+
+func (_c *MockSignal_Name_Call) Run(run func()) *MockSignal_Name_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockSignal_Name_Call) Return(s string) *MockSignal_Name_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *MockSignal_Name_Call) RunAndReturn(run func() string) *MockSignal_Name_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Score provides a mock function for the type MockSignal
+func (_mock *MockSignal) Score(ctx context.Context, in sink.RelevanceInput) ([]sink.SignalScore, error) {
+	ret := _mock.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Score")
+	}
+
+	var r0 []sink.SignalScore
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sink.RelevanceInput) ([]sink.SignalScore, error)); ok {
+		return returnFunc(ctx, in)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sink.RelevanceInput) []sink.SignalScore); ok {
+		r0 = returnFunc(ctx, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sink.SignalScore)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, sink.RelevanceInput) error); ok {
+		r1 = returnFunc(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSignal_Score_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Score'
+type MockSignal_Score_Call struct {
+	*mock.Call
+}
+
+// Score is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in sink.RelevanceInput
+func (_e *MockSignal_Expecter) Score(ctx interface{}, in interface{}) *MockSignal_Score_Call {
+	return &MockSignal_Score_Call{Call: _e.mock.On("Score", ctx, in)}
+}
+
+func (_c *MockSignal_Score_Call) Run(run func(ctx context.Context, in sink.RelevanceInput)) *MockSignal_Score_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 sink.RelevanceInput
+		if args[1] != nil {
+			arg1 = args[1].(sink.RelevanceInput)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSignal_Score_Call) Return(signalScores []sink.SignalScore, err error) *MockSignal_Score_Call {
+	_c.Call.Return(signalScores, err)
+	return _c
+}
+
+func (_c *MockSignal_Score_Call) RunAndReturn(run func(ctx context.Context, in sink.RelevanceInput) ([]sink.SignalScore, error)) *MockSignal_Score_Call {
+	_c.Call.Return(run)
+	return _c
+}
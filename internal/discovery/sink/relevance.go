@@ -0,0 +1,313 @@
+package sink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+
+	"github.com/ChiaYuChang/prism/internal/llm"
+	"github.com/ChiaYuChang/prism/internal/model"
+	"github.com/ChiaYuChang/prism/internal/repo"
+	"github.com/google/uuid"
+)
+
+// MetadataKeyRelevance is the candidate metadata key the sink stores a
+// Relevance under.
+const MetadataKeyRelevance = "relevance"
+
+var ErrNoSignals = errors.New("no relevance signals")
+
+// RelevanceInput is what a Scorer sees of one request: its candidates after
+// request defaults are applied and the seed context they were found for.
+type RelevanceInput struct {
+	Candidates    []model.Candidates
+	SourceType    string
+	SeedPhrases   []string
+	SeedContentID uuid.UUID
+}
+
+// Relevance is a candidate's score in [0, 1] and the signal scores it was
+// combined from, keyed by signal name.
+type Relevance struct {
+	Score   float64            `json:"score"`
+	Signals map[string]float64 `json:"signals,omitempty"`
+}
+
+// Scorer rates how relevant each candidate is to the seed that led to it.
+// Score returns one Relevance per candidate of in, in order.
+type Scorer interface {
+	Score(ctx context.Context, in RelevanceInput) ([]Relevance, error)
+}
+
+// SignalScore is a Signal's value in [0, 1] for one candidate. OK is false
+// when the signal has nothing to go on for it, e.g. no seed phrases or no
+// seed embedding.
+type SignalScore struct {
+	Value float64
+	OK    bool
+}
+
+// Signal is one input of a WeightedScorer. Score returns one SignalScore per
+// candidate of in, in order. It sees the whole request so that per-request
+// work, such as loading the seed embedding, is done once.
+type Signal interface {
+	Name() string
+	Score(ctx context.Context, in RelevanceInput) ([]SignalScore, error)
+}
+
+// WeightedSignal pairs a Signal with its weight in a WeightedScorer.
+type WeightedSignal struct {
+	Signal Signal
+	Weight float64
+}
+
+// WeightedScorer scores a candidate as the weighted mean of the signals that
+// are available for it. A signal that fails is logged and left out, so a
+// flaky embedder lowers the confidence of a score instead of failing the
+// batch.
+type WeightedScorer struct {
+	logger  *slog.Logger
+	signals []WeightedSignal
+}
+
+var _ Scorer = (*WeightedScorer)(nil)
+
+// NewWeightedScorer keeps the signals with a positive weight and returns
+// ErrNoSignals when none is left.
+func NewWeightedScorer(logger *slog.Logger, signals ...WeightedSignal) (*WeightedScorer, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("%w: logger", ErrParamMissing)
+	}
+	s := &WeightedScorer{logger: logger}
+	for _, sig := range signals {
+		if sig.Signal == nil || sig.Weight <= 0 {
+			continue
+		}
+		s.signals = append(s.signals, sig)
+	}
+	if len(s.signals) == 0 {
+		return nil, ErrNoSignals
+	}
+	return s, nil
+}
+
+// Score gives a candidate with no available signal a zero Relevance without
+// signals.
+func (s *WeightedScorer) Score(ctx context.Context, in RelevanceInput) ([]Relevance, error) {
+	out := make([]Relevance, len(in.Candidates))
+	if len(in.Candidates) == 0 {
+		return out, nil
+	}
+	sums := make([]float64, len(in.Candidates))
+	weights := make([]float64, len(in.Candidates))
+	for _, sig := range s.signals {
+		scores, err := sig.Signal.Score(ctx, in)
+		if err == nil && len(scores) != len(in.Candidates) {
+			err = fmt.Errorf("got %d scores for %d candidates", len(scores), len(in.Candidates))
+		}
+		if err != nil {
+			s.logger.WarnContext(ctx, "relevance signal failed",
+				slog.String("signal", sig.Signal.Name()),
+				slog.Int("candidates", len(in.Candidates)),
+				slog.Any("error", err),
+			)
+			continue
+		}
+		for i, score := range scores {
+			if !score.OK {
+				continue
+			}
+			value := clamp01(score.Value)
+			if out[i].Signals == nil {
+				out[i].Signals = make(map[string]float64, len(s.signals))
+			}
+			out[i].Signals[sig.Signal.Name()] = value
+			sums[i] += value * sig.Weight
+			weights[i] += sig.Weight
+		}
+	}
+	for i := range out {
+		if weights[i] > 0 {
+			out[i].Score = sums[i] / weights[i]
+		}
+	}
+	return out, nil
+}
+
+// KeywordSignal scores the share of a seed phrase's words found in the
+// candidate's title and description, taking the best phrase. Matching is by
+// substring, which suits Chinese text where phrase words are not separated
+// by spaces in the headline.
+type KeywordSignal struct{}
+
+func (KeywordSignal) Name() string { return "keyword" }
+
+func (KeywordSignal) Score(_ context.Context, in RelevanceInput) ([]SignalScore, error) {
+	var phrases [][]string
+	for _, phrase := range in.SeedPhrases {
+		if words := strings.Fields(strings.ToLower(phrase)); len(words) > 0 {
+			phrases = append(phrases, words)
+		}
+	}
+	out := make([]SignalScore, len(in.Candidates))
+	if len(phrases) == 0 {
+		return out, nil
+	}
+	for i, c := range in.Candidates {
+		text := strings.ToLower(c.Title + " " + c.Description)
+		best := 0.0
+		for _, words := range phrases {
+			hits := 0
+			for _, w := range words {
+				if strings.Contains(text, w) {
+					hits++
+				}
+			}
+			best = math.Max(best, float64(hits)/float64(len(words)))
+		}
+		out[i] = SignalScore{Value: best, OK: true}
+	}
+	return out, nil
+}
+
+// SourcePriorSignal scores a candidate by how often its outlet is worth
+// fetching, as judged by the operator. Outlets missing from Priors get
+// Default. Keys are source abbreviations, matched case-insensitively.
+type SourcePriorSignal struct {
+	Priors  map[string]float64
+	Default float64
+}
+
+func (SourcePriorSignal) Name() string { return "prior" }
+
+func (s SourcePriorSignal) Score(_ context.Context, in RelevanceInput) ([]SignalScore, error) {
+	out := make([]SignalScore, len(in.Candidates))
+	for i, c := range in.Candidates {
+		out[i] = SignalScore{Value: s.prior(c.SourceAbbr), OK: true}
+	}
+	return out, nil
+}
+
+func (s SourcePriorSignal) prior(sourceAbbr string) float64 {
+	for k, v := range s.Priors {
+		if strings.EqualFold(k, sourceAbbr) {
+			return v
+		}
+	}
+	return s.Default
+}
+
+// EmbeddingSignal scores the cosine similarity between the candidate's
+// title and description and the seed release, using the seed's stored
+// embedding from the same model. It is unavailable when the request names
+// no seed content or the seed has not been embedded with that model. The
+// seed embedding is loaded once per request and the candidates are embedded
+// in a single call.
+type EmbeddingSignal struct {
+	embedder   llm.Embedder
+	embeddings repo.Embeddings
+	model      string
+	modelID    int16
+}
+
+// NewEmbeddingSignal embeds candidates with model, registered in models
+// under modelID.
+func NewEmbeddingSignal(embedder llm.Embedder, embeddings repo.Embeddings, model string, modelID int16) (*EmbeddingSignal, error) {
+	if embedder == nil {
+		return nil, fmt.Errorf("%w: embedder", ErrParamMissing)
+	}
+	if embeddings == nil {
+		return nil, fmt.Errorf("%w: embeddings_repository", ErrParamMissing)
+	}
+	if model == "" {
+		return nil, fmt.Errorf("%w: model", ErrParamMissing)
+	}
+	return &EmbeddingSignal{embedder: embedder, embeddings: embeddings, model: model, modelID: modelID}, nil
+}
+
+func (*EmbeddingSignal) Name() string { return "embedding" }
+
+func (s *EmbeddingSignal) Score(ctx context.Context, in RelevanceInput) ([]SignalScore, error) {
+	out := make([]SignalScore, len(in.Candidates))
+	if in.SeedContentID == uuid.Nil || len(in.Candidates) == 0 {
+		return out, nil
+	}
+	seed, err := s.seedVector(ctx, in.SeedContentID)
+	if err != nil {
+		return nil, err
+	}
+	if seed == nil {
+		return out, nil
+	}
+
+	var texts []string
+	var index []int
+	for i, c := range in.Candidates {
+		text := strings.TrimSpace(c.Title + "\n" + c.Description)
+		if text == "" {
+			continue
+		}
+		texts = append(texts, text)
+		index = append(index, i)
+	}
+	if len(texts) == 0 {
+		return out, nil
+	}
+	resp, err := s.embedder.Embed(ctx, llm.NewEmbedRequest(s.model, texts...))
+	if err != nil {
+		return nil, fmt.Errorf("embed candidates: %w", err)
+	}
+	if resp == nil || len(resp.Vectors) != len(texts) {
+		got := 0
+		if resp != nil {
+			got = len(resp.Vectors)
+		}
+		return nil, fmt.Errorf("embed candidates: got %d vectors for %d texts", got, len(texts))
+	}
+	for j, vec := range resp.Vectors {
+		sim, err := cosine(seed, vec)
+		if err != nil {
+			return nil, err
+		}
+		out[index[j]] = SignalScore{Value: sim, OK: true}
+	}
+	return out, nil
+}
+
+// seedVector returns the seed's embedding from the signal's model, or nil
+// when the seed has not been embedded with it.
+func (s *EmbeddingSignal) seedVector(ctx context.Context, seedID uuid.UUID) ([]float32, error) {
+	rows, err := s.embeddings.ListContentEmbeddings(ctx, seedID)
+	if err != nil {
+		return nil, fmt.Errorf("list seed embeddings: %w", err)
+	}
+	for _, row := range rows {
+		if row.ModelID == s.modelID && len(row.Vector) > 0 {
+			return row.Vector, nil
+		}
+	}
+	return nil, nil
+}
+
+func cosine(a, b []float32) (float64, error) {
+	if len(a) != len(b) {
+		return 0, fmt.Errorf("vector dimensions differ: %d != %d", len(a), len(b))
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0, nil
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb)), nil
+}
+
+func clamp01(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
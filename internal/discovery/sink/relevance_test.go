@@ -0,0 +1,211 @@
+package sink_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ChiaYuChang/prism/internal/discovery/sink"
+	sinkmocks "github.com/ChiaYuChang/prism/internal/discovery/sink/mocks"
+	"github.com/ChiaYuChang/prism/internal/llm"
+	llmmocks "github.com/ChiaYuChang/prism/internal/llm/mocks"
+	"github.com/ChiaYuChang/prism/internal/model"
+	"github.com/ChiaYuChang/prism/internal/repo"
+	repomocks "github.com/ChiaYuChang/prism/internal/repo/mocks"
+	"github.com/ChiaYuChang/prism/pkg/testutils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// stubSignal gives every candidate the same score.
+type stubSignal struct {
+	name  string
+	score float64
+	ok    bool
+	err   error
+}
+
+func (s stubSignal) Name() string { return s.name }
+
+func (s stubSignal) Score(_ context.Context, in sink.RelevanceInput) ([]sink.SignalScore, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	out := make([]sink.SignalScore, len(in.Candidates))
+	for i := range out {
+		out[i] = sink.SignalScore{Value: s.score, OK: s.ok}
+	}
+	return out, nil
+}
+
+func TestKeywordSignal(t *testing.T) {
+	candidate := model.Candidates{Title: "NCC 對電視台開罰", Description: "通傳會今日裁罰兩家業者"}
+	tests := []struct {
+		name    string
+		phrases []string
+		want    float64
+		wantOK  bool
+	}{
+		{name: "no phrases"},
+		{name: "every word", phrases: []string{"NCC 開罰"}, want: 1, wantOK: true},
+		{name: "case insensitive", phrases: []string{"ncc 預算"}, want: 0.5, wantOK: true},
+		{name: "best variant", phrases: []string{"國家通訊傳播委員會 裁罰", "通傳會 裁罰"}, want: 1, wantOK: true},
+		{name: "no match", phrases: []string{"半導體 政策"}, want: 0, wantOK: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sink.KeywordSignal{}.Score(context.Background(), sink.RelevanceInput{
+				Candidates:  []model.Candidates{candidate},
+				SeedPhrases: tt.phrases,
+			})
+			require.NoError(t, err)
+			require.Len(t, got, 1)
+			require.Equal(t, tt.wantOK, got[0].OK)
+			require.InDelta(t, tt.want, got[0].Value, 1e-9)
+		})
+	}
+}
+
+func TestSourcePriorSignal(t *testing.T) {
+	signal := sink.SourcePriorSignal{Priors: map[string]float64{"CNA": 0.9}, Default: 0.4}
+
+	got, err := signal.Score(context.Background(), sink.RelevanceInput{Candidates: []model.Candidates{
+		{SourceAbbr: "cna"},
+		{SourceAbbr: "yahoo"},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, []sink.SignalScore{{Value: 0.9, OK: true}, {Value: 0.4, OK: true}}, got)
+}
+
+func TestWeightedScorerAveragesAvailableSignals(t *testing.T) {
+	scorer, err := sink.NewWeightedScorer(testutils.Logger(),
+		sink.WeightedSignal{Signal: stubSignal{name: "keyword", score: 1, ok: true}, Weight: 2},
+		sink.WeightedSignal{Signal: stubSignal{name: "prior", score: 0.4, ok: true}, Weight: 1},
+		sink.WeightedSignal{Signal: stubSignal{name: "embedding"}, Weight: 1},
+		sink.WeightedSignal{Signal: stubSignal{name: "broken", err: errors.New("boom")}, Weight: 1},
+		sink.WeightedSignal{Signal: stubSignal{name: "zero", score: 1, ok: true}, Weight: 0},
+	)
+	require.NoError(t, err)
+
+	got, err := scorer.Score(context.Background(), sink.RelevanceInput{Candidates: []model.Candidates{{}}})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.InDelta(t, 0.8, got[0].Score, 1e-9)
+	require.Equal(t, map[string]float64{"keyword": 1, "prior": 0.4}, got[0].Signals)
+}
+
+func TestWeightedScorerClampsSignals(t *testing.T) {
+	scorer, err := sink.NewWeightedScorer(testutils.Logger(),
+		sink.WeightedSignal{Signal: stubSignal{name: "embedding", score: -0.3, ok: true}, Weight: 1},
+	)
+	require.NoError(t, err)
+
+	got, err := scorer.Score(context.Background(), sink.RelevanceInput{Candidates: []model.Candidates{{}}})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Zero(t, got[0].Score)
+	require.Equal(t, map[string]float64{"embedding": 0}, got[0].Signals)
+}
+
+func TestNewWeightedScorerRequiresSignals(t *testing.T) {
+	_, err := sink.NewWeightedScorer(testutils.Logger(),
+		sink.WeightedSignal{Signal: sink.KeywordSignal{}, Weight: 0},
+	)
+	require.ErrorIs(t, err, sink.ErrNoSignals)
+}
+
+func TestWeightedScorerDropsMisalignedSignal(t *testing.T) {
+	short := sinkmocks.NewMockSignal(t)
+	short.EXPECT().Name().Return("short")
+	short.EXPECT().Score(mock.Anything, mock.Anything).Return([]sink.SignalScore{{Value: 1, OK: true}}, nil).Once()
+	scorer, err := sink.NewWeightedScorer(testutils.Logger(),
+		sink.WeightedSignal{Signal: short, Weight: 1},
+		sink.WeightedSignal{Signal: stubSignal{name: "prior", score: 0.4, ok: true}, Weight: 1},
+	)
+	require.NoError(t, err)
+
+	got, err := scorer.Score(context.Background(), sink.RelevanceInput{Candidates: []model.Candidates{{}, {}}})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	for _, rel := range got {
+		require.Equal(t, map[string]float64{"prior": 0.4}, rel.Signals)
+	}
+}
+
+func TestEmbeddingSignal(t *testing.T) {
+	seedID := uuid.Must(uuid.NewV7())
+	embeddings := repomocks.NewMockEmbeddings(t)
+	embedder := llmmocks.NewMockEmbedder(t)
+	signal, err := sink.NewEmbeddingSignal(embedder, embeddings, "embeddinggemma", 4)
+	require.NoError(t, err)
+
+	// The seed vector is loaded once and both candidates with text are
+	// embedded in one call; the blank one is left unscored.
+	embeddings.EXPECT().ListContentEmbeddings(mock.Anything, seedID).Return([]repo.ContentEmbedding{
+		{ModelID: 9, Vector: []float32{0, 1}},
+		{ModelID: 4, Vector: []float32{1, 0}},
+	}, nil).Once()
+	embedder.EXPECT().Embed(mock.Anything, &llm.EmbedRequest{
+		Model: "embeddinggemma",
+		Input: []string{"Title\nDesc", "Other"},
+	}).Return(&llm.EmbedResponse{Vectors: [][]float32{{3, 4}, {0, 2}}}, nil).Once()
+
+	got, err := signal.Score(context.Background(), sink.RelevanceInput{
+		Candidates: []model.Candidates{
+			{Title: "Title", Description: "Desc"},
+			{Title: " "},
+			{Title: "Other"},
+		},
+		SeedContentID: seedID,
+	})
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+	require.True(t, got[0].OK)
+	require.InDelta(t, 0.6, got[0].Value, 1e-6)
+	require.False(t, got[1].OK)
+	require.True(t, got[2].OK)
+	require.InDelta(t, 0, got[2].Value, 1e-6)
+}
+
+func TestEmbeddingSignalVectorCountMismatch(t *testing.T) {
+	seedID := uuid.Must(uuid.NewV7())
+	embeddings := repomocks.NewMockEmbeddings(t)
+	embedder := llmmocks.NewMockEmbedder(t)
+	signal, err := sink.NewEmbeddingSignal(embedder, embeddings, "embeddinggemma", 4)
+	require.NoError(t, err)
+
+	embeddings.EXPECT().ListContentEmbeddings(mock.Anything, seedID).Return([]repo.ContentEmbedding{
+		{ModelID: 4, Vector: []float32{1, 0}},
+	}, nil).Once()
+	embedder.EXPECT().Embed(mock.Anything, mock.Anything).
+		Return(&llm.EmbedResponse{Vectors: [][]float32{{1, 0}}}, nil).Once()
+
+	_, err = signal.Score(context.Background(), sink.RelevanceInput{
+		Candidates:    []model.Candidates{{Title: "A"}, {Title: "B"}},
+		SeedContentID: seedID,
+	})
+	require.Error(t, err)
+}
+
+func TestEmbeddingSignalUnavailableWithoutSeedVector(t *testing.T) {
+	seedID := uuid.Must(uuid.NewV7())
+	embeddings := repomocks.NewMockEmbeddings(t)
+	embedder := llmmocks.NewMockEmbedder(t)
+	signal, err := sink.NewEmbeddingSignal(embedder, embeddings, "embeddinggemma", 4)
+	require.NoError(t, err)
+
+	got, err := signal.Score(context.Background(), sink.RelevanceInput{Candidates: []model.Candidates{{Title: "Title"}}})
+	require.NoError(t, err)
+	require.Equal(t, []sink.SignalScore{{}}, got)
+
+	embeddings.EXPECT().ListContentEmbeddings(mock.Anything, seedID).Return([]repo.ContentEmbedding{
+		{ModelID: 9, Vector: []float32{0, 1}},
+	}, nil).Once()
+	got, err = signal.Score(context.Background(), sink.RelevanceInput{
+		Candidates:    []model.Candidates{{Title: "Title"}},
+		SeedContentID: seedID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []sink.SignalScore{{}}, got)
+}
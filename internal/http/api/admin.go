@@ -0,0 +1,302 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/http/apikey"
+	"github.com/ChiaYuChang/prism/internal/http/middleware"
+	"github.com/ChiaYuChang/prism/internal/repo"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const maxAdminNameLen = 128
+
+// CreateUserRequest is the body of POST /api/v1/admin/users.
+type CreateUserRequest struct {
+	Name string `json:"name"`
+}
+
+// User is the admin view of an API caller.
+type User struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	CreatedAt  time.Time  `json:"created_at"`
+	DisabledAt *time.Time `json:"disabled_at,omitempty"`
+}
+
+// CreateAPIKeyRequest is the body of POST /api/v1/admin/users/{id}/api_keys.
+// Rate limits default to the server-wide budget when omitted.
+type CreateAPIKeyRequest struct {
+	Name           string     `json:"name"`
+	Scopes         []string   `json:"scopes"`
+	RateLimitRPS   *float64   `json:"rate_limit_rps,omitempty"`
+	RateLimitBurst *int32     `json:"rate_limit_burst,omitempty"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+}
+
+// APIKey is the admin view of a key. It never carries the key itself.
+type APIKey struct {
+	ID             uuid.UUID  `json:"id"`
+	UserID         uuid.UUID  `json:"user_id"`
+	Name           string     `json:"name"`
+	Prefix         string     `json:"prefix"`
+	Scopes         []string   `json:"scopes"`
+	RateLimitRPS   *float64   `json:"rate_limit_rps,omitempty"`
+	RateLimitBurst *int32     `json:"rate_limit_burst,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	LastUsedAt     *time.Time `json:"last_used_at,omitempty"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+}
+
+// CreateAPIKeyResponse is returned once, at creation. Key is the only copy
+// of the plaintext key; Prism stores its hash.
+type CreateAPIKeyResponse struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+// ListAPIKeysResponse is returned by GET /api/v1/admin/users/{id}/api_keys.
+type ListAPIKeysResponse struct {
+	Items []APIKey `json:"items"`
+	Count int      `json:"count"`
+}
+
+// CreateUser handles POST /api/v1/admin/users.
+//
+// @Summary   Create an API user
+// @Tags      admin
+// @Accept    json
+// @Produce   json
+// @Param     body body CreateUserRequest true "User to create"
+// @Success   201 {object} User
+// @Failure   400 {object} ErrorResponse
+// @Failure   409 {object} ErrorResponse
+// @Failure   500 {object} ErrorResponse
+// @Router    /admin/users [post]
+func (s *Server) CreateUser(w http.ResponseWriter, r *http.Request) {
+	var req CreateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > maxAdminNameLen {
+		writeError(w, http.StatusBadRequest, "name is required (max 128 characters)")
+		return
+	}
+
+	ctx := r.Context()
+	user, err := s.Users.CreateUser(ctx, name)
+	if err != nil {
+		if errors.Is(err, repo.ErrUserExists) {
+			writeError(w, http.StatusConflict, "user already exists")
+			return
+		}
+		s.Logger.ErrorContext(ctx, "create user failed", slog.Any("error", err))
+		writeError(w, http.StatusInternalServerError, "failed to create user")
+		return
+	}
+	writeJSON(w, http.StatusCreated, toUser(user))
+}
+
+// CreateAPIKey handles POST /api/v1/admin/users/{id}/api_keys.
+//
+// Scopes are a non-empty subset of read, page_fetch and admin. The response
+// is the only time the key is shown.
+//
+// @Summary   Issue an API key for a user
+// @Tags      admin
+// @Accept    json
+// @Produce   json
+// @Param     id   path string              true "User ID"
+// @Param     body body CreateAPIKeyRequest true "Key to issue"
+// @Success   201 {object} CreateAPIKeyResponse
+// @Failure   400 {object} ErrorResponse
+// @Failure   404 {object} ErrorResponse
+// @Failure   500 {object} ErrorResponse
+// @Router    /admin/users/{id}/api_keys [post]
+func (s *Server) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+	var req CreateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	if msg := validateCreateAPIKey(&req); msg != "" {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	ctx := r.Context()
+	if _, err := s.Users.GetUser(ctx, userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			writeError(w, http.StatusNotFound, "user not found")
+			return
+		}
+		s.Logger.ErrorContext(ctx, "get user failed",
+			slog.String("user_id", userID.String()), slog.Any("error", err))
+		writeError(w, http.StatusInternalServerError, "failed to load user")
+		return
+	}
+
+	key, prefix, hash, err := apikey.Generate()
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "generate api key failed", slog.Any("error", err))
+		writeError(w, http.StatusInternalServerError, "failed to generate key")
+		return
+	}
+	created, err := s.Users.CreateAPIKey(ctx, repo.CreateAPIKeyParams{
+		UserID:         userID,
+		Name:           req.Name,
+		Prefix:         prefix,
+		KeyHash:        hash,
+		Scopes:         req.Scopes,
+		RateLimitRPS:   req.RateLimitRPS,
+		RateLimitBurst: req.RateLimitBurst,
+		ExpiresAt:      req.ExpiresAt,
+	})
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "create api key failed",
+			slog.String("user_id", userID.String()), slog.Any("error", err))
+		writeError(w, http.StatusInternalServerError, "failed to create key")
+		return
+	}
+	writeJSON(w, http.StatusCreated, CreateAPIKeyResponse{APIKey: toAPIKey(created), Key: key})
+}
+
+// ListAPIKeys handles GET /api/v1/admin/users/{id}/api_keys.
+//
+// Revoked and expired keys are listed too, oldest first.
+//
+// @Summary   List a user's API keys
+// @Tags      admin
+// @Produce   json
+// @Param     id path string true "User ID"
+// @Success   200 {object} ListAPIKeysResponse
+// @Failure   400 {object} ErrorResponse
+// @Failure   500 {object} ErrorResponse
+// @Router    /admin/users/{id}/api_keys [get]
+func (s *Server) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+	ctx := r.Context()
+	keys, err := s.Users.ListAPIKeys(ctx, userID)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "list api keys failed",
+			slog.String("user_id", userID.String()), slog.Any("error", err))
+		writeError(w, http.StatusInternalServerError, "failed to list keys")
+		return
+	}
+	items := make([]APIKey, len(keys))
+	for i, k := range keys {
+		items[i] = toAPIKey(k)
+	}
+	writeJSON(w, http.StatusOK, ListAPIKeysResponse{Items: items, Count: len(items)})
+}
+
+// RevokeAPIKey handles DELETE /api/v1/admin/api_keys/{id}.
+//
+// Servers cache resolved keys briefly (auth.api-keys.cache-ttl), so a
+// revoked key may keep working for up to that long.
+//
+// @Summary   Revoke an API key
+// @Tags      admin
+// @Param     id path string true "API key ID"
+// @Success   204
+// @Failure   400 {object} ErrorResponse
+// @Failure   404 {object} ErrorResponse
+// @Failure   500 {object} ErrorResponse
+// @Router    /admin/api_keys/{id} [delete]
+func (s *Server) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	keyID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid api key id")
+		return
+	}
+	ctx := r.Context()
+	n, err := s.Users.RevokeAPIKey(ctx, keyID)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "revoke api key failed",
+			slog.String("api_key_id", keyID.String()), slog.Any("error", err))
+		writeError(w, http.StatusInternalServerError, "failed to revoke key")
+		return
+	}
+	if n == 0 {
+		writeError(w, http.StatusNotFound, "api key not found or already revoked")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// validateCreateAPIKey normalises req in place and returns a client-facing
+// message for the first invalid field, or "".
+func validateCreateAPIKey(req *CreateAPIKeyRequest) string {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" || len(req.Name) > maxAdminNameLen {
+		return "name is required (max 128 characters)"
+	}
+	if len(req.Scopes) == 0 {
+		return "scopes is required"
+	}
+	scopes := make([]string, 0, len(req.Scopes))
+	for _, scope := range req.Scopes {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		if !slices.Contains(middleware.Scopes, scope) {
+			return "invalid scope: expected " + strings.Join(middleware.Scopes, ", ")
+		}
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	req.Scopes = scopes
+	if req.RateLimitRPS != nil && *req.RateLimitRPS <= 0 {
+		return "rate_limit_rps must be positive"
+	}
+	if req.RateLimitBurst != nil && *req.RateLimitBurst <= 0 {
+		return "rate_limit_burst must be positive"
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return "expires_at must be in the future"
+	}
+	return ""
+}
+
+func toUser(u repo.User) User {
+	return User{
+		ID:         u.ID,
+		Name:       u.Name,
+		CreatedAt:  u.CreatedAt,
+		DisabledAt: u.DisabledAt,
+	}
+}
+
+func toAPIKey(k repo.APIKey) APIKey {
+	return APIKey{
+		ID:             k.ID,
+		UserID:         k.UserID,
+		Name:           k.Name,
+		Prefix:         k.Prefix,
+		Scopes:         k.Scopes,
+		RateLimitRPS:   k.RateLimitRPS,
+		RateLimitBurst: k.RateLimitBurst,
+		CreatedAt:      k.CreatedAt,
+		LastUsedAt:     k.LastUsedAt,
+		ExpiresAt:      k.ExpiresAt,
+		RevokedAt:      k.RevokedAt,
+	}
+}
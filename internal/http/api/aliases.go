@@ -0,0 +1,182 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/repo"
+)
+
+var aliasKinds = []string{repo.AliasKindAlias, repo.AliasKindAbbreviation, repo.AliasKindVariant}
+
+// SearchAlias is one alias of a group. Kind is ALIAS, ABBREVIATION or
+// VARIANT.
+type SearchAlias struct {
+	Alias     string    `json:"alias"`
+	Kind      string    `json:"kind"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+// SearchAliasGroup is a canonical name and its aliases. The planner
+// searches a phrase naming any of them under all of them.
+type SearchAliasGroup struct {
+	Canonical string        `json:"canonical"`
+	Aliases   []SearchAlias `json:"aliases"`
+}
+
+// PutSearchAliasesRequest is the body of PUT
+// /api/v1/admin/aliases/{canonical}. Kind defaults to ALIAS.
+type PutSearchAliasesRequest struct {
+	Aliases []SearchAlias `json:"aliases"`
+}
+
+// ListSearchAliasesResponse is returned by GET /api/v1/admin/aliases.
+type ListSearchAliasesResponse struct {
+	Items []SearchAliasGroup `json:"items"`
+	Count int                `json:"count"`
+}
+
+// ListSearchAliases handles GET /api/v1/admin/aliases.
+//
+// @Summary   List search alias groups
+// @Tags      admin
+// @Produce   json
+// @Success   200 {object} ListSearchAliasesResponse
+// @Failure   500 {object} ErrorResponse
+// @Router    /admin/aliases [get]
+func (s *Server) ListSearchAliases(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rows, err := s.Aliases.ListAliases(ctx)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "list search aliases failed", slog.Any("error", err))
+		writeError(w, http.StatusInternalServerError, "failed to list aliases")
+		return
+	}
+	items := toSearchAliasGroups(rows)
+	writeJSON(w, http.StatusOK, ListSearchAliasesResponse{Items: items, Count: len(items)})
+}
+
+// PutSearchAliases handles PUT /api/v1/admin/aliases/{canonical}.
+//
+// Replaces the whole alias group of canonical: aliases missing from the
+// body are removed. The planner reads the dictionary at the start of each
+// plan, so the change applies from the next seed batch.
+//
+// @Summary   Create or replace a search alias group
+// @Tags      admin
+// @Accept    json
+// @Produce   json
+// @Param     canonical path string                  true "Canonical name"
+// @Param     body      body PutSearchAliasesRequest true "Aliases"
+// @Success   200 {object} SearchAliasGroup
+// @Failure   400 {object} ErrorResponse
+// @Failure   500 {object} ErrorResponse
+// @Router    /admin/aliases/{canonical} [put]
+func (s *Server) PutSearchAliases(w http.ResponseWriter, r *http.Request) {
+	canonical := strings.TrimSpace(r.PathValue("canonical"))
+	if canonical == "" || len(canonical) > maxAdminNameLen {
+		writeError(w, http.StatusBadRequest, "canonical is required (max 128 characters)")
+		return
+	}
+	var req PutSearchAliasesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	entries, msg := validatePutSearchAliases(canonical, req)
+	if msg != "" {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	ctx := r.Context()
+	rows, err := s.Aliases.ReplaceAliases(ctx, repo.ReplaceSearchAliasesParams{
+		Canonical: canonical,
+		Aliases:   entries,
+	})
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "replace search aliases failed", slog.String("canonical", canonical), slog.Any("error", err))
+		writeError(w, http.StatusInternalServerError, "failed to save aliases")
+		return
+	}
+	group := SearchAliasGroup{Canonical: canonical, Aliases: []SearchAlias{}}
+	if groups := toSearchAliasGroups(rows); len(groups) > 0 {
+		group = groups[0]
+	}
+	writeJSON(w, http.StatusOK, group)
+}
+
+// DeleteSearchAliases handles DELETE /api/v1/admin/aliases/{canonical}.
+//
+// @Summary   Delete a search alias group
+// @Tags      admin
+// @Param     canonical path string true "Canonical name"
+// @Success   204
+// @Failure   404 {object} ErrorResponse
+// @Failure   500 {object} ErrorResponse
+// @Router    /admin/aliases/{canonical} [delete]
+func (s *Server) DeleteSearchAliases(w http.ResponseWriter, r *http.Request) {
+	canonical := strings.TrimSpace(r.PathValue("canonical"))
+	ctx := r.Context()
+	n, err := s.Aliases.DeleteAliases(ctx, canonical)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "delete search aliases failed", slog.String("canonical", canonical), slog.Any("error", err))
+		writeError(w, http.StatusInternalServerError, "failed to delete aliases")
+		return
+	}
+	if n == 0 {
+		writeError(w, http.StatusNotFound, "alias group not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// validatePutSearchAliases normalises req into repo entries and returns a
+// client-facing message for the first invalid alias, or "".
+func validatePutSearchAliases(canonical string, req PutSearchAliasesRequest) ([]repo.SearchAliasEntry, string) {
+	if len(req.Aliases) == 0 {
+		return nil, "aliases must not be empty; DELETE removes a group"
+	}
+	entries := make([]repo.SearchAliasEntry, 0, len(req.Aliases))
+	seen := make(map[string]struct{}, len(req.Aliases))
+	for _, a := range req.Aliases {
+		alias := strings.TrimSpace(a.Alias)
+		if alias == "" || len(alias) > maxAdminNameLen {
+			return nil, "alias is required (max 128 characters)"
+		}
+		if alias == canonical {
+			return nil, "alias " + alias + " equals the canonical name"
+		}
+		if _, dup := seen[alias]; dup {
+			return nil, "duplicate alias " + alias
+		}
+		seen[alias] = struct{}{}
+		kind := strings.ToUpper(strings.TrimSpace(a.Kind))
+		if kind == "" {
+			kind = repo.AliasKindAlias
+		}
+		if !slices.Contains(aliasKinds, kind) {
+			return nil, "invalid kind: expected " + strings.Join(aliasKinds, ", ")
+		}
+		entries = append(entries, repo.SearchAliasEntry{Alias: alias, Kind: kind})
+	}
+	return entries, ""
+}
+
+// toSearchAliasGroups groups rows, which ListAliases returns ordered by
+// canonical.
+func toSearchAliasGroups(rows []repo.SearchAlias) []SearchAliasGroup {
+	groups := []SearchAliasGroup{}
+	for _, row := range rows {
+		if n := len(groups); n == 0 || groups[n-1].Canonical != row.Canonical {
+			groups = append(groups, SearchAliasGroup{Canonical: row.Canonical})
+		}
+		g := &groups[len(groups)-1]
+		g.Aliases = append(g.Aliases, SearchAlias{Alias: row.Alias, Kind: row.Kind, UpdatedAt: row.UpdatedAt})
+	}
+	return groups
+}
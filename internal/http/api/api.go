@@ -133,12 +133,13 @@ func WithProgressCache(c ProgressCache) ServerOption {
 	}
 }
 
-// WithGetFetchLimiter attaches a per-IP rate limiter applied to GET /fetches/{id}.
-// When unset, no rate limiting is applied.
-func WithGetFetchLimiter(l middleware.IPLimiter) ServerOption {
+// WithRateLimiter attaches a rate limiter applied to every public route,
+// keyed by the caller's API key (or client IP without one). When unset, no
+// rate limiting is applied.
+func WithRateLimiter(l middleware.KeyLimiter) ServerOption {
 	return func(s *Server) {
 		if l != nil {
-			s.GetFetchLimiter = l
+			s.Limiter = l
 		}
 	}
 }
@@ -160,16 +161,91 @@ func WithStatusMonitor(m StatusMonitor) ServerOption {
 	}
 }
 
+// WithLLMSpend attaches the LLM usage ledger and enables GET /llm/spend.
+// When unset, the route is not registered. Only set it together with an
+// auth middleware: without a principal RequireScope lets every caller
+// through.
+func WithLLMSpend(usage repo.LLMUsage) ServerOption {
+	return func(s *Server) {
+		if usage != nil {
+			s.LLMSpend = usage
+		}
+	}
+}
+
+// WithChangeFeed attaches a change feed and enables the SSE routes
+// GET /fetches/{id}/events and GET /candidates/stream. When unset, the routes
+// are not registered.
+func WithChangeFeed(feed repo.ChangeFeed, cfg StreamConfig) ServerOption {
+	return func(s *Server) {
+		if feed != nil {
+			s.ChangeFeed = feed
+			s.Stream = cfg.withDefaults()
+		}
+	}
+}
+
+// WithUsers attaches the user/API key store and enables the admin routes
+// under /api/v1/admin. Only set it together with middleware.APIKeyAuth:
+// without a principal RequireScope lets every caller through.
+func WithUsers(users repo.Users) ServerOption {
+	return func(s *Server) {
+		if users != nil {
+			s.Users = users
+		}
+	}
+}
+
+// WithCatalog attaches the outlet catalog and enables the admin routes for
+// sources, scout configs and parser rules. Like WithUsers, only set it
+// together with middleware.APIKeyAuth.
+func WithCatalog(c repo.Catalog) ServerOption {
+	return func(s *Server) {
+		if c != nil {
+			s.Catalog = c
+		}
+	}
+}
+
+// WithAliases attaches the search alias dictionary and enables the admin
+// routes under /api/v1/admin/aliases. Like WithUsers, only set it together
+// with middleware.APIKeyAuth.
+func WithAliases(a repo.Aliases) ServerOption {
+	return func(s *Server) {
+		if a != nil {
+			s.Aliases = a
+		}
+	}
+}
+
+// WithTaskRuns attaches the discovery run history and enables the admin
+// yield and per-task run routes. Like WithUsers, only set it together with
+// middleware.APIKeyAuth.
+func WithTaskRuns(runs repo.TaskRuns) ServerOption {
+	return func(s *Server) {
+		if runs != nil {
+			s.TaskRuns = runs
+		}
+	}
+}
+
 // Server groups dependencies shared by all API handlers.
 type Server struct {
-	Logger          *slog.Logger
-	Scout           repo.Scout
-	Tasks           repo.Tasks
-	Pipeline        repo.Pipeline
-	UserFetches     repo.UserFetches
-	Cache           ProgressCache
-	GetFetchLimiter middleware.IPLimiter
-	Monitor         StatusMonitor
+	Logger      *slog.Logger
+	Scout       repo.Scout
+	Tasks       repo.Tasks
+	Pipeline    repo.Pipeline
+	UserFetches repo.UserFetches
+	Cache       ProgressCache
+	Limiter     middleware.KeyLimiter
+	Monitor     StatusMonitor
+	LLMSpend    repo.LLMUsage
+	ChangeFeed  repo.ChangeFeed
+	Stream      StreamConfig
+	Users       repo.Users
+	Catalog     repo.Catalog
+	Aliases     repo.Aliases
+	TaskRuns    repo.TaskRuns
 }
 
 // NewServer validates dependencies and returns a ready-to-register Server.
@@ -190,14 +266,14 @@ func NewServer(logger *slog.Logger, scout repo.Scout, tasks repo.Tasks, pipeline
 		return nil, fmt.Errorf("%w: userFetches", ErrParamMissing)
 	}
 	s := &Server{
-		Logger:          logger,
-		Scout:           scout,
-		Tasks:           tasks,
-		Pipeline:        pipeline,
-		UserFetches:     userFetches,
-		Cache:           NoOpProgressCache{},
-		GetFetchLimiter: middleware.NoOpIPLimiter{},
-		Monitor:         NewInMemoryMonitor(""),
+		Logger:      logger,
+		Scout:       scout,
+		Tasks:       tasks,
+		Pipeline:    pipeline,
+		UserFetches: userFetches,
+		Cache:       NoOpProgressCache{},
+		Limiter:     middleware.NoOpKeyLimiter{},
+		Monitor:     NewInMemoryMonitor(""),
 	}
 	for _, opt := range opts {
 		opt(s)
@@ -207,17 +283,58 @@ func NewServer(logger *slog.Logger, scout repo.Scout, tasks repo.Tasks, pipeline
 
 // RegisterPublic wires public v1 routes onto the supplied mux under the /api/v1 prefix.
 //
-// The /fetches/{id} route is wrapped in a per-IP rate-limit middleware. When
-// no limiter is configured, the wrapping uses NoOpIPLimiter and is effectively
-// a passthrough.
+// mws (typically the auth middleware) run outermost. Inside them every
+// route is rate limited per API key, which for the SSE streams bounds
+// reconnects, and requires the scope listed below; without a configured
+// limiter the limit is a passthrough.
 func (s *Server) RegisterPublic(mux *http.ServeMux, mws ...middleware.Middleware) {
 	wrap := middleware.Chain(mws...)
-	mux.Handle("GET /api/v1/candidates", wrap(http.HandlerFunc(s.ListCandidates)))
-	mux.Handle("POST /api/v1/page_fetch", wrap(http.HandlerFunc(s.PageFetch)))
-	mux.Handle("GET /api/v1/contents/{candidate_id}", wrap(http.HandlerFunc(s.GetContent)))
-	mux.Handle("GET /api/v1/fetches/{id}",
-		wrap(middleware.RateLimit(s.GetFetchLimiter)(http.HandlerFunc(s.GetFetch))))
-	mux.Handle("GET /api/v1/status", wrap(http.HandlerFunc(s.GetStatus)))
+	limit := middleware.RateLimitPerKey(s.Limiter)
+	route := func(pattern, scope string, h http.HandlerFunc) {
+		mux.Handle(pattern, wrap(limit(middleware.RequireScope(scope)(h))))
+	}
+
+	route("GET /api/v1/candidates", middleware.ScopeRead, s.ListCandidates)
+	route("POST /api/v1/page_fetch", middleware.ScopePageFetch, s.PageFetch)
+	route("POST /api/v1/page_fetch/query", middleware.ScopePageFetch, s.PageFetchQuery)
+	route("GET /api/v1/contents", middleware.ScopeRead, s.ListContents)
+	route("GET /api/v1/contents/export", middleware.ScopeRead, s.ExportContents)
+	route("GET /api/v1/contents/{candidate_id}", middleware.ScopeRead, s.GetContent)
+	route("GET /api/v1/fetches/{id}", middleware.ScopeRead, s.GetFetch)
+	route("GET /api/v1/status", middleware.ScopeRead, s.GetStatus)
+	route("GET /api/v1/sources", middleware.ScopeRead, s.ListSources)
+	if s.ChangeFeed != nil {
+		route("GET /api/v1/fetches/{id}/events", middleware.ScopeRead, s.StreamFetchEvents)
+		route("GET /api/v1/candidates/stream", middleware.ScopeRead, s.StreamCandidates)
+	}
+	if s.LLMSpend != nil {
+		route("GET /api/v1/llm/spend", middleware.ScopeAdmin, s.GetLLMSpend)
+	}
+	if s.Users != nil {
+		route("POST /api/v1/admin/users", middleware.ScopeAdmin, s.CreateUser)
+		route("POST /api/v1/admin/users/{id}/api_keys", middleware.ScopeAdmin, s.CreateAPIKey)
+		route("GET /api/v1/admin/users/{id}/api_keys", middleware.ScopeAdmin, s.ListAPIKeys)
+		route("DELETE /api/v1/admin/api_keys/{id}", middleware.ScopeAdmin, s.RevokeAPIKey)
+	}
+	if s.Catalog != nil {
+		route("PUT /api/v1/admin/sources/{abbr}", middleware.ScopeAdmin, s.PutSource)
+		route("DELETE /api/v1/admin/sources/{abbr}", middleware.ScopeAdmin, s.DeleteSource)
+		route("GET /api/v1/admin/scouts", middleware.ScopeAdmin, s.ListScoutConfigs)
+		route("PUT /api/v1/admin/scouts/{name}", middleware.ScopeAdmin, s.PutScoutConfig)
+		route("DELETE /api/v1/admin/scouts/{name}", middleware.ScopeAdmin, s.DeleteScoutConfig)
+		route("GET /api/v1/admin/parsers", middleware.ScopeAdmin, s.ListParserRules)
+		route("PUT /api/v1/admin/parsers/{host}", middleware.ScopeAdmin, s.PutParserRule)
+		route("DELETE /api/v1/admin/parsers/{host}", middleware.ScopeAdmin, s.DeleteParserRule)
+	}
+	if s.Aliases != nil {
+		route("GET /api/v1/admin/aliases", middleware.ScopeAdmin, s.ListSearchAliases)
+		route("PUT /api/v1/admin/aliases/{canonical}", middleware.ScopeAdmin, s.PutSearchAliases)
+		route("DELETE /api/v1/admin/aliases/{canonical}", middleware.ScopeAdmin, s.DeleteSearchAliases)
+	}
+	if s.TaskRuns != nil {
+		route("GET /api/v1/admin/task_runs/yield", middleware.ScopeAdmin, s.GetTaskRunYield)
+		route("GET /api/v1/admin/tasks/{id}/runs", middleware.ScopeAdmin, s.ListTaskRuns)
+	}
 }
 
 // RegisterInternal wires private routes for internal administration/push telemetry.
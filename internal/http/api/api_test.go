@@ -1,21 +1,30 @@
 package api_test
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/ChiaYuChang/prism/internal/http/api"
+	"github.com/ChiaYuChang/prism/internal/http/apikey"
+	"github.com/ChiaYuChang/prism/internal/http/middleware"
 	"github.com/ChiaYuChang/prism/internal/repo"
 	"github.com/ChiaYuChang/prism/internal/repo/mocks"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/parquet-go/parquet-go"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -44,7 +53,23 @@ func (f *fakeProgressCache) Set(_ context.Context, _ uuid.UUID, resp api.FetchPr
 
 type denyAllLimiter struct{}
 
-func (denyAllLimiter) Allow(string) bool { return false }
+func (denyAllLimiter) Allow(string, float64, int) bool { return false }
+
+// withPrincipal attaches an authenticated caller as APIKeyAuth would.
+func withPrincipal(req *http.Request, p middleware.Principal) *http.Request {
+	return req.WithContext(middleware.WithPrincipal(req.Context(), p))
+}
+
+// fakeKeyResolver maps presented keys to principals for route-level tests.
+type fakeKeyResolver map[string]middleware.Principal
+
+func (f fakeKeyResolver) Resolve(_ context.Context, key string) (middleware.Principal, error) {
+	p, ok := f[key]
+	if !ok {
+		return middleware.Principal{}, middleware.ErrInvalidAPIKey
+	}
+	return p, nil
+}
 
 type testServerMocks struct {
 	scout       *mocks.MockScout
@@ -76,7 +101,7 @@ func TestListCandidates_HappyPath(t *testing.T) {
 	m.scout.EXPECT().ListCandidates(mock.Anything, mock.MatchedBy(func(p repo.ListCandidatesParams) bool {
 		return p.Query != nil && *p.Query == "election" &&
 			p.SourceAbbr != nil && *p.SourceAbbr == "dpp" &&
-			p.Limit == 25 && p.Offset == 10
+			p.Limit == 26 && p.Offset == 10
 	})).Return([]repo.Candidate{{
 		ID:              id,
 		BatchID:         batch,
@@ -100,6 +125,68 @@ func TestListCandidates_HappyPath(t *testing.T) {
 	require.Equal(t, id, body.Items[0].ID)
 	require.EqualValues(t, 25, body.Limit)
 	require.EqualValues(t, 10, body.Offset)
+	require.Empty(t, body.NextCursor)
+}
+
+func TestListCandidates_CursorPaging(t *testing.T) {
+	srv, m := newTestServer(t)
+
+	discovered := time.Date(2026, 5, 20, 8, 0, 0, 0, time.UTC)
+	published := discovered.Add(-time.Hour)
+	dated := repo.Candidate{ID: uuid.Must(uuid.NewV7()), PublishedAt: &published, DiscoveredAt: discovered}
+	undated := repo.Candidate{ID: uuid.Must(uuid.NewV7()), DiscoveredAt: discovered.Add(-time.Minute)}
+	tail := repo.Candidate{ID: uuid.Must(uuid.NewV7()), DiscoveredAt: discovered.Add(-2 * time.Minute)}
+
+	page := func(cursor string) api.ListCandidatesResponse {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/candidates?limit=1&cursor="+cursor, nil)
+		rec := httptest.NewRecorder()
+		srv.ListCandidates(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var body api.ListCandidatesResponse
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+		return body
+	}
+
+	m.scout.EXPECT().ListCandidates(mock.Anything, mock.MatchedBy(func(p repo.ListCandidatesParams) bool {
+		return p.AfterDiscoveredAt == nil && p.Limit == 2
+	})).Return([]repo.Candidate{dated, undated}, nil).Once()
+	first := page("")
+	require.Equal(t, dated.ID, first.Items[0].ID)
+	require.NotEmpty(t, first.NextCursor)
+
+	m.scout.EXPECT().ListCandidates(mock.Anything, mock.MatchedBy(func(p repo.ListCandidatesParams) bool {
+		return p.AfterPublishedAt != nil && p.AfterPublishedAt.Equal(published) &&
+			p.AfterDiscoveredAt != nil && p.AfterDiscoveredAt.Equal(discovered) &&
+			p.AfterID != nil && *p.AfterID == dated.ID
+	})).Return([]repo.Candidate{undated, tail}, nil).Once()
+	second := page(first.NextCursor)
+	require.Equal(t, undated.ID, second.Items[0].ID)
+
+	// Past the dated rows the cursor carries no published_at.
+	m.scout.EXPECT().ListCandidates(mock.Anything, mock.MatchedBy(func(p repo.ListCandidatesParams) bool {
+		return p.AfterPublishedAt == nil &&
+			p.AfterDiscoveredAt != nil && p.AfterDiscoveredAt.Equal(undated.DiscoveredAt) &&
+			p.AfterID != nil && *p.AfterID == undated.ID
+	})).Return([]repo.Candidate{tail}, nil).Once()
+	third := page(second.NextCursor)
+	require.Equal(t, tail.ID, third.Items[0].ID)
+	require.Empty(t, third.NextCursor)
+}
+
+func TestListCandidates_InvalidCursor(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	for _, query := range []string{
+		"cursor=not-base64!",
+		"cursor=e30", // {}
+		"cursor=eyJkIjoiMjAyNi0wNS0yMFQwODowMDowMFoiLCJpIjoiMDE5NmRlMDAtMDAwMC03MDAwLTgwMDAtMDAwMDAwMDAwMDAwIn0&offset=5",
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/candidates?"+query, nil)
+		rec := httptest.NewRecorder()
+		srv.ListCandidates(rec, req)
+		require.Equal(t, http.StatusBadRequest, rec.Code, query)
+	}
 }
 
 func TestListCandidates_InvalidSince(t *testing.T) {
@@ -282,6 +369,171 @@ func TestPageFetch_EmptyBody(t *testing.T) {
 	require.Equal(t, http.StatusBadRequest, rec.Code)
 }
 
+func TestPageFetch_NotifyIsStoredOnFetch(t *testing.T) {
+	srv, m := newTestServer(t)
+
+	candID := uuid.Must(uuid.NewV7())
+	notify := api.PageFetchNotify{URL: "https://hooks.example.com/prism", Secret: "0123456789abcdef"}
+
+	m.scout.EXPECT().GetCandidatesByIDs(mock.Anything, mock.Anything).
+		Return([]repo.Candidate{{
+			ID: candID, BatchID: uuid.Must(uuid.NewV7()),
+			SourceAbbr: "yahoo", URL: "https://news.example/n", TraceID: "t",
+		}}, nil).Once()
+	fetchID := uuid.Must(uuid.NewV7())
+	m.userFetches.EXPECT().Create(mock.Anything, repo.CreateUserFetchParams{
+		NotifyURL:    &notify.URL,
+		NotifySecret: &notify.Secret,
+	}).Return(repo.UserFetch{ID: fetchID}, nil).Once()
+	m.tasks.EXPECT().CreateTask(mock.Anything, mock.Anything).
+		Return(repo.Task{ID: uuid.Must(uuid.NewV7())}, nil).Once()
+	m.userFetches.EXPECT().CreateItem(mock.Anything, mock.Anything).
+		Return(repo.UserFetchItem{}, nil).Once()
+
+	body, _ := json.Marshal(api.PageFetchRequest{CandidateIDs: []uuid.UUID{candID}, Notify: &notify})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/page_fetch", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	srv.PageFetch(rec, req)
+
+	require.Equal(t, http.StatusAccepted, rec.Code)
+	require.NotContains(t, rec.Body.String(), notify.Secret)
+}
+
+func TestPageFetch_InvalidNotify(t *testing.T) {
+	cases := map[string]api.PageFetchNotify{
+		"RelativeURL": {URL: "/hook", Secret: "0123456789abcdef"},
+		"NonHTTP":     {URL: "ftp://hooks.example.com", Secret: "0123456789abcdef"},
+		"ShortSecret": {URL: "https://hooks.example.com", Secret: "short"},
+		"Loopback":    {URL: "http://127.0.0.1:8080/hook", Secret: "0123456789abcdef"},
+		"Localhost":   {URL: "http://localhost/hook", Secret: "0123456789abcdef"},
+		"Metadata":    {URL: "http://169.254.169.254/latest/meta-data", Secret: "0123456789abcdef"},
+		"PrivateIPv6": {URL: "http://[fd00::1]/hook", Secret: "0123456789abcdef"},
+	}
+	for name, notify := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv, _ := newTestServer(t)
+			body, _ := json.Marshal(api.PageFetchRequest{
+				CandidateIDs: []uuid.UUID{uuid.Must(uuid.NewV7())},
+				Notify:       &notify,
+			})
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/page_fetch", bytes.NewReader(body))
+			rec := httptest.NewRecorder()
+			srv.PageFetch(rec, req)
+			require.Equal(t, http.StatusBadRequest, rec.Code)
+		})
+	}
+}
+
+func postPageFetchQuery(t *testing.T, srv *api.Server, req api.PageFetchQueryRequest) (*httptest.ResponseRecorder, api.PageFetchQueryResponse) {
+	t.Helper()
+	body, err := json.Marshal(req)
+	require.NoError(t, err)
+	rec := httptest.NewRecorder()
+	srv.PageFetchQuery(rec, httptest.NewRequest(http.MethodPost, "/api/v1/page_fetch/query", bytes.NewReader(body)))
+	var resp api.PageFetchQueryResponse
+	if rec.Code < http.StatusBadRequest {
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	}
+	return rec, resp
+}
+
+func TestPageFetchQuery_DryRunOnlyCounts(t *testing.T) {
+	srv, m := newTestServer(t)
+
+	source := "yahoo"
+	m.scout.EXPECT().CountCandidates(mock.Anything, mock.MatchedBy(func(p repo.CountCandidatesParams) bool {
+		return p.SourceAbbr != nil && *p.SourceAbbr == source && p.Query == nil
+	})).Return(250, nil).Once()
+
+	rec, resp := postPageFetchQuery(t, srv, api.PageFetchQueryRequest{SourceAbbr: &source, DryRun: true})
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.True(t, resp.DryRun)
+	require.EqualValues(t, 250, resp.Matched)
+	require.Equal(t, 100, resp.Selected)
+	require.Nil(t, resp.FetchID)
+	require.Empty(t, resp.Items)
+}
+
+func TestPageFetchQuery_RecordsMatchesInChunks(t *testing.T) {
+	srv, m := newTestServer(t)
+
+	q := "能源"
+	since := time.Date(2026, 5, 18, 0, 0, 0, 0, time.UTC)
+	newest := time.Date(2026, 5, 20, 8, 0, 0, 0, time.UTC)
+	candidates := make([]repo.Candidate, 150)
+	for i := range candidates {
+		candidates[i] = repo.Candidate{
+			ID: uuid.Must(uuid.NewV7()), BatchID: uuid.Must(uuid.NewV7()), SourceAbbr: "yahoo",
+			URL: fmt.Sprintf("https://news.example/%d", i), DiscoveredAt: newest.Add(-time.Duration(i) * time.Minute),
+		}
+	}
+
+	m.scout.EXPECT().CountCandidates(mock.Anything, mock.Anything).Return(400, nil).Once()
+	fetchID := expectCreateFetch(t, m)
+	m.scout.EXPECT().ListCandidates(mock.Anything, mock.MatchedBy(func(p repo.ListCandidatesParams) bool {
+		return p.AfterID == nil && p.Limit == 100 &&
+			p.Query != nil && *p.Query == q && p.Since != nil && p.Since.Equal(since)
+	})).Return(candidates[:100], nil).Once()
+	m.scout.EXPECT().ListCandidates(mock.Anything, mock.MatchedBy(func(p repo.ListCandidatesParams) bool {
+		return p.AfterID != nil && *p.AfterID == candidates[99].ID && p.Limit == 50 &&
+			p.AfterPublishedAt == nil && p.AfterDiscoveredAt.Equal(candidates[99].DiscoveredAt)
+	})).Return(candidates[100:], nil).Once()
+	m.tasks.EXPECT().CreateTask(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ repo.CreateTaskParams) (repo.Task, error) {
+			return repo.Task{ID: uuid.Must(uuid.NewV7())}, nil
+		}).Times(150)
+	m.userFetches.EXPECT().CreateItem(mock.Anything, mock.MatchedBy(func(p repo.CreateUserFetchItemParams) bool {
+		return p.FetchID == fetchID && p.TaskID != nil
+	})).Return(repo.UserFetchItem{}, nil).Times(150)
+
+	rec, resp := postPageFetchQuery(t, srv, api.PageFetchQueryRequest{Q: &q, Since: &since, MaxCandidates: 150})
+
+	require.Equal(t, http.StatusAccepted, rec.Code)
+	require.EqualValues(t, 400, resp.Matched)
+	require.Equal(t, 150, resp.Selected)
+	require.NotNil(t, resp.FetchID)
+	require.Equal(t, fetchID, *resp.FetchID)
+	require.Len(t, resp.Items, 150)
+	require.Equal(t, candidates[0].ID, resp.Items[0].CandidateID)
+	require.Equal(t, api.PageFetchStatusCreated, resp.Items[149].Status)
+}
+
+func TestPageFetchQuery_NoMatchCreatesNoFetch(t *testing.T) {
+	srv, m := newTestServer(t)
+
+	q := "nothing"
+	m.scout.EXPECT().CountCandidates(mock.Anything, mock.Anything).Return(0, nil).Once()
+
+	rec, resp := postPageFetchQuery(t, srv, api.PageFetchQueryRequest{Q: &q})
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Zero(t, resp.Matched)
+	require.Nil(t, resp.FetchID)
+}
+
+func TestPageFetchQuery_Validation(t *testing.T) {
+	q := "energy"
+	blank := "  "
+	since := time.Date(2026, 5, 18, 0, 0, 0, 0, time.UTC)
+	until := since.Add(-time.Hour)
+	cases := map[string]api.PageFetchQueryRequest{
+		"NoFilter":       {},
+		"BlankFilter":    {Q: &blank},
+		"CapTooLarge":    {Q: &q, MaxCandidates: 1001},
+		"NegativeCap":    {Q: &q, MaxCandidates: -1},
+		"UntilPastSince": {Since: &since, Until: &until},
+		"InvalidNotify":  {Q: &q, Notify: &api.PageFetchNotify{URL: "/hook", Secret: "0123456789abcdef"}},
+	}
+	for name, req := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv, _ := newTestServer(t)
+			rec, _ := postPageFetchQuery(t, srv, req)
+			require.Equal(t, http.StatusBadRequest, rec.Code)
+		})
+	}
+}
+
 func TestGetFetch_HappyPath(t *testing.T) {
 	srv, m := newTestServer(t)
 
@@ -343,7 +595,7 @@ func TestGetFetch_InvalidID(t *testing.T) {
 	require.Equal(t, http.StatusBadRequest, rec.Code)
 }
 
-func TestGetFetch_CacheHit_ShortCircuitsRepo(t *testing.T) {
+func TestGetFetch_CacheHit_SkipsProgressQuery(t *testing.T) {
 	t.Helper()
 	m := &testServerMocks{
 		scout:       mocks.NewMockScout(t),
@@ -368,6 +620,8 @@ func TestGetFetch_CacheHit_ShortCircuitsRepo(t *testing.T) {
 	}
 	srv, err := api.NewServer(logger, m.scout, m.tasks, m.pipeline, m.userFetches, api.WithProgressCache(cache))
 	require.NoError(t, err)
+	m.userFetches.EXPECT().Get(mock.Anything, fetchID).
+		Return(repo.UserFetch{ID: fetchID}, nil).Once()
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/fetches/"+fetchID.String(), nil)
 	req.SetPathValue("id", fetchID.String())
@@ -383,7 +637,7 @@ func TestGetFetch_CacheHit_ShortCircuitsRepo(t *testing.T) {
 	require.True(t, body.Terminal)
 	require.EqualValues(t, 1, atomic.LoadInt32(&cache.gets))
 	require.EqualValues(t, 0, atomic.LoadInt32(&cache.sets))
-	// userFetches mock has no expectations — would fail if called.
+	// Only the ownership lookup hits the repo; GetProgress has no expectation.
 }
 
 func TestGetFetch_CacheMiss_PopulatesCache(t *testing.T) {
@@ -429,7 +683,7 @@ func TestGetFetch_RateLimit_Returns429(t *testing.T) {
 	}
 	logger := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
 	srv, err := api.NewServer(logger, m.scout, m.tasks, m.pipeline, m.userFetches,
-		api.WithGetFetchLimiter(denyAllLimiter{}))
+		api.WithRateLimiter(denyAllLimiter{}))
 	require.NoError(t, err)
 
 	mux := http.NewServeMux()
@@ -445,6 +699,83 @@ func TestGetFetch_RateLimit_Returns429(t *testing.T) {
 	require.Equal(t, "1", rec.Header().Get("Retry-After"))
 }
 
+func TestGetFetch_OwnershipEnforced(t *testing.T) {
+	owner := uuid.Must(uuid.NewV7())
+	fetchID := uuid.Must(uuid.NewV7())
+
+	for name, tc := range map[string]struct {
+		caller middleware.Principal
+		want   int
+	}{
+		"owner":      {caller: middleware.Principal{UserID: owner, Scopes: []string{middleware.ScopeRead}}, want: http.StatusOK},
+		"other user": {caller: middleware.Principal{UserID: uuid.Must(uuid.NewV7()), Scopes: []string{middleware.ScopeRead}}, want: http.StatusNotFound},
+		"admin":      {caller: middleware.Principal{UserID: uuid.Must(uuid.NewV7()), Scopes: []string{middleware.ScopeAdmin}}, want: http.StatusOK},
+	} {
+		t.Run(name, func(t *testing.T) {
+			srv, m := newTestServer(t)
+			m.userFetches.EXPECT().Get(mock.Anything, fetchID).
+				Return(repo.UserFetch{ID: fetchID, UserID: &owner}, nil).Once()
+			if tc.want == http.StatusOK {
+				m.userFetches.EXPECT().GetProgress(mock.Anything, fetchID).
+					Return(repo.UserFetchProgress{Total: 1}, nil).Once()
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/fetches/"+fetchID.String(), nil)
+			req.SetPathValue("id", fetchID.String())
+			rec := httptest.NewRecorder()
+			srv.GetFetch(rec, withPrincipal(req, tc.caller))
+			require.Equal(t, tc.want, rec.Code)
+		})
+	}
+}
+
+func TestPageFetch_RecordsCallerAsOwner(t *testing.T) {
+	srv, m := newTestServer(t)
+	userID := uuid.Must(uuid.NewV7())
+	candID := uuid.Must(uuid.NewV7())
+
+	m.scout.EXPECT().GetCandidatesByIDs(mock.Anything, []uuid.UUID{candID}).
+		Return(nil, nil).Once()
+	m.userFetches.EXPECT().Create(mock.Anything, repo.CreateUserFetchParams{UserID: &userID}).
+		Return(repo.UserFetch{ID: uuid.Must(uuid.NewV7()), UserID: &userID}, nil).Once()
+
+	body, _ := json.Marshal(api.PageFetchRequest{CandidateIDs: []uuid.UUID{candID}})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/page_fetch", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	srv.PageFetch(rec, withPrincipal(req, middleware.Principal{UserID: userID, Scopes: []string{middleware.ScopePageFetch}}))
+
+	require.Equal(t, http.StatusAccepted, rec.Code)
+}
+
+func TestRegisterPublic_EnforcesScopes(t *testing.T) {
+	srv, _ := newTestServer(t)
+	api.WithLLMSpend(mocks.NewMockLLMUsage(t))(srv)
+	resolver := fakeKeyResolver{
+		"reader": {UserID: uuid.Must(uuid.NewV7()), KeyID: uuid.Must(uuid.NewV7()), Scopes: []string{middleware.ScopeRead}},
+	}
+	mux := http.NewServeMux()
+	srv.RegisterPublic(mux, middleware.APIKeyAuth(resolver))
+
+	for _, tc := range []struct {
+		method, path, key string
+		want              int
+	}{
+		{http.MethodPost, "/api/v1/page_fetch", "reader", http.StatusForbidden},
+		{http.MethodPost, "/api/v1/page_fetch/query", "reader", http.StatusForbidden},
+		{http.MethodGet, "/api/v1/llm/spend", "reader", http.StatusForbidden},
+		{http.MethodGet, "/api/v1/candidates", "", http.StatusUnauthorized},
+		{http.MethodGet, "/api/v1/candidates", "unknown", http.StatusUnauthorized},
+	} {
+		req := httptest.NewRequest(tc.method, tc.path, strings.NewReader("{}"))
+		if tc.key != "" {
+			req.Header.Set(middleware.TokenAuthHeader, tc.key)
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		require.Equal(t, tc.want, rec.Code, "%s %s as %q", tc.method, tc.path, tc.key)
+	}
+}
+
 func TestGetContent_NotFoundReturns404(t *testing.T) {
 	srv, m := newTestServer(t)
 
@@ -493,6 +824,227 @@ func TestGetContent_InvalidID(t *testing.T) {
 	require.Equal(t, http.StatusBadRequest, rec.Code)
 }
 
+func TestListSources(t *testing.T) {
+	srv, m := newTestServer(t)
+	m.scout.EXPECT().ListSourcesByType(mock.Anything, repo.SourceTypeParty).Return([]repo.Source{
+		{Abbr: "dpp", Name: "Democratic Progressive Party", Type: repo.SourceTypeParty, BaseURL: "https://www.dpp.org.tw"},
+	}, nil).Once()
+	m.scout.EXPECT().ListSourcesByType(mock.Anything, repo.SourceTypeGovernment).Return([]repo.Source{
+		{Abbr: "ly", Name: "立法院", Type: repo.SourceTypeGovernment, BaseURL: "https://data.ly.gov.tw"},
+	}, nil).Once()
+	m.scout.EXPECT().ListSourcesByType(mock.Anything, repo.SourceTypeMedia).Return([]repo.Source{
+		{Abbr: "cna", Name: "Central News Agency", Type: repo.SourceTypeMedia, BaseURL: "https://www.cna.com.tw"},
+	}, nil).Once()
+
+	rec := httptest.NewRecorder()
+	srv.ListSources(rec, httptest.NewRequest(http.MethodGet, "/api/v1/sources", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp api.ListSourcesResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	require.Equal(t, 3, resp.Count)
+	require.Equal(t, "dpp", resp.Items[0].Abbr)
+	require.Equal(t, repo.SourceTypeGovernment, resp.Items[1].Type)
+	require.Equal(t, repo.SourceTypeMedia, resp.Items[2].Type)
+	require.Equal(t, "https://www.cna.com.tw", resp.Items[2].BaseURL)
+}
+
+func TestListSources_ByType(t *testing.T) {
+	srv, m := newTestServer(t)
+	m.scout.EXPECT().ListSourcesByType(mock.Anything, repo.SourceTypeMedia).Return(nil, nil).Once()
+
+	rec := httptest.NewRecorder()
+	srv.ListSources(rec, httptest.NewRequest(http.MethodGet, "/api/v1/sources?type=media", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, `{"items":[],"count":0}`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	srv.ListSources(rec, httptest.NewRequest(http.MethodGet, "/api/v1/sources?type=blog", nil))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func testContents(n int, newest time.Time) []repo.Content {
+	out := make([]repo.Content, n)
+	for i := range out {
+		out[i] = repo.Content{
+			ID: uuid.Must(uuid.NewV7()), CandidateID: uuid.Must(uuid.NewV7()),
+			URL: fmt.Sprintf("https://news.example/%d", i), Title: "標題", Content: "內容, with comma",
+			Type: repo.ContentTypeArticle, SourceAbbr: "yahoo", TraceID: "tr",
+			PublishedAt: newest.Add(-time.Duration(i) * time.Minute), FetchedAt: newest,
+		}
+	}
+	return out
+}
+
+func TestListContents_FiltersAndNextCursor(t *testing.T) {
+	srv, m := newTestServer(t)
+
+	batch := uuid.Must(uuid.NewV7())
+	rows := testContents(3, time.Date(2026, 5, 20, 8, 0, 0, 0, time.UTC))
+	m.pipeline.EXPECT().ListContents(mock.Anything, mock.MatchedBy(func(p repo.ListContentsParams) bool {
+		return p.Query != nil && *p.Query == "能源政策" &&
+			p.SourceAbbr != nil && *p.SourceAbbr == "yahoo" &&
+			p.Type != nil && *p.Type == repo.ContentTypeArticle &&
+			p.BatchID != nil && *p.BatchID == batch &&
+			p.Since != nil && p.AfterPublishedAt == nil &&
+			p.Limit == 3
+	})).Return(rows, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/contents?q=%E8%83%BD%E6%BA%90%E6%94%BF%E7%AD%96"+
+		"&source_abbr=yahoo&type=article&batch_id="+batch.String()+"&since=2026-05-01T00:00:00Z&limit=2", nil)
+	rec := httptest.NewRecorder()
+	srv.ListContents(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp api.ListContentsResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	require.Equal(t, 2, resp.Count)
+	require.Len(t, resp.Items, 2)
+	require.NotEmpty(t, resp.NextCursor)
+
+	// Following the cursor resumes strictly after the last returned row.
+	last := rows[1]
+	m.pipeline.EXPECT().ListContents(mock.Anything, mock.MatchedBy(func(p repo.ListContentsParams) bool {
+		return p.AfterPublishedAt != nil && p.AfterPublishedAt.Equal(last.PublishedAt) &&
+			p.AfterID != nil && *p.AfterID == last.ID && p.Limit == 51
+	})).Return(rows[2:], nil).Once()
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/contents?cursor="+resp.NextCursor, nil)
+	rec = httptest.NewRecorder()
+	srv.ListContents(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	resp = api.ListContentsResponse{}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	require.Equal(t, 1, resp.Count)
+	require.Empty(t, resp.NextCursor)
+}
+
+func TestListContents_InvalidFilters(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	for _, query := range []string{
+		"type=VIDEO",
+		"batch_id=nope",
+		"since=yesterday",
+		"cursor=garbage",
+		"limit=0",
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/contents?"+query, nil)
+		rec := httptest.NewRecorder()
+		srv.ListContents(rec, req)
+		require.Equal(t, http.StatusBadRequest, rec.Code, query)
+	}
+}
+
+func TestExportContents_NDJSONPagesUntilShortPage(t *testing.T) {
+	srv, m := newTestServer(t)
+
+	first := testContents(500, time.Date(2026, 5, 20, 8, 0, 0, 0, time.UTC))
+	second := testContents(1, first[499].PublishedAt.Add(-time.Minute))
+	m.pipeline.EXPECT().ListContents(mock.Anything, mock.MatchedBy(func(p repo.ListContentsParams) bool {
+		return p.AfterID == nil && p.SourceAbbr != nil && *p.SourceAbbr == "yahoo"
+	})).Return(first, nil).Once()
+	m.pipeline.EXPECT().ListContents(mock.Anything, mock.MatchedBy(func(p repo.ListContentsParams) bool {
+		return p.AfterID != nil && *p.AfterID == first[499].ID && p.SourceAbbr != nil
+	})).Return(second, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/contents/export?source_abbr=yahoo", nil)
+	rec := httptest.NewRecorder()
+	srv.ExportContents(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/x-ndjson", rec.Header().Get("Content-Type"))
+	sc := bufio.NewScanner(rec.Body)
+	sc.Buffer(nil, 1<<20)
+	lines := 0
+	for sc.Scan() {
+		var c api.Content
+		require.NoError(t, json.Unmarshal(sc.Bytes(), &c))
+		lines++
+	}
+	require.Equal(t, 501, lines)
+}
+
+func TestExportContents_CSV(t *testing.T) {
+	srv, m := newTestServer(t)
+
+	rows := testContents(1, time.Date(2026, 5, 20, 8, 0, 0, 0, time.UTC))
+	m.pipeline.EXPECT().ListContents(mock.Anything, mock.Anything).Return(rows, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/contents/export?format=csv", nil)
+	rec := httptest.NewRecorder()
+	srv.ExportContents(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	require.Len(t, lines, 2)
+	require.True(t, strings.HasPrefix(lines[0], "id,batch_id,type,"))
+	require.Contains(t, lines[1], `"內容, with comma"`)
+	require.Contains(t, lines[1], "2026-05-20T08:00:00Z")
+}
+
+func TestExportContents_ParquetRowGroupPerPage(t *testing.T) {
+	srv, m := newTestServer(t)
+
+	first := testContents(500, time.Date(2026, 5, 20, 8, 0, 0, 0, time.UTC))
+	author := "記者"
+	first[0].Author = &author
+	second := testContents(1, first[499].PublishedAt.Add(-time.Minute))
+	m.pipeline.EXPECT().ListContents(mock.Anything, mock.MatchedBy(func(p repo.ListContentsParams) bool {
+		return p.AfterID == nil
+	})).Return(first, nil).Once()
+	m.pipeline.EXPECT().ListContents(mock.Anything, mock.MatchedBy(func(p repo.ListContentsParams) bool {
+		return p.AfterID != nil && *p.AfterID == first[499].ID
+	})).Return(second, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/contents/export?format=parquet", nil)
+	rec := httptest.NewRecorder()
+	srv.ExportContents(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/vnd.apache.parquet", rec.Header().Get("Content-Type"))
+
+	body := bytes.NewReader(rec.Body.Bytes())
+	f, err := parquet.OpenFile(body, body.Size())
+	require.NoError(t, err)
+	var columns []string
+	for _, field := range f.Schema().Fields() {
+		columns = append(columns, field.Name())
+	}
+	require.Equal(t, []string{
+		"id", "batch_id", "type", "source_abbr", "candidate_id", "url", "title",
+		"content", "author", "published_at", "fetched_at", "trace_id",
+	}, columns, "same columns as the CSV export")
+	require.Len(t, f.RowGroups(), 2, "one row group per page")
+
+	type row struct {
+		ID          string    `parquet:"id"`
+		Content     string    `parquet:"content"`
+		Author      *string   `parquet:"author,optional"`
+		PublishedAt time.Time `parquet:"published_at,timestamp(millisecond)"`
+	}
+	rows, err := parquet.Read[row](body, body.Size())
+	require.NoError(t, err)
+	require.Len(t, rows, 501)
+	require.Equal(t, first[0].ID.String(), rows[0].ID)
+	require.Equal(t, "內容, with comma", rows[0].Content)
+	require.Equal(t, &author, rows[0].Author)
+	require.Nil(t, rows[1].Author)
+	require.True(t, first[0].PublishedAt.Equal(rows[0].PublishedAt))
+	require.Equal(t, second[0].ID.String(), rows[500].ID)
+}
+
+func TestExportContents_RejectsUnknownFormat(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/contents/export?format=xlsx", nil)
+	rec := httptest.NewRecorder()
+	srv.ExportContents(rec, req)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
 func TestGetStatus_CachePinging(t *testing.T) {
 	srv, _ := newTestServer(t)
 
@@ -673,3 +1225,775 @@ func TestGetStatus_InitializeStatuses(t *testing.T) {
 	require.Equal(t, "STARTING", statusVal["level"])
 	require.Equal(t, "Waiting for first heartbeat", statusVal["message"])
 }
+
+func TestGetLLMSpend_SummarisesByDayAndComponent(t *testing.T) {
+	srv, _ := newTestServer(t)
+	usage := mocks.NewMockLLMUsage(t)
+	api.WithLLMSpend(usage)(srv)
+
+	day := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	usage.EXPECT().SummarizeSpend(mock.Anything, mock.MatchedBy(func(p repo.SummarizeLLMSpendParams) bool {
+		return p.Since.Equal(day) && p.Until.Equal(day.AddDate(0, 0, 2)) && p.Component == nil
+	})).Return([]repo.LLMSpendSummary{
+		{Day: day, Component: "planner", Provider: "llm.gemini", Model: "gemini-2.0-flash", Calls: 3, TotalTokens: 300, CostMicros: 1_500_000},
+		{Day: day, Component: "collector", Provider: "llm.gemini", Model: "gemini-2.0-flash", Calls: 1, TotalTokens: 50, CostMicros: 250_000},
+		{Day: day.AddDate(0, 0, 1), Component: "planner", Provider: "llm.openai", Model: "gpt-4o-mini", Calls: 2, TotalTokens: 100, CostMicros: 500_000},
+	}, nil).Once()
+
+	mux := http.NewServeMux()
+	srv.RegisterPublic(mux)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/llm/spend?since=2026-10-01&until=2026-10-03", nil)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp api.LLMSpendResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	require.InDelta(t, 2.25, resp.TotalCostUSD, 1e-9)
+	require.Len(t, resp.Days, 3)
+	require.Equal(t, "2026-10-02", resp.Days[2].Day)
+	require.Equal(t, []api.LLMComponentSpend{
+		{Component: "planner", Calls: 5, TotalTokens: 400, CostUSD: 2},
+		{Component: "collector", Calls: 1, TotalTokens: 50, CostUSD: 0.25},
+	}, resp.Components)
+}
+
+func TestGetLLMSpend_InvalidWindow(t *testing.T) {
+	srv, _ := newTestServer(t)
+	api.WithLLMSpend(mocks.NewMockLLMUsage(t))(srv)
+
+	for _, query := range []string{
+		"since=yesterday",
+		"since=2026-10-03&until=2026-10-01",
+		"since=2024-01-01&until=2026-01-01",
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/llm/spend?"+query, nil)
+		rec := httptest.NewRecorder()
+		srv.GetLLMSpend(rec, req)
+		require.Equal(t, http.StatusBadRequest, rec.Code, query)
+	}
+}
+
+func TestGetLLMSpend_NotRegisteredWithoutLedger(t *testing.T) {
+	srv, _ := newTestServer(t)
+	mux := http.NewServeMux()
+	srv.RegisterPublic(mux)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/llm/spend", nil)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+// fakeChangeFeed records subscriptions and lets tests deliver wake-ups.
+type fakeChangeFeed struct {
+	mu   sync.Mutex
+	subs map[string]chan struct{}
+}
+
+func (f *fakeChangeFeed) Subscribe(channel, key string) (<-chan struct{}, func()) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.subs == nil {
+		f.subs = make(map[string]chan struct{})
+	}
+	ch := make(chan struct{}, 1)
+	f.subs[channel+"/"+key] = ch
+	return ch, func() {}
+}
+
+func (f *fakeChangeFeed) wake(t *testing.T, channel, key string) {
+	t.Helper()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ch, ok := f.subs[channel+"/"+key]
+	require.True(t, ok, "no subscription for %s/%s", channel, key)
+	ch <- struct{}{}
+}
+
+type sseEvent struct {
+	id, event, data string
+}
+
+// readSSEEvent reads frames until the next event, skipping retry and
+// comment frames. It returns ok=false at end of stream.
+func readSSEEvent(t *testing.T, r *bufio.Reader) (sseEvent, bool) {
+	t.Helper()
+	var ev sseEvent
+	for {
+		line, err := r.ReadString('\n')
+		if err == io.EOF {
+			return ev, false
+		}
+		require.NoError(t, err)
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "":
+			if ev.event != "" {
+				return ev, true
+			}
+		case strings.HasPrefix(line, "id: "):
+			ev.id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			ev.event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			ev.data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func newStreamTestServer(t *testing.T) (*httptest.Server, *testServerMocks, *fakeChangeFeed) {
+	t.Helper()
+	srv, m := newTestServer(t)
+	feed := &fakeChangeFeed{}
+	api.WithChangeFeed(feed, api.StreamConfig{
+		Settle:    10 * time.Millisecond,
+		Poll:      time.Hour,
+		Heartbeat: time.Hour,
+	})(srv)
+	mux := http.NewServeMux()
+	srv.RegisterPublic(mux)
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+	return ts, m, feed
+}
+
+func TestStreamFetchEvents_PushesChangesUntilTerminal(t *testing.T) {
+	ts, m, feed := newStreamTestServer(t)
+
+	fetchID := uuid.Must(uuid.NewV7())
+	candidateID := uuid.Must(uuid.NewV7())
+	m.userFetches.EXPECT().Get(mock.Anything, fetchID).
+		Return(repo.UserFetch{ID: fetchID}, nil).Once()
+	m.userFetches.EXPECT().GetProgress(mock.Anything, fetchID).
+		Return(repo.UserFetchProgress{Total: 1, RunningCandidateIDs: []uuid.UUID{candidateID}}, nil).Once()
+	m.userFetches.EXPECT().GetProgress(mock.Anything, fetchID).
+		Return(repo.UserFetchProgress{Total: 1, CompletedCandidateIDs: []uuid.UUID{candidateID}, Terminal: true}, nil).Once()
+
+	resp, err := http.Get(ts.URL + "/api/v1/fetches/" + fetchID.String() + "/events")
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	body := bufio.NewReader(resp.Body)
+
+	ev, ok := readSSEEvent(t, body)
+	require.True(t, ok)
+	require.Equal(t, "progress", ev.event)
+	require.Equal(t, "0.1.0.0.0", ev.id)
+
+	feed.wake(t, repo.ChangeChannelFetchProgress, fetchID.String())
+	ev, ok = readSSEEvent(t, body)
+	require.True(t, ok)
+	require.Equal(t, "0.0.1.0.0", ev.id)
+	var progress api.FetchProgressResponse
+	require.NoError(t, json.Unmarshal([]byte(ev.data), &progress))
+	require.True(t, progress.Terminal)
+	require.Equal(t, []uuid.UUID{candidateID}, progress.Completed.CandidateIDs)
+
+	_, ok = readSSEEvent(t, body)
+	require.False(t, ok, "stream must end after the terminal event")
+}
+
+func TestStreamFetchEvents_TerminalAlreadySeenReturns204(t *testing.T) {
+	ts, m, _ := newStreamTestServer(t)
+
+	fetchID := uuid.Must(uuid.NewV7())
+	m.userFetches.EXPECT().Get(mock.Anything, fetchID).
+		Return(repo.UserFetch{ID: fetchID}, nil).Once()
+	m.userFetches.EXPECT().GetProgress(mock.Anything, fetchID).
+		Return(repo.UserFetchProgress{Total: 1, FailedCandidateIDs: []uuid.UUID{uuid.New()}, Terminal: true}, nil).Once()
+
+	req, err := http.NewRequest(http.MethodGet, ts.URL+"/api/v1/fetches/"+fetchID.String()+"/events", nil)
+	require.NoError(t, err)
+	req.Header.Set("Last-Event-ID", "0.0.0.1.0")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+}
+
+func TestStreamFetchEvents_NotFound(t *testing.T) {
+	ts, m, _ := newStreamTestServer(t)
+
+	fetchID := uuid.Must(uuid.NewV7())
+	m.userFetches.EXPECT().Get(mock.Anything, fetchID).
+		Return(repo.UserFetch{}, pgx.ErrNoRows).Once()
+
+	resp, err := http.Get(ts.URL + "/api/v1/fetches/" + fetchID.String() + "/events")
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestStreamCandidates_ResumesFromLastEventID(t *testing.T) {
+	ts, m, _ := newStreamTestServer(t)
+
+	lastSeen := time.Date(2026, 5, 1, 12, 0, 0, 123456000, time.UTC)
+	lastID := uuid.Must(uuid.NewV7())
+	next := repo.Candidate{
+		ID:              uuid.Must(uuid.NewV7()),
+		SourceAbbr:      "dpp",
+		Title:           "T",
+		URL:             "https://example.com/a",
+		DiscoveredAt:    lastSeen.Add(time.Second),
+		IngestionMethod: repo.IngestionMethodDirectory,
+	}
+	m.scout.EXPECT().ListCandidatesDiscoveredAfter(mock.Anything, mock.MatchedBy(func(p repo.ListCandidatesDiscoveredAfterParams) bool {
+		return p.AfterDiscoveredAt.Equal(lastSeen) && p.AfterID == lastID &&
+			p.SourceAbbr != nil && *p.SourceAbbr == "dpp" &&
+			p.IngestionMethod != nil && *p.IngestionMethod == "DIRECTORY"
+	})).Return([]repo.Candidate{next}, nil).Once()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		ts.URL+"/api/v1/candidates/stream?source_abbr=dpp&ingestion_method=directory", nil)
+	require.NoError(t, err)
+	req.Header.Set("Last-Event-ID", fmt.Sprintf("%d_%s", lastSeen.UnixMicro(), lastID))
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	ev, ok := readSSEEvent(t, bufio.NewReader(resp.Body))
+	require.True(t, ok)
+	require.Equal(t, "candidate", ev.event)
+	require.Equal(t, fmt.Sprintf("%d_%s", next.DiscoveredAt.UnixMicro(), next.ID), ev.id)
+	var got api.Candidate
+	require.NoError(t, json.Unmarshal([]byte(ev.data), &got))
+	require.Equal(t, next.ID, got.ID)
+}
+
+func TestStreamCandidates_InvalidParams(t *testing.T) {
+	ts, _, _ := newStreamTestServer(t)
+
+	for name, tc := range map[string]struct {
+		query       string
+		lastEventID string
+	}{
+		"IngestionMethod": {query: "?ingestion_method=rss"},
+		"LastEventID":     {lastEventID: "not-a-cursor"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, ts.URL+"/api/v1/candidates/stream"+tc.query, nil)
+			require.NoError(t, err)
+			if tc.lastEventID != "" {
+				req.Header.Set("Last-Event-ID", tc.lastEventID)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer func() { _ = resp.Body.Close() }()
+			require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		})
+	}
+}
+
+func TestStreams_NotRegisteredWithoutChangeFeed(t *testing.T) {
+	srv, _ := newTestServer(t)
+	mux := http.NewServeMux()
+	srv.RegisterPublic(mux)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/candidates/stream", nil)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func newAdminTestServer(t *testing.T) (*http.ServeMux, *mocks.MockUsers) {
+	t.Helper()
+	srv, _ := newTestServer(t)
+	users := mocks.NewMockUsers(t)
+	api.WithUsers(users)(srv)
+	mux := http.NewServeMux()
+	srv.RegisterPublic(mux)
+	return mux, users
+}
+
+func TestAdmin_CreateUser(t *testing.T) {
+	mux, users := newAdminTestServer(t)
+	created := repo.User{ID: uuid.Must(uuid.NewV7()), Name: "newsroom", CreatedAt: time.Now().UTC()}
+	users.EXPECT().CreateUser(mock.Anything, "newsroom").Return(created, nil).Once()
+	users.EXPECT().CreateUser(mock.Anything, "newsroom").Return(repo.User{}, repo.ErrUserExists).Once()
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/users", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := post(`{"name":" newsroom "}`)
+	require.Equal(t, http.StatusCreated, rec.Code)
+	var resp api.User
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	require.Equal(t, created.ID, resp.ID)
+
+	require.Equal(t, http.StatusConflict, post(`{"name":"newsroom"}`).Code)
+	require.Equal(t, http.StatusBadRequest, post(`{"name":""}`).Code)
+}
+
+func TestAdmin_CreateAPIKeyReturnsKeyOnce(t *testing.T) {
+	mux, users := newAdminTestServer(t)
+	userID := uuid.Must(uuid.NewV7())
+	users.EXPECT().GetUser(mock.Anything, userID).Return(repo.User{ID: userID}, nil).Once()
+
+	var stored repo.CreateAPIKeyParams
+	users.EXPECT().CreateAPIKey(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, p repo.CreateAPIKeyParams) (repo.APIKey, error) {
+			stored = p
+			return repo.APIKey{ID: uuid.Must(uuid.NewV7()), UserID: p.UserID, Name: p.Name, Prefix: p.Prefix, KeyHash: p.KeyHash, Scopes: p.Scopes}, nil
+		}).Once()
+
+	body := `{"name":"ci","scopes":["READ","page_fetch","read"],"rate_limit_rps":2}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/users/"+userID.String()+"/api_keys", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusCreated, rec.Code)
+	raw := rec.Body.String()
+	require.NotContains(t, raw, "key_hash")
+	var resp api.CreateAPIKeyResponse
+	require.NoError(t, json.Unmarshal([]byte(raw), &resp))
+	require.Equal(t, apikey.Hash(resp.Key), stored.KeyHash)
+	require.True(t, strings.HasPrefix(resp.Key, resp.Prefix))
+	require.Equal(t, []string{"read", "page_fetch"}, resp.Scopes)
+	require.NotNil(t, stored.RateLimitRPS)
+	require.InDelta(t, 2.0, *stored.RateLimitRPS, 1e-9)
+}
+
+func TestAdmin_CreateAPIKeyValidation(t *testing.T) {
+	mux, users := newAdminTestServer(t)
+	missing := uuid.Must(uuid.NewV7())
+	users.EXPECT().GetUser(mock.Anything, missing).Return(repo.User{}, pgx.ErrNoRows).Once()
+
+	for path, want := range map[string]int{
+		"/api/v1/admin/users/not-a-uuid/api_keys":               http.StatusBadRequest,
+		"/api/v1/admin/users/" + missing.String() + "/api_keys": http.StatusNotFound,
+	} {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"name":"ci","scopes":["read"]}`))
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		require.Equal(t, want, rec.Code, path)
+	}
+
+	userID := uuid.Must(uuid.NewV7())
+	for _, body := range []string{
+		`{"scopes":["read"]}`,
+		`{"name":"ci"}`,
+		`{"name":"ci","scopes":["write"]}`,
+		`{"name":"ci","scopes":["read"],"rate_limit_burst":0}`,
+		`{"name":"ci","scopes":["read"],"expires_at":"2001-01-01T00:00:00Z"}`,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/users/"+userID.String()+"/api_keys", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusBadRequest, rec.Code, body)
+	}
+}
+
+func TestAdmin_ListAndRevokeAPIKeys(t *testing.T) {
+	mux, users := newAdminTestServer(t)
+	userID := uuid.Must(uuid.NewV7())
+	keyID := uuid.Must(uuid.NewV7())
+	users.EXPECT().ListAPIKeys(mock.Anything, userID).
+		Return([]repo.APIKey{{ID: keyID, UserID: userID, Name: "ci", Prefix: "prism_abcdef", KeyHash: "secret-hash"}}, nil).Once()
+	users.EXPECT().RevokeAPIKey(mock.Anything, keyID).Return(int64(1), nil).Once()
+	users.EXPECT().RevokeAPIKey(mock.Anything, keyID).Return(int64(0), nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/users/"+userID.String()+"/api_keys", nil)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NotContains(t, rec.Body.String(), "secret-hash")
+	var list api.ListAPIKeysResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&list))
+	require.Equal(t, 1, list.Count)
+	require.Equal(t, keyID, list.Items[0].ID)
+
+	for _, want := range []int{http.StatusNoContent, http.StatusNotFound} {
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/admin/api_keys/"+keyID.String(), nil)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		require.Equal(t, want, rec.Code)
+	}
+}
+
+func TestAdmin_NotRegisteredWithoutUsers(t *testing.T) {
+	srv, _ := newTestServer(t)
+	mux := http.NewServeMux()
+	srv.RegisterPublic(mux)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/users", strings.NewReader(`{"name":"x"}`))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func newCatalogTestServer(t *testing.T) (*http.ServeMux, *mocks.MockCatalog) {
+	t.Helper()
+	srv, _ := newTestServer(t)
+	c := mocks.NewMockCatalog(t)
+	api.WithCatalog(c)(srv)
+	mux := http.NewServeMux()
+	srv.RegisterPublic(mux)
+	return mux, c
+}
+
+func serveCatalog(mux *http.ServeMux, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestCatalog_PutAndDeleteSource(t *testing.T) {
+	mux, c := newCatalogTestServer(t)
+	c.EXPECT().UpsertSource(mock.Anything, repo.UpsertSourceParams{
+		Abbr: "udn", Name: "聯合新聞網", Type: repo.SourceTypeMedia, BaseURL: "https://udn.com",
+	}).Return(repo.Source{Abbr: "udn", Name: "聯合新聞網", Type: repo.SourceTypeMedia, BaseURL: "https://udn.com"}, nil).Once()
+	c.EXPECT().DeleteSource(mock.Anything, "udn").Return(int64(1), nil).Once()
+	c.EXPECT().DeleteSource(mock.Anything, "udn").Return(int64(0), nil).Once()
+
+	rec := serveCatalog(mux, http.MethodPut, "/api/v1/admin/sources/UDN", `{"name":"聯合新聞網","type":"media","base_url":"https://udn.com"}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var src api.Source
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&src))
+	require.Equal(t, "udn", src.Abbr)
+
+	require.Equal(t, http.StatusBadRequest, serveCatalog(mux, http.MethodPut, "/api/v1/admin/sources/udn", `{"name":"x","type":"BLOG","base_url":"https://udn.com"}`).Code)
+	require.Equal(t, http.StatusBadRequest, serveCatalog(mux, http.MethodPut, "/api/v1/admin/sources/udn", `{"name":"x","type":"MEDIA","base_url":"udn.com"}`).Code)
+	require.Equal(t, http.StatusBadRequest, serveCatalog(mux, http.MethodPut, "/api/v1/admin/sources/waytoolongabbreviation", `{"name":"x","type":"MEDIA","base_url":"https://udn.com"}`).Code)
+
+	require.Equal(t, http.StatusNoContent, serveCatalog(mux, http.MethodDelete, "/api/v1/admin/sources/udn", "").Code)
+	require.Equal(t, http.StatusNotFound, serveCatalog(mux, http.MethodDelete, "/api/v1/admin/sources/udn", "").Code)
+}
+
+func TestCatalog_PutScoutConfigValidates(t *testing.T) {
+	mux, c := newCatalogTestServer(t)
+	existing := repo.ScoutConfig{Name: "cna", Kind: "rss", Config: []byte(`{"name":"cna","hosts":["feeds.feedburner.com"]}`)}
+	c.EXPECT().ListScoutConfigs(mock.Anything).Return([]repo.ScoutConfig{existing}, nil)
+	c.EXPECT().UpsertScoutConfig(mock.Anything, mock.MatchedBy(func(p repo.UpsertScoutConfigParams) bool {
+		return p.Name == "udn" && p.Kind == "rss"
+	})).RunAndReturn(func(_ context.Context, p repo.UpsertScoutConfigParams) (repo.ScoutConfig, error) {
+		return repo.ScoutConfig{Name: p.Name, Kind: p.Kind, Config: p.Config}, nil
+	}).Once()
+
+	rec := serveCatalog(mux, http.MethodPut, "/api/v1/admin/scouts/udn", `{"kind":"RSS","config":{"name":"udn","hosts":["udn.com"]}}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var got api.ScoutConfig
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&got))
+	require.Equal(t, "udn", got.Name)
+	require.JSONEq(t, `{"name":"udn","hosts":["udn.com"]}`, string(got.Config))
+
+	for name, body := range map[string]string{
+		"host taken by cna": `{"kind":"rss","config":{"name":"udn","hosts":["feeds.feedburner.com"]}}`,
+		"name mismatch":     `{"kind":"rss","config":{"name":"other","hosts":["udn.com"]}}`,
+		"no hosts":          `{"kind":"rss","config":{"name":"udn"}}`,
+		"html without rule": `{"kind":"html","config":{"name":"udn","hosts":["udn.com"]}}`,
+		"unknown kind":      `{"kind":"json","config":{"name":"udn","hosts":["udn.com"]}}`,
+		"unknown field":     `{"kind":"rss","config":{"name":"udn","hosts":["udn.com"],"hots":1}}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			rec := serveCatalog(mux, http.MethodPut, "/api/v1/admin/scouts/udn", body)
+			require.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+		})
+	}
+
+	// Replacing a scout may keep its own hosts.
+	c.EXPECT().UpsertScoutConfig(mock.Anything, mock.MatchedBy(func(p repo.UpsertScoutConfigParams) bool {
+		return p.Name == "cna"
+	})).Return(existing, nil).Once()
+	rec = serveCatalog(mux, http.MethodPut, "/api/v1/admin/scouts/cna", `{"kind":"rss","config":{"name":"cna","hosts":["feeds.feedburner.com"],"enabled":false}}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+}
+
+func TestCatalog_ListAndDeleteScouts(t *testing.T) {
+	mux, c := newCatalogTestServer(t)
+	c.EXPECT().ListScoutConfigs(mock.Anything).
+		Return([]repo.ScoutConfig{{Name: "cna", Kind: "rss", Config: []byte(`{"name":"cna"}`)}}, nil).Once()
+	c.EXPECT().DeleteScoutConfig(mock.Anything, "cna").Return(int64(1), nil).Once()
+	c.EXPECT().DeleteScoutConfig(mock.Anything, "gone").Return(int64(0), nil).Once()
+
+	rec := serveCatalog(mux, http.MethodGet, "/api/v1/admin/scouts", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var list api.ListScoutConfigsResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&list))
+	require.Equal(t, 1, list.Count)
+	require.Equal(t, "rss", list.Items[0].Kind)
+
+	require.Equal(t, http.StatusNoContent, serveCatalog(mux, http.MethodDelete, "/api/v1/admin/scouts/cna", "").Code)
+	require.Equal(t, http.StatusNotFound, serveCatalog(mux, http.MethodDelete, "/api/v1/admin/scouts/gone", "").Code)
+}
+
+func TestCatalog_ParserRules(t *testing.T) {
+	mux, c := newCatalogTestServer(t)
+	c.EXPECT().UpsertParserRule(mock.Anything, mock.MatchedBy(func(p repo.UpsertParserRuleParams) bool {
+		return p.Host == "udn.com"
+	})).RunAndReturn(func(_ context.Context, p repo.UpsertParserRuleParams) (repo.ParserRule, error) {
+		return repo.ParserRule{Host: p.Host, Config: p.Config}, nil
+	}).Once()
+	c.EXPECT().ListParserRules(mock.Anything).
+		Return([]repo.ParserRule{{Host: "udn.com", Config: []byte(`{"html":{"title":["h1"]}}`)}}, nil).Once()
+	c.EXPECT().DeleteParserRule(mock.Anything, "udn.com").Return(int64(1), nil).Once()
+
+	rec := serveCatalog(mux, http.MethodPut, "/api/v1/admin/parsers/UDN.com", `{"jsonld":true,"html":{"title":["h1"]}}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	require.Equal(t, http.StatusBadRequest, serveCatalog(mux, http.MethodPut, "/api/v1/admin/parsers/udn.com", `{"jsonld":true}`).Code)
+	require.Equal(t, http.StatusBadRequest, serveCatalog(mux, http.MethodPut, "/api/v1/admin/parsers/udn.com:8080", `{"html":{}}`).Code)
+
+	rec = serveCatalog(mux, http.MethodGet, "/api/v1/admin/parsers", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var list api.ListParserRulesResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&list))
+	require.Equal(t, "udn.com", list.Items[0].Host)
+
+	require.Equal(t, http.StatusNoContent, serveCatalog(mux, http.MethodDelete, "/api/v1/admin/parsers/udn.com", "").Code)
+}
+
+func TestCatalog_NotRegisteredWithoutCatalog(t *testing.T) {
+	srv, _ := newTestServer(t)
+	mux := http.NewServeMux()
+	srv.RegisterPublic(mux)
+
+	require.Equal(t, http.StatusNotFound, serveCatalog(mux, http.MethodGet, "/api/v1/admin/scouts", "").Code)
+}
+
+func newAliasesTestServer(t *testing.T) (*http.ServeMux, *mocks.MockAliases) {
+	t.Helper()
+	srv, _ := newTestServer(t)
+	a := mocks.NewMockAliases(t)
+	api.WithAliases(a)(srv)
+	mux := http.NewServeMux()
+	srv.RegisterPublic(mux)
+	return mux, a
+}
+
+func TestAliases_ListGroupsByCanonical(t *testing.T) {
+	mux, a := newAliasesTestServer(t)
+	a.EXPECT().ListAliases(mock.Anything).Return([]repo.SearchAlias{
+		{Canonical: "中國國民黨", Alias: "國民黨", Kind: repo.AliasKindAbbreviation},
+		{Canonical: "國家通訊傳播委員會", Alias: "NCC", Kind: repo.AliasKindAbbreviation},
+		{Canonical: "國家通訊傳播委員會", Alias: "通傳會", Kind: repo.AliasKindAlias},
+	}, nil).Once()
+
+	rec := serveCatalog(mux, http.MethodGet, "/api/v1/admin/aliases", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var list api.ListSearchAliasesResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&list))
+	require.Equal(t, 2, list.Count)
+	require.Equal(t, "國家通訊傳播委員會", list.Items[1].Canonical)
+	require.Len(t, list.Items[1].Aliases, 2)
+	require.Equal(t, "通傳會", list.Items[1].Aliases[1].Alias)
+}
+
+func TestAliases_PutValidatesAndReplacesGroup(t *testing.T) {
+	mux, a := newAliasesTestServer(t)
+	a.EXPECT().ReplaceAliases(mock.Anything, repo.ReplaceSearchAliasesParams{
+		Canonical: "民主進步黨",
+		Aliases: []repo.SearchAliasEntry{
+			{Alias: "民進黨", Kind: repo.AliasKindAbbreviation},
+			{Alias: "DPP", Kind: repo.AliasKindAlias},
+		},
+	}).Return([]repo.SearchAlias{
+		{Canonical: "民主進步黨", Alias: "民進黨", Kind: repo.AliasKindAbbreviation},
+		{Canonical: "民主進步黨", Alias: "DPP", Kind: repo.AliasKindAlias},
+	}, nil).Once()
+
+	rec := serveCatalog(mux, http.MethodPut, "/api/v1/admin/aliases/"+url.PathEscape("民主進步黨"),
+		`{"aliases":[{"alias":" 民進黨 ","kind":"abbreviation"},{"alias":"DPP"}]}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var group api.SearchAliasGroup
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&group))
+	require.Equal(t, "民主進步黨", group.Canonical)
+	require.Len(t, group.Aliases, 2)
+
+	for name, body := range map[string]string{
+		"no aliases":      `{"aliases":[]}`,
+		"empty alias":     `{"aliases":[{"alias":" "}]}`,
+		"alias is itself": `{"aliases":[{"alias":"民主進步黨"}]}`,
+		"duplicate alias": `{"aliases":[{"alias":"DPP"},{"alias":"DPP","kind":"VARIANT"}]}`,
+		"unknown kind":    `{"aliases":[{"alias":"DPP","kind":"NICKNAME"}]}`,
+		"invalid JSON":    `{"aliases":`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			rec := serveCatalog(mux, http.MethodPut, "/api/v1/admin/aliases/"+url.PathEscape("民主進步黨"), body)
+			require.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+		})
+	}
+}
+
+func TestAliases_Delete(t *testing.T) {
+	mux, a := newAliasesTestServer(t)
+	a.EXPECT().DeleteAliases(mock.Anything, "立法院").Return(int64(1), nil).Once()
+	a.EXPECT().DeleteAliases(mock.Anything, "立法院").Return(int64(0), nil).Once()
+
+	path := "/api/v1/admin/aliases/" + url.PathEscape("立法院")
+	require.Equal(t, http.StatusNoContent, serveCatalog(mux, http.MethodDelete, path, "").Code)
+	require.Equal(t, http.StatusNotFound, serveCatalog(mux, http.MethodDelete, path, "").Code)
+}
+
+func TestAliases_NotRegisteredWithoutAliases(t *testing.T) {
+	mux, _ := newCatalogTestServer(t)
+	require.Equal(t, http.StatusNotFound, serveCatalog(mux, http.MethodGet, "/api/v1/admin/aliases", "").Code)
+}
+
+func newTaskRunsTestServer(t *testing.T) (*http.ServeMux, *mocks.MockTaskRuns) {
+	t.Helper()
+	srv, _ := newTestServer(t)
+	runs := mocks.NewMockTaskRuns(t)
+	api.WithTaskRuns(runs)(srv)
+	mux := http.NewServeMux()
+	srv.RegisterPublic(mux)
+	return mux, runs
+}
+
+func TestTaskRunYield_GroupsByTask(t *testing.T) {
+	mux, runs := newTaskRunsTestServer(t)
+	since := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	until := since.AddDate(0, 0, 30)
+	lastRun := until.Add(-time.Hour)
+	taskID := uuid.Must(uuid.NewV7())
+
+	runs.EXPECT().SummarizeByTask(mock.Anything, mock.MatchedBy(func(p repo.SummarizeTaskRunsParams) bool {
+		return p.Since.Equal(since) && p.Until.Equal(until) &&
+			p.Kind != nil && *p.Kind == repo.TaskKindKeywordSearch &&
+			p.SourceAbbr != nil && *p.SourceAbbr == "yahoo" && p.Limit == 20
+	})).Return([]repo.TaskRunYield{{
+		TaskID:         taskID,
+		Kind:           repo.TaskKindKeywordSearch,
+		SourceAbbr:     "yahoo",
+		Query:          "NCC 開罰",
+		Runs:           12,
+		FailedRuns:     1,
+		ItemsSeen:      40,
+		ItemsDuplicate: 40,
+		AvgDuration:    1500 * time.Millisecond,
+		LastRunAt:      lastRun,
+	}}, nil).Once()
+
+	rec := serveCatalog(mux, http.MethodGet,
+		"/api/v1/admin/task_runs/yield?group_by=task&since=2026-09-01&until=2026-10-01&kind=keyword_search&source_abbr=Yahoo&limit=20", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var resp api.TaskRunYieldResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	require.Equal(t, api.YieldGroupByTask, resp.GroupBy)
+	require.Equal(t, 1, resp.Count)
+	got := resp.Items[0]
+	require.NotNil(t, got.TaskID)
+	require.Equal(t, taskID, *got.TaskID)
+	require.Equal(t, "NCC 開罰", got.Query)
+	require.Equal(t, int64(40), got.ItemsDuplicate)
+	require.Zero(t, got.ItemsNew)
+	require.Equal(t, int64(1500), got.AvgDurationMs)
+	require.Nil(t, got.LastNewAt)
+}
+
+func TestTaskRunYield_DefaultsToSourceAndGroupsByProvider(t *testing.T) {
+	mux, runs := newTaskRunsTestServer(t)
+	runs.EXPECT().SummarizeBySource(mock.Anything, mock.MatchedBy(func(p repo.SummarizeTaskRunsParams) bool {
+		return p.Kind == nil && p.SourceAbbr == nil && p.Limit == 100
+	})).Return([]repo.TaskRunYield{{Kind: repo.TaskKindDirectoryFetch, SourceAbbr: "dpp", Runs: 3}}, nil).Once()
+	runs.EXPECT().SummarizeByProvider(mock.Anything, mock.Anything).
+		Return([]repo.TaskRunYield{{Provider: "brave", Runs: 5, FailedRuns: 2, ItemsSeen: 30}}, nil).Once()
+
+	rec := serveCatalog(mux, http.MethodGet, "/api/v1/admin/task_runs/yield", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var resp api.TaskRunYieldResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	require.Equal(t, api.YieldGroupBySource, resp.GroupBy)
+	require.Equal(t, "dpp", resp.Items[0].SourceAbbr)
+	require.Nil(t, resp.Items[0].TaskID)
+
+	rec = serveCatalog(mux, http.MethodGet, "/api/v1/admin/task_runs/yield?group_by=provider", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	require.Equal(t, "brave", resp.Items[0].Provider)
+	require.Equal(t, int64(2), resp.Items[0].FailedRuns)
+}
+
+func TestTaskRunYield_InvalidQuery(t *testing.T) {
+	mux, _ := newTaskRunsTestServer(t)
+	for _, query := range []string{
+		"group_by=day",
+		"kind=PAGE_FETCH",
+		"limit=0",
+		"since=2026-10-03&until=2026-10-01",
+	} {
+		rec := serveCatalog(mux, http.MethodGet, "/api/v1/admin/task_runs/yield?"+query, "")
+		require.Equal(t, http.StatusBadRequest, rec.Code, query)
+	}
+}
+
+func TestListTaskRuns(t *testing.T) {
+	mux, runs := newTaskRunsTestServer(t)
+	taskID := uuid.Must(uuid.NewV7())
+	query := "NCC 開罰"
+	errorClass := "search"
+	runs.EXPECT().ListByTask(mock.Anything, repo.ListTaskRunsParams{TaskID: taskID, Limit: 501}).Return([]repo.TaskRun{{
+		ID:         7,
+		TaskID:     taskID,
+		Kind:       repo.TaskKindKeywordSearch,
+		SourceType: repo.SourceTypeMedia,
+		SourceAbbr: "yahoo",
+		Query:      &query,
+		Status:     repo.TaskRunStatusFailed,
+		ErrorClass: &errorClass,
+		Providers:  map[string]repo.TaskRunProvider{"brave": {ErrorClass: "rate_limited"}},
+		Duration:   2 * time.Second,
+	}}, nil).Once()
+
+	rec := serveCatalog(mux, http.MethodGet, "/api/v1/admin/tasks/"+taskID.String()+"/runs?limit=5000", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var resp api.ListTaskRunsResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	require.Equal(t, 1, resp.Count)
+	require.Equal(t, "search", resp.Items[0].ErrorClass)
+	require.Equal(t, "NCC 開罰", resp.Items[0].Query)
+	require.Equal(t, int64(2000), resp.Items[0].DurationMs)
+	require.Equal(t, map[string]api.TaskRunProvider{"brave": {ErrorClass: "rate_limited"}}, resp.Items[0].Providers)
+	require.Empty(t, resp.NextCursor)
+
+	require.Equal(t, http.StatusBadRequest, serveCatalog(mux, http.MethodGet, "/api/v1/admin/tasks/nope/runs", "").Code)
+	require.Equal(t, http.StatusBadRequest, serveCatalog(mux, http.MethodGet, "/api/v1/admin/tasks/"+taskID.String()+"/runs?cursor=nope", "").Code)
+}
+
+func TestListTaskRuns_Cursor(t *testing.T) {
+	mux, runs := newTaskRunsTestServer(t)
+	taskID := uuid.Must(uuid.NewV7())
+	newest := time.Date(2026, 10, 2, 8, 0, 0, 0, time.UTC)
+	older := newest.Add(-time.Hour)
+	runs.EXPECT().ListByTask(mock.Anything, repo.ListTaskRunsParams{TaskID: taskID, Limit: 2}).
+		Return([]repo.TaskRun{
+			{ID: 9, TaskID: taskID, StartedAt: newest},
+			{ID: 8, TaskID: taskID, StartedAt: older},
+		}, nil).Once()
+
+	path := "/api/v1/admin/tasks/" + taskID.String() + "/runs?limit=1"
+	rec := serveCatalog(mux, http.MethodGet, path, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var first api.ListTaskRunsResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&first))
+	require.Equal(t, 1, first.Count)
+	require.Equal(t, int64(9), first.Items[0].ID)
+	require.NotEmpty(t, first.NextCursor)
+
+	runs.EXPECT().ListByTask(mock.Anything, mock.MatchedBy(func(p repo.ListTaskRunsParams) bool {
+		return p.TaskID == taskID && p.Limit == 2 &&
+			p.AfterStartedAt != nil && p.AfterStartedAt.Equal(newest) &&
+			p.AfterID != nil && *p.AfterID == 9
+	})).Return([]repo.TaskRun{{ID: 8, TaskID: taskID, StartedAt: older}}, nil).Once()
+
+	rec = serveCatalog(mux, http.MethodGet, path+"&cursor="+first.NextCursor, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var second api.ListTaskRunsResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&second))
+	require.Equal(t, int64(8), second.Items[0].ID)
+	require.Empty(t, second.NextCursor)
+}
+
+func TestTaskRuns_NotRegisteredWithoutTaskRuns(t *testing.T) {
+	mux, _ := newCatalogTestServer(t)
+	require.Equal(t, http.StatusNotFound, serveCatalog(mux, http.MethodGet, "/api/v1/admin/task_runs/yield", "").Code)
+}
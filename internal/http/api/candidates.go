@@ -35,10 +35,25 @@ type ListCandidatesResponse struct {
 	Limit  int32       `json:"limit"`
 	Offset int32       `json:"offset"`
 	Count  int         `json:"count"`
+	// NextCursor is set when more rows may follow; pass it back as ?cursor=.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// candidatesCursor is the keyset position behind /candidates cursors.
+// PublishedAt is nil once paging has reached undated candidates.
+type candidatesCursor struct {
+	PublishedAt  *time.Time `json:"p,omitempty"`
+	DiscoveredAt time.Time  `json:"d"`
+	ID           uuid.UUID  `json:"i"`
 }
 
 // ListCandidates handles GET /api/v1/candidates.
 //
+// Rows are ordered newest first by published_at (undated candidates last),
+// then discovered_at. Follow next_cursor for the next page; unlike offset,
+// the cursor neither skips nor repeats rows while discovery keeps inserting.
+// offset is deprecated and cannot be combined with cursor.
+//
 // @Summary   List candidate article briefs
 // @Tags      candidates
 // @Produce   json
@@ -47,7 +62,8 @@ type ListCandidatesResponse struct {
 // @Param     since       query string false "Lower bound on published_at/discovered_at (RFC3339)"
 // @Param     until       query string false "Upper bound on published_at/discovered_at (RFC3339)"
 // @Param     limit       query int    false "Page size (default 50, max 200)"
-// @Param     offset      query int    false "Pagination offset (default 0)"
+// @Param     cursor      query string false "next_cursor from the previous page"
+// @Param     offset      query int    false "Deprecated: pagination offset (default 0); use cursor"
 // @Success   200 {object} ListCandidatesResponse
 // @Failure   400 {object} ErrorResponse
 // @Failure   500 {object} ErrorResponse
@@ -100,7 +116,22 @@ func (s *Server) ListCandidates(w http.ResponseWriter, r *http.Request) {
 		}
 		params.Offset = int32(n)
 	}
+	if v := strings.TrimSpace(q.Get("cursor")); v != "" {
+		if params.Offset != 0 {
+			writeError(w, http.StatusBadRequest, "cursor and offset are mutually exclusive")
+			return
+		}
+		pos, err := decodePageCursor[candidatesCursor](v)
+		if err != nil || pos.ID == uuid.Nil || pos.DiscoveredAt.IsZero() {
+			writeError(w, http.StatusBadRequest, errInvalidCursor.Error())
+			return
+		}
+		params.AfterPublishedAt = pos.PublishedAt
+		params.AfterDiscoveredAt, params.AfterID = &pos.DiscoveredAt, &pos.ID
+	}
 
+	limit := params.Limit
+	params.Limit++
 	rows, err := s.Scout.ListCandidates(r.Context(), params)
 	if err != nil {
 		s.Logger.ErrorContext(r.Context(), "list candidates failed", slog.Any("error", err))
@@ -108,16 +139,25 @@ func (s *Server) ListCandidates(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	items := make([]Candidate, 0, len(rows))
+	rows, more := trimPage(rows, limit)
+	resp := ListCandidatesResponse{
+		Items:  make([]Candidate, 0, len(rows)),
+		Limit:  limit,
+		Offset: params.Offset,
+		Count:  len(rows),
+	}
 	for _, c := range rows {
-		items = append(items, toCandidate(c))
+		resp.Items = append(resp.Items, toCandidate(c))
 	}
-	writeJSON(w, http.StatusOK, ListCandidatesResponse{
-		Items:  items,
-		Limit:  params.Limit,
-		Offset: params.Offset,
-		Count:  len(items),
-	})
+	if more {
+		last := rows[len(rows)-1]
+		resp.NextCursor = encodePageCursor(candidatesCursor{
+			PublishedAt:  last.PublishedAt,
+			DiscoveredAt: last.DiscoveredAt,
+			ID:           last.ID,
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
 }
 
 func toCandidate(c repo.Candidate) Candidate {
@@ -0,0 +1,392 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/catalog"
+	parserconfig "github.com/ChiaYuChang/prism/internal/collector/parser/config"
+	scoutconfig "github.com/ChiaYuChang/prism/internal/discovery/scout/config"
+	"github.com/ChiaYuChang/prism/internal/repo"
+)
+
+const (
+	maxSourceAbbrLen = 16
+	maxScoutNameLen  = 64
+	maxParserHostLen = 255
+)
+
+var scoutKinds = []string{scoutconfig.KindHTML, scoutconfig.KindRSS, scoutconfig.KindAtom, scoutconfig.KindSitemap, scoutconfig.KindJSON, scoutconfig.KindSocial, scoutconfig.KindCustom}
+
+// PutSourceRequest is the body of PUT /api/v1/admin/sources/{abbr}.
+type PutSourceRequest struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	BaseURL string `json:"base_url"`
+}
+
+// ScoutConfig is one discovery scout. Config is a scouts.yaml entry of the
+// given kind, in JSON, with the section defaults spelled out.
+type ScoutConfig struct {
+	Name      string          `json:"name"`
+	Kind      string          `json:"kind"`
+	Config    json.RawMessage `json:"config" swaggertype:"object"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// PutScoutConfigRequest is the body of PUT /api/v1/admin/scouts/{name}.
+// Config.name must equal the path name.
+type PutScoutConfigRequest struct {
+	Kind   string          `json:"kind"`
+	Config json.RawMessage `json:"config" swaggertype:"object"`
+}
+
+// ListScoutConfigsResponse is returned by GET /api/v1/admin/scouts.
+type ListScoutConfigsResponse struct {
+	Items []ScoutConfig `json:"items"`
+	Count int           `json:"count"`
+}
+
+// ParserRule is the parser config of one host: a parsers.yaml `parsers:`
+// entry in JSON.
+type ParserRule struct {
+	Host      string          `json:"host"`
+	Config    json.RawMessage `json:"config" swaggertype:"object"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// ListParserRulesResponse is returned by GET /api/v1/admin/parsers.
+type ListParserRulesResponse struct {
+	Items []ParserRule `json:"items"`
+	Count int          `json:"count"`
+}
+
+// PutSource handles PUT /api/v1/admin/sources/{abbr}.
+//
+// Creates or replaces a source. Putting a deleted source revives it.
+//
+// @Summary   Create or replace a source
+// @Tags      admin
+// @Accept    json
+// @Produce   json
+// @Param     abbr path string           true "Source abbreviation"
+// @Param     body body PutSourceRequest true "Source"
+// @Success   200 {object} Source
+// @Failure   400 {object} ErrorResponse
+// @Failure   500 {object} ErrorResponse
+// @Router    /admin/sources/{abbr} [put]
+func (s *Server) PutSource(w http.ResponseWriter, r *http.Request) {
+	abbr := strings.ToLower(strings.TrimSpace(r.PathValue("abbr")))
+	if abbr == "" || len(abbr) > maxSourceAbbrLen {
+		writeError(w, http.StatusBadRequest, "abbr is required (max 16 characters)")
+		return
+	}
+	var req PutSourceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	if msg := validatePutSource(&req); msg != "" {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	ctx := r.Context()
+	src, err := s.Catalog.UpsertSource(ctx, repo.UpsertSourceParams{
+		Abbr:    abbr,
+		Name:    req.Name,
+		Type:    req.Type,
+		BaseURL: req.BaseURL,
+	})
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "upsert source failed", slog.String("abbr", abbr), slog.Any("error", err))
+		writeError(w, http.StatusInternalServerError, "failed to save source")
+		return
+	}
+	writeJSON(w, http.StatusOK, Source{Abbr: src.Abbr, Name: src.Name, Type: src.Type, BaseURL: src.BaseURL})
+}
+
+// DeleteSource handles DELETE /api/v1/admin/sources/{abbr}.
+//
+// Sources are soft-deleted: they drop out of GET /sources while their
+// candidates and contents stay.
+//
+// @Summary   Delete a source
+// @Tags      admin
+// @Param     abbr path string true "Source abbreviation"
+// @Success   204
+// @Failure   404 {object} ErrorResponse
+// @Failure   500 {object} ErrorResponse
+// @Router    /admin/sources/{abbr} [delete]
+func (s *Server) DeleteSource(w http.ResponseWriter, r *http.Request) {
+	abbr := strings.ToLower(strings.TrimSpace(r.PathValue("abbr")))
+	ctx := r.Context()
+	n, err := s.Catalog.DeleteSource(ctx, abbr)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "delete source failed", slog.String("abbr", abbr), slog.Any("error", err))
+		writeError(w, http.StatusInternalServerError, "failed to delete source")
+		return
+	}
+	if n == 0 {
+		writeError(w, http.StatusNotFound, "source not found or already deleted")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListScoutConfigs handles GET /api/v1/admin/scouts.
+//
+// @Summary   List scout configs
+// @Tags      admin
+// @Produce   json
+// @Success   200 {object} ListScoutConfigsResponse
+// @Failure   500 {object} ErrorResponse
+// @Router    /admin/scouts [get]
+func (s *Server) ListScoutConfigs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rows, err := s.Catalog.ListScoutConfigs(ctx)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "list scout configs failed", slog.Any("error", err))
+		writeError(w, http.StatusInternalServerError, "failed to list scouts")
+		return
+	}
+	items := make([]ScoutConfig, len(rows))
+	for i, row := range rows {
+		items[i] = toScoutConfig(row)
+	}
+	writeJSON(w, http.StatusOK, ListScoutConfigsResponse{Items: items, Count: len(items)})
+}
+
+// PutScoutConfig handles PUT /api/v1/admin/scouts/{name}.
+//
+// Creates or replaces a scout. The new set of scouts must pass the same
+// validation as scouts.yaml, so a host already served by another scout is
+// rejected. Discovery workers reading the catalog pick the change up
+// without a restart.
+//
+// @Summary   Create or replace a scout config
+// @Tags      admin
+// @Accept    json
+// @Produce   json
+// @Param     name path string                true "Scout name"
+// @Param     body body PutScoutConfigRequest true "Scout config"
+// @Success   200 {object} ScoutConfig
+// @Failure   400 {object} ErrorResponse
+// @Failure   500 {object} ErrorResponse
+// @Router    /admin/scouts/{name} [put]
+func (s *Server) PutScoutConfig(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimSpace(r.PathValue("name"))
+	if name == "" || len(name) > maxScoutNameLen {
+		writeError(w, http.StatusBadRequest, "name is required (max 64 characters)")
+		return
+	}
+	var req PutScoutConfigRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	req.Kind = strings.ToLower(strings.TrimSpace(req.Kind))
+	if !slices.Contains(scoutKinds, req.Kind) {
+		writeError(w, http.StatusBadRequest, "invalid kind: expected "+strings.Join(scoutKinds, ", "))
+		return
+	}
+	if len(req.Config) == 0 {
+		writeError(w, http.StatusBadRequest, "config is required")
+		return
+	}
+
+	ctx := r.Context()
+	rows, err := s.Catalog.ListScoutConfigs(ctx)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "list scout configs failed", slog.Any("error", err))
+		writeError(w, http.StatusInternalServerError, "failed to load scouts")
+		return
+	}
+	next := repo.ScoutConfig{Name: name, Kind: req.Kind, Config: req.Config}
+	rows = slices.DeleteFunc(rows, func(row repo.ScoutConfig) bool { return row.Name == name })
+	if err := catalog.ValidateScouts(append(rows, next)); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid scout config: "+err.Error())
+		return
+	}
+
+	saved, err := s.Catalog.UpsertScoutConfig(ctx, repo.UpsertScoutConfigParams{
+		Name:   name,
+		Kind:   req.Kind,
+		Config: req.Config,
+	})
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "upsert scout config failed", slog.String("name", name), slog.Any("error", err))
+		writeError(w, http.StatusInternalServerError, "failed to save scout")
+		return
+	}
+	writeJSON(w, http.StatusOK, toScoutConfig(saved))
+}
+
+// DeleteScoutConfig handles DELETE /api/v1/admin/scouts/{name}.
+//
+// @Summary   Delete a scout config
+// @Tags      admin
+// @Param     name path string true "Scout name"
+// @Success   204
+// @Failure   404 {object} ErrorResponse
+// @Failure   500 {object} ErrorResponse
+// @Router    /admin/scouts/{name} [delete]
+func (s *Server) DeleteScoutConfig(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimSpace(r.PathValue("name"))
+	ctx := r.Context()
+	n, err := s.Catalog.DeleteScoutConfig(ctx, name)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "delete scout config failed", slog.String("name", name), slog.Any("error", err))
+		writeError(w, http.StatusInternalServerError, "failed to delete scout")
+		return
+	}
+	if n == 0 {
+		writeError(w, http.StatusNotFound, "scout not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListParserRules handles GET /api/v1/admin/parsers.
+//
+// @Summary   List parser rules
+// @Tags      admin
+// @Produce   json
+// @Success   200 {object} ListParserRulesResponse
+// @Failure   500 {object} ErrorResponse
+// @Router    /admin/parsers [get]
+func (s *Server) ListParserRules(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rows, err := s.Catalog.ListParserRules(ctx)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "list parser rules failed", slog.Any("error", err))
+		writeError(w, http.StatusInternalServerError, "failed to list parser rules")
+		return
+	}
+	items := make([]ParserRule, len(rows))
+	for i, row := range rows {
+		items[i] = toParserRule(row)
+	}
+	writeJSON(w, http.StatusOK, ListParserRulesResponse{Items: items, Count: len(items)})
+}
+
+// PutParserRule handles PUT /api/v1/admin/parsers/{host}.
+//
+// The body is one parsers.yaml `parsers:` entry; unknown fields are
+// rejected. Collector workers reading the catalog pick the change up
+// without a restart.
+//
+// @Summary   Create or replace a host's parser rule
+// @Tags      admin
+// @Accept    json
+// @Produce   json
+// @Param     host path string true "Host name, e.g. www.example.com"
+// @Param     body body object true "Parser config (parsers.yaml entry)"
+// @Success   200 {object} ParserRule
+// @Failure   400 {object} ErrorResponse
+// @Failure   500 {object} ErrorResponse
+// @Router    /admin/parsers/{host} [put]
+func (s *Server) PutParserRule(w http.ResponseWriter, r *http.Request) {
+	host := strings.ToLower(strings.TrimSpace(r.PathValue("host")))
+	if !validHost(host) {
+		writeError(w, http.StatusBadRequest, "invalid host")
+		return
+	}
+	var body json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	if _, err := parserconfig.DecodeParserConfig(body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid parser config: "+err.Error())
+		return
+	}
+
+	ctx := r.Context()
+	saved, err := s.Catalog.UpsertParserRule(ctx, repo.UpsertParserRuleParams{Host: host, Config: body})
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "upsert parser rule failed", slog.String("host", host), slog.Any("error", err))
+		writeError(w, http.StatusInternalServerError, "failed to save parser rule")
+		return
+	}
+	writeJSON(w, http.StatusOK, toParserRule(saved))
+}
+
+// DeleteParserRule handles DELETE /api/v1/admin/parsers/{host}.
+//
+// @Summary   Delete a host's parser rule
+// @Tags      admin
+// @Param     host path string true "Host name"
+// @Success   204
+// @Failure   404 {object} ErrorResponse
+// @Failure   500 {object} ErrorResponse
+// @Router    /admin/parsers/{host} [delete]
+func (s *Server) DeleteParserRule(w http.ResponseWriter, r *http.Request) {
+	host := strings.ToLower(strings.TrimSpace(r.PathValue("host")))
+	ctx := r.Context()
+	n, err := s.Catalog.DeleteParserRule(ctx, host)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "delete parser rule failed", slog.String("host", host), slog.Any("error", err))
+		writeError(w, http.StatusInternalServerError, "failed to delete parser rule")
+		return
+	}
+	if n == 0 {
+		writeError(w, http.StatusNotFound, "parser rule not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// validatePutSource normalises req in place and returns a client-facing
+// message for the first invalid field, or "".
+func validatePutSource(req *PutSourceRequest) string {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" || len(req.Name) > maxAdminNameLen {
+		return "name is required (max 128 characters)"
+	}
+	req.Type = strings.ToUpper(strings.TrimSpace(req.Type))
+	if !slices.Contains(sourceTypes, req.Type) {
+		return "invalid type: expected PARTY, GOVERNMENT or MEDIA"
+	}
+	req.BaseURL = strings.TrimSpace(req.BaseURL)
+	u, err := url.Parse(req.BaseURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "base_url must be an absolute http(s) URL"
+	}
+	return ""
+}
+
+// validHost accepts a bare host name: no scheme, port, path or spaces.
+func validHost(host string) bool {
+	if host == "" || len(host) > maxParserHostLen || strings.ContainsAny(host, "/:?# ") {
+		return false
+	}
+	u, err := url.Parse("https://" + host)
+	return err == nil && u.Hostname() == host
+}
+
+func toScoutConfig(row repo.ScoutConfig) ScoutConfig {
+	return ScoutConfig{
+		Name:      row.Name,
+		Kind:      row.Kind,
+		Config:    row.Config,
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}
+}
+
+func toParserRule(row repo.ParserRule) ParserRule {
+	return ParserRule{
+		Host:      row.Host,
+		Config:    row.Config,
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}
+}
@@ -2,14 +2,24 @@ package api
 
 import (
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/ChiaYuChang/prism/internal/repo"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 )
 
+const (
+	defaultContentLimit = 50
+	maxContentLimit     = 200
+)
+
 // Content is the JSON shape returned by /contents endpoints.
 type Content struct {
 	ID          uuid.UUID `json:"id"`
@@ -61,18 +71,145 @@ func (s *Server) GetContent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	writeJSON(w, http.StatusOK, Content{
-		ID:          content.ID,
-		BatchID:     content.BatchID,
-		Type:        content.Type,
-		SourceAbbr:  content.SourceAbbr,
-		CandidateID: content.CandidateID,
-		URL:         content.URL,
-		Title:       content.Title,
-		Content:     content.Content,
-		Author:      content.Author,
-		PublishedAt: content.PublishedAt,
-		FetchedAt:   content.FetchedAt,
-		TraceID:     content.TraceID,
-	})
+	writeJSON(w, http.StatusOK, toContent(content))
+}
+
+type ListContentsResponse struct {
+	Items []Content `json:"items"`
+	Count int       `json:"count"`
+	// NextCursor is set when more rows may follow; pass it back as ?cursor=.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// ListContents handles GET /api/v1/contents.
+//
+// Rows are ordered newest first by published_at. Pages are keyset based, so
+// contents fetched while a client pages through the list never shift or
+// duplicate rows.
+//
+// @Summary   List and search fetched contents
+// @Tags      contents
+// @Produce   json
+// @Param     q           query string false "Full-text query over title/content; CJK text is matched by bigrams"
+// @Param     source_abbr query string false "Filter by source abbreviation (e.g. dpp, tpp, yahoo)"
+// @Param     type        query string false "Filter by content type" Enums(PARTY_RELEASE, GOVERNMENT_RELEASE, ARTICLE, SOCIAL)
+// @Param     batch_id    query string false "Filter by batch UUID"
+// @Param     since       query string false "Lower bound on published_at (RFC3339)"
+// @Param     until       query string false "Upper bound on published_at (RFC3339)"
+// @Param     limit       query int    false "Page size (default 50, max 200)"
+// @Param     cursor      query string false "next_cursor from the previous page"
+// @Success   200 {object} ListContentsResponse
+// @Failure   400 {object} ErrorResponse
+// @Failure   500 {object} ErrorResponse
+// @Router    /contents [get]
+func (s *Server) ListContents(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	params, err := parseContentFilters(q)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	params.Limit = defaultContentLimit
+	if v := strings.TrimSpace(q.Get("limit")); v != "" {
+		n, err := strconv.ParseInt(v, 10, 32)
+		if err != nil || n <= 0 {
+			writeError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+		params.Limit = int32(min(n, maxContentLimit))
+	}
+
+	limit := params.Limit
+	params.Limit++
+	rows, err := s.Pipeline.ListContents(r.Context(), params)
+	if err != nil {
+		s.Logger.ErrorContext(r.Context(), "list contents failed", slog.Any("error", err))
+		writeError(w, http.StatusInternalServerError, "failed to list contents")
+		return
+	}
+
+	rows, more := trimPage(rows, limit)
+	resp := ListContentsResponse{Items: make([]Content, 0, len(rows)), Count: len(rows)}
+	for _, c := range rows {
+		resp.Items = append(resp.Items, toContent(c))
+	}
+	if more {
+		last := rows[len(rows)-1]
+		resp.NextCursor = encodePageCursor(contentsCursor{PublishedAt: last.PublishedAt, ID: last.ID})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// parseContentFilters reads the filters shared by ListContents and
+// ExportContents. The returned error message is safe to send to the client.
+func parseContentFilters(q url.Values) (repo.ListContentsParams, error) {
+	var params repo.ListContentsParams
+	if v := strings.TrimSpace(q.Get("q")); v != "" {
+		params.Query = &v
+	}
+	if v := strings.TrimSpace(q.Get("source_abbr")); v != "" {
+		params.SourceAbbr = &v
+	}
+	if v := strings.TrimSpace(q.Get("type")); v != "" {
+		v = strings.ToUpper(v)
+		switch v {
+		case repo.ContentTypePartyRelease, repo.ContentTypeGovernmentRelease, repo.ContentTypeArticle, repo.ContentTypeSocial:
+		default:
+			return params, fmt.Errorf("invalid type: expected %s, %s, %s or %s",
+				repo.ContentTypePartyRelease, repo.ContentTypeGovernmentRelease, repo.ContentTypeArticle, repo.ContentTypeSocial)
+		}
+		params.Type = &v
+	}
+	if v := strings.TrimSpace(q.Get("batch_id")); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return params, errors.New("invalid batch_id")
+		}
+		params.BatchID = &id
+	}
+	if v := strings.TrimSpace(q.Get("since")); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return params, errors.New("invalid since: expected RFC3339")
+		}
+		params.Since = &t
+	}
+	if v := strings.TrimSpace(q.Get("until")); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return params, errors.New("invalid until: expected RFC3339")
+		}
+		params.Until = &t
+	}
+	if v := strings.TrimSpace(q.Get("cursor")); v != "" {
+		pos, err := decodePageCursor[contentsCursor](v)
+		if err != nil || pos.ID == uuid.Nil || pos.PublishedAt.IsZero() {
+			return params, errInvalidCursor
+		}
+		params.AfterPublishedAt, params.AfterID = &pos.PublishedAt, &pos.ID
+	}
+	return params, nil
+}
+
+// contentsCursor is the keyset position behind /contents cursors.
+type contentsCursor struct {
+	PublishedAt time.Time `json:"p"`
+	ID          uuid.UUID `json:"i"`
+}
+
+func toContent(c repo.Content) Content {
+	return Content{
+		ID:          c.ID,
+		BatchID:     c.BatchID,
+		Type:        c.Type,
+		SourceAbbr:  c.SourceAbbr,
+		CandidateID: c.CandidateID,
+		URL:         c.URL,
+		Title:       c.Title,
+		Content:     c.Content,
+		Author:      c.Author,
+		PublishedAt: c.PublishedAt,
+		FetchedAt:   c.FetchedAt,
+		TraceID:     c.TraceID,
+	}
 }
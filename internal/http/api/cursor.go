@@ -0,0 +1,79 @@
+package api
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// errInvalidCursor is returned for any cursor the server did not issue.
+var errInvalidCursor = errors.New("invalid cursor")
+
+// encodePageCursor turns the keyset position of the last row on a page into
+// the opaque next_cursor of a list endpoint: base64url over a small JSON
+// object. Each endpoint defines its own position struct, so clients must
+// treat the value as opaque and only pass it back to the same endpoint.
+func encodePageCursor(pos any) string {
+	b, err := json.Marshal(pos)
+	if err != nil {
+		// Position structs hold only times and ids.
+		panic(fmt.Sprintf("marshal page cursor: %v", err))
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodePageCursor reverses encodePageCursor into T.
+func decodePageCursor[T any](v string) (T, error) {
+	var pos T
+	b, err := base64.RawURLEncoding.DecodeString(v)
+	if err != nil {
+		return pos, errInvalidCursor
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&pos); err != nil {
+		return pos, errInvalidCursor
+	}
+	return pos, nil
+}
+
+// trimPage is the read side of limit+1 paging: list handlers ask the
+// repository for one row more than the page size, and the extra row only
+// signals that a next page exists.
+func trimPage[T any](rows []T, limit int32) ([]T, bool) {
+	if len(rows) > int(limit) {
+		return rows[:limit], true
+	}
+	return rows, false
+}
+
+// keysetCursor encodes a (timestamp, id) keyset position as
+// "<unix micros>_<id>", the SSE event id of the candidate stream. It is
+// kept readable because clients echo it in Last-Event-ID. Postgres
+// timestamps carry microseconds, so the cursor round-trips exactly.
+func keysetCursor(at time.Time, id uuid.UUID) string {
+	return strconv.FormatInt(at.UnixMicro(), 10) + "_" + id.String()
+}
+
+func parseKeysetCursor(v string) (time.Time, uuid.UUID, error) {
+	micros, rawID, ok := strings.Cut(v, "_")
+	if !ok {
+		return time.Time{}, uuid.Nil, fmt.Errorf("missing separator")
+	}
+	us, err := strconv.ParseInt(micros, 10, 64)
+	if err != nil {
+		return time.Time{}, uuid.Nil, fmt.Errorf("parse timestamp: %w", err)
+	}
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		return time.Time{}, uuid.Nil, fmt.Errorf("parse id: %w", err)
+	}
+	return time.UnixMicro(us).UTC(), id, nil
+}
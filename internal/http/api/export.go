@@ -0,0 +1,250 @@
+package api
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// exportPageSize is how many rows ExportContents reads per query. Each page
+// is flushed before the next is read, so memory stays flat regardless of
+// how many rows the filters select.
+const exportPageSize = 500
+
+const (
+	exportFormatNDJSON  = "ndjson"
+	exportFormatCSV     = "csv"
+	exportFormatParquet = "parquet"
+)
+
+// contentCSVHeader is the column order of the CSV export; the Parquet
+// export uses the same column names.
+var contentCSVHeader = []string{
+	"id", "batch_id", "type", "source_abbr", "candidate_id", "url", "title",
+	"content", "author", "published_at", "fetched_at", "trace_id",
+}
+
+// contentEncoder writes one exported row at a time.
+type contentEncoder interface {
+	Encode(c Content) error
+	// Flush pushes buffered rows to the underlying writer.
+	Flush() error
+	// Close ends the stream after the last page.
+	Close() error
+}
+
+type ndjsonEncoder struct{ enc *json.Encoder }
+
+func (e ndjsonEncoder) Encode(c Content) error { return e.enc.Encode(c) }
+func (e ndjsonEncoder) Flush() error           { return nil }
+func (e ndjsonEncoder) Close() error           { return nil }
+
+type csvEncoder struct{ w *csv.Writer }
+
+func (e csvEncoder) Encode(c Content) error {
+	author := ""
+	if c.Author != nil {
+		author = *c.Author
+	}
+	return e.w.Write([]string{
+		c.ID.String(), c.BatchID.String(), c.Type, c.SourceAbbr,
+		c.CandidateID.String(), c.URL, c.Title, c.Content, author,
+		c.PublishedAt.Format(time.RFC3339), c.FetchedAt.Format(time.RFC3339),
+		c.TraceID,
+	})
+}
+
+func (e csvEncoder) Flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e csvEncoder) Close() error { return e.Flush() }
+
+// contentParquetRow is one row of the Parquet export, with the
+// contentCSVHeader columns. IDs stay strings, as in the CSV, so notebooks
+// read both exports the same way.
+type contentParquetRow struct {
+	ID          string    `parquet:"id"`
+	BatchID     string    `parquet:"batch_id"`
+	Type        string    `parquet:"type"`
+	SourceAbbr  string    `parquet:"source_abbr"`
+	CandidateID string    `parquet:"candidate_id"`
+	URL         string    `parquet:"url"`
+	Title       string    `parquet:"title"`
+	Content     string    `parquet:"content"`
+	Author      *string   `parquet:"author,optional"`
+	PublishedAt time.Time `parquet:"published_at,timestamp(millisecond)"`
+	FetchedAt   time.Time `parquet:"fetched_at,timestamp(millisecond)"`
+	TraceID     string    `parquet:"trace_id"`
+}
+
+// parquetEncoder buffers one page of rows and writes it as a row group on
+// Flush, so memory stays bounded by exportPageSize. Close writes the
+// footer; without it the file is unreadable, which is what an aborted
+// export should be.
+type parquetEncoder struct {
+	w    *parquet.GenericWriter[contentParquetRow]
+	rows []contentParquetRow
+}
+
+func newParquetEncoder(w io.Writer) *parquetEncoder {
+	return &parquetEncoder{
+		w:    parquet.NewGenericWriter[contentParquetRow](w, parquet.Compression(&parquet.Zstd)),
+		rows: make([]contentParquetRow, 0, exportPageSize),
+	}
+}
+
+func (e *parquetEncoder) Encode(c Content) error {
+	e.rows = append(e.rows, contentParquetRow{
+		ID: c.ID.String(), BatchID: c.BatchID.String(), Type: c.Type, SourceAbbr: c.SourceAbbr,
+		CandidateID: c.CandidateID.String(), URL: c.URL, Title: c.Title, Content: c.Content,
+		Author: c.Author, PublishedAt: c.PublishedAt, FetchedAt: c.FetchedAt, TraceID: c.TraceID,
+	})
+	return nil
+}
+
+func (e *parquetEncoder) Flush() error {
+	if len(e.rows) == 0 {
+		return nil
+	}
+	if _, err := e.w.Write(e.rows); err != nil {
+		return err
+	}
+	e.rows = e.rows[:0]
+	return e.w.Flush()
+}
+
+func (e *parquetEncoder) Close() error {
+	if err := e.Flush(); err != nil {
+		return err
+	}
+	return e.w.Close()
+}
+
+// ExportContents handles GET /api/v1/contents/export.
+//
+// Streams every content matching the ListContents filters, newest first, as
+// NDJSON (one Content object per line), CSV with a header row, or Parquet
+// with the CSV columns and one row group per page. The export is not
+// paginated; pass cursor to resume after the last row received. A failure
+// after the first byte aborts the connection, so a truncated download is
+// never mistaken for a complete one.
+//
+// @Summary   Export fetched contents as NDJSON, CSV or Parquet
+// @Tags      contents
+// @Produce   application/x-ndjson
+// @Produce   text/csv
+// @Produce   application/vnd.apache.parquet
+// @Param     format      query string false "Output format (default ndjson)" Enums(ndjson, csv, parquet)
+// @Param     q           query string false "Full-text query over title/content; CJK text is matched by bigrams"
+// @Param     source_abbr query string false "Filter by source abbreviation (e.g. dpp, tpp, yahoo)"
+// @Param     type        query string false "Filter by content type" Enums(PARTY_RELEASE, GOVERNMENT_RELEASE, ARTICLE, SOCIAL)
+// @Param     batch_id    query string false "Filter by batch UUID"
+// @Param     since       query string false "Lower bound on published_at (RFC3339)"
+// @Param     until       query string false "Upper bound on published_at (RFC3339)"
+// @Param     cursor      query string false "Resume after this row (same encoding as next_cursor)"
+// @Success   200 {string} string
+// @Failure   400 {object} ErrorResponse
+// @Failure   500 {object} ErrorResponse
+// @Router    /contents/export [get]
+func (s *Server) ExportContents(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	format := strings.ToLower(strings.TrimSpace(q.Get("format")))
+	if format == "" {
+		format = exportFormatNDJSON
+	}
+	switch format {
+	case exportFormatNDJSON, exportFormatCSV, exportFormatParquet:
+	default:
+		writeError(w, http.StatusBadRequest, "invalid format: expected ndjson, csv or parquet")
+		return
+	}
+
+	params, err := parseContentFilters(q)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	params.Limit = exportPageSize
+
+	ctx := r.Context()
+	// Read the first page before committing to a 200 so an early database
+	// failure still gets a proper error response.
+	rows, err := s.Pipeline.ListContents(ctx, params)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "export contents failed", slog.Any("error", err))
+		writeError(w, http.StatusInternalServerError, "failed to export contents")
+		return
+	}
+
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		s.Logger.WarnContext(ctx, "clear export write deadline failed", slog.Any("error", err))
+	}
+
+	var enc contentEncoder
+	switch format {
+	case exportFormatCSV:
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="contents.csv"`)
+		cw := csv.NewWriter(w)
+		enc = csvEncoder{w: cw}
+		_ = cw.Write(contentCSVHeader)
+	case exportFormatParquet:
+		w.Header().Set("Content-Type", "application/vnd.apache.parquet")
+		w.Header().Set("Content-Disposition", `attachment; filename="contents.parquet"`)
+		enc = newParquetEncoder(w)
+	default:
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.Header().Set("Content-Disposition", `attachment; filename="contents.ndjson"`)
+		enc = ndjsonEncoder{enc: json.NewEncoder(w)}
+	}
+	w.WriteHeader(http.StatusOK)
+
+	total := 0
+	for {
+		for _, c := range rows {
+			if err := enc.Encode(toContent(c)); err != nil {
+				s.abortExport(r, total, err)
+			}
+			total++
+		}
+		if err := enc.Flush(); err != nil {
+			s.abortExport(r, total, err)
+		}
+		if err := rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			s.abortExport(r, total, err)
+		}
+		if len(rows) < int(params.Limit) {
+			if err := enc.Close(); err != nil {
+				s.abortExport(r, total, err)
+			}
+			return
+		}
+
+		last := rows[len(rows)-1]
+		params.AfterPublishedAt, params.AfterID = &last.PublishedAt, &last.ID
+		if rows, err = s.Pipeline.ListContents(ctx, params); err != nil {
+			s.abortExport(r, total, err)
+		}
+	}
+}
+
+// abortExport logs a mid-stream export failure and aborts the response.
+// The status line is already sent, so dropping the connection is the only
+// way to tell the client the body is incomplete.
+func (s *Server) abortExport(r *http.Request, written int, err error) {
+	if !errors.Is(err, io.ErrClosedPipe) && r.Context().Err() == nil {
+		s.Logger.ErrorContext(r.Context(), "export contents aborted",
+			slog.Int("rows_written", written), slog.Any("error", err))
+	}
+	panic(http.ErrAbortHandler)
+}
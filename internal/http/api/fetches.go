@@ -1,10 +1,13 @@
 package api
 
 import (
+	"context"
 	"errors"
 	"log/slog"
 	"net/http"
 
+	"github.com/ChiaYuChang/prism/internal/http/middleware"
+	"github.com/ChiaYuChang/prism/internal/repo"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 )
@@ -34,7 +37,8 @@ type FetchProgressStatus struct {
 // GetFetch handles GET /api/v1/fetches/{id}.
 //
 // Returns aggregated progress for one user fetch. The caller never sees
-// per-item task_ids or membership of other fetches.
+// per-item task_ids or membership of other fetches. With API keys enabled
+// only the submitting user (or an admin) can read a fetch; others get 404.
 //
 // @Summary   Get progress for a user fetch
 // @Tags      fetches
@@ -55,6 +59,12 @@ func (s *Server) GetFetch(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
+	// Ownership is checked before the cache so a cached body never leaks to
+	// another user; the primary-key read is cheap next to the aggregation.
+	if !s.authorizeFetch(w, r, fetchID) {
+		return
+	}
+
 	if cached, ok, err := s.Cache.Get(ctx, fetchID); err != nil {
 		s.Logger.WarnContext(ctx, "progress cache get failed",
 			slog.String("fetch_id", fetchID.String()), slog.Any("error", err))
@@ -63,26 +73,61 @@ func (s *Server) GetFetch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if _, err := s.UserFetches.Get(ctx, fetchID); err != nil {
+	resp, err := s.fetchProgress(ctx, fetchID)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "get user fetch progress failed",
+			slog.String("fetch_id", fetchID.String()), slog.Any("error", err))
+		writeError(w, http.StatusInternalServerError, "failed to compute fetch progress")
+		return
+	}
+	if err := s.Cache.Set(ctx, fetchID, resp); err != nil {
+		s.Logger.WarnContext(ctx, "progress cache set failed",
+			slog.String("fetch_id", fetchID.String()), slog.Any("error", err))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// authorizeFetch loads fetchID and checks the caller may read it. On
+// failure it writes the response and returns false. Another user's fetch is
+// reported as not found so fetch ids cannot be probed.
+func (s *Server) authorizeFetch(w http.ResponseWriter, r *http.Request, fetchID uuid.UUID) bool {
+	ctx := r.Context()
+	fetch, err := s.UserFetches.Get(ctx, fetchID)
+	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			writeError(w, http.StatusNotFound, "fetch not found")
-			return
+			return false
 		}
 		s.Logger.ErrorContext(ctx, "get user fetch failed",
 			slog.String("fetch_id", fetchID.String()), slog.Any("error", err))
 		writeError(w, http.StatusInternalServerError, "failed to load fetch")
-		return
+		return false
+	}
+	if !canReadFetch(r, fetch) {
+		writeError(w, http.StatusNotFound, "fetch not found")
+		return false
 	}
+	return true
+}
 
+// canReadFetch allows admins and the submitting user. Without a principal
+// (API keys disabled) every caller shares one namespace, as before.
+func canReadFetch(r *http.Request, fetch repo.UserFetch) bool {
+	p, ok := middleware.PrincipalFromContext(r.Context())
+	if !ok || p.IsAdmin() {
+		return true
+	}
+	return p.UserID != uuid.Nil && fetch.UserID != nil && *fetch.UserID == p.UserID
+}
+
+// fetchProgress reads the progress of fetchID from the repository,
+// bypassing the cache.
+func (s *Server) fetchProgress(ctx context.Context, fetchID uuid.UUID) (FetchProgressResponse, error) {
 	progress, err := s.UserFetches.GetProgress(ctx, fetchID)
 	if err != nil {
-		s.Logger.ErrorContext(ctx, "get user fetch progress failed",
-			slog.String("fetch_id", fetchID.String()), slog.Any("error", err))
-		writeError(w, http.StatusInternalServerError, "failed to compute fetch progress")
-		return
+		return FetchProgressResponse{}, err
 	}
-
-	resp := FetchProgressResponse{
+	return FetchProgressResponse{
 		FetchID:         fetchID,
 		Total:           progress.Total,
 		Pending:         fetchProgressStatus(progress.PendingCandidateIDs),
@@ -91,12 +136,7 @@ func (s *Server) GetFetch(w http.ResponseWriter, r *http.Request) {
 		Failed:          fetchProgressStatus(progress.FailedCandidateIDs),
 		AlreadyComplete: fetchProgressStatus(progress.AlreadyCompleteCandidateIDs),
 		Terminal:        progress.Terminal,
-	}
-	if err := s.Cache.Set(ctx, fetchID, resp); err != nil {
-		s.Logger.WarnContext(ctx, "progress cache set failed",
-			slog.String("fetch_id", fetchID.String()), slog.Any("error", err))
-	}
-	writeJSON(w, http.StatusOK, resp)
+	}, nil
 }
 
 func fetchProgressStatus(candidateIDs []uuid.UUID) FetchProgressStatus {
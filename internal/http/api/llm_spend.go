@@ -0,0 +1,167 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/repo"
+)
+
+const (
+	defaultSpendWindow = 30 * 24 * time.Hour
+	maxSpendWindow     = 366 * 24 * time.Hour
+	spendDayLayout     = "2006-01-02"
+)
+
+// LLMSpendResponse is returned by GET /api/v1/llm/spend.
+//
+// Days holds one row per (UTC day, component, provider, model); Components
+// rolls the same rows up per component over the whole window.
+type LLMSpendResponse struct {
+	Since        time.Time           `json:"since"`
+	Until        time.Time           `json:"until"`
+	TotalCostUSD float64             `json:"total_cost_usd"`
+	Components   []LLMComponentSpend `json:"components"`
+	Days         []LLMDailySpend     `json:"days"`
+}
+
+// LLMComponentSpend is the spend of one calling component over the window.
+type LLMComponentSpend struct {
+	Component   string  `json:"component"`
+	Calls       int64   `json:"calls"`
+	TotalTokens int64   `json:"total_tokens"`
+	CostUSD     float64 `json:"cost_usd"`
+}
+
+// LLMDailySpend is one UTC day of spend for a component, provider and model.
+type LLMDailySpend struct {
+	Day          string  `json:"day"`
+	Component    string  `json:"component"`
+	Provider     string  `json:"provider"`
+	Model        string  `json:"model"`
+	Calls        int64   `json:"calls"`
+	InputTokens  int64   `json:"input_tokens"`
+	OutputTokens int64   `json:"output_tokens"`
+	CachedTokens int64   `json:"cached_tokens"`
+	TotalTokens  int64   `json:"total_tokens"`
+	CostUSD      float64 `json:"cost_usd"`
+}
+
+// GetLLMSpend handles GET /api/v1/llm/spend.
+//
+// since/until accept RFC3339 or a YYYY-MM-DD day (UTC). The window is
+// half-open, defaults to the last 30 days and is capped at 366 days.
+//
+// @Summary   Summarise LLM spend by day and component
+// @Tags      llm
+// @Produce   json
+// @Param     since     query string false "Window start (RFC3339 or YYYY-MM-DD, default until-30d)"
+// @Param     until     query string false "Window end, exclusive (RFC3339 or YYYY-MM-DD, default now)"
+// @Param     component query string false "Filter by calling component (e.g. planner, collector)"
+// @Success   200 {object} LLMSpendResponse
+// @Failure   400 {object} ErrorResponse
+// @Failure   500 {object} ErrorResponse
+// @Router    /llm/spend [get]
+func (s *Server) GetLLMSpend(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	since, until, msg := parseSpendWindow(q)
+	if msg != "" {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	params := repo.SummarizeLLMSpendParams{Since: since, Until: until}
+	if v := strings.TrimSpace(q.Get("component")); v != "" {
+		params.Component = &v
+	}
+
+	rows, err := s.LLMSpend.SummarizeSpend(r.Context(), params)
+	if err != nil {
+		s.Logger.ErrorContext(r.Context(), "summarize llm spend failed", slog.Any("error", err))
+		writeError(w, http.StatusInternalServerError, "failed to summarize llm spend")
+		return
+	}
+
+	resp := LLMSpendResponse{
+		Since:      since,
+		Until:      until,
+		Components: []LLMComponentSpend{},
+		Days:       make([]LLMDailySpend, 0, len(rows)),
+	}
+	var totalMicros int64
+	byComponent := make(map[string]int)
+	componentMicros := make(map[string]int64)
+	for _, row := range rows {
+		totalMicros += row.CostMicros
+		resp.Days = append(resp.Days, LLMDailySpend{
+			Day:          row.Day.UTC().Format(spendDayLayout),
+			Component:    row.Component,
+			Provider:     row.Provider,
+			Model:        row.Model,
+			Calls:        row.Calls,
+			InputTokens:  row.InputTokens,
+			OutputTokens: row.OutputTokens,
+			CachedTokens: row.CachedTokens,
+			TotalTokens:  row.TotalTokens,
+			CostUSD:      microsToUSD(row.CostMicros),
+		})
+
+		i, ok := byComponent[row.Component]
+		if !ok {
+			i = len(resp.Components)
+			byComponent[row.Component] = i
+			resp.Components = append(resp.Components, LLMComponentSpend{Component: row.Component})
+		}
+		resp.Components[i].Calls += row.Calls
+		resp.Components[i].TotalTokens += row.TotalTokens
+		componentMicros[row.Component] += row.CostMicros
+	}
+	// Sum in micros and convert once so rounding does not accumulate.
+	for i := range resp.Components {
+		resp.Components[i].CostUSD = microsToUSD(componentMicros[resp.Components[i].Component])
+	}
+	resp.TotalCostUSD = microsToUSD(totalMicros)
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// parseSpendWindow reads the half-open since/until window of the summary
+// routes. It returns a client error message when the window is invalid.
+func parseSpendWindow(q url.Values) (since, until time.Time, msg string) {
+	until = time.Now().UTC()
+	if v := strings.TrimSpace(q.Get("until")); v != "" {
+		t, err := parseSpendTime(v)
+		if err != nil {
+			return time.Time{}, time.Time{}, "invalid until: expected RFC3339 or YYYY-MM-DD"
+		}
+		until = t
+	}
+	since = until.Add(-defaultSpendWindow)
+	if v := strings.TrimSpace(q.Get("since")); v != "" {
+		t, err := parseSpendTime(v)
+		if err != nil {
+			return time.Time{}, time.Time{}, "invalid since: expected RFC3339 or YYYY-MM-DD"
+		}
+		since = t
+	}
+	if !since.Before(until) {
+		return time.Time{}, time.Time{}, "since must be before until"
+	}
+	if until.Sub(since) > maxSpendWindow {
+		return time.Time{}, time.Time{}, "window too large: at most 366 days"
+	}
+	return since, until, ""
+}
+
+func parseSpendTime(v string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t.UTC(), nil
+	}
+	return time.Parse(spendDayLayout, v)
+}
+
+func microsToUSD(micros int64) float64 {
+	return float64(micros) / 1e6
+}
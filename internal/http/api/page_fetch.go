@@ -6,13 +6,23 @@ import (
 	"errors"
 	"log/slog"
 	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
 
+	"github.com/ChiaYuChang/prism/internal/http/middleware"
+	"github.com/ChiaYuChang/prism/internal/notify"
 	"github.com/ChiaYuChang/prism/internal/repo"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 )
 
-const maxPageFetchBatch = 100
+const (
+	maxPageFetchBatch = 100
+
+	minNotifySecretLen = 16
+	maxNotifySecretLen = 256
+)
 
 // Per-item status values returned by POST /page_fetch. Three values only:
 // `created` collapses fresh-insert and shared-active-task to avoid leaking
@@ -24,7 +34,17 @@ const (
 )
 
 type PageFetchRequest struct {
-	CandidateIDs []uuid.UUID `json:"candidate_ids"`
+	CandidateIDs []uuid.UUID      `json:"candidate_ids"`
+	Notify       *PageFetchNotify `json:"notify,omitempty"`
+}
+
+// PageFetchNotify asks for a fetch.completed webhook once every candidate
+// of the fetch reaches a terminal status. The POST body carries the same
+// candidate groups as GET /fetches/{id} and is signed with Secret; see
+// internal/notify for the header and signature format.
+type PageFetchNotify struct {
+	URL    string `json:"url"`
+	Secret string `json:"secret"`
 }
 
 // PageFetchItem is the per-candidate response entry.
@@ -49,6 +69,12 @@ type PageFetchResponse struct {
 // existing active task with the same URL, or records an
 // `already_complete` snapshot when contents are already present.
 //
+// The fetch is owned by the calling user, who alone (besides admins) can read
+// its progress.
+//
+// With a notify block the fetch is also registered for a signed
+// fetch.completed webhook, delivered by cmd/fetch/notifier.
+//
 // @Summary   Request full-article fetch for candidates
 // @Tags      candidates
 // @Accept    json
@@ -72,6 +98,20 @@ func (s *Server) PageFetch(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "too many candidate_ids (max 100)")
 		return
 	}
+	params := repo.CreateUserFetchParams{}
+	// The fetch belongs to the calling user; static tokens and disabled
+	// auth leave it unowned.
+	if p, ok := middleware.PrincipalFromContext(r.Context()); ok && p.UserID != uuid.Nil {
+		params.UserID = &p.UserID
+	}
+	if req.Notify != nil {
+		if msg := validatePageFetchNotify(*req.Notify); msg != "" {
+			writeError(w, http.StatusBadRequest, msg)
+			return
+		}
+		params.NotifyURL = &req.Notify.URL
+		params.NotifySecret = &req.Notify.Secret
+	}
 
 	ctx := r.Context()
 	candidates, err := s.Scout.GetCandidatesByIDs(ctx, req.CandidateIDs)
@@ -86,7 +126,7 @@ func (s *Server) PageFetch(w http.ResponseWriter, r *http.Request) {
 		byID[c.ID] = c
 	}
 
-	fetch, err := s.UserFetches.Create(ctx, repo.CreateUserFetchParams{UserID: nil})
+	fetch, err := s.UserFetches.Create(ctx, params)
 	if err != nil {
 		s.Logger.ErrorContext(ctx, "create user fetch failed", slog.Any("error", err))
 		writeError(w, http.StatusInternalServerError, "failed to create fetch")
@@ -116,6 +156,30 @@ func (s *Server) PageFetch(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusAccepted, PageFetchResponse{FetchID: fetch.ID, Items: items})
 }
 
+// validatePageFetchNotify returns a client-facing message for an unusable
+// notify block, or "" when it is valid.
+//
+// Literal non-public addresses and localhost are refused up front; hostnames
+// are checked again at delivery time by the notifier's dialer, which also
+// catches names that resolve to private addresses.
+func validatePageFetchNotify(n PageFetchNotify) string {
+	u, err := url.Parse(n.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "notify.url must be an absolute http(s) URL"
+	}
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return "notify.url must not target a private address"
+	}
+	if ip, err := netip.ParseAddr(host); err == nil && !notify.PublicAddr(ip) {
+		return "notify.url must not target a private address"
+	}
+	if len(n.Secret) < minNotifySecretLen || len(n.Secret) > maxNotifySecretLen {
+		return "notify.secret must be 16 to 256 characters"
+	}
+	return ""
+}
+
 // recordPageFetchItem persists one fetch_items row and returns its public
 // status.
 //
@@ -0,0 +1,197 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/http/middleware"
+	"github.com/ChiaYuChang/prism/internal/repo"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultPageFetchQueryCap = 100
+	maxPageFetchQueryCap     = 1000
+)
+
+// PageFetchQueryRequest selects candidates by the GET /candidates filters
+// instead of explicit ids. At least one filter is required so a request
+// never promotes the whole candidate table by accident.
+type PageFetchQueryRequest struct {
+	Q          *string    `json:"q,omitempty"`
+	SourceAbbr *string    `json:"source_abbr,omitempty"`
+	Since      *time.Time `json:"since,omitempty"`
+	Until      *time.Time `json:"until,omitempty"`
+	// MaxCandidates caps how many matches are promoted, newest first
+	// (default 100, max 1000).
+	MaxCandidates int32 `json:"max_candidates,omitempty"`
+	// DryRun only counts the matches; no fetch is created.
+	DryRun bool             `json:"dry_run,omitempty"`
+	Notify *PageFetchNotify `json:"notify,omitempty"`
+}
+
+// PageFetchQueryResponse is returned by POST /api/v1/page_fetch/query.
+//
+// Matched counts every candidate the filters select; Selected is how many
+// of them were (or, on a dry run, would be) promoted. FetchID and Items are
+// omitted on a dry run and when nothing matched.
+type PageFetchQueryResponse struct {
+	DryRun   bool            `json:"dry_run"`
+	Matched  int64           `json:"matched"`
+	Selected int             `json:"selected"`
+	FetchID  *uuid.UUID      `json:"fetch_id,omitempty"`
+	Items    []PageFetchItem `json:"items,omitempty"`
+}
+
+// PageFetchQuery handles POST /api/v1/page_fetch/query.
+//
+// Promotes every candidate matching the filters, up to max_candidates and
+// newest first, as a single fetch with the same per-item semantics as
+// POST /page_fetch. Matches are read and recorded in keyset pages of 100;
+// once the fetch row exists the remaining pages are recorded even if the
+// client disconnects, so the fetch is never left half-populated.
+//
+// @Summary   Request full-article fetch for candidates matching a query
+// @Tags      candidates
+// @Accept    json
+// @Produce   json
+// @Param     body body PageFetchQueryRequest true "Candidate filters, cap and dry-run flag"
+// @Success   200 {object} PageFetchQueryResponse "Dry run or no match"
+// @Success   202 {object} PageFetchQueryResponse
+// @Failure   400 {object} ErrorResponse
+// @Failure   500 {object} ErrorResponse
+// @Router    /page_fetch/query [post]
+func (s *Server) PageFetchQuery(w http.ResponseWriter, r *http.Request) {
+	var req PageFetchQueryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	filter, msg := pageFetchQueryFilter(req)
+	if msg != "" {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+	limit := req.MaxCandidates
+	switch {
+	case limit == 0:
+		limit = defaultPageFetchQueryCap
+	case limit < 0 || limit > maxPageFetchQueryCap:
+		writeError(w, http.StatusBadRequest, "max_candidates must be 1 to 1000")
+		return
+	}
+
+	params := repo.CreateUserFetchParams{}
+	if p, ok := middleware.PrincipalFromContext(r.Context()); ok && p.UserID != uuid.Nil {
+		params.UserID = &p.UserID
+	}
+	if req.Notify != nil {
+		if msg := validatePageFetchNotify(*req.Notify); msg != "" {
+			writeError(w, http.StatusBadRequest, msg)
+			return
+		}
+		params.NotifyURL = &req.Notify.URL
+		params.NotifySecret = &req.Notify.Secret
+	}
+
+	ctx := r.Context()
+	matched, err := s.Scout.CountCandidates(ctx, filter)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "count candidates failed", slog.Any("error", err))
+		writeError(w, http.StatusInternalServerError, "failed to count candidates")
+		return
+	}
+	resp := PageFetchQueryResponse{
+		DryRun:   req.DryRun,
+		Matched:  matched,
+		Selected: int(min(matched, int64(limit))),
+	}
+	if req.DryRun || matched == 0 {
+		writeJSON(w, http.StatusOK, resp)
+		return
+	}
+
+	fetch, err := s.UserFetches.Create(ctx, params)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "create user fetch failed", slog.Any("error", err))
+		writeError(w, http.StatusInternalServerError, "failed to create fetch")
+		return
+	}
+
+	items, err := s.recordPageFetchQuery(context.WithoutCancel(ctx), fetch.ID, filter, limit)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "record page fetch query failed",
+			slog.String("fetch_id", fetch.ID.String()),
+			slog.Int("recorded", len(items)),
+			slog.Any("error", err))
+		writeError(w, http.StatusInternalServerError, "failed to record fetch items")
+		return
+	}
+	resp.FetchID = &fetch.ID
+	resp.Items = items
+	resp.Selected = len(items)
+	writeJSON(w, http.StatusAccepted, resp)
+}
+
+// recordPageFetchQuery walks the matches in ListCandidates order, one
+// keyset page of maxPageFetchBatch at a time, and records up to limit of
+// them on the fetch. It returns the items recorded so far alongside any
+// error.
+func (s *Server) recordPageFetchQuery(ctx context.Context, fetchID uuid.UUID, filter repo.CountCandidatesParams, limit int32) ([]PageFetchItem, error) {
+	items := make([]PageFetchItem, 0, limit)
+	params := repo.ListCandidatesParams{
+		Query:      filter.Query,
+		SourceAbbr: filter.SourceAbbr,
+		Since:      filter.Since,
+		Until:      filter.Until,
+	}
+	for int32(len(items)) < limit {
+		params.Limit = min(maxPageFetchBatch, limit-int32(len(items)))
+		rows, err := s.Scout.ListCandidates(ctx, params)
+		if err != nil {
+			return items, err
+		}
+		for _, c := range rows {
+			status, err := s.recordPageFetchItem(ctx, fetchID, c)
+			if err != nil {
+				return items, err
+			}
+			items = append(items, PageFetchItem{CandidateID: c.ID, Status: status})
+		}
+		if len(rows) < int(params.Limit) {
+			break
+		}
+		last := rows[len(rows)-1]
+		params.AfterPublishedAt = last.PublishedAt
+		params.AfterDiscoveredAt, params.AfterID = &last.DiscoveredAt, &last.ID
+	}
+	return items, nil
+}
+
+// pageFetchQueryFilter normalises the request filters, returning a
+// client-facing message when they are unusable.
+func pageFetchQueryFilter(req PageFetchQueryRequest) (repo.CountCandidatesParams, string) {
+	var filter repo.CountCandidatesParams
+	if req.Q != nil {
+		if v := strings.TrimSpace(*req.Q); v != "" {
+			filter.Query = &v
+		}
+	}
+	if req.SourceAbbr != nil {
+		if v := strings.TrimSpace(*req.SourceAbbr); v != "" {
+			filter.SourceAbbr = &v
+		}
+	}
+	filter.Since, filter.Until = req.Since, req.Until
+	if filter.Query == nil && filter.SourceAbbr == nil && filter.Since == nil && filter.Until == nil {
+		return filter, "at least one of q, source_abbr, since or until is required"
+	}
+	if filter.Since != nil && filter.Until != nil && filter.Until.Before(*filter.Since) {
+		return filter, "until must not be before since"
+	}
+	return filter, ""
+}
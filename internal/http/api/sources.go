@@ -0,0 +1,67 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/ChiaYuChang/prism/internal/repo"
+)
+
+// sourceTypes lists the source types GET /sources walks, in response order.
+var sourceTypes = []string{repo.SourceTypeParty, repo.SourceTypeGovernment, repo.SourceTypeMedia}
+
+// Source is the public view of one active source.
+type Source struct {
+	Abbr    string `json:"abbr"`
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	BaseURL string `json:"base_url"`
+}
+
+// ListSourcesResponse is returned by GET /api/v1/sources.
+type ListSourcesResponse struct {
+	Items []Source `json:"items"`
+	Count int      `json:"count"`
+}
+
+// ListSources handles GET /api/v1/sources.
+//
+// Lists active (not soft-deleted) sources grouped by type, each group
+// ordered by abbr. The abbr is the value candidates and contents are
+// filtered by (`source_abbr`).
+//
+// @Summary   List sources
+// @Tags      sources
+// @Produce   json
+// @Param     type query string false "Only this source type" Enums(PARTY, GOVERNMENT, MEDIA)
+// @Success   200 {object} ListSourcesResponse
+// @Failure   400 {object} ErrorResponse
+// @Failure   500 {object} ErrorResponse
+// @Router    /sources [get]
+func (s *Server) ListSources(w http.ResponseWriter, r *http.Request) {
+	types := sourceTypes
+	if v := strings.ToUpper(strings.TrimSpace(r.URL.Query().Get("type"))); v != "" {
+		if !slices.Contains(sourceTypes, v) {
+			writeError(w, http.StatusBadRequest, "invalid type: expected PARTY, GOVERNMENT or MEDIA")
+			return
+		}
+		types = []string{v}
+	}
+
+	ctx := r.Context()
+	items := []Source{}
+	for _, t := range types {
+		rows, err := s.Scout.ListSourcesByType(ctx, t)
+		if err != nil {
+			s.Logger.ErrorContext(ctx, "list sources failed", slog.String("type", t), slog.Any("error", err))
+			writeError(w, http.StatusInternalServerError, "failed to list sources")
+			return
+		}
+		for _, src := range rows {
+			items = append(items, Source{Abbr: src.Abbr, Name: src.Name, Type: src.Type, BaseURL: src.BaseURL})
+		}
+	}
+	writeJSON(w, http.StatusOK, ListSourcesResponse{Items: items, Count: len(items)})
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultStreamSettle    = 2 * time.Second
+	defaultStreamPoll      = 30 * time.Second
+	defaultStreamHeartbeat = 15 * time.Second
+	// streamRetry is the reconnect delay suggested to EventSource clients.
+	streamRetry = 5 * time.Second
+	// lastEventIDHeader is sent by EventSource clients when they reconnect.
+	lastEventIDHeader = "Last-Event-ID"
+)
+
+// StreamConfig tunes the SSE endpoints. Zero values take the defaults.
+type StreamConfig struct {
+	// Settle delays a candidate scan after a wake-up and excludes rows
+	// discovered within the last Settle, so a transaction that commits
+	// slightly out of discovered_at order is not skipped by the cursor.
+	// Default 2s.
+	Settle time.Duration
+	// Poll re-reads state even without a wake-up, covering notifications
+	// lost while the change feed reconnects. Default 30s.
+	Poll time.Duration
+	// Heartbeat is the interval of keep-alive comments. Default 15s.
+	Heartbeat time.Duration
+}
+
+func (c StreamConfig) withDefaults() StreamConfig {
+	if c.Settle <= 0 {
+		c.Settle = defaultStreamSettle
+	}
+	if c.Poll <= 0 {
+		c.Poll = defaultStreamPoll
+	}
+	if c.Heartbeat <= 0 {
+		c.Heartbeat = defaultStreamHeartbeat
+	}
+	return c
+}
+
+// eventStream writes text/event-stream frames and flushes each one.
+type eventStream struct {
+	w  http.ResponseWriter
+	rc *http.ResponseController
+}
+
+// openEventStream sends the SSE response headers and lifts the server write
+// timeout for this response. It returns false when the client is gone or the
+// writer cannot flush; the handler should return without writing more.
+func (s *Server) openEventStream(w http.ResponseWriter, r *http.Request) (*eventStream, bool) {
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		s.Logger.WarnContext(r.Context(), "clear stream write deadline failed", slog.Any("error", err))
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	// Disable response buffering in nginx-style reverse proxies.
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	stream := &eventStream{w: w, rc: rc}
+	if _, err := fmt.Fprintf(w, "retry: %d\n\n", streamRetry.Milliseconds()); err != nil {
+		return nil, false
+	}
+	if err := rc.Flush(); err != nil {
+		s.Logger.ErrorContext(r.Context(), "flush event stream failed", slog.Any("error", err))
+		return nil, false
+	}
+	return stream, true
+}
+
+// send writes one event. data is JSON-encoded on a single line.
+func (e *eventStream) send(id, event string, data any) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("marshal %s event: %w", event, err)
+	}
+	if _, err := fmt.Fprintf(e.w, "id: %s\nevent: %s\ndata: %s\n\n", id, event, b); err != nil {
+		return err
+	}
+	return e.rc.Flush()
+}
+
+// ping writes a comment frame that keeps idle proxies from closing the
+// connection.
+func (e *eventStream) ping() error {
+	if _, err := fmt.Fprint(e.w, ": ping\n\n"); err != nil {
+		return err
+	}
+	return e.rc.Flush()
+}
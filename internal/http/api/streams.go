@@ -0,0 +1,250 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/repo"
+	"github.com/google/uuid"
+)
+
+const (
+	eventProgress  = "progress"
+	eventCandidate = "candidate"
+	// candidateStreamPage bounds one catch-up query; the scan loops until a
+	// short page.
+	candidateStreamPage = 200
+)
+
+// candidateIngestionMethods are the values accepted by the ingestion_method
+// filter (the candidate_ingestion_method enum).
+var candidateIngestionMethods = []string{"DIRECTORY", "SEARCH", "SUBSCRIPTION", "MANUAL"}
+
+// StreamFetchEvents handles GET /api/v1/fetches/{id}/events.
+//
+// Streams `progress` events carrying a FetchProgressResponse: the current
+// state on connect, then one event per change. The stream ends after the
+// terminal event. Event ids encode the per-status counts, so a client that
+// reconnects with Last-Event-ID only receives the state if it changed; a
+// reconnect to a terminal fetch it has already seen gets 204, which stops
+// EventSource from retrying.
+//
+// @Summary   Stream progress for a user fetch (Server-Sent Events)
+// @Tags      fetches
+// @Produce   text/event-stream
+// @Param     id            path   string true  "User fetch ID"
+// @Param     Last-Event-ID header string false "Id of the last event received"
+// @Success   200 {object} FetchProgressResponse "event: progress"
+// @Success   204 "Terminal state already delivered"
+// @Failure   400 {object} ErrorResponse
+// @Failure   404 {object} ErrorResponse
+// @Failure   500 {object} ErrorResponse
+// @Router    /fetches/{id}/events [get]
+func (s *Server) StreamFetchEvents(w http.ResponseWriter, r *http.Request) {
+	fetchID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid fetch id")
+		return
+	}
+	ctx := r.Context()
+	lastID := strings.TrimSpace(r.Header.Get(lastEventIDHeader))
+
+	if !s.authorizeFetch(w, r, fetchID) {
+		return
+	}
+
+	// Subscribe before the first read so no transition falls in between.
+	wake, unsubscribe := s.ChangeFeed.Subscribe(repo.ChangeChannelFetchProgress, fetchID.String())
+	defer unsubscribe()
+
+	resp, err := s.fetchProgress(ctx, fetchID)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "get user fetch progress failed",
+			slog.String("fetch_id", fetchID.String()), slog.Any("error", err))
+		writeError(w, http.StatusInternalServerError, "failed to compute fetch progress")
+		return
+	}
+	version := progressEventID(resp)
+	if resp.Terminal && version == lastID {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	stream, ok := s.openEventStream(w, r)
+	if !ok {
+		return
+	}
+	if version != lastID {
+		if err := stream.send(version, eventProgress, resp); err != nil {
+			return
+		}
+	}
+	if resp.Terminal {
+		return
+	}
+
+	poll := time.NewTicker(s.Stream.Poll)
+	defer poll.Stop()
+	heartbeat := time.NewTicker(s.Stream.Heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if err := stream.ping(); err != nil {
+				return
+			}
+			continue
+		case _, open := <-wake:
+			if !open {
+				return
+			}
+		case <-poll.C:
+		}
+
+		resp, err := s.fetchProgress(ctx, fetchID)
+		if err != nil {
+			// The client reconnects with Last-Event-ID and resumes.
+			s.Logger.ErrorContext(ctx, "get user fetch progress failed",
+				slog.String("fetch_id", fetchID.String()), slog.Any("error", err))
+			return
+		}
+		if next := progressEventID(resp); next != version {
+			version = next
+			if err := stream.send(version, eventProgress, resp); err != nil {
+				return
+			}
+		}
+		if resp.Terminal {
+			return
+		}
+	}
+}
+
+// progressEventID encodes the per-status counts of a progress snapshot.
+// Items only move towards terminal states, so equal ids mean no change a
+// client needs to see.
+func progressEventID(p FetchProgressResponse) string {
+	return fmt.Sprintf("%d.%d.%d.%d.%d",
+		p.Pending.Count, p.Running.Count, p.Completed.Count, p.Failed.Count, p.AlreadyComplete.Count)
+}
+
+// StreamCandidates handles GET /api/v1/candidates/stream.
+//
+// Streams a `candidate` event for every candidate inserted or re-seen
+// (upserted) after the connection opened, in discovered_at order. Event ids
+// are a (discovered_at, id) cursor: reconnecting with Last-Event-ID replays
+// everything discovered after that event. Candidates are emitted once they
+// are older than the settle delay (2s by default), which keeps the cursor
+// from skipping transactions that commit out of order.
+//
+// @Summary   Stream newly discovered candidates (Server-Sent Events)
+// @Tags      candidates
+// @Produce   text/event-stream
+// @Param     source_abbr      query  string false "Filter by source abbreviation"
+// @Param     ingestion_method query  string false "Filter by ingestion method (DIRECTORY, SEARCH, SUBSCRIPTION, MANUAL)"
+// @Param     Last-Event-ID    header string false "Id of the last event received"
+// @Success   200 {object} Candidate "event: candidate"
+// @Failure   400 {object} ErrorResponse
+// @Router    /candidates/stream [get]
+func (s *Server) StreamCandidates(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	ctx := r.Context()
+
+	params := repo.ListCandidatesDiscoveredAfterParams{Limit: candidateStreamPage}
+	var sourceAbbr string
+	if v := strings.TrimSpace(q.Get("source_abbr")); v != "" {
+		sourceAbbr = v
+		params.SourceAbbr = &v
+	}
+	if v := strings.ToUpper(strings.TrimSpace(q.Get("ingestion_method"))); v != "" {
+		if !slices.Contains(candidateIngestionMethods, v) {
+			writeError(w, http.StatusBadRequest, "invalid ingestion_method: expected one of "+strings.Join(candidateIngestionMethods, ", "))
+			return
+		}
+		params.IngestionMethod = &v
+	}
+
+	params.AfterDiscoveredAt = time.Now()
+	if v := strings.TrimSpace(r.Header.Get(lastEventIDHeader)); v != "" {
+		at, id, err := parseKeysetCursor(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid Last-Event-ID")
+			return
+		}
+		params.AfterDiscoveredAt, params.AfterID = at, id
+	}
+
+	wake, unsubscribe := s.ChangeFeed.Subscribe(repo.ChangeChannelCandidates, sourceAbbr)
+	defer unsubscribe()
+
+	stream, ok := s.openEventStream(w, r)
+	if !ok {
+		return
+	}
+
+	poll := time.NewTicker(s.Stream.Poll)
+	defer poll.Stop()
+	heartbeat := time.NewTicker(s.Stream.Heartbeat)
+	defer heartbeat.Stop()
+	// A resumed stream catches up once the settle delay has passed.
+	settle := time.After(s.Stream.Settle)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if err := stream.ping(); err != nil {
+				return
+			}
+			continue
+		case _, open := <-wake:
+			if !open {
+				return
+			}
+			if settle == nil {
+				settle = time.After(s.Stream.Settle)
+			}
+			continue
+		case <-settle:
+			settle = nil
+		case <-poll.C:
+		}
+
+		if err := s.sendCandidates(ctx, stream, &params); err != nil {
+			if ctx.Err() == nil {
+				s.Logger.ErrorContext(ctx, "stream candidates failed", slog.Any("error", err))
+			}
+			return
+		}
+	}
+}
+
+// sendCandidates emits every candidate past the cursor that is older than
+// the settle delay, advancing the cursor as it goes.
+func (s *Server) sendCandidates(ctx context.Context, stream *eventStream, params *repo.ListCandidatesDiscoveredAfterParams) error {
+	params.Until = time.Now().Add(-s.Stream.Settle)
+	for {
+		rows, err := s.Scout.ListCandidatesDiscoveredAfter(ctx, *params)
+		if err != nil {
+			return fmt.Errorf("list candidates: %w", err)
+		}
+		for _, c := range rows {
+			if err := stream.send(keysetCursor(c.DiscoveredAt, c.ID), eventCandidate, toCandidate(c)); err != nil {
+				return err
+			}
+			params.AfterDiscoveredAt, params.AfterID = c.DiscoveredAt, c.ID
+		}
+		if len(rows) < int(params.Limit) {
+			return nil
+		}
+	}
+}
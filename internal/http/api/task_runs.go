@@ -0,0 +1,298 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/repo"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultYieldLimit   = 100
+	maxYieldLimit       = 1000
+	defaultTaskRunLimit = 50
+	maxTaskRunLimit     = 500
+)
+
+// Task run yield groupings accepted by group_by.
+const (
+	YieldGroupBySource   = "source"
+	YieldGroupByTask     = "task"
+	YieldGroupByProvider = "provider"
+)
+
+// TaskRunYield aggregates discovery runs over one group. Only the fields of
+// the grouping are set: kind and source_abbr for source, plus task_id and
+// query for task, provider for provider. Provider groups only count runs,
+// failures and items seen; new and duplicate are known per run only.
+type TaskRunYield struct {
+	TaskID         *uuid.UUID `json:"task_id,omitempty"`
+	Kind           string     `json:"kind,omitempty"`
+	SourceAbbr     string     `json:"source_abbr,omitempty"`
+	Query          string     `json:"query,omitempty"`
+	Provider       string     `json:"provider,omitempty"`
+	Runs           int64      `json:"runs"`
+	FailedRuns     int64      `json:"failed_runs"`
+	ItemsSeen      int64      `json:"items_seen"`
+	ItemsNew       int64      `json:"items_new"`
+	ItemsDuplicate int64      `json:"items_duplicate"`
+	AvgDurationMs  int64      `json:"avg_duration_ms"`
+	LastRunAt      time.Time  `json:"last_run_at"`
+	LastNewAt      *time.Time `json:"last_new_at,omitempty"`
+}
+
+// TaskRunYieldResponse is returned by GET /api/v1/admin/task_runs/yield.
+type TaskRunYieldResponse struct {
+	Since   time.Time      `json:"since"`
+	Until   time.Time      `json:"until"`
+	GroupBy string         `json:"group_by"`
+	Items   []TaskRunYield `json:"items"`
+	Count   int            `json:"count"`
+}
+
+// TaskRunProvider is one search provider's share of a KEYWORD_SEARCH run.
+type TaskRunProvider struct {
+	Seen       int32  `json:"seen,omitempty"`
+	ErrorClass string `json:"error_class,omitempty"`
+}
+
+// TaskRun is one discovery execution of a task.
+type TaskRun struct {
+	ID             int64                      `json:"id"`
+	TaskID         uuid.UUID                  `json:"task_id"`
+	BatchID        uuid.UUID                  `json:"batch_id"`
+	Kind           string                     `json:"kind"`
+	SourceType     string                     `json:"source_type"`
+	SourceAbbr     string                     `json:"source_abbr"`
+	Query          string                     `json:"query,omitempty"`
+	Status         string                     `json:"status"`
+	ErrorClass     string                     `json:"error_class,omitempty"`
+	ItemsSeen      int32                      `json:"items_seen"`
+	ItemsNew       int32                      `json:"items_new"`
+	ItemsDuplicate int32                      `json:"items_duplicate"`
+	Providers      map[string]TaskRunProvider `json:"providers,omitempty"`
+	DurationMs     int64                      `json:"duration_ms"`
+	TraceID        string                     `json:"trace_id"`
+	StartedAt      time.Time                  `json:"started_at"`
+}
+
+// ListTaskRunsResponse is returned by GET /api/v1/admin/tasks/{id}/runs.
+type ListTaskRunsResponse struct {
+	Items []TaskRun `json:"items"`
+	Count int       `json:"count"`
+	// NextCursor is set when more rows may follow; pass it back as ?cursor=.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// taskRunsCursor is the keyset position behind /admin/tasks/{id}/runs
+// cursors.
+type taskRunsCursor struct {
+	StartedAt time.Time `json:"s"`
+	ID        int64     `json:"i"`
+}
+
+// GetTaskRunYield handles GET /api/v1/admin/task_runs/yield.
+//
+// The window works like GET /llm/spend. group_by=task lists the least
+// productive tasks first, which is where keyword tasks worth pruning show up.
+//
+// @Summary   Summarise discovery yield by source, task or search provider
+// @Tags      admin
+// @Produce   json
+// @Param     group_by    query string false "source (default), task or provider"
+// @Param     since       query string false "Window start (RFC3339 or YYYY-MM-DD, default until-30d)"
+// @Param     until       query string false "Window end, exclusive (RFC3339 or YYYY-MM-DD, default now)"
+// @Param     kind        query string false "DIRECTORY_FETCH or KEYWORD_SEARCH; ignored for provider"
+// @Param     source_abbr query string false "Filter by source abbreviation"
+// @Param     limit       query int    false "Max groups (default 100, max 1000)"
+// @Success   200 {object} TaskRunYieldResponse
+// @Failure   400 {object} ErrorResponse
+// @Failure   500 {object} ErrorResponse
+// @Router    /admin/task_runs/yield [get]
+func (s *Server) GetTaskRunYield(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	since, until, msg := parseSpendWindow(q)
+	if msg != "" {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	groupBy := strings.ToLower(strings.TrimSpace(q.Get("group_by")))
+	if groupBy == "" {
+		groupBy = YieldGroupBySource
+	}
+	if groupBy != YieldGroupBySource && groupBy != YieldGroupByTask && groupBy != YieldGroupByProvider {
+		writeError(w, http.StatusBadRequest, "invalid group_by: expected source, task or provider")
+		return
+	}
+
+	params := repo.SummarizeTaskRunsParams{Since: since, Until: until, Limit: defaultYieldLimit}
+	if v := strings.ToUpper(strings.TrimSpace(q.Get("kind"))); v != "" {
+		if v != repo.TaskKindDirectoryFetch && v != repo.TaskKindKeywordSearch {
+			writeError(w, http.StatusBadRequest, "invalid kind: expected DIRECTORY_FETCH or KEYWORD_SEARCH")
+			return
+		}
+		params.Kind = &v
+	}
+	if v := strings.ToLower(strings.TrimSpace(q.Get("source_abbr"))); v != "" {
+		params.SourceAbbr = &v
+	}
+	limit, ok := parseRouteLimit(w, q.Get("limit"), defaultYieldLimit, maxYieldLimit)
+	if !ok {
+		return
+	}
+	params.Limit = limit
+
+	ctx := r.Context()
+	var (
+		rows []repo.TaskRunYield
+		err  error
+	)
+	switch groupBy {
+	case YieldGroupBySource:
+		rows, err = s.TaskRuns.SummarizeBySource(ctx, params)
+	case YieldGroupByTask:
+		rows, err = s.TaskRuns.SummarizeByTask(ctx, params)
+	case YieldGroupByProvider:
+		rows, err = s.TaskRuns.SummarizeByProvider(ctx, params)
+	}
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "summarize task runs failed",
+			slog.String("group_by", groupBy), slog.Any("error", err))
+		writeError(w, http.StatusInternalServerError, "failed to summarize task runs")
+		return
+	}
+
+	resp := TaskRunYieldResponse{
+		Since:   since,
+		Until:   until,
+		GroupBy: groupBy,
+		Items:   make([]TaskRunYield, len(rows)),
+		Count:   len(rows),
+	}
+	for i, row := range rows {
+		resp.Items[i] = TaskRunYield{
+			Kind:           row.Kind,
+			SourceAbbr:     row.SourceAbbr,
+			Query:          row.Query,
+			Provider:       row.Provider,
+			Runs:           row.Runs,
+			FailedRuns:     row.FailedRuns,
+			ItemsSeen:      row.ItemsSeen,
+			ItemsNew:       row.ItemsNew,
+			ItemsDuplicate: row.ItemsDuplicate,
+			AvgDurationMs:  row.AvgDuration.Milliseconds(),
+			LastRunAt:      row.LastRunAt,
+			LastNewAt:      row.LastNewAt,
+		}
+		if row.TaskID != uuid.Nil {
+			id := row.TaskID
+			resp.Items[i].TaskID = &id
+		}
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// ListTaskRuns handles GET /api/v1/admin/tasks/{id}/runs.
+//
+// Runs outlive their task, so an unknown or deleted task lists its history
+// or nothing rather than 404. Pages are keyset based on (started_at, id), so
+// runs recorded while a client pages through the history never shift rows.
+//
+// @Summary   List the discovery runs of a task
+// @Tags      admin
+// @Produce   json
+// @Param     id     path  string true  "Task ID"
+// @Param     limit  query int    false "Page size, newest first (default 50, max 500)"
+// @Param     cursor query string false "next_cursor from the previous page"
+// @Success   200 {object} ListTaskRunsResponse
+// @Failure   400 {object} ErrorResponse
+// @Failure   500 {object} ErrorResponse
+// @Router    /admin/tasks/{id}/runs [get]
+func (s *Server) ListTaskRuns(w http.ResponseWriter, r *http.Request) {
+	taskID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid task id")
+		return
+	}
+	q := r.URL.Query()
+	limit, ok := parseRouteLimit(w, q.Get("limit"), defaultTaskRunLimit, maxTaskRunLimit)
+	if !ok {
+		return
+	}
+	params := repo.ListTaskRunsParams{TaskID: taskID, Limit: limit + 1}
+	if v := strings.TrimSpace(q.Get("cursor")); v != "" {
+		pos, err := decodePageCursor[taskRunsCursor](v)
+		if err != nil || pos.ID == 0 || pos.StartedAt.IsZero() {
+			writeError(w, http.StatusBadRequest, errInvalidCursor.Error())
+			return
+		}
+		params.AfterStartedAt, params.AfterID = &pos.StartedAt, &pos.ID
+	}
+
+	ctx := r.Context()
+	rows, err := s.TaskRuns.ListByTask(ctx, params)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "list task runs failed",
+			slog.String("task_id", taskID.String()), slog.Any("error", err))
+		writeError(w, http.StatusInternalServerError, "failed to list task runs")
+		return
+	}
+
+	rows, more := trimPage(rows, limit)
+	items := make([]TaskRun, len(rows))
+	for i, row := range rows {
+		items[i] = TaskRun{
+			ID:             row.ID,
+			TaskID:         row.TaskID,
+			BatchID:        row.BatchID,
+			Kind:           row.Kind,
+			SourceType:     row.SourceType,
+			SourceAbbr:     row.SourceAbbr,
+			Status:         row.Status,
+			ItemsSeen:      row.ItemsSeen,
+			ItemsNew:       row.ItemsNew,
+			ItemsDuplicate: row.ItemsDuplicate,
+			DurationMs:     row.Duration.Milliseconds(),
+			TraceID:        row.TraceID,
+			StartedAt:      row.StartedAt,
+		}
+		if row.Query != nil {
+			items[i].Query = *row.Query
+		}
+		if row.ErrorClass != nil {
+			items[i].ErrorClass = *row.ErrorClass
+		}
+		if len(row.Providers) > 0 {
+			items[i].Providers = make(map[string]TaskRunProvider, len(row.Providers))
+			for name, p := range row.Providers {
+				items[i].Providers[name] = TaskRunProvider{Seen: p.Seen, ErrorClass: p.ErrorClass}
+			}
+		}
+	}
+	resp := ListTaskRunsResponse{Items: items, Count: len(items)}
+	if more {
+		last := rows[len(rows)-1]
+		resp.NextCursor = encodePageCursor(taskRunsCursor{StartedAt: last.StartedAt, ID: last.ID})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// parseRouteLimit reads a positive limit, defaulting to def and capped at
+// ceiling. It writes the 400 itself and reports false when v is invalid.
+func parseRouteLimit(w http.ResponseWriter, v string, def, ceiling int32) (int32, bool) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return def, true
+	}
+	n, err := strconv.ParseInt(v, 10, 32)
+	if err != nil || n <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid limit")
+		return 0, false
+	}
+	return int32(min(n, int64(ceiling))), true
+}
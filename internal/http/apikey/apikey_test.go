@@ -0,0 +1,113 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/http/middleware"
+	"github.com/ChiaYuChang/prism/internal/repo"
+	"github.com/ChiaYuChang/prism/internal/repo/mocks"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	key, prefix, hash, err := Generate()
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(key, KeyPrefix))
+	assert.True(t, strings.HasPrefix(key, prefix))
+	assert.Len(t, prefix, displayPrefixLen)
+	assert.Len(t, hash, 64)
+	assert.Equal(t, Hash(key), hash)
+
+	other, _, _, err := Generate()
+	require.NoError(t, err)
+	assert.NotEqual(t, key, other)
+}
+
+func TestStore_ResolvesAndCachesKeys(t *testing.T) {
+	users := mocks.NewMockUsers(t)
+	rps := 2.0
+	k := repo.APIKey{
+		ID:           uuid.New(),
+		UserID:       uuid.New(),
+		Scopes:       []string{middleware.ScopeRead},
+		RateLimitRPS: &rps,
+	}
+	users.EXPECT().ResolveAPIKey(mock.Anything, Hash("prism_good")).Return(k, nil).Once()
+
+	store, err := NewStore(users, nil, Config{CacheTTL: time.Minute})
+	require.NoError(t, err)
+
+	for range 2 {
+		p, err := store.Resolve(context.Background(), "prism_good")
+		require.NoError(t, err)
+		assert.Equal(t, middleware.Principal{
+			UserID:       k.UserID,
+			KeyID:        k.ID,
+			Scopes:       k.Scopes,
+			RateLimitRPS: 2,
+		}, p)
+	}
+}
+
+func TestStore_CachesRejectionsUntilTTL(t *testing.T) {
+	users := mocks.NewMockUsers(t)
+	users.EXPECT().ResolveAPIKey(mock.Anything, Hash("prism_bad")).Return(repo.APIKey{}, pgx.ErrNoRows).Twice()
+
+	store, err := NewStore(users, nil, Config{CacheTTL: time.Minute})
+	require.NoError(t, err)
+	now := time.Now()
+	store.now = func() time.Time { return now }
+
+	for range 2 {
+		_, err := store.Resolve(context.Background(), "prism_bad")
+		require.ErrorIs(t, err, middleware.ErrInvalidAPIKey)
+	}
+
+	now = now.Add(time.Minute)
+	_, err = store.Resolve(context.Background(), "prism_bad")
+	require.ErrorIs(t, err, middleware.ErrInvalidAPIKey)
+}
+
+func TestStore_DatabaseErrorIsNotInvalidKey(t *testing.T) {
+	users := mocks.NewMockUsers(t)
+	users.EXPECT().ResolveAPIKey(mock.Anything, mock.Anything).Return(repo.APIKey{}, errors.New("db down"))
+
+	store, err := NewStore(users, nil, Config{})
+	require.NoError(t, err)
+
+	_, err = store.Resolve(context.Background(), "prism_any")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, middleware.ErrInvalidAPIKey)
+}
+
+func TestStore_StaticTokensAreAdmins(t *testing.T) {
+	users := mocks.NewMockUsers(t)
+	store, err := NewStore(users, map[string]struct{}{"ops-token": {}, " ": {}}, Config{})
+	require.NoError(t, err)
+
+	p, err := store.Resolve(context.Background(), "ops-token")
+	require.NoError(t, err)
+	assert.Equal(t, uuid.Nil, p.UserID)
+	assert.NotEqual(t, uuid.Nil, p.KeyID)
+	assert.True(t, p.IsAdmin())
+
+	again, err := NewStore(users, map[string]struct{}{"ops-token": {}}, Config{})
+	require.NoError(t, err)
+	p2, err := again.Resolve(context.Background(), "ops-token")
+	require.NoError(t, err)
+	assert.Equal(t, p.KeyID, p2.KeyID, "static key ids are stable across instances")
+}
+
+func TestNewStore_RequiresUsers(t *testing.T) {
+	_, err := NewStore(nil, nil, Config{})
+	require.ErrorIs(t, err, ErrParamMissing)
+}
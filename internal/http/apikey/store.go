@@ -0,0 +1,164 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/http/middleware"
+	"github.com/ChiaYuChang/prism/internal/repo"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	defaultCacheTTL        = 30 * time.Second
+	defaultCacheMaxEntries = 4096
+)
+
+// staticKeyNamespace derives stable KeyIDs for static tokens so each one
+// keeps its own rate-limit bucket across restarts.
+var staticKeyNamespace = uuid.MustParse("6c1f5f0e-8a7b-4d0c-9a55-2f3d0e4b8c11")
+
+var _ middleware.KeyResolver = (*Store)(nil)
+
+// Config tunes Store. Zero values take the defaults.
+type Config struct {
+	// CacheTTL bounds how long a resolved key (or a rejected one) is served
+	// from memory. It is also how long a revoked key keeps working on this
+	// instance. Default 30s.
+	CacheTTL time.Duration
+	// CacheMaxEntries bounds the cache; when full, expired entries are
+	// dropped and, failing that, the whole cache is reset. Default 4096.
+	CacheMaxEntries int
+}
+
+// Store resolves API keys against repo.Users with a short in-memory cache,
+// so authenticated requests do not each cost a database round-trip.
+//
+// Static tokens (the operator tokens of auth.tokens) resolve to admin
+// principals without a user; they bootstrap the first users and keys.
+type Store struct {
+	users      repo.Users
+	static     map[string]middleware.Principal // by key hash
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]cacheEntry // by key hash
+}
+
+type cacheEntry struct {
+	principal middleware.Principal
+	valid     bool
+	expiresAt time.Time
+}
+
+// NewStore returns a Store backed by users. staticTokens may be empty.
+func NewStore(users repo.Users, staticTokens map[string]struct{}, cfg Config) (*Store, error) {
+	if users == nil {
+		return nil, fmt.Errorf("%w: users", ErrParamMissing)
+	}
+	if cfg.CacheTTL <= 0 {
+		cfg.CacheTTL = defaultCacheTTL
+	}
+	if cfg.CacheMaxEntries <= 0 {
+		cfg.CacheMaxEntries = defaultCacheMaxEntries
+	}
+
+	static := make(map[string]middleware.Principal, len(staticTokens))
+	for token := range staticTokens {
+		token = strings.TrimSpace(token)
+		if token == "" {
+			continue
+		}
+		hash := Hash(token)
+		static[hash] = middleware.Principal{
+			KeyID:  uuid.NewSHA1(staticKeyNamespace, []byte(hash)),
+			Scopes: []string{middleware.ScopeAdmin},
+		}
+	}
+
+	return &Store{
+		users:      users,
+		static:     static,
+		ttl:        cfg.CacheTTL,
+		maxEntries: cfg.CacheMaxEntries,
+		now:        time.Now,
+		cache:      make(map[string]cacheEntry),
+	}, nil
+}
+
+// Resolve implements middleware.KeyResolver.
+func (s *Store) Resolve(ctx context.Context, key string) (middleware.Principal, error) {
+	hash := Hash(key)
+	if p, ok := s.static[hash]; ok {
+		return p, nil
+	}
+	if e, ok := s.cached(hash); ok {
+		if !e.valid {
+			return middleware.Principal{}, middleware.ErrInvalidAPIKey
+		}
+		return e.principal, nil
+	}
+
+	k, err := s.users.ResolveAPIKey(ctx, hash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			s.store(hash, cacheEntry{})
+			return middleware.Principal{}, middleware.ErrInvalidAPIKey
+		}
+		return middleware.Principal{}, fmt.Errorf("resolve api key: %w", err)
+	}
+	p := principalFromKey(k)
+	s.store(hash, cacheEntry{principal: p, valid: true})
+	return p, nil
+}
+
+func (s *Store) cached(hash string) (cacheEntry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.cache[hash]
+	if !ok || !s.now().Before(e.expiresAt) {
+		return cacheEntry{}, false
+	}
+	return e, true
+}
+
+func (s *Store) store(hash string, e cacheEntry) {
+	now := s.now()
+	e.expiresAt = now.Add(s.ttl)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.cache) >= s.maxEntries {
+		for h, old := range s.cache {
+			if !now.Before(old.expiresAt) {
+				delete(s.cache, h)
+			}
+		}
+		if len(s.cache) >= s.maxEntries {
+			clear(s.cache)
+		}
+	}
+	s.cache[hash] = e
+}
+
+func principalFromKey(k repo.APIKey) middleware.Principal {
+	p := middleware.Principal{
+		UserID: k.UserID,
+		KeyID:  k.ID,
+		Scopes: k.Scopes,
+	}
+	if k.RateLimitRPS != nil {
+		p.RateLimitRPS = *k.RateLimitRPS
+	}
+	if k.RateLimitBurst != nil {
+		p.RateLimitBurst = int(*k.RateLimitBurst)
+	}
+	return p
+}
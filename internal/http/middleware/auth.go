@@ -1,9 +1,15 @@
 package middleware
 
 import (
+	"context"
 	"crypto/subtle"
+	"errors"
 	"net/http"
+	"slices"
 	"strings"
+
+	"github.com/ChiaYuChang/prism/internal/obs"
+	"github.com/google/uuid"
 )
 
 // TokenAuthHeader is the HTTP header used by operator clients to authenticate
@@ -56,3 +62,119 @@ func TokenListAuth(tokens map[string]struct{}) Middleware {
 		})
 	}
 }
+
+// API key scopes. ScopeAdmin implies every other scope.
+const (
+	// ScopeRead reads candidates, contents, fetch progress and status.
+	ScopeRead = "read"
+	// ScopePageFetch submits POST /page_fetch requests.
+	ScopePageFetch = "page_fetch"
+	// ScopeAdmin manages users and keys, reads every user's fetches and the
+	// LLM spend report.
+	ScopeAdmin = "admin"
+)
+
+// Scopes lists every valid scope.
+var Scopes = []string{ScopeRead, ScopePageFetch, ScopeAdmin}
+
+// ErrInvalidAPIKey is returned by a KeyResolver for unknown, revoked or
+// expired keys.
+var ErrInvalidAPIKey = errors.New("invalid api key")
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	// UserID is uuid.Nil for callers that are not backed by a users row
+	// (static operator tokens).
+	UserID uuid.UUID
+	// KeyID identifies the credential; rate limits are kept per KeyID.
+	KeyID  uuid.UUID
+	Scopes []string
+	// RateLimitRPS and RateLimitBurst override the server default when > 0.
+	RateLimitRPS   float64
+	RateLimitBurst int
+}
+
+// HasScope reports whether p was granted scope, directly or through
+// ScopeAdmin.
+func (p Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, scope) || slices.Contains(p.Scopes, ScopeAdmin)
+}
+
+// IsAdmin reports whether p holds ScopeAdmin.
+func (p Principal) IsAdmin() bool {
+	return slices.Contains(p.Scopes, ScopeAdmin)
+}
+
+// KeyResolver maps a presented API key to its Principal.
+type KeyResolver interface {
+	Resolve(ctx context.Context, key string) (Principal, error)
+}
+
+// WithPrincipal returns a context carrying p.
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, ctxKeyPrincipal, p)
+}
+
+// PrincipalFromContext returns the caller set by APIKeyAuth.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(ctxKeyPrincipal).(Principal)
+	return p, ok
+}
+
+// APIKeyAuth authenticates callers by API key, read from TokenAuthHeader or
+// an "Authorization: Bearer" header, and stores the resolved Principal in
+// the request context (plus its user ID for log correlation). Unknown keys
+// get 401; resolver failures get 503 so clients retry instead of dropping
+// their key.
+func APIKeyAuth(resolver KeyResolver) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := presentedKey(r)
+			if key == "" {
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+				return
+			}
+			p, err := resolver.Resolve(r.Context(), key)
+			if err != nil {
+				if errors.Is(err, ErrInvalidAPIKey) {
+					http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+					return
+				}
+				http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+				return
+			}
+
+			ctx := WithPrincipal(r.Context(), p)
+			if p.UserID != uuid.Nil {
+				ctx = obs.WithUserID(ctx, p.UserID)
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RequireScope rejects callers that lack scope with 403 Forbidden. Requests
+// without a Principal pass: they were admitted by TokenAuth/TokenListAuth,
+// which grant full access.
+func RequireScope(scope string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if p, ok := PrincipalFromContext(r.Context()); ok && !p.HasScope(scope) {
+				http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func presentedKey(r *http.Request) string {
+	if key := strings.TrimSpace(r.Header.Get(TokenAuthHeader)); key != "" {
+		return key
+	}
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if ok && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(token)
+	}
+	return ""
+}
@@ -14,6 +14,7 @@ type ctxKey int
 
 const (
 	ctxKeyRequestID ctxKey = iota
+	ctxKeyPrincipal
 )
 
 // RequestIDHeader is the HTTP header that carries the request identifier.
@@ -84,6 +85,12 @@ func (s *statusRecorder) Write(b []byte) (int, error) {
 	return n, err
 }
 
+// Unwrap lets http.ResponseController reach Flush and SetWriteDeadline on
+// the underlying writer, which streaming handlers need.
+func (s *statusRecorder) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
+
 func (s *statusRecorder) statusCode() int {
 	if s.status == 0 {
 		return http.StatusOK
@@ -3,6 +3,7 @@ package middleware_test
 import (
 	"bytes"
 	"context"
+	"errors"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
@@ -10,6 +11,8 @@ import (
 	"testing"
 
 	"github.com/ChiaYuChang/prism/internal/http/middleware"
+	"github.com/ChiaYuChang/prism/internal/obs"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/attribute"
@@ -190,6 +193,87 @@ func TestTokenListAuth_ClonesTokenSet(t *testing.T) {
 	assert.Equal(t, http.StatusOK, rec.Code)
 }
 
+type staticResolver map[string]middleware.Principal
+
+func (r staticResolver) Resolve(_ context.Context, key string) (middleware.Principal, error) {
+	if key == "broken" {
+		return middleware.Principal{}, errors.New("db down")
+	}
+	p, ok := r[key]
+	if !ok {
+		return middleware.Principal{}, middleware.ErrInvalidAPIKey
+	}
+	return p, nil
+}
+
+func TestAPIKeyAuth_ResolvesPrincipal(t *testing.T) {
+	userID := uuid.New()
+	want := middleware.Principal{UserID: userID, KeyID: uuid.New(), Scopes: []string{middleware.ScopeRead}}
+	resolver := staticResolver{"k1": want}
+
+	for _, set := range []func(*http.Request){
+		func(r *http.Request) { r.Header.Set(middleware.TokenAuthHeader, "k1") },
+		func(r *http.Request) { r.Header.Set("Authorization", "Bearer k1") },
+	} {
+		var got middleware.Principal
+		h := middleware.APIKeyAuth(resolver)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got, _ = middleware.PrincipalFromContext(r.Context())
+			assert.Equal(t, userID, obs.ExtractUserID(r.Context()))
+			w.WriteHeader(http.StatusOK)
+		}))
+		req := httptest.NewRequest(http.MethodGet, "/x", nil)
+		set(req)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestAPIKeyAuth_RejectsMissingAndUnknownKey(t *testing.T) {
+	h := middleware.APIKeyAuth(staticResolver{})(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		t.Fatal("handler should not be called")
+	}))
+
+	for key, status := range map[string]int{
+		"":        http.StatusUnauthorized,
+		"unknown": http.StatusUnauthorized,
+		"broken":  http.StatusServiceUnavailable,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/x", nil)
+		req.Header.Set(middleware.TokenAuthHeader, key)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		assert.Equal(t, status, rec.Code, "key %q", key)
+	}
+}
+
+func TestRequireScope(t *testing.T) {
+	h := middleware.RequireScope(middleware.ScopePageFetch)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for name, tc := range map[string]struct {
+		scopes []string
+		anon   bool
+		want   int
+	}{
+		"granted":      {scopes: []string{middleware.ScopePageFetch}, want: http.StatusOK},
+		"admin":        {scopes: []string{middleware.ScopeAdmin}, want: http.StatusOK},
+		"missing":      {scopes: []string{middleware.ScopeRead}, want: http.StatusForbidden},
+		"no principal": {anon: true, want: http.StatusOK},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/x", nil)
+		if !tc.anon {
+			req = req.WithContext(middleware.WithPrincipal(req.Context(), middleware.Principal{Scopes: tc.scopes}))
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		assert.Equal(t, tc.want, rec.Code, name)
+	}
+}
+
 func TestRecoverer_ConvertsPanicTo500(t *testing.T) {
 	h := middleware.Recoverer(discardLogger())(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
 		panic("boom")
@@ -202,6 +286,16 @@ func TestRecoverer_ConvertsPanicTo500(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, rec.Code)
 }
 
+func TestRecoverer_ReraisesAbortHandler(t *testing.T) {
+	h := middleware.Recoverer(discardLogger())(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/x", nil)
+	rec := httptest.NewRecorder()
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() { h.ServeHTTP(rec, req) })
+}
+
 func TestLogger_WritesOneLine(t *testing.T) {
 	var buf bytes.Buffer
 	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
@@ -403,6 +497,35 @@ func TestRateLimit_PerIPIsolation(t *testing.T) {
 	require.Equal(t, http.StatusTooManyRequests, rec.Code)
 }
 
+func TestRateLimitPerKey_UsesKeyBudget(t *testing.T) {
+	limiter := middleware.NewInMemoryKeyLimiter(1, 1, 16)
+	h := middleware.RateLimitPerKey(limiter)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	generous := middleware.Principal{KeyID: uuid.New(), RateLimitRPS: 1, RateLimitBurst: 3}
+	strict := middleware.Principal{KeyID: uuid.New()}
+	mk := func(p middleware.Principal) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/x", nil)
+		r.RemoteAddr = "10.0.0.1:1"
+		return r.WithContext(middleware.WithPrincipal(r.Context(), p))
+	}
+	do := func(p middleware.Principal) int {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, mk(p))
+		return rec.Code
+	}
+
+	for range 3 {
+		require.Equal(t, http.StatusOK, do(generous))
+	}
+	require.Equal(t, http.StatusTooManyRequests, do(generous))
+
+	// Same client IP, different key: separate bucket at the default budget.
+	require.Equal(t, http.StatusOK, do(strict))
+	require.Equal(t, http.StatusTooManyRequests, do(strict))
+}
+
 func TestClientIP_PrefersXForwardedFor(t *testing.T) {
 	r := httptest.NewRequest(http.MethodGet, "/x", nil)
 	r.RemoteAddr = "10.0.0.1:1234"
@@ -20,12 +20,25 @@ type NoOpIPLimiter struct{}
 
 func (NoOpIPLimiter) Allow(string) bool { return true }
 
-// InMemoryIPLimiter is a per-IP token-bucket limiter backed by an LRU map.
+// KeyLimiter decides whether a request attributed to key may proceed. rps
+// and burst are the key's own budget; values <= 0 take the limiter's
+// defaults.
+type KeyLimiter interface {
+	Allow(key string, rps float64, burst int) bool
+}
+
+// NoOpKeyLimiter always allows. Suitable as the default when rate limiting is disabled.
+type NoOpKeyLimiter struct{}
+
+func (NoOpKeyLimiter) Allow(string, float64, int) bool { return true }
+
+// InMemoryKeyLimiter is a per-key token-bucket limiter backed by an LRU map.
 //
-// Each unique IP gets its own *rate.Limiter (rps requests per second, burst).
-// The map is bounded by maxEntries; on overflow the least-recently-used IP is
-// evicted. Safe for concurrent use.
-type InMemoryIPLimiter struct {
+// Each unique key gets its own *rate.Limiter. A key whose budget changes
+// between calls (e.g. an admin edited it) has its limiter retuned in place.
+// The map is bounded by maxEntries; on overflow the least-recently-used key
+// is evicted. Safe for concurrent use.
+type InMemoryKeyLimiter struct {
 	rps        rate.Limit
 	burst      int
 	maxEntries int
@@ -35,14 +48,14 @@ type InMemoryIPLimiter struct {
 	order   *list.List // front = MRU, back = LRU
 }
 
-type ipLimiterEntry struct {
-	ip      string
+type keyLimiterEntry struct {
+	key     string
 	limiter *rate.Limiter
 }
 
-// NewInMemoryIPLimiter constructs a limiter. rps and burst must be > 0;
-// maxEntries must be > 0.
-func NewInMemoryIPLimiter(rps float64, burst, maxEntries int) *InMemoryIPLimiter {
+// NewInMemoryKeyLimiter constructs a limiter with default rps and burst.
+// Non-positive arguments fall back to 5 rps, burst 10 and 4096 entries.
+func NewInMemoryKeyLimiter(rps float64, burst, maxEntries int) *InMemoryKeyLimiter {
 	if rps <= 0 {
 		rps = 5
 	}
@@ -52,7 +65,7 @@ func NewInMemoryIPLimiter(rps float64, burst, maxEntries int) *InMemoryIPLimiter
 	if maxEntries <= 0 {
 		maxEntries = 4096
 	}
-	return &InMemoryIPLimiter{
+	return &InMemoryKeyLimiter{
 		rps:        rate.Limit(rps),
 		burst:      burst,
 		maxEntries: maxEntries,
@@ -61,30 +74,60 @@ func NewInMemoryIPLimiter(rps float64, burst, maxEntries int) *InMemoryIPLimiter
 	}
 }
 
-func (l *InMemoryIPLimiter) Allow(ip string) bool {
+func (l *InMemoryKeyLimiter) Allow(key string, rps float64, burst int) bool {
+	limit := l.rps
+	if rps > 0 {
+		limit = rate.Limit(rps)
+	}
+	if burst <= 0 {
+		burst = l.burst
+	}
+
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
-	if elem, ok := l.entries[ip]; ok {
+	if elem, ok := l.entries[key]; ok {
 		l.order.MoveToFront(elem)
-		return elem.Value.(*ipLimiterEntry).limiter.Allow()
+		lim := elem.Value.(*keyLimiterEntry).limiter
+		if lim.Limit() != limit {
+			lim.SetLimit(limit)
+		}
+		if lim.Burst() != burst {
+			lim.SetBurst(burst)
+		}
+		return lim.Allow()
 	}
 
-	lim := rate.NewLimiter(l.rps, l.burst)
-	entry := &ipLimiterEntry{ip: ip, limiter: lim}
-	elem := l.order.PushFront(entry)
-	l.entries[ip] = elem
+	lim := rate.NewLimiter(limit, burst)
+	elem := l.order.PushFront(&keyLimiterEntry{key: key, limiter: lim})
+	l.entries[key] = elem
 
 	if l.order.Len() > l.maxEntries {
 		oldest := l.order.Back()
 		if oldest != nil {
 			l.order.Remove(oldest)
-			delete(l.entries, oldest.Value.(*ipLimiterEntry).ip)
+			delete(l.entries, oldest.Value.(*keyLimiterEntry).key)
 		}
 	}
 	return lim.Allow()
 }
 
+// InMemoryIPLimiter is a per-IP token-bucket limiter: an InMemoryKeyLimiter
+// keyed by client IP with one budget for every IP.
+type InMemoryIPLimiter struct {
+	keys *InMemoryKeyLimiter
+}
+
+// NewInMemoryIPLimiter constructs a limiter. rps and burst must be > 0;
+// maxEntries must be > 0.
+func NewInMemoryIPLimiter(rps float64, burst, maxEntries int) *InMemoryIPLimiter {
+	return &InMemoryIPLimiter{keys: NewInMemoryKeyLimiter(rps, burst, maxEntries)}
+}
+
+func (l *InMemoryIPLimiter) Allow(ip string) bool {
+	return l.keys.Allow(ip, 0, 0)
+}
+
 // ClientIP extracts the request's client IP. It honors the leftmost
 // X-Forwarded-For entry when present, otherwise falls back to RemoteAddr.
 func ClientIP(r *http.Request) string {
@@ -116,3 +159,24 @@ func RateLimit(limiter IPLimiter) Middleware {
 		})
 	}
 }
+
+// RateLimitPerKey returns a middleware that rejects requests above the
+// caller's budget with 429 Too Many Requests. Requests authenticated by
+// APIKeyAuth are limited per key with the key's own rps/burst; anonymous
+// requests fall back to one bucket per client IP at the default budget.
+func RateLimitPerKey(limiter KeyLimiter) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key, rps, burst := "ip:"+ClientIP(r), 0.0, 0
+			if p, ok := PrincipalFromContext(r.Context()); ok {
+				key, rps, burst = "key:"+p.KeyID.String(), p.RateLimitRPS, p.RateLimitBurst
+			}
+			if !limiter.Allow(key, rps, burst) {
+				w.Header().Set("Retry-After", "1")
+				http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -8,11 +8,16 @@ import (
 
 // Recoverer catches panics in downstream handlers, logs a stack trace, and
 // returns a 500 so a single bad request does not crash the server.
+// http.ErrAbortHandler is re-raised so net/http drops the connection, which
+// is how streaming handlers signal a truncated body.
 func Recoverer(logger *slog.Logger) Middleware {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			defer func() {
 				if rec := recover(); rec != nil {
+					if rec == http.ErrAbortHandler {
+						panic(rec)
+					}
 					logger.ErrorContext(r.Context(), "panic in handler",
 						slog.Any("panic", rec),
 						slog.String("request_id", RequestIDFromContext(r.Context())),
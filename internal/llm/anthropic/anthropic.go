@@ -0,0 +1,298 @@
+package anthropic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/llm"
+	"github.com/ChiaYuChang/prism/internal/obs"
+	"github.com/ChiaYuChang/prism/pkg/logger"
+	pkgschema "github.com/ChiaYuChang/prism/pkg/schema"
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/anthropics/anthropic-sdk-go/option"
+	"github.com/go-playground/mold/v4"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// defaultToolName names the structured-output tool when the request
+	// schema has no usable name.
+	defaultToolName = "structured_output"
+	// defaultMaxTokens is used when neither Config nor the request set one.
+	defaultMaxTokens = 4096
+)
+
+// Config holds Anthropic-specific configuration (Pure Data).
+//
+// MaxTokens is used when GenerateRequest.MaxTokens is nil: the Messages API
+// requires an explicit output limit on every call. Zero means 4096.
+type Config struct {
+	APIKey     string            `json:"api_key"     mod:"trim"                                   validate:"required"`
+	BaseURL    string            `json:"base_url"    mod:"trim,default=https://api.anthropic.com" validate:"omitempty,url"`
+	Timeout    time.Duration     `json:"timeout"     mod:"trim,default=30s"`
+	MaxTokens  int64             `json:"max_tokens"                                               validate:"min=0"`
+	HttpHeader map[string]string `json:"http_header" mod:"trim"`
+}
+
+// Provider implements llm.Generator for the Anthropic Messages API.
+// Anthropic has no embeddings endpoint; Embed always fails with
+// llm.ErrUnsupportedOperation.
+type Provider struct {
+	client      *anthropic.Client
+	maxTokens   int64
+	logger      *slog.Logger
+	tracer      trace.Tracer
+	validator   *validator.Validate
+	transformer *mold.Transformer
+}
+
+// New creates a new Anthropic provider instance with explicit dependency injection.
+func New(ctx context.Context, l *slog.Logger, t trace.Tracer, v *validator.Validate,
+	m *mold.Transformer, c *http.Client, cfg Config) (*Provider, error) {
+
+	if err := m.Struct(ctx, &cfg); err != nil {
+		return nil, fmt.Errorf("anthropic %w: %s", llm.ErrCfgModError, err)
+	}
+
+	if err := v.StructCtx(ctx, cfg); err != nil {
+		return nil, fmt.Errorf("anthropic %w: %s", llm.ErrCfgValError, err)
+	}
+
+	if cfg.APIKey == "" {
+		return nil, llm.ErrMissingAPIKEY
+	}
+
+	opts := []option.RequestOption{
+		option.WithAPIKey(cfg.APIKey),
+	}
+
+	if cfg.BaseURL != "" {
+		opts = append(opts, option.WithBaseURL(cfg.BaseURL))
+	}
+
+	if c != nil {
+		opts = append(opts, option.WithHTTPClient(c))
+	}
+
+	if cfg.Timeout != 0 {
+		opts = append(opts, option.WithRequestTimeout(cfg.Timeout))
+	}
+
+	for k, v := range cfg.HttpHeader {
+		opts = append(opts, option.WithHeaderAdd(k, v))
+	}
+
+	if cfg.MaxTokens == 0 {
+		cfg.MaxTokens = defaultMaxTokens
+	}
+
+	client := anthropic.NewClient(opts...)
+	return &Provider{
+		client:      &client,
+		maxTokens:   cfg.MaxTokens,
+		logger:      l,
+		tracer:      t,
+		validator:   v,
+		transformer: m,
+	}, nil
+}
+
+// Generate produces content based on the structured request.
+//
+// JSON schema requests are served through a single forced tool call: the
+// schema becomes the tool's input_schema and the tool input is returned as
+// the response text, so callers decode it exactly like other providers'
+// structured output.
+func (p *Provider) Generate(ctx context.Context, req *llm.GenerateRequest) (*llm.GenerateResponse, error) {
+	tid := obs.ExtractTraceID(ctx)
+	uid := obs.ExtractUserID(ctx)
+
+	l := logger.WithHook(p.logger,
+		logger.SinceHook("time", time.Now()),
+		func(ctx context.Context, r slog.Record) slog.Record {
+			r.Add("trace_id", tid)
+			r.Add("user_id", uid)
+			return r
+		})
+
+	params := anthropic.MessageNewParams{
+		Model:     anthropic.Model(req.Model),
+		MaxTokens: p.maxTokens,
+		Messages: []anthropic.MessageParam{
+			anthropic.NewUserMessage(anthropic.NewTextBlock(req.Prompt)),
+		},
+	}
+
+	if req.SystemInstruction != "" {
+		params.System = []anthropic.TextBlockParam{{Text: req.SystemInstruction}}
+	}
+
+	// Extract UserID from context via internal/obs
+	if uid != uuid.Nil {
+		params.Metadata = anthropic.MetadataParam{UserID: anthropic.String(uid.String())}
+	}
+
+	if req.Temperature != nil {
+		params.Temperature = anthropic.Float(float64(*req.Temperature))
+	}
+
+	if req.TopP != nil {
+		params.TopP = anthropic.Float(float64(*req.TopP))
+	}
+
+	if req.TopK != nil {
+		params.TopK = anthropic.Int(int64(*req.TopK))
+	}
+
+	if req.MaxTokens != nil {
+		params.MaxTokens = int64(*req.MaxTokens)
+	}
+
+	// Handle JSON Mode via a forced tool call
+	var toolName string
+	if req.Format == llm.ResponseFormatJsonSchema && req.JSONSchema.Schema != nil {
+		tool, err := schemaTool(req.JSONSchema)
+		if err != nil {
+			return nil, fmt.Errorf("anthropic %w: %w", llm.ErrGenAPIError, err)
+		}
+		toolName = tool.Name
+		params.Tools = []anthropic.ToolUnionParam{{OfTool: tool}}
+		params.ToolChoice = anthropic.ToolChoiceParamOfTool(toolName)
+	}
+
+	resp, err := p.client.Messages.New(ctx, params)
+	if err != nil {
+		l.LogAttrs(ctx, slog.LevelError,
+			"anthropic generate error",
+			slog.String("message", err.Error()),
+			slog.String("model", req.Model))
+		return nil, llm.NewAPIError("anthropic", llm.ErrGenAPIError, statusCode(err), err)
+	}
+
+	text := responseText(resp, toolName)
+	usage := tokenUsage(resp.Usage)
+
+	l.LogAttrs(ctx, slog.LevelInfo,
+		"anthropic generate success",
+		slog.String("model", string(resp.Model)),
+		slog.String("stop_reason", string(resp.StopReason)),
+		slog.Int("total_tokens", usage.Total))
+
+	return &llm.GenerateResponse{
+		Model:      string(resp.Model),
+		Text:       text,
+		Usage:      usage,
+		Raw:        resp,
+		JsonSchema: req.JSONSchema,
+	}, nil
+}
+
+// Embed is not offered by the Anthropic API.
+func (p *Provider) Embed(_ context.Context, req *llm.EmbedRequest) (*llm.EmbedResponse, error) {
+	model := ""
+	if req != nil {
+		model = req.Model
+	}
+	return nil, fmt.Errorf("anthropic embed %s: %w", model, llm.ErrUnsupportedOperation)
+}
+
+func (p *Provider) Close() error {
+	return nil
+}
+
+// schemaTool turns a response schema into the tool that carries it. The
+// top-level properties/required map onto input_schema; every other keyword
+// (additionalProperties, $defs, ...) is passed through unchanged.
+func schemaTool(s pkgschema.JSONSchema) (*anthropic.ToolParam, error) {
+	m, err := s.ToOpenAI()
+	if err != nil {
+		return nil, err
+	}
+
+	input := anthropic.ToolInputSchemaParam{
+		Properties:  m["properties"],
+		ExtraFields: map[string]any{},
+	}
+	if raw, ok := m["required"].([]any); ok {
+		for _, r := range raw {
+			if name, ok := r.(string); ok {
+				input.Required = append(input.Required, name)
+			}
+		}
+	}
+	for k, v := range m {
+		switch k {
+		case "type", "properties", "required", "$schema":
+		default:
+			input.ExtraFields[k] = v
+		}
+	}
+
+	return &anthropic.ToolParam{
+		Name:        toolName(s.Name),
+		Description: anthropic.String("Return the answer as the input of this tool."),
+		InputSchema: input,
+	}, nil
+}
+
+// toolName keeps a schema name when it fits the tool-name pattern
+// ^[a-zA-Z0-9_-]{1,64}$.
+func toolName(name string) string {
+	if name == "" || len(name) > 64 {
+		return defaultToolName
+	}
+	for _, r := range name {
+		if !(r == '_' || r == '-' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z') {
+			return defaultToolName
+		}
+	}
+	return name
+}
+
+// responseText returns the input of the structured-output tool when one was
+// requested, otherwise the concatenated text blocks.
+func responseText(resp *anthropic.Message, tool string) string {
+	var b strings.Builder
+	for _, block := range resp.Content {
+		switch block.Type {
+		case "tool_use":
+			if tool != "" && block.Name == tool {
+				return string(block.Input)
+			}
+		case "text":
+			b.WriteString(block.Text)
+		}
+	}
+	return b.String()
+}
+
+// tokenUsage normalises Anthropic usage to llm.TokenUsage. Anthropic reports
+// cache reads and writes apart from input_tokens; they are folded into Input
+// so Input covers the whole prompt as it does for the other providers.
+func tokenUsage(u anthropic.Usage) llm.TokenUsage {
+	input := int(u.InputTokens + u.CacheReadInputTokens + u.CacheCreationInputTokens)
+	output := int(u.OutputTokens)
+	return llm.TokenUsage{
+		Input:  input,
+		Output: output,
+		Total:  input + output,
+		Cached: int(u.CacheReadInputTokens),
+	}
+}
+
+// statusCode extracts the HTTP status from an SDK error, or 0 when the call
+// failed before a response arrived.
+func statusCode(err error) int {
+	var apiErr *anthropic.Error
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
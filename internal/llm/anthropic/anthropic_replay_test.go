@@ -0,0 +1,141 @@
+// Replay tests use cassettes captured by the manual smoke tests so the
+// Anthropic provider's request shaping, tool-based structured output, usage
+// mapping and error classification can be exercised offline. Run as part
+// of the default `go test ./...`.
+package anthropic_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/llm"
+	"github.com/ChiaYuChang/prism/internal/llm/anthropic"
+	pkgschema "github.com/ChiaYuChang/prism/pkg/schema"
+	"github.com/ChiaYuChang/prism/pkg/utils"
+	"github.com/go-playground/mold/v4"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func newReplayProvider(t *testing.T, ctx context.Context, c cassette) (*anthropic.Provider, *replayTransport) {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	tracer := noop.NewTracerProvider().Tracer("anthropic-replay")
+	v := validator.New()
+	m := mold.New()
+	rt := &replayTransport{c: c}
+	hc := &http.Client{
+		Timeout:   5 * time.Second,
+		Transport: rt,
+	}
+	p, err := anthropic.New(ctx, logger, tracer, v, m, hc, anthropic.Config{
+		APIKey:  "replay-fixture-key",
+		BaseURL: "http://replay-fixture.invalid",
+		Timeout: 5 * time.Second,
+	})
+	require.NoError(t, err)
+	return p, rt
+}
+
+func TestAnthropicReplay_GenerateText(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	p, rt := newReplayProvider(t, ctx, loadCassette(t, "generate_text"))
+
+	resp, err := p.Generate(ctx, &llm.GenerateRequest{
+		Model:             "claude-haiku-4-5",
+		SystemInstruction: "Reply with a single short word.",
+		Prompt:            "Say hi.",
+		Temperature:       utils.Ptr(float32(0.0)),
+		Format:            llm.ResponseFormatText,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Hi!", resp.Text)
+	require.Equal(t, llm.TokenUsage{Input: 18, Output: 5, Total: 23}, resp.Usage)
+
+	var sent map[string]any
+	require.NoError(t, json.Unmarshal(rt.body, &sent))
+	require.EqualValues(t, 4096, sent["max_tokens"], "default max_tokens must be sent")
+	require.NotContains(t, sent, "tools")
+}
+
+// TestAnthropicReplay_GenerateJSONSchema checks that a JSON schema request
+// becomes one forced tool call and that the tool input comes back as the
+// response text.
+func TestAnthropicReplay_GenerateJSONSchema(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	p, rt := newReplayProvider(t, ctx, loadCassette(t, "generate_jsonschema"))
+
+	type Greeting struct {
+		Greeting string `json:"greeting"`
+		Language string `json:"language"`
+	}
+	schema := pkgschema.NewSkeleton[Greeting]("greeting", 1)
+
+	resp, err := p.Generate(ctx, &llm.GenerateRequest{
+		Model:       "claude-haiku-4-5",
+		Prompt:      "Greet someone in Traditional Chinese.",
+		Temperature: utils.Ptr(float32(0.0)),
+		Format:      llm.ResponseFormatJsonSchema,
+		JSONSchema:  schema,
+	})
+	require.NoError(t, err)
+
+	var out Greeting
+	require.NoError(t, resp.DecodeJSONSchema(&out))
+	require.Equal(t, "您好", out.Greeting)
+	require.Equal(t, "zh", out.Language)
+	require.Equal(t, llm.TokenUsage{Input: 668, Output: 38, Total: 706, Cached: 256}, resp.Usage)
+
+	var sent struct {
+		Tools []struct {
+			Name        string         `json:"name"`
+			InputSchema map[string]any `json:"input_schema"`
+		} `json:"tools"`
+		ToolChoice map[string]any `json:"tool_choice"`
+	}
+	require.NoError(t, json.Unmarshal(rt.body, &sent))
+	require.Len(t, sent.Tools, 1)
+	require.Equal(t, "greeting", sent.Tools[0].Name)
+	require.Equal(t, "object", sent.Tools[0].InputSchema["type"])
+	require.Contains(t, sent.Tools[0].InputSchema["properties"], "greeting")
+	require.Equal(t, false, sent.Tools[0].InputSchema["additionalProperties"])
+	require.Equal(t, map[string]any{"type": "tool", "name": "greeting"}, sent.ToolChoice)
+}
+
+// TestAnthropicReplay_ModelNotFound exercises the 404 error path: the
+// provider must surface a wrapped llm.ErrGenAPIError so callers can
+// classify failures without inspecting strings.
+func TestAnthropicReplay_ModelNotFound(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	p, _ := newReplayProvider(t, ctx, loadCassette(t, "model_not_found"))
+
+	_, err := p.Generate(ctx, &llm.GenerateRequest{
+		Model:  "definitely-not-a-real-model",
+		Prompt: "Say hi.",
+		Format: llm.ResponseFormatText,
+	})
+	require.Error(t, err)
+	require.ErrorIs(t, err, llm.ErrGenAPIError)
+
+	var apiErr *llm.APIError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, 404, apiErr.StatusCode)
+	require.False(t, llm.IsRetryable(err))
+}
+
+func TestAnthropic_EmbedUnsupported(t *testing.T) {
+	ctx := context.Background()
+	p, _ := newReplayProvider(t, ctx, cassette{})
+
+	_, err := p.Embed(ctx, &llm.EmbedRequest{Model: "any", Input: []string{"a"}})
+	require.ErrorIs(t, err, llm.ErrUnsupportedOperation)
+}
@@ -0,0 +1,155 @@
+//go:build manual
+
+// Manual smoke tests against the Anthropic Messages API.
+//
+// Run with `go test -tags=manual -count=1 -run Anthropic ./internal/llm/anthropic/...`.
+//
+// Env knobs:
+//
+//	PRISM_ANTHROPIC_RECORD=1             capture cassettes
+//	PRISM_ANTHROPIC_BASE_URL=...         override base URL
+//	PRISM_ANTHROPIC_KEY_FILE=path / PRISM_ANTHROPIC_KEY=raw
+//	PRISM_ANTHROPIC_GENERATE_MODEL=...   default claude-haiku-4-5
+package anthropic_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/llm"
+	"github.com/ChiaYuChang/prism/internal/llm/anthropic"
+	pkgschema "github.com/ChiaYuChang/prism/pkg/schema"
+	"github.com/ChiaYuChang/prism/pkg/utils"
+	"github.com/go-playground/mold/v4"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const defaultGenerateModel = "claude-haiku-4-5"
+
+func generateModel() string {
+	if v := os.Getenv("PRISM_ANTHROPIC_GENERATE_MODEL"); v != "" {
+		return v
+	}
+	return defaultGenerateModel
+}
+
+// loadAPIKey returns the key without ever logging it, skipping the test
+// when none is configured.
+func loadAPIKey(t *testing.T) string {
+	t.Helper()
+	if path := os.Getenv("PRISM_ANTHROPIC_KEY_FILE"); path != "" {
+		raw, err := os.ReadFile(path)
+		require.NoError(t, err)
+		if k := strings.TrimSpace(string(raw)); k != "" {
+			return k
+		}
+	}
+	if k := strings.TrimSpace(os.Getenv("PRISM_ANTHROPIC_KEY")); k != "" {
+		return k
+	}
+	t.Skip("set PRISM_ANTHROPIC_KEY_FILE or PRISM_ANTHROPIC_KEY to call the live API")
+	return ""
+}
+
+func newRecordingProvider(t *testing.T, ctx context.Context, cassetteName string) *anthropic.Provider {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	tracer := noop.NewTracerProvider().Tracer("anthropic-smoke")
+	v := validator.New()
+	m := mold.New()
+	hc := &http.Client{
+		Timeout: 120 * time.Second,
+		Transport: &recordingTransport{
+			t:    t,
+			name: cassetteName,
+			base: http.DefaultTransport,
+		},
+	}
+	p, err := anthropic.New(ctx, logger, tracer, v, m, hc, anthropic.Config{
+		APIKey:  loadAPIKey(t),
+		BaseURL: os.Getenv("PRISM_ANTHROPIC_BASE_URL"),
+		Timeout: 120 * time.Second,
+	})
+	require.NoError(t, err)
+	return p
+}
+
+func TestAnthropicGenerate_Text(t *testing.T) {
+	if !recordingEnabled() {
+		t.Skip("set PRISM_ANTHROPIC_RECORD=1 to call the live API and capture cassette")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+	p := newRecordingProvider(t, ctx, "generate_text")
+
+	resp, err := p.Generate(ctx, &llm.GenerateRequest{
+		Model:             generateModel(),
+		SystemInstruction: "Reply with a single short word.",
+		Prompt:            "Say hi.",
+		Temperature:       utils.Ptr(float32(0.0)),
+		Format:            llm.ResponseFormatText,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.Text)
+	t.Logf("model=%s text_len=%d total_tokens=%d", resp.Model, len(resp.Text), resp.Usage.Total)
+}
+
+// TestAnthropicGenerate_JSONSchema exercises tool-based structured output:
+// the schema is sent as a forced tool and the tool input is decoded like
+// any other provider's JSON response.
+func TestAnthropicGenerate_JSONSchema(t *testing.T) {
+	if !recordingEnabled() {
+		t.Skip("set PRISM_ANTHROPIC_RECORD=1 to call the live API and capture cassette")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+	p := newRecordingProvider(t, ctx, "generate_jsonschema")
+
+	type Greeting struct {
+		Greeting string `json:"greeting"`
+		Language string `json:"language"`
+	}
+	schema := pkgschema.NewSkeleton[Greeting]("greeting", 1)
+
+	resp, err := p.Generate(ctx, &llm.GenerateRequest{
+		Model:       generateModel(),
+		Prompt:      "Greet someone in Traditional Chinese.",
+		Temperature: utils.Ptr(float32(0.0)),
+		Format:      llm.ResponseFormatJsonSchema,
+		JSONSchema:  schema,
+	})
+	require.NoError(t, err)
+
+	var out Greeting
+	require.NoError(t, resp.DecodeJSONSchema(&out))
+	require.NotEmpty(t, out.Greeting)
+	require.NotEmpty(t, out.Language)
+	t.Logf("model=%s greeting_len=%d language=%s", resp.Model, len(out.Greeting), out.Language)
+}
+
+// TestAnthropicModelNotFound captures the error returned for an unknown
+// model. Replay tests assert classification as llm.ErrGenAPIError.
+func TestAnthropicModelNotFound(t *testing.T) {
+	if !recordingEnabled() {
+		t.Skip("set PRISM_ANTHROPIC_RECORD=1 to call the live API and capture cassette")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	p := newRecordingProvider(t, ctx, "model_not_found")
+
+	_, err := p.Generate(ctx, &llm.GenerateRequest{
+		Model:  "definitely-not-a-real-model",
+		Prompt: "Say hi.",
+		Format: llm.ResponseFormatText,
+	})
+	require.Error(t, err)
+	require.ErrorIs(t, err, llm.ErrGenAPIError)
+}
@@ -0,0 +1,133 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// ErrCircuitOpen is returned when a provider is skipped because its circuit
+// breaker is open.
+var ErrCircuitOpen = errors.New("llm circuit breaker is open")
+
+const (
+	breakerClosed   = "closed"
+	breakerOpen     = "open"
+	breakerHalfOpen = "half_open"
+)
+
+// BreakerPolicy configures a per-provider circuit breaker.
+type BreakerPolicy struct {
+	// FailureThreshold is the number of consecutive retryable failures that
+	// opens the breaker. Zero disables the breaker.
+	FailureThreshold int
+	// Cooldown is how long the breaker stays open before a single probe
+	// call is let through.
+	Cooldown time.Duration
+}
+
+// circuitBreaker is a consecutive-failure breaker. While open every call is
+// rejected; after Cooldown one probe is admitted (half-open) and its outcome
+// either closes the breaker or re-opens it for another Cooldown.
+type circuitBreaker struct {
+	policy   BreakerPolicy
+	provider string
+	metrics  *Metrics
+	now      func() time.Time
+
+	mu       sync.Mutex
+	state    string
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func newCircuitBreaker(policy BreakerPolicy, provider string, metrics *Metrics) *circuitBreaker {
+	return &circuitBreaker{
+		policy:   policy,
+		provider: provider,
+		metrics:  metrics,
+		now:      time.Now,
+		state:    breakerClosed,
+	}
+}
+
+// allow reports whether a call may proceed.
+func (b *circuitBreaker) allow(ctx context.Context) bool {
+	if b.policy.FailureThreshold <= 0 {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if b.now().Sub(b.openedAt) < b.policy.Cooldown {
+			return false
+		}
+		b.transition(ctx, breakerHalfOpen)
+		b.probing = true
+		return true
+	case breakerHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+func (b *circuitBreaker) success(ctx context.Context) {
+	if b.policy.FailureThreshold <= 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.probing = false
+	if b.state != breakerClosed {
+		b.transition(ctx, breakerClosed)
+	}
+}
+
+func (b *circuitBreaker) failure(ctx context.Context) {
+	if b.policy.FailureThreshold <= 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+	b.failures++
+	if b.state == breakerHalfOpen || b.failures >= b.policy.FailureThreshold {
+		b.openedAt = b.now()
+		if b.state != breakerOpen {
+			b.transition(ctx, breakerOpen)
+		}
+	}
+}
+
+// release returns an admitted probe without recording an outcome, e.g. when
+// the caller's context was cancelled mid-call.
+func (b *circuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+// transition must be called with mu held.
+func (b *circuitBreaker) transition(ctx context.Context, state string) {
+	b.state = state
+	if b.metrics == nil || b.metrics.breaker == nil {
+		return
+	}
+	b.metrics.breaker.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("provider", normalizeLLMLabel(b.provider)),
+		attribute.String("state", state),
+	))
+}
@@ -0,0 +1,360 @@
+package llm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	// DefaultCacheTTL is used when CacheOptions.TTL is zero.
+	DefaultCacheTTL = 7 * 24 * time.Hour
+
+	cacheKeyPrefix = "llm:cache:"
+
+	cacheResultHit    = "hit"
+	cacheResultMiss   = "miss"
+	cacheResultBypass = "bypass"
+	cacheResultError  = "error"
+)
+
+// ErrCacheStoreMissing is returned when a caching decorator is built without a store.
+var ErrCacheStoreMissing = errors.New("llm cache store is missing")
+
+// CacheStore persists encoded LLM responses keyed by CacheKey output.
+//
+// Implementations must be safe for concurrent use. Get returns ok=false on a
+// miss with a nil error; transport errors are returned with ok=false. The
+// caching decorators treat every Get/Set error as a miss so a cache outage
+// degrades to direct provider calls instead of failing the caller.
+type CacheStore interface {
+	Get(ctx context.Context, key string) (value []byte, ok bool, err error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// CacheOptions tunes the caching decorators.
+type CacheOptions struct {
+	// TTL is the lifetime of one cached response. Zero means DefaultCacheTTL.
+	TTL time.Duration
+	// Bypass skips lookups but still writes fresh responses, so a bypassed
+	// run refreshes the cache for the next one.
+	Bypass bool
+	// Metrics records hit/miss counters. Nil disables cache metrics.
+	Metrics *Metrics
+	// Provider labels cache metrics, e.g. "llm.gemini".
+	Provider string
+}
+
+func (o CacheOptions) ttl() time.Duration {
+	if o.TTL <= 0 {
+		return DefaultCacheTTL
+	}
+	return o.TTL
+}
+
+type cacheBypassKey struct{}
+
+// WithCacheBypass marks ctx so caching decorators skip lookups for calls made
+// with it. Fresh responses are still written back.
+func WithCacheBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheBypassKey{}, true)
+}
+
+func cacheBypassed(ctx context.Context) bool {
+	v, _ := ctx.Value(cacheBypassKey{}).(bool)
+	return v
+}
+
+// CacheHit is stored in GenerateResponse.Raw / EmbedResponse.Raw when a
+// response is served from the cache. Usage on the returned response is zero
+// because no provider tokens were spent; the original usage is kept here.
+type CacheHit struct {
+	Key      string     `json:"key"`
+	CachedAt time.Time  `json:"cached_at"`
+	Usage    TokenUsage `json:"usage"`
+}
+
+type cachedGenerateResponse struct {
+	Model    string     `json:"model"`
+	Text     string     `json:"text"`
+	Usage    TokenUsage `json:"usage"`
+	CachedAt time.Time  `json:"cached_at"`
+}
+
+type cachedEmbedResponse struct {
+	Model    string      `json:"model"`
+	Vectors  [][]float32 `json:"vectors"`
+	CachedAt time.Time   `json:"cached_at"`
+}
+
+// GenerateCacheKey derives the cache key for req. The key covers the model,
+// a hash of the system instruction, the prompt, the response format, the
+// JSON schema name/version, and the sampling parameters — any of which can
+// change the output.
+func GenerateCacheKey(req *GenerateRequest) string {
+	if req == nil {
+		return ""
+	}
+	instruction := sha256.Sum256([]byte(req.SystemInstruction))
+	return cacheKey(operationGenerate, struct {
+		Model         string         `json:"model"`
+		Instruction   string         `json:"instruction_sha256"`
+		Prompt        string         `json:"prompt"`
+		Format        ResponseFormat `json:"format,omitempty"`
+		SchemaName    string         `json:"schema_name,omitempty"`
+		SchemaVersion int            `json:"schema_version,omitempty"`
+		Temperature   *float32       `json:"temperature,omitempty"`
+		TopP          *float32       `json:"top_p,omitempty"`
+		TopK          *int           `json:"top_k,omitempty"`
+		MaxTokens     *int           `json:"max_tokens,omitempty"`
+	}{
+		Model:         req.Model,
+		Instruction:   hex.EncodeToString(instruction[:]),
+		Prompt:        req.Prompt,
+		Format:        req.Format,
+		SchemaName:    req.JSONSchema.Name,
+		SchemaVersion: req.JSONSchema.Version,
+		Temperature:   req.Temperature,
+		TopP:          req.TopP,
+		TopK:          req.TopK,
+		MaxTokens:     req.MaxTokens,
+	})
+}
+
+// EmbedCacheKey derives the cache key for req from the model, the requested
+// dimensions and the ordered inputs.
+func EmbedCacheKey(req *EmbedRequest) string {
+	if req == nil {
+		return ""
+	}
+	return cacheKey(operationEmbed, struct {
+		Model      string   `json:"model"`
+		Dimensions int      `json:"dimensions,omitempty"`
+		Input      []string `json:"input"`
+	}{
+		Model:      req.Model,
+		Dimensions: req.Dimentions,
+		Input:      req.Input,
+	})
+}
+
+func cacheKey(operation string, fields any) string {
+	raw, _ := json.Marshal(fields)
+	sum := sha256.Sum256(raw)
+	return cacheKeyPrefix + operation + ":" + hex.EncodeToString(sum[:])
+}
+
+type cachedGenerator struct {
+	base  Generator
+	store CacheStore
+	opts  CacheOptions
+}
+
+type cachedEmbedder struct {
+	base  Embedder
+	store CacheStore
+	opts  CacheOptions
+}
+
+type cachedProvider struct {
+	Generator
+	Embedder
+}
+
+// CacheGenerator wraps base with a read-through response cache.
+func CacheGenerator(base Generator, store CacheStore, opts CacheOptions) (Generator, error) {
+	if store == nil {
+		return nil, ErrCacheStoreMissing
+	}
+	if base == nil {
+		return base, nil
+	}
+	return &cachedGenerator{base: base, store: store, opts: opts}, nil
+}
+
+// CacheEmbedder wraps base with a read-through embedding cache.
+func CacheEmbedder(base Embedder, store CacheStore, opts CacheOptions) (Embedder, error) {
+	if store == nil {
+		return nil, ErrCacheStoreMissing
+	}
+	if base == nil {
+		return base, nil
+	}
+	return &cachedEmbedder{base: base, store: store, opts: opts}, nil
+}
+
+// CacheProvider wraps both Generate and Embed calls with a read-through cache.
+func CacheProvider(base Provider, store CacheStore, opts CacheOptions) (Provider, error) {
+	if store == nil {
+		return nil, ErrCacheStoreMissing
+	}
+	if base == nil {
+		return base, nil
+	}
+	return &cachedProvider{
+		Generator: &cachedGenerator{base: base, store: store, opts: opts},
+		Embedder:  &cachedEmbedder{base: base, store: store, opts: opts},
+	}, nil
+}
+
+func (g *cachedGenerator) Generate(ctx context.Context, req *GenerateRequest) (*GenerateResponse, error) {
+	if req == nil {
+		return g.base.Generate(ctx, req)
+	}
+
+	key := GenerateCacheKey(req)
+	if g.opts.Bypass || cacheBypassed(ctx) {
+		g.opts.Metrics.recordCacheLookup(ctx, g.opts.Provider, req.Model, operationGenerate, cacheResultBypass)
+	} else if raw, ok, err := g.store.Get(ctx, key); err != nil {
+		g.opts.Metrics.recordCacheLookup(ctx, g.opts.Provider, req.Model, operationGenerate, cacheResultError)
+	} else if ok {
+		var entry cachedGenerateResponse
+		if err := json.Unmarshal(raw, &entry); err == nil {
+			g.opts.Metrics.recordCacheLookup(ctx, g.opts.Provider, req.Model, operationGenerate, cacheResultHit)
+			return &GenerateResponse{
+				Model:      entry.Model,
+				Text:       entry.Text,
+				Raw:        CacheHit{Key: key, CachedAt: entry.CachedAt, Usage: entry.Usage},
+				JsonSchema: req.JSONSchema,
+			}, nil
+		}
+		g.opts.Metrics.recordCacheLookup(ctx, g.opts.Provider, req.Model, operationGenerate, cacheResultError)
+	} else {
+		g.opts.Metrics.recordCacheLookup(ctx, g.opts.Provider, req.Model, operationGenerate, cacheResultMiss)
+	}
+
+	resp, err := g.base.Generate(ctx, req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+
+	// Never pin a structured response that does not satisfy its schema;
+	// the next run should get a fresh attempt instead of the same failure.
+	if req.Format == ResponseFormatJsonSchema && req.JSONSchema.Schema != nil {
+		var probe map[string]any
+		if err := DecodeJsonSchema(req.JSONSchema, resp.Text, &probe); err != nil {
+			return resp, nil
+		}
+	}
+
+	raw, err := json.Marshal(cachedGenerateResponse{
+		Model:    resp.Model,
+		Text:     resp.Text,
+		Usage:    resp.Usage,
+		CachedAt: time.Now().UTC(),
+	})
+	if err == nil {
+		_ = g.store.Set(ctx, key, raw, g.opts.ttl())
+	}
+	return resp, nil
+}
+
+func (e *cachedEmbedder) Embed(ctx context.Context, req *EmbedRequest) (*EmbedResponse, error) {
+	if req == nil {
+		return e.base.Embed(ctx, req)
+	}
+
+	key := EmbedCacheKey(req)
+	if e.opts.Bypass || cacheBypassed(ctx) {
+		e.opts.Metrics.recordCacheLookup(ctx, e.opts.Provider, req.Model, operationEmbed, cacheResultBypass)
+	} else if raw, ok, err := e.store.Get(ctx, key); err != nil {
+		e.opts.Metrics.recordCacheLookup(ctx, e.opts.Provider, req.Model, operationEmbed, cacheResultError)
+	} else if ok {
+		var entry cachedEmbedResponse
+		if err := json.Unmarshal(raw, &entry); err == nil {
+			e.opts.Metrics.recordCacheLookup(ctx, e.opts.Provider, req.Model, operationEmbed, cacheResultHit)
+			return &EmbedResponse{
+				Model:   entry.Model,
+				Vectors: entry.Vectors,
+				Raw:     CacheHit{Key: key, CachedAt: entry.CachedAt},
+			}, nil
+		}
+		e.opts.Metrics.recordCacheLookup(ctx, e.opts.Provider, req.Model, operationEmbed, cacheResultError)
+	} else {
+		e.opts.Metrics.recordCacheLookup(ctx, e.opts.Provider, req.Model, operationEmbed, cacheResultMiss)
+	}
+
+	resp, err := e.base.Embed(ctx, req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+
+	raw, err := json.Marshal(cachedEmbedResponse{
+		Model:    resp.Model,
+		Vectors:  resp.Vectors,
+		CachedAt: time.Now().UTC(),
+	})
+	if err == nil {
+		_ = e.store.Set(ctx, key, raw, e.opts.ttl())
+	}
+	return resp, nil
+}
+
+func (m *Metrics) recordCacheLookup(ctx context.Context, provider, model, operation, result string) {
+	if m == nil || m.cache == nil {
+		return
+	}
+	m.cache.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("provider", normalizeLLMLabel(provider)),
+		attribute.String("model", normalizeLLMLabel(model)),
+		attribute.String("operation", operation),
+		attribute.String("result", result),
+	))
+}
+
+// MemoryCacheStore is a process-local CacheStore. Expired entries are dropped
+// lazily on Get. Intended for tests and single-process evaluation runs.
+type MemoryCacheStore struct {
+	mu      sync.Mutex
+	entries map[string]memoryCacheEntry
+	now     func() time.Time
+}
+
+type memoryCacheEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewMemoryCacheStore returns an empty in-memory store.
+func NewMemoryCacheStore() *MemoryCacheStore {
+	return &MemoryCacheStore{
+		entries: make(map[string]memoryCacheEntry),
+		now:     time.Now,
+	}
+}
+
+func (s *MemoryCacheStore) Get(_ context.Context, key string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	if !entry.expiresAt.IsZero() && !s.now().Before(entry.expiresAt) {
+		delete(s.entries, key)
+		return nil, false, nil
+	}
+	return entry.value, true, nil
+}
+
+func (s *MemoryCacheStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	if key == "" {
+		return fmt.Errorf("llm cache: empty key")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry := memoryCacheEntry{value: append([]byte(nil), value...)}
+	if ttl > 0 {
+		entry.expiresAt = s.now().Add(ttl)
+	}
+	s.entries[key] = entry
+	return nil
+}
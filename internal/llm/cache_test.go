@@ -0,0 +1,193 @@
+package llm_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/llm"
+	llmmocks "github.com/ChiaYuChang/prism/internal/llm/mocks"
+	pkgschema "github.com/ChiaYuChang/prism/pkg/schema"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/metric"
+)
+
+func TestCacheGeneratorServesRepeatedRequestFromCache(t *testing.T) {
+	reader := metric.NewManualReader()
+	meterProvider := metric.NewMeterProvider(metric.WithReader(reader))
+	t.Cleanup(func() { require.NoError(t, meterProvider.Shutdown(context.Background())) })
+	metrics, err := llm.NewMetrics(meterProvider.Meter("test"))
+	require.NoError(t, err)
+
+	base := llmmocks.NewMockGenerator(t)
+	req := llm.NewGenerateRequest("model-a", "system", "prompt")
+	base.EXPECT().Generate(mock.Anything, req).Return(&llm.GenerateResponse{
+		Model: "model-a-001",
+		Text:  "answer",
+		Usage: llm.TokenUsage{Input: 10, Output: 5, Total: 15},
+	}, nil).Once()
+
+	cached, err := llm.CacheGenerator(base, llm.NewMemoryCacheStore(), llm.CacheOptions{
+		Metrics:  metrics,
+		Provider: "llm.test",
+	})
+	require.NoError(t, err)
+
+	first, err := cached.Generate(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, "answer", first.Text)
+	require.Equal(t, 15, first.Usage.Total)
+
+	second, err := cached.Generate(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, "answer", second.Text)
+	require.Equal(t, "model-a-001", second.Model)
+	require.Zero(t, second.Usage.Total)
+	hit, ok := second.Raw.(llm.CacheHit)
+	require.True(t, ok)
+	require.Equal(t, llm.GenerateCacheKey(req), hit.Key)
+	require.Equal(t, 15, hit.Usage.Total)
+
+	rm := collectLLMMetrics(t, reader)
+	attrs := map[string]string{
+		"provider":  "llm.test",
+		"model":     "model-a",
+		"operation": "generate",
+	}
+	attrs["result"] = "miss"
+	require.Equal(t, int64(1), llmCounterValue(t, rm, "prism.llm.cache.lookups", attrs))
+	attrs["result"] = "hit"
+	require.Equal(t, int64(1), llmCounterValue(t, rm, "prism.llm.cache.lookups", attrs))
+}
+
+func TestCacheGeneratorBypassRefreshesEntry(t *testing.T) {
+	cases := []struct {
+		name string
+		opts llm.CacheOptions
+		ctx  context.Context
+	}{
+		{name: "Option", opts: llm.CacheOptions{Bypass: true}, ctx: context.Background()},
+		{name: "Context", ctx: llm.WithCacheBypass(context.Background())},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			store := llm.NewMemoryCacheStore()
+			req := llm.NewGenerateRequest("model-a", "system", "prompt")
+
+			base := llmmocks.NewMockGenerator(t)
+			base.EXPECT().Generate(mock.Anything, req).
+				Return(&llm.GenerateResponse{Text: "fresh"}, nil).Twice()
+
+			bypassed, err := llm.CacheGenerator(base, store, c.opts)
+			require.NoError(t, err)
+			for range 2 {
+				resp, err := bypassed.Generate(c.ctx, req)
+				require.NoError(t, err)
+				require.Equal(t, "fresh", resp.Text)
+			}
+
+			// The bypassed calls still wrote the fresh response back.
+			_, ok, err := store.Get(context.Background(), llm.GenerateCacheKey(req))
+			require.NoError(t, err)
+			require.True(t, ok)
+		})
+	}
+}
+
+func TestCacheGeneratorSkipsSchemaInvalidResponses(t *testing.T) {
+	type answer struct {
+		ID int `json:"id"`
+	}
+	store := llm.NewMemoryCacheStore()
+	req := llm.NewGenerateRequest("model-a", "system", "prompt")
+	req.Format = llm.ResponseFormatJsonSchema
+	req.JSONSchema = pkgschema.NewSkeleton[answer]("answer", 1)
+
+	base := llmmocks.NewMockGenerator(t)
+	base.EXPECT().Generate(mock.Anything, req).
+		Return(&llm.GenerateResponse{Text: `{"id":"wrong"}`}, nil).Once()
+
+	cached, err := llm.CacheGenerator(base, store, llm.CacheOptions{})
+	require.NoError(t, err)
+	_, err = cached.Generate(context.Background(), req)
+	require.NoError(t, err)
+
+	_, ok, err := store.Get(context.Background(), llm.GenerateCacheKey(req))
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestCacheGeneratorDoesNotCacheErrors(t *testing.T) {
+	store := llm.NewMemoryCacheStore()
+	req := llm.NewGenerateRequest("model-a", "system", "prompt")
+	wantErr := errors.New("provider failed")
+
+	base := llmmocks.NewMockGenerator(t)
+	base.EXPECT().Generate(mock.Anything, req).Return(nil, wantErr).Once()
+
+	cached, err := llm.CacheGenerator(base, store, llm.CacheOptions{})
+	require.NoError(t, err)
+	_, err = cached.Generate(context.Background(), req)
+	require.ErrorIs(t, err, wantErr)
+
+	_, ok, err := store.Get(context.Background(), llm.GenerateCacheKey(req))
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestGenerateCacheKeyCoversPromptInputs(t *testing.T) {
+	base := llm.NewGenerateRequest("model-a", "system", "prompt")
+	key := llm.GenerateCacheKey(base)
+
+	variants := map[string]func(*llm.GenerateRequest){
+		"Model":         func(r *llm.GenerateRequest) { r.Model = "model-b" },
+		"System":        func(r *llm.GenerateRequest) { r.SystemInstruction = "other system" },
+		"Prompt":        func(r *llm.GenerateRequest) { r.Prompt = "other prompt" },
+		"SchemaName":    func(r *llm.GenerateRequest) { r.JSONSchema.Name = "other" },
+		"SchemaVersion": func(r *llm.GenerateRequest) { r.JSONSchema.Version = 2 },
+	}
+	for name, mutate := range variants {
+		t.Run(name, func(t *testing.T) {
+			req := llm.NewGenerateRequest("model-a", "system", "prompt")
+			mutate(req)
+			require.NotEqual(t, key, llm.GenerateCacheKey(req))
+		})
+	}
+	require.Equal(t, key, llm.GenerateCacheKey(llm.NewGenerateRequest("model-a", "system", "prompt")))
+}
+
+func TestCacheEmbedderServesRepeatedRequestFromCache(t *testing.T) {
+	base := llmmocks.NewMockEmbedder(t)
+	req := &llm.EmbedRequest{Model: "embed-a", Input: []string{"a", "b"}}
+	base.EXPECT().Embed(mock.Anything, req).Return(&llm.EmbedResponse{
+		Model:   "embed-a",
+		Vectors: [][]float32{{0.1, 0.2}, {0.3, 0.4}},
+	}, nil).Once()
+
+	cached, err := llm.CacheEmbedder(base, llm.NewMemoryCacheStore(), llm.CacheOptions{})
+	require.NoError(t, err)
+
+	for range 2 {
+		resp, err := cached.Embed(context.Background(), req)
+		require.NoError(t, err)
+		require.Equal(t, [][]float32{{0.1, 0.2}, {0.3, 0.4}}, resp.Vectors)
+	}
+}
+
+func TestMemoryCacheStoreExpiresEntries(t *testing.T) {
+	store := llm.NewMemoryCacheStore()
+	require.NoError(t, store.Set(context.Background(), "k", []byte("v"), time.Millisecond))
+	time.Sleep(5 * time.Millisecond)
+
+	_, ok, err := store.Get(context.Background(), "k")
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestCacheGeneratorRequiresStore(t *testing.T) {
+	_, err := llm.CacheGenerator(llmmocks.NewMockGenerator(t), nil, llm.CacheOptions{})
+	require.ErrorIs(t, err, llm.ErrCacheStoreMissing)
+}
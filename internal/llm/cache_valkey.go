@@ -0,0 +1,44 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ValkeyCacheStore stores encoded LLM responses in Valkey/Redis with a
+// per-entry TTL. Keys are namespaced by Prefix so several environments can
+// share one Valkey database.
+type ValkeyCacheStore struct {
+	Client *redis.Client
+	Prefix string
+}
+
+// NewValkeyCacheStore validates parameters and returns a ready store.
+func NewValkeyCacheStore(client *redis.Client, prefix string) (*ValkeyCacheStore, error) {
+	if client == nil {
+		return nil, fmt.Errorf("%w: valkey client", ErrCacheStoreMissing)
+	}
+	return &ValkeyCacheStore{Client: client, Prefix: prefix}, nil
+}
+
+func (s *ValkeyCacheStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	raw, err := s.Client.Get(ctx, s.Prefix+key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("valkey get: %w", err)
+	}
+	return raw, true, nil
+}
+
+func (s *ValkeyCacheStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if err := s.Client.Set(ctx, s.Prefix+key, value, ttl).Err(); err != nil {
+		return fmt.Errorf("valkey set: %w", err)
+	}
+	return nil
+}
@@ -1,6 +1,6 @@
 // Package factory builds LLM providers from an appconfig.LLMConfig.
 // Lives in a subpackage so it can import the concrete provider packages
-// (gemini / openai / ollama) without creating an import cycle on the
+// (gemini / openai / ollama / anthropic / openaicompat) without creating an import cycle on the
 // parent llm package.
 package factory
 
@@ -8,15 +8,18 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"math"
 	"net/http"
 	"time"
 
 	"github.com/ChiaYuChang/prism/internal/appconfig"
 	"github.com/ChiaYuChang/prism/internal/infra"
 	"github.com/ChiaYuChang/prism/internal/llm"
+	"github.com/ChiaYuChang/prism/internal/llm/anthropic"
 	"github.com/ChiaYuChang/prism/internal/llm/gemini"
 	"github.com/ChiaYuChang/prism/internal/llm/ollama"
 	"github.com/ChiaYuChang/prism/internal/llm/openai"
+	"github.com/ChiaYuChang/prism/internal/llm/openaicompat"
 	"github.com/go-playground/mold/v4"
 	"github.com/go-playground/validator/v10"
 	"go.opentelemetry.io/otel"
@@ -28,29 +31,236 @@ const defaultTimeout = 30 * time.Second
 // Promoted from cmd/worker/planner so the same construction path is shared
 // by every command that needs a generator (planner, collector fallback,
 // recover, parse-probe).
-func NewGenerator(ctx context.Context, cfg appconfig.LLMConfig, logger *slog.Logger) (llm.Generator, error) {
-	return NewProvider(ctx, cfg, logger)
+func NewGenerator(ctx context.Context, cfg appconfig.LLMConfig, logger *slog.Logger, opts ...Option) (llm.Generator, error) {
+	return NewProvider(ctx, cfg, logger, opts...)
 }
 
 // NewEmbedder instantiates an instrumented llm.Embedder from the supplied LLMConfig.
-func NewEmbedder(ctx context.Context, cfg appconfig.LLMConfig, logger *slog.Logger) (llm.Embedder, error) {
-	return NewProvider(ctx, cfg, logger)
+func NewEmbedder(ctx context.Context, cfg appconfig.LLMConfig, logger *slog.Logger, opts ...Option) (llm.Embedder, error) {
+	return NewProvider(ctx, cfg, logger, opts...)
+}
+
+// Option supplies process-level dependencies that do not belong in
+// LLMConfig.
+type Option func(*options)
+
+type options struct {
+	ledger    llm.UsageLedger
+	component string
+}
+
+// WithUsageLedger hands NewProvider the ledger used when cfg.Ledger.Enabled.
+// component names the caller in ledger rows and budget checks, e.g.
+// "planner".
+func WithUsageLedger(ledger llm.UsageLedger, component string) Option {
+	return func(o *options) {
+		o.ledger = ledger
+		o.component = component
+	}
 }
 
 // NewProvider instantiates an instrumented llm.Provider from the supplied LLMConfig.
-func NewProvider(ctx context.Context, cfg appconfig.LLMConfig, logger *slog.Logger) (llm.Provider, error) {
-	provider, err := newProvider(ctx, cfg, logger)
-	if err != nil {
-		return nil, err
+//
+// When cfg.Fallbacks or a breaker threshold is configured, the primary and
+// every fallback provider are instrumented individually and combined into
+// an llm fallback chain, so prism.llm.requests still reports each upstream
+// call while prism.llm.served reports which entry answered.
+//
+// When cfg.Ledger.Enabled and a ledger is supplied via WithUsageLedger,
+// every upstream call in the chain is priced and recorded, and refused once
+// the component's budget is spent.
+//
+// When cfg.Cache.Enabled every chain entry gets its own read-through
+// response cache inside its accounting, so cache hits never show up as
+// provider requests or tokens in the prism.llm.* metrics, and a response
+// served by a fallback is only ever cached under the fallback's model.
+func NewProvider(ctx context.Context, cfg appconfig.LLMConfig, logger *slog.Logger, opts ...Option) (llm.Provider, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
 	}
 	metrics, err := llm.NewMetrics(otel.Meter("prism.llm"))
 	if err != nil {
 		return nil, fmt.Errorf("create LLM metrics: %w", err)
 	}
-	return llm.InstrumentProvider(provider, metrics, "llm."+cfg.Provider), nil
+	account, err := newAccounting(cfg, o, logger)
+	if err != nil {
+		return nil, err
+	}
+	cache, err := newCaching(ctx, cfg, metrics, logger)
+	if err != nil {
+		return nil, err
+	}
+	wrap := func(p llm.Provider, label string) (llm.Provider, error) {
+		accounted, err := account(p, label)
+		if err != nil {
+			return nil, err
+		}
+		return cache(accounted, label)
+	}
+	return newChain(ctx, cfg, metrics, wrap, logger)
 }
 
-func newProvider(ctx context.Context, cfg appconfig.LLMConfig, logger *slog.Logger) (llm.Provider, error) {
+// entryWrapper decorates one instrumented chain entry.
+type entryWrapper func(p llm.Provider, label string) (llm.Provider, error)
+
+// newAccounting returns the entryWrapper that adds usage accounting, or a
+// passthrough when the ledger is disabled or unavailable.
+func newAccounting(cfg appconfig.LLMConfig, o options, logger *slog.Logger) (entryWrapper, error) {
+	passthrough := func(p llm.Provider, _ string) (llm.Provider, error) { return p, nil }
+	if !cfg.Ledger.Enabled {
+		return passthrough, nil
+	}
+	if o.ledger == nil {
+		logger.Warn("llm ledger enabled but this command has no usage ledger; accounting disabled")
+		return passthrough, nil
+	}
+	if o.component == "" {
+		return nil, fmt.Errorf("llm ledger requires a component name")
+	}
+
+	prices := make(llm.PriceTable, len(cfg.Ledger.Prices))
+	for _, p := range cfg.Ledger.Prices {
+		prices[p.Model] = llm.ModelPrice{Input: p.Input, Output: p.Output, CachedInput: p.Cached}
+	}
+	budget := llm.Budget{
+		DailyMicros:   usdToMicros(cfg.Ledger.Daily),
+		MonthlyMicros: usdToMicros(cfg.Ledger.Monthly),
+	}
+	logger.Info("llm usage ledger enabled",
+		"component", o.component,
+		"priced_models", len(prices),
+		"daily_usd", cfg.Ledger.Daily,
+		"monthly_usd", cfg.Ledger.Monthly)
+	return func(p llm.Provider, label string) (llm.Provider, error) {
+		return llm.AccountProvider(p, o.ledger, llm.AccountingOptions{
+			Provider:  label,
+			Component: o.component,
+			Prices:    prices,
+			Budget:    budget,
+			Logger:    logger,
+		})
+	}, nil
+}
+
+// newCaching returns the entryWrapper that adds the response cache, or a
+// passthrough when the cache is disabled. All entries share one store; the
+// cache key covers the model, and every fallback entry calls with its own.
+func newCaching(ctx context.Context, cfg appconfig.LLMConfig, metrics *llm.Metrics, logger *slog.Logger) (entryWrapper, error) {
+	if !cfg.Cache.Enabled {
+		return func(p llm.Provider, _ string) (llm.Provider, error) { return p, nil }, nil
+	}
+	store, err := newCacheStore(ctx, cfg.Cache)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("llm response cache enabled",
+		"provider", cfg.Provider,
+		"backend", cfg.Cache.Backend,
+		"ttl", cfg.Cache.TTL,
+		"bypass", cfg.Cache.Bypass)
+	return func(p llm.Provider, label string) (llm.Provider, error) {
+		return llm.CacheProvider(p, store, llm.CacheOptions{
+			TTL:      cfg.Cache.TTL,
+			Bypass:   cfg.Cache.Bypass,
+			Metrics:  metrics,
+			Provider: label,
+		})
+	}, nil
+}
+
+func usdToMicros(usd float64) int64 {
+	return int64(math.Round(usd * 1e6))
+}
+
+// newChain builds the primary provider and, when configured, the fallback
+// chain around it. wrap is applied to every instrumented entry, so the
+// ledger sees each upstream call under the provider that served it.
+func newChain(ctx context.Context, cfg appconfig.LLMConfig, metrics *llm.Metrics, wrap entryWrapper, logger *slog.Logger) (llm.Provider, error) {
+	primaryCfg := cfg.Primary()
+	primary, err := newProvider(ctx, primaryCfg, logger)
+	if err != nil {
+		return nil, err
+	}
+	label := "llm." + cfg.Provider
+	instrumented, err := wrap(llm.InstrumentProvider(primary, metrics, label), label)
+	if err != nil {
+		return nil, err
+	}
+	if !cfg.Chained() {
+		return instrumented, nil
+	}
+
+	entries := []llm.FallbackEntry{{
+		Name:             label,
+		Provider:         instrumented,
+		StructuredOutput: structuredOutput(primaryCfg),
+	}}
+	for _, fb := range cfg.Fallbacks {
+		p, err := newProvider(ctx, fb, logger)
+		if err != nil {
+			return nil, fmt.Errorf("build LLM fallback %s/%s: %w", fb.Provider, fb.Model, err)
+		}
+		name := "llm." + fb.Provider
+		wrapped, err := wrap(llm.InstrumentProvider(p, metrics, name), name)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, llm.FallbackEntry{
+			Name:             name,
+			Provider:         wrapped,
+			Model:            fb.Model,
+			StructuredOutput: structuredOutput(fb),
+		})
+	}
+	logger.Info("llm fallback chain enabled",
+		"primary", cfg.Provider,
+		"fallbacks", len(cfg.Fallbacks),
+		"breaker_threshold", cfg.Breaker.Threshold)
+	return llm.NewFallbackProvider(entries, llm.FallbackOptions{
+		Retry: llm.RetryPolicy{
+			MaxAttempts:    cfg.Retry.Attempts,
+			InitialBackoff: cfg.Retry.Backoff,
+			MaxBackoff:     cfg.Retry.Cap,
+		},
+		Breaker: llm.BreakerPolicy{
+			FailureThreshold: cfg.Breaker.Threshold,
+			Cooldown:         cfg.Breaker.Cooldown,
+		},
+		Metrics: metrics,
+		Logger:  logger,
+	})
+}
+
+// newCacheStore builds the CacheStore selected by cfg.Backend. The Valkey
+// client lives for the rest of the process, like the provider it backs.
+func newCacheStore(ctx context.Context, cfg appconfig.LLMCacheConfig) (llm.CacheStore, error) {
+	switch cfg.Backend {
+	case "", "memory":
+		return llm.NewMemoryCacheStore(), nil
+	case "valkey":
+		if cfg.Valkey == nil {
+			return nil, fmt.Errorf("llm cache backend valkey requires a valkey config")
+		}
+		client, err := infra.NewValkeyClient(ctx, infra.ValkeyClientConfig{
+			Addr:           cfg.Valkey.Addr(),
+			Username:       cfg.Valkey.Username,
+			Password:       cfg.Valkey.Password,
+			DB:             cfg.Valkey.DB,
+			ClientName:     cfg.Valkey.ClientName,
+			TracingEnabled: cfg.Valkey.TracingEnabled,
+			MetricsEnabled: cfg.Valkey.MetricsEnabled,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("connect llm cache valkey: %w", err)
+		}
+		return llm.NewValkeyCacheStore(client, cfg.Prefix)
+	default:
+		return nil, fmt.Errorf("unsupported LLM cache backend: %s", cfg.Backend)
+	}
+}
+
+func newProvider(ctx context.Context, cfg appconfig.LLMFallbackConfig, logger *slog.Logger) (llm.Provider, error) {
 	timeout := cfg.Timeout
 	if timeout == 0 {
 		timeout = defaultTimeout
@@ -75,7 +285,27 @@ func newProvider(ctx context.Context, cfg appconfig.LLMConfig, logger *slog.Logg
 		return ollama.New(ctx, logger, infra.Tracer(), v, m, hc, ollama.Config{
 			Timeout: timeout,
 		})
+	case "anthropic":
+		return anthropic.New(ctx, logger, infra.Tracer(), v, m, hc, anthropic.Config{
+			APIKey:  cfg.Key,
+			Timeout: timeout,
+		})
+	case "openai-compatible":
+		return openaicompat.New(ctx, logger, infra.Tracer(), v, m, hc, openaicompat.Config{
+			BaseURL:          cfg.URL,
+			APIKey:           cfg.Key,
+			AuthHeader:       cfg.Header,
+			Timeout:          timeout,
+			StructuredOutput: structuredOutput(cfg),
+			Embeddings:       cfg.Embeddings,
+		})
 	default:
 		return nil, fmt.Errorf("unsupported LLM provider: %s", cfg.Provider)
 	}
 }
+
+// structuredOutput reports whether cfg's provider honours JSON schema
+// requests. Unset means yes.
+func structuredOutput(cfg appconfig.LLMFallbackConfig) bool {
+	return cfg.StructuredOutput == nil || *cfg.StructuredOutput
+}
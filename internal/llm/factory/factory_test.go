@@ -4,11 +4,19 @@ import (
 	"context"
 	"io"
 	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 
 	"github.com/ChiaYuChang/prism/internal/appconfig"
+	"github.com/ChiaYuChang/prism/internal/llm"
 	llmfactory "github.com/ChiaYuChang/prism/internal/llm/factory"
+	"github.com/ChiaYuChang/prism/internal/repo"
+	repomocks "github.com/ChiaYuChang/prism/internal/repo/mocks"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -37,7 +45,153 @@ func TestNewProvider_UnsupportedProvider(t *testing.T) {
 	assert.ErrorContains(t, err, "unsupported LLM provider")
 }
 
+func TestNewProvider_UnsupportedFallbackProvider(t *testing.T) {
+	cfg := appconfig.LLMConfig{
+		Provider:  "ollama",
+		Model:     "x",
+		Fallbacks: []appconfig.LLMFallbackConfig{{Provider: "not-a-real-provider", Model: "y"}},
+	}
+	_, err := llmfactory.NewProvider(context.Background(), cfg, discardLogger())
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "build LLM fallback not-a-real-provider/y")
+}
+
+func TestNewProvider_OpenAICompatibleRequiresURL(t *testing.T) {
+	cfg := appconfig.LLMConfig{Provider: "openai-compatible", Model: "Qwen/Qwen2.5-7B-Instruct"}
+	_, err := llmfactory.NewProvider(context.Background(), cfg, discardLogger())
+	require.ErrorIs(t, err, llm.ErrCfgValError)
+}
+
+func TestNewProvider_AnthropicRequiresKey(t *testing.T) {
+	cfg := appconfig.LLMConfig{Provider: "anthropic", Model: "claude-haiku-4-5"}
+	_, err := llmfactory.NewProvider(context.Background(), cfg, discardLogger())
+	require.ErrorIs(t, err, llm.ErrCfgValError)
+}
+
+func TestNewProvider_OpenAICompatibleFallback(t *testing.T) {
+	structured := false
+	cfg := appconfig.LLMConfig{
+		Provider: "ollama",
+		Model:    "x",
+		Fallbacks: []appconfig.LLMFallbackConfig{{
+			Provider:         "openai-compatible",
+			Model:            "Qwen/Qwen2.5-7B-Instruct",
+			URL:              "http://vllm:8000/v1",
+			StructuredOutput: &structured,
+		}},
+	}
+	p, err := llmfactory.NewProvider(context.Background(), cfg, discardLogger())
+	require.NoError(t, err)
+	require.NotNil(t, p)
+}
+
+func TestNewProvider_LedgerRequiresComponent(t *testing.T) {
+	cfg := appconfig.LLMConfig{
+		Provider: "ollama",
+		Model:    "x",
+		Ledger:   appconfig.LLMLedgerConfig{Enabled: true},
+	}
+	ledger := llmfactory.NewRepoLedger(repomocks.NewMockLLMUsage(t))
+	_, err := llmfactory.NewProvider(context.Background(), cfg, discardLogger(),
+		llmfactory.WithUsageLedger(ledger, ""))
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "requires a component name")
+}
+
+func TestRepoLedger_RecordUsage(t *testing.T) {
+	batchID := uuid.New()
+	usage := repomocks.NewMockLLMUsage(t)
+	usage.EXPECT().Record(mock.Anything, repo.CreateLLMUsageParams{
+		Provider:     "llm.gemini",
+		Model:        "gemini-2.0-flash",
+		Operation:    "generate",
+		Component:    "planner",
+		InputTokens:  100,
+		OutputTokens: 20,
+		CachedTokens: 40,
+		TotalTokens:  120,
+		CostMicros:   18,
+		TraceID:      "trace-1",
+		BatchID:      &batchID,
+	}).Return(repo.LLMUsageRecord{ID: 1}, nil).Once()
+
+	ledger := llmfactory.NewRepoLedger(usage)
+	require.NoError(t, ledger.RecordUsage(context.Background(), llm.UsageRecord{
+		Provider:   "llm.gemini",
+		Model:      "gemini-2.0-flash",
+		Operation:  "generate",
+		Component:  "planner",
+		Usage:      llm.TokenUsage{Input: 100, Output: 20, Cached: 40, Total: 120},
+		CostMicros: 18,
+		TraceID:    "trace-1",
+		BatchID:    batchID,
+	}))
+}
+
 // Provider construction success paths are covered by the per-provider
 // unit tests in internal/llm/{gemini,openai,ollama}. This file only guards
 // the dispatch / unsupported-provider branch — the actual provider code
 // requires real API keys / endpoints that don't belong in unit tests.
+
+// chatServer answers /chat/completions with text, or with a 400 while down
+// is set, and counts the requests it receives.
+func chatServer(t *testing.T, text string, down *atomic.Bool, calls *atomic.Int32) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		if down != nil && down.Load() {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, `{"error":{"message":"unavailable","type":"invalid_request_error"}}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"id":"c","object":"chat.completion","created":0,"model":"m",`+
+			`"choices":[{"index":0,"message":{"role":"assistant","content":"`+text+`"},"finish_reason":"stop"}],`+
+			`"usage":{"prompt_tokens":1,"completion_tokens":1,"total_tokens":2}}`)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestNewProvider_CacheDoesNotPinFallbackResponse(t *testing.T) {
+	var down atomic.Bool
+	var primaryCalls, fallbackCalls atomic.Int32
+	down.Store(true)
+	primary := chatServer(t, "primary", &down, &primaryCalls)
+	fallback := chatServer(t, "fallback", nil, &fallbackCalls)
+
+	cfg := appconfig.LLMConfig{
+		Provider: "openai-compatible",
+		Model:    "primary-model",
+		URL:      primary.URL + "/v1",
+		Retry:    appconfig.LLMRetryConfig{Attempts: 1},
+		Cache:    appconfig.LLMCacheConfig{Enabled: true, Backend: "memory"},
+		Fallbacks: []appconfig.LLMFallbackConfig{{
+			Provider: "openai-compatible",
+			Model:    "fallback-model",
+			URL:      fallback.URL + "/v1",
+		}},
+	}
+	p, err := llmfactory.NewProvider(context.Background(), cfg, discardLogger())
+	require.NoError(t, err)
+
+	req := func() *llm.GenerateRequest {
+		return &llm.GenerateRequest{Model: "primary-model", Prompt: "Say hi.", Format: llm.ResponseFormatText}
+	}
+	resp, err := p.Generate(context.Background(), req())
+	require.NoError(t, err)
+	assert.Equal(t, "fallback", resp.Text)
+
+	// The fallback answer is cached under the fallback model only, so the
+	// recovered primary is asked again instead of replaying it.
+	down.Store(false)
+	resp, err = p.Generate(context.Background(), req())
+	require.NoError(t, err)
+	assert.Equal(t, "primary", resp.Text)
+
+	resp, err = p.Generate(context.Background(), req())
+	require.NoError(t, err)
+	assert.Equal(t, "primary", resp.Text)
+	assert.Equal(t, int32(2), primaryCalls.Load())
+	assert.Equal(t, int32(1), fallbackCalls.Load())
+}
@@ -0,0 +1,43 @@
+package factory
+
+import (
+	"context"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/llm"
+	"github.com/ChiaYuChang/prism/internal/repo"
+	"github.com/google/uuid"
+)
+
+// NewRepoLedger adapts the Postgres llm_usage table to llm.UsageLedger.
+func NewRepoLedger(usage repo.LLMUsage) llm.UsageLedger {
+	return repoLedger{usage: usage}
+}
+
+type repoLedger struct {
+	usage repo.LLMUsage
+}
+
+func (l repoLedger) RecordUsage(ctx context.Context, record llm.UsageRecord) error {
+	params := repo.CreateLLMUsageParams{
+		Provider:     record.Provider,
+		Model:        record.Model,
+		Operation:    record.Operation,
+		Component:    record.Component,
+		InputTokens:  int32(record.Usage.Input),
+		OutputTokens: int32(record.Usage.Output),
+		CachedTokens: int32(record.Usage.Cached),
+		TotalTokens:  int32(record.Usage.Total),
+		CostMicros:   record.CostMicros,
+		TraceID:      record.TraceID,
+	}
+	if record.BatchID != uuid.Nil {
+		params.BatchID = &record.BatchID
+	}
+	_, err := l.usage.Record(ctx, params)
+	return err
+}
+
+func (l repoLedger) SpentSince(ctx context.Context, component string, since time.Time) (int64, error) {
+	return l.usage.SumCostSince(ctx, component, since)
+}
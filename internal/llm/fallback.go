@@ -0,0 +1,263 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var (
+	// ErrNoProviderAvailable is returned when every entry of a fallback
+	// chain was skipped or failed. The per-entry errors are joined to it.
+	ErrNoProviderAvailable = errors.New("no LLM provider available")
+	// ErrStructuredOutputUnsupported marks a chain entry skipped because
+	// the request needs a JSON schema response the provider cannot produce.
+	ErrStructuredOutputUnsupported = errors.New("provider does not support structured output")
+	// ErrEmptyFallbackChain is returned when a fallback chain has no entries.
+	ErrEmptyFallbackChain = errors.New("llm fallback chain is empty")
+)
+
+const (
+	defaultRetryAttempts  = 3
+	defaultInitialBackoff = 500 * time.Millisecond
+	defaultMaxBackoff     = 10 * time.Second
+)
+
+// FallbackEntry is one provider in a fallback chain.
+type FallbackEntry struct {
+	// Name labels metrics and logs, e.g. "llm.gemini".
+	Name     string
+	Provider Provider
+	// Model replaces GenerateRequest.Model when set. Leave it empty on the
+	// primary entry so callers keep control of the model they asked for.
+	Model string
+	// StructuredOutput reports whether the provider/model honours
+	// ResponseFormatJsonSchema. Entries without it are skipped for
+	// structured requests.
+	StructuredOutput bool
+}
+
+// RetryPolicy configures retries against a single chain entry. Only errors
+// classified by IsRetryable are retried.
+type RetryPolicy struct {
+	// MaxAttempts counts the first call. Zero means 3; one disables retries.
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// FallbackOptions tunes NewFallbackProvider.
+type FallbackOptions struct {
+	Retry   RetryPolicy
+	Breaker BreakerPolicy
+	Metrics *Metrics
+	Logger  *slog.Logger
+}
+
+type fallbackEntry struct {
+	FallbackEntry
+	breaker *circuitBreaker
+}
+
+type fallbackProvider struct {
+	entries []*fallbackEntry
+	retry   RetryPolicy
+	metrics *Metrics
+	logger  *slog.Logger
+}
+
+// NewFallbackProvider returns a Provider that tries entries in order.
+//
+// Each entry has its own circuit breaker and is retried with exponential
+// backoff on retryable errors before the chain moves on. Structured
+// requests skip entries without StructuredOutput, and a response that fails
+// GenerateRequest.JSONSchema validation sends the call to the next entry;
+// if no entry produces a valid response the first invalid one is returned
+// so callers see the same decode error they would without a chain.
+//
+// Embed only uses the first entry: vectors from different models live in
+// different spaces and must not be mixed silently.
+func NewFallbackProvider(entries []FallbackEntry, opts FallbackOptions) (Provider, error) {
+	if len(entries) == 0 {
+		return nil, ErrEmptyFallbackChain
+	}
+	chain := &fallbackProvider{
+		entries: make([]*fallbackEntry, 0, len(entries)),
+		retry:   opts.Retry,
+		metrics: opts.Metrics,
+		logger:  opts.Logger,
+	}
+	if chain.logger == nil {
+		chain.logger = slog.New(slog.DiscardHandler)
+	}
+	for i, e := range entries {
+		if e.Provider == nil {
+			return nil, fmt.Errorf("llm fallback entry %d (%s): provider is nil", i, e.Name)
+		}
+		chain.entries = append(chain.entries, &fallbackEntry{
+			FallbackEntry: e,
+			breaker:       newCircuitBreaker(opts.Breaker, e.Name, opts.Metrics),
+		})
+	}
+	return chain, nil
+}
+
+func (p *fallbackProvider) Generate(ctx context.Context, req *GenerateRequest) (*GenerateResponse, error) {
+	if req == nil {
+		return p.entries[0].Provider.Generate(ctx, req)
+	}
+
+	structured := req.Format == ResponseFormatJsonSchema
+	var errs []error
+	var invalid *GenerateResponse
+	for i, e := range p.entries {
+		if structured && !e.StructuredOutput {
+			errs = append(errs, fmt.Errorf("%s: %w", e.Name, ErrStructuredOutputUnsupported))
+			continue
+		}
+		if !e.breaker.allow(ctx) {
+			errs = append(errs, fmt.Errorf("%s: %w", e.Name, ErrCircuitOpen))
+			continue
+		}
+
+		call := req
+		if e.Model != "" && e.Model != req.Model {
+			clone := *req
+			clone.Model = e.Model
+			call = &clone
+		}
+
+		resp, err := retryCall(ctx, p.retry, func() (*GenerateResponse, error) {
+			return e.Provider.Generate(ctx, call)
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				e.breaker.release()
+				return nil, err
+			}
+			p.recordFailure(ctx, e, err)
+			errs = append(errs, fmt.Errorf("%s: %w", e.Name, err))
+			continue
+		}
+		e.breaker.success(ctx)
+
+		if structured && call.JSONSchema.Schema != nil && resp != nil {
+			var probe map[string]any
+			if err := DecodeJsonSchema(call.JSONSchema, resp.Text, &probe); err != nil {
+				p.logger.WarnContext(ctx, "llm fallback entry returned schema-invalid response",
+					slog.String("provider", e.Name),
+					slog.String("model", call.Model),
+					slog.String("error", err.Error()))
+				errs = append(errs, fmt.Errorf("%s: %w", e.Name, err))
+				if invalid == nil {
+					invalid = resp
+				}
+				continue
+			}
+		}
+
+		p.metrics.recordServed(ctx, e.Name, call.Model, operationGenerate, i)
+		return resp, nil
+	}
+
+	if invalid != nil {
+		return invalid, nil
+	}
+	return nil, fmt.Errorf("%w: %w", ErrNoProviderAvailable, errors.Join(errs...))
+}
+
+func (p *fallbackProvider) Embed(ctx context.Context, req *EmbedRequest) (*EmbedResponse, error) {
+	e := p.entries[0]
+	if !e.breaker.allow(ctx) {
+		return nil, fmt.Errorf("%s: %w", e.Name, ErrCircuitOpen)
+	}
+	resp, err := retryCall(ctx, p.retry, func() (*EmbedResponse, error) {
+		return e.Provider.Embed(ctx, req)
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			e.breaker.release()
+			return nil, err
+		}
+		p.recordFailure(ctx, e, err)
+		return nil, err
+	}
+	e.breaker.success(ctx)
+	if req != nil {
+		p.metrics.recordServed(ctx, e.Name, req.Model, operationEmbed, 0)
+	}
+	return resp, nil
+}
+
+// recordFailure only counts outage-like errors against the breaker; a bad
+// request says nothing about provider health.
+func (p *fallbackProvider) recordFailure(ctx context.Context, e *fallbackEntry, err error) {
+	if IsRetryable(err) {
+		e.breaker.failure(ctx)
+	} else {
+		e.breaker.release()
+	}
+	p.logger.WarnContext(ctx, "llm fallback entry failed",
+		slog.String("provider", e.Name),
+		slog.Bool("retryable", IsRetryable(err)),
+		slog.String("error", err.Error()))
+}
+
+// retryCall runs fn until it succeeds, returns a non-retryable error, the
+// policy runs out of attempts, or ctx is done.
+func retryCall[T any](ctx context.Context, policy RetryPolicy, fn func() (T, error)) (T, error) {
+	attempts := policy.MaxAttempts
+	if attempts <= 0 {
+		attempts = defaultRetryAttempts
+	}
+	backoff := policy.InitialBackoff
+	if backoff <= 0 {
+		backoff = defaultInitialBackoff
+	}
+	maxBackoff := policy.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = defaultMaxBackoff
+	}
+
+	var (
+		out T
+		err error
+	)
+	for attempt := 1; ; attempt++ {
+		out, err = fn()
+		if err == nil || attempt >= attempts || !IsRetryable(err) {
+			return out, err
+		}
+
+		// Full jitter keeps several workers from retrying in lockstep
+		// against a provider that is already throttling them.
+		wait := time.Duration(rand.Int64N(int64(backoff)) + 1)
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return out, err
+		case <-timer.C:
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+func (m *Metrics) recordServed(ctx context.Context, provider, model, operation string, position int) {
+	if m == nil || m.served == nil {
+		return
+	}
+	m.served.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("provider", normalizeLLMLabel(provider)),
+		attribute.String("model", normalizeLLMLabel(model)),
+		attribute.String("operation", operation),
+		attribute.String("position", strconv.Itoa(position)),
+	))
+}
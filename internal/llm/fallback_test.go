@@ -0,0 +1,222 @@
+package llm_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/llm"
+	llmmocks "github.com/ChiaYuChang/prism/internal/llm/mocks"
+	pkgschema "github.com/ChiaYuChang/prism/pkg/schema"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/metric"
+)
+
+var fastRetry = llm.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+func apiError(status int) error {
+	return llm.NewAPIError("test", llm.ErrGenAPIError, status, errors.New(http.StatusText(status)))
+}
+
+func TestIsRetryable(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "Nil", err: nil, want: false},
+		{name: "TooManyRequests", err: apiError(http.StatusTooManyRequests), want: true},
+		{name: "ServiceUnavailable", err: apiError(http.StatusServiceUnavailable), want: true},
+		{name: "RequestTimeout", err: apiError(http.StatusRequestTimeout), want: true},
+		{name: "Transport", err: apiError(0), want: true},
+		{name: "BadRequest", err: apiError(http.StatusBadRequest), want: false},
+		{name: "Unauthorized", err: apiError(http.StatusUnauthorized), want: false},
+		{name: "Canceled", err: llm.NewAPIError("test", llm.ErrGenAPIError, 0, context.Canceled), want: false},
+		{name: "Plain", err: errors.New("boom"), want: false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			require.Equal(t, c.want, llm.IsRetryable(c.err))
+		})
+	}
+}
+
+func TestFallbackProviderRetriesThenFallsBack(t *testing.T) {
+	reader := metric.NewManualReader()
+	meterProvider := metric.NewMeterProvider(metric.WithReader(reader))
+	t.Cleanup(func() { require.NoError(t, meterProvider.Shutdown(context.Background())) })
+	metrics, err := llm.NewMetrics(meterProvider.Meter("test"))
+	require.NoError(t, err)
+
+	req := llm.NewGenerateRequest("primary-model", "system", "prompt")
+	primary := llmmocks.NewMockProvider(t)
+	primary.EXPECT().Generate(mock.Anything, req).
+		Return(nil, apiError(http.StatusTooManyRequests)).Times(2)
+
+	secondary := llmmocks.NewMockProvider(t)
+	secondary.EXPECT().Generate(mock.Anything, mock.MatchedBy(func(r *llm.GenerateRequest) bool {
+		return r.Model == "secondary-model" && r.Prompt == "prompt"
+	})).Return(&llm.GenerateResponse{Text: "from secondary"}, nil).Once()
+
+	chain, err := llm.NewFallbackProvider([]llm.FallbackEntry{
+		{Name: "llm.primary", Provider: primary, StructuredOutput: true},
+		{Name: "llm.secondary", Provider: secondary, Model: "secondary-model", StructuredOutput: true},
+	}, llm.FallbackOptions{Retry: fastRetry, Metrics: metrics})
+	require.NoError(t, err)
+
+	resp, err := chain.Generate(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, "from secondary", resp.Text)
+	require.Equal(t, "primary-model", req.Model, "caller request must not be mutated")
+
+	rm := collectLLMMetrics(t, reader)
+	require.Equal(t, int64(1), llmCounterValue(t, rm, "prism.llm.served", map[string]string{
+		"provider":  "llm.secondary",
+		"model":     "secondary-model",
+		"operation": "generate",
+		"position":  "1",
+	}))
+}
+
+func TestFallbackProviderDoesNotRetryBadRequest(t *testing.T) {
+	req := llm.NewGenerateRequest("primary-model", "system", "prompt")
+	primary := llmmocks.NewMockProvider(t)
+	primary.EXPECT().Generate(mock.Anything, req).
+		Return(nil, apiError(http.StatusBadRequest)).Once()
+
+	chain, err := llm.NewFallbackProvider([]llm.FallbackEntry{
+		{Name: "llm.primary", Provider: primary, StructuredOutput: true},
+	}, llm.FallbackOptions{Retry: fastRetry})
+	require.NoError(t, err)
+
+	_, err = chain.Generate(context.Background(), req)
+	require.ErrorIs(t, err, llm.ErrNoProviderAvailable)
+	require.ErrorIs(t, err, llm.ErrGenAPIError)
+}
+
+func TestFallbackProviderSkipsEntriesWithoutStructuredOutput(t *testing.T) {
+	type answer struct {
+		ID int `json:"id"`
+	}
+	req := llm.NewGenerateRequest("model", "system", "prompt")
+	req.Format = llm.ResponseFormatJsonSchema
+	req.JSONSchema = pkgschema.NewSkeleton[answer]("answer", 1)
+
+	textOnly := llmmocks.NewMockProvider(t)
+	structured := llmmocks.NewMockProvider(t)
+	structured.EXPECT().Generate(mock.Anything, req).
+		Return(&llm.GenerateResponse{Text: `{"id":1}`}, nil).Once()
+
+	chain, err := llm.NewFallbackProvider([]llm.FallbackEntry{
+		{Name: "llm.text", Provider: textOnly},
+		{Name: "llm.structured", Provider: structured, StructuredOutput: true},
+	}, llm.FallbackOptions{Retry: fastRetry})
+	require.NoError(t, err)
+
+	resp, err := chain.Generate(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, `{"id":1}`, resp.Text)
+}
+
+func TestFallbackProviderFallsBackOnSchemaInvalidResponse(t *testing.T) {
+	type answer struct {
+		ID int `json:"id"`
+	}
+	req := llm.NewGenerateRequest("model", "system", "prompt")
+	req.Format = llm.ResponseFormatJsonSchema
+	req.JSONSchema = pkgschema.NewSkeleton[answer]("answer", 1)
+
+	first := llmmocks.NewMockProvider(t)
+	first.EXPECT().Generate(mock.Anything, req).
+		Return(&llm.GenerateResponse{Text: `{"id":"wrong"}`}, nil).Once()
+	second := llmmocks.NewMockProvider(t)
+	second.EXPECT().Generate(mock.Anything, req).
+		Return(&llm.GenerateResponse{Text: `{"id":"still wrong"}`}, nil).Once()
+
+	chain, err := llm.NewFallbackProvider([]llm.FallbackEntry{
+		{Name: "llm.first", Provider: first, StructuredOutput: true},
+		{Name: "llm.second", Provider: second, StructuredOutput: true},
+	}, llm.FallbackOptions{Retry: fastRetry})
+	require.NoError(t, err)
+
+	// No entry produced a valid response: the first invalid one comes back
+	// so the caller's own decode step reports the schema violation.
+	resp, err := chain.Generate(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, `{"id":"wrong"}`, resp.Text)
+}
+
+func TestFallbackProviderCircuitBreakerOpensAndRecovers(t *testing.T) {
+	reader := metric.NewManualReader()
+	meterProvider := metric.NewMeterProvider(metric.WithReader(reader))
+	t.Cleanup(func() { require.NoError(t, meterProvider.Shutdown(context.Background())) })
+	metrics, err := llm.NewMetrics(meterProvider.Meter("test"))
+	require.NoError(t, err)
+
+	req := llm.NewGenerateRequest("model", "system", "prompt")
+	primary := llmmocks.NewMockProvider(t)
+	primary.EXPECT().Generate(mock.Anything, req).
+		Return(nil, apiError(http.StatusServiceUnavailable)).Times(2)
+	secondary := llmmocks.NewMockProvider(t)
+	secondary.EXPECT().Generate(mock.Anything, req).
+		Return(&llm.GenerateResponse{Text: "secondary"}, nil).Times(3)
+
+	const cooldown = 50 * time.Millisecond
+	chain, err := llm.NewFallbackProvider([]llm.FallbackEntry{
+		{Name: "llm.primary", Provider: primary, StructuredOutput: true},
+		{Name: "llm.secondary", Provider: secondary, StructuredOutput: true},
+	}, llm.FallbackOptions{
+		Retry:   llm.RetryPolicy{MaxAttempts: 1},
+		Breaker: llm.BreakerPolicy{FailureThreshold: 2, Cooldown: cooldown},
+		Metrics: metrics,
+	})
+	require.NoError(t, err)
+
+	// Two failures open the breaker; the third call never reaches primary.
+	for range 3 {
+		resp, err := chain.Generate(context.Background(), req)
+		require.NoError(t, err)
+		require.Equal(t, "secondary", resp.Text)
+	}
+
+	// After the cooldown a single probe goes through and closes the breaker.
+	time.Sleep(2 * cooldown)
+	primary.EXPECT().Generate(mock.Anything, req).
+		Return(&llm.GenerateResponse{Text: "primary"}, nil).Once()
+	resp, err := chain.Generate(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, "primary", resp.Text)
+
+	rm := collectLLMMetrics(t, reader)
+	for _, state := range []string{"open", "half_open", "closed"} {
+		require.Equal(t, int64(1), llmCounterValue(t, rm, "prism.llm.breaker.transitions", map[string]string{
+			"provider": "llm.primary",
+			"state":    state,
+		}), "state %s", state)
+	}
+}
+
+func TestFallbackProviderEmbedUsesPrimaryOnly(t *testing.T) {
+	req := &llm.EmbedRequest{Model: "embed", Input: []string{"a"}}
+	primary := llmmocks.NewMockProvider(t)
+	primary.EXPECT().Embed(mock.Anything, req).
+		Return(nil, llm.NewAPIError("test", llm.ErrEmbedAPIError, http.StatusBadGateway, errors.New("bad gateway"))).Times(2)
+	secondary := llmmocks.NewMockProvider(t)
+
+	chain, err := llm.NewFallbackProvider([]llm.FallbackEntry{
+		{Name: "llm.primary", Provider: primary},
+		{Name: "llm.secondary", Provider: secondary},
+	}, llm.FallbackOptions{Retry: fastRetry})
+	require.NoError(t, err)
+
+	_, err = chain.Embed(context.Background(), req)
+	require.ErrorIs(t, err, llm.ErrEmbedAPIError)
+}
+
+func TestNewFallbackProviderRequiresEntries(t *testing.T) {
+	_, err := llm.NewFallbackProvider(nil, llm.FallbackOptions{})
+	require.ErrorIs(t, err, llm.ErrEmptyFallbackChain)
+}
@@ -2,6 +2,7 @@ package gemini
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -135,7 +136,7 @@ func (p *Provider) Generate(ctx context.Context, req *llm.GenerateRequest) (*llm
 			"gemini generate error",
 			slog.String("message", err.Error()),
 			slog.String("model", req.Model))
-		return nil, fmt.Errorf("gemini %w: %s", llm.ErrGenAPIError, err.Error())
+		return nil, llm.NewAPIError("gemini", llm.ErrGenAPIError, statusCode(err), err)
 	}
 
 	l.LogAttrs(ctx, slog.LevelInfo,
@@ -189,7 +190,7 @@ func (p *Provider) Embed(ctx context.Context, req *llm.EmbedRequest) (*llm.Embed
 			"gemini embed error",
 			slog.String("message", err.Error()),
 			slog.String("model", req.Model))
-		return nil, fmt.Errorf("gemini %w: %s", llm.ErrEmbedAPIError, err.Error())
+		return nil, llm.NewAPIError("gemini", llm.ErrEmbedAPIError, statusCode(err), err)
 	}
 
 	n := len(resp.Embeddings)
@@ -269,3 +270,13 @@ func (p *Provider) BatchEmbed(ctx context.Context, req *llm.BatchJobRequest, mod
 func (p *Provider) Close() error {
 	return nil
 }
+
+// statusCode extracts the HTTP status from an SDK error, or 0 when the call
+// failed before a response arrived.
+func statusCode(err error) int {
+	var apiErr genai.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code
+	}
+	return 0
+}
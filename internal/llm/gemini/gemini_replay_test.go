@@ -118,4 +118,9 @@ func TestGeminiReplay_Unauthorized(t *testing.T) {
 	})
 	require.Error(t, err)
 	require.ErrorIs(t, err, llm.ErrGenAPIError)
+
+	var apiErr *llm.APIError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, 400, apiErr.StatusCode)
+	require.False(t, llm.IsRetryable(err))
 }
@@ -0,0 +1,228 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/obs"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrBudgetExceeded is returned before an upstream call when the calling
+	// component has already spent its daily or monthly budget.
+	ErrBudgetExceeded = errors.New("llm budget exceeded")
+	// ErrUsageLedgerMissing is returned when an accounting decorator is built
+	// without a ledger.
+	ErrUsageLedgerMissing = errors.New("llm usage ledger is missing")
+)
+
+// UsageRecord is one priced upstream call.
+type UsageRecord struct {
+	Provider   string
+	Model      string
+	Operation  string
+	Component  string
+	Usage      TokenUsage
+	CostMicros int64
+	TraceID    string
+	BatchID    uuid.UUID
+}
+
+// UsageLedger persists UsageRecords and answers spend queries for budget
+// checks. Amounts are in micro-USD.
+type UsageLedger interface {
+	RecordUsage(ctx context.Context, record UsageRecord) error
+	SpentSince(ctx context.Context, component string, since time.Time) (int64, error)
+}
+
+// ModelPrice is the list price of one model in USD per million tokens.
+// CachedInput applies to the cached share of the input tokens; zero means
+// cached tokens are billed at the Input rate.
+type ModelPrice struct {
+	Input       float64
+	Output      float64
+	CachedInput float64
+}
+
+// PriceTable maps a model name, as sent in the request, to its price.
+type PriceTable map[string]ModelPrice
+
+// Cost returns the price of usage on model in micro-USD. Models missing from
+// the table cost nothing, which keeps local models (ollama) free without
+// listing them.
+//
+// Input tokens include cached tokens for every supported provider, so only
+// the uncached share is billed at the Input rate. Thinking tokens are billed
+// as output.
+func (t PriceTable) Cost(model string, usage TokenUsage) int64 {
+	price, ok := t[model]
+	if !ok {
+		return 0
+	}
+	cachedRate := price.CachedInput
+	if cachedRate == 0 {
+		cachedRate = price.Input
+	}
+	cached := min(usage.Cached, usage.Input)
+	// USD per million tokens is numerically micro-USD per token.
+	cost := float64(usage.Input-cached)*price.Input +
+		float64(cached)*cachedRate +
+		float64(usage.Output+usage.Thought)*price.Output
+	return int64(cost + 0.5)
+}
+
+// Budget caps the spend of one component in micro-USD. Windows are calendar
+// days and months in UTC. Zero disables a limit.
+type Budget struct {
+	DailyMicros   int64
+	MonthlyMicros int64
+}
+
+func (b Budget) enabled() bool {
+	return b.DailyMicros > 0 || b.MonthlyMicros > 0
+}
+
+// AccountingOptions tunes the accounting decorators.
+type AccountingOptions struct {
+	// Provider labels ledger rows, e.g. "llm.gemini".
+	Provider string
+	// Component is the calling service, e.g. "planner" or "collector".
+	Component string
+	Prices    PriceTable
+	Budget    Budget
+	Logger    *slog.Logger
+}
+
+type accountant struct {
+	ledger UsageLedger
+	opts   AccountingOptions
+	now    func() time.Time
+}
+
+type accountedGenerator struct {
+	base Generator
+	*accountant
+}
+
+type accountedEmbedder struct {
+	base Embedder
+	*accountant
+}
+
+type accountedProvider struct {
+	Generator
+	Embedder
+}
+
+// AccountProvider wraps base so every upstream call is priced and written to
+// ledger, and calls are refused with ErrBudgetExceeded once the component's
+// budget is spent.
+//
+// The budget check runs before the call against spend already recorded, so
+// a single call can overshoot by its own cost but the next one is refused.
+// Ledger failures are logged and never fail the caller: accounting must not
+// take the pipeline down with it.
+func AccountProvider(base Provider, ledger UsageLedger, opts AccountingOptions) (Provider, error) {
+	if ledger == nil {
+		return nil, ErrUsageLedgerMissing
+	}
+	if base == nil {
+		return base, nil
+	}
+	if opts.Logger == nil {
+		opts.Logger = slog.New(slog.DiscardHandler)
+	}
+	a := &accountant{ledger: ledger, opts: opts, now: time.Now}
+	return &accountedProvider{
+		Generator: &accountedGenerator{base: base, accountant: a},
+		Embedder:  &accountedEmbedder{base: base, accountant: a},
+	}, nil
+}
+
+func (g *accountedGenerator) Generate(ctx context.Context, req *GenerateRequest) (*GenerateResponse, error) {
+	if req == nil {
+		return g.base.Generate(ctx, req)
+	}
+	if err := g.checkBudget(ctx); err != nil {
+		return nil, err
+	}
+	resp, err := g.base.Generate(ctx, req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+	g.record(ctx, req.Model, operationGenerate, resp.Usage)
+	return resp, nil
+}
+
+func (e *accountedEmbedder) Embed(ctx context.Context, req *EmbedRequest) (*EmbedResponse, error) {
+	if req == nil {
+		return e.base.Embed(ctx, req)
+	}
+	if err := e.checkBudget(ctx); err != nil {
+		return nil, err
+	}
+	resp, err := e.base.Embed(ctx, req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+	// Embedding responses carry no token counts; the call is still recorded
+	// so spend summaries show how many were made.
+	e.record(ctx, req.Model, operationEmbed, TokenUsage{})
+	return resp, nil
+}
+
+func (a *accountant) checkBudget(ctx context.Context) error {
+	if !a.opts.Budget.enabled() {
+		return nil
+	}
+	now := a.now().UTC()
+	for _, window := range []struct {
+		name  string
+		limit int64
+		since time.Time
+	}{
+		{name: "daily", limit: a.opts.Budget.DailyMicros, since: time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)},
+		{name: "monthly", limit: a.opts.Budget.MonthlyMicros, since: time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)},
+	} {
+		if window.limit <= 0 {
+			continue
+		}
+		spent, err := a.ledger.SpentSince(ctx, a.opts.Component, window.since)
+		if err != nil {
+			a.opts.Logger.WarnContext(ctx, "llm budget check failed",
+				slog.String("component", a.opts.Component),
+				slog.String("window", window.name),
+				slog.String("error", err.Error()))
+			continue
+		}
+		if spent >= window.limit {
+			return fmt.Errorf("%w: %s %s spend %d >= %d micro-USD",
+				ErrBudgetExceeded, a.opts.Component, window.name, spent, window.limit)
+		}
+	}
+	return nil
+}
+
+func (a *accountant) record(ctx context.Context, model, operation string, usage TokenUsage) {
+	record := UsageRecord{
+		Provider:   a.opts.Provider,
+		Model:      model,
+		Operation:  operation,
+		Component:  a.opts.Component,
+		Usage:      usage,
+		CostMicros: a.opts.Prices.Cost(model, usage),
+		TraceID:    obs.ExtractTraceID(ctx),
+		BatchID:    obs.ExtractBatchID(ctx),
+	}
+	// The call already happened; record it even if the caller gave up.
+	if err := a.ledger.RecordUsage(context.WithoutCancel(ctx), record); err != nil {
+		a.opts.Logger.WarnContext(ctx, "llm usage record failed",
+			slog.String("provider", a.opts.Provider),
+			slog.String("model", model),
+			slog.String("error", err.Error()))
+	}
+}
@@ -0,0 +1,132 @@
+package llm_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ChiaYuChang/prism/internal/llm"
+	llmmocks "github.com/ChiaYuChang/prism/internal/llm/mocks"
+	"github.com/ChiaYuChang/prism/internal/obs"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testPrices = llm.PriceTable{
+	"model-a": {Input: 0.5, Output: 2, CachedInput: 0.1},
+}
+
+func TestPriceTableCost(t *testing.T) {
+	cases := []struct {
+		name  string
+		model string
+		usage llm.TokenUsage
+		want  int64
+	}{
+		{name: "Uncached", model: "model-a", usage: llm.TokenUsage{Input: 1000, Output: 100}, want: 700},
+		{name: "Cached", model: "model-a", usage: llm.TokenUsage{Input: 1000, Cached: 400, Output: 100}, want: 540},
+		{name: "Thought", model: "model-a", usage: llm.TokenUsage{Input: 0, Output: 10, Thought: 10}, want: 40},
+		{name: "Unknown", model: "local", usage: llm.TokenUsage{Input: 1000, Output: 1000}, want: 0},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			require.Equal(t, c.want, testPrices.Cost(c.model, c.usage))
+		})
+	}
+}
+
+func TestAccountProviderRecordsPricedUsage(t *testing.T) {
+	batchID := uuid.New()
+	ctx := obs.WithBatchID(obs.WithTraceID(context.Background(), "trace-1"), batchID)
+	req := llm.NewGenerateRequest("model-a", "system", "prompt")
+
+	base := llmmocks.NewMockProvider(t)
+	base.EXPECT().Generate(mock.Anything, req).Return(&llm.GenerateResponse{
+		Text:  "answer",
+		Usage: llm.TokenUsage{Input: 1000, Output: 100, Total: 1100},
+	}, nil).Once()
+
+	ledger := llmmocks.NewMockUsageLedger(t)
+	ledger.EXPECT().RecordUsage(mock.Anything, llm.UsageRecord{
+		Provider:   "llm.test",
+		Model:      "model-a",
+		Operation:  "generate",
+		Component:  "planner",
+		Usage:      llm.TokenUsage{Input: 1000, Output: 100, Total: 1100},
+		CostMicros: 700,
+		TraceID:    "trace-1",
+		BatchID:    batchID,
+	}).Return(nil).Once()
+
+	provider, err := llm.AccountProvider(base, ledger, llm.AccountingOptions{
+		Provider:  "llm.test",
+		Component: "planner",
+		Prices:    testPrices,
+	})
+	require.NoError(t, err)
+
+	resp, err := provider.Generate(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, "answer", resp.Text)
+}
+
+func TestAccountProviderRefusesOverBudget(t *testing.T) {
+	cases := []struct {
+		name    string
+		budget  llm.Budget
+		daily   int64
+		monthly int64
+	}{
+		{name: "Daily", budget: llm.Budget{DailyMicros: 1000}, daily: 1000},
+		{name: "Monthly", budget: llm.Budget{DailyMicros: 1000, MonthlyMicros: 5000}, daily: 10, monthly: 5001},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			base := llmmocks.NewMockProvider(t)
+			ledger := llmmocks.NewMockUsageLedger(t)
+			// The daily window is checked first, then the monthly one.
+			ledger.EXPECT().SpentSince(mock.Anything, "planner", mock.Anything).Return(c.daily, nil).Once()
+			if c.budget.DailyMicros > c.daily {
+				ledger.EXPECT().SpentSince(mock.Anything, "planner", mock.Anything).Return(c.monthly, nil).Once()
+			}
+
+			provider, err := llm.AccountProvider(base, ledger, llm.AccountingOptions{
+				Component: "planner",
+				Budget:    c.budget,
+			})
+			require.NoError(t, err)
+
+			_, err = provider.Generate(context.Background(), llm.NewGenerateRequest("model-a", "system", "prompt"))
+			require.ErrorIs(t, err, llm.ErrBudgetExceeded)
+		})
+	}
+}
+
+func TestAccountProviderIgnoresLedgerFailures(t *testing.T) {
+	req := llm.NewGenerateRequest("model-a", "system", "prompt")
+	base := llmmocks.NewMockProvider(t)
+	base.EXPECT().Generate(mock.Anything, req).
+		Return(&llm.GenerateResponse{Text: "answer"}, nil).Once()
+
+	ledger := llmmocks.NewMockUsageLedger(t)
+	ledger.EXPECT().SpentSince(mock.Anything, "collector", mock.Anything).
+		Return(0, errors.New("db down")).Once()
+	ledger.EXPECT().RecordUsage(mock.Anything, mock.Anything).
+		Return(errors.New("db down")).Once()
+
+	provider, err := llm.AccountProvider(base, ledger, llm.AccountingOptions{
+		Component: "collector",
+		Budget:    llm.Budget{DailyMicros: 1},
+	})
+	require.NoError(t, err)
+
+	resp, err := provider.Generate(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, "answer", resp.Text)
+}
+
+func TestAccountProviderRequiresLedger(t *testing.T) {
+	_, err := llm.AccountProvider(llmmocks.NewMockProvider(t), nil, llm.AccountingOptions{})
+	require.ErrorIs(t, err, llm.ErrUsageLedgerMissing)
+}
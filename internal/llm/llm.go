@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 
 	pkgschema "github.com/ChiaYuChang/prism/pkg/schema"
 	"github.com/go-viper/mapstructure/v2"
@@ -20,8 +21,62 @@ var (
 	ErrGenAPIError        = errors.New("content generation API error")
 	ErrEmbedAPIError      = errors.New("embedding API error")
 	ErrEmbedBatchAPIError = errors.New("batch embedding API error")
+
+	// ErrUnsupportedOperation is returned by providers asked for something
+	// their upstream API or configuration cannot do, e.g. embeddings from
+	// Anthropic.
+	ErrUnsupportedOperation = errors.New("operation not supported by provider")
 )
 
+// APIError is returned by providers when the upstream API call fails. It
+// keeps the HTTP status (zero for transport failures) so decorators can tell
+// throttling and outages apart from bad requests without parsing messages.
+//
+// errors.Is matches both Kind (ErrGenAPIError / ErrEmbedAPIError) and the
+// underlying SDK error.
+type APIError struct {
+	Provider   string
+	Kind       error
+	StatusCode int
+	Err        error
+}
+
+// NewAPIError wraps err from provider as kind with the given HTTP status.
+func NewAPIError(provider string, kind error, status int, err error) *APIError {
+	return &APIError{Provider: provider, Kind: kind, StatusCode: status, Err: err}
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s %s: %s", e.Provider, e.Kind, e.Err)
+}
+
+func (e *APIError) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+// IsRetryable reports whether err is worth retrying against the same
+// provider: throttling (429), request timeouts (408), server errors (5xx),
+// and transport failures without a status. Cancellation and every other
+// status are final.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return errors.Is(err, context.DeadlineExceeded)
+	}
+	switch {
+	case apiErr.StatusCode == 0:
+		return true
+	case apiErr.StatusCode == http.StatusTooManyRequests,
+		apiErr.StatusCode == http.StatusRequestTimeout:
+		return true
+	default:
+		return apiErr.StatusCode >= http.StatusInternalServerError
+	}
+}
+
 // ResponseFormat defines the expected format of the LLM output.
 type ResponseFormat string
 
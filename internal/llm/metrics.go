@@ -10,6 +10,9 @@ import (
 type Metrics struct {
 	request *requestMetrics
 	tokens  metric.Int64Counter
+	cache   metric.Int64Counter
+	served  metric.Int64Counter
+	breaker metric.Int64Counter
 }
 
 type requestMetrics struct {
@@ -46,11 +49,41 @@ func NewMetrics(meter metric.Meter) (*Metrics, error) {
 		return nil, fmt.Errorf("create LLM token counter: %w", err)
 	}
 
+	cache, err := meter.Int64Counter(
+		"prism.llm.cache.lookups",
+		metric.WithDescription("Count of LLM response cache lookups by result."),
+		metric.WithUnit("{lookup}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create LLM cache lookup counter: %w", err)
+	}
+
+	served, err := meter.Int64Counter(
+		"prism.llm.served",
+		metric.WithDescription("Count of LLM calls by the fallback chain entry that served them."),
+		metric.WithUnit("{call}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create LLM served counter: %w", err)
+	}
+
+	breaker, err := meter.Int64Counter(
+		"prism.llm.breaker.transitions",
+		metric.WithDescription("Count of LLM circuit breaker state transitions."),
+		metric.WithUnit("{transition}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create LLM breaker transition counter: %w", err)
+	}
+
 	return &Metrics{
 		request: &requestMetrics{
 			count:    requests,
 			duration: requestDuration,
 		},
-		tokens: tokens,
+		tokens:  tokens,
+		cache:   cache,
+		served:  served,
+		breaker: breaker,
 	}, nil
 }
@@ -0,0 +1,182 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockCacheStore creates a new instance of MockCacheStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCacheStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCacheStore {
+	mock := &MockCacheStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockCacheStore is an autogenerated mock type for the CacheStore type
+type MockCacheStore struct {
+	mock.Mock
+}
+
+type MockCacheStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCacheStore) EXPECT() *MockCacheStore_Expecter {
+	return &MockCacheStore_Expecter{mock: &_m.Mock}
+}
+
+// Get provides a mock function for the type MockCacheStore
+func (_mock *MockCacheStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	ret := _mock.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 []byte
+	var r1 bool
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]byte, bool, error)); ok {
+		return returnFunc(ctx, key)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []byte); ok {
+		r0 = returnFunc(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) bool); ok {
+		r1 = returnFunc(ctx, key)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = returnFunc(ctx, key)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockCacheStore_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockCacheStore_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockCacheStore_Expecter) Get(ctx interface{}, key interface{}) *MockCacheStore_Get_Call {
+	return &MockCacheStore_Get_Call{Call: _e.mock.On("Get", ctx, key)}
+}
+
+func (_c *MockCacheStore_Get_Call) Run(run func(ctx context.Context, key string)) *MockCacheStore_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCacheStore_Get_Call) Return(value []byte, ok bool, err error) *MockCacheStore_Get_Call {
+	_c.Call.Return(value, ok, err)
+	return _c
+}
+
+func (_c *MockCacheStore_Get_Call) RunAndReturn(run func(ctx context.Context, key string) ([]byte, bool, error)) *MockCacheStore_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Set provides a mock function for the type MockCacheStore
+func (_mock *MockCacheStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	ret := _mock.Called(ctx, key, value, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Set")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []byte, time.Duration) error); ok {
+		r0 = returnFunc(ctx, key, value, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockCacheStore_Set_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Set'
+type MockCacheStore_Set_Call struct {
+	*mock.Call
+}
+
+// Set is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - value []byte
+//   - ttl time.Duration
+func (_e *MockCacheStore_Expecter) Set(ctx interface{}, key interface{}, value interface{}, ttl interface{}) *MockCacheStore_Set_Call {
+	return &MockCacheStore_Set_Call{Call: _e.mock.On("Set", ctx, key, value, ttl)}
+}
+
+func (_c *MockCacheStore_Set_Call) Run(run func(ctx context.Context, key string, value []byte, ttl time.Duration)) *MockCacheStore_Set_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []byte
+		if args[2] != nil {
+			arg2 = args[2].([]byte)
+		}
+		var arg3 time.Duration
+		if args[3] != nil {
+			arg3 = args[3].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCacheStore_Set_Call) Return(err error) *MockCacheStore_Set_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockCacheStore_Set_Call) RunAndReturn(run func(ctx context.Context, key string, value []byte, ttl time.Duration) error) *MockCacheStore_Set_Call {
+	_c.Call.Return(run)
+	return _c
+}
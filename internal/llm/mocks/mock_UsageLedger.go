@@ -0,0 +1,169 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/llm"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockUsageLedger creates a new instance of MockUsageLedger. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockUsageLedger(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockUsageLedger {
+	mock := &MockUsageLedger{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockUsageLedger is an autogenerated mock type for the UsageLedger type
+type MockUsageLedger struct {
+	mock.Mock
+}
+
+type MockUsageLedger_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockUsageLedger) EXPECT() *MockUsageLedger_Expecter {
+	return &MockUsageLedger_Expecter{mock: &_m.Mock}
+}
+
+// RecordUsage provides a mock function for the type MockUsageLedger
+func (_mock *MockUsageLedger) RecordUsage(ctx context.Context, record llm.UsageRecord) error {
+	ret := _mock.Called(ctx, record)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordUsage")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, llm.UsageRecord) error); ok {
+		r0 = returnFunc(ctx, record)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUsageLedger_RecordUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordUsage'
+type MockUsageLedger_RecordUsage_Call struct {
+	*mock.Call
+}
+
+// RecordUsage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - record llm.UsageRecord
+func (_e *MockUsageLedger_Expecter) RecordUsage(ctx interface{}, record interface{}) *MockUsageLedger_RecordUsage_Call {
+	return &MockUsageLedger_RecordUsage_Call{Call: _e.mock.On("RecordUsage", ctx, record)}
+}
+
+func (_c *MockUsageLedger_RecordUsage_Call) Run(run func(ctx context.Context, record llm.UsageRecord)) *MockUsageLedger_RecordUsage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 llm.UsageRecord
+		if args[1] != nil {
+			arg1 = args[1].(llm.UsageRecord)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsageLedger_RecordUsage_Call) Return(err error) *MockUsageLedger_RecordUsage_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUsageLedger_RecordUsage_Call) RunAndReturn(run func(ctx context.Context, record llm.UsageRecord) error) *MockUsageLedger_RecordUsage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SpentSince provides a mock function for the type MockUsageLedger
+func (_mock *MockUsageLedger) SpentSince(ctx context.Context, component string, since time.Time) (int64, error) {
+	ret := _mock.Called(ctx, component, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SpentSince")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) (int64, error)); ok {
+		return returnFunc(ctx, component, since)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) int64); ok {
+		r0 = returnFunc(ctx, component, since)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = returnFunc(ctx, component, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUsageLedger_SpentSince_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SpentSince'
+type MockUsageLedger_SpentSince_Call struct {
+	*mock.Call
+}
+
+// SpentSince is a helper method to define mock.On call
+//   - ctx context.Context
+//   - component string
+//   - since time.Time
+func (_e *MockUsageLedger_Expecter) SpentSince(ctx interface{}, component interface{}, since interface{}) *MockUsageLedger_SpentSince_Call {
+	return &MockUsageLedger_SpentSince_Call{Call: _e.mock.On("SpentSince", ctx, component, since)}
+}
+
+func (_c *MockUsageLedger_SpentSince_Call) Run(run func(ctx context.Context, component string, since time.Time)) *MockUsageLedger_SpentSince_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsageLedger_SpentSince_Call) Return(n int64, err error) *MockUsageLedger_SpentSince_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockUsageLedger_SpentSince_Call) RunAndReturn(run func(ctx context.Context, component string, since time.Time) (int64, error)) *MockUsageLedger_SpentSince_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -3,6 +3,7 @@ package ollama
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -176,7 +177,7 @@ func (p *Provider) Generate(ctx context.Context, req *llm.GenerateRequest) (*llm
 			"ollama generate error",
 			slog.String("message", err.Error()),
 			slog.String("model", req.Model))
-		return nil, fmt.Errorf("ollama %w: %s", llm.ErrGenAPIError, err.Error())
+		return nil, llm.NewAPIError("ollama", llm.ErrGenAPIError, statusCode(err), err)
 	}
 	usage.Total = usage.Input + usage.Output
 
@@ -219,7 +220,7 @@ func (p *Provider) Embed(ctx context.Context, req *llm.EmbedRequest) (*llm.Embed
 			"ollama embed error",
 			slog.String("message", err.Error()),
 			slog.String("model", req.Model))
-		return nil, fmt.Errorf("ollama %w: %s", llm.ErrEmbedAPIError, err)
+		return nil, llm.NewAPIError("ollama", llm.ErrEmbedAPIError, statusCode(err), err)
 	}
 
 	l.LogAttrs(ctx, slog.LevelInfo,
@@ -237,3 +238,13 @@ func (p *Provider) Embed(ctx context.Context, req *llm.EmbedRequest) (*llm.Embed
 func (p *Provider) Close() error {
 	return nil
 }
+
+// statusCode extracts the HTTP status from an SDK error, or 0 when the call
+// failed before a response arrived.
+func statusCode(err error) int {
+	var apiErr api.StatusError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
@@ -145,4 +145,9 @@ func TestOllamaReplay_ModelNotFound(t *testing.T) {
 	})
 	require.Error(t, err)
 	require.ErrorIs(t, err, llm.ErrGenAPIError)
+
+	var apiErr *llm.APIError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, 404, apiErr.StatusCode)
+	require.False(t, llm.IsRetryable(err))
 }
@@ -2,6 +2,7 @@ package openai
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -157,7 +158,7 @@ func (p *Provider) Generate(ctx context.Context, req *llm.GenerateRequest) (*llm
 			"openai generate error",
 			slog.String("message", err.Error()),
 			slog.String("model", req.Model))
-		return nil, fmt.Errorf("openai %w: %s", llm.ErrGenAPIError, err)
+		return nil, llm.NewAPIError("openai", llm.ErrGenAPIError, statusCode(err), err)
 	}
 
 	l.LogAttrs(ctx, slog.LevelInfo,
@@ -213,7 +214,7 @@ func (p *Provider) Embed(ctx context.Context, req *llm.EmbedRequest) (*llm.Embed
 			"openai embed error",
 			slog.String("message", err.Error()),
 			slog.String("model", req.Model))
-		return nil, fmt.Errorf("openai %w: %s", llm.ErrEmbedAPIError, err.Error())
+		return nil, llm.NewAPIError("openai", llm.ErrEmbedAPIError, statusCode(err), err)
 	}
 
 	n := len(resp.Data)
@@ -243,3 +244,13 @@ func (p *Provider) Embed(ctx context.Context, req *llm.EmbedRequest) (*llm.Embed
 func (p *Provider) Close() error {
 	return nil
 }
+
+// statusCode extracts the HTTP status from an SDK error, or 0 when the call
+// failed before a response arrived.
+func statusCode(err error) int {
+	var apiErr *openai.Error
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
@@ -0,0 +1,281 @@
+// Package openaicompat implements llm.Generator and llm.Embedder against
+// self-hosted servers that speak the OpenAI Chat Completions and Embeddings
+// APIs (vLLM, llama.cpp server, LM Studio, ...).
+//
+// Unlike internal/llm/openai, which targets the Responses API, this provider
+// only relies on /chat/completions and /embeddings, the subset every
+// compatible server implements. Capabilities that vary between servers are
+// declared in Config rather than probed.
+package openaicompat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/llm"
+	"github.com/ChiaYuChang/prism/internal/obs"
+	"github.com/ChiaYuChang/prism/pkg/logger"
+	"github.com/go-playground/mold/v4"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/openai/openai-go/v3"
+	"github.com/openai/openai-go/v3/option"
+	"github.com/openai/openai-go/v3/shared"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Config holds OpenAI-compatible server configuration (Pure Data).
+//
+// APIKey is optional: many self-hosted servers run unauthenticated. It is
+// sent as "Authorization: Bearer <key>" unless AuthHeader names another
+// header, in which case the raw key goes there instead (e.g. "X-API-Key"
+// behind a gateway).
+//
+// StructuredOutput enables response_format=json_schema; leave it off for
+// servers or models that cannot constrain decoding, and JSON schema
+// requests fail with llm.ErrUnsupportedOperation instead of returning
+// free text. Embeddings enables /embeddings.
+type Config struct {
+	BaseURL          string            `json:"base_url"          mod:"trim" validate:"required,url"`
+	APIKey           string            `json:"api_key"           mod:"trim"`
+	AuthHeader       string            `json:"auth_header"       mod:"trim"`
+	Timeout          time.Duration     `json:"timeout"           mod:"trim,default=30s"`
+	HttpHeader       map[string]string `json:"http_header"       mod:"trim"`
+	StructuredOutput bool              `json:"structured_output"`
+	Embeddings       bool              `json:"embeddings"`
+}
+
+// Provider implements both llm.Generator and llm.Embedder for
+// OpenAI-compatible servers.
+type Provider struct {
+	client           *openai.Client
+	structuredOutput bool
+	embeddings       bool
+	logger           *slog.Logger
+	tracer           trace.Tracer
+	validator        *validator.Validate
+	transformer      *mold.Transformer
+}
+
+// New creates a new OpenAI-compatible provider instance with explicit dependency injection.
+func New(ctx context.Context, l *slog.Logger, t trace.Tracer, v *validator.Validate,
+	m *mold.Transformer, c *http.Client, cfg Config) (*Provider, error) {
+
+	if err := m.Struct(ctx, &cfg); err != nil {
+		return nil, fmt.Errorf("openai-compatible %w: %s", llm.ErrCfgModError, err)
+	}
+
+	if err := v.StructCtx(ctx, cfg); err != nil {
+		return nil, fmt.Errorf("openai-compatible %w: %s", llm.ErrCfgValError, err)
+	}
+
+	// Never forward an OPENAI_API_KEY picked up from the environment to a
+	// third-party server: authentication comes from Config only.
+	opts := []option.RequestOption{
+		option.WithBaseURL(cfg.BaseURL),
+		option.WithHeaderDel("authorization"),
+	}
+
+	switch {
+	case cfg.APIKey != "" && cfg.AuthHeader != "":
+		opts = append(opts, option.WithHeader(cfg.AuthHeader, cfg.APIKey))
+	case cfg.APIKey != "":
+		opts = append(opts, option.WithAPIKey(cfg.APIKey))
+	}
+
+	if c != nil {
+		opts = append(opts, option.WithHTTPClient(c))
+	}
+
+	if cfg.Timeout != 0 {
+		opts = append(opts, option.WithRequestTimeout(cfg.Timeout))
+	}
+
+	for k, v := range cfg.HttpHeader {
+		opts = append(opts, option.WithHeaderAdd(k, v))
+	}
+
+	client := openai.NewClient(opts...)
+	return &Provider{
+		client:           &client,
+		structuredOutput: cfg.StructuredOutput,
+		embeddings:       cfg.Embeddings,
+		logger:           l,
+		tracer:           t,
+		validator:        v,
+		transformer:      m,
+	}, nil
+}
+
+// Generate produces content based on the structured request.
+func (p *Provider) Generate(ctx context.Context, req *llm.GenerateRequest) (*llm.GenerateResponse, error) {
+	tid := obs.ExtractTraceID(ctx)
+	uid := obs.ExtractUserID(ctx)
+
+	l := logger.WithHook(p.logger,
+		logger.SinceHook("time", time.Now()),
+		func(ctx context.Context, r slog.Record) slog.Record {
+			r.Add("trace_id", tid)
+			r.Add("user_id", uid)
+			return r
+		})
+
+	structured := req.Format == llm.ResponseFormatJsonSchema && req.JSONSchema.Schema != nil
+	if structured && !p.structuredOutput {
+		return nil, fmt.Errorf("openai-compatible structured output for %s: %w", req.Model, llm.ErrUnsupportedOperation)
+	}
+
+	messages := make([]openai.ChatCompletionMessageParamUnion, 0, 2)
+	if req.SystemInstruction != "" {
+		messages = append(messages, openai.SystemMessage(req.SystemInstruction))
+	}
+	messages = append(messages, openai.UserMessage(req.Prompt))
+
+	params := openai.ChatCompletionNewParams{
+		Model:    shared.ChatModel(req.Model),
+		Messages: messages,
+	}
+
+	// Extract UserID from context via internal/obs
+	if uid != uuid.Nil {
+		params.User = openai.String(uid.String())
+	}
+
+	if req.Temperature != nil {
+		params.Temperature = openai.Float(float64(*req.Temperature))
+	}
+
+	if req.TopP != nil {
+		params.TopP = openai.Float(float64(*req.TopP))
+	}
+
+	// max_tokens rather than max_completion_tokens: it is the field every
+	// compatible server understands.
+	if req.MaxTokens != nil {
+		params.MaxTokens = openai.Int(int64(*req.MaxTokens))
+	}
+
+	// Handle JSON Mode
+	if structured {
+		params.ResponseFormat = openai.ChatCompletionNewParamsResponseFormatUnion{
+			OfJSONSchema: &shared.ResponseFormatJSONSchemaParam{
+				JSONSchema: shared.ResponseFormatJSONSchemaJSONSchemaParam{
+					Name:   req.JSONSchema.Name,
+					Schema: req.JSONSchema.MustToOpenAI(),
+					Strict: openai.Bool(true),
+				},
+			},
+		}
+	}
+
+	resp, err := p.client.Chat.Completions.New(ctx, params)
+	if err != nil {
+		l.LogAttrs(ctx, slog.LevelError,
+			"openai-compatible generate error",
+			slog.String("message", err.Error()),
+			slog.String("model", req.Model))
+		return nil, llm.NewAPIError("openai-compatible", llm.ErrGenAPIError, statusCode(err), err)
+	}
+
+	var text string
+	if len(resp.Choices) > 0 {
+		text = resp.Choices[0].Message.Content
+	}
+
+	l.LogAttrs(ctx, slog.LevelInfo,
+		"openai-compatible generate success",
+		slog.String("model", resp.Model),
+		slog.Int64("total_tokens", resp.Usage.TotalTokens))
+
+	return &llm.GenerateResponse{
+		Model: resp.Model,
+		Text:  text,
+		Usage: llm.TokenUsage{
+			Input:     int(resp.Usage.PromptTokens),
+			Output:    int(resp.Usage.CompletionTokens),
+			Total:     int(resp.Usage.TotalTokens),
+			Cached:    int(resp.Usage.PromptTokensDetails.CachedTokens),
+			Reasoning: int(resp.Usage.CompletionTokensDetails.ReasoningTokens),
+		},
+		Raw:        resp,
+		JsonSchema: req.JSONSchema,
+	}, nil
+}
+
+// Embed generates vector embeddings for the provided input strings.
+func (p *Provider) Embed(ctx context.Context, req *llm.EmbedRequest) (*llm.EmbedResponse, error) {
+	if !p.embeddings {
+		return nil, fmt.Errorf("openai-compatible embed %s: %w", req.Model, llm.ErrUnsupportedOperation)
+	}
+
+	tid := obs.ExtractTraceID(ctx)
+	uid := obs.ExtractUserID(ctx)
+
+	l := logger.WithHook(p.logger,
+		logger.SinceHook("time", time.Now()),
+		func(ctx context.Context, r slog.Record) slog.Record {
+			r.Add("trace_id", tid)
+			r.Add("user_id", uid)
+			return r
+		})
+
+	params := openai.EmbeddingNewParams{
+		Model: openai.EmbeddingModel(req.Model),
+		Input: openai.EmbeddingNewParamsInputUnion{
+			OfArrayOfStrings: req.Input,
+		},
+		EncodingFormat: openai.EmbeddingNewParamsEncodingFormatFloat,
+	}
+
+	// Most servers reject dimensions for models without Matryoshka
+	// support, so only send it when asked for.
+	if req.Dimentions > 0 {
+		params.Dimensions = openai.Int(int64(req.Dimentions))
+	}
+
+	resp, err := p.client.Embeddings.New(ctx, params)
+	if err != nil {
+		l.LogAttrs(ctx, slog.LevelError,
+			"openai-compatible embed error",
+			slog.String("message", err.Error()),
+			slog.String("model", req.Model))
+		return nil, llm.NewAPIError("openai-compatible", llm.ErrEmbedAPIError, statusCode(err), err)
+	}
+
+	vectors := make([][]float32, len(resp.Data))
+	for i, d := range resp.Data {
+		vectors[i] = make([]float32, len(d.Embedding))
+		for j, f64 := range d.Embedding {
+			vectors[i][j] = float32(f64)
+		}
+	}
+
+	l.LogAttrs(ctx, slog.LevelInfo,
+		"openai-compatible embed success",
+		slog.String("model", resp.Model),
+		slog.Int("input_count", len(vectors)))
+
+	return &llm.EmbedResponse{
+		Model:   resp.Model,
+		Vectors: vectors,
+		Raw:     resp,
+	}, nil
+}
+
+func (p *Provider) Close() error {
+	return nil
+}
+
+// statusCode extracts the HTTP status from an SDK error, or 0 when the call
+// failed before a response arrived.
+func statusCode(err error) int {
+	var apiErr *openai.Error
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
@@ -0,0 +1,203 @@
+// Replay tests use cassettes captured by the manual smoke tests so the
+// OpenAI-compatible provider's request shaping, Chat Completions parsing,
+// capability gating and error classification can be exercised offline.
+// Run as part of the default `go test ./...`.
+package openaicompat_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/llm"
+	"github.com/ChiaYuChang/prism/internal/llm/openaicompat"
+	pkgschema "github.com/ChiaYuChang/prism/pkg/schema"
+	"github.com/ChiaYuChang/prism/pkg/utils"
+	"github.com/go-playground/mold/v4"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func newReplayProvider(t *testing.T, ctx context.Context, c cassette, cfg openaicompat.Config) (*openaicompat.Provider, *replayTransport) {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	tracer := noop.NewTracerProvider().Tracer("openaicompat-replay")
+	v := validator.New()
+	m := mold.New()
+	rt := &replayTransport{c: c}
+	hc := &http.Client{
+		Timeout:   5 * time.Second,
+		Transport: rt,
+	}
+	cfg.BaseURL = "http://replay-fixture.invalid/v1"
+	cfg.Timeout = 5 * time.Second
+	p, err := openaicompat.New(ctx, logger, tracer, v, m, hc, cfg)
+	require.NoError(t, err)
+	return p, rt
+}
+
+func TestOpenAICompatReplay_GenerateText(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	p, rt := newReplayProvider(t, ctx, loadCassette(t, "generate_text"), openaicompat.Config{})
+
+	resp, err := p.Generate(ctx, &llm.GenerateRequest{
+		Model:             "Qwen/Qwen2.5-7B-Instruct",
+		SystemInstruction: "Reply with a single short word.",
+		Prompt:            "Say hi.",
+		Temperature:       utils.Ptr(float32(0.0)),
+		MaxTokens:         utils.Ptr(16),
+		Format:            llm.ResponseFormatText,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Hi!", resp.Text)
+	require.Equal(t, llm.TokenUsage{Input: 24, Output: 3, Total: 27}, resp.Usage)
+
+	require.Equal(t, "/v1/chat/completions", rt.path)
+	require.Empty(t, rt.header.Get("Authorization"), "no key configured, no auth header")
+
+	var sent map[string]any
+	require.NoError(t, json.Unmarshal(rt.body, &sent))
+	require.EqualValues(t, 16, sent["max_tokens"])
+	require.NotContains(t, sent, "response_format")
+	require.Len(t, sent["messages"], 2)
+}
+
+func TestOpenAICompatReplay_GenerateJSONSchema(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	p, rt := newReplayProvider(t, ctx, loadCassette(t, "generate_jsonschema"),
+		openaicompat.Config{StructuredOutput: true})
+
+	type Greeting struct {
+		Greeting string `json:"greeting"`
+		Language string `json:"language"`
+	}
+	schema := pkgschema.NewSkeleton[Greeting]("greeting", 1)
+
+	resp, err := p.Generate(ctx, &llm.GenerateRequest{
+		Model:       "Qwen/Qwen2.5-7B-Instruct",
+		Prompt:      "Greet someone in Traditional Chinese.",
+		Temperature: utils.Ptr(float32(0.0)),
+		Format:      llm.ResponseFormatJsonSchema,
+		JSONSchema:  schema,
+	})
+	require.NoError(t, err)
+
+	var out Greeting
+	require.NoError(t, resp.DecodeJSONSchema(&out))
+	require.Equal(t, "您好", out.Greeting)
+	require.Equal(t, "zh", out.Language)
+
+	var sent struct {
+		ResponseFormat struct {
+			Type       string `json:"type"`
+			JSONSchema struct {
+				Name   string `json:"name"`
+				Strict bool   `json:"strict"`
+			} `json:"json_schema"`
+		} `json:"response_format"`
+	}
+	require.NoError(t, json.Unmarshal(rt.body, &sent))
+	require.Equal(t, "json_schema", sent.ResponseFormat.Type)
+	require.Equal(t, "greeting", sent.ResponseFormat.JSONSchema.Name)
+	require.True(t, sent.ResponseFormat.JSONSchema.Strict)
+}
+
+func TestOpenAICompat_StructuredOutputDisabled(t *testing.T) {
+	ctx := context.Background()
+	p, rt := newReplayProvider(t, ctx, cassette{}, openaicompat.Config{})
+
+	type Greeting struct {
+		Greeting string `json:"greeting"`
+	}
+	_, err := p.Generate(ctx, &llm.GenerateRequest{
+		Model:      "any",
+		Prompt:     "Greet.",
+		Format:     llm.ResponseFormatJsonSchema,
+		JSONSchema: pkgschema.NewSkeleton[Greeting]("greeting", 1),
+	})
+	require.ErrorIs(t, err, llm.ErrUnsupportedOperation)
+	require.Nil(t, rt.body, "no request may reach the server")
+}
+
+func TestOpenAICompatReplay_Embed(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	p, rt := newReplayProvider(t, ctx, loadCassette(t, "embed"), openaicompat.Config{Embeddings: true})
+
+	resp, err := p.Embed(ctx, &llm.EmbedRequest{
+		Model: "BAAI/bge-m3",
+		Input: []string{"立法院今日通過國會改革法案"},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Vectors, 1)
+	require.Len(t, resp.Vectors[0], 8)
+	require.Equal(t, "/v1/embeddings", rt.path)
+
+	var sent map[string]any
+	require.NoError(t, json.Unmarshal(rt.body, &sent))
+	require.NotContains(t, sent, "dimensions", "dimensions must only be sent when requested")
+}
+
+func TestOpenAICompat_EmbeddingsDisabled(t *testing.T) {
+	ctx := context.Background()
+	p, _ := newReplayProvider(t, ctx, cassette{}, openaicompat.Config{})
+
+	_, err := p.Embed(ctx, &llm.EmbedRequest{Model: "any", Input: []string{"a"}})
+	require.ErrorIs(t, err, llm.ErrUnsupportedOperation)
+}
+
+func TestOpenAICompatReplay_Auth(t *testing.T) {
+	cases := []struct {
+		name   string
+		cfg    openaicompat.Config
+		header string
+		want   string
+	}{
+		{name: "Bearer", cfg: openaicompat.Config{APIKey: "secret"}, header: "Authorization", want: "Bearer secret"},
+		{name: "CustomHeader", cfg: openaicompat.Config{APIKey: "secret", AuthHeader: "X-API-Key"}, header: "X-API-Key", want: "secret"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			t.Setenv("OPENAI_API_KEY", "must-not-leak")
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			p, rt := newReplayProvider(t, ctx, loadCassette(t, "generate_text"), c.cfg)
+
+			_, err := p.Generate(ctx, llm.NewGenerateRequest("Qwen/Qwen2.5-7B-Instruct", "", "Say hi."))
+			require.NoError(t, err)
+			require.Equal(t, c.want, rt.header.Get(c.header))
+			if c.header != "Authorization" {
+				require.Empty(t, rt.header.Get("Authorization"))
+			}
+		})
+	}
+}
+
+// TestOpenAICompatReplay_ModelNotFound exercises the 404 error path: the
+// provider must surface a wrapped llm.ErrGenAPIError so callers can
+// classify failures without inspecting strings.
+func TestOpenAICompatReplay_ModelNotFound(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	p, _ := newReplayProvider(t, ctx, loadCassette(t, "model_not_found"), openaicompat.Config{})
+
+	_, err := p.Generate(ctx, &llm.GenerateRequest{
+		Model:  "definitely-not-a-real-model",
+		Prompt: "Say hi.",
+		Format: llm.ResponseFormatText,
+	})
+	require.Error(t, err)
+	require.ErrorIs(t, err, llm.ErrGenAPIError)
+
+	var apiErr *llm.APIError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, 404, apiErr.StatusCode)
+	require.False(t, llm.IsRetryable(err))
+}
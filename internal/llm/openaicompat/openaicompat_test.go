@@ -0,0 +1,174 @@
+//go:build manual
+
+// Manual smoke tests against a local OpenAI-compatible server (vLLM by
+// default).
+//
+// Run with `go test -tags=manual -count=1 -run OpenAICompat ./internal/llm/openaicompat/...`.
+//
+// Env knobs:
+//
+//	PRISM_OPENAI_COMPAT_RECORD=1             capture cassettes
+//	PRISM_OPENAI_COMPAT_BASE_URL=...         default http://localhost:8000/v1
+//	PRISM_OPENAI_COMPAT_KEY_FILE=path / PRISM_OPENAI_COMPAT_KEY=raw (optional)
+//	PRISM_OPENAI_COMPAT_GENERATE_MODEL=...   default Qwen/Qwen2.5-7B-Instruct
+//	PRISM_OPENAI_COMPAT_EMBED_MODEL=...      default BAAI/bge-m3
+package openaicompat_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/llm"
+	"github.com/ChiaYuChang/prism/internal/llm/openaicompat"
+	pkgschema "github.com/ChiaYuChang/prism/pkg/schema"
+	"github.com/ChiaYuChang/prism/pkg/utils"
+	"github.com/go-playground/mold/v4"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const (
+	defaultBaseURL        = "http://localhost:8000/v1"
+	defaultGenerateModel  = "Qwen/Qwen2.5-7B-Instruct"
+	defaultEmbeddingModel = "BAAI/bge-m3"
+)
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// loadAPIKey returns the key without ever logging it. Self-hosted servers
+// often run without one, so an empty key is fine.
+func loadAPIKey(t *testing.T) string {
+	t.Helper()
+	if path := os.Getenv("PRISM_OPENAI_COMPAT_KEY_FILE"); path != "" {
+		raw, err := os.ReadFile(path)
+		require.NoError(t, err)
+		return strings.TrimSpace(string(raw))
+	}
+	return strings.TrimSpace(os.Getenv("PRISM_OPENAI_COMPAT_KEY"))
+}
+
+func newRecordingProvider(t *testing.T, ctx context.Context, cassetteName string) *openaicompat.Provider {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	tracer := noop.NewTracerProvider().Tracer("openaicompat-smoke")
+	v := validator.New()
+	m := mold.New()
+	hc := &http.Client{
+		Timeout: 120 * time.Second,
+		Transport: &recordingTransport{
+			t:    t,
+			name: cassetteName,
+			base: http.DefaultTransport,
+		},
+	}
+	p, err := openaicompat.New(ctx, logger, tracer, v, m, hc, openaicompat.Config{
+		BaseURL:          envOr("PRISM_OPENAI_COMPAT_BASE_URL", defaultBaseURL),
+		APIKey:           loadAPIKey(t),
+		Timeout:          120 * time.Second,
+		StructuredOutput: true,
+		Embeddings:       true,
+	})
+	require.NoError(t, err)
+	return p
+}
+
+func TestOpenAICompatGenerate_Text(t *testing.T) {
+	if !recordingEnabled() {
+		t.Skip("set PRISM_OPENAI_COMPAT_RECORD=1 to call the local server and capture cassette")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+	p := newRecordingProvider(t, ctx, "generate_text")
+
+	resp, err := p.Generate(ctx, &llm.GenerateRequest{
+		Model:             envOr("PRISM_OPENAI_COMPAT_GENERATE_MODEL", defaultGenerateModel),
+		SystemInstruction: "Reply with a single short word.",
+		Prompt:            "Say hi.",
+		Temperature:       utils.Ptr(float32(0.0)),
+		Format:            llm.ResponseFormatText,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.Text)
+	t.Logf("model=%s text_len=%d total_tokens=%d", resp.Model, len(resp.Text), resp.Usage.Total)
+}
+
+// TestOpenAICompatGenerate_JSONSchema exercises response_format=json_schema,
+// which vLLM serves through guided decoding.
+func TestOpenAICompatGenerate_JSONSchema(t *testing.T) {
+	if !recordingEnabled() {
+		t.Skip("set PRISM_OPENAI_COMPAT_RECORD=1 to call the local server and capture cassette")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+	p := newRecordingProvider(t, ctx, "generate_jsonschema")
+
+	type Greeting struct {
+		Greeting string `json:"greeting"`
+		Language string `json:"language"`
+	}
+	schema := pkgschema.NewSkeleton[Greeting]("greeting", 1)
+
+	resp, err := p.Generate(ctx, &llm.GenerateRequest{
+		Model:       envOr("PRISM_OPENAI_COMPAT_GENERATE_MODEL", defaultGenerateModel),
+		Prompt:      "Greet someone in Traditional Chinese.",
+		Temperature: utils.Ptr(float32(0.0)),
+		Format:      llm.ResponseFormatJsonSchema,
+		JSONSchema:  schema,
+	})
+	require.NoError(t, err)
+
+	var out Greeting
+	require.NoError(t, resp.DecodeJSONSchema(&out))
+	require.NotEmpty(t, out.Greeting)
+	require.NotEmpty(t, out.Language)
+	t.Logf("model=%s greeting_len=%d language=%s", resp.Model, len(out.Greeting), out.Language)
+}
+
+func TestOpenAICompatEmbed(t *testing.T) {
+	if !recordingEnabled() {
+		t.Skip("set PRISM_OPENAI_COMPAT_RECORD=1 to call the local server and capture cassette")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+	p := newRecordingProvider(t, ctx, "embed")
+
+	resp, err := p.Embed(ctx, &llm.EmbedRequest{
+		Model: envOr("PRISM_OPENAI_COMPAT_EMBED_MODEL", defaultEmbeddingModel),
+		Input: []string{"立法院今日通過國會改革法案"},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Vectors, 1)
+	require.NotEmpty(t, resp.Vectors[0])
+	t.Logf("model=%s dims=%d", resp.Model, len(resp.Vectors[0]))
+}
+
+// TestOpenAICompatModelNotFound captures the error returned for an unknown
+// model. Replay tests assert classification as llm.ErrGenAPIError.
+func TestOpenAICompatModelNotFound(t *testing.T) {
+	if !recordingEnabled() {
+		t.Skip("set PRISM_OPENAI_COMPAT_RECORD=1 to call the local server and capture cassette")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	p := newRecordingProvider(t, ctx, "model_not_found")
+
+	_, err := p.Generate(ctx, &llm.GenerateRequest{
+		Model:  "definitely-not-a-real-model",
+		Prompt: "Say hi.",
+		Format: llm.ResponseFormatText,
+	})
+	require.Error(t, err)
+	require.ErrorIs(t, err, llm.ErrGenAPIError)
+}
@@ -1,5 +1,7 @@
 package model
 
+import "github.com/google/uuid"
+
 // ExtractionInput represents the input for the extraction process.
 type ExtractionInput struct {
 	Title string `json:"title"`
@@ -18,6 +20,22 @@ type ExtractionOutput struct {
 	Phrases []string `json:"phrases"`
 	// Summary provides a concise multi-sentence overview for auditability.
 	Summary string `json:"summary"`
+
+	// Provenance records how the output was produced. It is filled in by
+	// the extractor, never by the LLM, and is not part of the response
+	// schema.
+	Provenance ExtractionProvenance `json:"-"`
+}
+
+// ExtractionProvenance pins an extraction to the model, prompt version and
+// response schema that produced it, mirroring the content_extractions
+// provenance columns.
+type ExtractionProvenance struct {
+	Model         string
+	PromptID      uuid.UUID
+	PromptVersion string
+	SchemaName    string
+	SchemaVersion int32
 }
 
 // ExtractionEntity represents a normalized named entity extracted from content.
@@ -89,6 +89,6 @@ type ArchiveRecord struct {
 type Source struct {
 	Abbr    string `json:"abbr"`
 	Name    string `json:"name"`
-	Type    string `json:"type"` // e.g., "MEDIA", "PARTY"
+	Type    string `json:"type"` // e.g., "MEDIA", "PARTY", "GOVERNMENT"
 	BaseURL string `json:"base_url"`
 }
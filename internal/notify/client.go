@@ -0,0 +1,67 @@
+package notify
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// sharedAddressSpace is the carrier-grade NAT range (RFC 6598), which
+// netip does not count as private.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// PublicAddr reports whether ip is a globally routable unicast address.
+// Loopback, private, link-local (including the 169.254.169.254 cloud
+// metadata endpoint), shared, unspecified and multicast addresses are not.
+func PublicAddr(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	return ip.IsValid() &&
+		!ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsUnspecified() &&
+		!ip.IsMulticast() &&
+		!sharedAddressSpace.Contains(ip) &&
+		!(ip.Is4() && ip.As4()[0] == 0)
+}
+
+// NewHTTPClient returns the client webhooks are delivered with. It never
+// follows redirects, so a receiver cannot bounce the signed payload
+// elsewhere, and ignores proxy settings.
+//
+// Unless allowPrivate is set, it refuses to connect to addresses that are
+// not PublicAddr. The check runs in the dialer's Control hook on the
+// address actually dialled, so a hostname that resolves to a private
+// address at delivery time (DNS rebinding) is refused too. allowPrivate is
+// meant for local development against cmd/dev/mock-server.
+func NewHTTPClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, KeepAlive: 30 * time.Second}
+	if !allowPrivate {
+		dialer.Control = publicOnly
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// publicOnly is a net.Dialer Control hook that rejects non-public targets.
+func publicOnly(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrTargetNotAllowed, address)
+	}
+	if !PublicAddr(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", ErrTargetNotAllowed, addrPort.Addr())
+	}
+	return nil
+}
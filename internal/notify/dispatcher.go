@@ -0,0 +1,273 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/repo"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	defaultMaxAttempts = 8
+	defaultBaseBackoff = 30 * time.Second
+	defaultMaxBackoff  = time.Hour
+	defaultLease       = 2 * time.Minute
+
+	userAgent = "prism-webhook/1"
+	// maxErrorBody caps how much of a failed response is kept in last_error.
+	maxErrorBody = 512
+)
+
+// Config tunes delivery retries. Zero values take the defaults: 8 attempts,
+// 30s doubling to at most 1h between them, and a 2m lease per attempt.
+type Config struct {
+	// MaxAttempts counts the first delivery; the row is marked FAILED after
+	// the last one.
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// Lease hides a claimed delivery from other notifier instances while it
+	// is in flight. It must exceed the HTTP client timeout.
+	Lease time.Duration
+}
+
+func (c Config) withDefaults() Config {
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = defaultMaxAttempts
+	}
+	if c.BaseBackoff <= 0 {
+		c.BaseBackoff = defaultBaseBackoff
+	}
+	if c.MaxBackoff <= 0 {
+		c.MaxBackoff = defaultMaxBackoff
+	}
+	if c.Lease <= 0 {
+		c.Lease = defaultLease
+	}
+	return c
+}
+
+// Dispatcher detects completed user fetches and delivers their webhooks.
+// It is safe to run several instances against one database: completion is
+// an optimistic claim and deliveries are leased with SKIP LOCKED.
+type Dispatcher struct {
+	logger *slog.Logger
+	tracer trace.Tracer
+	repo   repo.UserFetches
+	client *http.Client
+	cfg    Config
+	now    func() time.Time
+}
+
+// NewDispatcher builds a Dispatcher that delivers with client. Production
+// callers pass NewHTTPClient, which refuses non-public targets.
+func NewDispatcher(logger *slog.Logger, tracer trace.Tracer, r repo.UserFetches, client *http.Client, cfg Config) (*Dispatcher, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("%w: logger", ErrParamMissing)
+	}
+	if tracer == nil {
+		return nil, fmt.Errorf("%w: tracer", ErrParamMissing)
+	}
+	if r == nil {
+		return nil, fmt.Errorf("%w: user_fetches_repository", ErrParamMissing)
+	}
+	if client == nil {
+		return nil, fmt.Errorf("%w: http_client", ErrParamMissing)
+	}
+	return &Dispatcher{
+		logger: logger,
+		tracer: tracer,
+		repo:   r,
+		client: client,
+		cfg:    cfg.withDefaults(),
+		now:    time.Now,
+	}, nil
+}
+
+// Sweep marks up to limit newly terminal fetches as completed and enqueues a
+// fetch.completed delivery for those submitted with a notify block. It
+// returns the number of fetches this call completed.
+//
+// The delivery is enqueued before completed_at is set: if the process dies
+// in between, the fetch is still open on the next sweep and the enqueue is
+// idempotent, so a notification is never lost or sent twice.
+func (d *Dispatcher) Sweep(ctx context.Context, limit int32) (int, error) {
+	ctx, span := d.tracer.Start(ctx, "notify.dispatcher.sweep")
+	defer span.End()
+
+	fetches, err := d.repo.ListTerminal(ctx, limit)
+	if err != nil {
+		return 0, fmt.Errorf("list terminal fetches: %w", err)
+	}
+
+	completed := 0
+	for _, fetch := range fetches {
+		if fetch.NotifyURL != nil {
+			if err := d.enqueue(ctx, fetch); err != nil {
+				return completed, err
+			}
+		}
+
+		rows, err := d.repo.MarkCompleted(ctx, fetch.ID)
+		if err != nil {
+			return completed, fmt.Errorf("mark fetch %s completed: %w", fetch.ID, err)
+		}
+		if rows == 0 {
+			d.logger.InfoContext(ctx, "fetch already completed by another instance; skipping",
+				slog.String("fetch_id", fetch.ID.String()))
+			continue
+		}
+		completed++
+		d.logger.InfoContext(ctx, "fetch marked as completed",
+			slog.String("fetch_id", fetch.ID.String()),
+			slog.Bool("notify", fetch.NotifyURL != nil))
+	}
+	return completed, nil
+}
+
+func (d *Dispatcher) enqueue(ctx context.Context, fetch repo.UserFetch) error {
+	progress, err := d.repo.GetProgress(ctx, fetch.ID)
+	if err != nil {
+		return fmt.Errorf("get fetch %s progress: %w", fetch.ID, err)
+	}
+	payload, err := json.Marshal(FetchCompletedEvent{
+		Event:           EventFetchCompleted,
+		FetchID:         fetch.ID,
+		CompletedAt:     d.now().UTC(),
+		Total:           progress.Total,
+		Pending:         newCandidateGroup(progress.PendingCandidateIDs),
+		Running:         newCandidateGroup(progress.RunningCandidateIDs),
+		Completed:       newCandidateGroup(progress.CompletedCandidateIDs),
+		Failed:          newCandidateGroup(progress.FailedCandidateIDs),
+		AlreadyComplete: newCandidateGroup(progress.AlreadyCompleteCandidateIDs),
+	})
+	if err != nil {
+		return fmt.Errorf("marshal fetch %s event: %w", fetch.ID, err)
+	}
+	if _, err := d.repo.EnqueueNotification(ctx, repo.CreateFetchNotificationParams{
+		FetchID: fetch.ID,
+		URL:     *fetch.NotifyURL,
+		Payload: payload,
+	}); err != nil {
+		return fmt.Errorf("enqueue fetch %s notification: %w", fetch.ID, err)
+	}
+	return nil
+}
+
+// Deliver sends up to limit due webhooks and records each attempt. It
+// returns the number delivered successfully. A failed attempt is an outcome,
+// not an error: only repository failures are returned.
+//
+// 2xx marks the delivery DELIVERED. 408, 429, 5xx and transport errors are
+// retried with exponential backoff until MaxAttempts; any other status, or a
+// target refused with ErrTargetNotAllowed, fails the delivery immediately.
+func (d *Dispatcher) Deliver(ctx context.Context, limit int32) (int, error) {
+	ctx, span := d.tracer.Start(ctx, "notify.dispatcher.deliver")
+	defer span.End()
+
+	notifications, err := d.repo.ClaimDueNotifications(ctx, limit, d.cfg.Lease)
+	if err != nil {
+		return 0, fmt.Errorf("claim due notifications: %w", err)
+	}
+
+	delivered := 0
+	for _, n := range notifications {
+		code, sendErr := d.send(ctx, n)
+		attempt := int(n.Attempts) + 1
+
+		record := repo.RecordFetchNotificationAttemptParams{
+			ID:            n.ID,
+			Status:        repo.FetchNotificationStatusDelivered,
+			NextAttemptAt: d.now(),
+		}
+		if code != 0 {
+			c := int32(code)
+			record.StatusCode = &c
+		}
+		level := slog.LevelInfo
+		if sendErr != nil {
+			msg := sendErr.Error()
+			record.Error = &msg
+			level = slog.LevelWarn
+			switch {
+			case !retryable(code) || errors.Is(sendErr, ErrTargetNotAllowed) || attempt >= d.cfg.MaxAttempts:
+				record.Status = repo.FetchNotificationStatusFailed
+				level = slog.LevelError
+			default:
+				record.Status = repo.FetchNotificationStatusPending
+				record.NextAttemptAt = d.now().Add(d.backoff(attempt))
+			}
+		} else {
+			delivered++
+		}
+
+		// The attempt already happened; record it even if shutdown began.
+		if err := d.repo.RecordNotificationAttempt(context.WithoutCancel(ctx), record); err != nil {
+			return delivered, fmt.Errorf("record notification %s attempt: %w", n.ID, err)
+		}
+		d.logger.LogAttrs(ctx, level, "fetch webhook attempt",
+			slog.String("notification_id", n.ID.String()),
+			slog.String("fetch_id", n.FetchID.String()),
+			slog.Int("attempt", attempt),
+			slog.Int("status_code", code),
+			slog.String("status", record.Status),
+			slog.Time("next_attempt_at", record.NextAttemptAt))
+	}
+	return delivered, nil
+}
+
+// send POSTs one delivery and returns the response status (0 when no
+// response arrived) and a non-nil error unless it was 2xx.
+func (d *Dispatcher) send(ctx context.Context, n repo.FetchNotification) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(n.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("build request: %w", err)
+	}
+	ts := d.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(HeaderEvent, EventFetchCompleted)
+	req.Header.Set(HeaderDelivery, n.ID.String())
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(ts, 10))
+	req.Header.Set(HeaderSignature, Sign(n.Secret, ts, n.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return resp.StatusCode, nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	return resp.StatusCode, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+}
+
+// backoff returns the wait before the attempt after the given one.
+func (d *Dispatcher) backoff(attempt int) time.Duration {
+	wait := d.cfg.BaseBackoff
+	for i := 1; i < attempt && wait < d.cfg.MaxBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, d.cfg.MaxBackoff)
+}
+
+// retryable reports whether a failed attempt may succeed later. Code 0 means
+// the request never got a response.
+func retryable(code int) bool {
+	return code == 0 ||
+		code == http.StatusRequestTimeout ||
+		code == http.StatusTooManyRequests ||
+		code >= 500
+}
@@ -0,0 +1,57 @@
+// Package notify delivers webhook notifications for user fetches
+// (POST /api/v1/page_fetch). A Dispatcher sweeps for fetches whose items
+// have all reached a terminal status, sets fetches.completed_at, freezes a
+// fetch.completed payload into fetch_notifications and delivers it as an
+// HMAC-signed POST with retries.
+package notify
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrParamMissing = errors.New("param missing")
+	// ErrInvalidSignature is returned by Verify when the signature header is
+	// malformed or does not match the body.
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	// ErrStaleTimestamp is returned by Verify when the timestamp header is
+	// outside the accepted tolerance, which guards against replays.
+	ErrStaleTimestamp = errors.New("webhook timestamp outside tolerance")
+	// ErrTargetNotAllowed is returned when a webhook target resolves to an
+	// address that is not PublicAddr. Such deliveries are not retried.
+	ErrTargetNotAllowed = errors.New("webhook target address not allowed")
+)
+
+// EventFetchCompleted is the only event type delivered today.
+const EventFetchCompleted = "fetch.completed"
+
+// FetchCompletedEvent is the JSON body of a fetch.completed webhook. The
+// candidate groups mirror GET /api/v1/fetches/{id}, so a receiver can use
+// the same decoder for both.
+type FetchCompletedEvent struct {
+	Event           string         `json:"event"`
+	FetchID         uuid.UUID      `json:"fetch_id"`
+	CompletedAt     time.Time      `json:"completed_at"`
+	Total           int64          `json:"total"`
+	Pending         CandidateGroup `json:"pending"`
+	Running         CandidateGroup `json:"running"`
+	Completed       CandidateGroup `json:"completed"`
+	Failed          CandidateGroup `json:"failed"`
+	AlreadyComplete CandidateGroup `json:"already_complete"`
+}
+
+// CandidateGroup lists the candidates of a fetch in one resolved status.
+type CandidateGroup struct {
+	Count        int         `json:"count"`
+	CandidateIDs []uuid.UUID `json:"candidate_ids"`
+}
+
+func newCandidateGroup(ids []uuid.UUID) CandidateGroup {
+	if ids == nil {
+		ids = []uuid.UUID{}
+	}
+	return CandidateGroup{Count: len(ids), CandidateIDs: ids}
+}
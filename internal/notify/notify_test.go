@@ -0,0 +1,227 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/repo"
+	"github.com/ChiaYuChang/prism/internal/repo/mocks"
+	"github.com/ChiaYuChang/prism/pkg/testutils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+var testNow = time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestDispatcher(t *testing.T, r repo.UserFetches, cfg Config) *Dispatcher {
+	t.Helper()
+	d, err := NewDispatcher(testutils.Logger(), noop.NewTracerProvider().Tracer("test"), r, http.DefaultClient, cfg)
+	require.NoError(t, err)
+	d.now = func() time.Time { return testNow }
+	return d
+}
+
+func TestSignVerify(t *testing.T) {
+	body := []byte(`{"event":"fetch.completed"}`)
+	ts := strconv.FormatInt(testNow.Unix(), 10)
+	sig := Sign("s3cret", testNow.Unix(), body)
+
+	require.NoError(t, Verify("s3cret", ts, sig, body, 5*time.Minute, testNow))
+	require.ErrorIs(t, Verify("other", ts, sig, body, 5*time.Minute, testNow), ErrInvalidSignature)
+	require.ErrorIs(t, Verify("s3cret", ts, sig, []byte(`{}`), 5*time.Minute, testNow), ErrInvalidSignature)
+	require.ErrorIs(t, Verify("s3cret", ts, sig[len(signaturePrefix):], body, 5*time.Minute, testNow), ErrInvalidSignature)
+	require.ErrorIs(t, Verify("s3cret", ts, sig, body, 5*time.Minute, testNow.Add(time.Hour)), ErrStaleTimestamp)
+	require.NoError(t, Verify("s3cret", ts, sig, body, 0, testNow.Add(time.Hour)))
+}
+
+func TestDispatcher_Sweep(t *testing.T) {
+	url := "https://hooks.example.com/prism"
+	notified := repo.UserFetch{ID: uuid.New(), NotifyURL: &url}
+	silent := repo.UserFetch{ID: uuid.New()}
+	raced := repo.UserFetch{ID: uuid.New()}
+	done, failed := uuid.New(), uuid.New()
+
+	mRepo := mocks.NewMockUserFetches(t)
+	mRepo.EXPECT().ListTerminal(mock.Anything, int32(10)).
+		Return([]repo.UserFetch{notified, silent, raced}, nil).Once()
+	mRepo.EXPECT().GetProgress(mock.Anything, notified.ID).Return(repo.UserFetchProgress{
+		Total:                 2,
+		CompletedCandidateIDs: []uuid.UUID{done},
+		FailedCandidateIDs:    []uuid.UUID{failed},
+		Terminal:              true,
+	}, nil).Once()
+	mRepo.EXPECT().EnqueueNotification(mock.Anything, mock.MatchedBy(func(p repo.CreateFetchNotificationParams) bool {
+		var event FetchCompletedEvent
+		require.NoError(t, json.Unmarshal(p.Payload, &event))
+		return p.FetchID == notified.ID &&
+			p.URL == url &&
+			event.Event == EventFetchCompleted &&
+			event.Total == 2 &&
+			event.Completed.CandidateIDs[0] == done &&
+			event.Failed.Count == 1 &&
+			event.Pending.CandidateIDs != nil
+	})).Return(int64(1), nil).Once()
+	mRepo.EXPECT().MarkCompleted(mock.Anything, notified.ID).Return(int64(1), nil).Once()
+	mRepo.EXPECT().MarkCompleted(mock.Anything, silent.ID).Return(int64(1), nil).Once()
+	mRepo.EXPECT().MarkCompleted(mock.Anything, raced.ID).Return(int64(0), nil).Once()
+
+	got, err := newTestDispatcher(t, mRepo, Config{}).Sweep(context.Background(), 10)
+	require.NoError(t, err)
+	require.Equal(t, 2, got)
+}
+
+func TestDispatcher_Deliver(t *testing.T) {
+	cases := []struct {
+		name        string
+		status      int
+		attempts    int32
+		maxAttempts int
+		wantStatus  string
+		wantNext    time.Time
+	}{
+		{name: "Delivered", status: http.StatusNoContent, maxAttempts: 3, wantStatus: repo.FetchNotificationStatusDelivered, wantNext: testNow},
+		{name: "RetryServerError", status: http.StatusBadGateway, attempts: 2, maxAttempts: 5, wantStatus: repo.FetchNotificationStatusPending, wantNext: testNow.Add(4 * time.Second)},
+		{name: "FailFastClientError", status: http.StatusGone, maxAttempts: 3, wantStatus: repo.FetchNotificationStatusFailed, wantNext: testNow},
+		{name: "FailAfterLastAttempt", status: http.StatusTooManyRequests, attempts: 2, maxAttempts: 3, wantStatus: repo.FetchNotificationStatusFailed, wantNext: testNow},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			n := repo.FetchNotification{
+				ID:       uuid.New(),
+				FetchID:  uuid.New(),
+				Payload:  []byte(`{"event":"fetch.completed"}`),
+				Attempts: c.attempts,
+				Secret:   "s3cret",
+			}
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				require.Equal(t, EventFetchCompleted, r.Header.Get(HeaderEvent))
+				require.Equal(t, n.ID.String(), r.Header.Get(HeaderDelivery))
+				require.NoError(t, Verify("s3cret", r.Header.Get(HeaderTimestamp), r.Header.Get(HeaderSignature), body, time.Minute, testNow))
+				w.WriteHeader(c.status)
+			}))
+			defer srv.Close()
+			n.URL = srv.URL
+
+			cfg := Config{MaxAttempts: c.maxAttempts, BaseBackoff: time.Second, Lease: time.Minute}
+
+			mRepo := mocks.NewMockUserFetches(t)
+			mRepo.EXPECT().ClaimDueNotifications(mock.Anything, int32(5), time.Minute).
+				Return([]repo.FetchNotification{n}, nil).Once()
+			mRepo.EXPECT().RecordNotificationAttempt(mock.Anything, mock.MatchedBy(func(p repo.RecordFetchNotificationAttemptParams) bool {
+				return p.ID == n.ID &&
+					p.Status == c.wantStatus &&
+					p.StatusCode != nil && int(*p.StatusCode) == c.status &&
+					p.NextAttemptAt.Equal(c.wantNext) &&
+					(p.Error == nil) == (c.wantStatus == repo.FetchNotificationStatusDelivered)
+			})).Return(nil).Once()
+
+			got, err := newTestDispatcher(t, mRepo, cfg).Deliver(context.Background(), 5)
+			require.NoError(t, err)
+			if c.wantStatus == repo.FetchNotificationStatusDelivered {
+				require.Equal(t, 1, got)
+			} else {
+				require.Zero(t, got)
+			}
+		})
+	}
+}
+
+func TestDispatcher_DeliverTransportErrorIsRetried(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL
+	srv.Close()
+
+	n := repo.FetchNotification{ID: uuid.New(), URL: url, Payload: []byte(`{}`), Secret: "s3cret"}
+	mRepo := mocks.NewMockUserFetches(t)
+	mRepo.EXPECT().ClaimDueNotifications(mock.Anything, int32(1), defaultLease).
+		Return([]repo.FetchNotification{n}, nil).Once()
+	mRepo.EXPECT().RecordNotificationAttempt(mock.Anything, mock.MatchedBy(func(p repo.RecordFetchNotificationAttemptParams) bool {
+		return p.Status == repo.FetchNotificationStatusPending &&
+			p.StatusCode == nil &&
+			p.Error != nil &&
+			p.NextAttemptAt.Equal(testNow.Add(defaultBaseBackoff))
+	})).Return(nil).Once()
+
+	got, err := newTestDispatcher(t, mRepo, Config{}).Deliver(context.Background(), 1)
+	require.NoError(t, err)
+	require.Zero(t, got)
+}
+
+func TestPublicAddr(t *testing.T) {
+	cases := map[string]bool{
+		"93.184.216.34":   true,
+		"2606:4700::1111": true,
+		"127.0.0.1":       false,
+		"10.1.2.3":        false,
+		"172.16.0.1":      false,
+		"192.168.1.1":     false,
+		"169.254.169.254": false,
+		"100.64.0.1":      false,
+		"0.0.0.0":         false,
+		"0.1.2.3":         false,
+		"224.0.0.1":       false,
+		"::1":             false,
+		"fd00::1":         false,
+		"fe80::1":         false,
+		"::ffff:10.0.0.1": false,
+	}
+	for addr, want := range cases {
+		require.Equal(t, want, PublicAddr(netip.MustParseAddr(addr)), addr)
+	}
+}
+
+func TestNewHTTPClient_RefusesPrivateTargets(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+	_, port, err := net.SplitHostPort(srv.Listener.Addr().String())
+	require.NoError(t, err)
+
+	// A hostname is checked after resolution, at connect time.
+	for _, target := range []string{srv.URL, "http://localhost:" + port} {
+		_, err := NewHTTPClient(time.Second, false).Get(target)
+		require.ErrorIs(t, err, ErrTargetNotAllowed, target)
+	}
+	require.Zero(t, hits.Load())
+
+	resp, err := NewHTTPClient(time.Second, true).Get(srv.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+}
+
+func TestDispatcher_DeliverRefusedTargetFails(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	n := repo.FetchNotification{ID: uuid.New(), URL: srv.URL, Payload: []byte(`{}`), Secret: "s3cret"}
+	mRepo := mocks.NewMockUserFetches(t)
+	mRepo.EXPECT().ClaimDueNotifications(mock.Anything, int32(1), defaultLease).
+		Return([]repo.FetchNotification{n}, nil).Once()
+	mRepo.EXPECT().RecordNotificationAttempt(mock.Anything, mock.MatchedBy(func(p repo.RecordFetchNotificationAttemptParams) bool {
+		return p.Status == repo.FetchNotificationStatusFailed &&
+			p.StatusCode == nil &&
+			p.Error != nil
+	})).Return(nil).Once()
+
+	d, err := NewDispatcher(testutils.Logger(), noop.NewTracerProvider().Tracer("test"), mRepo, NewHTTPClient(time.Second, false), Config{})
+	require.NoError(t, err)
+	got, err := d.Deliver(context.Background(), 1)
+	require.NoError(t, err)
+	require.Zero(t, got)
+}
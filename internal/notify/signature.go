@@ -0,0 +1,55 @@
+package notify
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Webhook request headers.
+const (
+	HeaderEvent     = "X-Prism-Event"
+	HeaderDelivery  = "X-Prism-Delivery"
+	HeaderTimestamp = "X-Prism-Timestamp"
+	HeaderSignature = "X-Prism-Signature"
+)
+
+const signaturePrefix = "sha256="
+
+// Sign returns the X-Prism-Signature value for body sent at timestamp
+// (Unix seconds): "sha256=" followed by the hex HMAC-SHA256 of
+// "<timestamp>.<body>" keyed with secret. Binding the timestamp into the MAC
+// lets receivers reject replays without trusting the header alone.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte{'.'})
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a received webhook against secret. timestamp and signature
+// are the raw X-Prism-Timestamp and X-Prism-Signature header values. A
+// non-positive tolerance skips the freshness check.
+func Verify(secret, timestamp, signature string, body []byte, tolerance time.Duration, now time.Time) error {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: bad timestamp %q", ErrInvalidSignature, timestamp)
+	}
+	if tolerance > 0 {
+		if d := now.Sub(time.Unix(ts, 0)); d > tolerance || d < -tolerance {
+			return fmt.Errorf("%w: %s", ErrStaleTimestamp, d.Truncate(time.Second))
+		}
+	}
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return fmt.Errorf("%w: missing %s prefix", ErrInvalidSignature, signaturePrefix)
+	}
+	if !hmac.Equal([]byte(signature), []byte(Sign(secret, ts, body))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
@@ -15,6 +15,7 @@ const (
 
 	traceIDKey contextKey = "prism.trace_id"
 	userIDKey  contextKey = "prism.user_id"
+	batchIDKey contextKey = "prism.batch_id"
 )
 
 // WithTraceID returns a new context with the manually injected trace ID.
@@ -51,3 +52,16 @@ func ExtractUserID(ctx context.Context) uuid.UUID {
 	}
 	return uuid.Nil
 }
+
+// WithBatchID returns a new context with the planner batch ID.
+func WithBatchID(ctx context.Context, batchID uuid.UUID) context.Context {
+	return context.WithValue(ctx, batchIDKey, batchID)
+}
+
+// ExtractBatchID retrieves the BatchID from context.
+func ExtractBatchID(ctx context.Context) uuid.UUID {
+	if v, ok := ctx.Value(batchIDKey).(uuid.UUID); ok {
+		return v
+	}
+	return uuid.Nil
+}
@@ -0,0 +1,206 @@
+// Package prompt loads versioned system prompts from disk, registers them in
+// the prompts table and splits traffic between a control and a candidate
+// version so their results can be compared.
+//
+// Prompts stay plain files next to the worker that uses them. A file's name
+// is its path relative to the registry root without the extension; a
+// version is appended to the stem after an "@":
+//
+//	analysis/extractor.md      name "analysis/extractor", version "v1"
+//	analysis/extractor@v2.md   name "analysis/extractor", version "v2"
+//
+// Versions are immutable once registered: editing a registered file without
+// renaming it fails registration with repo.ErrPromptVersionConflict.
+package prompt
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ChiaYuChang/prism/internal/repo"
+	"github.com/google/uuid"
+)
+
+// DefaultVersion is the version of a prompt file without an "@version"
+// suffix.
+const DefaultVersion = "v1"
+
+var (
+	ErrPromptNotFound = errors.New("prompt not found")
+	ErrInvalidName    = errors.New("invalid prompt name")
+	ErrInvalidVersion = errors.New("invalid prompt version")
+	ErrEmptyPrompt    = errors.New("prompt is empty")
+	ErrStoreMissing   = errors.New("prompt store is missing")
+)
+
+var versionPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`)
+
+// Prompt is one version of a named system prompt. ID is zero until the
+// prompt has been registered.
+type Prompt struct {
+	ID      uuid.UUID
+	Name    string
+	Version string
+	Path    string
+	Content string
+}
+
+// Hash returns the hex SHA-256 of Content, the value stored in prompts.hash.
+func (p Prompt) Hash() string {
+	sum := sha256.Sum256([]byte(p.Content))
+	return hex.EncodeToString(sum[:])
+}
+
+// String renders name@version for logs.
+func (p Prompt) String() string {
+	return p.Name + "@" + p.Version
+}
+
+// Store is the part of repo.Analysis the registry persists through.
+type Store interface {
+	UpsertPrompt(ctx context.Context, arg repo.UpsertPromptParams) (repo.Prompt, error)
+}
+
+// Registry indexes every prompt file below a root directory by name and
+// version.
+type Registry struct {
+	root    string
+	prompts map[string]map[string]Prompt
+}
+
+// LoadDir reads every regular, non-hidden file below root into a Registry.
+// Trailing whitespace is trimmed so an editor's final newline does not
+// change the hash.
+func LoadDir(root string) (*Registry, error) {
+	r := &Registry{root: root, prompts: make(map[string]map[string]Prompt)}
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(d.Name(), ".") && path != root {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		name, version, err := ParseFileName(rel)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		body, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("read prompt %s: %w", path, err)
+		}
+		content := strings.TrimRight(string(body), " \t\r\n")
+		if content == "" {
+			return fmt.Errorf("%s: %w", path, ErrEmptyPrompt)
+		}
+		if _, ok := r.prompts[name]; !ok {
+			r.prompts[name] = make(map[string]Prompt)
+		}
+		if prev, ok := r.prompts[name][version]; ok {
+			return fmt.Errorf("prompt %s@%s defined twice: %s and %s", name, version, prev.Path, path)
+		}
+		r.prompts[name][version] = Prompt{
+			Name:    name,
+			Version: version,
+			Path:    path,
+			Content: content,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("load prompts from %s: %w", root, err)
+	}
+	return r, nil
+}
+
+// ParseFileName splits a path relative to the registry root into prompt name
+// and version. Names always use forward slashes.
+func ParseFileName(rel string) (name, version string, err error) {
+	rel = filepath.ToSlash(rel)
+	dir, file := "", rel
+	if i := strings.LastIndex(rel, "/"); i >= 0 {
+		dir, file = rel[:i+1], rel[i+1:]
+	}
+	stem := strings.TrimSuffix(file, filepath.Ext(file))
+	version = DefaultVersion
+	if i := strings.LastIndex(stem, "@"); i >= 0 {
+		stem, version = stem[:i], stem[i+1:]
+	}
+	if stem == "" {
+		return "", "", fmt.Errorf("%w: %q", ErrInvalidName, rel)
+	}
+	if !versionPattern.MatchString(version) {
+		return "", "", fmt.Errorf("%w: %q", ErrInvalidVersion, version)
+	}
+	return dir + stem, version, nil
+}
+
+// Get returns name at version. An empty version means DefaultVersion.
+func (r *Registry) Get(name, version string) (Prompt, error) {
+	if version == "" {
+		version = DefaultVersion
+	}
+	p, ok := r.prompts[name][version]
+	if !ok {
+		return Prompt{}, fmt.Errorf("%w: %s@%s under %s", ErrPromptNotFound, name, version, r.root)
+	}
+	return p, nil
+}
+
+// Versions lists the versions available for name in lexical order.
+func (r *Registry) Versions(name string) []string {
+	versions := make([]string, 0, len(r.prompts[name]))
+	for v := range r.prompts[name] {
+		versions = append(versions, v)
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+// Register upserts p into store and returns it with its row ID. metadata is
+// stored alongside as JSON and may be nil.
+func Register(ctx context.Context, store Store, p Prompt, metadata map[string]any) (Prompt, error) {
+	if store == nil {
+		return Prompt{}, ErrStoreMissing
+	}
+	meta := []byte("{}")
+	if len(metadata) > 0 {
+		b, err := json.Marshal(metadata)
+		if err != nil {
+			return Prompt{}, fmt.Errorf("marshal prompt metadata: %w", err)
+		}
+		meta = b
+	}
+	row, err := store.UpsertPrompt(ctx, repo.UpsertPromptParams{
+		Name:     p.Name,
+		Version:  p.Version,
+		Hash:     p.Hash(),
+		Path:     p.Path,
+		Content:  p.Content,
+		Metadata: meta,
+	})
+	if err != nil {
+		return Prompt{}, fmt.Errorf("register prompt %s: %w", p, err)
+	}
+	p.ID = row.ID
+	return p, nil
+}
@@ -0,0 +1,115 @@
+package prompt_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ChiaYuChang/prism/internal/prompt"
+	"github.com/ChiaYuChang/prism/internal/repo"
+	repomocks "github.com/ChiaYuChang/prism/internal/repo/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func writePrompt(t *testing.T, root, rel, body string) string {
+	t.Helper()
+	path := filepath.Join(root, rel)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(body), 0o644))
+	return path
+}
+
+func TestParseFileName(t *testing.T) {
+	cases := []struct {
+		rel     string
+		name    string
+		version string
+		err     error
+	}{
+		{rel: "analysis/extractor.md", name: "analysis/extractor", version: "v1"},
+		{rel: "analysis/extractor@v2.md", name: "analysis/extractor", version: "v2"},
+		{rel: "article_parser@2026-05-01.txt", name: "article_parser", version: "2026-05-01"},
+		{rel: "extractor@.md", err: prompt.ErrInvalidVersion},
+		{rel: "extractor@v 2.md", err: prompt.ErrInvalidVersion},
+		{rel: "@v2.md", err: prompt.ErrInvalidName},
+	}
+	for _, c := range cases {
+		t.Run(c.rel, func(t *testing.T) {
+			name, version, err := prompt.ParseFileName(c.rel)
+			if c.err != nil {
+				require.ErrorIs(t, err, c.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, c.name, name)
+			require.Equal(t, c.version, version)
+		})
+	}
+}
+
+func TestLoadDir(t *testing.T) {
+	root := t.TempDir()
+	writePrompt(t, root, "analysis/extractor.md", "control\n\n")
+	writePrompt(t, root, "analysis/extractor@v2.md", "candidate")
+	writePrompt(t, root, ".hidden/ignored.md", "ignored")
+
+	r, err := prompt.LoadDir(root)
+	require.NoError(t, err)
+	require.Equal(t, []string{"v1", "v2"}, r.Versions("analysis/extractor"))
+
+	p, err := r.Get("analysis/extractor", "")
+	require.NoError(t, err)
+	require.Equal(t, "v1", p.Version)
+	require.Equal(t, "control", p.Content, "trailing whitespace is trimmed")
+
+	_, err = r.Get("analysis/extractor", "v3")
+	require.ErrorIs(t, err, prompt.ErrPromptNotFound)
+	_, err = r.Get("ignored", "")
+	require.ErrorIs(t, err, prompt.ErrPromptNotFound)
+}
+
+func TestLoadDirRejectsDuplicateAndEmptyPrompts(t *testing.T) {
+	root := t.TempDir()
+	writePrompt(t, root, "extractor.md", "a")
+	writePrompt(t, root, "extractor@v1.txt", "b")
+	_, err := prompt.LoadDir(root)
+	require.ErrorContains(t, err, "defined twice")
+
+	root = t.TempDir()
+	writePrompt(t, root, "extractor.md", " \n")
+	_, err = prompt.LoadDir(root)
+	require.ErrorIs(t, err, prompt.ErrEmptyPrompt)
+}
+
+func TestRegister(t *testing.T) {
+	p := prompt.Prompt{Name: "extractor", Version: "v2", Path: "/app/extractor@v2.md", Content: "body"}
+	id := uuid.New()
+
+	store := repomocks.NewMockAnalysis(t)
+	store.EXPECT().UpsertPrompt(mock.Anything, repo.UpsertPromptParams{
+		Name:     "extractor",
+		Version:  "v2",
+		Hash:     p.Hash(),
+		Path:     "/app/extractor@v2.md",
+		Content:  "body",
+		Metadata: []byte(`{"component":"planner"}`),
+	}).Return(repo.Prompt{ID: id}, nil).Once()
+
+	got, err := prompt.Register(context.Background(), store, p, map[string]any{"component": "planner"})
+	require.NoError(t, err)
+	require.Equal(t, id, got.ID)
+	require.Len(t, p.Hash(), 64)
+}
+
+func TestRegisterSurfacesVersionConflict(t *testing.T) {
+	store := repomocks.NewMockAnalysis(t)
+	store.EXPECT().UpsertPrompt(mock.Anything, mock.Anything).
+		Return(repo.Prompt{}, repo.ErrPromptVersionConflict).Once()
+
+	_, err := prompt.Register(context.Background(), store,
+		prompt.Prompt{Name: "extractor", Version: "v1", Content: "edited"}, nil)
+	require.ErrorIs(t, err, repo.ErrPromptVersionConflict)
+}
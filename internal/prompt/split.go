@@ -0,0 +1,114 @@
+package prompt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"path/filepath"
+)
+
+// ErrInvalidSplit is returned when a Split's percentage or candidate is
+// inconsistent.
+var ErrInvalidSplit = errors.New("invalid prompt split")
+
+// Split routes a fixed share of traffic to a candidate prompt version and
+// the rest to the control version.
+//
+// Routing is deterministic in the key, so retries and re-runs over the same
+// input always see the same version and the two arms stay comparable.
+type Split struct {
+	Control   Prompt
+	Candidate *Prompt
+	// Percent of keys, 0-100, routed to Candidate.
+	Percent int
+}
+
+// Single returns a Split that always serves p.
+func Single(p Prompt) Split {
+	return Split{Control: p}
+}
+
+// NewSplit validates and returns a Split. A nil candidate requires a zero
+// percentage.
+func NewSplit(control Prompt, candidate *Prompt, percent int) (Split, error) {
+	if control.Content == "" {
+		return Split{}, fmt.Errorf("%w: control %w", ErrInvalidSplit, ErrEmptyPrompt)
+	}
+	if percent < 0 || percent > 100 {
+		return Split{}, fmt.Errorf("%w: percent %d outside 0-100", ErrInvalidSplit, percent)
+	}
+	if candidate == nil && percent > 0 {
+		return Split{}, fmt.Errorf("%w: percent %d without a candidate", ErrInvalidSplit, percent)
+	}
+	if candidate != nil && candidate.Content == "" {
+		return Split{}, fmt.Errorf("%w: candidate %w", ErrInvalidSplit, ErrEmptyPrompt)
+	}
+	return Split{Control: control, Candidate: candidate, Percent: percent}, nil
+}
+
+// LoadSplit reads name@control and, when candidate is non-empty,
+// name@candidate from r.
+func LoadSplit(r *Registry, name, control, candidate string, percent int) (Split, error) {
+	c, err := r.Get(name, control)
+	if err != nil {
+		return Split{}, err
+	}
+	if candidate == "" {
+		return NewSplit(c, nil, percent)
+	}
+	cand, err := r.Get(name, candidate)
+	if err != nil {
+		return Split{}, err
+	}
+	return NewSplit(c, &cand, percent)
+}
+
+// Choose returns the prompt serving key.
+func (s Split) Choose(key string) Prompt {
+	if s.Candidate == nil || s.Percent <= 0 {
+		return s.Control
+	}
+	if s.Percent >= 100 {
+		return *s.Candidate
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	if int(h.Sum32()%100) < s.Percent {
+		return *s.Candidate
+	}
+	return s.Control
+}
+
+// Register upserts both arms into store and returns the Split with their
+// row IDs filled in.
+func (s Split) Register(ctx context.Context, store Store, metadata map[string]any) (Split, error) {
+	control, err := Register(ctx, store, s.Control, metadata)
+	if err != nil {
+		return Split{}, err
+	}
+	s.Control = control
+	if s.Candidate != nil {
+		candidate, err := Register(ctx, store, *s.Candidate, metadata)
+		if err != nil {
+			return Split{}, err
+		}
+		s.Candidate = &candidate
+	}
+	return s, nil
+}
+
+// LoadFileSplit builds a Split around a single configured prompt file: the
+// file is the control, and candidate names a sibling version of it
+// ("<stem>@<candidate><ext>" in the same directory).
+func LoadFileSplit(path, candidate string, percent int) (Split, error) {
+	r, err := LoadDir(filepath.Dir(path))
+	if err != nil {
+		return Split{}, err
+	}
+	name, version, err := ParseFileName(filepath.Base(path))
+	if err != nil {
+		return Split{}, err
+	}
+	return LoadSplit(r, name, version, candidate, percent)
+}
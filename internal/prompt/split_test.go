@@ -0,0 +1,73 @@
+package prompt_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/ChiaYuChang/prism/internal/prompt"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSplitValidates(t *testing.T) {
+	control := prompt.Prompt{Version: "v1", Content: "a"}
+	candidate := prompt.Prompt{Version: "v2", Content: "b"}
+
+	_, err := prompt.NewSplit(control, &candidate, 101)
+	require.ErrorIs(t, err, prompt.ErrInvalidSplit)
+	_, err = prompt.NewSplit(control, nil, 10)
+	require.ErrorIs(t, err, prompt.ErrInvalidSplit)
+	_, err = prompt.NewSplit(prompt.Prompt{}, nil, 0)
+	require.ErrorIs(t, err, prompt.ErrInvalidSplit)
+	_, err = prompt.NewSplit(control, &candidate, 10)
+	require.NoError(t, err)
+}
+
+func TestSplitChoose(t *testing.T) {
+	control := prompt.Prompt{Version: "v1", Content: "a"}
+	candidate := prompt.Prompt{Version: "v2", Content: "b"}
+
+	t.Run("Single", func(t *testing.T) {
+		s := prompt.Single(control)
+		require.Equal(t, "v1", s.Choose("anything").Version)
+	})
+
+	t.Run("DeterministicShare", func(t *testing.T) {
+		s, err := prompt.NewSplit(control, &candidate, 20)
+		require.NoError(t, err)
+
+		n := 10000
+		hits := 0
+		for i := range n {
+			key := fmt.Sprintf("https://example.com/news/%d", i)
+			v := s.Choose(key).Version
+			require.Equal(t, v, s.Choose(key).Version, "same key must stay on the same arm")
+			if v == "v2" {
+				hits++
+			}
+		}
+		require.InDelta(t, 0.20, float64(hits)/float64(n), 0.02)
+	})
+
+	t.Run("AllCandidate", func(t *testing.T) {
+		s, err := prompt.NewSplit(control, &candidate, 100)
+		require.NoError(t, err)
+		require.Equal(t, "v2", s.Choose("x").Version)
+	})
+}
+
+func TestLoadFileSplit(t *testing.T) {
+	root := t.TempDir()
+	path := writePrompt(t, root, "extractor.md", "control")
+	writePrompt(t, root, "extractor@v2.md", "candidate")
+
+	s, err := prompt.LoadFileSplit(path, "v2", 50)
+	require.NoError(t, err)
+	require.Equal(t, "extractor", s.Control.Name)
+	require.Equal(t, "v1", s.Control.Version)
+	require.Equal(t, "v2", s.Candidate.Version)
+	require.Equal(t, filepath.Join(root, "extractor@v2.md"), s.Candidate.Path)
+
+	_, err = prompt.LoadFileSplit(path, "v3", 50)
+	require.ErrorIs(t, err, prompt.ErrPromptNotFound)
+}
@@ -1,5 +1,7 @@
 package repo
 
+import "strings"
+
 type TaskStatus string
 
 const (
@@ -16,8 +18,9 @@ const (
 	TaskKindPageFetch      = "PAGE_FETCH"
 
 	// Source Types
-	SourceTypeParty = "PARTY"
-	SourceTypeMedia = "MEDIA"
+	SourceTypeParty      = "PARTY"
+	SourceTypeMedia      = "MEDIA"
+	SourceTypeGovernment = "GOVERNMENT"
 
 	// Ingestion Methods
 	IngestionMethodDirectory    = "DIRECTORY"
@@ -26,8 +29,20 @@ const (
 	IngestionMethodManual       = "MANUAL"
 
 	// Content Types
-	ContentTypePartyRelease = "PARTY_RELEASE"
-	ContentTypeArticle      = "ARTICLE"
+	ContentTypePartyRelease      = "PARTY_RELEASE"
+	ContentTypeGovernmentRelease = "GOVERNMENT_RELEASE"
+	ContentTypeArticle           = "ARTICLE"
+	ContentTypeSocial            = "SOCIAL"
+
+	// Model Types
+	ModelTypeExtractor = "EXTRACTOR"
+	ModelTypeEmbedder  = "EMBEDDER"
+	ModelTypeAnalyzer  = "ANALYZER"
+
+	// Search Alias Kinds
+	AliasKindAlias        = "ALIAS"
+	AliasKindAbbreviation = "ABBREVIATION"
+	AliasKindVariant      = "VARIANT"
 
 	// Source Abbreviations (Commonly used)
 	SourceAbbrDPP   = "dpp"
@@ -35,3 +50,20 @@ const (
 	SourceAbbrTPP   = "tpp"
 	SourceAbbrYahoo = "yahoo"
 )
+
+// SeedSourceTypes are the source types whose contents seed the planner:
+// their candidates are fetched without a user request and their completed
+// batches are published to the planner.
+var SeedSourceTypes = []string{SourceTypeParty, SourceTypeGovernment}
+
+// IsSeedSourceType reports whether sourceType is one of SeedSourceTypes,
+// ignoring case and surrounding space.
+func IsSeedSourceType(sourceType string) bool {
+	sourceType = strings.TrimSpace(sourceType)
+	for _, t := range SeedSourceTypes {
+		if strings.EqualFold(sourceType, t) {
+			return true
+		}
+	}
+	return false
+}
@@ -27,6 +27,24 @@ type Task struct {
 	UpdatedAt   time.Time
 }
 
+// BackfillCheckpoint is where the last backfill run of a source stopped.
+// LastPage is the pager's position of the last page handed to the sink;
+// CompletedAt is set once the run reached the end of the listing or Until.
+type BackfillCheckpoint struct {
+	SourceAbbr          string
+	Pager               string
+	LastPage            string
+	BatchID             uuid.UUID
+	Until               time.Time
+	PagesVisited        int32
+	CandidatesSeen      int32
+	CandidatesProcessed int32
+	OldestPublishedAt   *time.Time
+	CompletedAt         *time.Time
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
 type Batch struct {
 	ID                   uuid.UUID
 	SourceType           string
@@ -50,6 +68,36 @@ type Source struct {
 	DeletedAt *time.Time
 }
 
+// ScoutConfig is one discovery scout. Config is the JSON form of a
+// scouts.yaml entry of the given Kind; internal/discovery/scout/config
+// decodes and validates it.
+type ScoutConfig struct {
+	Name      string
+	Kind      string
+	Config    []byte
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// SearchAlias is one member of an alias group keyed by Canonical. Kind is
+// one of the AliasKind constants.
+type SearchAlias struct {
+	Canonical string
+	Alias     string
+	Kind      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ParserRule is the parser config of one host. Config is the JSON form of a
+// parsers.yaml `parsers:` entry.
+type ParserRule struct {
+	Host      string
+	Config    []byte
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
 type Model struct {
 	ID          int16
 	Name        string
@@ -64,8 +112,12 @@ type Model struct {
 
 type Prompt struct {
 	ID        uuid.UUID
+	Name      string
+	Version   string
 	Hash      string
 	Path      string
+	Content   string
+	Metadata  []byte
 	CreatedAt time.Time
 }
 
@@ -117,6 +169,7 @@ type ContentEmbedding struct {
 	ContentID uuid.UUID
 	ModelID   int16
 	Category  string
+	Vector    []float32
 	TraceID   string
 	CreatedAt time.Time
 }
@@ -132,6 +185,7 @@ type ContentExtraction struct {
 	Summary       string
 	RawResult     []byte
 	TraceID       string
+	PromptVersion string
 	CreatedAt     time.Time
 }
 
@@ -142,11 +196,14 @@ type Entity struct {
 	CreatedAt time.Time
 }
 
+// UserFetch never carries the webhook secret; it only leaves the database
+// with the delivery that signs with it (FetchNotification).
 type UserFetch struct {
 	ID          uuid.UUID
 	UserID      *uuid.UUID
 	CreatedAt   time.Time
 	CompletedAt *time.Time
+	NotifyURL   *string
 }
 
 type UserFetchItem struct {
@@ -169,7 +226,155 @@ type UserFetchProgress struct {
 	Terminal                    bool
 }
 
+// FetchNotification is one leased webhook delivery: the frozen payload plus
+// the fetch's signing secret.
+type FetchNotification struct {
+	ID       uuid.UUID
+	FetchID  uuid.UUID
+	URL      string
+	Payload  []byte
+	Attempts int32
+	Secret   string
+}
+
+type User struct {
+	ID         uuid.UUID
+	Name       string
+	CreatedAt  time.Time
+	DisabledAt *time.Time
+}
+
+// APIKey never carries the plaintext key; KeyHash is its SHA-256. A nil
+// RateLimitRPS / RateLimitBurst falls back to the server default.
+type APIKey struct {
+	ID             uuid.UUID
+	UserID         uuid.UUID
+	Name           string
+	Prefix         string
+	KeyHash        string
+	Scopes         []string
+	RateLimitRPS   *float64
+	RateLimitBurst *int32
+	CreatedAt      time.Time
+	LastUsedAt     *time.Time
+	ExpiresAt      *time.Time
+	RevokedAt      *time.Time
+}
+
+type LLMUsageRecord struct {
+	ID           int64
+	Provider     string
+	Model        string
+	Operation    string
+	Component    string
+	InputTokens  int32
+	OutputTokens int32
+	CachedTokens int32
+	TotalTokens  int32
+	CostMicros   int64
+	TraceID      string
+	BatchID      *uuid.UUID
+	CreatedAt    time.Time
+}
+
+// LLMSpendSummary is one (UTC day, component, provider, model) bucket of the
+// LLM usage ledger.
+type LLMSpendSummary struct {
+	Day          time.Time
+	Component    string
+	Provider     string
+	Model        string
+	Calls        int64
+	InputTokens  int64
+	OutputTokens int64
+	CachedTokens int64
+	TotalTokens  int64
+	CostMicros   int64
+}
+
+// TaskRun is one discovery execution of a DIRECTORY_FETCH or KEYWORD_SEARCH
+// task. ItemsDuplicate counts stored candidates that were already known.
+type TaskRun struct {
+	ID             int64
+	TaskID         uuid.UUID
+	BatchID        uuid.UUID
+	Kind           string
+	SourceType     string
+	SourceAbbr     string
+	Query          *string
+	Status         string
+	ErrorClass     *string
+	ItemsSeen      int32
+	ItemsNew       int32
+	ItemsDuplicate int32
+	Providers      map[string]TaskRunProvider
+	Duration       time.Duration
+	TraceID        string
+	StartedAt      time.Time
+	CreatedAt      time.Time
+}
+
+// TaskRunProvider is one search provider's share of a KEYWORD_SEARCH run:
+// the results it returned, or the class of its failure.
+type TaskRunProvider struct {
+	Seen       int32  `json:"seen,omitempty"`
+	ErrorClass string `json:"error_class,omitempty"`
+}
+
+// TaskRunYield aggregates task runs over one group: a (kind, source), a
+// task or a search provider. Fields outside the grouping are zero; provider
+// groups only carry Runs, FailedRuns, ItemsSeen and LastRunAt.
+type TaskRunYield struct {
+	TaskID         uuid.UUID
+	Kind           string
+	SourceAbbr     string
+	Query          string
+	Provider       string
+	Runs           int64
+	FailedRuns     int64
+	ItemsSeen      int64
+	ItemsNew       int64
+	ItemsDuplicate int64
+	AvgDuration    time.Duration
+	LastRunAt      time.Time
+	LastNewAt      *time.Time
+}
+
 // UserFetchItemSnapshotAlreadyComplete is the only snapshot value used in v1.
 // Items in this state were promoted to contents before the request was
 // created, so they do not reference an active task.
 const UserFetchItemSnapshotAlreadyComplete = "ALREADY_COMPLETE"
+
+// fetch_notifications.status values.
+const (
+	FetchNotificationStatusPending   = "PENDING"
+	FetchNotificationStatusDelivered = "DELIVERED"
+	FetchNotificationStatusFailed    = "FAILED"
+)
+
+// task_runs.status values.
+const (
+	TaskRunStatusOK     = "OK"
+	TaskRunStatusFailed = "FAILED"
+)
+
+// ChangeFeed channels published by the triggers in migrations 000007 and
+// 000010.
+const (
+	// ChangeChannelCandidates fires when a candidate is inserted or re-seen.
+	// The payload is its source_abbr.
+	ChangeChannelCandidates = "prism_candidates"
+	// ChangeChannelFetchProgress fires when a task referenced by a fetch
+	// changes status. The payload is the fetch_id.
+	ChangeChannelFetchProgress = "prism_fetch_progress"
+	// ChangeChannelCatalog fires when scout configs or parser rules change
+	// (migration 000010). The payload is the table name, one of
+	// CatalogScoutConfigs or CatalogParserRules.
+	ChangeChannelCatalog = "prism_catalog"
+)
+
+// Catalog tables, as published on ChangeChannelCatalog.
+const (
+	CatalogScoutConfigs = "scout_configs"
+	CatalogParserRules  = "parser_rules"
+)
@@ -7,3 +7,16 @@ import "errors"
 // Callers should treat this as an idempotent no-op, optionally extending the
 // existing task's expires_at via ExtendActiveTaskExpiry.
 var ErrTaskAlreadyActive = errors.New("task already active")
+
+// ErrPromptVersionConflict is returned by UpsertPrompt when (name, version)
+// is already registered with different content. Prompt versions are
+// immutable so past extractions keep pointing at the text that produced
+// them; publish the change under a new version instead.
+var ErrPromptVersionConflict = errors.New("prompt version already registered with different content")
+
+// ErrUserExists is returned by CreateUser when the name is already taken.
+var ErrUserExists = errors.New("user already exists")
+
+// ErrCheckpointNotFound is returned by Backfills.GetCheckpoint when the
+// source has never been backfilled.
+var ErrCheckpointNotFound = errors.New("backfill checkpoint not found")
@@ -0,0 +1,235 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/ChiaYuChang/prism/internal/repo"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockAliases creates a new instance of MockAliases. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAliases(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAliases {
+	mock := &MockAliases{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockAliases is an autogenerated mock type for the Aliases type
+type MockAliases struct {
+	mock.Mock
+}
+
+type MockAliases_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAliases) EXPECT() *MockAliases_Expecter {
+	return &MockAliases_Expecter{mock: &_m.Mock}
+}
+
+// DeleteAliases provides a mock function for the type MockAliases
+func (_mock *MockAliases) DeleteAliases(ctx context.Context, canonical string) (int64, error) {
+	ret := _mock.Called(ctx, canonical)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAliases")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return returnFunc(ctx, canonical)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = returnFunc(ctx, canonical)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, canonical)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAliases_DeleteAliases_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAliases'
+type MockAliases_DeleteAliases_Call struct {
+	*mock.Call
+}
+
+// DeleteAliases is a helper method to define mock.On call
+//   - ctx context.Context
+//   - canonical string
+func (_e *MockAliases_Expecter) DeleteAliases(ctx interface{}, canonical interface{}) *MockAliases_DeleteAliases_Call {
+	return &MockAliases_DeleteAliases_Call{Call: _e.mock.On("DeleteAliases", ctx, canonical)}
+}
+
+func (_c *MockAliases_DeleteAliases_Call) Run(run func(ctx context.Context, canonical string)) *MockAliases_DeleteAliases_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAliases_DeleteAliases_Call) Return(n int64, err error) *MockAliases_DeleteAliases_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockAliases_DeleteAliases_Call) RunAndReturn(run func(ctx context.Context, canonical string) (int64, error)) *MockAliases_DeleteAliases_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAliases provides a mock function for the type MockAliases
+func (_mock *MockAliases) ListAliases(ctx context.Context) ([]repo.SearchAlias, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAliases")
+	}
+
+	var r0 []repo.SearchAlias
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]repo.SearchAlias, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []repo.SearchAlias); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repo.SearchAlias)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAliases_ListAliases_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAliases'
+type MockAliases_ListAliases_Call struct {
+	*mock.Call
+}
+
+// ListAliases is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockAliases_Expecter) ListAliases(ctx interface{}) *MockAliases_ListAliases_Call {
+	return &MockAliases_ListAliases_Call{Call: _e.mock.On("ListAliases", ctx)}
+}
+
+func (_c *MockAliases_ListAliases_Call) Run(run func(ctx context.Context)) *MockAliases_ListAliases_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAliases_ListAliases_Call) Return(searchAliass []repo.SearchAlias, err error) *MockAliases_ListAliases_Call {
+	_c.Call.Return(searchAliass, err)
+	return _c
+}
+
+func (_c *MockAliases_ListAliases_Call) RunAndReturn(run func(ctx context.Context) ([]repo.SearchAlias, error)) *MockAliases_ListAliases_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplaceAliases provides a mock function for the type MockAliases
+func (_mock *MockAliases) ReplaceAliases(ctx context.Context, arg repo.ReplaceSearchAliasesParams) ([]repo.SearchAlias, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceAliases")
+	}
+
+	var r0 []repo.SearchAlias
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repo.ReplaceSearchAliasesParams) ([]repo.SearchAlias, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repo.ReplaceSearchAliasesParams) []repo.SearchAlias); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repo.SearchAlias)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repo.ReplaceSearchAliasesParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAliases_ReplaceAliases_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceAliases'
+type MockAliases_ReplaceAliases_Call struct {
+	*mock.Call
+}
+
+// ReplaceAliases is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg repo.ReplaceSearchAliasesParams
+func (_e *MockAliases_Expecter) ReplaceAliases(ctx interface{}, arg interface{}) *MockAliases_ReplaceAliases_Call {
+	return &MockAliases_ReplaceAliases_Call{Call: _e.mock.On("ReplaceAliases", ctx, arg)}
+}
+
+func (_c *MockAliases_ReplaceAliases_Call) Run(run func(ctx context.Context, arg repo.ReplaceSearchAliasesParams)) *MockAliases_ReplaceAliases_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 repo.ReplaceSearchAliasesParams
+		if args[1] != nil {
+			arg1 = args[1].(repo.ReplaceSearchAliasesParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAliases_ReplaceAliases_Call) Return(searchAliass []repo.SearchAlias, err error) *MockAliases_ReplaceAliases_Call {
+	_c.Call.Return(searchAliass, err)
+	return _c
+}
+
+func (_c *MockAliases_ReplaceAliases_Call) RunAndReturn(run func(ctx context.Context, arg repo.ReplaceSearchAliasesParams) ([]repo.SearchAlias, error)) *MockAliases_ReplaceAliases_Call {
+	_c.Call.Return(run)
+	return _c
+}
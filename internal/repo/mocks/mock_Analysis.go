@@ -498,6 +498,146 @@ func (_c *MockAnalysis_GetPromptByID_Call) RunAndReturn(run func(ctx context.Con
 	return _c
 }
 
+// GetPromptByNameAndVersion provides a mock function for the type MockAnalysis
+func (_mock *MockAnalysis) GetPromptByNameAndVersion(ctx context.Context, name string, version string) (repo.Prompt, error) {
+	ret := _mock.Called(ctx, name, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPromptByNameAndVersion")
+	}
+
+	var r0 repo.Prompt
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (repo.Prompt, error)); ok {
+		return returnFunc(ctx, name, version)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) repo.Prompt); ok {
+		r0 = returnFunc(ctx, name, version)
+	} else {
+		r0 = ret.Get(0).(repo.Prompt)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, name, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAnalysis_GetPromptByNameAndVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPromptByNameAndVersion'
+type MockAnalysis_GetPromptByNameAndVersion_Call struct {
+	*mock.Call
+}
+
+// GetPromptByNameAndVersion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - version string
+func (_e *MockAnalysis_Expecter) GetPromptByNameAndVersion(ctx interface{}, name interface{}, version interface{}) *MockAnalysis_GetPromptByNameAndVersion_Call {
+	return &MockAnalysis_GetPromptByNameAndVersion_Call{Call: _e.mock.On("GetPromptByNameAndVersion", ctx, name, version)}
+}
+
+func (_c *MockAnalysis_GetPromptByNameAndVersion_Call) Run(run func(ctx context.Context, name string, version string)) *MockAnalysis_GetPromptByNameAndVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAnalysis_GetPromptByNameAndVersion_Call) Return(prompt repo.Prompt, err error) *MockAnalysis_GetPromptByNameAndVersion_Call {
+	_c.Call.Return(prompt, err)
+	return _c
+}
+
+func (_c *MockAnalysis_GetPromptByNameAndVersion_Call) RunAndReturn(run func(ctx context.Context, name string, version string) (repo.Prompt, error)) *MockAnalysis_GetPromptByNameAndVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPromptVersions provides a mock function for the type MockAnalysis
+func (_mock *MockAnalysis) ListPromptVersions(ctx context.Context, name string) ([]repo.Prompt, error) {
+	ret := _mock.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPromptVersions")
+	}
+
+	var r0 []repo.Prompt
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]repo.Prompt, error)); ok {
+		return returnFunc(ctx, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []repo.Prompt); ok {
+		r0 = returnFunc(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repo.Prompt)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAnalysis_ListPromptVersions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPromptVersions'
+type MockAnalysis_ListPromptVersions_Call struct {
+	*mock.Call
+}
+
+// ListPromptVersions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockAnalysis_Expecter) ListPromptVersions(ctx interface{}, name interface{}) *MockAnalysis_ListPromptVersions_Call {
+	return &MockAnalysis_ListPromptVersions_Call{Call: _e.mock.On("ListPromptVersions", ctx, name)}
+}
+
+func (_c *MockAnalysis_ListPromptVersions_Call) Run(run func(ctx context.Context, name string)) *MockAnalysis_ListPromptVersions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAnalysis_ListPromptVersions_Call) Return(prompts []repo.Prompt, err error) *MockAnalysis_ListPromptVersions_Call {
+	_c.Call.Return(prompts, err)
+	return _c
+}
+
+func (_c *MockAnalysis_ListPromptVersions_Call) RunAndReturn(run func(ctx context.Context, name string) ([]repo.Prompt, error)) *MockAnalysis_ListPromptVersions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ReplaceContentExtractionPhrases provides a mock function for the type MockAnalysis
 func (_mock *MockAnalysis) ReplaceContentExtractionPhrases(ctx context.Context, extractionID uuid.UUID, phrases []string) error {
 	ret := _mock.Called(ctx, extractionID, phrases)
@@ -0,0 +1,171 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/ChiaYuChang/prism/internal/repo"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockBackfills creates a new instance of MockBackfills. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockBackfills(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockBackfills {
+	mock := &MockBackfills{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockBackfills is an autogenerated mock type for the Backfills type
+type MockBackfills struct {
+	mock.Mock
+}
+
+type MockBackfills_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockBackfills) EXPECT() *MockBackfills_Expecter {
+	return &MockBackfills_Expecter{mock: &_m.Mock}
+}
+
+// GetCheckpoint provides a mock function for the type MockBackfills
+func (_mock *MockBackfills) GetCheckpoint(ctx context.Context, sourceAbbr string) (repo.BackfillCheckpoint, error) {
+	ret := _mock.Called(ctx, sourceAbbr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCheckpoint")
+	}
+
+	var r0 repo.BackfillCheckpoint
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (repo.BackfillCheckpoint, error)); ok {
+		return returnFunc(ctx, sourceAbbr)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) repo.BackfillCheckpoint); ok {
+		r0 = returnFunc(ctx, sourceAbbr)
+	} else {
+		r0 = ret.Get(0).(repo.BackfillCheckpoint)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, sourceAbbr)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBackfills_GetCheckpoint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCheckpoint'
+type MockBackfills_GetCheckpoint_Call struct {
+	*mock.Call
+}
+
+// GetCheckpoint is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sourceAbbr string
+func (_e *MockBackfills_Expecter) GetCheckpoint(ctx interface{}, sourceAbbr interface{}) *MockBackfills_GetCheckpoint_Call {
+	return &MockBackfills_GetCheckpoint_Call{Call: _e.mock.On("GetCheckpoint", ctx, sourceAbbr)}
+}
+
+func (_c *MockBackfills_GetCheckpoint_Call) Run(run func(ctx context.Context, sourceAbbr string)) *MockBackfills_GetCheckpoint_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBackfills_GetCheckpoint_Call) Return(backfillCheckpoint repo.BackfillCheckpoint, err error) *MockBackfills_GetCheckpoint_Call {
+	_c.Call.Return(backfillCheckpoint, err)
+	return _c
+}
+
+func (_c *MockBackfills_GetCheckpoint_Call) RunAndReturn(run func(ctx context.Context, sourceAbbr string) (repo.BackfillCheckpoint, error)) *MockBackfills_GetCheckpoint_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveCheckpoint provides a mock function for the type MockBackfills
+func (_mock *MockBackfills) SaveCheckpoint(ctx context.Context, arg repo.SaveBackfillCheckpointParams) (repo.BackfillCheckpoint, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveCheckpoint")
+	}
+
+	var r0 repo.BackfillCheckpoint
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repo.SaveBackfillCheckpointParams) (repo.BackfillCheckpoint, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repo.SaveBackfillCheckpointParams) repo.BackfillCheckpoint); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(repo.BackfillCheckpoint)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repo.SaveBackfillCheckpointParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBackfills_SaveCheckpoint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveCheckpoint'
+type MockBackfills_SaveCheckpoint_Call struct {
+	*mock.Call
+}
+
+// SaveCheckpoint is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg repo.SaveBackfillCheckpointParams
+func (_e *MockBackfills_Expecter) SaveCheckpoint(ctx interface{}, arg interface{}) *MockBackfills_SaveCheckpoint_Call {
+	return &MockBackfills_SaveCheckpoint_Call{Call: _e.mock.On("SaveCheckpoint", ctx, arg)}
+}
+
+func (_c *MockBackfills_SaveCheckpoint_Call) Run(run func(ctx context.Context, arg repo.SaveBackfillCheckpointParams)) *MockBackfills_SaveCheckpoint_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 repo.SaveBackfillCheckpointParams
+		if args[1] != nil {
+			arg1 = args[1].(repo.SaveBackfillCheckpointParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBackfills_SaveCheckpoint_Call) Return(backfillCheckpoint repo.BackfillCheckpoint, err error) *MockBackfills_SaveCheckpoint_Call {
+	_c.Call.Return(backfillCheckpoint, err)
+	return _c
+}
+
+func (_c *MockBackfills_SaveCheckpoint_Call) RunAndReturn(run func(ctx context.Context, arg repo.SaveBackfillCheckpointParams) (repo.BackfillCheckpoint, error)) *MockBackfills_SaveCheckpoint_Call {
+	_c.Call.Return(run)
+	return _c
+}
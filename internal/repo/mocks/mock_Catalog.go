@@ -0,0 +1,559 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/ChiaYuChang/prism/internal/repo"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockCatalog creates a new instance of MockCatalog. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCatalog(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCatalog {
+	mock := &MockCatalog{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockCatalog is an autogenerated mock type for the Catalog type
+type MockCatalog struct {
+	mock.Mock
+}
+
+type MockCatalog_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCatalog) EXPECT() *MockCatalog_Expecter {
+	return &MockCatalog_Expecter{mock: &_m.Mock}
+}
+
+// DeleteParserRule provides a mock function for the type MockCatalog
+func (_mock *MockCatalog) DeleteParserRule(ctx context.Context, host string) (int64, error) {
+	ret := _mock.Called(ctx, host)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteParserRule")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return returnFunc(ctx, host)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = returnFunc(ctx, host)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, host)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCatalog_DeleteParserRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteParserRule'
+type MockCatalog_DeleteParserRule_Call struct {
+	*mock.Call
+}
+
+// DeleteParserRule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - host string
+func (_e *MockCatalog_Expecter) DeleteParserRule(ctx interface{}, host interface{}) *MockCatalog_DeleteParserRule_Call {
+	return &MockCatalog_DeleteParserRule_Call{Call: _e.mock.On("DeleteParserRule", ctx, host)}
+}
+
+func (_c *MockCatalog_DeleteParserRule_Call) Run(run func(ctx context.Context, host string)) *MockCatalog_DeleteParserRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCatalog_DeleteParserRule_Call) Return(n int64, err error) *MockCatalog_DeleteParserRule_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockCatalog_DeleteParserRule_Call) RunAndReturn(run func(ctx context.Context, host string) (int64, error)) *MockCatalog_DeleteParserRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteScoutConfig provides a mock function for the type MockCatalog
+func (_mock *MockCatalog) DeleteScoutConfig(ctx context.Context, name string) (int64, error) {
+	ret := _mock.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteScoutConfig")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return returnFunc(ctx, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = returnFunc(ctx, name)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCatalog_DeleteScoutConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteScoutConfig'
+type MockCatalog_DeleteScoutConfig_Call struct {
+	*mock.Call
+}
+
+// DeleteScoutConfig is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockCatalog_Expecter) DeleteScoutConfig(ctx interface{}, name interface{}) *MockCatalog_DeleteScoutConfig_Call {
+	return &MockCatalog_DeleteScoutConfig_Call{Call: _e.mock.On("DeleteScoutConfig", ctx, name)}
+}
+
+func (_c *MockCatalog_DeleteScoutConfig_Call) Run(run func(ctx context.Context, name string)) *MockCatalog_DeleteScoutConfig_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCatalog_DeleteScoutConfig_Call) Return(n int64, err error) *MockCatalog_DeleteScoutConfig_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockCatalog_DeleteScoutConfig_Call) RunAndReturn(run func(ctx context.Context, name string) (int64, error)) *MockCatalog_DeleteScoutConfig_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteSource provides a mock function for the type MockCatalog
+func (_mock *MockCatalog) DeleteSource(ctx context.Context, abbr string) (int64, error) {
+	ret := _mock.Called(ctx, abbr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSource")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return returnFunc(ctx, abbr)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = returnFunc(ctx, abbr)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, abbr)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCatalog_DeleteSource_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSource'
+type MockCatalog_DeleteSource_Call struct {
+	*mock.Call
+}
+
+// DeleteSource is a helper method to define mock.On call
+//   - ctx context.Context
+//   - abbr string
+func (_e *MockCatalog_Expecter) DeleteSource(ctx interface{}, abbr interface{}) *MockCatalog_DeleteSource_Call {
+	return &MockCatalog_DeleteSource_Call{Call: _e.mock.On("DeleteSource", ctx, abbr)}
+}
+
+func (_c *MockCatalog_DeleteSource_Call) Run(run func(ctx context.Context, abbr string)) *MockCatalog_DeleteSource_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCatalog_DeleteSource_Call) Return(n int64, err error) *MockCatalog_DeleteSource_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockCatalog_DeleteSource_Call) RunAndReturn(run func(ctx context.Context, abbr string) (int64, error)) *MockCatalog_DeleteSource_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListParserRules provides a mock function for the type MockCatalog
+func (_mock *MockCatalog) ListParserRules(ctx context.Context) ([]repo.ParserRule, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListParserRules")
+	}
+
+	var r0 []repo.ParserRule
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]repo.ParserRule, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []repo.ParserRule); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repo.ParserRule)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCatalog_ListParserRules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListParserRules'
+type MockCatalog_ListParserRules_Call struct {
+	*mock.Call
+}
+
+// ListParserRules is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockCatalog_Expecter) ListParserRules(ctx interface{}) *MockCatalog_ListParserRules_Call {
+	return &MockCatalog_ListParserRules_Call{Call: _e.mock.On("ListParserRules", ctx)}
+}
+
+func (_c *MockCatalog_ListParserRules_Call) Run(run func(ctx context.Context)) *MockCatalog_ListParserRules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCatalog_ListParserRules_Call) Return(parserRules []repo.ParserRule, err error) *MockCatalog_ListParserRules_Call {
+	_c.Call.Return(parserRules, err)
+	return _c
+}
+
+func (_c *MockCatalog_ListParserRules_Call) RunAndReturn(run func(ctx context.Context) ([]repo.ParserRule, error)) *MockCatalog_ListParserRules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListScoutConfigs provides a mock function for the type MockCatalog
+func (_mock *MockCatalog) ListScoutConfigs(ctx context.Context) ([]repo.ScoutConfig, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListScoutConfigs")
+	}
+
+	var r0 []repo.ScoutConfig
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]repo.ScoutConfig, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []repo.ScoutConfig); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repo.ScoutConfig)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCatalog_ListScoutConfigs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListScoutConfigs'
+type MockCatalog_ListScoutConfigs_Call struct {
+	*mock.Call
+}
+
+// ListScoutConfigs is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockCatalog_Expecter) ListScoutConfigs(ctx interface{}) *MockCatalog_ListScoutConfigs_Call {
+	return &MockCatalog_ListScoutConfigs_Call{Call: _e.mock.On("ListScoutConfigs", ctx)}
+}
+
+func (_c *MockCatalog_ListScoutConfigs_Call) Run(run func(ctx context.Context)) *MockCatalog_ListScoutConfigs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCatalog_ListScoutConfigs_Call) Return(scoutConfigs []repo.ScoutConfig, err error) *MockCatalog_ListScoutConfigs_Call {
+	_c.Call.Return(scoutConfigs, err)
+	return _c
+}
+
+func (_c *MockCatalog_ListScoutConfigs_Call) RunAndReturn(run func(ctx context.Context) ([]repo.ScoutConfig, error)) *MockCatalog_ListScoutConfigs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertParserRule provides a mock function for the type MockCatalog
+func (_mock *MockCatalog) UpsertParserRule(ctx context.Context, arg repo.UpsertParserRuleParams) (repo.ParserRule, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertParserRule")
+	}
+
+	var r0 repo.ParserRule
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repo.UpsertParserRuleParams) (repo.ParserRule, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repo.UpsertParserRuleParams) repo.ParserRule); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(repo.ParserRule)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repo.UpsertParserRuleParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCatalog_UpsertParserRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertParserRule'
+type MockCatalog_UpsertParserRule_Call struct {
+	*mock.Call
+}
+
+// UpsertParserRule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg repo.UpsertParserRuleParams
+func (_e *MockCatalog_Expecter) UpsertParserRule(ctx interface{}, arg interface{}) *MockCatalog_UpsertParserRule_Call {
+	return &MockCatalog_UpsertParserRule_Call{Call: _e.mock.On("UpsertParserRule", ctx, arg)}
+}
+
+func (_c *MockCatalog_UpsertParserRule_Call) Run(run func(ctx context.Context, arg repo.UpsertParserRuleParams)) *MockCatalog_UpsertParserRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 repo.UpsertParserRuleParams
+		if args[1] != nil {
+			arg1 = args[1].(repo.UpsertParserRuleParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCatalog_UpsertParserRule_Call) Return(parserRule repo.ParserRule, err error) *MockCatalog_UpsertParserRule_Call {
+	_c.Call.Return(parserRule, err)
+	return _c
+}
+
+func (_c *MockCatalog_UpsertParserRule_Call) RunAndReturn(run func(ctx context.Context, arg repo.UpsertParserRuleParams) (repo.ParserRule, error)) *MockCatalog_UpsertParserRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertScoutConfig provides a mock function for the type MockCatalog
+func (_mock *MockCatalog) UpsertScoutConfig(ctx context.Context, arg repo.UpsertScoutConfigParams) (repo.ScoutConfig, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertScoutConfig")
+	}
+
+	var r0 repo.ScoutConfig
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repo.UpsertScoutConfigParams) (repo.ScoutConfig, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repo.UpsertScoutConfigParams) repo.ScoutConfig); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(repo.ScoutConfig)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repo.UpsertScoutConfigParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCatalog_UpsertScoutConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertScoutConfig'
+type MockCatalog_UpsertScoutConfig_Call struct {
+	*mock.Call
+}
+
+// UpsertScoutConfig is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg repo.UpsertScoutConfigParams
+func (_e *MockCatalog_Expecter) UpsertScoutConfig(ctx interface{}, arg interface{}) *MockCatalog_UpsertScoutConfig_Call {
+	return &MockCatalog_UpsertScoutConfig_Call{Call: _e.mock.On("UpsertScoutConfig", ctx, arg)}
+}
+
+func (_c *MockCatalog_UpsertScoutConfig_Call) Run(run func(ctx context.Context, arg repo.UpsertScoutConfigParams)) *MockCatalog_UpsertScoutConfig_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 repo.UpsertScoutConfigParams
+		if args[1] != nil {
+			arg1 = args[1].(repo.UpsertScoutConfigParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCatalog_UpsertScoutConfig_Call) Return(scoutConfig repo.ScoutConfig, err error) *MockCatalog_UpsertScoutConfig_Call {
+	_c.Call.Return(scoutConfig, err)
+	return _c
+}
+
+func (_c *MockCatalog_UpsertScoutConfig_Call) RunAndReturn(run func(ctx context.Context, arg repo.UpsertScoutConfigParams) (repo.ScoutConfig, error)) *MockCatalog_UpsertScoutConfig_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertSource provides a mock function for the type MockCatalog
+func (_mock *MockCatalog) UpsertSource(ctx context.Context, arg repo.UpsertSourceParams) (repo.Source, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertSource")
+	}
+
+	var r0 repo.Source
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repo.UpsertSourceParams) (repo.Source, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repo.UpsertSourceParams) repo.Source); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(repo.Source)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repo.UpsertSourceParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCatalog_UpsertSource_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertSource'
+type MockCatalog_UpsertSource_Call struct {
+	*mock.Call
+}
+
+// UpsertSource is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg repo.UpsertSourceParams
+func (_e *MockCatalog_Expecter) UpsertSource(ctx interface{}, arg interface{}) *MockCatalog_UpsertSource_Call {
+	return &MockCatalog_UpsertSource_Call{Call: _e.mock.On("UpsertSource", ctx, arg)}
+}
+
+func (_c *MockCatalog_UpsertSource_Call) Run(run func(ctx context.Context, arg repo.UpsertSourceParams)) *MockCatalog_UpsertSource_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 repo.UpsertSourceParams
+		if args[1] != nil {
+			arg1 = args[1].(repo.UpsertSourceParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCatalog_UpsertSource_Call) Return(source repo.Source, err error) *MockCatalog_UpsertSource_Call {
+	_c.Call.Return(source, err)
+	return _c
+}
+
+func (_c *MockCatalog_UpsertSource_Call) RunAndReturn(run func(ctx context.Context, arg repo.UpsertSourceParams) (repo.Source, error)) *MockCatalog_UpsertSource_Call {
+	_c.Call.Return(run)
+	return _c
+}
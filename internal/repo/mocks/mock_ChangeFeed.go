@@ -0,0 +1,106 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockChangeFeed creates a new instance of MockChangeFeed. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockChangeFeed(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockChangeFeed {
+	mock := &MockChangeFeed{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockChangeFeed is an autogenerated mock type for the ChangeFeed type
+type MockChangeFeed struct {
+	mock.Mock
+}
+
+type MockChangeFeed_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockChangeFeed) EXPECT() *MockChangeFeed_Expecter {
+	return &MockChangeFeed_Expecter{mock: &_m.Mock}
+}
+
+// Subscribe provides a mock function for the type MockChangeFeed
+func (_mock *MockChangeFeed) Subscribe(channel string, key string) (<-chan struct{}, func()) {
+	ret := _mock.Called(channel, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Subscribe")
+	}
+
+	var r0 <-chan struct{}
+	var r1 func()
+	if returnFunc, ok := ret.Get(0).(func(string, string) (<-chan struct{}, func())); ok {
+		return returnFunc(channel, key)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string) <-chan struct{}); ok {
+		r0 = returnFunc(channel, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan struct{})
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string) func()); ok {
+		r1 = returnFunc(channel, key)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(func())
+		}
+	}
+	return r0, r1
+}
+
+// MockChangeFeed_Subscribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Subscribe'
+type MockChangeFeed_Subscribe_Call struct {
+	*mock.Call
+}
+
+// Subscribe is a helper method to define mock.On call
+//   - channel string
+//   - key string
+func (_e *MockChangeFeed_Expecter) Subscribe(channel interface{}, key interface{}) *MockChangeFeed_Subscribe_Call {
+	return &MockChangeFeed_Subscribe_Call{Call: _e.mock.On("Subscribe", channel, key)}
+}
+
+func (_c *MockChangeFeed_Subscribe_Call) Run(run func(channel string, key string)) *MockChangeFeed_Subscribe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChangeFeed_Subscribe_Call) Return(valCh <-chan struct{}, fn func()) *MockChangeFeed_Subscribe_Call {
+	_c.Call.Return(valCh, fn)
+	return _c
+}
+
+func (_c *MockChangeFeed_Subscribe_Call) RunAndReturn(run func(channel string, key string) (<-chan struct{}, func())) *MockChangeFeed_Subscribe_Call {
+	_c.Call.Return(run)
+	return _c
+}
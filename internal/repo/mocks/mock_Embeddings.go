@@ -8,6 +8,7 @@ import (
 	"context"
 
 	"github.com/ChiaYuChang/prism/internal/repo"
+	"github.com/google/uuid"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -307,3 +308,71 @@ func (_c *MockEmbeddings_GetModelByNameAndType_Call) RunAndReturn(run func(ctx c
 	_c.Call.Return(run)
 	return _c
 }
+
+// ListContentEmbeddings provides a mock function for the type MockEmbeddings
+func (_mock *MockEmbeddings) ListContentEmbeddings(ctx context.Context, contentID uuid.UUID) ([]repo.ContentEmbedding, error) {
+	ret := _mock.Called(ctx, contentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListContentEmbeddings")
+	}
+
+	var r0 []repo.ContentEmbedding
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]repo.ContentEmbedding, error)); ok {
+		return returnFunc(ctx, contentID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []repo.ContentEmbedding); ok {
+		r0 = returnFunc(ctx, contentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repo.ContentEmbedding)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, contentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEmbeddings_ListContentEmbeddings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListContentEmbeddings'
+type MockEmbeddings_ListContentEmbeddings_Call struct {
+	*mock.Call
+}
+
+// ListContentEmbeddings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contentID uuid.UUID
+func (_e *MockEmbeddings_Expecter) ListContentEmbeddings(ctx interface{}, contentID interface{}) *MockEmbeddings_ListContentEmbeddings_Call {
+	return &MockEmbeddings_ListContentEmbeddings_Call{Call: _e.mock.On("ListContentEmbeddings", ctx, contentID)}
+}
+
+func (_c *MockEmbeddings_ListContentEmbeddings_Call) Run(run func(ctx context.Context, contentID uuid.UUID)) *MockEmbeddings_ListContentEmbeddings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEmbeddings_ListContentEmbeddings_Call) Return(contentEmbeddings []repo.ContentEmbedding, err error) *MockEmbeddings_ListContentEmbeddings_Call {
+	_c.Call.Return(contentEmbeddings, err)
+	return _c
+}
+
+func (_c *MockEmbeddings_ListContentEmbeddings_Call) RunAndReturn(run func(ctx context.Context, contentID uuid.UUID) ([]repo.ContentEmbedding, error)) *MockEmbeddings_ListContentEmbeddings_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,246 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/repo"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockLLMUsage creates a new instance of MockLLMUsage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockLLMUsage(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockLLMUsage {
+	mock := &MockLLMUsage{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockLLMUsage is an autogenerated mock type for the LLMUsage type
+type MockLLMUsage struct {
+	mock.Mock
+}
+
+type MockLLMUsage_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockLLMUsage) EXPECT() *MockLLMUsage_Expecter {
+	return &MockLLMUsage_Expecter{mock: &_m.Mock}
+}
+
+// Record provides a mock function for the type MockLLMUsage
+func (_mock *MockLLMUsage) Record(ctx context.Context, arg repo.CreateLLMUsageParams) (repo.LLMUsageRecord, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Record")
+	}
+
+	var r0 repo.LLMUsageRecord
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repo.CreateLLMUsageParams) (repo.LLMUsageRecord, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repo.CreateLLMUsageParams) repo.LLMUsageRecord); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(repo.LLMUsageRecord)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repo.CreateLLMUsageParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockLLMUsage_Record_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Record'
+type MockLLMUsage_Record_Call struct {
+	*mock.Call
+}
+
+// Record is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg repo.CreateLLMUsageParams
+func (_e *MockLLMUsage_Expecter) Record(ctx interface{}, arg interface{}) *MockLLMUsage_Record_Call {
+	return &MockLLMUsage_Record_Call{Call: _e.mock.On("Record", ctx, arg)}
+}
+
+func (_c *MockLLMUsage_Record_Call) Run(run func(ctx context.Context, arg repo.CreateLLMUsageParams)) *MockLLMUsage_Record_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 repo.CreateLLMUsageParams
+		if args[1] != nil {
+			arg1 = args[1].(repo.CreateLLMUsageParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLLMUsage_Record_Call) Return(lLMUsageRecord repo.LLMUsageRecord, err error) *MockLLMUsage_Record_Call {
+	_c.Call.Return(lLMUsageRecord, err)
+	return _c
+}
+
+func (_c *MockLLMUsage_Record_Call) RunAndReturn(run func(ctx context.Context, arg repo.CreateLLMUsageParams) (repo.LLMUsageRecord, error)) *MockLLMUsage_Record_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SumCostSince provides a mock function for the type MockLLMUsage
+func (_mock *MockLLMUsage) SumCostSince(ctx context.Context, component string, since time.Time) (int64, error) {
+	ret := _mock.Called(ctx, component, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SumCostSince")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) (int64, error)); ok {
+		return returnFunc(ctx, component, since)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) int64); ok {
+		r0 = returnFunc(ctx, component, since)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = returnFunc(ctx, component, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockLLMUsage_SumCostSince_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SumCostSince'
+type MockLLMUsage_SumCostSince_Call struct {
+	*mock.Call
+}
+
+// SumCostSince is a helper method to define mock.On call
+//   - ctx context.Context
+//   - component string
+//   - since time.Time
+func (_e *MockLLMUsage_Expecter) SumCostSince(ctx interface{}, component interface{}, since interface{}) *MockLLMUsage_SumCostSince_Call {
+	return &MockLLMUsage_SumCostSince_Call{Call: _e.mock.On("SumCostSince", ctx, component, since)}
+}
+
+func (_c *MockLLMUsage_SumCostSince_Call) Run(run func(ctx context.Context, component string, since time.Time)) *MockLLMUsage_SumCostSince_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLLMUsage_SumCostSince_Call) Return(n int64, err error) *MockLLMUsage_SumCostSince_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockLLMUsage_SumCostSince_Call) RunAndReturn(run func(ctx context.Context, component string, since time.Time) (int64, error)) *MockLLMUsage_SumCostSince_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SummarizeSpend provides a mock function for the type MockLLMUsage
+func (_mock *MockLLMUsage) SummarizeSpend(ctx context.Context, arg repo.SummarizeLLMSpendParams) ([]repo.LLMSpendSummary, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SummarizeSpend")
+	}
+
+	var r0 []repo.LLMSpendSummary
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repo.SummarizeLLMSpendParams) ([]repo.LLMSpendSummary, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repo.SummarizeLLMSpendParams) []repo.LLMSpendSummary); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repo.LLMSpendSummary)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repo.SummarizeLLMSpendParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockLLMUsage_SummarizeSpend_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SummarizeSpend'
+type MockLLMUsage_SummarizeSpend_Call struct {
+	*mock.Call
+}
+
+// SummarizeSpend is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg repo.SummarizeLLMSpendParams
+func (_e *MockLLMUsage_Expecter) SummarizeSpend(ctx interface{}, arg interface{}) *MockLLMUsage_SummarizeSpend_Call {
+	return &MockLLMUsage_SummarizeSpend_Call{Call: _e.mock.On("SummarizeSpend", ctx, arg)}
+}
+
+func (_c *MockLLMUsage_SummarizeSpend_Call) Run(run func(ctx context.Context, arg repo.SummarizeLLMSpendParams)) *MockLLMUsage_SummarizeSpend_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 repo.SummarizeLLMSpendParams
+		if args[1] != nil {
+			arg1 = args[1].(repo.SummarizeLLMSpendParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLLMUsage_SummarizeSpend_Call) Return(lLMSpendSummarys []repo.LLMSpendSummary, err error) *MockLLMUsage_SummarizeSpend_Call {
+	_c.Call.Return(lLMSpendSummarys, err)
+	return _c
+}
+
+func (_c *MockLLMUsage_SummarizeSpend_Call) RunAndReturn(run func(ctx context.Context, arg repo.SummarizeLLMSpendParams) ([]repo.LLMSpendSummary, error)) *MockLLMUsage_SummarizeSpend_Call {
+	_c.Call.Return(run)
+	return _c
+}
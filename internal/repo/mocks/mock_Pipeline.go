@@ -303,6 +303,74 @@ func (_c *MockPipeline_GetContentByURL_Call) RunAndReturn(run func(ctx context.C
 	return _c
 }
 
+// ListContents provides a mock function for the type MockPipeline
+func (_mock *MockPipeline) ListContents(ctx context.Context, arg repo.ListContentsParams) ([]repo.Content, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListContents")
+	}
+
+	var r0 []repo.Content
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repo.ListContentsParams) ([]repo.Content, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repo.ListContentsParams) []repo.Content); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repo.Content)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repo.ListContentsParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPipeline_ListContents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListContents'
+type MockPipeline_ListContents_Call struct {
+	*mock.Call
+}
+
+// ListContents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg repo.ListContentsParams
+func (_e *MockPipeline_Expecter) ListContents(ctx interface{}, arg interface{}) *MockPipeline_ListContents_Call {
+	return &MockPipeline_ListContents_Call{Call: _e.mock.On("ListContents", ctx, arg)}
+}
+
+func (_c *MockPipeline_ListContents_Call) Run(run func(ctx context.Context, arg repo.ListContentsParams)) *MockPipeline_ListContents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 repo.ListContentsParams
+		if args[1] != nil {
+			arg1 = args[1].(repo.ListContentsParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPipeline_ListContents_Call) Return(contents []repo.Content, err error) *MockPipeline_ListContents_Call {
+	_c.Call.Return(contents, err)
+	return _c
+}
+
+func (_c *MockPipeline_ListContents_Call) RunAndReturn(run func(ctx context.Context, arg repo.ListContentsParams) ([]repo.Content, error)) *MockPipeline_ListContents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListContentsByBatchID provides a mock function for the type MockPipeline
 func (_mock *MockPipeline) ListContentsByBatchID(ctx context.Context, batchID uuid.UUID) ([]repo.Content, error) {
 	ret := _mock.Called(ctx, batchID)
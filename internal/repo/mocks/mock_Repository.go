@@ -36,6 +36,52 @@ func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
 	return &MockRepository_Expecter{mock: &_m.Mock}
 }
 
+// Aliases provides a mock function for the type MockRepository
+func (_mock *MockRepository) Aliases() repo.Aliases {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Aliases")
+	}
+
+	var r0 repo.Aliases
+	if returnFunc, ok := ret.Get(0).(func() repo.Aliases); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repo.Aliases)
+		}
+	}
+	return r0
+}
+
+// MockRepository_Aliases_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Aliases'
+type MockRepository_Aliases_Call struct {
+	*mock.Call
+}
+
+// Aliases is a helper method to define mock.On call
+func (_e *MockRepository_Expecter) Aliases() *MockRepository_Aliases_Call {
+	return &MockRepository_Aliases_Call{Call: _e.mock.On("Aliases")}
+}
+
+func (_c *MockRepository_Aliases_Call) Run(run func()) *MockRepository_Aliases_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRepository_Aliases_Call) Return(aliases repo.Aliases) *MockRepository_Aliases_Call {
+	_c.Call.Return(aliases)
+	return _c
+}
+
+func (_c *MockRepository_Aliases_Call) RunAndReturn(run func() repo.Aliases) *MockRepository_Aliases_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Analysis provides a mock function for the type MockRepository
 func (_mock *MockRepository) Analysis() repo.Analysis {
 	ret := _mock.Called()
@@ -82,6 +128,52 @@ func (_c *MockRepository_Analysis_Call) RunAndReturn(run func() repo.Analysis) *
 	return _c
 }
 
+// Backfills provides a mock function for the type MockRepository
+func (_mock *MockRepository) Backfills() repo.Backfills {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Backfills")
+	}
+
+	var r0 repo.Backfills
+	if returnFunc, ok := ret.Get(0).(func() repo.Backfills); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repo.Backfills)
+		}
+	}
+	return r0
+}
+
+// MockRepository_Backfills_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Backfills'
+type MockRepository_Backfills_Call struct {
+	*mock.Call
+}
+
+// Backfills is a helper method to define mock.On call
+func (_e *MockRepository_Expecter) Backfills() *MockRepository_Backfills_Call {
+	return &MockRepository_Backfills_Call{Call: _e.mock.On("Backfills")}
+}
+
+func (_c *MockRepository_Backfills_Call) Run(run func()) *MockRepository_Backfills_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRepository_Backfills_Call) Return(backfills repo.Backfills) *MockRepository_Backfills_Call {
+	_c.Call.Return(backfills)
+	return _c
+}
+
+func (_c *MockRepository_Backfills_Call) RunAndReturn(run func() repo.Backfills) *MockRepository_Backfills_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // BatchTrigger provides a mock function for the type MockRepository
 func (_mock *MockRepository) BatchTrigger() repo.BatchTrigger {
 	ret := _mock.Called()
@@ -128,6 +220,52 @@ func (_c *MockRepository_BatchTrigger_Call) RunAndReturn(run func() repo.BatchTr
 	return _c
 }
 
+// Catalog provides a mock function for the type MockRepository
+func (_mock *MockRepository) Catalog() repo.Catalog {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Catalog")
+	}
+
+	var r0 repo.Catalog
+	if returnFunc, ok := ret.Get(0).(func() repo.Catalog); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repo.Catalog)
+		}
+	}
+	return r0
+}
+
+// MockRepository_Catalog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Catalog'
+type MockRepository_Catalog_Call struct {
+	*mock.Call
+}
+
+// Catalog is a helper method to define mock.On call
+func (_e *MockRepository_Expecter) Catalog() *MockRepository_Catalog_Call {
+	return &MockRepository_Catalog_Call{Call: _e.mock.On("Catalog")}
+}
+
+func (_c *MockRepository_Catalog_Call) Run(run func()) *MockRepository_Catalog_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRepository_Catalog_Call) Return(catalog repo.Catalog) *MockRepository_Catalog_Call {
+	_c.Call.Return(catalog)
+	return _c
+}
+
+func (_c *MockRepository_Catalog_Call) RunAndReturn(run func() repo.Catalog) *MockRepository_Catalog_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Embedding provides a mock function for the type MockRepository
 func (_mock *MockRepository) Embedding() repo.Embeddings {
 	ret := _mock.Called()
@@ -174,6 +312,52 @@ func (_c *MockRepository_Embedding_Call) RunAndReturn(run func() repo.Embeddings
 	return _c
 }
 
+// LLMUsage provides a mock function for the type MockRepository
+func (_mock *MockRepository) LLMUsage() repo.LLMUsage {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for LLMUsage")
+	}
+
+	var r0 repo.LLMUsage
+	if returnFunc, ok := ret.Get(0).(func() repo.LLMUsage); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repo.LLMUsage)
+		}
+	}
+	return r0
+}
+
+// MockRepository_LLMUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LLMUsage'
+type MockRepository_LLMUsage_Call struct {
+	*mock.Call
+}
+
+// LLMUsage is a helper method to define mock.On call
+func (_e *MockRepository_Expecter) LLMUsage() *MockRepository_LLMUsage_Call {
+	return &MockRepository_LLMUsage_Call{Call: _e.mock.On("LLMUsage")}
+}
+
+func (_c *MockRepository_LLMUsage_Call) Run(run func()) *MockRepository_LLMUsage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRepository_LLMUsage_Call) Return(lLMUsage repo.LLMUsage) *MockRepository_LLMUsage_Call {
+	_c.Call.Return(lLMUsage)
+	return _c
+}
+
+func (_c *MockRepository_LLMUsage_Call) RunAndReturn(run func() repo.LLMUsage) *MockRepository_LLMUsage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Pipeline provides a mock function for the type MockRepository
 func (_mock *MockRepository) Pipeline() repo.Pipeline {
 	ret := _mock.Called()
@@ -312,6 +496,52 @@ func (_c *MockRepository_Scout_Call) RunAndReturn(run func() repo.Scout) *MockRe
 	return _c
 }
 
+// TaskRuns provides a mock function for the type MockRepository
+func (_mock *MockRepository) TaskRuns() repo.TaskRuns {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for TaskRuns")
+	}
+
+	var r0 repo.TaskRuns
+	if returnFunc, ok := ret.Get(0).(func() repo.TaskRuns); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repo.TaskRuns)
+		}
+	}
+	return r0
+}
+
+// MockRepository_TaskRuns_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TaskRuns'
+type MockRepository_TaskRuns_Call struct {
+	*mock.Call
+}
+
+// TaskRuns is a helper method to define mock.On call
+func (_e *MockRepository_Expecter) TaskRuns() *MockRepository_TaskRuns_Call {
+	return &MockRepository_TaskRuns_Call{Call: _e.mock.On("TaskRuns")}
+}
+
+func (_c *MockRepository_TaskRuns_Call) Run(run func()) *MockRepository_TaskRuns_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRepository_TaskRuns_Call) Return(taskRuns repo.TaskRuns) *MockRepository_TaskRuns_Call {
+	_c.Call.Return(taskRuns)
+	return _c
+}
+
+func (_c *MockRepository_TaskRuns_Call) RunAndReturn(run func() repo.TaskRuns) *MockRepository_TaskRuns_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Tasks provides a mock function for the type MockRepository
 func (_mock *MockRepository) Tasks() repo.Tasks {
 	ret := _mock.Called()
@@ -403,3 +633,49 @@ func (_c *MockRepository_UserFetches_Call) RunAndReturn(run func() repo.UserFetc
 	_c.Call.Return(run)
 	return _c
 }
+
+// Users provides a mock function for the type MockRepository
+func (_mock *MockRepository) Users() repo.Users {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Users")
+	}
+
+	var r0 repo.Users
+	if returnFunc, ok := ret.Get(0).(func() repo.Users); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repo.Users)
+		}
+	}
+	return r0
+}
+
+// MockRepository_Users_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Users'
+type MockRepository_Users_Call struct {
+	*mock.Call
+}
+
+// Users is a helper method to define mock.On call
+func (_e *MockRepository_Expecter) Users() *MockRepository_Users_Call {
+	return &MockRepository_Users_Call{Call: _e.mock.On("Users")}
+}
+
+func (_c *MockRepository_Users_Call) Run(run func()) *MockRepository_Users_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRepository_Users_Call) Return(users repo.Users) *MockRepository_Users_Call {
+	_c.Call.Return(users)
+	return _c
+}
+
+func (_c *MockRepository_Users_Call) RunAndReturn(run func() repo.Users) *MockRepository_Users_Call {
+	_c.Call.Return(run)
+	return _c
+}
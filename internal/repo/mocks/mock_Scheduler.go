@@ -120,16 +120,16 @@ func (_c *MockScheduler_ClaimTasks_Call) RunAndReturn(run func(ctx context.Conte
 }
 
 // CompleteTask provides a mock function for the type MockScheduler
-func (_mock *MockScheduler) CompleteTask(ctx context.Context, id uuid.UUID) error {
-	ret := _mock.Called(ctx, id)
+func (_mock *MockScheduler) CompleteTask(ctx context.Context, arg repo.CompleteTaskParams) error {
+	ret := _mock.Called(ctx, arg)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CompleteTask")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repo.CompleteTaskParams) error); ok {
+		r0 = returnFunc(ctx, arg)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -143,20 +143,20 @@ type MockScheduler_CompleteTask_Call struct {
 
 // CompleteTask is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id uuid.UUID
-func (_e *MockScheduler_Expecter) CompleteTask(ctx interface{}, id interface{}) *MockScheduler_CompleteTask_Call {
-	return &MockScheduler_CompleteTask_Call{Call: _e.mock.On("CompleteTask", ctx, id)}
+//   - arg repo.CompleteTaskParams
+func (_e *MockScheduler_Expecter) CompleteTask(ctx interface{}, arg interface{}) *MockScheduler_CompleteTask_Call {
+	return &MockScheduler_CompleteTask_Call{Call: _e.mock.On("CompleteTask", ctx, arg)}
 }
 
-func (_c *MockScheduler_CompleteTask_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockScheduler_CompleteTask_Call {
+func (_c *MockScheduler_CompleteTask_Call) Run(run func(ctx context.Context, arg repo.CompleteTaskParams)) *MockScheduler_CompleteTask_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 uuid.UUID
+		var arg1 repo.CompleteTaskParams
 		if args[1] != nil {
-			arg1 = args[1].(uuid.UUID)
+			arg1 = args[1].(repo.CompleteTaskParams)
 		}
 		run(
 			arg0,
@@ -171,7 +171,7 @@ func (_c *MockScheduler_CompleteTask_Call) Return(err error) *MockScheduler_Comp
 	return _c
 }
 
-func (_c *MockScheduler_CompleteTask_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) error) *MockScheduler_CompleteTask_Call {
+func (_c *MockScheduler_CompleteTask_Call) RunAndReturn(run func(ctx context.Context, arg repo.CompleteTaskParams) error) *MockScheduler_CompleteTask_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -39,6 +39,72 @@ func (_m *MockScout) EXPECT() *MockScout_Expecter {
 	return &MockScout_Expecter{mock: &_m.Mock}
 }
 
+// CountCandidates provides a mock function for the type MockScout
+func (_mock *MockScout) CountCandidates(ctx context.Context, arg repo.CountCandidatesParams) (int64, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountCandidates")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repo.CountCandidatesParams) (int64, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repo.CountCandidatesParams) int64); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repo.CountCandidatesParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockScout_CountCandidates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountCandidates'
+type MockScout_CountCandidates_Call struct {
+	*mock.Call
+}
+
+// CountCandidates is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg repo.CountCandidatesParams
+func (_e *MockScout_Expecter) CountCandidates(ctx interface{}, arg interface{}) *MockScout_CountCandidates_Call {
+	return &MockScout_CountCandidates_Call{Call: _e.mock.On("CountCandidates", ctx, arg)}
+}
+
+func (_c *MockScout_CountCandidates_Call) Run(run func(ctx context.Context, arg repo.CountCandidatesParams)) *MockScout_CountCandidates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 repo.CountCandidatesParams
+		if args[1] != nil {
+			arg1 = args[1].(repo.CountCandidatesParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockScout_CountCandidates_Call) Return(n int64, err error) *MockScout_CountCandidates_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockScout_CountCandidates_Call) RunAndReturn(run func(ctx context.Context, arg repo.CountCandidatesParams) (int64, error)) *MockScout_CountCandidates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CountCandidatesByBatchID provides a mock function for the type MockScout
 func (_mock *MockScout) CountCandidatesByBatchID(ctx context.Context, batchID uuid.UUID) (int64, error) {
 	ret := _mock.Called(ctx, batchID)
@@ -303,6 +369,74 @@ func (_c *MockScout_GetCandidateByID_Call) RunAndReturn(run func(ctx context.Con
 	return _c
 }
 
+// GetCandidatesByFingerprints provides a mock function for the type MockScout
+func (_mock *MockScout) GetCandidatesByFingerprints(ctx context.Context, fingerprints []string) ([]repo.Candidate, error) {
+	ret := _mock.Called(ctx, fingerprints)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCandidatesByFingerprints")
+	}
+
+	var r0 []repo.Candidate
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) ([]repo.Candidate, error)); ok {
+		return returnFunc(ctx, fingerprints)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) []repo.Candidate); ok {
+		r0 = returnFunc(ctx, fingerprints)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repo.Candidate)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, fingerprints)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockScout_GetCandidatesByFingerprints_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCandidatesByFingerprints'
+type MockScout_GetCandidatesByFingerprints_Call struct {
+	*mock.Call
+}
+
+// GetCandidatesByFingerprints is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fingerprints []string
+func (_e *MockScout_Expecter) GetCandidatesByFingerprints(ctx interface{}, fingerprints interface{}) *MockScout_GetCandidatesByFingerprints_Call {
+	return &MockScout_GetCandidatesByFingerprints_Call{Call: _e.mock.On("GetCandidatesByFingerprints", ctx, fingerprints)}
+}
+
+func (_c *MockScout_GetCandidatesByFingerprints_Call) Run(run func(ctx context.Context, fingerprints []string)) *MockScout_GetCandidatesByFingerprints_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockScout_GetCandidatesByFingerprints_Call) Return(candidates []repo.Candidate, err error) *MockScout_GetCandidatesByFingerprints_Call {
+	_c.Call.Return(candidates, err)
+	return _c
+}
+
+func (_c *MockScout_GetCandidatesByFingerprints_Call) RunAndReturn(run func(ctx context.Context, fingerprints []string) ([]repo.Candidate, error)) *MockScout_GetCandidatesByFingerprints_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetCandidatesByIDs provides a mock function for the type MockScout
 func (_mock *MockScout) GetCandidatesByIDs(ctx context.Context, ids []uuid.UUID) ([]repo.Candidate, error) {
 	ret := _mock.Called(ctx, ids)
@@ -505,6 +639,74 @@ func (_c *MockScout_ListCandidates_Call) RunAndReturn(run func(ctx context.Conte
 	return _c
 }
 
+// ListCandidatesDiscoveredAfter provides a mock function for the type MockScout
+func (_mock *MockScout) ListCandidatesDiscoveredAfter(ctx context.Context, arg repo.ListCandidatesDiscoveredAfterParams) ([]repo.Candidate, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCandidatesDiscoveredAfter")
+	}
+
+	var r0 []repo.Candidate
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repo.ListCandidatesDiscoveredAfterParams) ([]repo.Candidate, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repo.ListCandidatesDiscoveredAfterParams) []repo.Candidate); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repo.Candidate)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repo.ListCandidatesDiscoveredAfterParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockScout_ListCandidatesDiscoveredAfter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCandidatesDiscoveredAfter'
+type MockScout_ListCandidatesDiscoveredAfter_Call struct {
+	*mock.Call
+}
+
+// ListCandidatesDiscoveredAfter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg repo.ListCandidatesDiscoveredAfterParams
+func (_e *MockScout_Expecter) ListCandidatesDiscoveredAfter(ctx interface{}, arg interface{}) *MockScout_ListCandidatesDiscoveredAfter_Call {
+	return &MockScout_ListCandidatesDiscoveredAfter_Call{Call: _e.mock.On("ListCandidatesDiscoveredAfter", ctx, arg)}
+}
+
+func (_c *MockScout_ListCandidatesDiscoveredAfter_Call) Run(run func(ctx context.Context, arg repo.ListCandidatesDiscoveredAfterParams)) *MockScout_ListCandidatesDiscoveredAfter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 repo.ListCandidatesDiscoveredAfterParams
+		if args[1] != nil {
+			arg1 = args[1].(repo.ListCandidatesDiscoveredAfterParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockScout_ListCandidatesDiscoveredAfter_Call) Return(candidates []repo.Candidate, err error) *MockScout_ListCandidatesDiscoveredAfter_Call {
+	_c.Call.Return(candidates, err)
+	return _c
+}
+
+func (_c *MockScout_ListCandidatesDiscoveredAfter_Call) RunAndReturn(run func(ctx context.Context, arg repo.ListCandidatesDiscoveredAfterParams) ([]repo.Candidate, error)) *MockScout_ListCandidatesDiscoveredAfter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListSourcesByType provides a mock function for the type MockScout
 func (_mock *MockScout) ListSourcesByType(ctx context.Context, sourceType string) ([]repo.Source, error) {
 	ret := _mock.Called(ctx, sourceType)
@@ -0,0 +1,377 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/ChiaYuChang/prism/internal/repo"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockTaskRuns creates a new instance of MockTaskRuns. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTaskRuns(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTaskRuns {
+	mock := &MockTaskRuns{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTaskRuns is an autogenerated mock type for the TaskRuns type
+type MockTaskRuns struct {
+	mock.Mock
+}
+
+type MockTaskRuns_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTaskRuns) EXPECT() *MockTaskRuns_Expecter {
+	return &MockTaskRuns_Expecter{mock: &_m.Mock}
+}
+
+// ListByTask provides a mock function for the type MockTaskRuns
+func (_mock *MockTaskRuns) ListByTask(ctx context.Context, arg repo.ListTaskRunsParams) ([]repo.TaskRun, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByTask")
+	}
+
+	var r0 []repo.TaskRun
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repo.ListTaskRunsParams) ([]repo.TaskRun, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repo.ListTaskRunsParams) []repo.TaskRun); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repo.TaskRun)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repo.ListTaskRunsParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTaskRuns_ListByTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByTask'
+type MockTaskRuns_ListByTask_Call struct {
+	*mock.Call
+}
+
+// ListByTask is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg repo.ListTaskRunsParams
+func (_e *MockTaskRuns_Expecter) ListByTask(ctx interface{}, arg interface{}) *MockTaskRuns_ListByTask_Call {
+	return &MockTaskRuns_ListByTask_Call{Call: _e.mock.On("ListByTask", ctx, arg)}
+}
+
+func (_c *MockTaskRuns_ListByTask_Call) Run(run func(ctx context.Context, arg repo.ListTaskRunsParams)) *MockTaskRuns_ListByTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 repo.ListTaskRunsParams
+		if args[1] != nil {
+			arg1 = args[1].(repo.ListTaskRunsParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskRuns_ListByTask_Call) Return(taskRuns []repo.TaskRun, err error) *MockTaskRuns_ListByTask_Call {
+	_c.Call.Return(taskRuns, err)
+	return _c
+}
+
+func (_c *MockTaskRuns_ListByTask_Call) RunAndReturn(run func(ctx context.Context, arg repo.ListTaskRunsParams) ([]repo.TaskRun, error)) *MockTaskRuns_ListByTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Record provides a mock function for the type MockTaskRuns
+func (_mock *MockTaskRuns) Record(ctx context.Context, arg repo.CreateTaskRunParams) (repo.TaskRun, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Record")
+	}
+
+	var r0 repo.TaskRun
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repo.CreateTaskRunParams) (repo.TaskRun, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repo.CreateTaskRunParams) repo.TaskRun); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(repo.TaskRun)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repo.CreateTaskRunParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTaskRuns_Record_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Record'
+type MockTaskRuns_Record_Call struct {
+	*mock.Call
+}
+
+// Record is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg repo.CreateTaskRunParams
+func (_e *MockTaskRuns_Expecter) Record(ctx interface{}, arg interface{}) *MockTaskRuns_Record_Call {
+	return &MockTaskRuns_Record_Call{Call: _e.mock.On("Record", ctx, arg)}
+}
+
+func (_c *MockTaskRuns_Record_Call) Run(run func(ctx context.Context, arg repo.CreateTaskRunParams)) *MockTaskRuns_Record_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 repo.CreateTaskRunParams
+		if args[1] != nil {
+			arg1 = args[1].(repo.CreateTaskRunParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskRuns_Record_Call) Return(taskRun repo.TaskRun, err error) *MockTaskRuns_Record_Call {
+	_c.Call.Return(taskRun, err)
+	return _c
+}
+
+func (_c *MockTaskRuns_Record_Call) RunAndReturn(run func(ctx context.Context, arg repo.CreateTaskRunParams) (repo.TaskRun, error)) *MockTaskRuns_Record_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SummarizeByProvider provides a mock function for the type MockTaskRuns
+func (_mock *MockTaskRuns) SummarizeByProvider(ctx context.Context, arg repo.SummarizeTaskRunsParams) ([]repo.TaskRunYield, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SummarizeByProvider")
+	}
+
+	var r0 []repo.TaskRunYield
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repo.SummarizeTaskRunsParams) ([]repo.TaskRunYield, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repo.SummarizeTaskRunsParams) []repo.TaskRunYield); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repo.TaskRunYield)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repo.SummarizeTaskRunsParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTaskRuns_SummarizeByProvider_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SummarizeByProvider'
+type MockTaskRuns_SummarizeByProvider_Call struct {
+	*mock.Call
+}
+
+// SummarizeByProvider is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg repo.SummarizeTaskRunsParams
+func (_e *MockTaskRuns_Expecter) SummarizeByProvider(ctx interface{}, arg interface{}) *MockTaskRuns_SummarizeByProvider_Call {
+	return &MockTaskRuns_SummarizeByProvider_Call{Call: _e.mock.On("SummarizeByProvider", ctx, arg)}
+}
+
+func (_c *MockTaskRuns_SummarizeByProvider_Call) Run(run func(ctx context.Context, arg repo.SummarizeTaskRunsParams)) *MockTaskRuns_SummarizeByProvider_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 repo.SummarizeTaskRunsParams
+		if args[1] != nil {
+			arg1 = args[1].(repo.SummarizeTaskRunsParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskRuns_SummarizeByProvider_Call) Return(taskRunYields []repo.TaskRunYield, err error) *MockTaskRuns_SummarizeByProvider_Call {
+	_c.Call.Return(taskRunYields, err)
+	return _c
+}
+
+func (_c *MockTaskRuns_SummarizeByProvider_Call) RunAndReturn(run func(ctx context.Context, arg repo.SummarizeTaskRunsParams) ([]repo.TaskRunYield, error)) *MockTaskRuns_SummarizeByProvider_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SummarizeBySource provides a mock function for the type MockTaskRuns
+func (_mock *MockTaskRuns) SummarizeBySource(ctx context.Context, arg repo.SummarizeTaskRunsParams) ([]repo.TaskRunYield, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SummarizeBySource")
+	}
+
+	var r0 []repo.TaskRunYield
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repo.SummarizeTaskRunsParams) ([]repo.TaskRunYield, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repo.SummarizeTaskRunsParams) []repo.TaskRunYield); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repo.TaskRunYield)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repo.SummarizeTaskRunsParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTaskRuns_SummarizeBySource_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SummarizeBySource'
+type MockTaskRuns_SummarizeBySource_Call struct {
+	*mock.Call
+}
+
+// SummarizeBySource is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg repo.SummarizeTaskRunsParams
+func (_e *MockTaskRuns_Expecter) SummarizeBySource(ctx interface{}, arg interface{}) *MockTaskRuns_SummarizeBySource_Call {
+	return &MockTaskRuns_SummarizeBySource_Call{Call: _e.mock.On("SummarizeBySource", ctx, arg)}
+}
+
+func (_c *MockTaskRuns_SummarizeBySource_Call) Run(run func(ctx context.Context, arg repo.SummarizeTaskRunsParams)) *MockTaskRuns_SummarizeBySource_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 repo.SummarizeTaskRunsParams
+		if args[1] != nil {
+			arg1 = args[1].(repo.SummarizeTaskRunsParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskRuns_SummarizeBySource_Call) Return(taskRunYields []repo.TaskRunYield, err error) *MockTaskRuns_SummarizeBySource_Call {
+	_c.Call.Return(taskRunYields, err)
+	return _c
+}
+
+func (_c *MockTaskRuns_SummarizeBySource_Call) RunAndReturn(run func(ctx context.Context, arg repo.SummarizeTaskRunsParams) ([]repo.TaskRunYield, error)) *MockTaskRuns_SummarizeBySource_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SummarizeByTask provides a mock function for the type MockTaskRuns
+func (_mock *MockTaskRuns) SummarizeByTask(ctx context.Context, arg repo.SummarizeTaskRunsParams) ([]repo.TaskRunYield, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SummarizeByTask")
+	}
+
+	var r0 []repo.TaskRunYield
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repo.SummarizeTaskRunsParams) ([]repo.TaskRunYield, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repo.SummarizeTaskRunsParams) []repo.TaskRunYield); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repo.TaskRunYield)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repo.SummarizeTaskRunsParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTaskRuns_SummarizeByTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SummarizeByTask'
+type MockTaskRuns_SummarizeByTask_Call struct {
+	*mock.Call
+}
+
+// SummarizeByTask is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg repo.SummarizeTaskRunsParams
+func (_e *MockTaskRuns_Expecter) SummarizeByTask(ctx interface{}, arg interface{}) *MockTaskRuns_SummarizeByTask_Call {
+	return &MockTaskRuns_SummarizeByTask_Call{Call: _e.mock.On("SummarizeByTask", ctx, arg)}
+}
+
+func (_c *MockTaskRuns_SummarizeByTask_Call) Run(run func(ctx context.Context, arg repo.SummarizeTaskRunsParams)) *MockTaskRuns_SummarizeByTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 repo.SummarizeTaskRunsParams
+		if args[1] != nil {
+			arg1 = args[1].(repo.SummarizeTaskRunsParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskRuns_SummarizeByTask_Call) Return(taskRunYields []repo.TaskRunYield, err error) *MockTaskRuns_SummarizeByTask_Call {
+	_c.Call.Return(taskRunYields, err)
+	return _c
+}
+
+func (_c *MockTaskRuns_SummarizeByTask_Call) RunAndReturn(run func(ctx context.Context, arg repo.SummarizeTaskRunsParams) ([]repo.TaskRunYield, error)) *MockTaskRuns_SummarizeByTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -6,6 +6,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/ChiaYuChang/prism/internal/repo"
 	"github.com/google/uuid"
@@ -39,6 +40,80 @@ func (_m *MockUserFetches) EXPECT() *MockUserFetches_Expecter {
 	return &MockUserFetches_Expecter{mock: &_m.Mock}
 }
 
+// ClaimDueNotifications provides a mock function for the type MockUserFetches
+func (_mock *MockUserFetches) ClaimDueNotifications(ctx context.Context, limit int32, lease time.Duration) ([]repo.FetchNotification, error) {
+	ret := _mock.Called(ctx, limit, lease)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimDueNotifications")
+	}
+
+	var r0 []repo.FetchNotification
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, time.Duration) ([]repo.FetchNotification, error)); ok {
+		return returnFunc(ctx, limit, lease)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, time.Duration) []repo.FetchNotification); ok {
+		r0 = returnFunc(ctx, limit, lease)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repo.FetchNotification)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32, time.Duration) error); ok {
+		r1 = returnFunc(ctx, limit, lease)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserFetches_ClaimDueNotifications_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimDueNotifications'
+type MockUserFetches_ClaimDueNotifications_Call struct {
+	*mock.Call
+}
+
+// ClaimDueNotifications is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int32
+//   - lease time.Duration
+func (_e *MockUserFetches_Expecter) ClaimDueNotifications(ctx interface{}, limit interface{}, lease interface{}) *MockUserFetches_ClaimDueNotifications_Call {
+	return &MockUserFetches_ClaimDueNotifications_Call{Call: _e.mock.On("ClaimDueNotifications", ctx, limit, lease)}
+}
+
+func (_c *MockUserFetches_ClaimDueNotifications_Call) Run(run func(ctx context.Context, limit int32, lease time.Duration)) *MockUserFetches_ClaimDueNotifications_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int32
+		if args[1] != nil {
+			arg1 = args[1].(int32)
+		}
+		var arg2 time.Duration
+		if args[2] != nil {
+			arg2 = args[2].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserFetches_ClaimDueNotifications_Call) Return(fetchNotifications []repo.FetchNotification, err error) *MockUserFetches_ClaimDueNotifications_Call {
+	_c.Call.Return(fetchNotifications, err)
+	return _c
+}
+
+func (_c *MockUserFetches_ClaimDueNotifications_Call) RunAndReturn(run func(ctx context.Context, limit int32, lease time.Duration) ([]repo.FetchNotification, error)) *MockUserFetches_ClaimDueNotifications_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function for the type MockUserFetches
 func (_mock *MockUserFetches) Create(ctx context.Context, arg repo.CreateUserFetchParams) (repo.UserFetch, error) {
 	ret := _mock.Called(ctx, arg)
@@ -171,6 +246,72 @@ func (_c *MockUserFetches_CreateItem_Call) RunAndReturn(run func(ctx context.Con
 	return _c
 }
 
+// EnqueueNotification provides a mock function for the type MockUserFetches
+func (_mock *MockUserFetches) EnqueueNotification(ctx context.Context, arg repo.CreateFetchNotificationParams) (int64, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnqueueNotification")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repo.CreateFetchNotificationParams) (int64, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repo.CreateFetchNotificationParams) int64); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repo.CreateFetchNotificationParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserFetches_EnqueueNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnqueueNotification'
+type MockUserFetches_EnqueueNotification_Call struct {
+	*mock.Call
+}
+
+// EnqueueNotification is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg repo.CreateFetchNotificationParams
+func (_e *MockUserFetches_Expecter) EnqueueNotification(ctx interface{}, arg interface{}) *MockUserFetches_EnqueueNotification_Call {
+	return &MockUserFetches_EnqueueNotification_Call{Call: _e.mock.On("EnqueueNotification", ctx, arg)}
+}
+
+func (_c *MockUserFetches_EnqueueNotification_Call) Run(run func(ctx context.Context, arg repo.CreateFetchNotificationParams)) *MockUserFetches_EnqueueNotification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 repo.CreateFetchNotificationParams
+		if args[1] != nil {
+			arg1 = args[1].(repo.CreateFetchNotificationParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserFetches_EnqueueNotification_Call) Return(n int64, err error) *MockUserFetches_EnqueueNotification_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockUserFetches_EnqueueNotification_Call) RunAndReturn(run func(ctx context.Context, arg repo.CreateFetchNotificationParams) (int64, error)) *MockUserFetches_EnqueueNotification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Get provides a mock function for the type MockUserFetches
 func (_mock *MockUserFetches) Get(ctx context.Context, id uuid.UUID) (repo.UserFetch, error) {
 	ret := _mock.Called(ctx, id)
@@ -303,21 +444,98 @@ func (_c *MockUserFetches_GetProgress_Call) RunAndReturn(run func(ctx context.Co
 	return _c
 }
 
+// ListTerminal provides a mock function for the type MockUserFetches
+func (_mock *MockUserFetches) ListTerminal(ctx context.Context, limit int32) ([]repo.UserFetch, error) {
+	ret := _mock.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTerminal")
+	}
+
+	var r0 []repo.UserFetch
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) ([]repo.UserFetch, error)); ok {
+		return returnFunc(ctx, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) []repo.UserFetch); ok {
+		r0 = returnFunc(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repo.UserFetch)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = returnFunc(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserFetches_ListTerminal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTerminal'
+type MockUserFetches_ListTerminal_Call struct {
+	*mock.Call
+}
+
+// ListTerminal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int32
+func (_e *MockUserFetches_Expecter) ListTerminal(ctx interface{}, limit interface{}) *MockUserFetches_ListTerminal_Call {
+	return &MockUserFetches_ListTerminal_Call{Call: _e.mock.On("ListTerminal", ctx, limit)}
+}
+
+func (_c *MockUserFetches_ListTerminal_Call) Run(run func(ctx context.Context, limit int32)) *MockUserFetches_ListTerminal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int32
+		if args[1] != nil {
+			arg1 = args[1].(int32)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserFetches_ListTerminal_Call) Return(userFetchs []repo.UserFetch, err error) *MockUserFetches_ListTerminal_Call {
+	_c.Call.Return(userFetchs, err)
+	return _c
+}
+
+func (_c *MockUserFetches_ListTerminal_Call) RunAndReturn(run func(ctx context.Context, limit int32) ([]repo.UserFetch, error)) *MockUserFetches_ListTerminal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // MarkCompleted provides a mock function for the type MockUserFetches
-func (_mock *MockUserFetches) MarkCompleted(ctx context.Context, fetchID uuid.UUID) error {
+func (_mock *MockUserFetches) MarkCompleted(ctx context.Context, fetchID uuid.UUID) (int64, error) {
 	ret := _mock.Called(ctx, fetchID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for MarkCompleted")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (int64, error)); ok {
+		return returnFunc(ctx, fetchID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) int64); ok {
 		r0 = returnFunc(ctx, fetchID)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int64)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, fetchID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
 // MockUserFetches_MarkCompleted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkCompleted'
@@ -350,12 +568,69 @@ func (_c *MockUserFetches_MarkCompleted_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *MockUserFetches_MarkCompleted_Call) Return(err error) *MockUserFetches_MarkCompleted_Call {
+func (_c *MockUserFetches_MarkCompleted_Call) Return(n int64, err error) *MockUserFetches_MarkCompleted_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockUserFetches_MarkCompleted_Call) RunAndReturn(run func(ctx context.Context, fetchID uuid.UUID) (int64, error)) *MockUserFetches_MarkCompleted_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordNotificationAttempt provides a mock function for the type MockUserFetches
+func (_mock *MockUserFetches) RecordNotificationAttempt(ctx context.Context, arg repo.RecordFetchNotificationAttemptParams) error {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordNotificationAttempt")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repo.RecordFetchNotificationAttemptParams) error); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserFetches_RecordNotificationAttempt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordNotificationAttempt'
+type MockUserFetches_RecordNotificationAttempt_Call struct {
+	*mock.Call
+}
+
+// RecordNotificationAttempt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg repo.RecordFetchNotificationAttemptParams
+func (_e *MockUserFetches_Expecter) RecordNotificationAttempt(ctx interface{}, arg interface{}) *MockUserFetches_RecordNotificationAttempt_Call {
+	return &MockUserFetches_RecordNotificationAttempt_Call{Call: _e.mock.On("RecordNotificationAttempt", ctx, arg)}
+}
+
+func (_c *MockUserFetches_RecordNotificationAttempt_Call) Run(run func(ctx context.Context, arg repo.RecordFetchNotificationAttemptParams)) *MockUserFetches_RecordNotificationAttempt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 repo.RecordFetchNotificationAttemptParams
+		if args[1] != nil {
+			arg1 = args[1].(repo.RecordFetchNotificationAttemptParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserFetches_RecordNotificationAttempt_Call) Return(err error) *MockUserFetches_RecordNotificationAttempt_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockUserFetches_MarkCompleted_Call) RunAndReturn(run func(ctx context.Context, fetchID uuid.UUID) error) *MockUserFetches_MarkCompleted_Call {
+func (_c *MockUserFetches_RecordNotificationAttempt_Call) RunAndReturn(run func(ctx context.Context, arg repo.RecordFetchNotificationAttemptParams) error) *MockUserFetches_RecordNotificationAttempt_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,438 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/ChiaYuChang/prism/internal/repo"
+	"github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockUsers creates a new instance of MockUsers. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockUsers(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockUsers {
+	mock := &MockUsers{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockUsers is an autogenerated mock type for the Users type
+type MockUsers struct {
+	mock.Mock
+}
+
+type MockUsers_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockUsers) EXPECT() *MockUsers_Expecter {
+	return &MockUsers_Expecter{mock: &_m.Mock}
+}
+
+// CreateAPIKey provides a mock function for the type MockUsers
+func (_mock *MockUsers) CreateAPIKey(ctx context.Context, arg repo.CreateAPIKeyParams) (repo.APIKey, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAPIKey")
+	}
+
+	var r0 repo.APIKey
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repo.CreateAPIKeyParams) (repo.APIKey, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repo.CreateAPIKeyParams) repo.APIKey); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(repo.APIKey)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repo.CreateAPIKeyParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUsers_CreateAPIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAPIKey'
+type MockUsers_CreateAPIKey_Call struct {
+	*mock.Call
+}
+
+// CreateAPIKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg repo.CreateAPIKeyParams
+func (_e *MockUsers_Expecter) CreateAPIKey(ctx interface{}, arg interface{}) *MockUsers_CreateAPIKey_Call {
+	return &MockUsers_CreateAPIKey_Call{Call: _e.mock.On("CreateAPIKey", ctx, arg)}
+}
+
+func (_c *MockUsers_CreateAPIKey_Call) Run(run func(ctx context.Context, arg repo.CreateAPIKeyParams)) *MockUsers_CreateAPIKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 repo.CreateAPIKeyParams
+		if args[1] != nil {
+			arg1 = args[1].(repo.CreateAPIKeyParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsers_CreateAPIKey_Call) Return(aPIKey repo.APIKey, err error) *MockUsers_CreateAPIKey_Call {
+	_c.Call.Return(aPIKey, err)
+	return _c
+}
+
+func (_c *MockUsers_CreateAPIKey_Call) RunAndReturn(run func(ctx context.Context, arg repo.CreateAPIKeyParams) (repo.APIKey, error)) *MockUsers_CreateAPIKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateUser provides a mock function for the type MockUsers
+func (_mock *MockUsers) CreateUser(ctx context.Context, name string) (repo.User, error) {
+	ret := _mock.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUser")
+	}
+
+	var r0 repo.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (repo.User, error)); ok {
+		return returnFunc(ctx, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) repo.User); ok {
+		r0 = returnFunc(ctx, name)
+	} else {
+		r0 = ret.Get(0).(repo.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUsers_CreateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUser'
+type MockUsers_CreateUser_Call struct {
+	*mock.Call
+}
+
+// CreateUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockUsers_Expecter) CreateUser(ctx interface{}, name interface{}) *MockUsers_CreateUser_Call {
+	return &MockUsers_CreateUser_Call{Call: _e.mock.On("CreateUser", ctx, name)}
+}
+
+func (_c *MockUsers_CreateUser_Call) Run(run func(ctx context.Context, name string)) *MockUsers_CreateUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsers_CreateUser_Call) Return(user repo.User, err error) *MockUsers_CreateUser_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockUsers_CreateUser_Call) RunAndReturn(run func(ctx context.Context, name string) (repo.User, error)) *MockUsers_CreateUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUser provides a mock function for the type MockUsers
+func (_mock *MockUsers) GetUser(ctx context.Context, id uuid.UUID) (repo.User, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUser")
+	}
+
+	var r0 repo.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (repo.User, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) repo.User); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(repo.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUsers_GetUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUser'
+type MockUsers_GetUser_Call struct {
+	*mock.Call
+}
+
+// GetUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockUsers_Expecter) GetUser(ctx interface{}, id interface{}) *MockUsers_GetUser_Call {
+	return &MockUsers_GetUser_Call{Call: _e.mock.On("GetUser", ctx, id)}
+}
+
+func (_c *MockUsers_GetUser_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockUsers_GetUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsers_GetUser_Call) Return(user repo.User, err error) *MockUsers_GetUser_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockUsers_GetUser_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (repo.User, error)) *MockUsers_GetUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAPIKeys provides a mock function for the type MockUsers
+func (_mock *MockUsers) ListAPIKeys(ctx context.Context, userID uuid.UUID) ([]repo.APIKey, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAPIKeys")
+	}
+
+	var r0 []repo.APIKey
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]repo.APIKey, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []repo.APIKey); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repo.APIKey)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUsers_ListAPIKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAPIKeys'
+type MockUsers_ListAPIKeys_Call struct {
+	*mock.Call
+}
+
+// ListAPIKeys is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *MockUsers_Expecter) ListAPIKeys(ctx interface{}, userID interface{}) *MockUsers_ListAPIKeys_Call {
+	return &MockUsers_ListAPIKeys_Call{Call: _e.mock.On("ListAPIKeys", ctx, userID)}
+}
+
+func (_c *MockUsers_ListAPIKeys_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockUsers_ListAPIKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsers_ListAPIKeys_Call) Return(aPIKeys []repo.APIKey, err error) *MockUsers_ListAPIKeys_Call {
+	_c.Call.Return(aPIKeys, err)
+	return _c
+}
+
+func (_c *MockUsers_ListAPIKeys_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) ([]repo.APIKey, error)) *MockUsers_ListAPIKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResolveAPIKey provides a mock function for the type MockUsers
+func (_mock *MockUsers) ResolveAPIKey(ctx context.Context, keyHash string) (repo.APIKey, error) {
+	ret := _mock.Called(ctx, keyHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveAPIKey")
+	}
+
+	var r0 repo.APIKey
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (repo.APIKey, error)); ok {
+		return returnFunc(ctx, keyHash)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) repo.APIKey); ok {
+		r0 = returnFunc(ctx, keyHash)
+	} else {
+		r0 = ret.Get(0).(repo.APIKey)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, keyHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUsers_ResolveAPIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolveAPIKey'
+type MockUsers_ResolveAPIKey_Call struct {
+	*mock.Call
+}
+
+// ResolveAPIKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - keyHash string
+func (_e *MockUsers_Expecter) ResolveAPIKey(ctx interface{}, keyHash interface{}) *MockUsers_ResolveAPIKey_Call {
+	return &MockUsers_ResolveAPIKey_Call{Call: _e.mock.On("ResolveAPIKey", ctx, keyHash)}
+}
+
+func (_c *MockUsers_ResolveAPIKey_Call) Run(run func(ctx context.Context, keyHash string)) *MockUsers_ResolveAPIKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsers_ResolveAPIKey_Call) Return(aPIKey repo.APIKey, err error) *MockUsers_ResolveAPIKey_Call {
+	_c.Call.Return(aPIKey, err)
+	return _c
+}
+
+func (_c *MockUsers_ResolveAPIKey_Call) RunAndReturn(run func(ctx context.Context, keyHash string) (repo.APIKey, error)) *MockUsers_ResolveAPIKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeAPIKey provides a mock function for the type MockUsers
+func (_mock *MockUsers) RevokeAPIKey(ctx context.Context, id uuid.UUID) (int64, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeAPIKey")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (int64, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) int64); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUsers_RevokeAPIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeAPIKey'
+type MockUsers_RevokeAPIKey_Call struct {
+	*mock.Call
+}
+
+// RevokeAPIKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockUsers_Expecter) RevokeAPIKey(ctx interface{}, id interface{}) *MockUsers_RevokeAPIKey_Call {
+	return &MockUsers_RevokeAPIKey_Call{Call: _e.mock.On("RevokeAPIKey", ctx, id)}
+}
+
+func (_c *MockUsers_RevokeAPIKey_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockUsers_RevokeAPIKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsers_RevokeAPIKey_Call) Return(n int64, err error) *MockUsers_RevokeAPIKey_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockUsers_RevokeAPIKey_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (int64, error)) *MockUsers_RevokeAPIKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
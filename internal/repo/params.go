@@ -21,13 +21,57 @@ type CreateCandidateParams struct {
 
 type UpsertCandidateParams = CreateCandidateParams
 
+// ListCandidatesParams filters GET /candidates. Rows sort by
+// (published_at DESC NULLS LAST, discovered_at DESC, id DESC). When
+// AfterDiscoveredAt is set only rows strictly after that keyset position
+// are returned; AfterPublishedAt is nil when the last row had no
+// published_at. Offset is the legacy alternative to the keyset.
 type ListCandidatesParams struct {
+	Query             *string    `validate:"omitempty"`
+	SourceAbbr        *string    `validate:"omitempty"`
+	Since             *time.Time `validate:"omitempty"`
+	Until             *time.Time `validate:"omitempty"`
+	AfterPublishedAt  *time.Time `validate:"omitempty"`
+	AfterDiscoveredAt *time.Time `validate:"omitempty"`
+	AfterID           *uuid.UUID `validate:"required_with=AfterDiscoveredAt"`
+	Limit             int32      `validate:"min=1,max=500"`
+	Offset            int32      `validate:"min=0"`
+}
+
+// CountCandidatesParams holds the ListCandidatesParams filters.
+type CountCandidatesParams struct {
 	Query      *string    `validate:"omitempty"`
 	SourceAbbr *string    `validate:"omitempty"`
 	Since      *time.Time `validate:"omitempty"`
 	Until      *time.Time `validate:"omitempty"`
-	Limit      int32      `validate:"min=1,max=500"`
-	Offset     int32      `validate:"min=0"`
+}
+
+// ListCandidatesDiscoveredAfterParams is the keyset cursor for the candidate
+// stream. Rows sort by (discovered_at, id); only rows strictly after
+// (AfterDiscoveredAt, AfterID) and discovered at or before Until are returned.
+type ListCandidatesDiscoveredAfterParams struct {
+	AfterDiscoveredAt time.Time
+	AfterID           uuid.UUID
+	Until             time.Time
+	SourceAbbr        *string `validate:"omitempty"`
+	IngestionMethod   *string `validate:"omitempty"`
+	Limit             int32   `validate:"min=1,max=500"`
+}
+
+// ListContentsParams filters GET /contents. Rows sort by (published_at, id)
+// descending; when AfterPublishedAt is set only rows strictly before
+// (AfterPublishedAt, AfterID) are returned. Query is a full-text search over
+// title and content.
+type ListContentsParams struct {
+	Query            *string    `validate:"omitempty"`
+	SourceAbbr       *string    `validate:"omitempty"`
+	Type             *string    `validate:"omitempty,oneof=PARTY_RELEASE GOVERNMENT_RELEASE ARTICLE SOCIAL"`
+	BatchID          *uuid.UUID `validate:"omitempty"`
+	Since            *time.Time `validate:"omitempty"`
+	Until            *time.Time `validate:"omitempty"`
+	AfterPublishedAt *time.Time `validate:"omitempty"`
+	AfterID          *uuid.UUID `validate:"required_with=AfterPublishedAt"`
+	Limit            int32      `validate:"min=1,max=1000"`
 }
 
 type CreateTaskParams struct {
@@ -45,6 +89,15 @@ type CreateTaskParams struct {
 	ExpiresAt  *time.Time     `validate:"omitempty"`
 }
 
+// CompleteTaskParams finishes a RUNNING task. NextRunIn overrides the
+// frequency of a recurring task for its next run only; Meta is merged into
+// tasks.meta.
+type CompleteTaskParams struct {
+	ID        uuid.UUID      `validate:"required"`
+	NextRunIn *time.Duration `validate:"omitempty,gt=0"`
+	Meta      []byte         `validate:"omitempty"`
+}
+
 type ExtendActiveTaskExpiryParams struct {
 	SourceAbbr  string     `validate:"required"`
 	Kind        string     `validate:"required"`
@@ -75,8 +128,12 @@ type UpdateContentMetadataParams struct {
 }
 
 type UpsertPromptParams struct {
-	Hash string `validate:"required"`
-	Path string `validate:"required"`
+	Name     string `validate:"required"`
+	Version  string `validate:"required,max=64"`
+	Hash     string `validate:"required,len=64"`
+	Path     string `validate:"required"`
+	Content  string `validate:"required"`
+	Metadata []byte `validate:"omitempty"`
 }
 
 type CreateCandidateEmbeddingParams struct {
@@ -105,6 +162,7 @@ type CreateContentExtractionParams struct {
 	Summary       string    `validate:"required"`
 	RawResult     []byte    `validate:"required"`
 	TraceID       string    `validate:"required"`
+	PromptVersion string    `validate:"omitempty,max=64"`
 }
 
 type GetContentExtractionSnapshotParams struct {
@@ -127,7 +185,25 @@ type CreateContentExtractionEntityParams struct {
 }
 
 type CreateUserFetchParams struct {
-	UserID *uuid.UUID `validate:"omitempty"`
+	UserID       *uuid.UUID `validate:"omitempty"`
+	NotifyURL    *string    `validate:"omitempty,url"`
+	NotifySecret *string    `validate:"required_with=NotifyURL"`
+}
+
+type CreateFetchNotificationParams struct {
+	FetchID uuid.UUID `validate:"required"`
+	URL     string    `validate:"required,url"`
+	Payload []byte    `validate:"required"`
+}
+
+// RecordFetchNotificationAttemptParams records one delivery attempt.
+// StatusCode is nil when no response arrived.
+type RecordFetchNotificationAttemptParams struct {
+	ID            uuid.UUID `validate:"required"`
+	Status        string    `validate:"required,oneof=PENDING DELIVERED FAILED"`
+	StatusCode    *int32
+	Error         *string
+	NextAttemptAt time.Time
 }
 
 type CreateUserFetchItemParams struct {
@@ -136,3 +212,118 @@ type CreateUserFetchItemParams struct {
 	TaskID         *uuid.UUID `validate:"omitempty"`
 	SnapshotStatus *string    `validate:"omitempty"`
 }
+
+type CreateAPIKeyParams struct {
+	UserID         uuid.UUID  `validate:"required"`
+	Name           string     `validate:"required,max=128"`
+	Prefix         string     `validate:"required,max=16"`
+	KeyHash        string     `validate:"required,len=64,hexadecimal"`
+	Scopes         []string   `validate:"required,min=1,dive,oneof=read page_fetch admin"`
+	RateLimitRPS   *float64   `validate:"omitempty,gt=0"`
+	RateLimitBurst *int32     `validate:"omitempty,gt=0"`
+	ExpiresAt      *time.Time `validate:"omitempty"`
+}
+
+type CreateLLMUsageParams struct {
+	Provider     string     `validate:"required"`
+	Model        string     `validate:"required"`
+	Operation    string     `validate:"required"`
+	Component    string     `validate:"required"`
+	InputTokens  int32      `validate:"min=0"`
+	OutputTokens int32      `validate:"min=0"`
+	CachedTokens int32      `validate:"min=0"`
+	TotalTokens  int32      `validate:"min=0"`
+	CostMicros   int64      `validate:"min=0"`
+	TraceID      string     `validate:"required"`
+	BatchID      *uuid.UUID `validate:"omitempty"`
+}
+
+type SaveBackfillCheckpointParams struct {
+	SourceAbbr          string     `validate:"required,max=16"`
+	Pager               string     `validate:"required,max=16"`
+	LastPage            string     `validate:"required"`
+	BatchID             uuid.UUID  `validate:"required"`
+	Until               time.Time  `validate:"required"`
+	PagesVisited        int32      `validate:"min=0"`
+	CandidatesSeen      int32      `validate:"min=0"`
+	CandidatesProcessed int32      `validate:"min=0"`
+	OldestPublishedAt   *time.Time `validate:"omitempty"`
+	CompletedAt         *time.Time `validate:"omitempty"`
+}
+
+// SummarizeLLMSpendParams selects ledger rows in [Since, Until). Component
+// nil means every component.
+type SummarizeLLMSpendParams struct {
+	Since     time.Time `validate:"required"`
+	Until     time.Time `validate:"required,gtfield=Since"`
+	Component *string   `validate:"omitempty"`
+}
+
+type CreateTaskRunParams struct {
+	TaskID         uuid.UUID                  `validate:"required"`
+	BatchID        uuid.UUID                  `validate:"required"`
+	Kind           string                     `validate:"required,oneof=DIRECTORY_FETCH KEYWORD_SEARCH PAGE_FETCH"`
+	SourceType     string                     `validate:"required,oneof=PARTY MEDIA GOVERNMENT"`
+	SourceAbbr     string                     `validate:"required,max=16"`
+	Query          *string                    `validate:"omitempty"`
+	Status         string                     `validate:"required,oneof=OK FAILED"`
+	ErrorClass     *string                    `validate:"required_if=Status FAILED,omitempty,max=32"`
+	ItemsSeen      int32                      `validate:"min=0"`
+	ItemsNew       int32                      `validate:"min=0"`
+	ItemsDuplicate int32                      `validate:"min=0"`
+	Providers      map[string]TaskRunProvider `validate:"omitempty"`
+	Duration       time.Duration              `validate:"min=0"`
+	TraceID        string                     `validate:"required"`
+	StartedAt      time.Time                  `validate:"required"`
+}
+
+// ListTaskRunsParams pages through the runs of one task, newest first by
+// (StartedAt, ID); when AfterStartedAt is set only rows strictly before
+// (AfterStartedAt, AfterID) are returned.
+type ListTaskRunsParams struct {
+	TaskID         uuid.UUID  `validate:"required"`
+	AfterStartedAt *time.Time `validate:"omitempty"`
+	AfterID        *int64     `validate:"required_with=AfterStartedAt"`
+	Limit          int32      `validate:"min=1"`
+}
+
+// SummarizeTaskRunsParams selects runs started in [Since, Until). Kind and
+// SourceAbbr nil match every kind and source; Kind is ignored by the
+// provider summary, which only covers KEYWORD_SEARCH.
+type SummarizeTaskRunsParams struct {
+	Since      time.Time `validate:"required"`
+	Until      time.Time `validate:"required,gtfield=Since"`
+	Kind       *string   `validate:"omitempty,oneof=DIRECTORY_FETCH KEYWORD_SEARCH"`
+	SourceAbbr *string   `validate:"omitempty,max=16"`
+	Limit      int32     `validate:"min=1"`
+}
+
+type UpsertSourceParams struct {
+	Abbr    string `validate:"required,max=16"`
+	Name    string `validate:"required,max=128"`
+	Type    string `validate:"required,oneof=PARTY MEDIA GOVERNMENT"`
+	BaseURL string `validate:"required,url"`
+}
+
+type UpsertScoutConfigParams struct {
+	Name   string `validate:"required,max=64"`
+	Kind   string `validate:"required,oneof=html rss atom custom"`
+	Config []byte `validate:"required"`
+}
+
+// ReplaceSearchAliasesParams is the whole alias group of Canonical; aliases
+// not listed are removed.
+type ReplaceSearchAliasesParams struct {
+	Canonical string             `validate:"required"`
+	Aliases   []SearchAliasEntry `validate:"required,min=1,dive"`
+}
+
+type SearchAliasEntry struct {
+	Alias string `validate:"required"`
+	Kind  string `validate:"required,oneof=ALIAS ABBREVIATION VARIANT"`
+}
+
+type UpsertParserRuleParams struct {
+	Host   string `validate:"required,max=255"`
+	Config []byte `validate:"required"`
+}
@@ -146,8 +146,12 @@ func dbModelToRepoModel(m Model) repo.Model {
 func dbPromptToRepoPrompt(p Prompt) repo.Prompt {
 	return repo.Prompt{
 		ID:        p.ID,
+		Name:      p.Name,
+		Version:   p.Version,
 		Hash:      p.Hash,
 		Path:      p.Path,
+		Content:   p.Content,
+		Metadata:  p.Metadata,
 		CreatedAt: *pgconv.PgTimestamptzToTimePtr(p.CreatedAt),
 	}
 }
@@ -164,6 +168,7 @@ func dbContentExtractionToRepoContentExtraction(c ContentExtraction) repo.Conten
 		Summary:       c.Summary,
 		RawResult:     c.RawResult,
 		TraceID:       c.TraceID,
+		PromptVersion: c.PromptVersion,
 		CreatedAt:     *pgconv.PgTimestamptzToTimePtr(c.CreatedAt),
 	}
 }
@@ -194,6 +199,7 @@ func dbContentEmbeddingToRepoContentEmbedding(e ContentEmbeddingsGemma2025) repo
 		ContentID: e.ContentID,
 		ModelID:   e.ModelID,
 		Category:  string(e.Category),
+		Vector:    e.Vector.Slice(),
 		TraceID:   e.TraceID,
 		CreatedAt: *pgconv.PgTimestamptzToTimePtr(e.CreatedAt),
 	}
@@ -12,6 +12,37 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countCandidates = `-- name: CountCandidates :one
+SELECT COUNT(*)
+FROM candidates
+WHERE ($1::text IS NULL
+       OR title ILIKE '%' || $1::text || '%'
+       OR COALESCE(description, '') ILIKE '%' || $1::text || '%')
+  AND ($2::varchar IS NULL OR source_abbr = $2::varchar)
+  AND ($3::timestamptz IS NULL OR COALESCE(published_at, discovered_at) >= $3::timestamptz)
+  AND ($4::timestamptz IS NULL OR COALESCE(published_at, discovered_at) <= $4::timestamptz)
+`
+
+type CountCandidatesParams struct {
+	Query      pgtype.Text        `db:"query" json:"query"`
+	SourceAbbr pgtype.Text        `db:"source_abbr" json:"source_abbr"`
+	Since      pgtype.Timestamptz `db:"since" json:"since"`
+	Until      pgtype.Timestamptz `db:"until" json:"until"`
+}
+
+// Same filters as ListCandidates, without paging.
+func (q *Queries) CountCandidates(ctx context.Context, arg CountCandidatesParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countCandidates,
+		arg.Query,
+		arg.SourceAbbr,
+		arg.Since,
+		arg.Until,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countCandidatesByBatchID = `-- name: CountCandidatesByBatchID :one
 SELECT COUNT(*)
 FROM candidates
@@ -193,6 +224,46 @@ func (q *Queries) GetCandidatesByIDs(ctx context.Context, ids []uuid.UUID) ([]Ca
 	return items, nil
 }
 
+const getCandidatesByFingerprints = `-- name: GetCandidatesByFingerprints :many
+SELECT id, batch_id, source_abbr, trace_id, fingerprint, url, title, description, ingestion_method, metadata, published_at, discovered_at, created_at
+FROM candidates
+WHERE fingerprint = ANY($1::text[])
+`
+
+func (q *Queries) GetCandidatesByFingerprints(ctx context.Context, fingerprints []string) ([]Candidate, error) {
+	rows, err := q.db.Query(ctx, getCandidatesByFingerprints, fingerprints)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Candidate
+	for rows.Next() {
+		var i Candidate
+		if err := rows.Scan(
+			&i.ID,
+			&i.BatchID,
+			&i.SourceAbbr,
+			&i.TraceID,
+			&i.Fingerprint,
+			&i.Url,
+			&i.Title,
+			&i.Description,
+			&i.IngestionMethod,
+			&i.Metadata,
+			&i.PublishedAt,
+			&i.DiscoveredAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listCandidates = `-- name: ListCandidates :many
 SELECT id, batch_id, source_abbr, trace_id, fingerprint, url, title, description, ingestion_method, metadata, published_at, discovered_at, created_at
 FROM candidates
@@ -202,26 +273,43 @@ WHERE ($1::text IS NULL
   AND ($2::varchar IS NULL OR source_abbr = $2::varchar)
   AND ($3::timestamptz IS NULL OR COALESCE(published_at, discovered_at) >= $3::timestamptz)
   AND ($4::timestamptz IS NULL OR COALESCE(published_at, discovered_at) <= $4::timestamptz)
-ORDER BY published_at DESC NULLS LAST, discovered_at DESC, created_at DESC
-LIMIT $6::int
-OFFSET $5::int
+  AND ($5::timestamptz IS NULL
+       OR ($6::timestamptz IS NOT NULL
+           AND (published_at IS NULL
+                OR (published_at, discovered_at, id)
+                   < ($6::timestamptz, $5::timestamptz, $7::uuid)))
+       OR ($6::timestamptz IS NULL
+           AND published_at IS NULL
+           AND (discovered_at, id) < ($5::timestamptz, $7::uuid)))
+ORDER BY published_at DESC NULLS LAST, discovered_at DESC, id DESC
+LIMIT $9::int
+OFFSET $8::int
 `
 
 type ListCandidatesParams struct {
-	Query      pgtype.Text        `db:"query" json:"query"`
-	SourceAbbr pgtype.Text        `db:"source_abbr" json:"source_abbr"`
-	Since      pgtype.Timestamptz `db:"since" json:"since"`
-	Until      pgtype.Timestamptz `db:"until" json:"until"`
-	Off        int32              `db:"off" json:"off"`
-	Lim        int32              `db:"lim" json:"lim"`
+	Query             pgtype.Text        `db:"query" json:"query"`
+	SourceAbbr        pgtype.Text        `db:"source_abbr" json:"source_abbr"`
+	Since             pgtype.Timestamptz `db:"since" json:"since"`
+	Until             pgtype.Timestamptz `db:"until" json:"until"`
+	AfterDiscoveredAt pgtype.Timestamptz `db:"after_discovered_at" json:"after_discovered_at"`
+	AfterPublishedAt  pgtype.Timestamptz `db:"after_published_at" json:"after_published_at"`
+	AfterID           pgtype.UUID        `db:"after_id" json:"after_id"`
+	Off               int32              `db:"off" json:"off"`
+	Lim               int32              `db:"lim" json:"lim"`
 }
 
+// Pages in (published_at DESC NULLS LAST, discovered_at DESC, id DESC) order.
+// after_* is the last row of the previous page (keyset); rows without
+// published_at come after every dated row. off is kept for legacy clients.
 func (q *Queries) ListCandidates(ctx context.Context, arg ListCandidatesParams) ([]Candidate, error) {
 	rows, err := q.db.Query(ctx, listCandidates,
 		arg.Query,
 		arg.SourceAbbr,
 		arg.Since,
 		arg.Until,
+		arg.AfterDiscoveredAt,
+		arg.AfterPublishedAt,
+		arg.AfterID,
 		arg.Off,
 		arg.Lim,
 	)
@@ -257,6 +345,70 @@ func (q *Queries) ListCandidates(ctx context.Context, arg ListCandidatesParams)
 	return items, nil
 }
 
+const listCandidatesDiscoveredAfter = `-- name: ListCandidatesDiscoveredAfter :many
+SELECT id, batch_id, source_abbr, trace_id, fingerprint, url, title, description, ingestion_method, metadata, published_at, discovered_at, created_at
+FROM candidates
+WHERE (discovered_at, id) > ($1::timestamptz, $2::uuid)
+  AND discovered_at <= $3::timestamptz
+  AND ($4::varchar IS NULL OR source_abbr = $4::varchar)
+  AND ($5::candidate_ingestion_method IS NULL
+       OR ingestion_method = $5::candidate_ingestion_method)
+ORDER BY discovered_at, id
+LIMIT $6::int
+`
+
+type ListCandidatesDiscoveredAfterParams struct {
+	AfterDiscoveredAt pgtype.Timestamptz           `db:"after_discovered_at" json:"after_discovered_at"`
+	AfterID           uuid.UUID                    `db:"after_id" json:"after_id"`
+	Until             pgtype.Timestamptz           `db:"until" json:"until"`
+	SourceAbbr        pgtype.Text                  `db:"source_abbr" json:"source_abbr"`
+	IngestionMethod   NullCandidateIngestionMethod `db:"ingestion_method" json:"ingestion_method"`
+	Lim               int32                        `db:"lim" json:"lim"`
+}
+
+// Keyset scan in (discovered_at, id) order for GET /candidates/stream.
+// UpsertCandidate bumps discovered_at, so a re-seen candidate sorts again.
+func (q *Queries) ListCandidatesDiscoveredAfter(ctx context.Context, arg ListCandidatesDiscoveredAfterParams) ([]Candidate, error) {
+	rows, err := q.db.Query(ctx, listCandidatesDiscoveredAfter,
+		arg.AfterDiscoveredAt,
+		arg.AfterID,
+		arg.Until,
+		arg.SourceAbbr,
+		arg.IngestionMethod,
+		arg.Lim,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Candidate
+	for rows.Next() {
+		var i Candidate
+		if err := rows.Scan(
+			&i.ID,
+			&i.BatchID,
+			&i.SourceAbbr,
+			&i.TraceID,
+			&i.Fingerprint,
+			&i.Url,
+			&i.Title,
+			&i.Description,
+			&i.IngestionMethod,
+			&i.Metadata,
+			&i.PublishedAt,
+			&i.DiscoveredAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listCandidatesForAnalysis = `-- name: ListCandidatesForAnalysis :many
 SELECT id, batch_id, source_abbr, trace_id, fingerprint, url, title, description, ingestion_method, metadata, published_at, discovered_at, created_at
 FROM candidates
@@ -184,6 +184,84 @@ func (q *Queries) GetContentByURL(ctx context.Context, url string) (Content, err
 	return i, err
 }
 
+const listContents = `-- name: ListContents :many
+SELECT id, batch_id, type, source_abbr, candidate_id, url, title, content, author, trace_id, published_at, fetched_at, created_at, deleted_at, metadata
+FROM contents
+WHERE deleted_at IS NULL
+  AND ($1::text IS NULL
+       OR contents_search_document(title, content) @@ contents_search_query($1::text))
+  AND ($2::varchar IS NULL OR source_abbr = $2::varchar)
+  AND ($3::content_type IS NULL OR type = $3::content_type)
+  AND ($4::uuid IS NULL OR batch_id = $4::uuid)
+  AND ($5::timestamptz IS NULL OR published_at >= $5::timestamptz)
+  AND ($6::timestamptz IS NULL OR published_at <= $6::timestamptz)
+  AND ($7::timestamptz IS NULL
+       OR (published_at, id) < ($7::timestamptz, $8::uuid))
+ORDER BY published_at DESC, id DESC
+LIMIT $9::int
+`
+
+type ListContentsParams struct {
+	Query            pgtype.Text        `db:"query" json:"query"`
+	SourceAbbr       pgtype.Text        `db:"source_abbr" json:"source_abbr"`
+	Type             NullContentType    `db:"type" json:"type"`
+	BatchID          pgtype.UUID        `db:"batch_id" json:"batch_id"`
+	Since            pgtype.Timestamptz `db:"since" json:"since"`
+	Until            pgtype.Timestamptz `db:"until" json:"until"`
+	AfterPublishedAt pgtype.Timestamptz `db:"after_published_at" json:"after_published_at"`
+	AfterID          pgtype.UUID        `db:"after_id" json:"after_id"`
+	Lim              int32              `db:"lim" json:"lim"`
+}
+
+// Keyset page over (published_at, id) DESC. after_published_at/after_id are
+// the last row of the previous page; q is matched through the CJK-aware
+// contents_search_document index (see migration 000009).
+func (q *Queries) ListContents(ctx context.Context, arg ListContentsParams) ([]Content, error) {
+	rows, err := q.db.Query(ctx, listContents,
+		arg.Query,
+		arg.SourceAbbr,
+		arg.Type,
+		arg.BatchID,
+		arg.Since,
+		arg.Until,
+		arg.AfterPublishedAt,
+		arg.AfterID,
+		arg.Lim,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Content
+	for rows.Next() {
+		var i Content
+		if err := rows.Scan(
+			&i.ID,
+			&i.BatchID,
+			&i.Type,
+			&i.SourceAbbr,
+			&i.CandidateID,
+			&i.Url,
+			&i.Title,
+			&i.Content,
+			&i.Author,
+			&i.TraceID,
+			&i.PublishedAt,
+			&i.FetchedAt,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.Metadata,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listContentsByBatchID = `-- name: ListContentsByBatchID :many
 SELECT id, batch_id, type, source_abbr, candidate_id, url, title, content, author, trace_id, published_at, fetched_at, created_at, deleted_at, metadata
 FROM contents
@@ -231,7 +309,7 @@ func (q *Queries) ListContentsByBatchID(ctx context.Context, batchID pgtype.UUID
 const listRecentSeedContents = `-- name: ListRecentSeedContents :many
 SELECT id, batch_id, type, source_abbr, candidate_id, url, title, content, author, trace_id, published_at, fetched_at, created_at, deleted_at, metadata
 FROM contents
-WHERE type = 'PARTY_RELEASE'
+WHERE type IN ('PARTY_RELEASE', 'GOVERNMENT_RELEASE')
   AND deleted_at IS NULL
 ORDER BY published_at DESC, created_at DESC
 LIMIT $1
@@ -22,7 +22,8 @@ INSERT INTO content_extractions (
     title,
     summary,
     raw_result,
-    trace_id
+    trace_id,
+    prompt_version
 ) VALUES (
     $1,
     $2,
@@ -32,9 +33,10 @@ INSERT INTO content_extractions (
     $6,
     $7,
     $8,
-    $9
+    $9,
+    $10
 )
-RETURNING id, content_id, model_id, prompt_id, schema_name, schema_version, title, summary, raw_result, trace_id, created_at
+RETURNING id, content_id, model_id, prompt_id, schema_name, schema_version, title, summary, raw_result, trace_id, created_at, prompt_version
 `
 
 type CreateContentExtractionParams struct {
@@ -47,6 +49,7 @@ type CreateContentExtractionParams struct {
 	Summary       string    `db:"summary" json:"summary"`
 	RawResult     []byte    `db:"raw_result" json:"raw_result"`
 	TraceID       string    `db:"trace_id" json:"trace_id"`
+	PromptVersion string    `db:"prompt_version" json:"prompt_version"`
 }
 
 func (q *Queries) CreateContentExtraction(ctx context.Context, arg CreateContentExtractionParams) (ContentExtraction, error) {
@@ -60,6 +63,7 @@ func (q *Queries) CreateContentExtraction(ctx context.Context, arg CreateContent
 		arg.Summary,
 		arg.RawResult,
 		arg.TraceID,
+		arg.PromptVersion,
 	)
 	var i ContentExtraction
 	err := row.Scan(
@@ -74,6 +78,7 @@ func (q *Queries) CreateContentExtraction(ctx context.Context, arg CreateContent
 		&i.RawResult,
 		&i.TraceID,
 		&i.CreatedAt,
+		&i.PromptVersion,
 	)
 	return i, err
 }
@@ -110,7 +115,7 @@ func (q *Queries) CreateContentExtractionEntity(ctx context.Context, arg CreateC
 }
 
 const getContentExtractionByID = `-- name: GetContentExtractionByID :one
-SELECT id, content_id, model_id, prompt_id, schema_name, schema_version, title, summary, raw_result, trace_id, created_at
+SELECT id, content_id, model_id, prompt_id, schema_name, schema_version, title, summary, raw_result, trace_id, created_at, prompt_version
 FROM content_extractions
 WHERE id = $1
 LIMIT 1
@@ -131,12 +136,13 @@ func (q *Queries) GetContentExtractionByID(ctx context.Context, id uuid.UUID) (C
 		&i.RawResult,
 		&i.TraceID,
 		&i.CreatedAt,
+		&i.PromptVersion,
 	)
 	return i, err
 }
 
 const getContentExtractionSnapshot = `-- name: GetContentExtractionSnapshot :one
-SELECT id, content_id, model_id, prompt_id, schema_name, schema_version, title, summary, raw_result, trace_id, created_at
+SELECT id, content_id, model_id, prompt_id, schema_name, schema_version, title, summary, raw_result, trace_id, created_at, prompt_version
 FROM content_extractions
 WHERE content_id = $1
   AND model_id = $2
@@ -172,6 +178,7 @@ func (q *Queries) GetContentExtractionSnapshot(ctx context.Context, arg GetConte
 		&i.RawResult,
 		&i.TraceID,
 		&i.CreatedAt,
+		&i.PromptVersion,
 	)
 	return i, err
 }
@@ -0,0 +1,189 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/repo"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	defaultListenerMinBackoff = time.Second
+	defaultListenerMaxBackoff = 30 * time.Second
+)
+
+var ErrParamMissing = errors.New("param missing")
+
+var _ repo.ChangeFeed = (*Listener)(nil)
+
+// Listener implements repo.ChangeFeed over Postgres LISTEN/NOTIFY. It holds
+// one dedicated connection outside the pool, since a pooled connection would
+// be handed back and lose its LISTEN registrations.
+//
+// After every (re)connect all subscribers are woken once: notifications sent
+// while the connection was down are lost, and subscribers catch up by
+// re-reading their state.
+type Listener struct {
+	logger     *slog.Logger
+	connString string
+	channels   []string
+	minBackoff time.Duration
+	maxBackoff time.Duration
+
+	mu     sync.Mutex
+	closed bool
+	subs   map[string]map[*subscription]struct{}
+}
+
+type subscription struct {
+	key string
+	ch  chan struct{}
+}
+
+// NewListener returns a Listener for channels. Call Run to connect.
+func NewListener(logger *slog.Logger, connString string, channels ...string) (*Listener, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("%w: logger", ErrParamMissing)
+	}
+	if connString == "" {
+		return nil, fmt.Errorf("%w: conn_string", ErrParamMissing)
+	}
+	if len(channels) == 0 {
+		return nil, fmt.Errorf("%w: channels", ErrParamMissing)
+	}
+	subs := make(map[string]map[*subscription]struct{}, len(channels))
+	for _, ch := range channels {
+		subs[ch] = make(map[*subscription]struct{})
+	}
+	return &Listener{
+		logger:     logger,
+		connString: connString,
+		channels:   channels,
+		minBackoff: defaultListenerMinBackoff,
+		maxBackoff: defaultListenerMaxBackoff,
+		subs:       subs,
+	}, nil
+}
+
+// Subscribe implements repo.ChangeFeed. Subscribing to a channel the
+// Listener was not built for, or after Run returned, yields a closed channel.
+func (l *Listener) Subscribe(channel, key string) (<-chan struct{}, func()) {
+	sub := &subscription{key: key, ch: make(chan struct{}, 1)}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	subs, ok := l.subs[channel]
+	if !ok || l.closed {
+		close(sub.ch)
+		return sub.ch, func() {}
+	}
+	subs[sub] = struct{}{}
+
+	return sub.ch, func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if _, ok := subs[sub]; ok {
+			delete(subs, sub)
+			close(sub.ch)
+		}
+	}
+}
+
+// Run listens until ctx is done, reconnecting with exponential backoff. On
+// return every subscription channel is closed.
+func (l *Listener) Run(ctx context.Context) error {
+	defer l.close()
+
+	backoff := l.minBackoff
+	for {
+		connected, err := l.listen(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if connected {
+			backoff = l.minBackoff
+		}
+		l.logger.WarnContext(ctx, "change feed connection lost; reconnecting",
+			slog.Any("error", err),
+			slog.Duration("backoff", backoff))
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, l.maxBackoff)
+	}
+}
+
+// listen runs one connection until it fails. connected reports whether the
+// LISTEN registrations succeeded, which resets the reconnect backoff.
+func (l *Listener) listen(ctx context.Context) (connected bool, err error) {
+	conn, err := pgx.Connect(ctx, l.connString)
+	if err != nil {
+		return false, fmt.Errorf("connect: %w", err)
+	}
+	defer func() { _ = conn.Close(context.WithoutCancel(ctx)) }()
+
+	for _, ch := range l.channels {
+		if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{ch}.Sanitize()); err != nil {
+			return false, fmt.Errorf("listen %s: %w", ch, err)
+		}
+	}
+	l.logger.InfoContext(ctx, "change feed listening", slog.Any("channels", l.channels))
+	l.wakeAll()
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return true, fmt.Errorf("wait for notification: %w", err)
+		}
+		l.dispatch(n.Channel, n.Payload)
+	}
+}
+
+// dispatch wakes the subscribers of channel whose key matches payload. A
+// subscriber that already has a wake-up pending is skipped.
+func (l *Listener) dispatch(channel, payload string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for sub := range l.subs[channel] {
+		if sub.key == "" || sub.key == payload {
+			wake(sub.ch)
+		}
+	}
+}
+
+func (l *Listener) wakeAll() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, subs := range l.subs {
+		for sub := range subs {
+			wake(sub.ch)
+		}
+	}
+}
+
+func (l *Listener) close() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.closed = true
+	for _, subs := range l.subs {
+		for sub := range subs {
+			delete(subs, sub)
+			close(sub.ch)
+		}
+	}
+}
+
+func wake(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
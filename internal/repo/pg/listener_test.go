@@ -0,0 +1,63 @@
+package pg
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ChiaYuChang/prism/internal/repo"
+	"github.com/ChiaYuChang/prism/pkg/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListener_Dispatch(t *testing.T) {
+	l, err := NewListener(testutils.Logger(), "postgres://unused", repo.ChangeChannelFetchProgress)
+	require.NoError(t, err)
+
+	mine, unsubscribe := l.Subscribe(repo.ChangeChannelFetchProgress, "fetch-a")
+	all, _ := l.Subscribe(repo.ChangeChannelFetchProgress, "")
+
+	l.dispatch(repo.ChangeChannelFetchProgress, "fetch-b")
+	require.Len(t, mine, 0)
+	require.Len(t, all, 1)
+
+	// Wake-ups coalesce: a second notification does not block or queue.
+	l.dispatch(repo.ChangeChannelFetchProgress, "fetch-a")
+	l.dispatch(repo.ChangeChannelFetchProgress, "fetch-a")
+	require.Len(t, mine, 1)
+	require.Len(t, all, 1)
+
+	unsubscribe()
+	<-mine
+	_, open := <-mine
+	require.False(t, open)
+	unsubscribe()
+
+	unknown, _ := l.Subscribe(repo.ChangeChannelCandidates, "")
+	_, open = <-unknown
+	require.False(t, open)
+}
+
+func TestListener_RunClosesSubscriptions(t *testing.T) {
+	l, err := NewListener(testutils.Logger(), "postgres://unused", repo.ChangeChannelCandidates)
+	require.NoError(t, err)
+	sub, _ := l.Subscribe(repo.ChangeChannelCandidates, "")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.NoError(t, l.Run(ctx))
+
+	_, open := <-sub
+	require.False(t, open)
+	late, _ := l.Subscribe(repo.ChangeChannelCandidates, "")
+	_, open = <-late
+	require.False(t, open)
+}
+
+func TestNewListener_ParamMissing(t *testing.T) {
+	_, err := NewListener(nil, "postgres://unused", repo.ChangeChannelCandidates)
+	require.ErrorIs(t, err, ErrParamMissing)
+	_, err = NewListener(testutils.Logger(), "", repo.ChangeChannelCandidates)
+	require.ErrorIs(t, err, ErrParamMissing)
+	_, err = NewListener(testutils.Logger(), "postgres://unused")
+	require.ErrorIs(t, err, ErrParamMissing)
+}
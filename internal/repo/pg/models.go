@@ -80,9 +80,10 @@ func AllCandidateIngestionMethodValues() []CandidateIngestionMethod {
 type ContentType string
 
 const (
-	ContentTypePARTYRELEASE ContentType = "PARTY_RELEASE"
-	ContentTypeARTICLE      ContentType = "ARTICLE"
-	ContentTypeSOCIAL       ContentType = "SOCIAL"
+	ContentTypePARTYRELEASE      ContentType = "PARTY_RELEASE"
+	ContentTypeARTICLE           ContentType = "ARTICLE"
+	ContentTypeSOCIAL            ContentType = "SOCIAL"
+	ContentTypeGOVERNMENTRELEASE ContentType = "GOVERNMENT_RELEASE"
 )
 
 func (e *ContentType) Scan(src interface{}) error {
@@ -124,7 +125,8 @@ func (e ContentType) Valid() bool {
 	switch e {
 	case ContentTypePARTYRELEASE,
 		ContentTypeARTICLE,
-		ContentTypeSOCIAL:
+		ContentTypeSOCIAL,
+		ContentTypeGOVERNMENTRELEASE:
 		return true
 	}
 	return false
@@ -135,6 +137,7 @@ func AllContentTypeValues() []ContentType {
 		ContentTypePARTYRELEASE,
 		ContentTypeARTICLE,
 		ContentTypeSOCIAL,
+		ContentTypeGOVERNMENTRELEASE,
 	}
 }
 
@@ -351,8 +354,9 @@ func AllModelTypeValues() []ModelType {
 type SourceType string
 
 const (
-	SourceTypePARTY SourceType = "PARTY"
-	SourceTypeMEDIA SourceType = "MEDIA"
+	SourceTypePARTY      SourceType = "PARTY"
+	SourceTypeMEDIA      SourceType = "MEDIA"
+	SourceTypeGOVERNMENT SourceType = "GOVERNMENT"
 )
 
 func (e *SourceType) Scan(src interface{}) error {
@@ -393,7 +397,8 @@ func (ns NullSourceType) Value() (driver.Value, error) {
 func (e SourceType) Valid() bool {
 	switch e {
 	case SourceTypePARTY,
-		SourceTypeMEDIA:
+		SourceTypeMEDIA,
+		SourceTypeGOVERNMENT:
 		return true
 	}
 	return false
@@ -403,6 +408,7 @@ func AllSourceTypeValues() []SourceType {
 	return []SourceType{
 		SourceTypePARTY,
 		SourceTypeMEDIA,
+		SourceTypeGOVERNMENT,
 	}
 }
 
@@ -531,6 +537,46 @@ func AllTaskStatusValues() []TaskStatus {
 	}
 }
 
+// Hashed API keys. A key authenticates as its user with the listed scopes.
+type ApiKey struct {
+	ID     uuid.UUID `db:"id" json:"id"`
+	UserID uuid.UUID `db:"user_id" json:"user_id"`
+	Name   string    `db:"name" json:"name"`
+	// Leading characters of the plaintext key, shown in listings to tell keys apart.
+	Prefix string `db:"prefix" json:"prefix"`
+	// SHA-256 of the plaintext key, hex. The plaintext is never stored.
+	KeyHash string `db:"key_hash" json:"key_hash"`
+	// Subset of read, page_fetch, admin. admin implies the others.
+	Scopes []string `db:"scopes" json:"scopes"`
+	// Per-key request budget. NULL falls back to the server default.
+	RateLimitRps   pgtype.Float8      `db:"rate_limit_rps" json:"rate_limit_rps"`
+	RateLimitBurst pgtype.Int4        `db:"rate_limit_burst" json:"rate_limit_burst"`
+	CreatedAt      pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	// Refreshed when the API server resolves the key (at most once per cache TTL).
+	LastUsedAt pgtype.Timestamptz `db:"last_used_at" json:"last_used_at"`
+	ExpiresAt  pgtype.Timestamptz `db:"expires_at" json:"expires_at"`
+	RevokedAt  pgtype.Timestamptz `db:"revoked_at" json:"revoked_at"`
+}
+
+// Last finished listing page of each source's backfill run. A run resumes from it unless completed_at is set or the pager type changed.
+type BackfillCheckpoint struct {
+	SourceAbbr string `db:"source_abbr" json:"source_abbr"`
+	// Pager type (index, offset, next_link, date_window, sitemap) last_page belongs to.
+	Pager string `db:"pager" json:"pager"`
+	// Pager position of the last finished page: a page value, a URL or a window start.
+	LastPage string `db:"last_page" json:"last_page"`
+	// Batch of the run. A resumed run keeps it so all its candidates share one batch. No FK: batches are created by the sink.
+	BatchID             uuid.UUID          `db:"batch_id" json:"batch_id"`
+	Until               pgtype.Timestamptz `db:"until" json:"until"`
+	PagesVisited        int32              `db:"pages_visited" json:"pages_visited"`
+	CandidatesSeen      int32              `db:"candidates_seen" json:"candidates_seen"`
+	CandidatesProcessed int32              `db:"candidates_processed" json:"candidates_processed"`
+	OldestPublishedAt   pgtype.Timestamptz `db:"oldest_published_at" json:"oldest_published_at"`
+	CompletedAt         pgtype.Timestamptz `db:"completed_at" json:"completed_at"`
+	CreatedAt           pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt           pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
 // Groups one cron/trigger run so planner can detect completion. id used in tasks.batch_id and copied into candidates/contents.
 type Batch struct {
 	ID                   uuid.UUID          `db:"id" json:"id"`
@@ -616,6 +662,7 @@ type ContentExtraction struct {
 	RawResult     []byte             `db:"raw_result" json:"raw_result"`
 	TraceID       string             `db:"trace_id" json:"trace_id"`
 	CreatedAt     pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	PromptVersion string             `db:"prompt_version" json:"prompt_version"`
 }
 
 type ContentExtractionEntity struct {
@@ -649,11 +696,15 @@ type Entity struct {
 // User-facing observation layer for POST /page_fetch. Groups one user submission. Parallel to batches; see docs/plan/spec.md §6.
 type Fetch struct {
 	ID uuid.UUID `db:"id" json:"id"`
-	// Nullable in v1 (single-user dev). Filter target for multi-user RBAC.
+	// Submitting user. NULL when auth is disabled or the caller used a static token.
 	UserID    pgtype.UUID        `db:"user_id" json:"user_id"`
 	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
-	// Persisted in v1 but unused; v2 notification dispatcher will set on transition.
+	// Set by cmd/fetch/notifier when every item reaches COMPLETED / FAILED / ALREADY_COMPLETE.
 	CompletedAt pgtype.Timestamptz `db:"completed_at" json:"completed_at"`
+	// Webhook target for fetch.completed. NULL when the client polls GET /fetches/{id} instead.
+	NotifyUrl pgtype.Text `db:"notify_url" json:"notify_url"`
+	// HMAC-SHA256 key for X-Prism-Signature. Never returned by the API.
+	NotifySecret pgtype.Text `db:"notify_secret" json:"notify_secret"`
 }
 
 // One row per (fetch, candidate). task_id may point at a shared active task created by another fetch — task fan-out is internal and never user-visible.
@@ -666,6 +717,43 @@ type FetchItem struct {
 	CreatedAt      pgtype.Timestamptz `db:"created_at" json:"created_at"`
 }
 
+// Webhook delivery log: one row per notified fetch, carrying the frozen payload and retry state.
+type FetchNotification struct {
+	ID      uuid.UUID `db:"id" json:"id"`
+	FetchID uuid.UUID `db:"fetch_id" json:"fetch_id"`
+	Url     string    `db:"url" json:"url"`
+	Payload []byte    `db:"payload" json:"payload"`
+	// PENDING until a 2xx response (DELIVERED) or the attempt limit is reached (FAILED).
+	Status   string `db:"status" json:"status"`
+	Attempts int32  `db:"attempts" json:"attempts"`
+	// Due time of the next attempt. Also leased forward while an attempt is in flight.
+	NextAttemptAt  pgtype.Timestamptz `db:"next_attempt_at" json:"next_attempt_at"`
+	LastStatusCode pgtype.Int4        `db:"last_status_code" json:"last_status_code"`
+	LastError      pgtype.Text        `db:"last_error" json:"last_error"`
+	CreatedAt      pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	DeliveredAt    pgtype.Timestamptz `db:"delivered_at" json:"delivered_at"`
+}
+
+// Append-only ledger of LLM provider calls. One row per upstream call; cache hits are not recorded.
+type LlmUsage struct {
+	ID           int64  `db:"id" json:"id"`
+	Provider     string `db:"provider" json:"provider"`
+	Model        string `db:"model" json:"model"`
+	Operation    string `db:"operation" json:"operation"`
+	Component    string `db:"component" json:"component"`
+	InputTokens  int32  `db:"input_tokens" json:"input_tokens"`
+	OutputTokens int32  `db:"output_tokens" json:"output_tokens"`
+	CachedTokens int32  `db:"cached_tokens" json:"cached_tokens"`
+	TotalTokens  int32  `db:"total_tokens" json:"total_tokens"`
+	// Priced cost in micro-USD at record time. Zero when the model has no configured price.
+	CostMicros int64  `db:"cost_micros" json:"cost_micros"`
+	TraceID    string `db:"trace_id" json:"trace_id"`
+	// Batch being processed when known. No FK: the ledger outlives batch cleanup.
+	BatchID   pgtype.UUID        `db:"batch_id" json:"batch_id"`
+	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
 type Model struct {
 	ID          int16              `db:"id" json:"id"`
 	Name        string             `db:"name" json:"name"`
@@ -678,12 +766,24 @@ type Model struct {
 	DeletedAt   pgtype.Timestamptz `db:"deleted_at" json:"deleted_at"`
 }
 
+// Per-host article parser rules. Replaces the parsers: map of parsers.yaml when workers run with --registry-source=postgres.
+type ParserRule struct {
+	Host      string             `db:"host" json:"host"`
+	Config    []byte             `db:"config" json:"config"`
+	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
 // Prompt asset registry. hash = SHA-256(body), used to pin extraction provenance.
 type Prompt struct {
 	ID        uuid.UUID          `db:"id" json:"id"`
 	Hash      string             `db:"hash" json:"hash"`
 	Path      string             `db:"path" json:"path"`
 	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	Name      string             `db:"name" json:"name"`
+	Version   string             `db:"version" json:"version"`
+	Content   string             `db:"content" json:"content"`
+	Metadata  []byte             `db:"metadata" json:"metadata"`
 }
 
 type SchemaMigration struct {
@@ -691,6 +791,27 @@ type SchemaMigration struct {
 	Dirty   bool  `db:"dirty" json:"dirty"`
 }
 
+// Alias groups for planner query expansion, managed through /api/v1/admin/aliases.
+type SearchAlias struct {
+	// Name the group is keyed by. Planner tasks are deduplicated on it.
+	Canonical string `db:"canonical" json:"canonical"`
+	Alias     string `db:"alias" json:"alias"`
+	// ALIAS (nickname or other name), ABBREVIATION (short form) or VARIANT (script or spelling variant).
+	Kind      string             `db:"kind" json:"kind"`
+	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+// Discovery scouts, one per outlet listing. Replaces scouts.yaml when workers run with --registry-source=postgres.
+type ScoutConfig struct {
+	Name string `db:"name" json:"name"`
+	Kind string `db:"kind" json:"kind"`
+	// One scouts.yaml entry (name, hosts, headers, rules, ...) with section defaults already applied.
+	Config    []byte             `db:"config" json:"config"`
+	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
 type Source struct {
 	Abbr      string             `db:"abbr" json:"abbr"`
 	Name      string             `db:"name" json:"name"`
@@ -723,3 +844,39 @@ type Task struct {
 	CreatedAt   pgtype.Timestamptz `db:"created_at" json:"created_at"`
 	UpdatedAt   pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
 }
+
+// Append-only history of discovery task executions, one row per run.
+type TaskRun struct {
+	ID int64 `db:"id" json:"id"`
+	// Task that ran. No FK: the history outlives task cleanup.
+	TaskID     uuid.UUID  `db:"task_id" json:"task_id"`
+	BatchID    uuid.UUID  `db:"batch_id" json:"batch_id"`
+	Kind       TaskKind   `db:"kind" json:"kind"`
+	SourceType SourceType `db:"source_type" json:"source_type"`
+	SourceAbbr string     `db:"source_abbr" json:"source_abbr"`
+	// KEYWORD_SEARCH phrase; NULL for DIRECTORY_FETCH.
+	Query  pgtype.Text `db:"query" json:"query"`
+	Status string      `db:"status" json:"status"`
+	// Low-cardinality failure class, e.g. timeout, rate_limited, scout, search, sink. Set exactly when status is FAILED.
+	ErrorClass pgtype.Text `db:"error_class" json:"error_class"`
+	// Candidates the scout or search providers returned.
+	ItemsSeen int32 `db:"items_seen" json:"items_seen"`
+	ItemsNew  int32 `db:"items_new" json:"items_new"`
+	// Stored candidates whose fingerprint was already known; UpsertCandidate only bumped discovered_at.
+	ItemsDuplicate int32 `db:"items_duplicate" json:"items_duplicate"`
+	// KEYWORD_SEARCH result per search provider: {"<provider>": {"seen": n}} or {"<provider>": {"error_class": "..."}}.
+	Providers  []byte             `db:"providers" json:"providers"`
+	DurationMs int32              `db:"duration_ms" json:"duration_ms"`
+	TraceID    string             `db:"trace_id" json:"trace_id"`
+	StartedAt  pgtype.Timestamptz `db:"started_at" json:"started_at"`
+	CreatedAt  pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+// API callers. Owners of fetches and api_keys.
+type User struct {
+	ID        uuid.UUID          `db:"id" json:"id"`
+	Name      string             `db:"name" json:"name"`
+	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	// Set to reject every key of the user without revoking them one by one.
+	DisabledAt pgtype.Timestamptz `db:"disabled_at" json:"disabled_at"`
+}
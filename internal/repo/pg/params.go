@@ -1,21 +1,70 @@
 package pg
 
 import (
+	"encoding/json"
+	"fmt"
+
 	"github.com/ChiaYuChang/prism/internal/repo"
 	"github.com/ChiaYuChang/prism/pkg/pgconv"
 )
 
 func repoListCandidatesParamsToDB(arg repo.ListCandidatesParams) ListCandidatesParams {
 	return ListCandidatesParams{
+		Query:             pgconv.StringPtrToPgText(arg.Query),
+		SourceAbbr:        pgconv.StringPtrToPgText(arg.SourceAbbr),
+		Since:             pgconv.TimePtrToPgTimestamptz(arg.Since),
+		Until:             pgconv.TimePtrToPgTimestamptz(arg.Until),
+		AfterDiscoveredAt: pgconv.TimePtrToPgTimestamptz(arg.AfterDiscoveredAt),
+		AfterPublishedAt:  pgconv.TimePtrToPgTimestamptz(arg.AfterPublishedAt),
+		AfterID:           pgconv.UUIDPtrToPgUUID(arg.AfterID),
+		Lim:               arg.Limit,
+		Off:               arg.Offset,
+	}
+}
+
+func repoCountCandidatesParamsToDB(arg repo.CountCandidatesParams) CountCandidatesParams {
+	return CountCandidatesParams{
 		Query:      pgconv.StringPtrToPgText(arg.Query),
 		SourceAbbr: pgconv.StringPtrToPgText(arg.SourceAbbr),
 		Since:      pgconv.TimePtrToPgTimestamptz(arg.Since),
 		Until:      pgconv.TimePtrToPgTimestamptz(arg.Until),
-		Lim:        arg.Limit,
-		Off:        arg.Offset,
 	}
 }
 
+func repoListCandidatesDiscoveredAfterParamsToDB(arg repo.ListCandidatesDiscoveredAfterParams) ListCandidatesDiscoveredAfterParams {
+	params := ListCandidatesDiscoveredAfterParams{
+		AfterDiscoveredAt: pgconv.TimePtrToPgTimestamptz(&arg.AfterDiscoveredAt),
+		AfterID:           arg.AfterID,
+		Until:             pgconv.TimePtrToPgTimestamptz(&arg.Until),
+		SourceAbbr:        pgconv.StringPtrToPgText(arg.SourceAbbr),
+		Lim:               arg.Limit,
+	}
+	if arg.IngestionMethod != nil {
+		params.IngestionMethod = NullCandidateIngestionMethod{
+			CandidateIngestionMethod: CandidateIngestionMethod(*arg.IngestionMethod),
+			Valid:                    true,
+		}
+	}
+	return params
+}
+
+func repoListContentsParamsToDB(arg repo.ListContentsParams) ListContentsParams {
+	params := ListContentsParams{
+		Query:            pgconv.StringPtrToPgText(arg.Query),
+		SourceAbbr:       pgconv.StringPtrToPgText(arg.SourceAbbr),
+		BatchID:          pgconv.UUIDPtrToPgUUID(arg.BatchID),
+		Since:            pgconv.TimePtrToPgTimestamptz(arg.Since),
+		Until:            pgconv.TimePtrToPgTimestamptz(arg.Until),
+		AfterPublishedAt: pgconv.TimePtrToPgTimestamptz(arg.AfterPublishedAt),
+		AfterID:          pgconv.UUIDPtrToPgUUID(arg.AfterID),
+		Lim:              arg.Limit,
+	}
+	if arg.Type != nil {
+		params.Type = NullContentType{ContentType: ContentType(*arg.Type), Valid: true}
+	}
+	return params
+}
+
 func repoCreateTaskParamsToEnsureBatchExists(arg repo.CreateTaskParams) EnsureBatchExistsParams {
 	return EnsureBatchExistsParams{
 		ID:         arg.BatchID,
@@ -41,6 +90,14 @@ func repoCreateTaskParamsToDB(arg repo.CreateTaskParams) CreateTaskParams {
 	}
 }
 
+func repoCompleteTaskParamsToDB(arg repo.CompleteTaskParams) CompleteTaskParams {
+	return CompleteTaskParams{
+		ID:        arg.ID,
+		NextRunIn: pgconv.DurationPtrToPgInterval(arg.NextRunIn),
+		Meta:      arg.Meta,
+	}
+}
+
 func repoExtendActiveTaskExpiryParamsToDB(arg repo.ExtendActiveTaskExpiryParams) ExtendActiveTaskExpiryParams {
 	return ExtendActiveTaskExpiryParams{
 		SourceAbbr:  arg.SourceAbbr,
@@ -75,3 +132,143 @@ func repoUpdateContentMetadataParamsToDB(arg repo.UpdateContentMetadataParams) U
 		ID:          arg.ID,
 	}
 }
+
+func repoCreateAPIKeyParamsToDB(arg repo.CreateAPIKeyParams) CreateAPIKeyParams {
+	return CreateAPIKeyParams{
+		UserID:         arg.UserID,
+		Name:           arg.Name,
+		Prefix:         arg.Prefix,
+		KeyHash:        arg.KeyHash,
+		Scopes:         arg.Scopes,
+		RateLimitRps:   pgconv.Float64PtrToPgFloat8(arg.RateLimitRPS),
+		RateLimitBurst: pgconv.Int32PtrToPgInt4(arg.RateLimitBurst),
+		ExpiresAt:      pgconv.TimePtrToPgTimestamptz(arg.ExpiresAt),
+	}
+}
+
+func repoCreateLLMUsageParamsToDB(arg repo.CreateLLMUsageParams) CreateLLMUsageParams {
+	return CreateLLMUsageParams{
+		Provider:     arg.Provider,
+		Model:        arg.Model,
+		Operation:    arg.Operation,
+		Component:    arg.Component,
+		InputTokens:  arg.InputTokens,
+		OutputTokens: arg.OutputTokens,
+		CachedTokens: arg.CachedTokens,
+		TotalTokens:  arg.TotalTokens,
+		CostMicros:   arg.CostMicros,
+		TraceID:      arg.TraceID,
+		BatchID:      pgconv.UUIDPtrToPgUUID(arg.BatchID),
+	}
+}
+
+func repoSaveBackfillCheckpointParamsToDB(arg repo.SaveBackfillCheckpointParams) (UpsertBackfillCheckpointParams, error) {
+	until, err := pgconv.TimeToPgTimestamptz(arg.Until)
+	if err != nil {
+		return UpsertBackfillCheckpointParams{}, fmt.Errorf("convert until: %w", err)
+	}
+	return UpsertBackfillCheckpointParams{
+		SourceAbbr:          arg.SourceAbbr,
+		Pager:               arg.Pager,
+		LastPage:            arg.LastPage,
+		BatchID:             arg.BatchID,
+		Until:               until,
+		PagesVisited:        arg.PagesVisited,
+		CandidatesSeen:      arg.CandidatesSeen,
+		CandidatesProcessed: arg.CandidatesProcessed,
+		OldestPublishedAt:   pgconv.TimePtrToPgTimestamptz(arg.OldestPublishedAt),
+		CompletedAt:         pgconv.TimePtrToPgTimestamptz(arg.CompletedAt),
+	}, nil
+}
+
+func repoSummarizeLLMSpendParamsToDB(arg repo.SummarizeLLMSpendParams) (SummarizeLLMSpendParams, error) {
+	since, err := pgconv.TimeToPgTimestamptz(arg.Since)
+	if err != nil {
+		return SummarizeLLMSpendParams{}, fmt.Errorf("convert since: %w", err)
+	}
+	until, err := pgconv.TimeToPgTimestamptz(arg.Until)
+	if err != nil {
+		return SummarizeLLMSpendParams{}, fmt.Errorf("convert until: %w", err)
+	}
+	return SummarizeLLMSpendParams{
+		Since:     since,
+		Until:     until,
+		Component: pgconv.StringPtrToPgText(arg.Component),
+	}, nil
+}
+
+func repoCreateTaskRunParamsToDB(arg repo.CreateTaskRunParams) (CreateTaskRunParams, error) {
+	startedAt, err := pgconv.TimeToPgTimestamptz(arg.StartedAt)
+	if err != nil {
+		return CreateTaskRunParams{}, fmt.Errorf("convert started_at: %w", err)
+	}
+	providers := []byte("{}")
+	if len(arg.Providers) > 0 {
+		if providers, err = json.Marshal(arg.Providers); err != nil {
+			return CreateTaskRunParams{}, fmt.Errorf("encode providers: %w", err)
+		}
+	}
+	return CreateTaskRunParams{
+		TaskID:         arg.TaskID,
+		BatchID:        arg.BatchID,
+		Kind:           TaskKind(arg.Kind),
+		SourceType:     SourceType(arg.SourceType),
+		SourceAbbr:     arg.SourceAbbr,
+		Query:          pgconv.StringPtrToPgText(arg.Query),
+		Status:         arg.Status,
+		ErrorClass:     pgconv.StringPtrToPgText(arg.ErrorClass),
+		ItemsSeen:      arg.ItemsSeen,
+		ItemsNew:       arg.ItemsNew,
+		ItemsDuplicate: arg.ItemsDuplicate,
+		Providers:      providers,
+		DurationMs:     int32(arg.Duration.Milliseconds()),
+		TraceID:        arg.TraceID,
+		StartedAt:      startedAt,
+	}, nil
+}
+
+// repoSummarizeTaskRunsParamsToDB builds the by-source parameters; the
+// by-task query takes the same shape and the by-provider query drops Kind.
+func repoListTaskRunsParamsToDB(arg repo.ListTaskRunsParams) ListTaskRunsByTaskIDParams {
+	return ListTaskRunsByTaskIDParams{
+		TaskID:         arg.TaskID,
+		AfterStartedAt: pgconv.TimePtrToPgTimestamptz(arg.AfterStartedAt),
+		AfterID:        pgconv.Int64PtrToPgInt8(arg.AfterID),
+		RowLimit:       arg.Limit,
+	}
+}
+
+func repoSummarizeTaskRunsParamsToDB(arg repo.SummarizeTaskRunsParams) (SummarizeTaskRunsBySourceParams, error) {
+	since, err := pgconv.TimeToPgTimestamptz(arg.Since)
+	if err != nil {
+		return SummarizeTaskRunsBySourceParams{}, fmt.Errorf("convert since: %w", err)
+	}
+	until, err := pgconv.TimeToPgTimestamptz(arg.Until)
+	if err != nil {
+		return SummarizeTaskRunsBySourceParams{}, fmt.Errorf("convert until: %w", err)
+	}
+	var kind NullTaskKind
+	if arg.Kind != nil {
+		kind = NullTaskKind{TaskKind: TaskKind(*arg.Kind), Valid: true}
+	}
+	return SummarizeTaskRunsBySourceParams{
+		Since:      since,
+		Until:      until,
+		Kind:       kind,
+		SourceAbbr: pgconv.StringPtrToPgText(arg.SourceAbbr),
+		RowLimit:   arg.Limit,
+	}, nil
+}
+
+func repoReplaceSearchAliasesParamsToDB(arg repo.ReplaceSearchAliasesParams) ReplaceSearchAliasesParams {
+	params := ReplaceSearchAliasesParams{
+		Canonical: arg.Canonical,
+		Aliases:   make([]string, len(arg.Aliases)),
+		Kinds:     make([]string, len(arg.Aliases)),
+	}
+	for i, a := range arg.Aliases {
+		params.Aliases[i] = a.Alias
+		params.Kinds[i] = a.Kind
+	}
+	return params
+}
@@ -32,12 +32,83 @@ func TestRepoListCandidatesParamsToDB(t *testing.T) {
 	assert.Equal(t, pgtype.Timestamptz{Time: until, Valid: true}, got.Until)
 	assert.Equal(t, int32(25), got.Lim)
 	assert.Equal(t, int32(50), got.Off)
+	assert.False(t, got.AfterDiscoveredAt.Valid)
+
+	afterID := uuid.New()
+	keyset := repoListCandidatesParamsToDB(repo.ListCandidatesParams{
+		AfterDiscoveredAt: &until,
+		AfterID:           &afterID,
+		Limit:             25,
+	})
+	assert.Equal(t, pgtype.Timestamptz{Time: until, Valid: true}, keyset.AfterDiscoveredAt)
+	assert.False(t, keyset.AfterPublishedAt.Valid)
+	assert.Equal(t, pgtype.UUID{Bytes: afterID, Valid: true}, keyset.AfterID)
 
 	empty := repoListCandidatesParamsToDB(repo.ListCandidatesParams{})
 	assert.False(t, empty.Query.Valid)
 	assert.False(t, empty.SourceAbbr.Valid)
 	assert.False(t, empty.Since.Valid)
 	assert.False(t, empty.Until.Valid)
+	assert.False(t, empty.AfterID.Valid)
+}
+
+func TestRepoListCandidatesDiscoveredAfterParamsToDB(t *testing.T) {
+	after := time.Date(2026, 5, 19, 8, 0, 0, 0, time.UTC)
+	until := after.Add(time.Minute)
+	afterID := uuid.New()
+	sourceAbbr := "dpp"
+	method := "DIRECTORY"
+
+	got := repoListCandidatesDiscoveredAfterParamsToDB(repo.ListCandidatesDiscoveredAfterParams{
+		AfterDiscoveredAt: after,
+		AfterID:           afterID,
+		Until:             until,
+		SourceAbbr:        &sourceAbbr,
+		IngestionMethod:   &method,
+		Limit:             100,
+	})
+
+	assert.Equal(t, pgtype.Timestamptz{Time: after, Valid: true}, got.AfterDiscoveredAt)
+	assert.Equal(t, afterID, got.AfterID)
+	assert.Equal(t, pgtype.Timestamptz{Time: until, Valid: true}, got.Until)
+	assert.Equal(t, pgtype.Text{String: sourceAbbr, Valid: true}, got.SourceAbbr)
+	assert.Equal(t, NullCandidateIngestionMethod{CandidateIngestionMethod: CandidateIngestionMethodDIRECTORY, Valid: true}, got.IngestionMethod)
+	assert.Equal(t, int32(100), got.Lim)
+
+	empty := repoListCandidatesDiscoveredAfterParamsToDB(repo.ListCandidatesDiscoveredAfterParams{})
+	assert.False(t, empty.SourceAbbr.Valid)
+	assert.False(t, empty.IngestionMethod.Valid)
+}
+
+func TestRepoListContentsParamsToDB(t *testing.T) {
+	query := "能源政策"
+	contentType := "ARTICLE"
+	batchID := uuid.New()
+	afterID := uuid.New()
+	after := time.Date(2026, 5, 19, 8, 0, 0, 0, time.UTC)
+
+	got := repoListContentsParamsToDB(repo.ListContentsParams{
+		Query:            &query,
+		Type:             &contentType,
+		BatchID:          &batchID,
+		AfterPublishedAt: &after,
+		AfterID:          &afterID,
+		Limit:            100,
+	})
+
+	assert.Equal(t, pgtype.Text{String: query, Valid: true}, got.Query)
+	assert.Equal(t, NullContentType{ContentType: ContentTypeARTICLE, Valid: true}, got.Type)
+	assert.Equal(t, pgtype.UUID{Bytes: batchID, Valid: true}, got.BatchID)
+	assert.Equal(t, pgtype.Timestamptz{Time: after, Valid: true}, got.AfterPublishedAt)
+	assert.Equal(t, pgtype.UUID{Bytes: afterID, Valid: true}, got.AfterID)
+	assert.Equal(t, int32(100), got.Lim)
+
+	empty := repoListContentsParamsToDB(repo.ListContentsParams{})
+	assert.False(t, empty.Query.Valid)
+	assert.False(t, empty.Type.Valid)
+	assert.False(t, empty.BatchID.Valid)
+	assert.False(t, empty.AfterPublishedAt.Valid)
+	assert.False(t, empty.AfterID.Valid)
 }
 
 func TestRepoCreateTaskParamsToDB(t *testing.T) {
@@ -117,3 +188,108 @@ func TestRepoCreateContentParamsToDB(t *testing.T) {
 	assert.False(t, empty.CandidateID.Valid)
 	assert.False(t, empty.Author.Valid)
 }
+
+func TestRepoCreateAPIKeyParamsToDB(t *testing.T) {
+	userID := uuid.New()
+	rps := 2.5
+	burst := int32(5)
+	expires := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	got := repoCreateAPIKeyParamsToDB(repo.CreateAPIKeyParams{
+		UserID:         userID,
+		Name:           "ci",
+		Prefix:         "prism_abcd",
+		KeyHash:        "ab",
+		Scopes:         []string{"read", "page_fetch"},
+		RateLimitRPS:   &rps,
+		RateLimitBurst: &burst,
+		ExpiresAt:      &expires,
+	})
+
+	assert.Equal(t, userID, got.UserID)
+	assert.Equal(t, []string{"read", "page_fetch"}, got.Scopes)
+	assert.Equal(t, pgtype.Float8{Float64: rps, Valid: true}, got.RateLimitRps)
+	assert.Equal(t, pgtype.Int4{Int32: burst, Valid: true}, got.RateLimitBurst)
+	assert.Equal(t, pgtype.Timestamptz{Time: expires, Valid: true}, got.ExpiresAt)
+
+	empty := repoCreateAPIKeyParamsToDB(repo.CreateAPIKeyParams{})
+	assert.False(t, empty.RateLimitRps.Valid)
+	assert.False(t, empty.RateLimitBurst.Valid)
+	assert.False(t, empty.ExpiresAt.Valid)
+}
+
+func TestRepoReplaceSearchAliasesParamsToDB(t *testing.T) {
+	got := repoReplaceSearchAliasesParamsToDB(repo.ReplaceSearchAliasesParams{
+		Canonical: "國家通訊傳播委員會",
+		Aliases: []repo.SearchAliasEntry{
+			{Alias: "NCC", Kind: repo.AliasKindAbbreviation},
+			{Alias: "通傳會", Kind: repo.AliasKindAlias},
+		},
+	})
+
+	assert.Equal(t, "國家通訊傳播委員會", got.Canonical)
+	assert.Equal(t, []string{"NCC", "通傳會"}, got.Aliases)
+	assert.Equal(t, []string{"ABBREVIATION", "ALIAS"}, got.Kinds)
+}
+
+func TestRepoCreateTaskRunParamsToDB(t *testing.T) {
+	taskID := uuid.New()
+	query := "NCC 開罰"
+	errorClass := "search"
+	startedAt := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+
+	got, err := repoCreateTaskRunParamsToDB(repo.CreateTaskRunParams{
+		TaskID:     taskID,
+		Kind:       repo.TaskKindKeywordSearch,
+		SourceType: repo.SourceTypeMedia,
+		SourceAbbr: "cna",
+		Query:      &query,
+		Status:     repo.TaskRunStatusFailed,
+		ErrorClass: &errorClass,
+		Providers: map[string]repo.TaskRunProvider{
+			"brave":  {ErrorClass: "rate_limited"},
+			"google": {Seen: 3},
+		},
+		Duration:  1500 * time.Millisecond,
+		TraceID:   "trace-123",
+		StartedAt: startedAt,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, taskID, got.TaskID)
+	assert.Equal(t, TaskKindKEYWORDSEARCH, got.Kind)
+	assert.Equal(t, SourceTypeMEDIA, got.SourceType)
+	assert.Equal(t, pgtype.Text{String: query, Valid: true}, got.Query)
+	assert.Equal(t, pgtype.Text{String: errorClass, Valid: true}, got.ErrorClass)
+	assert.JSONEq(t, `{"brave":{"error_class":"rate_limited"},"google":{"seen":3}}`, string(got.Providers))
+	assert.Equal(t, int32(1500), got.DurationMs)
+	assert.Equal(t, pgtype.Timestamptz{Time: startedAt, Valid: true}, got.StartedAt)
+
+	got, err = repoCreateTaskRunParamsToDB(repo.CreateTaskRunParams{
+		Kind:      repo.TaskKindDirectoryFetch,
+		Status:    repo.TaskRunStatusOK,
+		StartedAt: startedAt,
+	})
+	require.NoError(t, err)
+	assert.False(t, got.Query.Valid)
+	assert.False(t, got.ErrorClass.Valid)
+	assert.Equal(t, []byte("{}"), got.Providers)
+}
+
+func TestRepoSummarizeTaskRunsParamsToDB(t *testing.T) {
+	since := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	until := since.AddDate(0, 1, 0)
+	kind := repo.TaskKindKeywordSearch
+
+	got, err := repoSummarizeTaskRunsParamsToDB(repo.SummarizeTaskRunsParams{
+		Since: since,
+		Until: until,
+		Kind:  &kind,
+		Limit: 50,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, pgtype.Timestamptz{Time: since, Valid: true}, got.Since)
+	assert.Equal(t, pgtype.Timestamptz{Time: until, Valid: true}, got.Until)
+	assert.Equal(t, NullTaskKind{TaskKind: TaskKindKEYWORDSEARCH, Valid: true}, got.Kind)
+	assert.False(t, got.SourceAbbr.Valid)
+	assert.Equal(t, int32(50), got.RowLimit)
+}
@@ -12,24 +12,47 @@ import (
 )
 
 type Querier interface {
+	// Leases due PENDING deliveries by pushing next_attempt_at forward so
+	// concurrent notifiers skip them. RecordFetchNotificationAttempt replaces the
+	// lease with the real retry time; a crashed attempt is retried once the lease
+	// expires.
+	ClaimDueFetchNotifications(ctx context.Context, arg ClaimDueFetchNotificationsParams) ([]ClaimDueFetchNotificationsRow, error)
 	ClaimTasks(ctx context.Context, arg ClaimTasksParams) ([]Task, error)
-	CompleteTask(ctx context.Context, id uuid.UUID) error
+	// next_run_in, when set, replaces frequency as the delay before the next run
+	// of a recurring task (adaptive polling); meta, when set, is merged into the
+	// task's meta.
+	CompleteTask(ctx context.Context, arg CompleteTaskParams) error
+	// Same filters as ListCandidates, without paging.
+	CountCandidates(ctx context.Context, arg CountCandidatesParams) (int64, error)
 	CountCandidatesByBatchID(ctx context.Context, batchID pgtype.UUID) (int64, error)
+	CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (ApiKey, error)
 	CreateCandidate(ctx context.Context, arg CreateCandidateParams) (Candidate, error)
 	CreateCandidateEmbeddingGemma2025(ctx context.Context, arg CreateCandidateEmbeddingGemma2025Params) (CandidateEmbeddingsGemma2025, error)
 	CreateContent(ctx context.Context, arg CreateContentParams) (Content, error)
 	CreateContentEmbeddingGemma2025(ctx context.Context, arg CreateContentEmbeddingGemma2025Params) (ContentEmbeddingsGemma2025, error)
 	CreateContentExtraction(ctx context.Context, arg CreateContentExtractionParams) (ContentExtraction, error)
 	CreateContentExtractionEntity(ctx context.Context, arg CreateContentExtractionEntityParams) error
+	// Freezes the fetch.completed payload for delivery. Idempotent per fetch, so
+	// a sweep that dies between enqueue and MarkUserFetchCompleted simply
+	// re-runs on the next tick.
+	CreateFetchNotification(ctx context.Context, arg CreateFetchNotificationParams) (int64, error)
 	// Single-round-trip insert-or-recover. On unique-violation against either
 	// uq_tasks_active_payload or uq_tasks_active_page_fetch, returns the
 	// existing PENDING/RUNNING row with inserted=false. Adapter maps
 	// inserted=false to repo.ErrTaskAlreadyActive while still surfacing the
 	// recovered task fields, so callers that need the existing task_id (e.g.
 	// the user-fetch handler) avoid a second SELECT.
+	CreateLLMUsage(ctx context.Context, arg CreateLLMUsageParams) (LlmUsage, error)
 	CreateTask(ctx context.Context, arg CreateTaskParams) (CreateTaskRow, error)
-	CreateUserFetch(ctx context.Context, userID pgtype.UUID) (Fetch, error)
+	CreateTaskRun(ctx context.Context, arg CreateTaskRunParams) (TaskRun, error)
+	// A taken name inserts nothing and returns no row. Adapter maps that to
+	// repo.ErrUserExists.
+	CreateUser(ctx context.Context, name string) (User, error)
+	CreateUserFetch(ctx context.Context, arg CreateUserFetchParams) (Fetch, error)
 	CreateUserFetchItem(ctx context.Context, arg CreateUserFetchItemParams) (FetchItem, error)
+	DeleteParserRule(ctx context.Context, host string) (int64, error)
+	DeleteScoutConfig(ctx context.Context, name string) (int64, error)
+	DeleteSearchAliases(ctx context.Context, canonical string) (int64, error)
 	EnsureBatchExists(ctx context.Context, arg EnsureBatchExistsParams) error
 	// Updates expires_at on an existing PENDING/RUNNING task identified by its dedup key.
 	// Used when CreateTask returns ErrTaskAlreadyActive to refresh the task's lifetime.
@@ -37,8 +60,10 @@ type Querier interface {
 	FailTask(ctx context.Context, id uuid.UUID) error
 	// Finds batches where all tasks are completed and all candidates are promoted to contents.
 	FindNewlyCompletedBatches(ctx context.Context, arg FindNewlyCompletedBatchesParams) ([]FindNewlyCompletedBatchesRow, error)
+	GetBackfillCheckpoint(ctx context.Context, sourceAbbr string) (BackfillCheckpoint, error)
 	GetCandidateByFingerprint(ctx context.Context, fingerprint string) (Candidate, error)
 	GetCandidateByID(ctx context.Context, id uuid.UUID) (Candidate, error)
+	GetCandidatesByFingerprints(ctx context.Context, fingerprints []string) ([]Candidate, error)
 	GetCandidatesByIDs(ctx context.Context, ids []uuid.UUID) ([]Candidate, error)
 	GetContentByCandidateID(ctx context.Context, candidateID pgtype.UUID) (Content, error)
 	GetContentByID(ctx context.Context, id uuid.UUID) (Content, error)
@@ -50,23 +75,47 @@ type Querier interface {
 	GetModelByNameAndType(ctx context.Context, arg GetModelByNameAndTypeParams) (Model, error)
 	GetPromptByHash(ctx context.Context, hash string) (Prompt, error)
 	GetPromptByID(ctx context.Context, id uuid.UUID) (Prompt, error)
+	GetPromptByNameAndVersion(ctx context.Context, arg GetPromptByNameAndVersionParams) (Prompt, error)
 	GetSourceByAbbr(ctx context.Context, abbr string) (Source, error)
 	GetTaskByID(ctx context.Context, id uuid.UUID) (Task, error)
+	GetUser(ctx context.Context, id uuid.UUID) (User, error)
 	GetUserFetch(ctx context.Context, id uuid.UUID) (Fetch, error)
 	// Aggregates item status using COALESCE(snapshot_status, tasks.status).
 	// Returns candidate IDs grouped by status plus a derived `terminal` flag (all
 	// items in COMPLETED / FAILED / ALREADY_COMPLETE).
 	GetUserFetchProgress(ctx context.Context, fetchID uuid.UUID) (GetUserFetchProgressRow, error)
+	ListAPIKeysByUserID(ctx context.Context, userID uuid.UUID) ([]ApiKey, error)
 	ListCandidateEmbeddingsByCandidateID(ctx context.Context, candidateID uuid.UUID) ([]CandidateEmbeddingsGemma2025, error)
+	// Pages in (published_at DESC NULLS LAST, discovered_at DESC, id DESC) order.
+	// after_* is the last row of the previous page (keyset); rows without
+	// published_at come after every dated row. off is kept for legacy clients.
 	ListCandidates(ctx context.Context, arg ListCandidatesParams) ([]Candidate, error)
+	// Keyset scan in (discovered_at, id) order for GET /candidates/stream.
+	// UpsertCandidate bumps discovered_at, so a re-seen candidate sorts again.
+	ListCandidatesDiscoveredAfter(ctx context.Context, arg ListCandidatesDiscoveredAfterParams) ([]Candidate, error)
 	ListCandidatesForAnalysis(ctx context.Context, arg ListCandidatesForAnalysisParams) ([]Candidate, error)
 	ListContentEmbeddingsByContentID(ctx context.Context, contentID uuid.UUID) ([]ContentEmbeddingsGemma2025, error)
+	// Keyset page over (published_at, id) DESC. after_published_at/after_id are
+	// the last row of the previous page; q is matched through the CJK-aware
+	// contents_search_document index (see migration 000009).
+	ListContents(ctx context.Context, arg ListContentsParams) ([]Content, error)
 	ListContentsByBatchID(ctx context.Context, batchID pgtype.UUID) ([]Content, error)
+	ListParserRules(ctx context.Context) ([]ParserRule, error)
 	ListPendingCompletionBatches(ctx context.Context, arg ListPendingCompletionBatchesParams) ([]Batch, error)
 	ListReadyToPublishBatches(ctx context.Context, arg ListReadyToPublishBatchesParams) ([]Batch, error)
+	ListPromptVersions(ctx context.Context, name string) ([]Prompt, error)
 	ListRecentSeedContents(ctx context.Context, limit int32) ([]Content, error)
 	ListRunnableTasks(ctx context.Context, limit int32) ([]Task, error)
+	ListScoutConfigs(ctx context.Context) ([]ScoutConfig, error)
+	ListSearchAliases(ctx context.Context) ([]SearchAlias, error)
 	ListSourcesByType(ctx context.Context, type_ SourceType) ([]Source, error)
+	// Open fetches whose items have all reached COMPLETED / FAILED /
+	// ALREADY_COMPLETE, oldest first. Resolves item status exactly like
+	// GetUserFetchProgress; fetches without items never qualify.
+	ListTerminalUserFetches(ctx context.Context, limit int32) ([]Fetch, error)
+	// Keyset page over (started_at, id) DESC. after_started_at/after_id are the
+	// last row of the previous page.
+	ListTaskRunsByTaskID(ctx context.Context, arg ListTaskRunsByTaskIDParams) ([]TaskRun, error)
 	ListTasksByBatchID(ctx context.Context, batchID uuid.UUID) ([]Task, error)
 	ListUserFetchItems(ctx context.Context, fetchID uuid.UUID) ([]ListUserFetchItemsRow, error)
 	// Optimistic-concurrency claim: returns rows-affected so the caller can
@@ -74,24 +123,57 @@ type Querier interface {
 	// (0). Only the winner should publish the batch.completed signal.
 	MarkBatchCompleted(ctx context.Context, arg MarkBatchCompletedParams) (int64, error)
 	MarkBatchPublished(ctx context.Context, id uuid.UUID) error
-	// Sets completed_at on transition to terminal. Idempotent (WHERE clause
-	// guards against double-set). v1 callers may skip this — progress endpoint
-	// computes terminal on-the-fly. Reserved for v2 notification dispatcher.
-	MarkUserFetchCompleted(ctx context.Context, id uuid.UUID) error
+	// Sets completed_at on transition to terminal. Optimistic-concurrency claim:
+	// returns rows-affected so only the notifier instance that wins (1) logs the
+	// transition; the progress endpoint still computes terminal on-the-fly.
+	MarkUserFetchCompleted(ctx context.Context, id uuid.UUID) (int64, error)
 	RecordBatchPublishFailure(ctx context.Context, arg RecordBatchPublishFailureParams) error
+	// Records the outcome of one delivery attempt. DELIVERED also stamps
+	// delivered_at; PENDING rows are retried at next_attempt_at.
+	RecordFetchNotificationAttempt(ctx context.Context, arg RecordFetchNotificationAttemptParams) error
 	// Resets RUNNING tasks back to PENDING in bulk, undoing the ClaimTasks
 	// retry_count increment. Used when dispatch is skipped (e.g. rate-limited)
 	// so tasks are retried on the next scheduler tick without consuming retry slots.
 	ReleaseTasks(ctx context.Context, ids []uuid.UUID) error
 	ReplaceContentExtractionPhrases(ctx context.Context, arg ReplaceContentExtractionPhrasesParams) error
 	ReplaceContentExtractionTopics(ctx context.Context, arg ReplaceContentExtractionTopicsParams) error
+	// Replaces the canonical's group in one statement: aliases missing from the
+	// new list are dropped, the rest are inserted or have their kind updated.
+	ReplaceSearchAliases(ctx context.Context, arg ReplaceSearchAliasesParams) ([]SearchAlias, error)
+	RevokeAPIKey(ctx context.Context, id uuid.UUID) (int64, error)
 	SearchCandidatesByText(ctx context.Context, arg SearchCandidatesByTextParams) ([]Candidate, error)
 	SearchCandidatesByVector(ctx context.Context, arg SearchCandidatesByVectorParams) ([]SearchCandidatesByVectorRow, error)
 	SearchContentsByVector(ctx context.Context, arg SearchContentsByVectorParams) ([]SearchContentsByVectorRow, error)
+	// Sources stay referenced by candidates and tasks, so they are only hidden.
+	SoftDeleteSource(ctx context.Context, abbr string) (int64, error)
+	// Budget check: micro-USD spent by one component since a window start.
+	SumLLMCostSince(ctx context.Context, arg SumLLMCostSinceParams) (int64, error)
+	// Spend per UTC day, component, provider and model in [since, until).
+	SummarizeLLMSpend(ctx context.Context, arg SummarizeLLMSpendParams) ([]SummarizeLLMSpendRow, error)
+	// Search provider results of KEYWORD_SEARCH runs started in [since, until).
+	// Only seen counts are per provider; new and duplicate are per run.
+	SummarizeTaskRunsByProvider(ctx context.Context, arg SummarizeTaskRunsByProviderParams) ([]SummarizeTaskRunsByProviderRow, error)
+	// Yield per task kind and source for runs started in [since, until).
+	SummarizeTaskRunsBySource(ctx context.Context, arg SummarizeTaskRunsBySourceParams) ([]SummarizeTaskRunsBySourceRow, error)
+	// Yield per task (one phrase and site for KEYWORD_SEARCH) for runs started
+	// in [since, until), least productive first: the pruning candidates.
+	SummarizeTaskRunsByTask(ctx context.Context, arg SummarizeTaskRunsByTaskParams) ([]SummarizeTaskRunsByTaskRow, error)
+	// Resolves a presented key: returns it only while it is unrevoked, unexpired
+	// and its user is enabled, refreshing last_used_at on the way.
+	TouchActiveAPIKeyByHash(ctx context.Context, keyHash string) (ApiKey, error)
 	UpdateContentMetadata(ctx context.Context, arg UpdateContentMetadataParams) (Content, error)
+	// One row per source: a new run overwrites the previous run's checkpoint.
+	UpsertBackfillCheckpoint(ctx context.Context, arg UpsertBackfillCheckpointParams) (BackfillCheckpoint, error)
 	UpsertCandidate(ctx context.Context, arg UpsertCandidateParams) (Candidate, error)
 	UpsertEntity(ctx context.Context, arg UpsertEntityParams) (Entity, error)
+	UpsertParserRule(ctx context.Context, arg UpsertParserRuleParams) (ParserRule, error)
+	// Registers (name, version). A version is immutable: re-registering the
+	// same content refreshes path and metadata, while different content updates
+	// nothing and returns no row. Adapter maps that to repo.ErrPromptVersionConflict.
 	UpsertPrompt(ctx context.Context, arg UpsertPromptParams) (Prompt, error)
+	UpsertScoutConfig(ctx context.Context, arg UpsertScoutConfigParams) (ScoutConfig, error)
+	// Re-creating a soft-deleted source revives it.
+	UpsertSource(ctx context.Context, arg UpsertSourceParams) (Source, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -67,9 +67,10 @@ func (q *Queries) GetModelByNameAndType(ctx context.Context, arg GetModelByNameA
 }
 
 const getPromptByHash = `-- name: GetPromptByHash :one
-SELECT id, hash, path, created_at
+SELECT id, hash, path, created_at, name, version, content, metadata
 FROM prompts
 WHERE hash = $1
+ORDER BY created_at ASC
 LIMIT 1
 `
 
@@ -81,12 +82,16 @@ func (q *Queries) GetPromptByHash(ctx context.Context, hash string) (Prompt, err
 		&i.Hash,
 		&i.Path,
 		&i.CreatedAt,
+		&i.Name,
+		&i.Version,
+		&i.Content,
+		&i.Metadata,
 	)
 	return i, err
 }
 
 const getPromptByID = `-- name: GetPromptByID :one
-SELECT id, hash, path, created_at
+SELECT id, hash, path, created_at, name, version, content, metadata
 FROM prompts
 WHERE id = $1
 LIMIT 1
@@ -100,6 +105,39 @@ func (q *Queries) GetPromptByID(ctx context.Context, id uuid.UUID) (Prompt, erro
 		&i.Hash,
 		&i.Path,
 		&i.CreatedAt,
+		&i.Name,
+		&i.Version,
+		&i.Content,
+		&i.Metadata,
+	)
+	return i, err
+}
+
+const getPromptByNameAndVersion = `-- name: GetPromptByNameAndVersion :one
+SELECT id, hash, path, created_at, name, version, content, metadata
+FROM prompts
+WHERE name = $1
+  AND version = $2
+LIMIT 1
+`
+
+type GetPromptByNameAndVersionParams struct {
+	Name    string `db:"name" json:"name"`
+	Version string `db:"version" json:"version"`
+}
+
+func (q *Queries) GetPromptByNameAndVersion(ctx context.Context, arg GetPromptByNameAndVersionParams) (Prompt, error) {
+	row := q.db.QueryRow(ctx, getPromptByNameAndVersion, arg.Name, arg.Version)
+	var i Prompt
+	err := row.Scan(
+		&i.ID,
+		&i.Hash,
+		&i.Path,
+		&i.CreatedAt,
+		&i.Name,
+		&i.Version,
+		&i.Content,
+		&i.Metadata,
 	)
 	return i, err
 }
@@ -125,6 +163,42 @@ func (q *Queries) GetSourceByAbbr(ctx context.Context, abbr string) (Source, err
 	return i, err
 }
 
+const listPromptVersions = `-- name: ListPromptVersions :many
+SELECT id, hash, path, created_at, name, version, content, metadata
+FROM prompts
+WHERE name = $1
+ORDER BY created_at ASC
+`
+
+func (q *Queries) ListPromptVersions(ctx context.Context, name string) ([]Prompt, error) {
+	rows, err := q.db.Query(ctx, listPromptVersions, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Prompt
+	for rows.Next() {
+		var i Prompt
+		if err := rows.Scan(
+			&i.ID,
+			&i.Hash,
+			&i.Path,
+			&i.CreatedAt,
+			&i.Name,
+			&i.Version,
+			&i.Content,
+			&i.Metadata,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listSourcesByType = `-- name: ListSourcesByType :many
 SELECT abbr, name, type, base_url, created_at, deleted_at
 FROM sources
@@ -162,30 +236,58 @@ func (q *Queries) ListSourcesByType(ctx context.Context, type_ SourceType) ([]So
 
 const upsertPrompt = `-- name: UpsertPrompt :one
 INSERT INTO prompts (
+    name,
+    version,
     hash,
-    path
+    path,
+    content,
+    metadata
 ) VALUES (
     $1,
-    $2
+    $2,
+    $3,
+    $4,
+    $5,
+    $6
 )
-ON CONFLICT (hash) DO UPDATE
-SET path = EXCLUDED.path
-RETURNING id, hash, path, created_at
+ON CONFLICT (name, version) DO UPDATE
+SET path = EXCLUDED.path,
+    metadata = EXCLUDED.metadata
+WHERE prompts.hash = EXCLUDED.hash
+RETURNING id, hash, path, created_at, name, version, content, metadata
 `
 
 type UpsertPromptParams struct {
-	Hash string `db:"hash" json:"hash"`
-	Path string `db:"path" json:"path"`
+	Name     string `db:"name" json:"name"`
+	Version  string `db:"version" json:"version"`
+	Hash     string `db:"hash" json:"hash"`
+	Path     string `db:"path" json:"path"`
+	Content  string `db:"content" json:"content"`
+	Metadata []byte `db:"metadata" json:"metadata"`
 }
 
+// Registers (name, version). A version is immutable: re-registering the
+// same content refreshes path and metadata, while different content updates
+// nothing and returns no row. Adapter maps that to repo.ErrPromptVersionConflict.
 func (q *Queries) UpsertPrompt(ctx context.Context, arg UpsertPromptParams) (Prompt, error) {
-	row := q.db.QueryRow(ctx, upsertPrompt, arg.Hash, arg.Path)
+	row := q.db.QueryRow(ctx, upsertPrompt,
+		arg.Name,
+		arg.Version,
+		arg.Hash,
+		arg.Path,
+		arg.Content,
+		arg.Metadata,
+	)
 	var i Prompt
 	err := row.Scan(
 		&i.ID,
 		&i.Hash,
 		&i.Path,
 		&i.CreatedAt,
+		&i.Name,
+		&i.Version,
+		&i.Content,
+		&i.Metadata,
 	)
 	return i, err
 }
@@ -2,8 +2,10 @@ package pg
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/ChiaYuChang/prism/internal/repo"
 	"github.com/ChiaYuChang/prism/pkg/pgconv"
@@ -56,6 +58,30 @@ type PGUserFetches struct {
 	q *Queries
 }
 
+type PGLLMUsage struct {
+	q *Queries
+}
+
+type PGUsers struct {
+	q *Queries
+}
+
+type PGCatalog struct {
+	q *Queries
+}
+
+type PGBackfills struct {
+	q *Queries
+}
+
+type PGAliases struct {
+	q *Queries
+}
+
+type PGTaskRuns struct {
+	q *Queries
+}
+
 var _ repo.Repository = (*PGRepository)(nil)
 var _ repo.Scheduler = (*PGScheduler)(nil)
 var _ repo.Scout = (*PGScout)(nil)
@@ -65,6 +91,12 @@ var _ repo.Embeddings = (*PGEmbeddings)(nil)
 var _ repo.Analysis = (*PGAnalysis)(nil)
 var _ repo.BatchTrigger = (*PGBatchTrigger)(nil)
 var _ repo.UserFetches = (*PGUserFetches)(nil)
+var _ repo.LLMUsage = (*PGLLMUsage)(nil)
+var _ repo.Users = (*PGUsers)(nil)
+var _ repo.Catalog = (*PGCatalog)(nil)
+var _ repo.Backfills = (*PGBackfills)(nil)
+var _ repo.Aliases = (*PGAliases)(nil)
+var _ repo.TaskRuns = (*PGTaskRuns)(nil)
 
 // Repository root getters.
 func (r *PGRepository) Scheduler() repo.Scheduler {
@@ -99,6 +131,30 @@ func (r *PGRepository) UserFetches() repo.UserFetches {
 	return &PGUserFetches{q: r.q}
 }
 
+func (r *PGRepository) LLMUsage() repo.LLMUsage {
+	return &PGLLMUsage{q: r.q}
+}
+
+func (r *PGRepository) Users() repo.Users {
+	return &PGUsers{q: r.q}
+}
+
+func (r *PGRepository) Catalog() repo.Catalog {
+	return &PGCatalog{q: r.q}
+}
+
+func (r *PGRepository) Backfills() repo.Backfills {
+	return &PGBackfills{q: r.q}
+}
+
+func (r *PGRepository) Aliases() repo.Aliases {
+	return &PGAliases{q: r.q}
+}
+
+func (r *PGRepository) TaskRuns() repo.TaskRuns {
+	return &PGTaskRuns{q: r.q}
+}
+
 // Scheduler repository.
 func (r *PGScheduler) ClaimTasks(ctx context.Context, limit int32, kinds []string, sourceTypes []string) ([]repo.Task, error) {
 	pgKinds := make([]TaskKind, len(kinds))
@@ -131,8 +187,8 @@ func (r *PGScheduler) ReleaseTasks(ctx context.Context, ids []uuid.UUID) error {
 	return r.q.ReleaseTasks(ctx, ids)
 }
 
-func (r *PGScheduler) CompleteTask(ctx context.Context, id uuid.UUID) error {
-	return r.q.CompleteTask(ctx, id)
+func (r *PGScheduler) CompleteTask(ctx context.Context, arg repo.CompleteTaskParams) error {
+	return r.q.CompleteTask(ctx, repoCompleteTaskParamsToDB(arg))
 }
 
 func (r *PGScheduler) FailTask(ctx context.Context, id uuid.UUID) error {
@@ -195,6 +251,21 @@ func (r *PGScout) GetCandidatesByIDs(ctx context.Context, ids []uuid.UUID) ([]re
 	return out, nil
 }
 
+func (r *PGScout) GetCandidatesByFingerprints(ctx context.Context, fingerprints []string) ([]repo.Candidate, error) {
+	if len(fingerprints) == 0 {
+		return nil, nil
+	}
+	rows, err := r.q.GetCandidatesByFingerprints(ctx, fingerprints)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]repo.Candidate, len(rows))
+	for i, row := range rows {
+		out[i] = dbCandidateToRepoCandidate(row)
+	}
+	return out, nil
+}
+
 func (r *PGScout) ListCandidates(ctx context.Context, arg repo.ListCandidatesParams) ([]repo.Candidate, error) {
 	rows, err := r.q.ListCandidates(ctx, repoListCandidatesParamsToDB(arg))
 	if err != nil {
@@ -207,6 +278,18 @@ func (r *PGScout) ListCandidates(ctx context.Context, arg repo.ListCandidatesPar
 	return out, nil
 }
 
+func (r *PGScout) ListCandidatesDiscoveredAfter(ctx context.Context, arg repo.ListCandidatesDiscoveredAfterParams) ([]repo.Candidate, error) {
+	rows, err := r.q.ListCandidatesDiscoveredAfter(ctx, repoListCandidatesDiscoveredAfterParamsToDB(arg))
+	if err != nil {
+		return nil, err
+	}
+	out := make([]repo.Candidate, len(rows))
+	for i, row := range rows {
+		out[i] = dbCandidateToRepoCandidate(row)
+	}
+	return out, nil
+}
+
 func (r *PGScout) GetCandidateByFingerprint(ctx context.Context, fingerprint string) (repo.Candidate, error) {
 	row, err := r.q.GetCandidateByFingerprint(ctx, fingerprint)
 	if err != nil {
@@ -215,6 +298,10 @@ func (r *PGScout) GetCandidateByFingerprint(ctx context.Context, fingerprint str
 	return dbCandidateToRepoCandidate(row), nil
 }
 
+func (r *PGScout) CountCandidates(ctx context.Context, arg repo.CountCandidatesParams) (int64, error) {
+	return r.q.CountCandidates(ctx, repoCountCandidatesParamsToDB(arg))
+}
+
 func (r *PGScout) CountCandidatesByBatchID(ctx context.Context, batchID uuid.UUID) (int64, error) {
 	return r.q.CountCandidatesByBatchID(ctx, pgconv.UUIDToPgUUID(batchID))
 }
@@ -347,6 +434,18 @@ func (r *PGPipeline) UpdateContentMetadata(ctx context.Context, arg repo.UpdateC
 	return dbContentToRepoContent(row), nil
 }
 
+func (r *PGPipeline) ListContents(ctx context.Context, arg repo.ListContentsParams) ([]repo.Content, error) {
+	rows, err := r.q.ListContents(ctx, repoListContentsParamsToDB(arg))
+	if err != nil {
+		return nil, err
+	}
+	out := make([]repo.Content, len(rows))
+	for i, row := range rows {
+		out[i] = dbContentToRepoContent(row)
+	}
+	return out, nil
+}
+
 func (r *PGPipeline) ListContentsByBatchID(ctx context.Context, batchID uuid.UUID) ([]repo.Content, error) {
 	rows, err := r.q.ListContentsByBatchID(ctx, pgtype.UUID{Bytes: batchID, Valid: true})
 	if err != nil {
@@ -542,6 +641,18 @@ func (r *PGEmbeddings) CreateContentEmbedding(ctx context.Context, arg repo.Crea
 	return dbContentEmbeddingToRepoContentEmbedding(row), nil
 }
 
+func (r *PGEmbeddings) ListContentEmbeddings(ctx context.Context, contentID uuid.UUID) ([]repo.ContentEmbedding, error) {
+	rows, err := r.q.ListContentEmbeddingsByContentID(ctx, contentID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]repo.ContentEmbedding, len(rows))
+	for i, row := range rows {
+		out[i] = dbContentEmbeddingToRepoContentEmbedding(row)
+	}
+	return out, nil
+}
+
 // Analysis repository.
 func (r *PGAnalysis) GetPromptByID(ctx context.Context, id uuid.UUID) (repo.Prompt, error) {
 	row, err := r.q.GetPromptByID(ctx, id)
@@ -559,9 +670,48 @@ func (r *PGAnalysis) GetPromptByHash(ctx context.Context, hash string) (repo.Pro
 	return dbPromptToRepoPrompt(row), nil
 }
 
+func (r *PGAnalysis) GetPromptByNameAndVersion(ctx context.Context, name, version string) (repo.Prompt, error) {
+	row, err := r.q.GetPromptByNameAndVersion(ctx, GetPromptByNameAndVersionParams{
+		Name:    name,
+		Version: version,
+	})
+	if err != nil {
+		return repo.Prompt{}, err
+	}
+	return dbPromptToRepoPrompt(row), nil
+}
+
+func (r *PGAnalysis) ListPromptVersions(ctx context.Context, name string) ([]repo.Prompt, error) {
+	rows, err := r.q.ListPromptVersions(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	prompts := make([]repo.Prompt, len(rows))
+	for i, row := range rows {
+		prompts[i] = dbPromptToRepoPrompt(row)
+	}
+	return prompts, nil
+}
+
 func (r *PGAnalysis) UpsertPrompt(ctx context.Context, arg repo.UpsertPromptParams) (repo.Prompt, error) {
-	row, err := r.q.UpsertPrompt(ctx, UpsertPromptParams{Hash: arg.Hash, Path: arg.Path})
+	metadata := arg.Metadata
+	if len(metadata) == 0 {
+		metadata = []byte("{}")
+	}
+	row, err := r.q.UpsertPrompt(ctx, UpsertPromptParams{
+		Name:     arg.Name,
+		Version:  arg.Version,
+		Hash:     arg.Hash,
+		Path:     arg.Path,
+		Content:  arg.Content,
+		Metadata: metadata,
+	})
 	if err != nil {
+		// The conflict clause only updates when the content hash matches, so
+		// no row back means the version exists with different content.
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repo.Prompt{}, fmt.Errorf("%w: %s@%s", repo.ErrPromptVersionConflict, arg.Name, arg.Version)
+		}
 		return repo.Prompt{}, err
 	}
 	return dbPromptToRepoPrompt(row), nil
@@ -578,6 +728,7 @@ func (r *PGAnalysis) CreateContentExtraction(ctx context.Context, arg repo.Creat
 		Summary:       arg.Summary,
 		RawResult:     arg.RawResult,
 		TraceID:       arg.TraceID,
+		PromptVersion: arg.PromptVersion,
 	})
 	if err != nil {
 		return repo.ContentExtraction{}, err
@@ -655,7 +806,11 @@ func (r *PGAnalysis) ReplaceContentExtractionPhrases(ctx context.Context, extrac
 // user-facing observation layer for POST /page_fetch. See
 // docs/plan/spec.md §6.
 func (r *PGUserFetches) Create(ctx context.Context, arg repo.CreateUserFetchParams) (repo.UserFetch, error) {
-	row, err := r.q.CreateUserFetch(ctx, pgconv.UUIDPtrToPgUUID(arg.UserID))
+	row, err := r.q.CreateUserFetch(ctx, CreateUserFetchParams{
+		UserID:       pgconv.UUIDPtrToPgUUID(arg.UserID),
+		NotifyUrl:    pgconv.StringPtrToPgText(arg.NotifyURL),
+		NotifySecret: pgconv.StringPtrToPgText(arg.NotifySecret),
+	})
 	if err != nil {
 		return repo.UserFetch{}, err
 	}
@@ -699,16 +854,73 @@ func (r *PGUserFetches) GetProgress(ctx context.Context, fetchID uuid.UUID) (rep
 	}, nil
 }
 
-func (r *PGUserFetches) MarkCompleted(ctx context.Context, fetchID uuid.UUID) error {
+func (r *PGUserFetches) ListTerminal(ctx context.Context, limit int32) ([]repo.UserFetch, error) {
+	rows, err := r.q.ListTerminalUserFetches(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	fetches := make([]repo.UserFetch, len(rows))
+	for i, row := range rows {
+		fetches[i] = dbUserFetchToRepo(row)
+	}
+	return fetches, nil
+}
+
+func (r *PGUserFetches) MarkCompleted(ctx context.Context, fetchID uuid.UUID) (int64, error) {
 	return r.q.MarkUserFetchCompleted(ctx, fetchID)
 }
 
+func (r *PGUserFetches) EnqueueNotification(ctx context.Context, arg repo.CreateFetchNotificationParams) (int64, error) {
+	return r.q.CreateFetchNotification(ctx, CreateFetchNotificationParams{
+		FetchID: arg.FetchID,
+		Url:     arg.URL,
+		Payload: arg.Payload,
+	})
+}
+
+func (r *PGUserFetches) ClaimDueNotifications(ctx context.Context, limit int32, lease time.Duration) ([]repo.FetchNotification, error) {
+	rows, err := r.q.ClaimDueFetchNotifications(ctx, ClaimDueFetchNotificationsParams{
+		LeaseSeconds: int32(lease.Seconds()),
+		RowLimit:     limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	notifications := make([]repo.FetchNotification, len(rows))
+	for i, row := range rows {
+		notifications[i] = repo.FetchNotification{
+			ID:       row.ID,
+			FetchID:  row.FetchID,
+			URL:      row.Url,
+			Payload:  row.Payload,
+			Attempts: row.Attempts,
+			Secret:   row.NotifySecret.String,
+		}
+	}
+	return notifications, nil
+}
+
+func (r *PGUserFetches) RecordNotificationAttempt(ctx context.Context, arg repo.RecordFetchNotificationAttemptParams) error {
+	next, err := pgconv.TimeToPgTimestamptz(arg.NextAttemptAt)
+	if err != nil {
+		return fmt.Errorf("convert next_attempt_at: %w", err)
+	}
+	return r.q.RecordFetchNotificationAttempt(ctx, RecordFetchNotificationAttemptParams{
+		Status:         arg.Status,
+		LastStatusCode: pgconv.Int32PtrToPgInt4(arg.StatusCode),
+		LastError:      pgconv.StringPtrToPgText(arg.Error),
+		NextAttemptAt:  next,
+		ID:             arg.ID,
+	})
+}
+
 func dbUserFetchToRepo(row Fetch) repo.UserFetch {
 	return repo.UserFetch{
 		ID:          row.ID,
 		UserID:      pgconv.PgUUIDToUUIDPtr(row.UserID),
 		CreatedAt:   *pgconv.PgTimestamptzToTimePtr(row.CreatedAt),
 		CompletedAt: pgconv.PgTimestamptzToTimePtr(row.CompletedAt),
+		NotifyURL:   pgconv.PgTextToStringPtr(row.NotifyUrl),
 	}
 }
 
@@ -721,3 +933,454 @@ func dbUserFetchItemToRepo(row FetchItem) repo.UserFetchItem {
 		CreatedAt:      *pgconv.PgTimestamptzToTimePtr(row.CreatedAt),
 	}
 }
+
+// LLM usage ledger.
+func (r *PGLLMUsage) Record(ctx context.Context, arg repo.CreateLLMUsageParams) (repo.LLMUsageRecord, error) {
+	row, err := r.q.CreateLLMUsage(ctx, repoCreateLLMUsageParamsToDB(arg))
+	if err != nil {
+		return repo.LLMUsageRecord{}, err
+	}
+	return dbLLMUsageToRepo(row), nil
+}
+
+func (r *PGLLMUsage) SumCostSince(ctx context.Context, component string, since time.Time) (int64, error) {
+	ts, err := pgconv.TimeToPgTimestamptz(since)
+	if err != nil {
+		return 0, fmt.Errorf("convert since: %w", err)
+	}
+	return r.q.SumLLMCostSince(ctx, SumLLMCostSinceParams{
+		Component: component,
+		Since:     ts,
+	})
+}
+
+func (r *PGLLMUsage) SummarizeSpend(ctx context.Context, arg repo.SummarizeLLMSpendParams) ([]repo.LLMSpendSummary, error) {
+	params, err := repoSummarizeLLMSpendParamsToDB(arg)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.q.SummarizeLLMSpend(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]repo.LLMSpendSummary, len(rows))
+	for i, row := range rows {
+		out[i] = repo.LLMSpendSummary{
+			Day:          row.Day.Time,
+			Component:    row.Component,
+			Provider:     row.Provider,
+			Model:        row.Model,
+			Calls:        row.Calls,
+			InputTokens:  row.InputTokens,
+			OutputTokens: row.OutputTokens,
+			CachedTokens: row.CachedTokens,
+			TotalTokens:  row.TotalTokens,
+			CostMicros:   row.CostMicros,
+		}
+	}
+	return out, nil
+}
+
+func dbLLMUsageToRepo(row LlmUsage) repo.LLMUsageRecord {
+	return repo.LLMUsageRecord{
+		ID:           row.ID,
+		Provider:     row.Provider,
+		Model:        row.Model,
+		Operation:    row.Operation,
+		Component:    row.Component,
+		InputTokens:  row.InputTokens,
+		OutputTokens: row.OutputTokens,
+		CachedTokens: row.CachedTokens,
+		TotalTokens:  row.TotalTokens,
+		CostMicros:   row.CostMicros,
+		TraceID:      row.TraceID,
+		BatchID:      pgconv.PgUUIDToUUIDPtr(row.BatchID),
+		CreatedAt:    *pgconv.PgTimestamptzToTimePtr(row.CreatedAt),
+	}
+}
+
+// Users and API keys.
+func (r *PGUsers) CreateUser(ctx context.Context, name string) (repo.User, error) {
+	row, err := r.q.CreateUser(ctx, name)
+	if err != nil {
+		// ON CONFLICT DO NOTHING returns no row for a taken name.
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repo.User{}, fmt.Errorf("%w: %s", repo.ErrUserExists, name)
+		}
+		return repo.User{}, err
+	}
+	return dbUserToRepo(row), nil
+}
+
+func (r *PGUsers) GetUser(ctx context.Context, id uuid.UUID) (repo.User, error) {
+	row, err := r.q.GetUser(ctx, id)
+	if err != nil {
+		return repo.User{}, err
+	}
+	return dbUserToRepo(row), nil
+}
+
+func (r *PGUsers) CreateAPIKey(ctx context.Context, arg repo.CreateAPIKeyParams) (repo.APIKey, error) {
+	row, err := r.q.CreateAPIKey(ctx, repoCreateAPIKeyParamsToDB(arg))
+	if err != nil {
+		return repo.APIKey{}, err
+	}
+	return dbAPIKeyToRepo(row), nil
+}
+
+func (r *PGUsers) ListAPIKeys(ctx context.Context, userID uuid.UUID) ([]repo.APIKey, error) {
+	rows, err := r.q.ListAPIKeysByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]repo.APIKey, len(rows))
+	for i, row := range rows {
+		out[i] = dbAPIKeyToRepo(row)
+	}
+	return out, nil
+}
+
+func (r *PGUsers) ResolveAPIKey(ctx context.Context, keyHash string) (repo.APIKey, error) {
+	row, err := r.q.TouchActiveAPIKeyByHash(ctx, keyHash)
+	if err != nil {
+		return repo.APIKey{}, err
+	}
+	return dbAPIKeyToRepo(row), nil
+}
+
+func (r *PGUsers) RevokeAPIKey(ctx context.Context, id uuid.UUID) (int64, error) {
+	return r.q.RevokeAPIKey(ctx, id)
+}
+
+func dbUserToRepo(row User) repo.User {
+	return repo.User{
+		ID:         row.ID,
+		Name:       row.Name,
+		CreatedAt:  *pgconv.PgTimestamptzToTimePtr(row.CreatedAt),
+		DisabledAt: pgconv.PgTimestamptzToTimePtr(row.DisabledAt),
+	}
+}
+
+func dbAPIKeyToRepo(row ApiKey) repo.APIKey {
+	return repo.APIKey{
+		ID:             row.ID,
+		UserID:         row.UserID,
+		Name:           row.Name,
+		Prefix:         row.Prefix,
+		KeyHash:        row.KeyHash,
+		Scopes:         row.Scopes,
+		RateLimitRPS:   pgconv.PgFloat8ToFloat64Ptr(row.RateLimitRps),
+		RateLimitBurst: pgconv.PgInt4ToInt32Ptr(row.RateLimitBurst),
+		CreatedAt:      *pgconv.PgTimestamptzToTimePtr(row.CreatedAt),
+		LastUsedAt:     pgconv.PgTimestamptzToTimePtr(row.LastUsedAt),
+		ExpiresAt:      pgconv.PgTimestamptzToTimePtr(row.ExpiresAt),
+		RevokedAt:      pgconv.PgTimestamptzToTimePtr(row.RevokedAt),
+	}
+}
+
+// Outlet catalog.
+func (r *PGCatalog) UpsertSource(ctx context.Context, arg repo.UpsertSourceParams) (repo.Source, error) {
+	row, err := r.q.UpsertSource(ctx, UpsertSourceParams{
+		Abbr:    arg.Abbr,
+		Name:    arg.Name,
+		Type:    SourceType(arg.Type),
+		BaseUrl: arg.BaseURL,
+	})
+	if err != nil {
+		return repo.Source{}, err
+	}
+	return dbSourceToRepoSource(row), nil
+}
+
+func (r *PGCatalog) DeleteSource(ctx context.Context, abbr string) (int64, error) {
+	return r.q.SoftDeleteSource(ctx, abbr)
+}
+
+func (r *PGCatalog) ListScoutConfigs(ctx context.Context) ([]repo.ScoutConfig, error) {
+	rows, err := r.q.ListScoutConfigs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]repo.ScoutConfig, len(rows))
+	for i, row := range rows {
+		out[i] = dbScoutConfigToRepo(row)
+	}
+	return out, nil
+}
+
+func (r *PGCatalog) UpsertScoutConfig(ctx context.Context, arg repo.UpsertScoutConfigParams) (repo.ScoutConfig, error) {
+	row, err := r.q.UpsertScoutConfig(ctx, UpsertScoutConfigParams{
+		Name:   arg.Name,
+		Kind:   arg.Kind,
+		Config: arg.Config,
+	})
+	if err != nil {
+		return repo.ScoutConfig{}, err
+	}
+	return dbScoutConfigToRepo(row), nil
+}
+
+func (r *PGCatalog) DeleteScoutConfig(ctx context.Context, name string) (int64, error) {
+	return r.q.DeleteScoutConfig(ctx, name)
+}
+
+func (r *PGCatalog) ListParserRules(ctx context.Context) ([]repo.ParserRule, error) {
+	rows, err := r.q.ListParserRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]repo.ParserRule, len(rows))
+	for i, row := range rows {
+		out[i] = dbParserRuleToRepo(row)
+	}
+	return out, nil
+}
+
+func (r *PGCatalog) UpsertParserRule(ctx context.Context, arg repo.UpsertParserRuleParams) (repo.ParserRule, error) {
+	row, err := r.q.UpsertParserRule(ctx, UpsertParserRuleParams{
+		Host:   arg.Host,
+		Config: arg.Config,
+	})
+	if err != nil {
+		return repo.ParserRule{}, err
+	}
+	return dbParserRuleToRepo(row), nil
+}
+
+func (r *PGCatalog) DeleteParserRule(ctx context.Context, host string) (int64, error) {
+	return r.q.DeleteParserRule(ctx, host)
+}
+
+func dbScoutConfigToRepo(row ScoutConfig) repo.ScoutConfig {
+	return repo.ScoutConfig{
+		Name:      row.Name,
+		Kind:      row.Kind,
+		Config:    row.Config,
+		CreatedAt: *pgconv.PgTimestamptzToTimePtr(row.CreatedAt),
+		UpdatedAt: *pgconv.PgTimestamptzToTimePtr(row.UpdatedAt),
+	}
+}
+
+func dbParserRuleToRepo(row ParserRule) repo.ParserRule {
+	return repo.ParserRule{
+		Host:      row.Host,
+		Config:    row.Config,
+		CreatedAt: *pgconv.PgTimestamptzToTimePtr(row.CreatedAt),
+		UpdatedAt: *pgconv.PgTimestamptzToTimePtr(row.UpdatedAt),
+	}
+}
+
+// Backfills repository.
+func (r *PGBackfills) GetCheckpoint(ctx context.Context, sourceAbbr string) (repo.BackfillCheckpoint, error) {
+	row, err := r.q.GetBackfillCheckpoint(ctx, sourceAbbr)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repo.BackfillCheckpoint{}, fmt.Errorf("%w: %s", repo.ErrCheckpointNotFound, sourceAbbr)
+		}
+		return repo.BackfillCheckpoint{}, err
+	}
+	return dbBackfillCheckpointToRepo(row), nil
+}
+
+func (r *PGBackfills) SaveCheckpoint(ctx context.Context, arg repo.SaveBackfillCheckpointParams) (repo.BackfillCheckpoint, error) {
+	params, err := repoSaveBackfillCheckpointParamsToDB(arg)
+	if err != nil {
+		return repo.BackfillCheckpoint{}, err
+	}
+	row, err := r.q.UpsertBackfillCheckpoint(ctx, params)
+	if err != nil {
+		return repo.BackfillCheckpoint{}, err
+	}
+	return dbBackfillCheckpointToRepo(row), nil
+}
+
+func dbBackfillCheckpointToRepo(row BackfillCheckpoint) repo.BackfillCheckpoint {
+	return repo.BackfillCheckpoint{
+		SourceAbbr:          row.SourceAbbr,
+		Pager:               row.Pager,
+		LastPage:            row.LastPage,
+		BatchID:             row.BatchID,
+		Until:               *pgconv.PgTimestamptzToTimePtr(row.Until),
+		PagesVisited:        row.PagesVisited,
+		CandidatesSeen:      row.CandidatesSeen,
+		CandidatesProcessed: row.CandidatesProcessed,
+		OldestPublishedAt:   pgconv.PgTimestamptzToTimePtr(row.OldestPublishedAt),
+		CompletedAt:         pgconv.PgTimestamptzToTimePtr(row.CompletedAt),
+		CreatedAt:           *pgconv.PgTimestamptzToTimePtr(row.CreatedAt),
+		UpdatedAt:           *pgconv.PgTimestamptzToTimePtr(row.UpdatedAt),
+	}
+}
+
+// Aliases repository.
+func (r *PGAliases) ListAliases(ctx context.Context) ([]repo.SearchAlias, error) {
+	rows, err := r.q.ListSearchAliases(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return dbSearchAliasesToRepo(rows), nil
+}
+
+func (r *PGAliases) ReplaceAliases(ctx context.Context, arg repo.ReplaceSearchAliasesParams) ([]repo.SearchAlias, error) {
+	rows, err := r.q.ReplaceSearchAliases(ctx, repoReplaceSearchAliasesParamsToDB(arg))
+	if err != nil {
+		return nil, err
+	}
+	return dbSearchAliasesToRepo(rows), nil
+}
+
+func (r *PGAliases) DeleteAliases(ctx context.Context, canonical string) (int64, error) {
+	return r.q.DeleteSearchAliases(ctx, canonical)
+}
+
+func dbSearchAliasesToRepo(rows []SearchAlias) []repo.SearchAlias {
+	out := make([]repo.SearchAlias, len(rows))
+	for i, row := range rows {
+		out[i] = repo.SearchAlias{
+			Canonical: row.Canonical,
+			Alias:     row.Alias,
+			Kind:      row.Kind,
+			CreatedAt: *pgconv.PgTimestamptzToTimePtr(row.CreatedAt),
+			UpdatedAt: *pgconv.PgTimestamptzToTimePtr(row.UpdatedAt),
+		}
+	}
+	return out
+}
+
+// Task run history.
+func (r *PGTaskRuns) Record(ctx context.Context, arg repo.CreateTaskRunParams) (repo.TaskRun, error) {
+	params, err := repoCreateTaskRunParamsToDB(arg)
+	if err != nil {
+		return repo.TaskRun{}, err
+	}
+	row, err := r.q.CreateTaskRun(ctx, params)
+	if err != nil {
+		return repo.TaskRun{}, err
+	}
+	return dbTaskRunToRepo(row)
+}
+
+func (r *PGTaskRuns) ListByTask(ctx context.Context, arg repo.ListTaskRunsParams) ([]repo.TaskRun, error) {
+	rows, err := r.q.ListTaskRunsByTaskID(ctx, repoListTaskRunsParamsToDB(arg))
+	if err != nil {
+		return nil, err
+	}
+	out := make([]repo.TaskRun, len(rows))
+	for i, row := range rows {
+		if out[i], err = dbTaskRunToRepo(row); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func (r *PGTaskRuns) SummarizeBySource(ctx context.Context, arg repo.SummarizeTaskRunsParams) ([]repo.TaskRunYield, error) {
+	params, err := repoSummarizeTaskRunsParamsToDB(arg)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.q.SummarizeTaskRunsBySource(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]repo.TaskRunYield, len(rows))
+	for i, row := range rows {
+		out[i] = repo.TaskRunYield{
+			Kind:           string(row.Kind),
+			SourceAbbr:     row.SourceAbbr,
+			Runs:           row.Runs,
+			FailedRuns:     row.FailedRuns,
+			ItemsSeen:      row.ItemsSeen,
+			ItemsNew:       row.ItemsNew,
+			ItemsDuplicate: row.ItemsDuplicate,
+			AvgDuration:    time.Duration(row.AvgDurationMs) * time.Millisecond,
+			LastRunAt:      row.LastRunAt.Time,
+			LastNewAt:      pgconv.PgTimestamptzToTimePtr(row.LastNewAt),
+		}
+	}
+	return out, nil
+}
+
+func (r *PGTaskRuns) SummarizeByTask(ctx context.Context, arg repo.SummarizeTaskRunsParams) ([]repo.TaskRunYield, error) {
+	params, err := repoSummarizeTaskRunsParamsToDB(arg)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.q.SummarizeTaskRunsByTask(ctx, SummarizeTaskRunsByTaskParams(params))
+	if err != nil {
+		return nil, err
+	}
+	out := make([]repo.TaskRunYield, len(rows))
+	for i, row := range rows {
+		out[i] = repo.TaskRunYield{
+			TaskID:         row.TaskID,
+			Kind:           string(row.Kind),
+			SourceAbbr:     row.SourceAbbr,
+			Query:          row.Query,
+			Runs:           row.Runs,
+			FailedRuns:     row.FailedRuns,
+			ItemsSeen:      row.ItemsSeen,
+			ItemsNew:       row.ItemsNew,
+			ItemsDuplicate: row.ItemsDuplicate,
+			AvgDuration:    time.Duration(row.AvgDurationMs) * time.Millisecond,
+			LastRunAt:      row.LastRunAt.Time,
+			LastNewAt:      pgconv.PgTimestamptzToTimePtr(row.LastNewAt),
+		}
+	}
+	return out, nil
+}
+
+func (r *PGTaskRuns) SummarizeByProvider(ctx context.Context, arg repo.SummarizeTaskRunsParams) ([]repo.TaskRunYield, error) {
+	params, err := repoSummarizeTaskRunsParamsToDB(arg)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.q.SummarizeTaskRunsByProvider(ctx, SummarizeTaskRunsByProviderParams{
+		Since:      params.Since,
+		Until:      params.Until,
+		SourceAbbr: params.SourceAbbr,
+		RowLimit:   params.RowLimit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]repo.TaskRunYield, len(rows))
+	for i, row := range rows {
+		out[i] = repo.TaskRunYield{
+			Kind:       repo.TaskKindKeywordSearch,
+			Provider:   row.Provider,
+			Runs:       row.Runs,
+			FailedRuns: row.FailedRuns,
+			ItemsSeen:  row.ItemsSeen,
+			LastRunAt:  row.LastRunAt.Time,
+		}
+	}
+	return out, nil
+}
+
+func dbTaskRunToRepo(row TaskRun) (repo.TaskRun, error) {
+	var providers map[string]repo.TaskRunProvider
+	if len(row.Providers) > 0 {
+		if err := json.Unmarshal(row.Providers, &providers); err != nil {
+			return repo.TaskRun{}, fmt.Errorf("decode providers: %w", err)
+		}
+	}
+	return repo.TaskRun{
+		ID:             row.ID,
+		TaskID:         row.TaskID,
+		BatchID:        row.BatchID,
+		Kind:           string(row.Kind),
+		SourceType:     string(row.SourceType),
+		SourceAbbr:     row.SourceAbbr,
+		Query:          pgconv.PgTextToStringPtr(row.Query),
+		Status:         row.Status,
+		ErrorClass:     pgconv.PgTextToStringPtr(row.ErrorClass),
+		ItemsSeen:      row.ItemsSeen,
+		ItemsNew:       row.ItemsNew,
+		ItemsDuplicate: row.ItemsDuplicate,
+		Providers:      providers,
+		Duration:       time.Duration(row.DurationMs) * time.Millisecond,
+		TraceID:        row.TraceID,
+		StartedAt:      *pgconv.PgTimestamptzToTimePtr(row.StartedAt),
+		CreatedAt:      *pgconv.PgTimestamptzToTimePtr(row.CreatedAt),
+	}, nil
+}
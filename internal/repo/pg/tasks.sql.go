@@ -96,24 +96,37 @@ const completeTask = `-- name: CompleteTask :exec
 UPDATE tasks
 SET status = CASE
         WHEN frequency IS NOT NULL
-         AND (expires_at IS NULL OR NOW() + frequency <= expires_at)
+         AND (expires_at IS NULL OR NOW() + COALESCE($1::interval, frequency) <= expires_at)
             THEN 'PENDING'::task_status
         ELSE 'COMPLETED'::task_status
     END,
     next_run_at = CASE
         WHEN frequency IS NOT NULL
-         AND (expires_at IS NULL OR NOW() + frequency <= expires_at)
-            THEN NOW() + frequency
+         AND (expires_at IS NULL OR NOW() + COALESCE($1::interval, frequency) <= expires_at)
+            THEN NOW() + COALESCE($1::interval, frequency)
         ELSE next_run_at
     END,
+    meta = CASE
+        WHEN $2::jsonb IS NULL THEN meta
+        ELSE COALESCE(meta, '{}'::jsonb) || $2::jsonb
+    END,
     last_run_at = NOW(),
     updated_at = NOW()
-WHERE id = $1
+WHERE id = $3
   AND status = 'RUNNING'
 `
 
-func (q *Queries) CompleteTask(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.Exec(ctx, completeTask, id)
+type CompleteTaskParams struct {
+	NextRunIn pgtype.Interval `db:"next_run_in" json:"next_run_in"`
+	Meta      []byte          `db:"meta" json:"meta"`
+	ID        uuid.UUID       `db:"id" json:"id"`
+}
+
+// next_run_in, when set, replaces frequency as the delay before the next run
+// of a recurring task (adaptive polling); meta, when set, is merged into the
+// task's meta.
+func (q *Queries) CompleteTask(ctx context.Context, arg CompleteTaskParams) error {
+	_, err := q.db.Exec(ctx, completeTask, arg.NextRunIn, arg.Meta, arg.ID)
 	return err
 }
 
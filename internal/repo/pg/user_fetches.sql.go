@@ -13,19 +13,27 @@ import (
 )
 
 const createUserFetch = `-- name: CreateUserFetch :one
-INSERT INTO fetches (user_id)
-VALUES ($1)
-RETURNING id, user_id, created_at, completed_at
+INSERT INTO fetches (user_id, notify_url, notify_secret)
+VALUES ($1, $2, $3)
+RETURNING id, user_id, created_at, completed_at, notify_url, notify_secret
 `
 
-func (q *Queries) CreateUserFetch(ctx context.Context, userID pgtype.UUID) (Fetch, error) {
-	row := q.db.QueryRow(ctx, createUserFetch, userID)
+type CreateUserFetchParams struct {
+	UserID       pgtype.UUID `db:"user_id" json:"user_id"`
+	NotifyUrl    pgtype.Text `db:"notify_url" json:"notify_url"`
+	NotifySecret pgtype.Text `db:"notify_secret" json:"notify_secret"`
+}
+
+func (q *Queries) CreateUserFetch(ctx context.Context, arg CreateUserFetchParams) (Fetch, error) {
+	row := q.db.QueryRow(ctx, createUserFetch, arg.UserID, arg.NotifyUrl, arg.NotifySecret)
 	var i Fetch
 	err := row.Scan(
 		&i.ID,
 		&i.UserID,
 		&i.CreatedAt,
 		&i.CompletedAt,
+		&i.NotifyUrl,
+		&i.NotifySecret,
 	)
 	return i, err
 }
@@ -71,7 +79,7 @@ func (q *Queries) CreateUserFetchItem(ctx context.Context, arg CreateUserFetchIt
 }
 
 const getUserFetch = `-- name: GetUserFetch :one
-SELECT id, user_id, created_at, completed_at
+SELECT id, user_id, created_at, completed_at, notify_url, notify_secret
 FROM fetches
 WHERE id = $1
 LIMIT 1
@@ -85,6 +93,8 @@ func (q *Queries) GetUserFetch(ctx context.Context, id uuid.UUID) (Fetch, error)
 		&i.UserID,
 		&i.CreatedAt,
 		&i.CompletedAt,
+		&i.NotifyUrl,
+		&i.NotifySecret,
 	)
 	return i, err
 }
@@ -158,6 +168,52 @@ func (q *Queries) GetUserFetchProgress(ctx context.Context, fetchID uuid.UUID) (
 	return i, err
 }
 
+const listTerminalUserFetches = `-- name: ListTerminalUserFetches :many
+SELECT f.id, f.user_id, f.created_at, f.completed_at, f.notify_url, f.notify_secret
+FROM fetches f
+WHERE f.completed_at IS NULL
+  AND EXISTS (SELECT 1 FROM fetch_items i WHERE i.fetch_id = f.id)
+  AND NOT EXISTS (
+      SELECT 1
+      FROM fetch_items i
+      LEFT JOIN tasks t ON t.id = i.task_id
+      WHERE i.fetch_id = f.id
+        AND COALESCE(i.snapshot_status, t.status::text, '') NOT IN ('COMPLETED', 'FAILED', 'ALREADY_COMPLETE')
+  )
+ORDER BY f.created_at ASC
+LIMIT $1
+`
+
+// Open fetches whose items have all reached COMPLETED / FAILED /
+// ALREADY_COMPLETE, oldest first. Resolves item status exactly like
+// GetUserFetchProgress; fetches without items never qualify.
+func (q *Queries) ListTerminalUserFetches(ctx context.Context, limit int32) ([]Fetch, error) {
+	rows, err := q.db.Query(ctx, listTerminalUserFetches, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Fetch
+	for rows.Next() {
+		var i Fetch
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.CreatedAt,
+			&i.CompletedAt,
+			&i.NotifyUrl,
+			&i.NotifySecret,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listUserFetchItems = `-- name: ListUserFetchItems :many
 SELECT
     i.fetch_id,
@@ -208,17 +264,20 @@ func (q *Queries) ListUserFetchItems(ctx context.Context, fetchID uuid.UUID) ([]
 	return items, nil
 }
 
-const markUserFetchCompleted = `-- name: MarkUserFetchCompleted :exec
+const markUserFetchCompleted = `-- name: MarkUserFetchCompleted :execrows
 UPDATE fetches
 SET completed_at = NOW()
 WHERE id = $1
   AND completed_at IS NULL
 `
 
-// Sets completed_at on transition to terminal. Idempotent (WHERE clause
-// guards against double-set). v1 callers may skip this — progress endpoint
-// computes terminal on-the-fly. Reserved for v2 notification dispatcher.
-func (q *Queries) MarkUserFetchCompleted(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.Exec(ctx, markUserFetchCompleted, id)
-	return err
+// Sets completed_at on transition to terminal. Optimistic-concurrency claim:
+// returns rows-affected so only the notifier instance that wins (1) logs the
+// transition; the progress endpoint still computes terminal on-the-fly.
+func (q *Queries) MarkUserFetchCompleted(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, markUserFetchCompleted, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
@@ -2,6 +2,7 @@ package repo
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -15,13 +16,21 @@ type Repository interface {
 	Analysis() Analysis
 	BatchTrigger() BatchTrigger
 	UserFetches() UserFetches
+	LLMUsage() LLMUsage
+	Users() Users
+	Catalog() Catalog
+	Backfills() Backfills
+	Aliases() Aliases
+	TaskRuns() TaskRuns
 }
 
 // TaskReporter is the push side of the task lifecycle: workers use it to
 // report the outcome of a claimed task. It is intentionally narrower than
 // Scheduler so worker handlers only depend on what they actually call.
 type TaskReporter interface {
-	CompleteTask(ctx context.Context, id uuid.UUID) error
+	// CompleteTask finishes a run. A recurring task goes back to PENDING
+	// after arg.NextRunIn, or its frequency when that is nil.
+	CompleteTask(ctx context.Context, arg CompleteTaskParams) error
 	FailTask(ctx context.Context, id uuid.UUID) error
 }
 
@@ -41,8 +50,15 @@ type Scout interface {
 	ListSourcesByType(ctx context.Context, sourceType string) ([]Source, error)
 	GetCandidateByID(ctx context.Context, id uuid.UUID) (Candidate, error)
 	GetCandidatesByIDs(ctx context.Context, ids []uuid.UUID) ([]Candidate, error)
+	// GetCandidatesByFingerprints returns the candidates already stored
+	// under any of fingerprints, in no particular order.
+	GetCandidatesByFingerprints(ctx context.Context, fingerprints []string) ([]Candidate, error)
 	GetCandidateByFingerprint(ctx context.Context, fingerprint string) (Candidate, error)
 	ListCandidates(ctx context.Context, arg ListCandidatesParams) ([]Candidate, error)
+	// ListCandidatesDiscoveredAfter pages candidates in (discovered_at, id)
+	// order strictly after the cursor; see ListCandidatesDiscoveredAfterParams.
+	ListCandidatesDiscoveredAfter(ctx context.Context, arg ListCandidatesDiscoveredAfterParams) ([]Candidate, error)
+	CountCandidates(ctx context.Context, arg CountCandidatesParams) (int64, error)
 	CountCandidatesByBatchID(ctx context.Context, batchID uuid.UUID) (int64, error)
 	CreateCandidate(ctx context.Context, arg CreateCandidateParams) (Candidate, error)
 	UpsertCandidate(ctx context.Context, arg UpsertCandidateParams) (Candidate, error)
@@ -65,6 +81,7 @@ type Pipeline interface {
 	GetContentByCandidateID(ctx context.Context, candidateID uuid.UUID) (Content, error)
 	CreateContent(ctx context.Context, arg CreateContentParams) (Content, error)
 	UpdateContentMetadata(ctx context.Context, arg UpdateContentMetadataParams) (Content, error)
+	ListContents(ctx context.Context, arg ListContentsParams) ([]Content, error)
 	ListContentsByBatchID(ctx context.Context, batchID uuid.UUID) ([]Content, error)
 	ListRecentSeedContents(ctx context.Context, limit int32) ([]Content, error)
 }
@@ -89,6 +106,9 @@ type Embeddings interface {
 	GetModelByNameAndType(ctx context.Context, name string, modelType string) (Model, error)
 	CreateCandidateEmbedding(ctx context.Context, arg CreateCandidateEmbeddingParams) (CandidateEmbedding, error)
 	CreateContentEmbedding(ctx context.Context, arg CreateContentEmbeddingParams) (ContentEmbedding, error)
+	// ListContentEmbeddings returns the embeddings of a content, newest
+	// first.
+	ListContentEmbeddings(ctx context.Context, contentID uuid.UUID) ([]ContentEmbedding, error)
 }
 
 // UserFetches is the user-facing observation layer for POST /page_fetch.
@@ -99,15 +119,121 @@ type UserFetches interface {
 	Get(ctx context.Context, id uuid.UUID) (UserFetch, error)
 	CreateItem(ctx context.Context, arg CreateUserFetchItemParams) (UserFetchItem, error)
 	GetProgress(ctx context.Context, fetchID uuid.UUID) (UserFetchProgress, error)
-	// MarkCompleted is reserved for the v2 notification dispatcher.
-	// v1 callers compute terminal on-the-fly from GetProgress and may
-	// skip this entirely.
-	MarkCompleted(ctx context.Context, fetchID uuid.UUID) error
+	// ListTerminal returns open fetches whose items are all terminal.
+	ListTerminal(ctx context.Context, limit int32) ([]UserFetch, error)
+	// MarkCompleted sets completed_at and returns rows-affected: 1 for the
+	// caller that won the transition, 0 when it was already set. Readers
+	// still compute terminal on-the-fly from GetProgress.
+	MarkCompleted(ctx context.Context, fetchID uuid.UUID) (int64, error)
+	// EnqueueNotification stores a fetch.completed delivery. Returns 0 when
+	// the fetch already has one.
+	EnqueueNotification(ctx context.Context, arg CreateFetchNotificationParams) (int64, error)
+	// ClaimDueNotifications leases up to limit due deliveries for lease.
+	ClaimDueNotifications(ctx context.Context, limit int32, lease time.Duration) ([]FetchNotification, error)
+	RecordNotificationAttempt(ctx context.Context, arg RecordFetchNotificationAttemptParams) error
+}
+
+// Users owns API callers and their hashed keys. The API server resolves
+// keys through it; the admin routes manage it.
+type Users interface {
+	CreateUser(ctx context.Context, name string) (User, error)
+	GetUser(ctx context.Context, id uuid.UUID) (User, error)
+	CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (APIKey, error)
+	ListAPIKeys(ctx context.Context, userID uuid.UUID) ([]APIKey, error)
+	// ResolveAPIKey returns the usable key with keyHash and refreshes its
+	// last_used_at. Revoked or expired keys, and keys of disabled users,
+	// yield pgx.ErrNoRows.
+	ResolveAPIKey(ctx context.Context, keyHash string) (APIKey, error)
+	// RevokeAPIKey returns rows-affected: 0 when the key does not exist or
+	// was already revoked.
+	RevokeAPIKey(ctx context.Context, id uuid.UUID) (int64, error)
+}
+
+// Catalog owns the outlet registry: sources, scout configs and parser rules.
+// Discovery and collector workers rebuild their registries from it; the
+// admin routes manage it. Writes to scout configs and parser rules publish
+// on ChangeChannelCatalog.
+type Catalog interface {
+	// UpsertSource creates or replaces a source, reviving a deleted one.
+	UpsertSource(ctx context.Context, arg UpsertSourceParams) (Source, error)
+	// DeleteSource soft-deletes a source and returns rows-affected: 0 when
+	// it does not exist or is already deleted.
+	DeleteSource(ctx context.Context, abbr string) (int64, error)
+	ListScoutConfigs(ctx context.Context) ([]ScoutConfig, error)
+	UpsertScoutConfig(ctx context.Context, arg UpsertScoutConfigParams) (ScoutConfig, error)
+	DeleteScoutConfig(ctx context.Context, name string) (int64, error)
+	ListParserRules(ctx context.Context) ([]ParserRule, error)
+	UpsertParserRule(ctx context.Context, arg UpsertParserRuleParams) (ParserRule, error)
+	DeleteParserRule(ctx context.Context, host string) (int64, error)
+}
+
+// Backfills records how far each source's historical backfill got, so
+// cmd/backfiller can resume a killed run from its last finished page.
+type Backfills interface {
+	// GetCheckpoint returns ErrCheckpointNotFound when the source has never
+	// been backfilled.
+	GetCheckpoint(ctx context.Context, sourceAbbr string) (BackfillCheckpoint, error)
+	// SaveCheckpoint replaces the source's checkpoint.
+	SaveCheckpoint(ctx context.Context, arg SaveBackfillCheckpointParams) (BackfillCheckpoint, error)
+}
+
+// Aliases owns the search alias dictionary the planner expands keyword
+// queries with; the admin routes manage it.
+type Aliases interface {
+	// ListAliases returns every alias ordered by canonical, then alias.
+	ListAliases(ctx context.Context) ([]SearchAlias, error)
+	// ReplaceAliases replaces the group of arg.Canonical and returns it.
+	ReplaceAliases(ctx context.Context, arg ReplaceSearchAliasesParams) ([]SearchAlias, error)
+	// DeleteAliases removes the group of canonical and returns the number
+	// of aliases removed.
+	DeleteAliases(ctx context.Context, canonical string) (int64, error)
+}
+
+// TaskRuns is the history of discovery executions. The discovery worker
+// records one run per DIRECTORY_FETCH or KEYWORD_SEARCH message; the admin
+// yield routes summarise it to find tasks that stopped finding anything.
+type TaskRuns interface {
+	Record(ctx context.Context, arg CreateTaskRunParams) (TaskRun, error)
+	// ListByTask returns one keyset page of a task's runs, newest first.
+	ListByTask(ctx context.Context, arg ListTaskRunsParams) ([]TaskRun, error)
+	SummarizeBySource(ctx context.Context, arg SummarizeTaskRunsParams) ([]TaskRunYield, error)
+	// SummarizeByTask orders tasks by new candidates found, fewest first.
+	SummarizeByTask(ctx context.Context, arg SummarizeTaskRunsParams) ([]TaskRunYield, error)
+	SummarizeByProvider(ctx context.Context, arg SummarizeTaskRunsParams) ([]TaskRunYield, error)
+}
+
+// ChangeFeed fans out database change notifications (Postgres
+// LISTEN/NOTIFY in production). A notification is only a wake-up: readers
+// re-query the tables, so dropped or coalesced notifications are harmless.
+type ChangeFeed interface {
+	// Subscribe registers for notifications on channel whose payload equals
+	// key, or every payload when key is empty. The returned channel holds at
+	// most one pending wake-up and is closed when the feed shuts down; the
+	// returned func unsubscribes.
+	Subscribe(channel, key string) (<-chan struct{}, func())
+}
+
+// LLMUsage is the append-only ledger of LLM provider calls. The budget
+// decorator in internal/llm reads it before each call; GET /api/v1/llm/spend
+// summarises it.
+type LLMUsage interface {
+	Record(ctx context.Context, arg CreateLLMUsageParams) (LLMUsageRecord, error)
+	// SumCostSince returns the micro-USD spent by component since the given
+	// instant.
+	SumCostSince(ctx context.Context, component string, since time.Time) (int64, error)
+	SummarizeSpend(ctx context.Context, arg SummarizeLLMSpendParams) ([]LLMSpendSummary, error)
 }
 
 type Analysis interface {
 	GetPromptByID(ctx context.Context, id uuid.UUID) (Prompt, error)
 	GetPromptByHash(ctx context.Context, hash string) (Prompt, error)
+	GetPromptByNameAndVersion(ctx context.Context, name, version string) (Prompt, error)
+	// ListPromptVersions returns every registered version of name, oldest
+	// first.
+	ListPromptVersions(ctx context.Context, name string) ([]Prompt, error)
+	// UpsertPrompt registers a prompt version. Re-registering identical
+	// content is a no-op; different content under an existing version fails
+	// with ErrPromptVersionConflict.
 	UpsertPrompt(ctx context.Context, arg UpsertPromptParams) (Prompt, error)
 	CreateContentExtraction(ctx context.Context, arg CreateContentExtractionParams) (ContentExtraction, error)
 	GetContentExtractionByID(ctx context.Context, id uuid.UUID) (ContentExtraction, error)
@@ -0,0 +1,37 @@
+// Package exporters builds social.Exporter implementations by platform
+// name, so discovery and the collector configure platforms the same way.
+package exporters
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ChiaYuChang/prism/internal/social"
+	"github.com/ChiaYuChang/prism/internal/social/youtube"
+)
+
+// Platforms lists the platform names New accepts.
+var Platforms = []string{social.PlatformYouTube}
+
+// Options carries the settings shared by every platform exporter.
+// Platforms ignore the ones they do not use.
+type Options struct {
+	Headers          map[string]string
+	CaptionLanguages []string
+	DisableCaptions  bool
+}
+
+// New returns the exporter for platform.
+func New(platform string, client *http.Client, opts Options) (social.Exporter, error) {
+	switch strings.ToLower(strings.TrimSpace(platform)) {
+	case social.PlatformYouTube:
+		return youtube.New(client, youtube.Config{
+			Headers:          opts.Headers,
+			CaptionLanguages: opts.CaptionLanguages,
+			DisableCaptions:  opts.DisableCaptions,
+		}), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", social.ErrUnsupportedPlatform, platform)
+	}
+}
@@ -0,0 +1,96 @@
+// Package social defines the platform exporter contract for SUBSCRIPTION
+// ingestion: public channel feeds (YouTube, Facebook pages, Threads) read
+// into Posts, which discovery turns into candidates and the collector into
+// SOCIAL contents. Platform packages such as social/youtube implement
+// Exporter; exporters.New builds one by platform name.
+package social
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+// Platforms with an Exporter.
+const (
+	PlatformYouTube = "youtube"
+)
+
+// Metadata keys shared by every platform. Candidates and SOCIAL contents
+// carry them next to the platform-specific keys in Post.Metadata.
+const (
+	MetadataPlatform  = "platform"
+	MetadataPostID    = "post_id"
+	MetadataChannelID = "channel_id"
+	MetadataChannel   = "channel"
+)
+
+var (
+	ErrUnsupportedPlatform = errors.New("unsupported social platform")
+	ErrUnsupportedURL      = errors.New("url is not a supported post or channel url")
+	ErrPostNotFound        = errors.New("social post not found")
+)
+
+// Post is one public post, video or update on a platform channel.
+// Description is the text the platform shows with the post; Transcript is
+// text recovered from the media itself, such as video captions.
+type Post struct {
+	Platform    string         `json:"platform"`
+	ID          string         `json:"id"`
+	URL         string         `json:"url"`
+	ChannelID   string         `json:"channel_id,omitempty"`
+	Channel     string         `json:"channel,omitempty"`
+	Title       string         `json:"title"`
+	Description string         `json:"description,omitempty"`
+	Transcript  string         `json:"transcript,omitempty"`
+	PublishedAt time.Time      `json:"published_at"`
+	Metadata    map[string]any `json:"metadata,omitempty"`
+}
+
+// Text returns the description and transcript as one body, separated by a
+// blank line; either may be empty.
+func (p Post) Text() string {
+	var parts []string
+	for _, s := range []string{p.Description, p.Transcript} {
+		if s = strings.TrimSpace(s); s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// CommonMetadata returns Post.Metadata plus the shared keys. Shared keys
+// win over platform keys of the same name.
+func (p Post) CommonMetadata() map[string]any {
+	out := make(map[string]any, len(p.Metadata)+4)
+	for k, v := range p.Metadata {
+		out[k] = v
+	}
+	out[MetadataPlatform] = p.Platform
+	out[MetadataPostID] = p.ID
+	if p.ChannelID != "" {
+		out[MetadataChannelID] = p.ChannelID
+	}
+	if p.Channel != "" {
+		out[MetadataChannel] = p.Channel
+	}
+	return out
+}
+
+// Exporter reads public posts from one platform.
+type Exporter interface {
+	// Platform is the platform name, e.g. PlatformYouTube.
+	Platform() string
+
+	// Hosts lists the URL hosts the exporter accepts, lower case.
+	Hosts() []string
+
+	// Channel lists the latest posts of a channel feed, newest first.
+	// Posts may lack a Transcript; Channel must stay cheap enough to poll.
+	Channel(ctx context.Context, feedURL string) ([]Post, error)
+
+	// Post fetches one post by URL with everything the platform exposes,
+	// Transcript included when available.
+	Post(ctx context.Context, postURL string) (Post, error)
+}
@@ -0,0 +1,514 @@
+// Package youtube exports public YouTube channel videos. Channel reads the
+// channel's Atom feed (/feeds/videos.xml?channel_id=...), which carries the
+// full video description. Post reads the watch page's player response for
+// the description, statistics and caption tracks, then downloads the
+// preferred caption track, auto-generated captions included, as the
+// transcript.
+package youtube
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	httpclient "github.com/ChiaYuChang/prism/internal/http/client"
+	"github.com/ChiaYuChang/prism/internal/social"
+)
+
+const (
+	// maxBodyBytes caps one response; watch pages run to about 1.5 MiB.
+	maxBodyBytes = 8 << 20
+
+	captionKindASR    = "asr"
+	captionKindManual = "manual"
+)
+
+var (
+	hosts = []string{"www.youtube.com", "youtube.com", "m.youtube.com", "youtu.be"}
+
+	videoIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+
+	playerResponseMarker = []byte("ytInitialPlayerResponse")
+)
+
+// Config configures the YouTube exporter.
+type Config struct {
+	// Headers are sent with every request. YouTube serves a consent page
+	// to some regions without a browser-like User-Agent and a CONSENT
+	// cookie.
+	Headers map[string]string
+
+	// CaptionLanguages ranks caption tracks by language code, e.g.
+	// ["zh-TW", "zh", "en"]. A code also matches its regional variants
+	// ("zh" matches "zh-Hant"). Creator captions beat auto-generated ones
+	// of the same language. With no match, or no preference, the first
+	// creator track wins, then the first auto-generated one.
+	CaptionLanguages []string
+
+	// DisableCaptions skips the caption download in Post.
+	DisableCaptions bool
+}
+
+// Exporter implements social.Exporter for YouTube.
+type Exporter struct {
+	client *http.Client
+	cfg    Config
+}
+
+var _ social.Exporter = (*Exporter)(nil)
+
+// New returns a YouTube exporter. A nil client uses the public-only client
+// with the default timeout.
+func New(client *http.Client, cfg Config) *Exporter {
+	if client == nil {
+		client = httpclient.NewPublicClient(httpclient.DefaultTimeout)
+	}
+	langs := make([]string, 0, len(cfg.CaptionLanguages))
+	for _, lang := range cfg.CaptionLanguages {
+		if lang = strings.TrimSpace(lang); lang != "" {
+			langs = append(langs, lang)
+		}
+	}
+	cfg.CaptionLanguages = langs
+	return &Exporter{client: client, cfg: cfg}
+}
+
+func (e *Exporter) Platform() string {
+	return social.PlatformYouTube
+}
+
+func (e *Exporter) Hosts() []string {
+	return append([]string(nil), hosts...)
+}
+
+type feed struct {
+	XMLName   xml.Name
+	ChannelID string  `xml:"http://www.youtube.com/xml/schemas/2015 channelId"`
+	Title     string  `xml:"http://www.w3.org/2005/Atom title"`
+	Entries   []entry `xml:"http://www.w3.org/2005/Atom entry"`
+}
+
+type entry struct {
+	VideoID   string `xml:"http://www.youtube.com/xml/schemas/2015 videoId"`
+	ChannelID string `xml:"http://www.youtube.com/xml/schemas/2015 channelId"`
+	Title     string `xml:"http://www.w3.org/2005/Atom title"`
+	Links     []struct {
+		Rel  string `xml:"rel,attr"`
+		Href string `xml:"href,attr"`
+	} `xml:"http://www.w3.org/2005/Atom link"`
+	Author struct {
+		Name string `xml:"http://www.w3.org/2005/Atom name"`
+	} `xml:"http://www.w3.org/2005/Atom author"`
+	Published string `xml:"http://www.w3.org/2005/Atom published"`
+	Updated   string `xml:"http://www.w3.org/2005/Atom updated"`
+	Group     struct {
+		Description string `xml:"http://search.yahoo.com/mrss/ description"`
+		Thumbnail   struct {
+			URL string `xml:"url,attr"`
+		} `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+		Community struct {
+			StarRating struct {
+				Count string `xml:"count,attr"`
+			} `xml:"http://search.yahoo.com/mrss/ starRating"`
+			Statistics struct {
+				Views string `xml:"views,attr"`
+			} `xml:"http://search.yahoo.com/mrss/ statistics"`
+		} `xml:"http://search.yahoo.com/mrss/ community"`
+	} `xml:"http://search.yahoo.com/mrss/ group"`
+}
+
+// Channel reads a channel or playlist Atom feed. Transcripts are not
+// fetched; Post does that for one video.
+func (e *Exporter) Channel(ctx context.Context, feedURL string) ([]social.Post, error) {
+	body, err := e.get(ctx, feedURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc feed
+	if err := xml.Unmarshal(body, &doc); err != nil {
+		return nil, fmt.Errorf("parse youtube feed %s: %w", feedURL, err)
+	}
+	if doc.XMLName.Local != "feed" {
+		return nil, fmt.Errorf("parse youtube feed %s: unexpected root element <%s>", feedURL, doc.XMLName.Local)
+	}
+
+	posts := make([]social.Post, 0, len(doc.Entries))
+	for _, en := range doc.Entries {
+		id := strings.TrimSpace(en.VideoID)
+		if id == "" {
+			continue
+		}
+		link := ""
+		for _, l := range en.Links {
+			if l.Rel == "" || l.Rel == "alternate" {
+				link = strings.TrimSpace(l.Href)
+				break
+			}
+		}
+		if link == "" {
+			link = WatchURL(id)
+		}
+
+		post := social.Post{
+			Platform:    social.PlatformYouTube,
+			ID:          id,
+			URL:         link,
+			ChannelID:   firstNonEmpty(en.ChannelID, doc.ChannelID),
+			Channel:     firstNonEmpty(en.Author.Name, doc.Title),
+			Title:       strings.TrimSpace(en.Title),
+			Description: strings.TrimSpace(en.Group.Description),
+			Metadata:    map[string]any{},
+		}
+		if t, err := time.Parse(time.RFC3339, strings.TrimSpace(en.Published)); err == nil {
+			post.PublishedAt = t
+		}
+		if thumb := strings.TrimSpace(en.Group.Thumbnail.URL); thumb != "" {
+			post.Metadata["thumbnail"] = thumb
+		}
+		if views, err := strconv.ParseInt(en.Group.Community.Statistics.Views, 10, 64); err == nil {
+			post.Metadata["view_count"] = views
+		}
+		if ratings, err := strconv.ParseInt(en.Group.Community.StarRating.Count, 10, 64); err == nil {
+			post.Metadata["rating_count"] = ratings
+		}
+		if strings.Contains(link, "/shorts/") {
+			post.Metadata["short"] = true
+		}
+		posts = append(posts, post)
+	}
+	return posts, nil
+}
+
+type playerResponse struct {
+	PlayabilityStatus struct {
+		Status string `json:"status"`
+		Reason string `json:"reason"`
+	} `json:"playabilityStatus"`
+	VideoDetails struct {
+		VideoID          string   `json:"videoId"`
+		Title            string   `json:"title"`
+		LengthSeconds    string   `json:"lengthSeconds"`
+		Keywords         []string `json:"keywords"`
+		ChannelID        string   `json:"channelId"`
+		ShortDescription string   `json:"shortDescription"`
+		ViewCount        string   `json:"viewCount"`
+		Author           string   `json:"author"`
+		IsLiveContent    bool     `json:"isLiveContent"`
+	} `json:"videoDetails"`
+	Microformat struct {
+		Renderer struct {
+			PublishDate string `json:"publishDate"`
+			UploadDate  string `json:"uploadDate"`
+			Category    string `json:"category"`
+		} `json:"playerMicroformatRenderer"`
+	} `json:"microformat"`
+	Captions struct {
+		Renderer struct {
+			Tracks []captionTrack `json:"captionTracks"`
+		} `json:"playerCaptionsTracklistRenderer"`
+	} `json:"captions"`
+}
+
+type captionTrack struct {
+	BaseURL      string `json:"baseUrl"`
+	LanguageCode string `json:"languageCode"`
+	Kind         string `json:"kind"`
+}
+
+// Post reads one video's watch page and, unless DisableCaptions is set,
+// its preferred caption track. A missing or failing caption track leaves
+// Transcript empty rather than failing the post.
+func (e *Exporter) Post(ctx context.Context, postURL string) (social.Post, error) {
+	id, err := VideoID(postURL)
+	if err != nil {
+		return social.Post{}, err
+	}
+
+	body, err := e.get(ctx, WatchURL(id))
+	if err != nil {
+		return social.Post{}, err
+	}
+	pr, err := parsePlayerResponse(body)
+	if err != nil {
+		return social.Post{}, fmt.Errorf("youtube video %s: %w", id, err)
+	}
+	if status := pr.PlayabilityStatus.Status; status != "" && status != "OK" {
+		return social.Post{}, fmt.Errorf("%w: youtube video %s: %s %s", social.ErrPostNotFound, id, status, pr.PlayabilityStatus.Reason)
+	}
+
+	vd := pr.VideoDetails
+	post := social.Post{
+		Platform:    social.PlatformYouTube,
+		ID:          id,
+		URL:         postURL,
+		ChannelID:   vd.ChannelID,
+		Channel:     vd.Author,
+		Title:       strings.TrimSpace(vd.Title),
+		Description: strings.TrimSpace(vd.ShortDescription),
+		Metadata:    map[string]any{},
+	}
+	if t, ok := parseDate(firstNonEmpty(pr.Microformat.Renderer.PublishDate, pr.Microformat.Renderer.UploadDate)); ok {
+		post.PublishedAt = t
+	}
+	if secs, err := strconv.ParseInt(vd.LengthSeconds, 10, 64); err == nil {
+		post.Metadata["duration_seconds"] = secs
+	}
+	if views, err := strconv.ParseInt(vd.ViewCount, 10, 64); err == nil {
+		post.Metadata["view_count"] = views
+	}
+	if len(vd.Keywords) > 0 {
+		post.Metadata["keywords"] = vd.Keywords
+	}
+	if category := strings.TrimSpace(pr.Microformat.Renderer.Category); category != "" {
+		post.Metadata["category"] = category
+	}
+	if vd.IsLiveContent {
+		post.Metadata["live"] = true
+	}
+
+	if e.cfg.DisableCaptions {
+		return post, nil
+	}
+	track, ok := pickTrack(pr.Captions.Renderer.Tracks, e.cfg.CaptionLanguages)
+	if !ok {
+		return post, nil
+	}
+	transcript, err := e.transcript(ctx, track.BaseURL)
+	if err != nil || transcript == "" {
+		if err != nil {
+			post.Metadata["caption_error"] = err.Error()
+		}
+		return post, nil
+	}
+	post.Transcript = transcript
+	post.Metadata["caption_language"] = track.LanguageCode
+	post.Metadata["caption_kind"] = trackKind(track)
+	return post, nil
+}
+
+// VideoID extracts the 11-character video ID from a watch, short, live,
+// embed or youtu.be URL.
+func VideoID(rawURL string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return "", fmt.Errorf("%w: %s: %w", social.ErrUnsupportedURL, rawURL, err)
+	}
+
+	var id string
+	host := strings.ToLower(u.Hostname())
+	switch {
+	case host == "youtu.be":
+		id = strings.Trim(u.Path, "/")
+	case host == "youtube.com" || strings.HasSuffix(host, ".youtube.com"):
+		if u.Path == "/watch" {
+			id = u.Query().Get("v")
+			break
+		}
+		for _, prefix := range []string{"/shorts/", "/live/", "/embed/"} {
+			if rest, ok := strings.CutPrefix(u.Path, prefix); ok {
+				id, _, _ = strings.Cut(rest, "/")
+				break
+			}
+		}
+	}
+	if !videoIDPattern.MatchString(id) {
+		return "", fmt.Errorf("%w: %s", social.ErrUnsupportedURL, rawURL)
+	}
+	return id, nil
+}
+
+// WatchURL returns the canonical watch page URL of a video.
+func WatchURL(id string) string {
+	return "https://www.youtube.com/watch?v=" + url.QueryEscape(id)
+}
+
+// parsePlayerResponse decodes the ytInitialPlayerResponse object embedded
+// in a watch page. The decoder stops at the end of the object, so the
+// trailing script does not matter.
+func parsePlayerResponse(page []byte) (playerResponse, error) {
+	rest := page
+	for {
+		i := bytes.Index(rest, playerResponseMarker)
+		if i < 0 {
+			return playerResponse{}, fmt.Errorf("player response not found")
+		}
+		rest = rest[i+len(playerResponseMarker):]
+		trimmed := bytes.TrimLeft(rest, " \t\r\n")
+		if !bytes.HasPrefix(trimmed, []byte("=")) {
+			continue
+		}
+		trimmed = bytes.TrimLeft(trimmed[1:], " \t\r\n")
+		if !bytes.HasPrefix(trimmed, []byte("{")) {
+			continue
+		}
+		var pr playerResponse
+		if err := json.NewDecoder(bytes.NewReader(trimmed)).Decode(&pr); err != nil {
+			return playerResponse{}, fmt.Errorf("decode player response: %w", err)
+		}
+		return pr, nil
+	}
+}
+
+func pickTrack(tracks []captionTrack, langs []string) (captionTrack, bool) {
+	if len(tracks) == 0 {
+		return captionTrack{}, false
+	}
+	for _, lang := range langs {
+		for _, asr := range []bool{false, true} {
+			for _, exact := range []bool{true, false} {
+				for _, t := range tracks {
+					if (t.Kind == captionKindASR) != asr || t.BaseURL == "" {
+						continue
+					}
+					if matchLang(t.LanguageCode, lang, exact) {
+						return t, true
+					}
+				}
+			}
+		}
+	}
+	for _, asr := range []bool{false, true} {
+		for _, t := range tracks {
+			if (t.Kind == captionKindASR) == asr && t.BaseURL != "" {
+				return t, true
+			}
+		}
+	}
+	return captionTrack{}, false
+}
+
+func matchLang(code, want string, exact bool) bool {
+	if strings.EqualFold(code, want) {
+		return true
+	}
+	if exact {
+		return false
+	}
+	return len(code) > len(want) && strings.EqualFold(code[:len(want)], want) && code[len(want)] == '-'
+}
+
+func trackKind(t captionTrack) string {
+	if t.Kind == captionKindASR {
+		return captionKindASR
+	}
+	return captionKindManual
+}
+
+// transcript downloads a timedtext track and flattens it to text, one cue
+// per line. Both the default format (<text> cues) and srv3 (<p> cues) are
+// understood.
+func (e *Exporter) transcript(ctx context.Context, baseURL string) (string, error) {
+	body, err := e.get(ctx, baseURL)
+	if err != nil {
+		return "", err
+	}
+
+	dec := xml.NewDecoder(bytes.NewReader(body))
+	var (
+		lines []string
+		cue   strings.Builder
+		depth int
+	)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("parse caption track: %w", err)
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if depth > 0 {
+				depth++
+			} else if t.Name.Local == "text" || t.Name.Local == "p" {
+				depth = 1
+				cue.Reset()
+			}
+		case xml.EndElement:
+			if depth == 0 {
+				continue
+			}
+			depth--
+			if depth == 0 {
+				// Cue text is HTML-escaped once more inside the XML.
+				line := strings.Join(strings.Fields(html.UnescapeString(cue.String())), " ")
+				if line != "" && (len(lines) == 0 || lines[len(lines)-1] != line) {
+					lines = append(lines, line)
+				}
+			}
+		case xml.CharData:
+			if depth > 0 {
+				cue.Write(t)
+			}
+		}
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func (e *Exporter) get(ctx context.Context, rawURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	for key, value := range e.cfg.Headers {
+		if key, value = strings.TrimSpace(key), strings.TrimSpace(value); key != "" && value != "" {
+			req.Header.Set(key, value)
+		}
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch %s: %w", rawURL, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: fetch %s: status 404", social.ErrPostNotFound, rawURL)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, fmt.Errorf("fetch %s: status %d: %s", rawURL, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodyBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", rawURL, err)
+	}
+	if len(body) > maxBodyBytes {
+		return nil, fmt.Errorf("read %s: body exceeds %d bytes", rawURL, maxBodyBytes)
+	}
+	return body, nil
+}
+
+// parseDate reads the microformat publish date, which is RFC 3339 or a
+// bare date depending on the page.
+func parseDate(raw string) (time.Time, bool) {
+	raw = strings.TrimSpace(raw)
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,201 @@
+package youtube_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/social"
+	"github.com/ChiaYuChang/prism/internal/social/youtube"
+	"github.com/ChiaYuChang/prism/pkg/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+const feedURL = "https://www.youtube.com/feeds/videos.xml?channel_id=UCsyntheticParty0000001"
+
+var fixtures = map[string]string{
+	feedURL: "channel_feed.xml",
+	"https://www.youtube.com/watch?v=synVideo001":                             "watch_synVideo001.html",
+	"https://www.youtube.com/watch?v=unavailabl1":                             "watch_unavailable.html",
+	"https://www.youtube.com/api/timedtext?v=synVideo001&lang=zh-TW&kind=asr": "timedtext_synVideo001_zh-TW.xml",
+	"https://www.youtube.com/api/timedtext?v=synVideo001&lang=en&kind=asr":    "timedtext_synVideo001_en_srv3.xml",
+}
+
+func fixtureClient(t *testing.T, fetched *[]string) *http.Client {
+	t.Helper()
+	return &http.Client{
+		Transport: testutils.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if fetched != nil {
+				*fetched = append(*fetched, req.URL.String())
+			}
+			name, ok := fixtures[req.URL.String()]
+			if !ok {
+				return &http.Response{
+					StatusCode: http.StatusNotFound,
+					Header:     make(http.Header),
+					Body:       io.NopCloser(testutils.NewReader(nil)),
+					Request:    req,
+				}, nil
+			}
+			body, err := os.ReadFile(filepath.Join("..", "..", "..", "testdata", "synthetic", "social", "youtube", name))
+			require.NoError(t, err)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     make(http.Header),
+				Body:       io.NopCloser(testutils.NewReader(body)),
+				Request:    req,
+			}, nil
+		}),
+	}
+}
+
+func TestChannel(t *testing.T) {
+	e := youtube.New(fixtureClient(t, nil), youtube.Config{})
+
+	posts, err := e.Channel(context.Background(), feedURL)
+	require.NoError(t, err)
+	require.Len(t, posts, 2)
+
+	video := posts[0]
+	require.Equal(t, social.PlatformYouTube, video.Platform)
+	require.Equal(t, "synVideo001", video.ID)
+	require.Equal(t, "https://www.youtube.com/watch?v=synVideo001", video.URL)
+	require.Equal(t, "UCsyntheticParty0000001", video.ChannelID)
+	require.Equal(t, "Synthetic Party Channel", video.Channel)
+	require.Equal(t, "Synthetic Press Conference on Energy Policy", video.Title)
+	require.Equal(t, "Synthetic spokesperson statement on the energy bill.\nFull remarks in the video.", video.Description)
+	require.Empty(t, video.Transcript)
+	require.True(t, video.PublishedAt.Equal(time.Date(2026, 3, 29, 2, 0, 0, 0, time.UTC)))
+	require.Equal(t, int64(1234), video.Metadata["view_count"])
+	require.Equal(t, int64(42), video.Metadata["rating_count"])
+	require.Equal(t, "https://i1.ytimg.com/vi/synVideo001/hqdefault.jpg", video.Metadata["thumbnail"])
+	require.NotContains(t, video.Metadata, "short")
+
+	short := posts[1]
+	require.Equal(t, "https://www.youtube.com/shorts/synShort002", short.URL)
+	require.Equal(t, true, short.Metadata["short"])
+	require.Empty(t, short.Description)
+}
+
+func TestChannel_NotAFeed(t *testing.T) {
+	e := youtube.New(fixtureClient(t, nil), youtube.Config{})
+	_, err := e.Channel(context.Background(), "https://www.youtube.com/watch?v=synVideo001")
+	require.Error(t, err)
+}
+
+func TestPost(t *testing.T) {
+	tests := []struct {
+		name         string
+		cfg          youtube.Config
+		url          string
+		wantLang     string
+		wantKind     string
+		wantText     string
+		wantFetchLen int
+	}{
+		{
+			name:         "preferred language, auto-generated",
+			cfg:          youtube.Config{CaptionLanguages: []string{"zh-TW", "en"}},
+			url:          "https://youtu.be/synVideo001",
+			wantLang:     "zh-TW",
+			wantKind:     "asr",
+			wantText:     "Synthetic caption line one about the \"energy bill\".\nSynthetic caption line two.",
+			wantFetchLen: 2,
+		},
+		{
+			name:         "prefix match, srv3 track",
+			cfg:          youtube.Config{CaptionLanguages: []string{"en"}},
+			url:          "https://www.youtube.com/watch?v=synVideo001&t=30s",
+			wantLang:     "en",
+			wantKind:     "asr",
+			wantText:     "Synthetic English caption\nsecond line",
+			wantFetchLen: 2,
+		},
+		{
+			name:         "no preference takes the first track",
+			url:          "https://m.youtube.com/watch?v=synVideo001",
+			wantLang:     "en",
+			wantKind:     "asr",
+			wantText:     "Synthetic English caption\nsecond line",
+			wantFetchLen: 2,
+		},
+		{
+			name:         "captions disabled",
+			cfg:          youtube.Config{DisableCaptions: true},
+			url:          "https://www.youtube.com/watch?v=synVideo001",
+			wantFetchLen: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fetched []string
+			e := youtube.New(fixtureClient(t, &fetched), tt.cfg)
+
+			post, err := e.Post(context.Background(), tt.url)
+			require.NoError(t, err)
+			require.Len(t, fetched, tt.wantFetchLen)
+
+			require.Equal(t, "synVideo001", post.ID)
+			require.Equal(t, tt.url, post.URL)
+			require.Equal(t, "Synthetic Press Conference on Energy Policy", post.Title)
+			require.Equal(t, "Synthetic Party Channel", post.Channel)
+			require.Equal(t, "UCsyntheticParty0000001", post.ChannelID)
+			require.True(t, post.PublishedAt.Equal(time.Date(2026, 3, 29, 2, 0, 0, 0, time.UTC)))
+			require.Equal(t, int64(754), post.Metadata["duration_seconds"])
+			require.Equal(t, []string{"energy", "legislature"}, post.Metadata["keywords"])
+			require.Equal(t, "News & Politics", post.Metadata["category"])
+			require.Equal(t, tt.wantText, post.Transcript)
+			if tt.wantLang == "" {
+				require.NotContains(t, post.Metadata, "caption_language")
+				return
+			}
+			require.Equal(t, tt.wantLang, post.Metadata["caption_language"])
+			require.Equal(t, tt.wantKind, post.Metadata["caption_kind"])
+		})
+	}
+}
+
+func TestPost_Errors(t *testing.T) {
+	e := youtube.New(fixtureClient(t, nil), youtube.Config{})
+
+	_, err := e.Post(context.Background(), "https://www.youtube.com/watch?v=unavailabl1")
+	require.ErrorIs(t, err, social.ErrPostNotFound)
+
+	_, err = e.Post(context.Background(), "https://www.youtube.com/watch?v=missingVid1")
+	require.ErrorIs(t, err, social.ErrPostNotFound)
+
+	_, err = e.Post(context.Background(), "https://www.youtube.com/@SyntheticParty")
+	require.ErrorIs(t, err, social.ErrUnsupportedURL)
+}
+
+func TestVideoID(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{url: "https://www.youtube.com/watch?v=synVideo001", want: "synVideo001"},
+		{url: "https://youtube.com/watch?v=synVideo001&list=PL1", want: "synVideo001"},
+		{url: "https://youtu.be/synVideo001", want: "synVideo001"},
+		{url: "https://www.youtube.com/shorts/synShort002", want: "synShort002"},
+		{url: "https://www.youtube.com/live/synVideo001?feature=share", want: "synVideo001"},
+		{url: "https://www.youtube.com/embed/synVideo001", want: "synVideo001"},
+		{url: "https://www.youtube.com/channel/UCsyntheticParty0000001"},
+		{url: "https://www.youtube.com/watch?v=short"},
+		{url: "https://example.org/watch?v=synVideo001"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			got, err := youtube.VideoID(tt.url)
+			if tt.want == "" {
+				require.ErrorIs(t, err, social.ErrUnsupportedURL)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
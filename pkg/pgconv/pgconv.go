@@ -66,6 +66,29 @@ func Int32PtrToPgInt4(v *int32) pgtype.Int4 {
 	return pgtype.Int4{Int32: *v, Valid: true}
 }
 
+func PgInt4ToInt32Ptr(v pgtype.Int4) *int32 {
+	if !v.Valid {
+		return nil
+	}
+	n := v.Int32
+	return &n
+}
+
+func Float64PtrToPgFloat8(v *float64) pgtype.Float8 {
+	if v == nil {
+		return pgtype.Float8{}
+	}
+	return pgtype.Float8{Float64: *v, Valid: true}
+}
+
+func PgFloat8ToFloat64Ptr(v pgtype.Float8) *float64 {
+	if !v.Valid {
+		return nil
+	}
+	f := v.Float64
+	return &f
+}
+
 func Int64PtrToPgInt8(v *int64) pgtype.Int8 {
 	if v == nil {
 		return pgtype.Int8{}
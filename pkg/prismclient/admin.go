@@ -0,0 +1,45 @@
+package prismclient
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// The admin methods need the admin scope and a server running with API key
+// auth.
+
+// CreateUser creates an API user. A duplicate name fails with 409.
+func (c *Client) CreateUser(ctx context.Context, name string) (User, error) {
+	var out User
+	err := c.doJSON(ctx, request{
+		method: http.MethodPost, path: "/admin/users", body: struct {
+			Name string `json:"name"`
+		}{name},
+	}, &out)
+	return out, err
+}
+
+// CreateAPIKey issues a key for userID. The plaintext key is only in the
+// returned CreatedAPIKey.Key.
+func (c *Client) CreateAPIKey(ctx context.Context, userID uuid.UUID, req CreateAPIKeyRequest) (CreatedAPIKey, error) {
+	var out CreatedAPIKey
+	err := c.doJSON(ctx, request{
+		method: http.MethodPost, path: "/admin/users/" + userID.String() + "/api_keys", body: req,
+	}, &out)
+	return out, err
+}
+
+// ListAPIKeys lists a user's keys, revoked ones included.
+func (c *Client) ListAPIKeys(ctx context.Context, userID uuid.UUID) (APIKeyList, error) {
+	var out APIKeyList
+	err := c.doJSON(ctx, request{method: http.MethodGet, path: "/admin/users/" + userID.String() + "/api_keys"}, &out)
+	return out, err
+}
+
+// RevokeAPIKey revokes a key. An unknown or already revoked key fails with
+// 404.
+func (c *Client) RevokeAPIKey(ctx context.Context, keyID uuid.UUID) error {
+	return c.doJSON(ctx, request{method: http.MethodDelete, path: "/admin/api_keys/" + keyID.String()}, nil)
+}
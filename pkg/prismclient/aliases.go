@@ -0,0 +1,36 @@
+package prismclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// The alias methods edit the search alias dictionary the planner expands
+// keyword phrases with. They need the admin scope.
+
+// ListSearchAliases lists every alias group.
+func (c *Client) ListSearchAliases(ctx context.Context) (SearchAliasGroupList, error) {
+	var out SearchAliasGroupList
+	err := c.doJSON(ctx, request{method: http.MethodGet, path: "/admin/aliases"}, &out)
+	return out, err
+}
+
+// PutSearchAliases replaces the alias group of canonical; aliases missing
+// from aliases are removed. An empty, duplicate or unknown-kind alias fails
+// with 400.
+func (c *Client) PutSearchAliases(ctx context.Context, canonical string, aliases []SearchAlias) (SearchAliasGroup, error) {
+	var out SearchAliasGroup
+	err := c.doJSON(ctx, request{
+		method: http.MethodPut,
+		path:   "/admin/aliases/" + url.PathEscape(canonical),
+		body:   putSearchAliasesRequest{Aliases: aliases},
+	}, &out)
+	return out, err
+}
+
+// DeleteSearchAliases deletes the alias group of canonical. An unknown
+// group fails with 404.
+func (c *Client) DeleteSearchAliases(ctx context.Context, canonical string) error {
+	return c.doJSON(ctx, request{method: http.MethodDelete, path: "/admin/aliases/" + url.PathEscape(canonical)}, nil)
+}
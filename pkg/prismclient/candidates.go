@@ -0,0 +1,82 @@
+package prismclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ListCandidatesParams filters GET /candidates. Zero values are omitted.
+type ListCandidatesParams struct {
+	// Q is matched against title and description.
+	Q          string
+	SourceAbbr string
+	Since      time.Time
+	Until      time.Time
+	// Limit is the page size (server default 50, max 200).
+	Limit int
+	// Cursor is CandidatePage.NextCursor from the previous page.
+	Cursor string
+}
+
+// ListCandidates returns one page of candidates, newest first.
+func (c *Client) ListCandidates(ctx context.Context, p ListCandidatesParams) (CandidatePage, error) {
+	q := url.Values{}
+	setString(q, "q", p.Q)
+	setString(q, "source_abbr", p.SourceAbbr)
+	setTime(q, "since", p.Since)
+	setTime(q, "until", p.Until)
+	setInt(q, "limit", p.Limit)
+	setString(q, "cursor", p.Cursor)
+
+	var out CandidatePage
+	err := c.doJSON(ctx, request{method: http.MethodGet, path: "/candidates", query: q}, &out)
+	return out, err
+}
+
+// PageFetch asks the collector to fetch the full text of up to 100
+// candidates and returns the fetch to follow with GetFetch.
+func (c *Client) PageFetch(ctx context.Context, req PageFetchRequest) (PageFetchResponse, error) {
+	var out PageFetchResponse
+	err := c.doJSON(ctx, request{method: http.MethodPost, path: "/page_fetch", body: req}, &out)
+	return out, err
+}
+
+// PageFetchQuery promotes the candidates matching the filters as one fetch,
+// or only counts them when DryRun is set.
+func (c *Client) PageFetchQuery(ctx context.Context, req PageFetchQueryRequest) (PageFetchQueryResponse, error) {
+	var out PageFetchQueryResponse
+	err := c.doJSON(ctx, request{method: http.MethodPost, path: "/page_fetch/query", body: req}, &out)
+	return out, err
+}
+
+// StreamCandidatesParams filters GET /candidates/stream.
+type StreamCandidatesParams struct {
+	SourceAbbr string
+	// IngestionMethod is DIRECTORY, SEARCH, SUBSCRIPTION or MANUAL.
+	IngestionMethod string
+	// LastEventID resumes after that event; empty starts at now.
+	LastEventID string
+}
+
+// StreamCandidates calls fn for every candidate discovered after the
+// stream opened (or after LastEventID). It blocks until ctx is done, the
+// server closes the stream or fn returns an error; see Event for resuming.
+// The server only offers the route when its change feed is enabled.
+func (c *Client) StreamCandidates(ctx context.Context, p StreamCandidatesParams, fn func(Event[Candidate]) error) error {
+	q := url.Values{}
+	setString(q, "source_abbr", p.SourceAbbr)
+	setString(q, "ingestion_method", strings.ToUpper(p.IngestionMethod))
+	return stream(ctx, c, request{
+		method: http.MethodGet, path: "/candidates/stream", query: q, header: lastEventHeader(p.LastEventID),
+	}, "candidate", fn)
+}
+
+func lastEventHeader(id string) http.Header {
+	if id == "" {
+		return nil
+	}
+	return http.Header{"Last-Event-Id": {id}}
+}
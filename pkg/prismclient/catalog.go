@@ -0,0 +1,65 @@
+package prismclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+// The catalog methods edit the sources, scouts and parser rules workers
+// read with --registry-source=postgres. They need the admin scope.
+
+// PutSource creates or replaces a source. Putting a deleted source revives
+// it.
+func (c *Client) PutSource(ctx context.Context, abbr string, req PutSourceRequest) (Source, error) {
+	var out Source
+	err := c.doJSON(ctx, request{method: http.MethodPut, path: "/admin/sources/" + url.PathEscape(abbr), body: req}, &out)
+	return out, err
+}
+
+// DeleteSource soft-deletes a source. An unknown source fails with 404.
+func (c *Client) DeleteSource(ctx context.Context, abbr string) error {
+	return c.doJSON(ctx, request{method: http.MethodDelete, path: "/admin/sources/" + url.PathEscape(abbr)}, nil)
+}
+
+// ListScoutConfigs lists every scout config.
+func (c *Client) ListScoutConfigs(ctx context.Context) (ScoutConfigList, error) {
+	var out ScoutConfigList
+	err := c.doJSON(ctx, request{method: http.MethodGet, path: "/admin/scouts"}, &out)
+	return out, err
+}
+
+// PutScoutConfig creates or replaces a scout. A config the discovery worker
+// would reject, a host claimed by another scout included, fails with 400.
+func (c *Client) PutScoutConfig(ctx context.Context, name string, req PutScoutConfigRequest) (ScoutConfig, error) {
+	var out ScoutConfig
+	err := c.doJSON(ctx, request{method: http.MethodPut, path: "/admin/scouts/" + url.PathEscape(name), body: req}, &out)
+	return out, err
+}
+
+// DeleteScoutConfig deletes a scout. An unknown scout fails with 404.
+func (c *Client) DeleteScoutConfig(ctx context.Context, name string) error {
+	return c.doJSON(ctx, request{method: http.MethodDelete, path: "/admin/scouts/" + url.PathEscape(name)}, nil)
+}
+
+// ListParserRules lists every parser rule.
+func (c *Client) ListParserRules(ctx context.Context) (ParserRuleList, error) {
+	var out ParserRuleList
+	err := c.doJSON(ctx, request{method: http.MethodGet, path: "/admin/parsers"}, &out)
+	return out, err
+}
+
+// PutParserRule creates or replaces the parser rule of host; config is a
+// parsers.yaml `parsers:` entry in JSON. An invalid rule fails with 400.
+func (c *Client) PutParserRule(ctx context.Context, host string, config json.RawMessage) (ParserRule, error) {
+	var out ParserRule
+	err := c.doJSON(ctx, request{method: http.MethodPut, path: "/admin/parsers/" + url.PathEscape(host), body: config}, &out)
+	return out, err
+}
+
+// DeleteParserRule deletes the parser rule of host. An unknown host fails
+// with 404.
+func (c *Client) DeleteParserRule(ctx context.Context, host string) error {
+	return c.doJSON(ctx, request{method: http.MethodDelete, path: "/admin/parsers/" + url.PathEscape(host)}, nil)
+}
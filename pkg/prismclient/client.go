@@ -0,0 +1,341 @@
+// Package prismclient is a typed Go client for the Prism HTTP API
+// (/api/v1).
+//
+// The request and response types mirror the JSON the API server encodes and
+// are versioned with this package rather than shared with the server, so
+// external programs can import them. Contract tests run every method
+// against the real internal/http/api handlers and check that each route in
+// the generated OpenAPI document is covered.
+//
+//	c, err := prismclient.New("http://localhost:8090", prismclient.WithToken(key))
+//	page, err := c.ListCandidates(ctx, prismclient.ListCandidatesParams{SourceAbbr: "dpp"})
+package prismclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Version is the client version, sent in the default User-Agent.
+const Version = "0.1.0"
+
+// TokenHeader carries the API key on every request.
+const TokenHeader = "X-PRISM-TOKEN"
+
+const (
+	apiPrefix = "/api/v1"
+
+	defaultTimeout = 30 * time.Second
+	// maxErrorBody bounds how much of an error response is read.
+	maxErrorBody = 4 << 10
+	// maxErrorPreview bounds, in runes, the message kept from an error
+	// body that is not the JSON error shape (a proxy's HTML page, say).
+	maxErrorPreview = 200
+)
+
+// RetryPolicy controls how requests answered with 429 Too Many Requests or
+// 503 Service Unavailable are retried. The wait before retry n is
+// BaseDelay·2ⁿ⁻¹ with jitter, capped at MaxDelay, unless the response
+// carries a Retry-After header, which wins (also capped at MaxDelay).
+//
+// Both usually come from the rate limiter or an overloaded proxy before a
+// handler runs, so POST bodies are replayed as-is.
+type RetryPolicy struct {
+	// MaxAttempts includes the first try; values below 1 disable retries.
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// DefaultRetryPolicy is used unless WithRetryPolicy overrides it.
+var DefaultRetryPolicy = RetryPolicy{MaxAttempts: 4, BaseDelay: 500 * time.Millisecond, MaxDelay: 10 * time.Second}
+
+// Client calls the Prism API. It is safe for concurrent use.
+type Client struct {
+	baseURL   string
+	token     string
+	userAgent string
+	timeout   time.Duration
+	retry     RetryPolicy
+	http      *http.Client
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithToken sets the API key sent as X-PRISM-TOKEN.
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = strings.TrimSpace(token)
+	}
+}
+
+// WithHTTPClient replaces the underlying HTTP client. Its own Timeout, if
+// any, also bounds the streaming calls, so prefer WithTimeout.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		if hc != nil {
+			c.http = hc
+		}
+	}
+}
+
+// WithTimeout bounds each non-streaming call, retries included. Zero
+// disables the bound. The default is 30s. Streams and exports are bounded
+// only by their context.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		if d >= 0 {
+			c.timeout = d
+		}
+	}
+}
+
+// WithRetryPolicy replaces DefaultRetryPolicy.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = p
+	}
+}
+
+// WithUserAgent replaces the default "prismclient/<Version>" User-Agent.
+func WithUserAgent(ua string) Option {
+	return func(c *Client) {
+		if ua != "" {
+			c.userAgent = ua
+		}
+	}
+}
+
+// New returns a client for the API server at baseURL (scheme and host, e.g.
+// "http://localhost:8090"; a trailing /api/v1 is accepted).
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(strings.TrimSpace(baseURL))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("prismclient: invalid base URL %q: expected http(s)://host", baseURL)
+	}
+	base := strings.TrimSuffix(strings.TrimRight(u.String(), "/"), apiPrefix)
+
+	c := &Client{
+		baseURL:   base,
+		userAgent: "prismclient/" + Version,
+		timeout:   defaultTimeout,
+		retry:     DefaultRetryPolicy,
+		http:      &http.Client{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// APIError is a non-2xx response. Message is the server's error text, or a
+// preview of the body when it was not the JSON error shape.
+type APIError struct {
+	StatusCode int
+	Message    string
+	// RetryAfter is the parsed Retry-After header, zero when absent.
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("prism api: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// IsStatus reports whether err is an APIError with the given status code.
+func IsStatus(err error, status int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == status
+}
+
+// IsNotFound reports whether err is a 404, e.g. content that has not been
+// fetched yet or a fetch owned by someone else.
+func IsNotFound(err error) bool {
+	return IsStatus(err, http.StatusNotFound)
+}
+
+// request describes one API call. Path is relative to /api/v1.
+type request struct {
+	method string
+	path   string
+	query  url.Values
+	body   any
+	header http.Header
+	accept string
+}
+
+// doJSON sends req and decodes a JSON response into out (nil discards it).
+func (c *Client) doJSON(ctx context.Context, req request, out any) error {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+	resp, err := c.send(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("prismclient: decode %s %s: %w", req.method, req.path, err)
+	}
+	return nil
+}
+
+// send performs req with retries and returns a 2xx response whose body the
+// caller must close. Non-2xx responses become *APIError.
+func (c *Client) send(ctx context.Context, req request) (*http.Response, error) {
+	var payload []byte
+	if req.body != nil {
+		b, err := json.Marshal(req.body)
+		if err != nil {
+			return nil, fmt.Errorf("prismclient: encode %s %s: %w", req.method, req.path, err)
+		}
+		payload = b
+	}
+	target := c.baseURL + apiPrefix + req.path
+	if len(req.query) > 0 {
+		target += "?" + req.query.Encode()
+	}
+
+	attempts := max(c.retry.MaxAttempts, 1)
+	for attempt := 1; ; attempt++ {
+		resp, err := c.sendOnce(ctx, req, target, payload)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode < http.StatusBadRequest {
+			return resp, nil
+		}
+
+		apiErr := readAPIError(resp)
+		if attempt >= attempts || !retryable(resp.StatusCode) {
+			return nil, apiErr
+		}
+		timer := time.NewTimer(c.retryDelay(attempt, apiErr.RetryAfter))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("%w (last response: %w)", ctx.Err(), apiErr)
+		case <-timer.C:
+		}
+	}
+}
+
+func (c *Client) sendOnce(ctx context.Context, req request, target string, payload []byte) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	hr, err := http.NewRequestWithContext(ctx, req.method, target, body)
+	if err != nil {
+		return nil, fmt.Errorf("prismclient: build request: %w", err)
+	}
+	for k, v := range req.header {
+		hr.Header[k] = v
+	}
+	accept := req.accept
+	if accept == "" {
+		accept = "application/json"
+	}
+	hr.Header.Set("Accept", accept)
+	hr.Header.Set("User-Agent", c.userAgent)
+	if payload != nil {
+		hr.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		hr.Header.Set(TokenHeader, c.token)
+	}
+	return c.http.Do(hr)
+}
+
+func retryable(status int) bool {
+	return status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable
+}
+
+func (c *Client) retryDelay(attempt int, retryAfter time.Duration) time.Duration {
+	d := retryAfter
+	if d <= 0 {
+		d = c.retry.BaseDelay << (attempt - 1)
+		// Up to 25% jitter so clients throttled together do not retry in
+		// lockstep.
+		if d > 0 {
+			d += rand.N(d/4 + 1)
+		}
+	}
+	if c.retry.MaxDelay > 0 && d > c.retry.MaxDelay {
+		d = c.retry.MaxDelay
+	}
+	return d
+}
+
+// readAPIError consumes and closes resp.
+func readAPIError(resp *http.Response) *APIError {
+	defer func() { _ = resp.Body.Close() }()
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+
+	apiErr := &APIError{StatusCode: resp.StatusCode, RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"))}
+	var body struct {
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(raw, &body) == nil && body.Error != "" {
+		apiErr.Message = body.Error
+	} else {
+		apiErr.Message = strings.TrimSpace(string(raw))
+		if r := []rune(apiErr.Message); len(r) > maxErrorPreview {
+			apiErr.Message = string(r[:maxErrorPreview]) + "…"
+		}
+	}
+	return apiErr
+}
+
+// parseRetryAfter accepts delay-seconds or an HTTP date.
+func parseRetryAfter(v string) time.Duration {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if at, err := http.ParseTime(v); err == nil {
+		return max(time.Until(at), 0)
+	}
+	return 0
+}
+
+// setString adds key=v unless v is empty.
+func setString(q url.Values, key, v string) {
+	if v = strings.TrimSpace(v); v != "" {
+		q.Set(key, v)
+	}
+}
+
+// setTime adds key as RFC3339 unless t is zero.
+func setTime(q url.Values, key string, t time.Time) {
+	if !t.IsZero() {
+		q.Set(key, t.Format(time.RFC3339Nano))
+	}
+}
+
+// setInt adds key unless n is zero.
+func setInt(q url.Values, key string, n int) {
+	if n != 0 {
+		q.Set(key, strconv.Itoa(n))
+	}
+}
@@ -0,0 +1,92 @@
+package prismclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Export formats accepted by ExportContents.
+const (
+	ExportNDJSON  = "ndjson"
+	ExportCSV     = "csv"
+	ExportParquet = "parquet"
+)
+
+// ContentFilter selects contents for ListContents and ExportContents. Zero
+// values are omitted.
+type ContentFilter struct {
+	// Q is a full-text query over title and body; CJK text is matched by
+	// bigrams.
+	Q          string
+	SourceAbbr string
+	// Type is ContentTypePartyRelease, ContentTypeGovernmentRelease,
+	// ContentTypeArticle or ContentTypeSocial.
+	Type    string
+	BatchID uuid.UUID
+	Since   time.Time
+	Until   time.Time
+	// Cursor resumes after a row: ContentPage.NextCursor for listing, or
+	// the cursor of the last exported row for an export.
+	Cursor string
+}
+
+func (f ContentFilter) values() url.Values {
+	q := url.Values{}
+	setString(q, "q", f.Q)
+	setString(q, "source_abbr", f.SourceAbbr)
+	setString(q, "type", f.Type)
+	if f.BatchID != uuid.Nil {
+		q.Set("batch_id", f.BatchID.String())
+	}
+	setTime(q, "since", f.Since)
+	setTime(q, "until", f.Until)
+	setString(q, "cursor", f.Cursor)
+	return q
+}
+
+// GetContent returns the stored content of a candidate. It fails with a
+// 404 APIError (see IsNotFound) until the candidate has been fetched.
+func (c *Client) GetContent(ctx context.Context, candidateID uuid.UUID) (Content, error) {
+	var out Content
+	err := c.doJSON(ctx, request{method: http.MethodGet, path: "/contents/" + candidateID.String()}, &out)
+	return out, err
+}
+
+// ListContents returns one page of contents, newest first. limit is the
+// page size (server default 50, max 200); zero takes the default.
+func (c *Client) ListContents(ctx context.Context, f ContentFilter, limit int) (ContentPage, error) {
+	q := f.values()
+	setInt(q, "limit", limit)
+
+	var out ContentPage
+	err := c.doJSON(ctx, request{method: http.MethodGet, path: "/contents", query: q}, &out)
+	return out, err
+}
+
+// ExportContents streams every content matching f as ExportNDJSON (the
+// default when format is empty), ExportCSV or ExportParquet. A Parquet
+// export is only readable once fully downloaded. The caller must close the
+// returned body. A server failure mid-export aborts the connection, so a
+// read error means the export is incomplete.
+func (c *Client) ExportContents(ctx context.Context, f ContentFilter, format string) (io.ReadCloser, error) {
+	q := f.values()
+	setString(q, "format", strings.ToLower(format))
+	accept := "application/x-ndjson"
+	switch {
+	case strings.EqualFold(format, ExportCSV):
+		accept = "text/csv"
+	case strings.EqualFold(format, ExportParquet):
+		accept = "application/vnd.apache.parquet"
+	}
+	resp, err := c.send(ctx, request{method: http.MethodGet, path: "/contents/export", query: q, accept: accept})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
@@ -0,0 +1,776 @@
+package prismclient_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ChiaYuChang/prism/internal/http/api"
+	"github.com/ChiaYuChang/prism/internal/http/middleware"
+	"github.com/ChiaYuChang/prism/internal/repo"
+	"github.com/ChiaYuChang/prism/internal/repo/mocks"
+	"github.com/ChiaYuChang/prism/pkg/prismclient"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	adminKey  = "admin-key"
+	readerKey = "reader-key"
+)
+
+// swaggerDoc is the OpenAPI document generated from the handler comments.
+var swaggerDoc = filepath.Join("..", "..", "cmd", "api-server", "docs", "swagger.json")
+
+// rootRoutes are documented in swagger.json but served outside /api/v1.
+var rootRoutes = []string{"GET /healthz", "GET /readyz"}
+
+// hitRoutes collects the mux patterns the contract tests reached.
+var hitRoutes sync.Map
+
+// TestMain fails the run when a documented route was never exercised, so a
+// new endpoint cannot ship without client coverage. It only checks full
+// runs; -run filters skip it.
+func TestMain(m *testing.M) {
+	flag.Parse()
+	code := m.Run()
+	if code == 0 && flag.Lookup("test.run").Value.String() == "" {
+		if missing, err := uncoveredRoutes(); err != nil {
+			fmt.Fprintln(os.Stderr, "route coverage:", err)
+			code = 1
+		} else if len(missing) > 0 {
+			fmt.Fprintln(os.Stderr, "routes in swagger.json without a contract test:", missing)
+			code = 1
+		}
+	}
+	os.Exit(code)
+}
+
+func uncoveredRoutes() ([]string, error) {
+	raw, err := os.ReadFile(swaggerDoc)
+	if err != nil {
+		return nil, err
+	}
+	var doc struct {
+		BasePath string                                `json:"basePath"`
+		Paths    map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+	var missing []string
+	for path, ops := range doc.Paths {
+		for method := range ops {
+			method = strings.ToUpper(method)
+			if slices.Contains(rootRoutes, method+" "+path) {
+				continue
+			}
+			pattern := method + " " + doc.BasePath + path
+			if _, ok := hitRoutes.Load(pattern); !ok {
+				missing = append(missing, pattern)
+			}
+		}
+	}
+	sort.Strings(missing)
+	return missing, nil
+}
+
+type keyResolver map[string]middleware.Principal
+
+func (r keyResolver) Resolve(_ context.Context, key string) (middleware.Principal, error) {
+	p, ok := r[key]
+	if !ok {
+		return middleware.Principal{}, middleware.ErrInvalidAPIKey
+	}
+	return p, nil
+}
+
+// changeFeed lets tests wake SSE handlers.
+type changeFeed struct {
+	mu   sync.Mutex
+	subs map[string]chan struct{}
+}
+
+func (f *changeFeed) Subscribe(channel, key string) (<-chan struct{}, func()) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.subs == nil {
+		f.subs = make(map[string]chan struct{})
+	}
+	ch := make(chan struct{}, 1)
+	f.subs[channel+"/"+key] = ch
+	return ch, func() {}
+}
+
+func (f *changeFeed) wake(t *testing.T, channel, key string) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		ch, ok := f.subs[channel+"/"+key]
+		if ok {
+			ch <- struct{}{}
+		}
+		return ok
+	}, time.Second, 5*time.Millisecond)
+}
+
+// limiter rejects the first deny calls.
+type limiter struct{ deny atomic.Int32 }
+
+func (l *limiter) Allow(string, float64, int) bool { return l.deny.Add(-1) < 0 }
+
+type contractEnv struct {
+	url         string
+	scout       *mocks.MockScout
+	tasks       *mocks.MockTasks
+	pipeline    *mocks.MockPipeline
+	userFetches *mocks.MockUserFetches
+	users       *mocks.MockUsers
+	catalog     *mocks.MockCatalog
+	aliases     *mocks.MockAliases
+	taskRuns    *mocks.MockTaskRuns
+	usage       *mocks.MockLLMUsage
+	feed        *changeFeed
+	limiter     *limiter
+	adminID     uuid.UUID
+}
+
+// newContractEnv serves the real API handlers, with every optional route
+// enabled, behind API key auth.
+func newContractEnv(t *testing.T) *contractEnv {
+	t.Helper()
+	env := &contractEnv{
+		scout:       mocks.NewMockScout(t),
+		tasks:       mocks.NewMockTasks(t),
+		pipeline:    mocks.NewMockPipeline(t),
+		userFetches: mocks.NewMockUserFetches(t),
+		users:       mocks.NewMockUsers(t),
+		catalog:     mocks.NewMockCatalog(t),
+		aliases:     mocks.NewMockAliases(t),
+		taskRuns:    mocks.NewMockTaskRuns(t),
+		usage:       mocks.NewMockLLMUsage(t),
+		feed:        &changeFeed{},
+		limiter:     &limiter{},
+		adminID:     uuid.Must(uuid.NewV7()),
+	}
+	srv, err := api.NewServer(slog.New(slog.DiscardHandler), env.scout, env.tasks, env.pipeline, env.userFetches,
+		api.WithLLMSpend(env.usage),
+		api.WithUsers(env.users),
+		api.WithCatalog(env.catalog),
+		api.WithAliases(env.aliases),
+		api.WithTaskRuns(env.taskRuns),
+		api.WithChangeFeed(env.feed, api.StreamConfig{Settle: 10 * time.Millisecond, Poll: time.Hour, Heartbeat: time.Hour}),
+		api.WithMonitorMode("push"),
+		api.WithRateLimiter(env.limiter),
+	)
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	srv.RegisterPublic(mux, middleware.APIKeyAuth(keyResolver{
+		adminKey: {UserID: env.adminID, KeyID: uuid.Must(uuid.NewV7()),
+			Scopes: []string{middleware.ScopeRead, middleware.ScopePageFetch, middleware.ScopeAdmin}},
+		readerKey: {UserID: uuid.Must(uuid.NewV7()), KeyID: uuid.Must(uuid.NewV7()),
+			Scopes: []string{middleware.ScopeRead}},
+	}))
+	srv.RegisterInternal(mux)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mux.ServeHTTP(w, r)
+		if r.Pattern != "" {
+			hitRoutes.Store(r.Pattern, true)
+		}
+	}))
+	t.Cleanup(ts.Close)
+	env.url = ts.URL
+	return env
+}
+
+func (env *contractEnv) client(t *testing.T, opts ...prismclient.Option) *prismclient.Client {
+	t.Helper()
+	opts = append([]prismclient.Option{
+		prismclient.WithToken(adminKey),
+		prismclient.WithRetryPolicy(prismclient.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}),
+	}, opts...)
+	c, err := prismclient.New(env.url, opts...)
+	require.NoError(t, err)
+	return c
+}
+
+func testCandidate(publishedAt time.Time) repo.Candidate {
+	return repo.Candidate{
+		ID: uuid.Must(uuid.NewV7()), BatchID: uuid.Must(uuid.NewV7()), SourceAbbr: "dpp",
+		Title: "新聞稿", URL: "https://www.dpp.org.tw/media/1", PublishedAt: &publishedAt,
+		DiscoveredAt: publishedAt, IngestionMethod: repo.IngestionMethodDirectory, TraceID: "trace",
+	}
+}
+
+func testContent(candidateID uuid.UUID, publishedAt time.Time) repo.Content {
+	return repo.Content{
+		ID: uuid.Must(uuid.NewV7()), CandidateID: candidateID, Type: repo.ContentTypeArticle,
+		SourceAbbr: "cna", URL: "https://www.cna.com.tw/news/1", Title: "標題", Content: "內容, with comma",
+		PublishedAt: publishedAt, FetchedAt: publishedAt.Add(time.Hour), TraceID: "trace",
+	}
+}
+
+func TestNew_RejectsInvalidBaseURL(t *testing.T) {
+	for _, bad := range []string{"", "localhost:8090", "ftp://prism", "http://"} {
+		_, err := prismclient.New(bad)
+		assert.Error(t, err, bad)
+	}
+	_, err := prismclient.New("http://prism.internal:8090/api/v1/")
+	assert.NoError(t, err)
+}
+
+func TestListCandidates(t *testing.T) {
+	env := newContractEnv(t)
+	c := env.client(t)
+	ctx := context.Background()
+
+	since := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	rows := []repo.Candidate{testCandidate(since.Add(2 * time.Hour)), testCandidate(since.Add(time.Hour))}
+	env.scout.EXPECT().ListCandidates(mock.Anything, mock.MatchedBy(func(p repo.ListCandidatesParams) bool {
+		return p.Query != nil && *p.Query == "能源" && p.SourceAbbr != nil && *p.SourceAbbr == "dpp" &&
+			p.Since != nil && p.Since.Equal(since) && p.Until == nil && p.Limit == 2 && p.AfterID == nil
+	})).Return(rows, nil).Once()
+
+	page, err := c.ListCandidates(ctx, prismclient.ListCandidatesParams{Q: "能源", SourceAbbr: "dpp", Since: since, Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, rows[0].ID, page.Items[0].ID)
+	assert.Equal(t, "新聞稿", page.Items[0].Title)
+	require.NotEmpty(t, page.NextCursor)
+
+	env.scout.EXPECT().ListCandidates(mock.Anything, mock.MatchedBy(func(p repo.ListCandidatesParams) bool {
+		return p.AfterID != nil && *p.AfterID == rows[0].ID
+	})).Return(rows[1:], nil).Once()
+	page, err = c.ListCandidates(ctx, prismclient.ListCandidatesParams{Cursor: page.NextCursor, Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, rows[1].ID, page.Items[0].ID)
+	assert.Empty(t, page.NextCursor)
+
+	_, err = c.ListCandidates(ctx, prismclient.ListCandidatesParams{Cursor: "garbage"})
+	assert.True(t, prismclient.IsStatus(err, http.StatusBadRequest), err)
+}
+
+func TestPageFetch(t *testing.T) {
+	env := newContractEnv(t)
+	c := env.client(t)
+	ctx := context.Background()
+
+	found := testCandidate(time.Now().UTC())
+	missing := uuid.Must(uuid.NewV7())
+	fetchID := uuid.Must(uuid.NewV7())
+	env.scout.EXPECT().GetCandidatesByIDs(mock.Anything, []uuid.UUID{found.ID, missing}).
+		Return([]repo.Candidate{found}, nil).Once()
+	env.userFetches.EXPECT().Create(mock.Anything, mock.MatchedBy(func(p repo.CreateUserFetchParams) bool {
+		return p.UserID != nil && *p.UserID == env.adminID
+	})).Return(repo.UserFetch{ID: fetchID}, nil).Once()
+	env.tasks.EXPECT().CreateTask(mock.Anything, mock.Anything).Return(repo.Task{ID: uuid.Must(uuid.NewV7())}, nil).Once()
+	env.userFetches.EXPECT().CreateItem(mock.Anything, mock.Anything).Return(repo.UserFetchItem{}, nil).Once()
+
+	resp, err := c.PageFetch(ctx, prismclient.PageFetchRequest{CandidateIDs: []uuid.UUID{found.ID, missing}})
+	require.NoError(t, err)
+	assert.Equal(t, fetchID, resp.FetchID)
+	assert.Equal(t, []prismclient.PageFetchItem{
+		{CandidateID: found.ID, Status: prismclient.PageFetchStatusCreated},
+		{CandidateID: missing, Status: prismclient.PageFetchStatusNotFound},
+	}, resp.Items)
+
+	_, err = c.PageFetch(ctx, prismclient.PageFetchRequest{})
+	assert.True(t, prismclient.IsStatus(err, http.StatusBadRequest), err)
+}
+
+func TestPageFetchQuery_DryRun(t *testing.T) {
+	env := newContractEnv(t)
+	c := env.client(t)
+
+	source := "dpp"
+	env.scout.EXPECT().CountCandidates(mock.Anything, mock.MatchedBy(func(p repo.CountCandidatesParams) bool {
+		return p.SourceAbbr != nil && *p.SourceAbbr == source
+	})).Return(42, nil).Once()
+
+	resp, err := c.PageFetchQuery(context.Background(), prismclient.PageFetchQueryRequest{SourceAbbr: &source, DryRun: true})
+	require.NoError(t, err)
+	assert.True(t, resp.DryRun)
+	assert.EqualValues(t, 42, resp.Matched)
+	assert.Equal(t, 42, resp.Selected)
+	assert.Nil(t, resp.FetchID)
+}
+
+func TestGetFetch(t *testing.T) {
+	env := newContractEnv(t)
+	c := env.client(t)
+	ctx := context.Background()
+
+	fetchID := uuid.Must(uuid.NewV7())
+	done := uuid.Must(uuid.NewV7())
+	env.userFetches.EXPECT().Get(mock.Anything, fetchID).Return(repo.UserFetch{ID: fetchID}, nil).Once()
+	env.userFetches.EXPECT().GetProgress(mock.Anything, fetchID).
+		Return(repo.UserFetchProgress{Total: 1, CompletedCandidateIDs: []uuid.UUID{done}, Terminal: true}, nil).Once()
+
+	progress, err := c.GetFetch(ctx, fetchID)
+	require.NoError(t, err)
+	assert.True(t, progress.Terminal)
+	assert.Equal(t, prismclient.FetchStatusGroup{Count: 1, CandidateIDs: []uuid.UUID{done}}, progress.Completed)
+
+	unknown := uuid.Must(uuid.NewV7())
+	env.userFetches.EXPECT().Get(mock.Anything, unknown).Return(repo.UserFetch{}, pgx.ErrNoRows).Once()
+	_, err = c.GetFetch(ctx, unknown)
+	assert.True(t, prismclient.IsNotFound(err), err)
+}
+
+func TestStreamFetchEvents(t *testing.T) {
+	env := newContractEnv(t)
+	c := env.client(t)
+
+	fetchID := uuid.Must(uuid.NewV7())
+	candidateID := uuid.Must(uuid.NewV7())
+	env.userFetches.EXPECT().Get(mock.Anything, fetchID).Return(repo.UserFetch{ID: fetchID}, nil).Twice()
+	env.userFetches.EXPECT().GetProgress(mock.Anything, fetchID).
+		Return(repo.UserFetchProgress{Total: 1, RunningCandidateIDs: []uuid.UUID{candidateID}}, nil).Once()
+	env.userFetches.EXPECT().GetProgress(mock.Anything, fetchID).
+		Return(repo.UserFetchProgress{Total: 1, CompletedCandidateIDs: []uuid.UUID{candidateID}, Terminal: true}, nil).Twice()
+
+	var events []prismclient.Event[prismclient.FetchProgress]
+	err := c.StreamFetchEvents(context.Background(), fetchID, "", func(ev prismclient.Event[prismclient.FetchProgress]) error {
+		events = append(events, ev)
+		if !ev.Data.Terminal {
+			env.feed.wake(t, repo.ChangeChannelFetchProgress, fetchID.String())
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, 1, events[0].Data.Running.Count)
+	assert.True(t, events[1].Data.Terminal)
+
+	// Resuming after the terminal event ends at once (204).
+	err = c.StreamFetchEvents(context.Background(), fetchID, events[1].ID, func(prismclient.Event[prismclient.FetchProgress]) error {
+		t.Fatal("no event expected")
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestStreamCandidates(t *testing.T) {
+	env := newContractEnv(t)
+	c := env.client(t)
+
+	lastSeen := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	lastID := uuid.Must(uuid.NewV7())
+	next := testCandidate(lastSeen.Add(time.Second))
+	env.scout.EXPECT().ListCandidatesDiscoveredAfter(mock.Anything, mock.MatchedBy(func(p repo.ListCandidatesDiscoveredAfterParams) bool {
+		return p.AfterDiscoveredAt.Equal(lastSeen) && p.AfterID == lastID &&
+			p.IngestionMethod != nil && *p.IngestionMethod == repo.IngestionMethodDirectory
+	})).Return([]repo.Candidate{next}, nil).Once()
+
+	errStop := errors.New("stop")
+	var got prismclient.Event[prismclient.Candidate]
+	err := c.StreamCandidates(context.Background(), prismclient.StreamCandidatesParams{
+		SourceAbbr:      "dpp",
+		IngestionMethod: "directory",
+		LastEventID:     fmt.Sprintf("%d_%s", lastSeen.UnixMicro(), lastID),
+	}, func(ev prismclient.Event[prismclient.Candidate]) error {
+		got = ev
+		return errStop
+	})
+	require.ErrorIs(t, err, errStop)
+	assert.Equal(t, next.ID, got.Data.ID)
+	assert.Equal(t, fmt.Sprintf("%d_%s", next.DiscoveredAt.UnixMicro(), next.ID), got.ID)
+}
+
+func TestContents(t *testing.T) {
+	env := newContractEnv(t)
+	c := env.client(t)
+	ctx := context.Background()
+
+	newest := time.Date(2026, 5, 20, 8, 0, 0, 0, time.UTC)
+	rows := []repo.Content{testContent(uuid.Must(uuid.NewV7()), newest), testContent(uuid.Must(uuid.NewV7()), newest.Add(-time.Hour))}
+
+	env.pipeline.EXPECT().GetContentByCandidateID(mock.Anything, rows[0].CandidateID).Return(rows[0], nil).Once()
+	content, err := c.GetContent(ctx, rows[0].CandidateID)
+	require.NoError(t, err)
+	assert.Equal(t, rows[0].ID, content.ID)
+	assert.Equal(t, "內容, with comma", content.Content)
+
+	pending := uuid.Must(uuid.NewV7())
+	env.pipeline.EXPECT().GetContentByCandidateID(mock.Anything, pending).Return(repo.Content{}, pgx.ErrNoRows).Once()
+	_, err = c.GetContent(ctx, pending)
+	assert.True(t, prismclient.IsNotFound(err), err)
+
+	batch := uuid.Must(uuid.NewV7())
+	env.pipeline.EXPECT().ListContents(mock.Anything, mock.MatchedBy(func(p repo.ListContentsParams) bool {
+		return p.Query != nil && *p.Query == "能源政策" && p.Type != nil && *p.Type == repo.ContentTypeArticle &&
+			p.BatchID != nil && *p.BatchID == batch && p.Limit == 2
+	})).Return(rows, nil).Once()
+	page, err := c.ListContents(ctx, prismclient.ContentFilter{Q: "能源政策", Type: prismclient.ContentTypeArticle, BatchID: batch}, 1)
+	require.NoError(t, err)
+	require.Len(t, page.Items, 1)
+	assert.NotEmpty(t, page.NextCursor)
+
+	env.pipeline.EXPECT().ListContents(mock.Anything, mock.Anything).Return(rows, nil).Times(3)
+	body, err := c.ExportContents(ctx, prismclient.ContentFilter{SourceAbbr: "cna"}, "")
+	require.NoError(t, err)
+	sc := bufio.NewScanner(body)
+	var exported []prismclient.Content
+	for sc.Scan() {
+		var row prismclient.Content
+		require.NoError(t, json.Unmarshal(sc.Bytes(), &row))
+		exported = append(exported, row)
+	}
+	require.NoError(t, body.Close())
+	require.Len(t, exported, 2)
+	assert.Equal(t, rows[1].ID, exported[1].ID)
+
+	body, err = c.ExportContents(ctx, prismclient.ContentFilter{}, prismclient.ExportCSV)
+	require.NoError(t, err)
+	records, err := csv.NewReader(body).ReadAll()
+	require.NoError(t, body.Close())
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, "id", records[0][0])
+
+	body, err = c.ExportContents(ctx, prismclient.ContentFilter{}, prismclient.ExportParquet)
+	require.NoError(t, err)
+	raw, err := io.ReadAll(body)
+	require.NoError(t, body.Close())
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(raw, []byte("PAR1")) && bytes.HasSuffix(raw, []byte("PAR1")), "a complete parquet file")
+}
+
+func TestStatusAndSources(t *testing.T) {
+	env := newContractEnv(t)
+	c := env.client(t)
+	ctx := context.Background()
+
+	require.NoError(t, c.ReportStatus(ctx, prismclient.StatusReport{Service: "scheduler", Level: "OK", Message: "running"}))
+	statuses, err := c.Status(ctx)
+	require.NoError(t, err)
+	require.Contains(t, statuses, "scheduler")
+	assert.Equal(t, "running", statuses["scheduler"].Message)
+	assert.False(t, statuses["scheduler"].Timestamp.IsZero())
+
+	err = c.ReportStatus(ctx, prismclient.StatusReport{})
+	assert.True(t, prismclient.IsStatus(err, http.StatusBadRequest), err)
+
+	env.scout.EXPECT().ListSourcesByType(mock.Anything, repo.SourceTypeParty).Return([]repo.Source{
+		{Abbr: "dpp", Name: "Democratic Progressive Party", Type: repo.SourceTypeParty, BaseURL: "https://www.dpp.org.tw"},
+	}, nil).Once()
+	sources, err := c.ListSources(ctx, "party")
+	require.NoError(t, err)
+	assert.Equal(t, prismclient.SourceList{Count: 1, Items: []prismclient.Source{
+		{Abbr: "dpp", Name: "Democratic Progressive Party", Type: prismclient.SourceTypeParty, BaseURL: "https://www.dpp.org.tw"},
+	}}, sources)
+}
+
+func TestLLMSpend(t *testing.T) {
+	env := newContractEnv(t)
+	c := env.client(t)
+
+	day := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	env.usage.EXPECT().SummarizeSpend(mock.Anything, mock.MatchedBy(func(p repo.SummarizeLLMSpendParams) bool {
+		return p.Since.Equal(day) && p.Until.Equal(day.AddDate(0, 0, 2)) && p.Component != nil && *p.Component == "planner"
+	})).Return([]repo.LLMSpendSummary{
+		{Day: day, Component: "planner", Provider: "llm.gemini", Model: "gemini-2.0-flash", Calls: 3, TotalTokens: 300, CostMicros: 1_500_000},
+	}, nil).Once()
+
+	spend, err := c.LLMSpend(context.Background(), prismclient.LLMSpendParams{Since: day, Until: day.AddDate(0, 0, 2), Component: "planner"})
+	require.NoError(t, err)
+	assert.InDelta(t, 1.5, spend.TotalCostUSD, 1e-9)
+	require.Len(t, spend.Days, 1)
+	assert.Equal(t, "2026-10-01", spend.Days[0].Day)
+}
+
+func TestAdmin(t *testing.T) {
+	env := newContractEnv(t)
+	c := env.client(t)
+	ctx := context.Background()
+
+	userID := uuid.Must(uuid.NewV7())
+	env.users.EXPECT().CreateUser(mock.Anything, "newsroom").Return(repo.User{ID: userID, Name: "newsroom"}, nil).Once()
+	env.users.EXPECT().CreateUser(mock.Anything, "newsroom").Return(repo.User{}, repo.ErrUserExists).Once()
+	user, err := c.CreateUser(ctx, "newsroom")
+	require.NoError(t, err)
+	assert.Equal(t, userID, user.ID)
+	_, err = c.CreateUser(ctx, "newsroom")
+	assert.True(t, prismclient.IsStatus(err, http.StatusConflict), err)
+
+	keyID := uuid.Must(uuid.NewV7())
+	env.users.EXPECT().GetUser(mock.Anything, userID).Return(repo.User{ID: userID}, nil).Once()
+	env.users.EXPECT().CreateAPIKey(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, p repo.CreateAPIKeyParams) (repo.APIKey, error) {
+			return repo.APIKey{ID: keyID, UserID: p.UserID, Name: p.Name, Prefix: p.Prefix, Scopes: p.Scopes}, nil
+		}).Once()
+	rps := 2.0
+	created, err := c.CreateAPIKey(ctx, userID, prismclient.CreateAPIKeyRequest{Name: "ci", Scopes: []string{"read"}, RateLimitRPS: &rps})
+	require.NoError(t, err)
+	assert.Equal(t, keyID, created.ID)
+	assert.NotEmpty(t, created.Key)
+	assert.Equal(t, []string{"read"}, created.Scopes)
+
+	env.users.EXPECT().ListAPIKeys(mock.Anything, userID).
+		Return([]repo.APIKey{{ID: keyID, UserID: userID, Name: "ci", Prefix: created.Prefix}}, nil).Once()
+	keys, err := c.ListAPIKeys(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, keys.Count)
+
+	env.users.EXPECT().RevokeAPIKey(mock.Anything, keyID).Return(int64(1), nil).Once()
+	env.users.EXPECT().RevokeAPIKey(mock.Anything, keyID).Return(int64(0), nil).Once()
+	require.NoError(t, c.RevokeAPIKey(ctx, keyID))
+	assert.True(t, prismclient.IsNotFound(c.RevokeAPIKey(ctx, keyID)))
+}
+
+func TestCatalog(t *testing.T) {
+	env := newContractEnv(t)
+	c := env.client(t)
+	ctx := context.Background()
+
+	env.catalog.EXPECT().UpsertSource(mock.Anything, repo.UpsertSourceParams{
+		Abbr: "cna", Name: "中央社", Type: repo.SourceTypeMedia, BaseURL: "https://www.cna.com.tw",
+	}).Return(repo.Source{Abbr: "cna", Name: "中央社", Type: repo.SourceTypeMedia, BaseURL: "https://www.cna.com.tw"}, nil).Once()
+	src, err := c.PutSource(ctx, "CNA", prismclient.PutSourceRequest{Name: "中央社", Type: prismclient.SourceTypeMedia, BaseURL: "https://www.cna.com.tw"})
+	require.NoError(t, err)
+	assert.Equal(t, "cna", src.Abbr)
+
+	env.catalog.EXPECT().DeleteSource(mock.Anything, "cna").Return(int64(1), nil).Once()
+	env.catalog.EXPECT().DeleteSource(mock.Anything, "cna").Return(int64(0), nil).Once()
+	require.NoError(t, c.DeleteSource(ctx, "cna"))
+	assert.True(t, prismclient.IsNotFound(c.DeleteSource(ctx, "cna")))
+
+	feed := json.RawMessage(`{"name":"cna","hosts":["www.cna.com.tw"]}`)
+	env.catalog.EXPECT().ListScoutConfigs(mock.Anything).Return(nil, nil).Once()
+	env.catalog.EXPECT().UpsertScoutConfig(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, p repo.UpsertScoutConfigParams) (repo.ScoutConfig, error) {
+			return repo.ScoutConfig{Name: p.Name, Kind: p.Kind, Config: p.Config}, nil
+		}).Once()
+	scout, err := c.PutScoutConfig(ctx, "cna", prismclient.PutScoutConfigRequest{Kind: prismclient.ScoutKindRSS, Config: feed})
+	require.NoError(t, err)
+	assert.Equal(t, prismclient.ScoutKindRSS, scout.Kind)
+
+	env.catalog.EXPECT().ListScoutConfigs(mock.Anything).
+		Return([]repo.ScoutConfig{{Name: "other", Kind: prismclient.ScoutKindRSS, Config: []byte(`{"name":"other","hosts":["www.cna.com.tw"]}`)}}, nil).Once()
+	_, err = c.PutScoutConfig(ctx, "cna", prismclient.PutScoutConfigRequest{Kind: prismclient.ScoutKindRSS, Config: feed})
+	assert.True(t, prismclient.IsStatus(err, http.StatusBadRequest), err)
+
+	env.catalog.EXPECT().ListScoutConfigs(mock.Anything).
+		Return([]repo.ScoutConfig{{Name: "cna", Kind: prismclient.ScoutKindRSS, Config: feed}}, nil).Once()
+	scouts, err := c.ListScoutConfigs(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, scouts.Count)
+
+	env.catalog.EXPECT().DeleteScoutConfig(mock.Anything, "cna").Return(int64(1), nil).Once()
+	require.NoError(t, c.DeleteScoutConfig(ctx, "cna"))
+
+	rule := json.RawMessage(`{"jsonld":true,"html":{"content":["article"]}}`)
+	env.catalog.EXPECT().UpsertParserRule(mock.Anything, mock.MatchedBy(func(p repo.UpsertParserRuleParams) bool {
+		return p.Host == "www.cna.com.tw"
+	})).RunAndReturn(func(_ context.Context, p repo.UpsertParserRuleParams) (repo.ParserRule, error) {
+		return repo.ParserRule{Host: p.Host, Config: p.Config}, nil
+	}).Once()
+	saved, err := c.PutParserRule(ctx, "www.cna.com.tw", rule)
+	require.NoError(t, err)
+	assert.Equal(t, "www.cna.com.tw", saved.Host)
+
+	_, err = c.PutParserRule(ctx, "www.cna.com.tw", json.RawMessage(`{"jsonld":true}`))
+	assert.True(t, prismclient.IsStatus(err, http.StatusBadRequest), err)
+
+	env.catalog.EXPECT().ListParserRules(mock.Anything).
+		Return([]repo.ParserRule{{Host: "www.cna.com.tw", Config: rule}}, nil).Once()
+	rules, err := c.ListParserRules(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, rules.Count)
+
+	env.catalog.EXPECT().DeleteParserRule(mock.Anything, "www.cna.com.tw").Return(int64(1), nil).Once()
+	require.NoError(t, c.DeleteParserRule(ctx, "www.cna.com.tw"))
+}
+
+func TestSearchAliases(t *testing.T) {
+	env := newContractEnv(t)
+	c := env.client(t)
+	ctx := context.Background()
+
+	env.aliases.EXPECT().ReplaceAliases(mock.Anything, repo.ReplaceSearchAliasesParams{
+		Canonical: "國家通訊傳播委員會",
+		Aliases: []repo.SearchAliasEntry{
+			{Alias: "NCC", Kind: repo.AliasKindAbbreviation},
+			{Alias: "通傳會", Kind: repo.AliasKindAlias},
+		},
+	}).Return([]repo.SearchAlias{
+		{Canonical: "國家通訊傳播委員會", Alias: "NCC", Kind: repo.AliasKindAbbreviation},
+		{Canonical: "國家通訊傳播委員會", Alias: "通傳會", Kind: repo.AliasKindAlias},
+	}, nil).Once()
+	group, err := c.PutSearchAliases(ctx, "國家通訊傳播委員會", []prismclient.SearchAlias{
+		{Alias: "NCC", Kind: prismclient.AliasKindAbbreviation},
+		{Alias: "通傳會"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "國家通訊傳播委員會", group.Canonical)
+	assert.Len(t, group.Aliases, 2)
+
+	_, err = c.PutSearchAliases(ctx, "國家通訊傳播委員會", []prismclient.SearchAlias{{Alias: "NCC", Kind: "NICKNAME"}})
+	assert.True(t, prismclient.IsStatus(err, http.StatusBadRequest), err)
+
+	env.aliases.EXPECT().ListAliases(mock.Anything).Return([]repo.SearchAlias{
+		{Canonical: "國家通訊傳播委員會", Alias: "NCC", Kind: repo.AliasKindAbbreviation},
+	}, nil).Once()
+	groups, err := c.ListSearchAliases(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, groups.Count)
+
+	env.aliases.EXPECT().DeleteAliases(mock.Anything, "國家通訊傳播委員會").Return(int64(1), nil).Once()
+	env.aliases.EXPECT().DeleteAliases(mock.Anything, "國家通訊傳播委員會").Return(int64(0), nil).Once()
+	require.NoError(t, c.DeleteSearchAliases(ctx, "國家通訊傳播委員會"))
+	assert.True(t, prismclient.IsNotFound(c.DeleteSearchAliases(ctx, "國家通訊傳播委員會")))
+}
+
+func TestTaskRuns(t *testing.T) {
+	env := newContractEnv(t)
+	c := env.client(t)
+	ctx := context.Background()
+
+	since := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	taskID := uuid.Must(uuid.NewV7())
+	env.taskRuns.EXPECT().SummarizeByTask(mock.Anything, mock.MatchedBy(func(p repo.SummarizeTaskRunsParams) bool {
+		return p.Since.Equal(since) && p.Kind != nil && *p.Kind == repo.TaskKindKeywordSearch && p.Limit == 10
+	})).Return([]repo.TaskRunYield{
+		{TaskID: taskID, Kind: repo.TaskKindKeywordSearch, SourceAbbr: "yahoo", Query: "NCC 開罰", Runs: 8, ItemsSeen: 24, ItemsDuplicate: 24},
+	}, nil).Once()
+	yield, err := c.TaskRunYield(ctx, prismclient.TaskRunYieldParams{
+		GroupBy: prismclient.YieldGroupByTask,
+		Since:   since,
+		Until:   since.AddDate(0, 0, 30),
+		Kind:    "KEYWORD_SEARCH",
+		Limit:   10,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, yield.Count)
+	require.NotNil(t, yield.Items[0].TaskID)
+	assert.Equal(t, taskID, *yield.Items[0].TaskID)
+	assert.Zero(t, yield.Items[0].ItemsNew)
+
+	_, err = c.TaskRunYield(ctx, prismclient.TaskRunYieldParams{GroupBy: "day"})
+	assert.True(t, prismclient.IsStatus(err, http.StatusBadRequest), err)
+
+	startedAt := since.Add(time.Hour)
+	env.taskRuns.EXPECT().ListByTask(mock.Anything, repo.ListTaskRunsParams{TaskID: taskID, Limit: 2}).Return([]repo.TaskRun{
+		{ID: 2, TaskID: taskID, Kind: repo.TaskKindKeywordSearch, Status: repo.TaskRunStatusOK,
+			Providers: map[string]repo.TaskRunProvider{"brave": {Seen: 3}}, StartedAt: startedAt},
+		{ID: 1, TaskID: taskID, Kind: repo.TaskKindKeywordSearch, Status: repo.TaskRunStatusOK, StartedAt: since},
+	}, nil).Once()
+	list, err := c.ListTaskRuns(ctx, taskID, prismclient.ListTaskRunsParams{Limit: 1})
+	require.NoError(t, err)
+	require.Equal(t, 1, list.Count)
+	assert.Equal(t, int32(3), list.Items[0].Providers["brave"].Seen)
+	require.NotEmpty(t, list.NextCursor)
+
+	env.taskRuns.EXPECT().ListByTask(mock.Anything, mock.MatchedBy(func(p repo.ListTaskRunsParams) bool {
+		return p.AfterID != nil && *p.AfterID == 2 && p.AfterStartedAt != nil && p.AfterStartedAt.Equal(startedAt)
+	})).Return([]repo.TaskRun{{ID: 1, TaskID: taskID, StartedAt: since}}, nil).Once()
+	list, err = c.ListTaskRuns(ctx, taskID, prismclient.ListTaskRunsParams{Limit: 1, Cursor: list.NextCursor})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), list.Items[0].ID)
+	assert.Empty(t, list.NextCursor)
+}
+
+func TestTokenHandling(t *testing.T) {
+	env := newContractEnv(t)
+	ctx := context.Background()
+
+	_, err := env.client(t, prismclient.WithToken("")).ListSources(ctx, "")
+	assert.True(t, prismclient.IsStatus(err, http.StatusUnauthorized), err)
+
+	_, err = env.client(t, prismclient.WithToken("unknown")).ListSources(ctx, "")
+	assert.True(t, prismclient.IsStatus(err, http.StatusUnauthorized), err)
+
+	_, err = env.client(t, prismclient.WithToken(readerKey)).PageFetch(ctx, prismclient.PageFetchRequest{CandidateIDs: []uuid.UUID{uuid.New()}})
+	assert.True(t, prismclient.IsStatus(err, http.StatusForbidden), err)
+}
+
+func TestRetriesRateLimitedRequests(t *testing.T) {
+	env := newContractEnv(t)
+	ctx := context.Background()
+
+	env.scout.EXPECT().ListSourcesByType(mock.Anything, repo.SourceTypeMedia).Return(nil, nil).Once()
+	env.limiter.deny.Store(2)
+	_, err := env.client(t).ListSources(ctx, prismclient.SourceTypeMedia)
+	require.NoError(t, err, "two 429s fit in three attempts")
+
+	env.limiter.deny.Store(3)
+	_, err = env.client(t).ListSources(ctx, prismclient.SourceTypeMedia)
+	var apiErr *prismclient.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusTooManyRequests, apiErr.StatusCode)
+	assert.Equal(t, time.Second, apiErr.RetryAfter)
+}
+
+func TestRetryPolicy(t *testing.T) {
+	var calls atomic.Int32
+	var bodies []string
+	var mu sync.Mutex
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		bodies = append(bodies, string(b))
+		mu.Unlock()
+		switch calls.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	c, err := prismclient.New(ts.URL, prismclient.WithRetryPolicy(prismclient.RetryPolicy{MaxAttempts: 5, BaseDelay: time.Millisecond}))
+	require.NoError(t, err)
+	_, err = c.PageFetch(context.Background(), prismclient.PageFetchRequest{CandidateIDs: []uuid.UUID{uuid.Nil}})
+	assert.True(t, prismclient.IsStatus(err, http.StatusBadGateway), "only 429 and 503 are retried: %v", err)
+	assert.EqualValues(t, 2, calls.Load())
+	assert.Equal(t, bodies[0], bodies[1], "the body is replayed on retry")
+
+	c, err = prismclient.New(ts.URL, prismclient.WithRetryPolicy(prismclient.RetryPolicy{}))
+	require.NoError(t, err)
+	calls.Store(0)
+	_, err = c.ListSources(context.Background(), "")
+	assert.True(t, prismclient.IsStatus(err, http.StatusServiceUnavailable), err)
+	assert.EqualValues(t, 1, calls.Load(), "a zero policy disables retries")
+}
+
+func TestErrorPreview(t *testing.T) {
+	long := strings.Repeat("錯", 300)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = io.WriteString(w, long)
+	}))
+	t.Cleanup(ts.Close)
+
+	c, err := prismclient.New(ts.URL)
+	require.NoError(t, err)
+	_, err = c.GetFetch(context.Background(), uuid.New())
+	var apiErr *prismclient.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadGateway, apiErr.StatusCode)
+	assert.Equal(t, 201, len([]rune(apiErr.Message)), "200 runes and an ellipsis")
+}
@@ -0,0 +1,27 @@
+package prismclient
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// GetFetch returns the progress of a page fetch. Fetches submitted by other
+// users read as 404 unless the token has the admin scope.
+func (c *Client) GetFetch(ctx context.Context, fetchID uuid.UUID) (FetchProgress, error) {
+	var out FetchProgress
+	err := c.doJSON(ctx, request{method: http.MethodGet, path: "/fetches/" + fetchID.String()}, &out)
+	return out, err
+}
+
+// StreamFetchEvents calls fn with the fetch's progress on connect and on
+// every change, and returns nil after the terminal state. Resuming with
+// the ID of a terminal event already seen returns nil at once. The server
+// only offers the route when its change feed is enabled; fall back to
+// polling GetFetch on a 404 for a fetch that GetFetch can read.
+func (c *Client) StreamFetchEvents(ctx context.Context, fetchID uuid.UUID, lastEventID string, fn func(Event[FetchProgress]) error) error {
+	return stream(ctx, c, request{
+		method: http.MethodGet, path: "/fetches/" + fetchID.String() + "/events", header: lastEventHeader(lastEventID),
+	}, "progress", fn)
+}
@@ -0,0 +1,57 @@
+package prismclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Status returns the last known health of every monitored service, keyed
+// by service name.
+func (c *Client) Status(ctx context.Context) (map[string]ServiceStatus, error) {
+	out := map[string]ServiceStatus{}
+	err := c.doJSON(ctx, request{method: http.MethodGet, path: "/status"}, &out)
+	return out, err
+}
+
+// ReportStatus pushes a service's health. The route lives on the API
+// server's internal listener and only exists in push monitoring mode, so
+// point this client at that listener.
+func (c *Client) ReportStatus(ctx context.Context, r StatusReport) error {
+	return c.doJSON(ctx, request{method: http.MethodPost, path: "/status", body: r}, nil)
+}
+
+// ListSources returns the active sources, optionally only those of
+// sourceType (SourceTypeParty, SourceTypeGovernment or SourceTypeMedia;
+// empty for all).
+func (c *Client) ListSources(ctx context.Context, sourceType string) (SourceList, error) {
+	q := url.Values{}
+	setString(q, "type", strings.ToUpper(sourceType))
+
+	var out SourceList
+	err := c.doJSON(ctx, request{method: http.MethodGet, path: "/sources", query: q}, &out)
+	return out, err
+}
+
+// LLMSpendParams selects the GET /llm/spend window. A zero Until is now and
+// a zero Since is Until minus 30 days; the window is capped at 366 days.
+type LLMSpendParams struct {
+	Since     time.Time
+	Until     time.Time
+	Component string
+}
+
+// LLMSpend summarises LLM spend by day and component. It needs the admin
+// scope and a server started with the usage ledger.
+func (c *Client) LLMSpend(ctx context.Context, p LLMSpendParams) (LLMSpend, error) {
+	q := url.Values{}
+	setTime(q, "since", p.Since)
+	setTime(q, "until", p.Until)
+	setString(q, "component", p.Component)
+
+	var out LLMSpend
+	err := c.doJSON(ctx, request{method: http.MethodGet, path: "/llm/spend", query: q}, &out)
+	return out, err
+}
@@ -0,0 +1,95 @@
+package prismclient
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxEventSize bounds one server-sent event line.
+const maxEventSize = 1 << 20
+
+// Event is one server-sent event. Pass the ID of the last event handled as
+// LastEventID to resume a stream after a disconnect.
+type Event[T any] struct {
+	ID   string
+	Data T
+}
+
+// stream opens req as text/event-stream and calls fn for every event named
+// name until the server ends the stream (nil), ctx is done (ctx.Err()) or
+// fn returns an error (that error). A 204 response is an empty stream.
+func stream[T any](ctx context.Context, c *Client, req request, name string, fn func(Event[T]) error) error {
+	req.accept = "text/event-stream"
+	resp, err := c.send(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	err = readEvents(resp.Body, func(id, event string, data []byte) error {
+		if event != name {
+			return nil
+		}
+		var v T
+		if err := json.Unmarshal(data, &v); err != nil {
+			return fmt.Errorf("prismclient: decode %s event %q: %w", name, id, err)
+		}
+		return fn(Event[T]{ID: id, Data: v})
+	})
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+// readEvents parses the text/event-stream format: fields up to a blank
+// line form one event, multi-line data is joined with "\n", and comment and
+// retry lines are skipped.
+func readEvents(r io.Reader, fn func(id, event string, data []byte) error) error {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64<<10), maxEventSize)
+
+	var id, event string
+	var data []string
+	for sc.Scan() {
+		line := strings.TrimSuffix(sc.Text(), "\r")
+		if line == "" {
+			if len(data) > 0 {
+				if event == "" {
+					event = "message"
+				}
+				if err := fn(id, event, []byte(strings.Join(data, "\n"))); err != nil {
+					return err
+				}
+			}
+			event, data = "", data[:0]
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "id":
+			id = value
+		case "event":
+			event = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+	if err := sc.Err(); err != nil && !errors.Is(err, context.Canceled) {
+		return fmt.Errorf("prismclient: read event stream: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package prismclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaskRunYieldParams selects the GET /admin/task_runs/yield window and
+// grouping. GroupBy is a YieldGroupBy constant, empty for source. Zero
+// Since and Until work like LLMSpendParams; a zero Limit is the server
+// default of 100.
+type TaskRunYieldParams struct {
+	GroupBy    string
+	Since      time.Time
+	Until      time.Time
+	Kind       string
+	SourceAbbr string
+	Limit      int
+}
+
+// TaskRunYield summarises discovery runs by source, task or search
+// provider. Grouped by task, the least productive tasks come first. It
+// needs the admin scope.
+func (c *Client) TaskRunYield(ctx context.Context, p TaskRunYieldParams) (TaskRunYieldList, error) {
+	q := url.Values{}
+	setString(q, "group_by", p.GroupBy)
+	setTime(q, "since", p.Since)
+	setTime(q, "until", p.Until)
+	setString(q, "kind", p.Kind)
+	setString(q, "source_abbr", p.SourceAbbr)
+	setInt(q, "limit", p.Limit)
+
+	var out TaskRunYieldList
+	err := c.doJSON(ctx, request{method: http.MethodGet, path: "/admin/task_runs/yield", query: q}, &out)
+	return out, err
+}
+
+// ListTaskRunsParams pages GET /admin/tasks/{id}/runs. A zero Limit is
+// the server default of 50.
+type ListTaskRunsParams struct {
+	Limit int
+	// Cursor is TaskRunList.NextCursor from the previous page.
+	Cursor string
+}
+
+// ListTaskRuns returns one page of a task's runs, newest first. It needs
+// the admin scope.
+func (c *Client) ListTaskRuns(ctx context.Context, taskID uuid.UUID, p ListTaskRunsParams) (TaskRunList, error) {
+	q := url.Values{}
+	setInt(q, "limit", p.Limit)
+	setString(q, "cursor", p.Cursor)
+
+	var out TaskRunList
+	err := c.doJSON(ctx, request{method: http.MethodGet, path: "/admin/tasks/" + taskID.String() + "/runs", query: q}, &out)
+	return out, err
+}
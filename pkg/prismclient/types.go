@@ -0,0 +1,409 @@
+package prismclient
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Per-item statuses of a page fetch. `created` also covers candidates that
+// joined a fetch already running for someone else.
+const (
+	PageFetchStatusCreated         = "created"
+	PageFetchStatusAlreadyComplete = "already_complete"
+	PageFetchStatusNotFound        = "not_found"
+)
+
+// Source types accepted by ListSources.
+const (
+	SourceTypeParty      = "PARTY"
+	SourceTypeGovernment = "GOVERNMENT"
+	SourceTypeMedia      = "MEDIA"
+)
+
+// Content types accepted by the contents filters.
+const (
+	ContentTypePartyRelease      = "PARTY_RELEASE"
+	ContentTypeGovernmentRelease = "GOVERNMENT_RELEASE"
+	ContentTypeArticle           = "ARTICLE"
+	ContentTypeSocial            = "SOCIAL"
+)
+
+// Candidate is a discovered article brief: title, URL and dates only.
+type Candidate struct {
+	ID              uuid.UUID  `json:"id"`
+	BatchID         uuid.UUID  `json:"batch_id"`
+	SourceAbbr      string     `json:"source_abbr"`
+	Title           string     `json:"title"`
+	URL             string     `json:"url"`
+	Description     *string    `json:"description,omitempty"`
+	PublishedAt     *time.Time `json:"published_at,omitempty"`
+	DiscoveredAt    time.Time  `json:"discovered_at"`
+	IngestionMethod string     `json:"ingestion_method"`
+	TraceID         string     `json:"trace_id"`
+}
+
+// CandidatePage is one page of ListCandidates.
+type CandidatePage struct {
+	Items []Candidate `json:"items"`
+	Limit int32       `json:"limit"`
+	Count int         `json:"count"`
+	// NextCursor is set when more rows may follow; pass it as Cursor.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// Content is the stored full text of a fetched candidate.
+type Content struct {
+	ID          uuid.UUID `json:"id"`
+	BatchID     uuid.UUID `json:"batch_id"`
+	Type        string    `json:"type"`
+	SourceAbbr  string    `json:"source_abbr"`
+	CandidateID uuid.UUID `json:"candidate_id"`
+	URL         string    `json:"url"`
+	Title       string    `json:"title"`
+	Content     string    `json:"content"`
+	Author      *string   `json:"author,omitempty"`
+	PublishedAt time.Time `json:"published_at"`
+	FetchedAt   time.Time `json:"fetched_at"`
+	TraceID     string    `json:"trace_id"`
+}
+
+// ContentPage is one page of ListContents.
+type ContentPage struct {
+	Items      []Content `json:"items"`
+	Count      int       `json:"count"`
+	NextCursor string    `json:"next_cursor,omitempty"`
+}
+
+// Notify asks for a signed fetch.completed webhook once every candidate of
+// the fetch is terminal.
+type Notify struct {
+	URL    string `json:"url"`
+	Secret string `json:"secret"`
+}
+
+// PageFetchRequest promotes up to 100 candidates to contents.
+type PageFetchRequest struct {
+	CandidateIDs []uuid.UUID `json:"candidate_ids"`
+	Notify       *Notify     `json:"notify,omitempty"`
+}
+
+// PageFetchItem is the status of one requested candidate.
+type PageFetchItem struct {
+	CandidateID uuid.UUID `json:"candidate_id"`
+	Status      string    `json:"status"`
+}
+
+// PageFetchResponse identifies the created fetch. Items keep request order.
+type PageFetchResponse struct {
+	FetchID uuid.UUID       `json:"fetch_id"`
+	Items   []PageFetchItem `json:"items"`
+}
+
+// PageFetchQueryRequest promotes candidates selected by filters. At least
+// one of Q, SourceAbbr, Since or Until is required.
+type PageFetchQueryRequest struct {
+	Q          *string    `json:"q,omitempty"`
+	SourceAbbr *string    `json:"source_abbr,omitempty"`
+	Since      *time.Time `json:"since,omitempty"`
+	Until      *time.Time `json:"until,omitempty"`
+	// MaxCandidates caps the promoted matches, newest first (server
+	// default 100, max 1000).
+	MaxCandidates int32   `json:"max_candidates,omitempty"`
+	DryRun        bool    `json:"dry_run,omitempty"`
+	Notify        *Notify `json:"notify,omitempty"`
+}
+
+// PageFetchQueryResponse reports the match count and, unless DryRun or
+// nothing matched, the created fetch.
+type PageFetchQueryResponse struct {
+	DryRun   bool            `json:"dry_run"`
+	Matched  int64           `json:"matched"`
+	Selected int             `json:"selected"`
+	FetchID  *uuid.UUID      `json:"fetch_id,omitempty"`
+	Items    []PageFetchItem `json:"items,omitempty"`
+}
+
+// FetchProgress is the per-status state of a page fetch.
+type FetchProgress struct {
+	FetchID         uuid.UUID        `json:"fetch_id"`
+	Total           int64            `json:"total"`
+	Pending         FetchStatusGroup `json:"pending"`
+	Running         FetchStatusGroup `json:"running"`
+	Completed       FetchStatusGroup `json:"completed"`
+	Failed          FetchStatusGroup `json:"failed"`
+	AlreadyComplete FetchStatusGroup `json:"already_complete"`
+	Terminal        bool             `json:"terminal"`
+}
+
+// FetchStatusGroup lists the candidates in one status.
+type FetchStatusGroup struct {
+	Count        int         `json:"count"`
+	CandidateIDs []uuid.UUID `json:"candidate_ids"`
+}
+
+// Source is an active source; Abbr is the source_abbr filter value.
+type Source struct {
+	Abbr    string `json:"abbr"`
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	BaseURL string `json:"base_url"`
+}
+
+// SourceList is returned by ListSources.
+type SourceList struct {
+	Items []Source `json:"items"`
+	Count int      `json:"count"`
+}
+
+// ServiceStatus is the last known health of one monitored service.
+type ServiceStatus struct {
+	Level     string    `json:"level"`
+	Message   string    `json:"message"`
+	Uptime    string    `json:"uptime"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// StatusReport is pushed by a service when the API runs in push mode.
+type StatusReport struct {
+	Service   string    `json:"service"`
+	Level     string    `json:"level,omitempty"`
+	Message   string    `json:"message,omitempty"`
+	Uptime    string    `json:"uptime,omitempty"`
+	Timestamp time.Time `json:"timestamp,omitzero"`
+}
+
+// LLMSpend summarises LLM provider spend over [Since, Until).
+type LLMSpend struct {
+	Since        time.Time           `json:"since"`
+	Until        time.Time           `json:"until"`
+	TotalCostUSD float64             `json:"total_cost_usd"`
+	Components   []LLMComponentSpend `json:"components"`
+	Days         []LLMDailySpend     `json:"days"`
+}
+
+// LLMComponentSpend is one calling component over the whole window.
+type LLMComponentSpend struct {
+	Component   string  `json:"component"`
+	Calls       int64   `json:"calls"`
+	TotalTokens int64   `json:"total_tokens"`
+	CostUSD     float64 `json:"cost_usd"`
+}
+
+// LLMDailySpend is one UTC day for a component, provider and model.
+type LLMDailySpend struct {
+	Day          string  `json:"day"`
+	Component    string  `json:"component"`
+	Provider     string  `json:"provider"`
+	Model        string  `json:"model"`
+	Calls        int64   `json:"calls"`
+	InputTokens  int64   `json:"input_tokens"`
+	OutputTokens int64   `json:"output_tokens"`
+	CachedTokens int64   `json:"cached_tokens"`
+	TotalTokens  int64   `json:"total_tokens"`
+	CostUSD      float64 `json:"cost_usd"`
+}
+
+// Groupings accepted by TaskRunYieldParams.GroupBy.
+const (
+	YieldGroupBySource   = "source"
+	YieldGroupByTask     = "task"
+	YieldGroupByProvider = "provider"
+)
+
+// TaskRunYield aggregates discovery runs over one group. Only the fields
+// of the grouping are set; provider groups carry no new or duplicate
+// counts.
+type TaskRunYield struct {
+	TaskID         *uuid.UUID `json:"task_id,omitempty"`
+	Kind           string     `json:"kind,omitempty"`
+	SourceAbbr     string     `json:"source_abbr,omitempty"`
+	Query          string     `json:"query,omitempty"`
+	Provider       string     `json:"provider,omitempty"`
+	Runs           int64      `json:"runs"`
+	FailedRuns     int64      `json:"failed_runs"`
+	ItemsSeen      int64      `json:"items_seen"`
+	ItemsNew       int64      `json:"items_new"`
+	ItemsDuplicate int64      `json:"items_duplicate"`
+	AvgDurationMs  int64      `json:"avg_duration_ms"`
+	LastRunAt      time.Time  `json:"last_run_at"`
+	LastNewAt      *time.Time `json:"last_new_at,omitempty"`
+}
+
+// TaskRunYieldList is returned by TaskRunYield.
+type TaskRunYieldList struct {
+	Since   time.Time      `json:"since"`
+	Until   time.Time      `json:"until"`
+	GroupBy string         `json:"group_by"`
+	Items   []TaskRunYield `json:"items"`
+	Count   int            `json:"count"`
+}
+
+// TaskRunProvider is one search provider's share of a KEYWORD_SEARCH run.
+type TaskRunProvider struct {
+	Seen       int32  `json:"seen,omitempty"`
+	ErrorClass string `json:"error_class,omitempty"`
+}
+
+// TaskRun is one discovery execution of a task. ErrorClass is set when
+// Status is FAILED.
+type TaskRun struct {
+	ID             int64                      `json:"id"`
+	TaskID         uuid.UUID                  `json:"task_id"`
+	BatchID        uuid.UUID                  `json:"batch_id"`
+	Kind           string                     `json:"kind"`
+	SourceType     string                     `json:"source_type"`
+	SourceAbbr     string                     `json:"source_abbr"`
+	Query          string                     `json:"query,omitempty"`
+	Status         string                     `json:"status"`
+	ErrorClass     string                     `json:"error_class,omitempty"`
+	ItemsSeen      int32                      `json:"items_seen"`
+	ItemsNew       int32                      `json:"items_new"`
+	ItemsDuplicate int32                      `json:"items_duplicate"`
+	Providers      map[string]TaskRunProvider `json:"providers,omitempty"`
+	DurationMs     int64                      `json:"duration_ms"`
+	TraceID        string                     `json:"trace_id"`
+	StartedAt      time.Time                  `json:"started_at"`
+}
+
+// TaskRunList is returned by ListTaskRuns.
+type TaskRunList struct {
+	Items []TaskRun `json:"items"`
+	Count int       `json:"count"`
+	// NextCursor is set when more rows may follow; pass it as Cursor.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// User is an API caller.
+type User struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	CreatedAt  time.Time  `json:"created_at"`
+	DisabledAt *time.Time `json:"disabled_at,omitempty"`
+}
+
+// CreateAPIKeyRequest issues a key. Rate limits default to the server-wide
+// budget when nil.
+type CreateAPIKeyRequest struct {
+	Name           string     `json:"name"`
+	Scopes         []string   `json:"scopes"`
+	RateLimitRPS   *float64   `json:"rate_limit_rps,omitempty"`
+	RateLimitBurst *int32     `json:"rate_limit_burst,omitempty"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+}
+
+// APIKey describes a key without the key itself.
+type APIKey struct {
+	ID             uuid.UUID  `json:"id"`
+	UserID         uuid.UUID  `json:"user_id"`
+	Name           string     `json:"name"`
+	Prefix         string     `json:"prefix"`
+	Scopes         []string   `json:"scopes"`
+	RateLimitRPS   *float64   `json:"rate_limit_rps,omitempty"`
+	RateLimitBurst *int32     `json:"rate_limit_burst,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	LastUsedAt     *time.Time `json:"last_used_at,omitempty"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+}
+
+// CreatedAPIKey is returned once, at creation; Key is the only copy of the
+// plaintext key.
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+// APIKeyList is returned by ListAPIKeys.
+type APIKeyList struct {
+	Items []APIKey `json:"items"`
+	Count int      `json:"count"`
+}
+
+// PutSourceRequest creates or replaces a source; Type is SourceTypeParty or
+// SourceTypeMedia.
+type PutSourceRequest struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	BaseURL string `json:"base_url"`
+}
+
+// Scout kinds accepted by PutScoutConfig, one per scouts.yaml section.
+const (
+	ScoutKindHTML    = "html"
+	ScoutKindRSS     = "rss"
+	ScoutKindAtom    = "atom"
+	ScoutKindSitemap = "sitemap"
+	ScoutKindJSON    = "json"
+	ScoutKindSocial  = "social"
+	ScoutKindCustom  = "custom"
+)
+
+// ScoutConfig is one discovery scout. Config is a scouts.yaml entry of the
+// given kind, in JSON.
+type ScoutConfig struct {
+	Name      string          `json:"name"`
+	Kind      string          `json:"kind"`
+	Config    json.RawMessage `json:"config"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// PutScoutConfigRequest creates or replaces a scout; Config.name must equal
+// the scout name.
+type PutScoutConfigRequest struct {
+	Kind   string          `json:"kind"`
+	Config json.RawMessage `json:"config"`
+}
+
+// ScoutConfigList is returned by ListScoutConfigs.
+type ScoutConfigList struct {
+	Items []ScoutConfig `json:"items"`
+	Count int           `json:"count"`
+}
+
+// Search alias kinds accepted by PutSearchAliases.
+const (
+	AliasKindAlias        = "ALIAS"
+	AliasKindAbbreviation = "ABBREVIATION"
+	AliasKindVariant      = "VARIANT"
+)
+
+// SearchAlias is one alias of a group. An empty Kind is sent as ALIAS.
+type SearchAlias struct {
+	Alias     string    `json:"alias"`
+	Kind      string    `json:"kind,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+// SearchAliasGroup is a canonical name and its aliases.
+type SearchAliasGroup struct {
+	Canonical string        `json:"canonical"`
+	Aliases   []SearchAlias `json:"aliases"`
+}
+
+// SearchAliasGroupList is returned by ListSearchAliases.
+type SearchAliasGroupList struct {
+	Items []SearchAliasGroup `json:"items"`
+	Count int                `json:"count"`
+}
+
+type putSearchAliasesRequest struct {
+	Aliases []SearchAlias `json:"aliases"`
+}
+
+// ParserRule is the parser config of one host: a parsers.yaml `parsers:`
+// entry in JSON.
+type ParserRule struct {
+	Host      string          `json:"host"`
+	Config    json.RawMessage `json:"config"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// ParserRuleList is returned by ListParserRules.
+type ParserRuleList struct {
+	Items []ParserRule `json:"items"`
+	Count int          `json:"count"`
+}